@@ -11,6 +11,37 @@ export DOCKER_REGISTRY="${DOCKER_REGISTRY:-gcr.io/linkerd-io}"
 # When set, causes docker's build output to be emitted to stderr.
 export DOCKER_TRACE="${DOCKER_TRACE:-}"
 
+# When set, the images that are deployed to the cluster are built for this node
+# architecture instead of amd64, and their tags get a "-$DOCKER_ARCH" suffix, as
+# expected by `linkerd install --image-arch` and `linkerd inject --image-arch`.
+# Building them on an amd64 host requires qemu's binfmt_misc handlers for the
+# target architecture, e.g. from the multiarch/qemu-user-static image.
+export DOCKER_ARCH="${DOCKER_ARCH:-}"
+
+# The tag of the base runtime image, see bin/docker-build-base.
+base_tag="2017-10-30.01"
+
+# Prints the given tag with the "-$DOCKER_ARCH" suffix, if DOCKER_ARCH is set.
+docker_arch_tag() {
+    tag="$1"
+    if [ -n "$DOCKER_ARCH" ]; then
+        tag="$tag-$DOCKER_ARCH"
+    fi
+    echo "$tag"
+}
+
+# Prints the build arg that packages an image in the base runtime image built
+# for DOCKER_ARCH.
+docker_runtime_build_arg() {
+    echo "--build-arg RUNTIME_IMAGE=$(docker_repo base):$(docker_arch_tag $base_tag)"
+}
+
+# Prints the build args that make a Dockerfile build Go binaries for
+# DOCKER_ARCH and package them in the base runtime image built for it.
+docker_arch_build_args() {
+    echo "--build-arg GOARCH=${DOCKER_ARCH:-amd64} $(docker_runtime_build_arg)"
+}
+
 docker_repo() {
     repo="$1"
 
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
@@ -15,21 +18,55 @@ const (
 	failStatus  = "[FAIL]"
 )
 
+// Exit codes of the check commands, so that scripts can tell the outcome
+// apart without parsing the output.
+const (
+	checkSuccessExitCode      = 0
+	checkFailureExitCode      = 1
+	checkWarningExitCode      = 2
+	checkConnectivityExitCode = 3
+)
+
 type checkOptions struct {
 	versionOverride string
 	preInstallOnly  bool
+	preUpgradeOnly  bool
 	dataPlaneOnly   bool
+	proxyAdmin      bool
+	ha              bool
+	serviceProfiles bool
+	saPermissions   bool
 	wait            time.Duration
-	namespace       string
+	checkTimeout    time.Duration
+	certExpiry      time.Duration
+	skipChecks      []string
+	namespaces      []string
+	selector        string
+	output          string
+
+	// withDataPlane adds the data plane checks to the control plane checks,
+	// as linkerd doctor does.
+	withDataPlane bool
 }
 
 func newCheckOptions() *checkOptions {
 	return &checkOptions{
 		versionOverride: "",
 		preInstallOnly:  false,
+		preUpgradeOnly:  false,
 		dataPlaneOnly:   false,
+		proxyAdmin:      false,
+		ha:              false,
+		serviceProfiles: false,
+		saPermissions:   false,
 		wait:            300 * time.Second,
-		namespace:       "",
+		checkTimeout:    2 * time.Minute,
+		certExpiry:      7 * 24 * time.Hour,
+		skipChecks:      nil,
+		namespaces:      nil,
+		selector:        "",
+		output:          tableOutput,
+		withDataPlane:   false,
 	}
 }
 
@@ -42,94 +79,293 @@ func newCmdCheck() *cobra.Command {
 		Long: `Check the Linkerd installation for potential problems.
 
 The check command will perform a series of checks to validate that the linkerd
-CLI and control plane are configured correctly. If the command encounters a
-failure it will print additional information about the failure and exit with a
-non-zero exit code.`,
+CLI and control plane are configured correctly. It also checks the extensions
+installed in the cluster, using the linkerd-<extension> plugin on the PATH for
+extensions the CLI doesn't know about. If the command encounters a failure it
+will print additional information about the failure and exit with a non-zero
+exit code.
+
+With "-o json" or "-o yaml", the results of the checks are printed as a single
+document once they have all run, and the exit code is left unchanged. Checks
+that are retried are reported once, with their final result and the number of
+retries.
+
+Every check has a stable ID, e.g. "kubernetes-api/version-min", which is part
+of the json and yaml output. Checks that don't apply to a cluster can be
+skipped by giving their IDs to --skip.`,
 		Example: `  # Check that the Linkerd control plane is up and running
   linkerd check
 
   # Check that the Linkerd control plane can be installed in the "test" namespace
   linkerd check --pre --linkerd-namespace test
 
+  # Check that the control plane's ServiceAccounts, created beforehand by a cluster
+  # administrator, have the permissions the control plane needs
+  linkerd check --pre --service-account-permissions
+
+  # Check that the Linkerd control plane can be upgraded to the CLI's version
+  linkerd check --pre-upgrade
+
   # Check that the Linkerd data plane proxies in the "app" namespace are up and running
-  linkerd check --proxy --namespace app`,
+  linkerd check --proxy --namespace app
+
+  # Check the data plane proxies of the "app=payments" pods in the "team-a" and "team-b" namespaces
+  linkerd check --proxy --selector app=payments -n team-a -n team-b
+
+  # Also check that the admin server of every proxy in the "app" namespace responds
+  linkerd check --proxy --proxy-admin --namespace app
+
+  # Check that the control plane is deployed in HA mode, even if it runs a single replica
+  linkerd check --ha
+
+  # Also check that ServiceProfiles can be used
+  linkerd check --service-profiles
+
+  # Print the results of the checks as JSON
+  linkerd check -o json
+
+  # Check the installation without checking whether the control plane is up-to-date
+  linkerd check --skip linkerd-version/control-plane`,
 		Args: cobra.NoArgs,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(options.output); err != nil {
+				return err
+			}
 			configureAndRunChecks(options)
+			return nil
 		},
 	}
 
 	cmd.Args = cobra.NoArgs
 	cmd.PersistentFlags().StringVar(&options.versionOverride, "expected-version", options.versionOverride, "Overrides the version used when checking if Linkerd is running the latest version (mostly for testing)")
 	cmd.PersistentFlags().BoolVar(&options.preInstallOnly, "pre", options.preInstallOnly, "Only run pre-installation checks, to determine if the control plane can be installed")
+	cmd.PersistentFlags().BoolVar(&options.preUpgradeOnly, "pre-upgrade", options.preUpgradeOnly, "Only run pre-upgrade checks, to determine if the control plane can be upgraded to the CLI's version")
+	cmd.PersistentFlags().BoolVar(&options.saPermissions, "service-account-permissions", options.saPermissions, "With --pre, check the permissions the control plane needs at runtime as its ServiceAccounts instead of as the current user; the ServiceAccounts and their RBAC must already exist")
 	cmd.PersistentFlags().BoolVar(&options.dataPlaneOnly, "proxy", options.dataPlaneOnly, "Only run data-plane checks, to determine if the data plane is healthy")
+	cmd.PersistentFlags().BoolVar(&options.proxyAdmin, "proxy-admin", options.proxyAdmin, "With --proxy, also check that the admin server of every proxy is ready and serves its metrics, reporting the result of each pod")
+	cmd.PersistentFlags().BoolVar(&options.ha, "ha", options.ha, "Check that the control plane is replicated, spread across nodes and protected by PodDisruptionBudgets, as in HA mode; these checks also run if the controller runs more than one replica")
+	cmd.PersistentFlags().BoolVar(&options.serviceProfiles, "service-profiles", options.serviceProfiles, "Also check that the ServiceProfile CRD is installed and that ServiceProfiles can be listed and created")
 	cmd.PersistentFlags().DurationVar(&options.wait, "wait", options.wait, "Retry and wait for some checks to succeed if they don't pass the first time")
-	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to use for --proxy checks (default: all namespaces)")
+	cmd.PersistentFlags().DurationVar(&options.checkTimeout, "check-timeout", options.checkTimeout, "Fail each attempt of a check that takes longer than this (0 for no timeout)")
+	cmd.PersistentFlags().DurationVar(&options.certExpiry, "cert-expiry-window", options.certExpiry, "Warn if the certificate of the CA issuing the proxies' certificates expires within this duration")
+	cmd.PersistentFlags().StringSliceVar(&options.skipChecks, "skip", options.skipChecks, "IDs of the checks to skip; can be repeated")
+	cmd.PersistentFlags().StringArrayVarP(&options.namespaces, "namespace", "n", options.namespaces, "Namespace to use for --proxy checks; repeat the flag to check several namespaces (default: all namespaces)")
+	cmd.PersistentFlags().StringVar(&options.selector, "selector", options.selector, "Label selector of the pods to use for --proxy checks, e.g. app=payments (default: all meshed pods)")
+	addOutputFlag(cmd, &options.output)
 
 	return cmd
 }
 
 func configureAndRunChecks(options *checkOptions) {
+	hc, err := healthcheck.NewHealthChecker(healthChecks(options))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure the health checks: %s\n", err)
+		os.Exit(checkFailureExitCode)
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	if isStructuredOutput(options.output) {
+		os.Exit(runChecksReport(ctx, os.Stdout, hc, options.output))
+	}
+
+	exitWithCheckStatus(runChecks(ctx, os.Stdout, hc))
+}
+
+// healthChecks returns the checks selected by the options, along with the
+// options of the health checker that runs them. Both linkerd check and linkerd
+// doctor build their health checker from it.
+func healthChecks(options *checkOptions) ([]healthcheck.Checks, *healthcheck.HealthCheckOptions) {
 	checks := []healthcheck.Checks{healthcheck.KubernetesAPIChecks}
 
 	if options.preInstallOnly {
 		checks = append(checks, healthcheck.LinkerdPreInstallChecks)
+	} else if options.preUpgradeOnly {
+		checks = append(checks, healthcheck.LinkerdPreUpgradeChecks)
 	} else if options.dataPlaneOnly {
 		checks = append(checks, healthcheck.LinkerdAPIChecks)
 		checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
+		checks = append(checks, healthcheck.LinkerdInjectionSafetyChecks)
+
+		if options.proxyAdmin {
+			checks = append(checks, healthcheck.LinkerdProxyAdminChecks)
+		}
 	} else {
+		checks = append(checks, healthcheck.LinkerdPostInstallChecks)
 		checks = append(checks, healthcheck.LinkerdAPIChecks)
+		if options.withDataPlane {
+			checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
+		}
+		checks = append(checks, healthcheck.LinkerdCAChecks)
+		checks = append(checks, healthcheck.LinkerdHAChecks)
+		checks = append(checks, healthcheck.LinkerdInjectionSafetyChecks)
+		checks = append(checks, healthcheck.LinkerdExtensionChecks)
+
+		if options.serviceProfiles {
+			checks = append(checks, healthcheck.LinkerdServiceProfileChecks)
+		}
 	}
 
 	checks = append(checks, healthcheck.LinkerdVersionChecks)
 
-	hc := healthcheck.NewHealthChecker(checks, &healthcheck.HealthCheckOptions{
+	return checks, &healthcheck.HealthCheckOptions{
 		ControlPlaneNamespace:          controlPlaneNamespace,
-		DataPlaneNamespace:             options.namespace,
+		DataPlaneNamespaces:            options.namespaces,
+		DataPlaneSelector:              options.selector,
 		KubeConfig:                     kubeconfigPath,
 		KubeContext:                    kubeContext,
 		APIAddr:                        apiAddr,
 		VersionOverride:                options.versionOverride,
 		RetryDeadline:                  time.Now().Add(options.wait),
+		CheckTimeout:                   options.checkTimeout,
+		CertExpiryWindow:               options.certExpiry,
+		CheckServiceAccountPermissions: options.saPermissions,
+		ExpectHA:                       options.ha,
+		SkipChecks:                     options.skipChecks,
 		ShouldCheckKubeVersion:         true,
-		ShouldCheckControlPlaneVersion: !(options.preInstallOnly || options.dataPlaneOnly),
-		ShouldCheckDataPlaneVersion:    options.dataPlaneOnly,
-	})
-
-	success := runChecks(os.Stdout, hc)
+		ShouldCheckControlPlaneVersion: !(options.preInstallOnly || options.preUpgradeOnly || options.dataPlaneOnly),
+		ShouldCheckDataPlaneVersion:    options.dataPlaneOnly || options.withDataPlane,
+		ExtensionCheck:                 extensionCheck,
+	}
+}
 
+// exitWithCheckStatus prints the overall status of the checks and exits with
+// the given exit code.
+func exitWithCheckStatus(exitCode int) {
 	fmt.Println("")
 
-	if !success {
-		fmt.Printf("Status check results are %s\n", failStatus)
-		os.Exit(2)
+	status := failStatus
+	switch exitCode {
+	case checkSuccessExitCode:
+		status = okStatus
+	case checkWarningExitCode:
+		status = warnStatus
 	}
+	fmt.Printf("Status check results are %s\n", status)
 
-	fmt.Printf("Status check results are %s\n", okStatus)
+	os.Exit(exitCode)
 }
 
-func runChecks(w io.Writer, hc *healthcheck.HealthChecker) bool {
-	prettyPrintResults := func(result *healthcheck.CheckResult) {
-		checkLabel := fmt.Sprintf("%s: %s", result.Category, result.Description)
+// extensionCheck returns a check that runs `check` on the plugin of an
+// installed extension, or nil if the plugin isn't on the PATH. The plugin is
+// passed the CLI's global flags, and the extension's namespace in the
+// LINKERD_EXTENSION_NAMESPACE environment variable.
+func extensionCheck(extension, namespace string) func() error {
+	path, err := exec.LookPath(pluginPrefix + extension)
+	if err != nil {
+		return nil
+	}
 
-		filler := ""
-		lineBreak := "\n"
-		for i := 0; i < lineWidth-len(checkLabel)-len(okStatus)-len(lineBreak); i++ {
-			filler = filler + "."
+	return func() error {
+		args := []string{"check", "--linkerd-namespace", controlPlaneNamespace}
+		if kubeconfigPath != "" {
+			args = append(args, "--kubeconfig", kubeconfigPath)
 		}
-
-		if result.Retry {
-			fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, retryStatus, result.Err, lineBreak)
-			return
+		if kubeContext != "" {
+			args = append(args, "--context", kubeContext)
 		}
 
-		if result.Err != nil {
-			fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, failStatus, result.Err, lineBreak)
-			return
+		plugin := exec.Command(path, args...)
+		plugin.Env = append(os.Environ(), "LINKERD_EXTENSION_NAMESPACE="+namespace)
+		output, err := plugin.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s check failed: %s\n%s", path, err, strings.TrimSpace(string(output)))
 		}
+		return nil
+	}
+}
+
+// runChecks runs the checks, printing their results to w, and returns the exit
+// code for their outcome. The checks are stopped when ctx is canceled, in
+// which case they fail.
+func runChecks(ctx context.Context, w io.Writer, hc *healthcheck.HealthChecker) int {
+	results := hc.RunChecks(ctx, func(result *healthcheck.CheckResult) {
+		printCheckResult(w, result)
+	})
 
-		fmt.Fprintf(w, "%s%s%s%s", checkLabel, filler, okStatus, lineBreak)
+	if ctx.Err() != nil {
+		fmt.Fprintf(w, "\nThe checks were interrupted: %s\n", ctx.Err())
+		return checkFailureExitCode
 	}
+	return checkExitCode(results.Checks)
+}
+
+// runChecksReport runs the checks, printing the report of their final
+// results to w in the given format, and returns the exit code for their
+// outcome.
+func runChecksReport(ctx context.Context, w io.Writer, hc *healthcheck.HealthChecker, output string) int {
+	results := hc.RunChecks(ctx, nil)
+
+	rendered, err := marshalOutput(healthcheck.NewReport(results.Checks), output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render the check results: %s\n", err)
+		return checkFailureExitCode
+	}
+	fmt.Fprint(w, rendered)
+
+	if ctx.Err() != nil {
+		return checkFailureExitCode
+	}
+	return checkExitCode(results.Checks)
+}
+
+// printCheckResult writes a line with the check's status to w, followed by
+// the check's hint when it failed.
+func printCheckResult(w io.Writer, result *healthcheck.CheckResult) {
+	checkLabel := fmt.Sprintf("%s: %s", result.Category, result.Description)
 
-	return hc.RunChecks(prettyPrintResults)
+	filler := ""
+	lineBreak := "\n"
+	for i := 0; i < lineWidth-len(checkLabel)-len(okStatus)-len(lineBreak); i++ {
+		filler = filler + "."
+	}
+
+	if result.Retry {
+		fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, retryStatus, result.Err, lineBreak)
+		return
+	}
+
+	if result.Err != nil {
+		status := failStatus
+		switch result.Severity {
+		case healthcheck.SeverityWarning:
+			status = warnStatus
+		case healthcheck.SeverityInfo:
+			status = infoStatus
+		}
+		fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, status, result.Err, lineBreak)
+		if result.Hint != nil {
+			fmt.Fprintf(w, "    %s%s", result.Hint.Remediation, lineBreak)
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "%s%s%s%s", checkLabel, filler, okStatus, lineBreak)
+}
+
+// checkExitCode returns the exit code for the outcome of the given check
+// results. Connectivity failures take precedence over other failures, which
+// take precedence over warnings. Informational failures are ignored.
+func checkExitCode(results []*healthcheck.CheckResult) int {
+	exitCode := checkSuccessExitCode
+	for _, result := range results {
+		if result.Err == nil || result.Retry {
+			continue
+		}
+
+		switch result.Severity {
+		case healthcheck.SeverityConnectivity:
+			return checkConnectivityExitCode
+		case healthcheck.SeverityWarning:
+			if exitCode == checkSuccessExitCode {
+				exitCode = checkWarningExitCode
+			}
+		case healthcheck.SeverityInfo:
+			continue
+		default:
+			exitCode = checkFailureExitCode
+		}
+	}
+	return exitCode
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"testing"
@@ -11,10 +12,13 @@ import (
 
 func TestCheckStatus(t *testing.T) {
 	t.Run("Prints expected output", func(t *testing.T) {
-		hc := healthcheck.NewHealthChecker(
+		hc, err := healthcheck.NewHealthChecker(
 			[]healthcheck.Checks{},
 			&healthcheck.HealthCheckOptions{},
 		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
 		hc.Add("category", "check1", func() error {
 			return nil
 		})
@@ -23,7 +27,7 @@ func TestCheckStatus(t *testing.T) {
 		})
 
 		output := bytes.NewBufferString("")
-		runChecks(output, hc)
+		runChecks(context.Background(), output, hc)
 
 		goldenFileBytes, err := ioutil.ReadFile("testdata/check_output.golden")
 		if err != nil {
@@ -36,4 +40,136 @@ func TestCheckStatus(t *testing.T) {
 			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
 		}
 	})
+
+	t.Run("Prints the hints of failed checks", func(t *testing.T) {
+		output := bytes.NewBufferString("")
+		printCheckResult(output, &healthcheck.CheckResult{
+			Category:    "category",
+			Description: "check",
+			Err:         fmt.Errorf("failed"),
+			Hint: &healthcheck.Hint{
+				Remediation: "Fix the check",
+			},
+		})
+
+		expected := `category: check............................................................[FAIL] -- failed
+    Fix the check
+`
+		if expected != output.String() {
+			t.Fatalf("Expected:\n%s\nbut got:\n%s", expected, output)
+		}
+	})
+
+	t.Run("Prints the results as JSON and YAML", func(t *testing.T) {
+		expectedOutputs := map[string]string{
+			"json": `{
+  "success": false,
+  "results": [
+    {
+      "category": "category",
+      "description": "check1",
+      "status": "ok",
+      "retries": 0,
+      "durationMs": 0
+    },
+    {
+      "category": "category",
+      "description": "check2",
+      "status": "error",
+      "error": "This should contain instructions for fail",
+      "retries": 0,
+      "durationMs": 0
+    }
+  ]
+}
+`,
+			"yaml": `results:
+- category: category
+  description: check1
+  durationMs: 0
+  retries: 0
+  status: ok
+- category: category
+  description: check2
+  durationMs: 0
+  error: This should contain instructions for fail
+  retries: 0
+  status: error
+success: false
+`,
+		}
+
+		for format, expectedOutput := range expectedOutputs {
+			hc, err := healthcheck.NewHealthChecker(
+				[]healthcheck.Checks{},
+				&healthcheck.HealthCheckOptions{},
+			)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			hc.Add("category", "check1", func() error {
+				return nil
+			})
+			hc.Add("category", "check2", func() error {
+				return fmt.Errorf("This should contain instructions for fail")
+			})
+
+			output := bytes.NewBufferString("")
+			exitCode := runChecksReport(context.Background(), output, hc, format)
+
+			if exitCode != checkFailureExitCode {
+				t.Fatalf("Expected exit code %d but got %d", checkFailureExitCode, exitCode)
+			}
+			if expectedOutput != output.String() {
+				t.Fatalf("Expected %s output:\n%s\nbut got:\n%s", format, expectedOutput, output)
+			}
+		}
+	})
+}
+
+func TestCheckExitCode(t *testing.T) {
+	ok := &healthcheck.CheckResult{Severity: healthcheck.SeverityError}
+	failed := &healthcheck.CheckResult{Severity: healthcheck.SeverityError, Err: fmt.Errorf("failed")}
+	retried := &healthcheck.CheckResult{Severity: healthcheck.SeverityError, Err: fmt.Errorf("failed"), Retry: true}
+	warned := &healthcheck.CheckResult{Severity: healthcheck.SeverityWarning, Err: fmt.Errorf("warned")}
+	unreachable := &healthcheck.CheckResult{Severity: healthcheck.SeverityConnectivity, Err: fmt.Errorf("unreachable")}
+	informed := &healthcheck.CheckResult{Severity: healthcheck.SeverityInfo, Err: fmt.Errorf("informed")}
+
+	testCases := []struct {
+		results  []*healthcheck.CheckResult
+		exitCode int
+	}{
+		{[]*healthcheck.CheckResult{ok, retried, ok}, checkSuccessExitCode},
+		{[]*healthcheck.CheckResult{ok, warned}, checkWarningExitCode},
+		{[]*healthcheck.CheckResult{failed, warned}, checkFailureExitCode},
+		{[]*healthcheck.CheckResult{warned, failed}, checkFailureExitCode},
+		{[]*healthcheck.CheckResult{failed, unreachable}, checkConnectivityExitCode},
+		{[]*healthcheck.CheckResult{ok, informed}, checkSuccessExitCode},
+		{[]*healthcheck.CheckResult{informed, warned}, checkWarningExitCode},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			exitCode := checkExitCode(tc.results)
+			if exitCode != tc.exitCode {
+				t.Fatalf("Expected exit code %d but got %d", tc.exitCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestCheckFlags(t *testing.T) {
+	executeRootCmd(t, "check", "--proxy", "--selector", "app=payments", "-n", "team-a", "--linkerd-namespace", "linkerd", "--help")
+
+	cmd := newCmdCheck()
+	if err := cmd.ParseFlags([]string{"-n", "team-a", "-n", "team-b,team-c"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	namespaces, err := cmd.Flags().GetStringArray("namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(namespaces) != 2 || namespaces[0] != "team-a" || namespaces[1] != "team-b,team-c" {
+		t.Fatalf("Expected namespaces [team-a team-b,team-c], got %v", namespaces)
+	}
 }
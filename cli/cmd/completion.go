@@ -4,10 +4,64 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/spf13/cobra"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
+// bashCompletionFunc is appended to the generated bash completion code. It
+// completes the resource names of the stat, tap and top commands, and the
+// values of their --namespace flags, by calling the hidden resource-names
+// command with the connection flags found on the command line.
+const bashCompletionFunc = `__linkerd_override_flags()
+{
+    local flags=() i
+    for ((i = 1; i < ${#words[@]}; i++)); do
+        case "${words[i]}" in
+            --kubeconfig=*|--context=*|--namespace=*)
+                flags+=("${words[i]}")
+                ;;
+            --kubeconfig|--context|--namespace|-n)
+                flags+=("${words[i]}" "${words[i+1]}")
+                ;;
+        esac
+    done
+    echo "${flags[@]}"
+}
+
+__linkerd_get_resources()
+{
+    local linkerd_out
+    if linkerd_out=$(linkerd resource-names "$1" $(__linkerd_override_flags) 2>/dev/null); then
+        COMPREPLY=( $( compgen -P "$2" -W "${linkerd_out[*]}" -- "${cur#$2}" ) )
+    fi
+}
+
+__linkerd_get_namespaces()
+{
+    __linkerd_get_resources namespace
+}
+
+__custom_func()
+{
+    case ${last_command} in
+        linkerd_stat | linkerd_tap | linkerd_top)
+            if [[ ${cur} == */* ]]; then
+                __linkerd_get_resources "${cur%%/*}" "${cur%%/*}/"
+            elif [[ ${#nouns[@]} -eq 1 ]]; then
+                __linkerd_get_resources "${nouns[0]}"
+            fi
+            return
+            ;;
+        *)
+            ;;
+    esac
+}
+`
+
 func newCmdCompletion() *cobra.Command {
 	example := `  # bash <= 3.2
   source /dev/stdin <<< "$(linkerd completion bash)"
@@ -26,6 +80,10 @@ func newCmdCompletion() *cobra.Command {
   # zsh
   source <(linkerd completion zsh)
 
+  # With bash, the names of namespaces and resources are completed from the
+  # cluster of the current kubeconfig context.
+  linkerd stat deploy/<TAB>
+
   # zsh on osx / oh-my-zsh
   linkerd completion zsh > "${fpath[1]}/_linkerd"`
 
@@ -69,3 +127,107 @@ func getCompletion(sh string, parent *cobra.Command) (string, error) {
 
 	return buf.String(), nil
 }
+
+// newCmdResourceNames creates the hidden command that the bash completion code
+// calls to list the names of the resources of a given type in the cluster.
+func newCmdResourceNames() *cobra.Command {
+	namespace := "default"
+
+	cmd := &cobra.Command{
+		Use:    "resource-names RESOURCE_TYPE",
+		Short:  "List the names of the resources of a given type, for shell completion",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			clientset, err := kubernetes.NewForConfig(kubeAPI.Config)
+			if err != nil {
+				return err
+			}
+
+			names, err := getResourceNames(clientset, args[0], namespace)
+			if err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				fmt.Println(name)
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", namespace, "Namespace of the listed resources")
+
+	return cmd
+}
+
+// getResourceNames returns the sorted names of the resources of the given
+// type in the namespace. Namespaces are listed regardless of the namespace.
+func getResourceNames(clientset kubernetes.Interface, friendlyName, namespace string) ([]string, error) {
+	resourceType, err := k8s.CanonicalResourceNameFromFriendlyName(friendlyName)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	listOptions := metaV1.ListOptions{}
+
+	switch resourceType {
+	case k8s.Namespace:
+		list, err := clientset.CoreV1().Namespaces().List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case k8s.Deployment:
+		list, err := clientset.AppsV1().Deployments(namespace).List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case k8s.Pod:
+		list, err := clientset.CoreV1().Pods(namespace).List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case k8s.ReplicationController:
+		list, err := clientset.CoreV1().ReplicationControllers(namespace).List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case k8s.Service:
+		list, err := clientset.CoreV1().Services(namespace).List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	default:
+		return nil, fmt.Errorf("cannot complete the names of resources of type [%s]", resourceType)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// addNamespaceCompletion completes the values of the command's --namespace
+// flag with the names of the namespaces in the cluster.
+func addNamespaceCompletion(cmd *cobra.Command) {
+	cmd.PersistentFlags().SetAnnotation("namespace", cobra.BashCompCustom, []string{"__linkerd_get_namespaces"})
+}
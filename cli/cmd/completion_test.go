@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestCompletion(t *testing.T) {
@@ -22,6 +29,10 @@ func TestCompletion(t *testing.T) {
 			t.Fatalf("Unexpected bash output: %+v", bash)
 		}
 
+		if !strings.Contains(bash, "__linkerd_get_namespaces") {
+			t.Fatalf("Expected bash output to complete namespaces: %+v", bash)
+		}
+
 		if !strings.Contains(zsh, "#compdef linkerd") {
 			t.Fatalf("Unexpected zsh output: %+v", zsh)
 		}
@@ -34,3 +45,45 @@ func TestCompletion(t *testing.T) {
 		}
 	})
 }
+
+func TestGetResourceNames(t *testing.T) {
+	clientset := fake.NewSimpleClientset([]runtime.Object{
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "emojivoto"}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "booksapp"}},
+		&appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "emojivoto"}},
+		&appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "emoji", Namespace: "emojivoto"}},
+		&appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "books", Namespace: "booksapp"}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web-5f86686c4d-58p7k", Namespace: "emojivoto"}},
+	}...)
+
+	expectations := []struct {
+		resourceType string
+		namespace    string
+		names        []string
+	}{
+		{"ns", "emojivoto", []string{"booksapp", "emojivoto"}},
+		{"deploy", "emojivoto", []string{"emoji", "web"}},
+		{"deployments", "booksapp", []string{"books"}},
+		{"po", "emojivoto", []string{"web-5f86686c4d-58p7k"}},
+		{"svc", "emojivoto", []string{}},
+	}
+
+	for _, exp := range expectations {
+		t.Run(exp.resourceType+"/"+exp.namespace, func(t *testing.T) {
+			names, err := getResourceNames(clientset, exp.resourceType, exp.namespace)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(names, exp.names) {
+				t.Fatalf("Expected names %v but got %v", exp.names, names)
+			}
+		})
+	}
+
+	t.Run("Returns an error for resources that can't be completed", func(t *testing.T) {
+		_, err := getResourceNames(clientset, "authority", "emojivoto")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+}
@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type doctorOptions struct {
+	namespace string
+	wait      time.Duration
+	report    string
+	logLines  int64
+}
+
+func newDoctorOptions() *doctorOptions {
+	return &doctorOptions{
+		namespace: "",
+		wait:      300 * time.Second,
+		report:    "linkerd-doctor-report.txt",
+		logLines:  50,
+	}
+}
+
+// checkOptions returns the options of the checks run by linkerd doctor: those
+// of linkerd check, plus the data plane checks.
+func (options *doctorOptions) checkOptions() *checkOptions {
+	checkOptions := newCheckOptions()
+	checkOptions.wait = options.wait
+	checkOptions.withDataPlane = true
+	if options.namespace != "" {
+		checkOptions.namespaces = []string{options.namespace}
+	}
+	return checkOptions
+}
+
+func newCmdDoctor() *cobra.Command {
+	options := newDoctorOptions()
+
+	cmd := &cobra.Command{
+		Use:   "doctor [flags]",
+		Short: "Check the Linkerd installation and collect evidence of its problems",
+		Long: `Check the Linkerd installation and collect evidence of its problems.
+
+The doctor command runs the checks of "linkerd check", including the data plane
+checks, and then gathers evidence for each failing or warning check into a
+report that can be shared when asking for help: the description, recent events
+and log tail of the pods involved in the failure, and the proxy injection
+webhook configurations. The report is written even if all the checks pass, and
+the command exits with the same exit code as "linkerd check".`,
+		Example: `  # Check the Linkerd installation and write the report to linkerd-doctor-report.txt
+  linkerd doctor
+
+  # Only check the data plane proxies of the "app" namespace, and keep 200 log lines
+  linkerd doctor --namespace app --log-lines 200 --report app-report.txt`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hc, err := healthcheck.NewHealthChecker(healthChecks(options.checkOptions()))
+			if err != nil {
+				return fmt.Errorf("Failed to configure the health checks: %s", err)
+			}
+
+			report, err := os.Create(options.report)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := interruptContext()
+			defer cancel()
+
+			exitCode := runDoctor(ctx, os.Stdout, report, hc, newEvidenceCollector(options))
+			if err := report.Close(); err != nil {
+				return err
+			}
+
+			fmt.Printf("\nWrote the diagnosis report to %s\n", options.report)
+			exitWithCheckStatus(exitCode)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to use for the data plane checks (default: all namespaces)")
+	cmd.PersistentFlags().DurationVar(&options.wait, "wait", options.wait, "Retry and wait for some checks to succeed if they don't pass the first time")
+	cmd.PersistentFlags().StringVar(&options.report, "report", options.report, "Path of the report file")
+	cmd.PersistentFlags().Int64Var(&options.logLines, "log-lines", options.logLines, "Number of log lines to collect from each container involved in a failure")
+
+	addNamespaceCompletion(cmd)
+
+	return cmd
+}
+
+// runDoctor runs the checks, printing their results to w, then writes the
+// report with the evidence for the checks that didn't pass, and returns the
+// exit code for the checks' outcome.
+func runDoctor(ctx context.Context, w io.Writer, report io.Writer, hc *healthcheck.HealthChecker, collector *evidenceCollector) int {
+	results := []*healthcheck.CheckResult{}
+
+	hc.RunChecks(ctx, func(result *healthcheck.CheckResult) {
+		printCheckResult(w, result)
+		if !result.Retry {
+			results = append(results, result)
+		}
+	})
+
+	writeDoctorReport(report, results, collector)
+	return checkExitCode(results)
+}
+
+// writeDoctorReport writes the check results to w, followed by a section per
+// category of checks that failed or warned, with their errors and the evidence
+// gathered for the category.
+func writeDoctorReport(w io.Writer, results []*healthcheck.CheckResult, collector *evidenceCollector) {
+	fmt.Fprintln(w, "Linkerd doctor report")
+	fmt.Fprintln(w, "")
+	fmt.Fprintf(w, "CLI version: %s\n", version.Version)
+	fmt.Fprintf(w, "Control plane namespace: %s\n", controlPlaneNamespace)
+	fmt.Fprintln(w, "")
+
+	writeReportHeading(w, "Check results")
+	categories := []string{}
+	failures := map[string][]*healthcheck.CheckResult{}
+	for _, result := range results {
+		printCheckResult(w, result)
+		if result.Err == nil {
+			continue
+		}
+		if _, ok := failures[result.Category]; !ok {
+			categories = append(categories, result.Category)
+		}
+		failures[result.Category] = append(failures[result.Category], result)
+	}
+
+	if len(categories) == 0 {
+		fmt.Fprintln(w, "")
+		fmt.Fprintln(w, "All the checks passed; no evidence was collected.")
+		return
+	}
+
+	for _, category := range categories {
+		fmt.Fprintln(w, "")
+		writeReportHeading(w, fmt.Sprintf("Evidence for %s", category))
+		for _, result := range failures[category] {
+			fmt.Fprintf(w, "%s: %s\n", result.Description, result.Err)
+		}
+		fmt.Fprintln(w, "")
+
+		if err := collector.collect(w, category); err != nil {
+			fmt.Fprintf(w, "Failed to collect evidence: %s\n", err)
+		}
+	}
+}
+
+func writeReportHeading(w io.Writer, heading string) {
+	fmt.Fprintln(w, heading)
+	fmt.Fprintln(w, strings.Repeat("=", len(heading)))
+	fmt.Fprintln(w, "")
+}
+
+// evidenceCollector gathers the Kubernetes resources relevant to the failures
+// of each category of checks.
+type evidenceCollector struct {
+	clientset kubernetes.Interface
+	// err is the error met while creating the clientset; it's reported
+	// instead of the evidence.
+	err                error
+	dataPlaneNamespace string
+	logLines           int64
+	// podLogs returns the last lines of a container's logs.
+	podLogs func(namespace, pod, container string, lines int64) (string, error)
+}
+
+func newEvidenceCollector(options *doctorOptions) *evidenceCollector {
+	collector := &evidenceCollector{
+		dataPlaneNamespace: options.namespace,
+		logLines:           options.logLines,
+	}
+
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+	if err != nil {
+		collector.err = err
+		return collector
+	}
+	clientset, err := kubernetes.NewForConfig(kubeAPI.Config)
+	if err != nil {
+		collector.err = err
+		return collector
+	}
+
+	collector.clientset = clientset
+	collector.podLogs = func(namespace, pod, container string, lines int64) (string, error) {
+		logs, err := clientset.CoreV1().Pods(namespace).GetLogs(pod, &v1.PodLogOptions{
+			Container: container,
+			TailLines: &lines,
+		}).DoRaw()
+		return string(logs), err
+	}
+	return collector
+}
+
+// collect writes the evidence for the failures of the given category of checks
+// to w. Failures of the Kubernetes API and version checks are described by
+// their errors alone.
+func (c *evidenceCollector) collect(w io.Writer, category string) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	switch category {
+	case healthcheck.LinkerdAPICategory:
+		return c.collectPods(w, controlPlaneNamespace, metav1.ListOptions{}, false)
+
+	case healthcheck.LinkerdDataPlaneCategory:
+		selector := fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace)
+		return c.collectPods(w, c.dataPlaneNamespace, metav1.ListOptions{LabelSelector: selector}, true)
+
+	case healthcheck.LinkerdInjectionSafetyCategory:
+		return c.collectWebhookConfigs(w)
+
+	case healthcheck.LinkerdExtensionCategory, healthcheck.LinkerdJaegerCategory:
+		namespaces, err := c.clientset.CoreV1().Namespaces().List(metav1.ListOptions{LabelSelector: k8s.ExtensionLabel})
+		if err != nil {
+			return err
+		}
+		for _, ns := range namespaces.Items {
+			if err := c.collectPods(w, ns.Name, metav1.ListOptions{}, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "No evidence is collected for this category.")
+	return nil
+}
+
+// collectPods writes the description, events and logs of the pods matching
+// the options, or only of those that aren't ready if unreadyOnly is set.
+func (c *evidenceCollector) collectPods(w io.Writer, namespace string, options metav1.ListOptions, unreadyOnly bool) error {
+	podList, err := c.clientset.CoreV1().Pods(namespace).List(options)
+	if err != nil {
+		return err
+	}
+
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	collected := 0
+	for _, pod := range pods {
+		if unreadyOnly && podIsReady(pod) {
+			continue
+		}
+		collected++
+
+		events, err := c.clientset.CoreV1().Events(pod.Namespace).List(metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s", pod.Name),
+		})
+		if err != nil {
+			return err
+		}
+		describePod(w, pod, events.Items)
+
+		for _, container := range pod.Spec.Containers {
+			fmt.Fprintf(w, "  Logs of %s (last %d lines):\n", container.Name, c.logLines)
+			logs, err := c.podLogs(pod.Namespace, pod.Name, container.Name, c.logLines)
+			if err != nil {
+				fmt.Fprintf(w, "    Failed to get the logs: %s\n", err)
+				continue
+			}
+			writeIndented(w, logs, "    ")
+		}
+		fmt.Fprintln(w, "")
+	}
+
+	if collected == 0 {
+		fmt.Fprintf(w, "No pods to collect evidence from in %s.\n", namespaceDescription(namespace))
+	}
+	return nil
+}
+
+// collectWebhookConfigs writes the mutating webhook configurations as YAML.
+func (c *evidenceCollector) collectWebhookConfigs(w io.Writer) error {
+	configs, err := c.clientset.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	if len(configs.Items) == 0 {
+		fmt.Fprintln(w, "No mutating webhook configurations found.")
+		return nil
+	}
+
+	for _, config := range configs.Items {
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "---")
+		fmt.Fprintf(w, "%s", out)
+	}
+	return nil
+}
+
+// describePod writes a summary of the pod's state and events to w, in the
+// spirit of kubectl describe.
+func describePod(w io.Writer, pod v1.Pod, events []v1.Event) {
+	fmt.Fprintf(w, "Pod %s/%s\n", pod.Namespace, pod.Name)
+	fmt.Fprintf(w, "  Node: %s\n", pod.Spec.NodeName)
+	fmt.Fprintf(w, "  Phase: %s\n", pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		fmt.Fprintf(w, "  Reason: %s: %s\n", pod.Status.Reason, pod.Status.Message)
+	}
+
+	fmt.Fprintln(w, "  Conditions:")
+	for _, condition := range pod.Status.Conditions {
+		fmt.Fprintf(w, "    %s: %s", condition.Type, condition.Status)
+		if condition.Reason != "" {
+			fmt.Fprintf(w, " (%s)", condition.Reason)
+		}
+		fmt.Fprintln(w, "")
+	}
+
+	statuses := map[string]v1.ContainerStatus{}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		statuses[status.Name] = status
+	}
+
+	fmt.Fprintln(w, "  Containers:")
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		fmt.Fprintf(w, "    %s:\n", container.Name)
+		fmt.Fprintf(w, "      Image: %s\n", container.Image)
+		status, ok := statuses[container.Name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "      Ready: %t\n", status.Ready)
+		fmt.Fprintf(w, "      Restarts: %d\n", status.RestartCount)
+		fmt.Fprintf(w, "      State: %s\n", describeContainerState(status.State))
+		if status.LastTerminationState.Terminated != nil {
+			fmt.Fprintf(w, "      Last State: %s\n", describeContainerState(status.LastTerminationState))
+		}
+	}
+
+	fmt.Fprintln(w, "  Events:")
+	if len(events) == 0 {
+		fmt.Fprintln(w, "    None")
+	}
+	for _, event := range events {
+		fmt.Fprintf(w, "    %s %s: %s", event.Type, event.Reason, event.Message)
+		if event.Count > 1 {
+			fmt.Fprintf(w, " (x%d)", event.Count)
+		}
+		fmt.Fprintln(w, "")
+	}
+}
+
+func describeContainerState(state v1.ContainerState) string {
+	switch {
+	case state.Waiting != nil:
+		return fmt.Sprintf("Waiting (%s: %s)", state.Waiting.Reason, state.Waiting.Message)
+	case state.Terminated != nil:
+		return fmt.Sprintf("Terminated (%s, exit code %d)", state.Terminated.Reason, state.Terminated.ExitCode)
+	case state.Running != nil:
+		return fmt.Sprintf("Running since %s", state.Running.StartedAt.UTC().Format(time.RFC3339))
+	}
+	return "Unknown"
+}
+
+func podIsReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func namespaceDescription(namespace string) string {
+	if namespace == "" {
+		return "any namespace"
+	}
+	return fmt.Sprintf("the %s namespace", namespace)
+}
+
+// writeIndented writes each line of text to w with the given prefix.
+func writeIndented(w io.Writer, text, prefix string) {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		fmt.Fprintf(w, "%s(empty)\n", prefix)
+		return
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(w, "%s%s\n", prefix, line)
+	}
+}
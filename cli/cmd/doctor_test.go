@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	admissionregistration "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWriteDoctorReport(t *testing.T) {
+	readyPod := func(name string, ready v1.ConditionStatus) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: "emojivoto",
+				Labels:    map[string]string{k8s.ControllerNSLabel: controlPlaneNamespace},
+			},
+			Spec: v1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []v1.Container{{Name: k8s.ProxyContainerName, Image: "gcr.io/linkerd-io/proxy:dev"}},
+			},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready, Reason: "ContainersNotReady"}},
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:         k8s.ProxyContainerName,
+					RestartCount: 3,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s"},
+					},
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+					},
+				}},
+			},
+		}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		readyPod("web", v1.ConditionFalse),
+		readyPod("voting", v1.ConditionTrue),
+		&v1.Event{
+			ObjectMeta:     metaV1.ObjectMeta{Name: "web.1", Namespace: "emojivoto"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web"},
+			Type:           "Warning",
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			Count:          12,
+		},
+		&admissionregistration.MutatingWebhookConfiguration{
+			ObjectMeta: metaV1.ObjectMeta{Name: "linkerd-proxy-injector-webhook-config"},
+		},
+	)
+
+	collector := &evidenceCollector{
+		clientset: clientset,
+		logLines:  10,
+		podLogs: func(namespace, pod, container string, lines int64) (string, error) {
+			return "ERR linkerd2_proxy connection refused\n", nil
+		},
+	}
+
+	t.Run("Collects the evidence of the categories with failures", func(t *testing.T) {
+		results := []*healthcheck.CheckResult{
+			{Category: healthcheck.LinkerdAPICategory, Description: "can query the control plane API"},
+			{Category: healthcheck.LinkerdDataPlaneCategory, Description: "data plane proxies are ready", Err: errors.New("The \"web\" pod is not running")},
+		}
+
+		var buf bytes.Buffer
+		writeDoctorReport(&buf, results, collector)
+		report := buf.String()
+
+		expected := []string{
+			"linkerd-api: can query the control plane API...............................[ok]\n",
+			"linkerd-data-plane: data plane proxies are ready...........................[FAIL] -- The \"web\" pod is not running\n",
+			"Evidence for linkerd-data-plane\n",
+			"Pod emojivoto/web\n",
+			"    Ready: False (ContainersNotReady)\n",
+			"      State: Waiting (CrashLoopBackOff: back-off 5m0s)\n",
+			"      Last State: Terminated (Error, exit code 1)\n",
+			"    Warning BackOff: Back-off restarting failed container (x12)\n",
+			"  Logs of linkerd-proxy (last 10 lines):\n    ERR linkerd2_proxy connection refused\n",
+		}
+		for _, e := range expected {
+			if !strings.Contains(report, e) {
+				t.Fatalf("Expected the report to contain:\n%s\ngot:\n%s", e, report)
+			}
+		}
+
+		unexpected := []string{"Evidence for linkerd-api", "Pod emojivoto/voting", "linkerd-proxy-injector-webhook-config"}
+		for _, u := range unexpected {
+			if strings.Contains(report, u) {
+				t.Fatalf("Expected the report not to contain %q, got:\n%s", u, report)
+			}
+		}
+	})
+
+	t.Run("Collects the webhook configurations for injection safety failures", func(t *testing.T) {
+		results := []*healthcheck.CheckResult{
+			{Category: healthcheck.LinkerdInjectionSafetyCategory, Description: "proxy injection webhooks exclude critical namespaces", Err: errors.New("no namespaceSelector"), Severity: healthcheck.SeverityWarning},
+		}
+
+		var buf bytes.Buffer
+		writeDoctorReport(&buf, results, collector)
+		report := buf.String()
+
+		if !strings.Contains(report, "[warn] -- no namespaceSelector\n") || !strings.Contains(report, "name: linkerd-proxy-injector-webhook-config\n") {
+			t.Fatalf("Expected the report to contain the webhook configuration, got:\n%s", report)
+		}
+	})
+
+	t.Run("Reports the error met while creating the Kubernetes client", func(t *testing.T) {
+		results := []*healthcheck.CheckResult{
+			{Category: healthcheck.LinkerdAPICategory, Description: "control plane pods are ready", Err: errors.New("not ready")},
+		}
+
+		var buf bytes.Buffer
+		writeDoctorReport(&buf, results, &evidenceCollector{err: errors.New("no kubeconfig")})
+
+		if !strings.Contains(buf.String(), "Failed to collect evidence: no kubeconfig\n") {
+			t.Fatalf("Expected the report to contain the error, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("Doesn't collect evidence if all the checks passed", func(t *testing.T) {
+		results := []*healthcheck.CheckResult{
+			{Category: healthcheck.LinkerdAPICategory, Description: "can query the control plane API"},
+		}
+
+		var buf bytes.Buffer
+		writeDoctorReport(&buf, results, collector)
+
+		if !strings.HasSuffix(buf.String(), "All the checks passed; no evidence was collected.\n") {
+			t.Fatalf("Unexpected report:\n%s", buf.String())
+		}
+	})
+}
+
+func TestDoctorChecks(t *testing.T) {
+	options := newDoctorOptions()
+	options.namespace = "emojivoto"
+	doctorChecks, doctorOptions := healthChecks(options.checkOptions())
+	checkChecks, checkOptions := healthChecks(newCheckOptions())
+
+	// doctor runs every check of linkerd check, plus the data plane checks
+	runs := map[healthcheck.Checks]bool{}
+	for _, checks := range doctorChecks {
+		runs[checks] = true
+	}
+	for _, checks := range append(checkChecks, healthcheck.LinkerdDataPlaneChecks) {
+		if !runs[checks] {
+			t.Errorf("Expected doctor to run the %s checks", checks)
+		}
+	}
+
+	if doctorOptions.CheckTimeout != checkOptions.CheckTimeout {
+		t.Errorf("Expected check timeout %s but got %s", checkOptions.CheckTimeout, doctorOptions.CheckTimeout)
+	}
+	if len(doctorOptions.DataPlaneNamespaces) != 1 || doctorOptions.DataPlaneNamespaces[0] != "emojivoto" {
+		t.Errorf("Expected the data plane checks to be restricted to emojivoto, got %v", doctorOptions.DataPlaneNamespaces)
+	}
+	if !doctorOptions.ShouldCheckDataPlaneVersion {
+		t.Error("Expected doctor to check the data plane version")
+	}
+}
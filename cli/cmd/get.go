@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
@@ -12,15 +12,33 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// getResourceTypes are the resource types that can be listed by "linkerd get".
+var getResourceTypes = []string{k8s.Deployment, k8s.Namespace, k8s.Pod, k8s.Service}
+
 type getOptions struct {
 	namespace     string
 	allNamespaces bool
+	labelSelector string
+	fieldSelector string
+	output        string
+}
+
+// getResourceJSON is a resource as exported by "-o json" and "-o yaml".
+type getResourceJSON struct {
+	Namespace   string            `json:"namespace,omitempty"`
+	Type        string            `json:"type"`
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 func newGetOptions() *getOptions {
 	return &getOptions{
 		namespace:     "default",
 		allNamespaces: false,
+		labelSelector: "",
+		fieldSelector: "",
+		output:        tableOutput,
 	}
 }
 
@@ -28,72 +46,146 @@ func newCmdGet() *cobra.Command {
 	options := newGetOptions()
 
 	cmd := &cobra.Command{
-		Use:   "get [flags] pods",
+		Use:   "get [flags] (TYPE)",
 		Short: "Display one or many mesh resources",
 		Long: `Display one or many mesh resources.
 
-Only pod resources (aka pods, po) are supported.`,
+The resources are read from the caches of the Linkerd public API, rather than
+from the Kubernetes API.
+
+Valid resource types include:
+
+  * deployments
+  * namespaces
+  * pods
+  * services
+
+The resources can be filtered with a label selector, given with "--selector",
+and with a field selector, given with "--field-selector". Field selectors
+support the metadata.name and metadata.namespace fields of every resource, the
+status.phase field of namespaces, the spec.nodeName, spec.serviceAccountName,
+status.phase and status.podIP fields of pods, and the spec.clusterIP and
+spec.type fields of services.
+
+With "-o json", the resources are exported as JSON along with their labels and
+annotations.`,
 		Example: `  # get all pods
   linkerd get pods
 
   # get pods from namespace linkerd
-  linkerd get pods --namespace linkerd`,
-		Args:      cobra.ExactArgs(1),
-		ValidArgs: []string{k8s.Pod},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return errors.New("please specify a resource type")
-			}
-
-			if len(args) > 1 {
-				return errors.New("please specify only one resource type")
-			}
+  linkerd get pods --namespace linkerd
 
-			friendlyName := args[0]
-			resourceType, err := k8s.CanonicalResourceNameFromFriendlyName(friendlyName)
+  # get the running pods labeled app=web in all namespaces
+  linkerd get pods --all-namespaces --selector app=web --field-selector status.phase=Running
 
-			if err != nil || resourceType != k8s.Pod {
-				return fmt.Errorf("invalid resource type %s, valid types: %s", friendlyName, k8s.Pod)
+  # export the namespaces, along with their annotations, as JSON
+  linkerd get namespaces -o json`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: getResourceTypes,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := buildListResourcesRequest(args[0], options)
+			if err != nil {
+				return err
 			}
 
-			podNames, err := getPods(validatedPublicAPIClient(time.Time{}), options)
+			resources, err := getResources(validatedPublicAPIClient(time.Time{}), req)
 			if err != nil {
 				return err
 			}
 
-			if len(podNames) == 0 {
+			if len(resources) == 0 && !isStructuredOutput(options.output) {
 				fmt.Fprintln(os.Stderr, "No resources found.")
 				os.Exit(0)
 			}
 
-			for _, podName := range podNames {
-				fmt.Println(podName)
+			output, err := renderResources(resources, options.output)
+			if err != nil {
+				return err
 			}
 
-			return nil
+			_, err = fmt.Print(output)
+			return err
 		},
 	}
 
-	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of pods")
-	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns pods across all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the resources")
+	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns resources across all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().StringVar(&options.labelSelector, "selector", options.labelSelector, "Selector (label query) to filter resources on, supports '=', '==', and '!='")
+	cmd.PersistentFlags().StringVar(&options.fieldSelector, "field-selector", options.fieldSelector, "Selector (field query) to filter resources on, supports '=', '==', and '!='")
+	addOutputFlag(cmd, &options.output)
+
+	addNamespaceCompletion(cmd)
+
 	return cmd
 }
 
-func getPods(apiClient pb.ApiClient, options *getOptions) ([]string, error) {
-	req := &pb.ListPodsRequest{}
-	if !options.allNamespaces {
-		req.Namespace = options.namespace
+func buildListResourcesRequest(friendlyName string, options *getOptions) (*pb.ListResourcesRequest, error) {
+	if err := validateOutput(options.output); err != nil {
+		return nil, err
 	}
 
-	resp, err := apiClient.ListPods(context.Background(), req)
+	resourceType, err := k8s.CanonicalResourceNameFromFriendlyName(friendlyName)
+	if err != nil || !isGetResourceType(resourceType) {
+		return nil, fmt.Errorf("invalid resource type %s, valid types: %s", friendlyName, strings.Join(getResourceTypes, ", "))
+	}
+
+	resource := &pb.Resource{Type: resourceType}
+	if !options.allNamespaces && resourceType != k8s.Namespace {
+		resource.Namespace = options.namespace
+	}
+
+	return &pb.ListResourcesRequest{
+		Selector: &pb.ResourceSelection{
+			Resource:      resource,
+			LabelSelector: options.labelSelector,
+		},
+		FieldSelector: options.fieldSelector,
+	}, nil
+}
+
+func isGetResourceType(resourceType string) bool {
+	for _, valid := range getResourceTypes {
+		if resourceType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func getResources(apiClient pb.ApiClient, req *pb.ListResourcesRequest) ([]*pb.ResourceInfo, error) {
+	resp, err := apiClient.ListResources(context.Background(), req)
 	if err != nil {
 		return nil, err
 	}
 
-	names := make([]string, 0)
-	for _, pod := range resp.GetPods() {
-		names = append(names, pod.Name)
+	return resp.GetResources(), nil
+}
+
+// renderResources returns the names of the resources, one per line and
+// prefixed by their namespace, or the resources as JSON or YAML.
+func renderResources(resources []*pb.ResourceInfo, output string) (string, error) {
+	if isStructuredOutput(output) {
+		exported := make([]getResourceJSON, 0)
+		for _, r := range resources {
+			exported = append(exported, getResourceJSON{
+				Namespace:   r.GetResource().GetNamespace(),
+				Type:        r.GetResource().GetType(),
+				Name:        r.GetResource().GetName(),
+				Labels:      r.GetLabels(),
+				Annotations: r.GetAnnotations(),
+			})
+		}
+
+		return marshalOutput(exported, output)
 	}
 
-	return names, nil
+	var lines []string
+	for _, r := range resources {
+		name := r.GetResource().GetName()
+		if namespace := r.GetResource().GetNamespace(); namespace != "" {
+			name = namespace + "/" + name
+		}
+		lines = append(lines, name+"\n")
+	}
+	return strings.Join(lines, ""), nil
 }
@@ -4,67 +4,184 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 )
 
-func TestGetPods(t *testing.T) {
-	t.Run("Returns names of existing pods if everything went ok", func(t *testing.T) {
+func TestGetResources(t *testing.T) {
+	t.Run("Returns names of existing resources if everything went ok", func(t *testing.T) {
 		mockClient := &public.MockApiClient{}
 
-		pods := []*pb.Pod{
-			{Name: "pod-a"},
-			{Name: "pod-b"},
-			{Name: "pod-c"},
+		mockClient.ListResourcesResponseToReturn = &pb.ListResourcesResponse{
+			Resources: []*pb.ResourceInfo{
+				{Resource: &pb.Resource{Namespace: "emojivoto", Type: "pod", Name: "pod-a"}},
+				{Resource: &pb.Resource{Namespace: "emojivoto", Type: "pod", Name: "pod-b"}},
+				{Resource: &pb.Resource{Namespace: "linkerd", Type: "pod", Name: "pod-c"}},
+			},
 		}
 
-		expectedPodNames := []string{
-			"pod-a",
-			"pod-b",
-			"pod-c",
+		req, err := buildListResourcesRequest("pods", newGetOptions())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
 		}
-		response := &pb.ListPodsResponse{
-			Pods: pods,
+
+		resources, err := getResources(mockClient, req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
 		}
 
-		mockClient.ListPodsResponseToReturn = response
-		actualPodNames, err := getPods(mockClient, newGetOptions())
+		output, err := renderResources(resources, "")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
 
-		for i, actualName := range actualPodNames {
-			expectedName := expectedPodNames[i]
-			if expectedName != actualName {
-				t.Fatalf("Expected %dth element on %v to be [%s], but was [%s]", i, actualPodNames, expectedName, actualName)
-			}
+		expectedOutput := "emojivoto/pod-a\nemojivoto/pod-b\nlinkerd/pod-c\n"
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
 		}
 	})
 
-	t.Run("Returns empty list if no pods found", func(t *testing.T) {
+	t.Run("Returns empty list if no resources found", func(t *testing.T) {
 		mockClient := &public.MockApiClient{}
 
-		mockClient.ListPodsResponseToReturn = &pb.ListPodsResponse{
-			Pods: []*pb.Pod{},
+		mockClient.ListResourcesResponseToReturn = &pb.ListResourcesResponse{
+			Resources: []*pb.ResourceInfo{},
 		}
 
-		actualPodNames, err := getPods(mockClient, newGetOptions())
+		req, err := buildListResourcesRequest("pods", newGetOptions())
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
 
-		if len(actualPodNames) != 0 {
-			t.Fatalf("Expecting no pod names, got %v", actualPodNames)
+		resources, err := getResources(mockClient, req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(resources) != 0 {
+			t.Fatalf("Expecting no resources, got %v", resources)
 		}
 	})
 
-	t.Run("Returns error if cant find pods in API", func(t *testing.T) {
+	t.Run("Returns error if cant find resources in API", func(t *testing.T) {
 		mockClient := &public.MockApiClient{}
 		mockClient.ErrorToReturn = errors.New("expected")
 
-		_, err := getPods(mockClient, newGetOptions())
+		req, err := buildListResourcesRequest("pods", newGetOptions())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		_, err = getResources(mockClient, req)
 		if err == nil {
 			t.Fatalf("Expecting error, got noting")
 		}
 	})
+
+	t.Run("Exports the resources as JSON and YAML", func(t *testing.T) {
+		resources := []*pb.ResourceInfo{
+			{
+				Resource:    &pb.Resource{Type: "namespace", Name: "emojivoto"},
+				Annotations: map[string]string{"linkerd.io/inject": "enabled"},
+			},
+		}
+
+		output, err := renderResources(resources, "json")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedOutput := `[
+  {
+    "type": "namespace",
+    "name": "emojivoto",
+    "annotations": {
+      "linkerd.io/inject": "enabled"
+    }
+  }
+]
+`
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+
+		output, err = renderResources(resources, "yaml")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedOutput = `- annotations:
+    linkerd.io/inject: enabled
+  name: emojivoto
+  type: namespace
+`
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+}
+
+func TestBuildListResourcesRequest(t *testing.T) {
+	t.Run("Builds a request with the selectors", func(t *testing.T) {
+		options := newGetOptions()
+		options.namespace = "emojivoto"
+		options.labelSelector = "app=web"
+		options.fieldSelector = "status.phase=Running"
+
+		req, err := buildListResourcesRequest("po", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := &pb.ListResourcesRequest{
+			Selector: &pb.ResourceSelection{
+				Resource:      &pb.Resource{Namespace: "emojivoto", Type: "pod"},
+				LabelSelector: "app=web",
+			},
+			FieldSelector: "status.phase=Running",
+		}
+		if !proto.Equal(expected, req) {
+			t.Fatalf("Expected: %+v, Got: %+v", expected, req)
+		}
+	})
+
+	t.Run("Lists namespaces and resources in all namespaces without a namespace", func(t *testing.T) {
+		options := newGetOptions()
+		options.allNamespaces = true
+
+		for _, resourceType := range []string{"ns", "deploy"} {
+			req, err := buildListResourcesRequest(resourceType, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if ns := req.GetSelector().GetResource().GetNamespace(); ns != "" {
+				t.Fatalf("Expected no namespace for %s, got %s", resourceType, ns)
+			}
+		}
+
+		req, err := buildListResourcesRequest("ns", newGetOptions())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if ns := req.GetSelector().GetResource().GetNamespace(); ns != "" {
+			t.Fatalf("Expected no namespace for namespaces, got %s", ns)
+		}
+	})
+
+	t.Run("Rejects unsupported resource types and output formats", func(t *testing.T) {
+		if _, err := buildListResourcesRequest("authority", newGetOptions()); err == nil {
+			t.Fatalf("Expected an error for the authority resource type")
+		}
+
+		options := newGetOptions()
+		options.output = "wide"
+		if _, err := buildListResourcesRequest("pods", options); err == nil {
+			t.Fatalf("Expected an error for the wide output format")
+		}
+	})
+}
+
+func TestGetFlags(t *testing.T) {
+	executeRootCmd(t, "get", "pods", "--selector", "app=web", "--linkerd-namespace", "linkerd", "--help")
 }
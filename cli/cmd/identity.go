@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type identityOptions struct {
+	namespace     string
+	allNamespaces bool
+}
+
+func newIdentityOptions() *identityOptions {
+	return &identityOptions{
+		namespace:     "default",
+		allNamespaces: false,
+	}
+}
+
+func newCmdIdentity() *cobra.Command {
+	options := newIdentityOptions()
+
+	cmd := &cobra.Command{
+		Use:   "identity [flags]",
+		Short: "Display the TLS identities of meshed pods and flag mismatches",
+		Long: `Display the TLS identities of meshed pods and flag mismatches.
+
+The identity each proxy was injected with is compared against the identity the
+control plane's CA derives from the pod's owner, in the control plane's trust
+domain, and against the certificate the CA issued to the proxy. Mismatches,
+which typically follow a rename of the control plane namespace or of a pod's
+owner, silently break TLS between the proxy and its peers. Pods injected for
+any control plane are displayed, so that pods that still point to a renamed
+control plane namespace show up.`,
+		Example: `  # Display the identities of the meshed pods in the emojivoto namespace.
+  linkerd identity -n emojivoto
+
+  # Display the identities of all the meshed pods.
+  linkerd identity --all-namespaces`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			clientset, err := kubernetes.NewForConfig(kubeAPI.Config)
+			if err != nil {
+				return err
+			}
+
+			mismatches, err := runIdentityCmd(clientset, os.Stdout, options)
+			if err != nil {
+				return err
+			}
+			if mismatches > 0 {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the pods")
+	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, displays pods across all namespaces, ignoring the \"--namespace\" flag")
+
+	addNamespaceCompletion(cmd)
+
+	return cmd
+}
+
+// runIdentityCmd writes the identities of the meshed pods to w, and returns
+// the number of pods whose identity doesn't match the control plane.
+func runIdentityCmd(clientset kubernetes.Interface, w io.Writer, options *identityOptions) (int, error) {
+	namespace := options.namespace
+	if options.allNamespaces {
+		namespace = ""
+	}
+
+	podList, err := clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: k8s.ControllerNSLabel})
+	if err != nil {
+		return 0, err
+	}
+
+	identities, err := healthcheck.DiagnoseProxyIdentities(clientset, controlPlaneNamespace, podList.Items)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(identities) == 0 {
+		fmt.Fprintln(w, "No meshed pods with TLS enabled found.")
+		return 0, nil
+	}
+
+	return renderIdentities(w, identities, options.allNamespaces), nil
+}
+
+// renderIdentities writes a table of the proxies' identities, followed by the
+// problems with each identity that doesn't match the control plane, and
+// returns the number of such identities.
+func renderIdentities(w io.Writer, identities []healthcheck.ProxyIdentity, allNamespaces bool) int {
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	if allNamespaces {
+		fmt.Fprint(tw, "NAMESPACE\t")
+	}
+	fmt.Fprintln(tw, "NAME\tIDENTITY\tSTATUS")
+
+	mismatches := 0
+	for _, identity := range identities {
+		status := "ok"
+		if len(identity.Problems) > 0 {
+			status = "mismatch"
+			mismatches++
+		}
+
+		if allNamespaces {
+			fmt.Fprintf(tw, "%s\t", identity.Pod.Namespace)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", identity.Pod.Name, identity.Configured, status)
+	}
+	tw.Flush()
+
+	for _, identity := range identities {
+		if len(identity.Problems) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s/%s (expected identity %s):\n", identity.Pod.Namespace, identity.Pod.Name, identity.Expected)
+		for _, problem := range identity.Problems {
+			fmt.Fprintf(w, "  * %s\n", problem)
+		}
+	}
+
+	return mismatches
+}
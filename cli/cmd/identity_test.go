@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderIdentities(t *testing.T) {
+	identities := []healthcheck.ProxyIdentity{
+		{
+			Pod:        &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "books-1", Namespace: "booksapp"}},
+			Configured: "books.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+			Expected:   "books.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+		},
+		{
+			Pod:        &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "booksapp"}},
+			Configured: "web.deployment.booksapp.linkerd-managed.old.svc.cluster.local",
+			Expected:   "web.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+			Problems:   []string{"trust domain linkerd-managed.old.svc.cluster.local doesn't match the control plane's linkerd-managed.linkerd.svc.cluster.local"},
+		},
+	}
+
+	expected := `NAME      IDENTITY                                                              STATUS
+books-1   books.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local   ok
+web-1     web.deployment.booksapp.linkerd-managed.old.svc.cluster.local         mismatch
+
+booksapp/web-1 (expected identity web.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local):
+  * trust domain linkerd-managed.old.svc.cluster.local doesn't match the control plane's linkerd-managed.linkerd.svc.cluster.local
+`
+
+	var buf bytes.Buffer
+	mismatches := renderIdentities(&buf, identities, false)
+	if mismatches != 1 {
+		t.Fatalf("Expected 1 mismatch, got %d", mismatches)
+	}
+	if buf.String() != expected {
+		t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expected, buf.String())
+	}
+}
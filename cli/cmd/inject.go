@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -20,6 +21,7 @@ import (
 	k8sMeta "k8s.io/apimachinery/pkg/api/meta"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
@@ -46,6 +48,12 @@ type injectOptions struct {
 	outboundPort        uint
 	ignoreInboundPorts  []uint
 	ignoreOutboundPorts []uint
+	networkPolicy       bool
+	// podTemplatePaths are the paths of the pod templates embedded in custom
+	// resources, by kind, as "Kind=field.path" entries.
+	podTemplatePaths []string
+	// reportOutput is the format of the report printed to stderr.
+	reportOutput string
 	*proxyConfigOptions
 }
 
@@ -68,10 +76,44 @@ func newInjectOptions() *injectOptions {
 		outboundPort:        4140,
 		ignoreInboundPorts:  nil,
 		ignoreOutboundPorts: nil,
+		networkPolicy:       false,
+		podTemplatePaths:    nil,
+		reportOutput:        tableOutput,
 		proxyConfigOptions:  newProxyConfigOptions(),
 	}
 }
 
+func (options *injectOptions) validate() error {
+	if _, err := options.podTemplatePathsByKind(); err != nil {
+		return err
+	}
+	if err := validateOutput(options.reportOutput); err != nil {
+		return err
+	}
+	return options.proxyConfigOptions.validate()
+}
+
+// podTemplatePathsByKind parses the --pod-template-path entries into the path
+// of the pod template of each custom resource kind, as a list of fields.
+func (options *injectOptions) podTemplatePathsByKind() (map[string][]string, error) {
+	paths := map[string][]string{}
+	for _, entry := range options.podTemplatePaths {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid entry '%s' for --pod-template-path flag; expected Kind=field.path, e.g. Rollout=spec.template", entry)
+		}
+
+		fields := strings.Split(parts[1], ".")
+		for _, field := range fields {
+			if field == "" {
+				return nil, fmt.Errorf("Invalid path '%s' for --pod-template-path flag", parts[1])
+			}
+		}
+		paths[parts[0]] = fields
+	}
+	return paths, nil
+}
+
 func newCmdInject() *cobra.Command {
 	options := newInjectOptions()
 
@@ -84,6 +126,14 @@ You can use a config file from stdin by using the '-' argument
 with 'linkerd inject'. e.g. curl http://url.to/yml | linkerd inject -
 Also works with a folder containing resource files and other
 sub-folder. e.g. linkerd inject <folder> | kubectl apply -f -
+
+Custom resources that embed a pod template, such as Argo Rollouts, are
+injected when the path of their template is given with --pod-template-path.
+e.g. linkerd inject --pod-template-path Rollout=spec.template rollout.yml
+
+The report printed to stderr can be rendered as JSON or YAML with
+--report-output, so that it can be parsed without affecting the injected
+config printed to stdout.
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -111,6 +161,9 @@ sub-folder. e.g. linkerd inject <folder> | kubectl apply -f -
 	cmd.PersistentFlags().UintVar(&options.outboundPort, "outbound-port", options.outboundPort, "Proxy port to use for outbound traffic")
 	cmd.PersistentFlags().UintSliceVar(&options.ignoreInboundPorts, "skip-inbound-ports", options.ignoreInboundPorts, "Ports that should skip the proxy and send directly to the application")
 	cmd.PersistentFlags().UintSliceVar(&options.ignoreOutboundPorts, "skip-outbound-ports", options.ignoreOutboundPorts, "Outbound ports that should skip the proxy")
+	cmd.PersistentFlags().StringSliceVar(&options.podTemplatePaths, "pod-template-path", options.podTemplatePaths, "Path of the pod template embedded in the custom resources of a kind, as Kind=field.path, e.g. Rollout=spec.template; can be repeated")
+	cmd.PersistentFlags().BoolVar(&options.networkPolicy, "network-policy", options.networkPolicy, "Output a NetworkPolicy for each injected workload, except bare pods, that only allows traffic to and from meshed pods, DNS, and the ports and subnets that skip the proxy (requires Kubernetes 1.11 or later)")
+	cmd.PersistentFlags().StringVar(&options.reportOutput, "report-output", options.reportOutput, fmt.Sprintf("Format of the report printed to stderr. One of: %s", strings.Join(outputFormats, ", ")))
 	return cmd
 }
 
@@ -166,6 +219,7 @@ func injectObjectMeta(t *metaV1.ObjectMeta, k8sLabels map[string]string, options
 	}
 	t.Annotations[k8s.CreatedByAnnotation] = k8s.CreatedByAnnotationValue()
 	t.Annotations[k8s.ProxyVersionAnnotation] = options.linkerdVersion
+	t.Annotations[k8s.ProxyConfigHashAnnotation] = options.configHash()
 
 	if t.Labels == nil {
 		t.Labels = make(map[string]string)
@@ -180,7 +234,7 @@ func injectObjectMeta(t *metaV1.ObjectMeta, k8sLabels map[string]string, options
  * and init-container injected. If the pod is unsuitable for having them
  * injected, return false.
  */
-func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameOverride string, options *injectOptions, report *injectReport) bool {
+func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameOverride, detectTimeout, opaquePorts string, proxyUID int64, options *injectOptions, report *injectReport) bool {
 	report.hostNetwork = t.HostNetwork
 	report.sidecar = checkSidecars(t)
 	report.udp = checkUDPPorts(t)
@@ -209,7 +263,7 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 	initArgs := []string{
 		"--incoming-proxy-port", fmt.Sprintf("%d", options.inboundPort),
 		"--outgoing-proxy-port", fmt.Sprintf("%d", options.outboundPort),
-		"--proxy-uid", fmt.Sprintf("%d", options.proxyUID),
+		"--proxy-uid", fmt.Sprintf("%d", proxyUID),
 	}
 
 	if len(inboundSkipPortsStr) > 0 {
@@ -222,6 +276,11 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		initArgs = append(initArgs, strings.Join(outboundSkipPortsStr, ","))
 	}
 
+	if len(options.ignoreOutboundSubnets) > 0 {
+		initArgs = append(initArgs, "--outbound-subnets-to-ignore")
+		initArgs = append(initArgs, strings.Join(options.ignoreOutboundSubnets, ","))
+	}
+
 	initContainer := v1.Container{
 		Name:                     k8s.InitContainerName,
 		Image:                    options.taggedProxyInitImage(),
@@ -272,7 +331,7 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		ImagePullPolicy:          v1.PullPolicy(options.imagePullPolicy),
 		TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
 		SecurityContext: &v1.SecurityContext{
-			RunAsUser: &options.proxyUID,
+			RunAsUser: &proxyUID,
 		},
 		Ports: []v1.ContainerPort{
 			{
@@ -305,6 +364,19 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		LivenessProbe:  &proxyProbe,
 	}
 
+	if opaquePorts != "" {
+		sidecar.Env = append(sidecar.Env,
+			v1.EnvVar{Name: "LINKERD2_PROXY_INBOUND_PORTS_DISABLE_PROTOCOL_DETECTION", Value: opaquePorts},
+			v1.EnvVar{Name: "LINKERD2_PROXY_OUTBOUND_PORTS_DISABLE_PROTOCOL_DETECTION", Value: opaquePorts},
+		)
+	}
+
+	if detectTimeout != "" {
+		sidecar.Env = append(sidecar.Env,
+			v1.EnvVar{Name: "LINKERD2_PROXY_DETECT_PROTOCOL_TIMEOUT", Value: detectTimeout},
+		)
+	}
+
 	// Special case if the caller specifies that
 	// LINKERD2_PROXY_OUTBOUND_ROUTER_CAPACITY be set on the pod.
 	// We key off of any container image in the pod. Ideally we would instead key
@@ -369,6 +441,13 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		t.Volumes = append(t.Volumes, configMapVolume, secretVolume)
 	}
 
+	// The proxy images only run on nodes of the architecture they're built
+	// for, which the control plane pods are constrained to as well.
+	if t.NodeSelector == nil {
+		t.NodeSelector = map[string]string{}
+	}
+	t.NodeSelector[k8s.NodeArchLabel] = options.nodeArchitecture()
+
 	t.Containers = append(t.Containers, sidecar)
 	t.InitContainers = append(t.InitContainers, initContainer)
 
@@ -404,6 +483,9 @@ func InjectYAML(in io.Reader, out io.Writer, report io.Writer, options *injectOp
 		injectReports = append(injectReports, ir)
 	}
 
+	if isStructuredOutput(options.reportOutput) {
+		return writeInjectReport(injectReports, report, options.reportOutput)
+	}
 	generateReport(injectReports, report)
 
 	return nil
@@ -423,15 +505,28 @@ func injectList(b []byte, options *injectOptions, report *injectReport) ([]byte,
 			return nil, err
 		}
 
-		// At this point, we have yaml. The kubernetes internal representation is
-		// json. Because we're building a list from RawExtensions, the yaml needs
-		// to be converted to json.
-		injected, err := yaml.YAMLToJSON(result)
-		if err != nil {
-			return nil, err
-		}
+		// The result holds several objects when a NetworkPolicy is generated
+		// for the injected workload.
+		reader := yamlDecoder.NewYAMLReader(bufio.NewReader(bytes.NewReader(result)))
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			// At this point, we have yaml. The kubernetes internal representation is
+			// json. Because we're building a list from RawExtensions, the yaml needs
+			// to be converted to json.
+			injected, err := yaml.YAMLToJSON(doc)
+			if err != nil {
+				return nil, err
+			}
 
-		items = append(items, runtime.RawExtension{Raw: injected})
+			items = append(items, runtime.RawExtension{Raw: injected})
+		}
 	}
 
 	sourceList.Items = items
@@ -572,6 +667,24 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 		// TODO: generate an injectReport per list item
 		return injectList(bytes, options, report)
 
+	default:
+		paths, err := options.podTemplatePathsByKind()
+		if err != nil {
+			return nil, err
+		}
+		path, ok := paths[meta.Kind]
+		if !ok {
+			break
+		}
+
+		workload, err := newCustomWorkload(bytes, path)
+		if err != nil {
+			return nil, err
+		}
+
+		obj = workload
+		podSpec = &workload.template.Spec
+		objectMeta = &workload.template.ObjectMeta
 	}
 
 	// If we don't inject anything into the pod template then output the
@@ -594,13 +707,41 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 			ControllerNamespace: controlPlaneNamespace,
 		}
 
-		if injectPodSpec(podSpec, identity, DNSNameOverride, options, report) {
+		if err := validateProxyAnnotations(objectMeta); err != nil {
+			return nil, err
+		}
+
+		detectTimeout, err := proxyDetectTimeout(objectMeta, options)
+		if err != nil {
+			return nil, err
+		}
+
+		uid, err := proxyUID(objectMeta, options)
+		if err != nil {
+			return nil, err
+		}
+
+		opaquePorts, err := proxyOpaquePorts(objectMeta, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if injectPodSpec(podSpec, identity, DNSNameOverride, detectTimeout, opaquePorts, uid, options, report) {
 			injectObjectMeta(objectMeta, k8sLabels, options)
 			var err error
 			output, err = yaml.Marshal(obj)
 			if err != nil {
 				return nil, err
 			}
+
+			// Bare pods have no label identifying them to select them with.
+			if options.networkPolicy && len(k8sLabels) > 0 {
+				policy, err := yaml.Marshal(proxyNetworkPolicy(metaAccessor.GetName(), strings.ToLower(meta.Kind), metaAccessor.GetNamespace(), k8sLabels, options))
+				if err != nil {
+					return nil, err
+				}
+				output = append(append(output, []byte("---\n")...), policy...)
+			}
 		}
 	} else {
 		report.unsupportedResource = true
@@ -609,6 +750,132 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	return output, nil
 }
 
+// customWorkload is a custom resource that embeds a pod template at path,
+// e.g. an Argo Rollout. The template is decoded so that it can be injected like
+// those of the built-in workloads, and is written back when the resource is
+// marshaled.
+type customWorkload struct {
+	unstructured.Unstructured
+	path     []string
+	template v1.PodTemplateSpec
+}
+
+func newCustomWorkload(bytes []byte, path []string) (*customWorkload, error) {
+	jsonBytes, err := yaml.YAMLToJSON(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	workload := &customWorkload{path: path}
+	if err := workload.UnmarshalJSON(jsonBytes); err != nil {
+		return nil, err
+	}
+
+	template, found, err := unstructured.NestedMap(workload.Object, path...)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s/%s has no pod template at %s", workload.GetKind(), workload.GetName(), strings.Join(path, "."))
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, &workload.template); err != nil {
+		return nil, fmt.Errorf("Invalid pod template at %s in %s/%s: %s", strings.Join(path, "."), workload.GetKind(), workload.GetName(), err)
+	}
+
+	return workload, nil
+}
+
+// MarshalJSON marshals the custom resource with its injected pod template.
+func (w *customWorkload) MarshalJSON() ([]byte, error) {
+	template, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&w.template)
+	if err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(w.Object, template, w.path...); err != nil {
+		return nil, err
+	}
+	return w.Unstructured.MarshalJSON()
+}
+
+// validateProxyAnnotations returns an error if the workload's pod template
+// has a Linkerd annotation that isn't recognized, so that a typo doesn't
+// silently leave the workload with the default configuration.
+func validateProxyAnnotations(objectMeta *metaV1.ObjectMeta) error {
+	for annotation := range objectMeta.Annotations {
+		if !strings.HasPrefix(annotation, k8s.AnnotationPrefix) {
+			continue
+		}
+
+		known := annotation == k8s.CreatedByAnnotation || annotation == k8s.ProxyVersionAnnotation || annotation == k8s.ProxyConfigHashAnnotation
+		for _, configAnnotation := range k8s.ProxyConfigAnnotations {
+			if annotation == configAnnotation {
+				known = true
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("Unknown annotation '%s'; valid proxy configuration annotations are: %s", annotation, strings.Join(k8s.ProxyConfigAnnotations, ", "))
+		}
+	}
+	return nil
+}
+
+// proxyDetectTimeout returns the protocol detection timeout to configure on
+// the proxy, preferring the value of the workload's
+// ProxyDetectProtocolTimeoutAnnotation over the command line default.
+func proxyDetectTimeout(objectMeta *metaV1.ObjectMeta, options *injectOptions) (string, error) {
+	timeout, ok := objectMeta.Annotations[k8s.ProxyDetectProtocolTimeoutAnnotation]
+	if !ok {
+		return options.proxyDetectTimeout, nil
+	}
+
+	if _, err := time.ParseDuration(timeout); err != nil {
+		return "", fmt.Errorf("Invalid duration '%s' for %s annotation", timeout, k8s.ProxyDetectProtocolTimeoutAnnotation)
+	}
+	return timeout, nil
+}
+
+// proxyUID returns the user ID the proxy runs as, preferring the value of the
+// workload's ProxyUIDAnnotation over the command line default. The init
+// container exempts traffic owned by this user from redirection, so both must
+// agree.
+func proxyUID(objectMeta *metaV1.ObjectMeta, options *injectOptions) (int64, error) {
+	uid, ok := objectMeta.Annotations[k8s.ProxyUIDAnnotation]
+	if !ok {
+		return options.proxyUID, nil
+	}
+
+	parsed, err := strconv.ParseInt(uid, 10, 64)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("Invalid user ID '%s' for %s annotation", uid, k8s.ProxyUIDAnnotation)
+	}
+	return parsed, nil
+}
+
+// proxyOpaquePorts returns the comma-separated list of ports on which the
+// proxy skips protocol detection, preferring the value of the workload's
+// ProxyOpaquePortsAnnotation over the command line default.
+func proxyOpaquePorts(objectMeta *metaV1.ObjectMeta, options *injectOptions) (string, error) {
+	ports, ok := objectMeta.Annotations[k8s.ProxyOpaquePortsAnnotation]
+	if !ok {
+		portsStr := make([]string, len(options.opaquePorts))
+		for i, p := range options.opaquePorts {
+			portsStr[i] = strconv.Itoa(int(p))
+		}
+		return strings.Join(portsStr, ","), nil
+	}
+
+	if ports == "" {
+		return "", nil
+	}
+	for _, port := range strings.Split(ports, ",") {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return "", fmt.Errorf("Invalid port '%s' for %s annotation", port, k8s.ProxyOpaquePortsAnnotation)
+		}
+	}
+	return ports, nil
+}
+
 // walk walks the file tree rooted at path. path may be a file or a directory.
 // Creates a reader for each file found.
 func walk(path string) ([]io.Reader, error) {
@@ -652,31 +919,102 @@ func walk(path string) ([]io.Reader, error) {
 	return in, nil
 }
 
-func generateReport(injectReports []injectReport, output io.Writer) {
+// injectSummary lists the resources of the inject reports that were
+// injected, and those that triggered each warning.
+type injectSummary struct {
+	injected    []string
+	hostNetwork []string
+	sidecar     []string
+	udp         []string
+}
 
-	injected := []string{}
-	hostNetwork := []string{}
-	sidecar := []string{}
-	udp := []string{}
+func summarizeInjectReports(injectReports []injectReport) *injectSummary {
+	summary := &injectSummary{
+		injected:    []string{},
+		hostNetwork: []string{},
+		sidecar:     []string{},
+		udp:         []string{},
+	}
 
 	for _, r := range injectReports {
 		if !r.hostNetwork && !r.sidecar && !r.unsupportedResource {
-			injected = append(injected, r.name)
+			summary.injected = append(summary.injected, r.name)
 		}
 
 		if r.hostNetwork {
-			hostNetwork = append(hostNetwork, r.name)
+			summary.hostNetwork = append(summary.hostNetwork, r.name)
 		}
 
 		if r.sidecar {
-			sidecar = append(sidecar, r.name)
+			summary.sidecar = append(summary.sidecar, r.name)
 		}
 
 		if r.udp {
-			udp = append(udp, r.name)
+			summary.udp = append(summary.udp, r.name)
 		}
 	}
 
+	return summary
+}
+
+// injectReportJSON is the document printed by "inject --report-output json"
+// and "inject --report-output yaml".
+type injectReportJSON struct {
+	Documents int                      `json:"documents"`
+	Injected  []string                 `json:"injected"`
+	Checks    []*injectCheckResultJSON `json:"checks"`
+}
+
+type injectCheckResultJSON struct {
+	Description string   `json:"description"`
+	Result      string   `json:"result"`
+	Resources   []string `json:"resources,omitempty"`
+}
+
+func newInjectCheckResult(description string, resources []string) *injectCheckResultJSON {
+	result := &injectCheckResultJSON{Description: description, Result: "ok"}
+	if len(resources) > 0 {
+		result.Result = "warning"
+		result.Resources = resources
+	}
+	return result
+}
+
+// writeInjectReport writes the inject reports to output in the given format.
+func writeInjectReport(injectReports []injectReport, output io.Writer, format string) error {
+	summary := summarizeInjectReports(injectReports)
+
+	supported := newInjectCheckResult(unsupportedDesc, nil)
+	if len(summary.injected) == 0 {
+		supported.Result = "warning"
+	}
+
+	report := &injectReportJSON{
+		Documents: len(injectReports),
+		Injected:  summary.injected,
+		Checks: []*injectCheckResultJSON{
+			newInjectCheckResult(hostNetworkDesc, summary.hostNetwork),
+			newInjectCheckResult(sidecarDesc, summary.sidecar),
+			supported,
+			newInjectCheckResult(udpDesc, summary.udp),
+		},
+	}
+
+	rendered, err := marshalOutput(report, format)
+	if err != nil {
+		return err
+	}
+	_, err = output.Write([]byte(rendered))
+	return err
+}
+
+func generateReport(injectReports []injectReport, output io.Writer) {
+	summary := summarizeInjectReports(injectReports)
+	injected := summary.injected
+	hostNetwork := summary.hostNetwork
+	sidecar := summary.sidecar
+	udp := summary.udp
+
 	//
 	// Warnings
 	//
@@ -720,8 +1058,8 @@ func generateReport(injectReports []injectReport, output io.Writer) {
 	// Summary
 	//
 
-	summary := fmt.Sprintf("Summary: %d of %d YAML document(s) injected", len(injected), len(injectReports))
-	output.Write([]byte(fmt.Sprintf("\n%s\n", summary)))
+	summaryLine := fmt.Sprintf("Summary: %d of %d YAML document(s) injected", len(injected), len(injectReports))
+	output.Write([]byte(fmt.Sprintf("\n%s\n", summaryLine)))
 
 	for _, i := range injected {
 		output.Write([]byte(fmt.Sprintf("  %s\n", i)))
@@ -8,7 +8,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestInjectYAML(t *testing.T) {
@@ -24,6 +30,14 @@ func TestInjectYAML(t *testing.T) {
 	proxyRequestOptions.proxyCpuRequest = "110m"
 	proxyRequestOptions.proxyMemoryRequest = "100Mi"
 
+	skipSubnetsOptions := newInjectOptions()
+	skipSubnetsOptions.linkerdVersion = "testinjectversion"
+	skipSubnetsOptions.ignoreOutboundSubnets = []string{"169.254.169.254/32", "10.0.0.0/8"}
+
+	archOptions := newInjectOptions()
+	archOptions.linkerdVersion = "testinjectversion"
+	archOptions.imageArch = "arm64"
+
 	testCases := []struct {
 		inputFileName     string
 		goldenFileName    string
@@ -78,6 +92,24 @@ func TestInjectYAML(t *testing.T) {
 			reportFileName:    "inject_emojivoto_pod_with_requests.report",
 			testInjectOptions: proxyRequestOptions,
 		},
+		{
+			inputFileName:     "inject_emojivoto_pod_with_detect_timeout.input.yml",
+			goldenFileName:    "inject_emojivoto_pod_with_detect_timeout.golden.yml",
+			reportFileName:    "inject_emojivoto_pod_with_detect_timeout.report",
+			testInjectOptions: defaultOptions,
+		},
+		{
+			inputFileName:     "inject_emojivoto_pod_with_opaque_ports.input.yml",
+			goldenFileName:    "inject_emojivoto_pod_with_opaque_ports.golden.yml",
+			reportFileName:    "inject_emojivoto_pod_with_opaque_ports.report",
+			testInjectOptions: defaultOptions,
+		},
+		{
+			inputFileName:     "inject_emojivoto_pod_with_proxy_uid.input.yml",
+			goldenFileName:    "inject_emojivoto_pod_with_proxy_uid.golden.yml",
+			reportFileName:    "inject_emojivoto_pod_with_proxy_uid.report",
+			testInjectOptions: defaultOptions,
+		},
 		{
 			inputFileName:     "inject_emojivoto_deployment.input.yml",
 			goldenFileName:    "inject_emojivoto_deployment_tls.golden.yml",
@@ -90,6 +122,18 @@ func TestInjectYAML(t *testing.T) {
 			reportFileName:    "inject_emojivoto_pod.report",
 			testInjectOptions: tlsOptions,
 		},
+		{
+			inputFileName:     "inject_emojivoto_deployment.input.yml",
+			goldenFileName:    "inject_emojivoto_deployment_skip_subnets.golden.yml",
+			reportFileName:    "inject_emojivoto_deployment.report",
+			testInjectOptions: skipSubnetsOptions,
+		},
+		{
+			inputFileName:     "inject_emojivoto_deployment.input.yml",
+			goldenFileName:    "inject_emojivoto_deployment_arch.golden.yml",
+			reportFileName:    "inject_emojivoto_deployment.report",
+			testInjectOptions: archOptions,
+		},
 		{
 			inputFileName:     "inject_emojivoto_deployment_udp.input.yml",
 			goldenFileName:    "inject_emojivoto_deployment_udp.golden.yml",
@@ -148,6 +192,150 @@ func TestInjectYAML(t *testing.T) {
 	}
 }
 
+func TestInjectCustomResource(t *testing.T) {
+	input, err := ioutil.ReadFile("testdata/inject_rollout.input.yml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("Injects the pod template at the configured path", func(t *testing.T) {
+		options := newInjectOptions()
+		options.podTemplatePaths = []string{"Rollout=spec.template"}
+
+		output := new(bytes.Buffer)
+		if err := InjectYAML(bytes.NewReader(input), output, ioutil.Discard, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var rollout map[string]interface{}
+		if err := yaml.Unmarshal(bytes.TrimSuffix(output.Bytes(), []byte("---\n")), &rollout); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		steps, _, _ := unstructured.NestedSlice(rollout, "spec", "strategy", "canary", "steps")
+		if len(steps) != 2 {
+			t.Fatalf("Expected the rollout strategy to be kept, got %v", rollout["spec"])
+		}
+
+		containers, _, _ := unstructured.NestedSlice(rollout, "spec", "template", "spec", "containers")
+		names := []string{}
+		for _, container := range containers {
+			names = append(names, container.(map[string]interface{})["name"].(string))
+		}
+		if !reflect.DeepEqual(names, []string{"web-svc", k8s.ProxyContainerName}) {
+			t.Fatalf("Expected the proxy to be added to the pod template, got containers %v", names)
+		}
+
+		initContainers, _, _ := unstructured.NestedSlice(rollout, "spec", "template", "spec", "initContainers")
+		if len(initContainers) != 1 {
+			t.Fatalf("Expected the init container to be added to the pod template, got %v", initContainers)
+		}
+
+		if _, ok, _ := unstructured.NestedString(rollout, "spec", "template", "metadata", "annotations", k8s.CreatedByAnnotation); !ok {
+			t.Fatalf("Expected the pod template to be annotated, got %v", rollout["spec"])
+		}
+	})
+
+	t.Run("Outputs custom resources of other kinds as is", func(t *testing.T) {
+		output := new(bytes.Buffer)
+		if err := InjectYAML(bytes.NewReader(input), output, ioutil.Discard, newInjectOptions()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output.String() != string(input)+"---\n" {
+			t.Fatalf("Expected the rollout to be output as is, got:\n%s", output.String())
+		}
+	})
+
+	t.Run("Returns an error if there's no pod template at the path", func(t *testing.T) {
+		options := newInjectOptions()
+		options.podTemplatePaths = []string{"Rollout=spec.podTemplate"}
+
+		err := InjectYAML(bytes.NewReader(input), ioutil.Discard, ioutil.Discard, options)
+		if err == nil || err.Error() != "Rollout/web has no pod template at spec.podTemplate" {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Rejects malformed paths", func(t *testing.T) {
+		for _, entry := range []string{"Rollout", "Rollout=", "=spec.template", "Rollout=spec..template"} {
+			options := newInjectOptions()
+			options.podTemplatePaths = []string{entry}
+			if err := options.validate(); err == nil {
+				t.Fatalf("Expected error for %s, got nothing", entry)
+			}
+		}
+	})
+}
+
+func TestInjectReportOutput(t *testing.T) {
+	input, err := ioutil.ReadFile("testdata/inject_emojivoto_deployment_udp.input.yml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedReports := map[string]string{
+		"json": `{
+  "documents": 1,
+  "injected": [
+    "deployment/web"
+  ],
+  "checks": [
+    {
+      "description": "hostNetwork: pods do not use host networking",
+      "result": "ok"
+    },
+    {
+      "description": "sidecar: pods do not have a proxy or initContainer already injected",
+      "result": "ok"
+    },
+    {
+      "description": "supported: at least one resource injected",
+      "result": "ok"
+    },
+    {
+      "description": "udp: pod specs do not include UDP ports",
+      "result": "warning",
+      "resources": [
+        "deployment/web"
+      ]
+    }
+  ]
+}
+`,
+		"yaml": `checks:
+- description: 'hostNetwork: pods do not use host networking'
+  result: ok
+- description: 'sidecar: pods do not have a proxy or initContainer already injected'
+  result: ok
+- description: 'supported: at least one resource injected'
+  result: ok
+- description: 'udp: pod specs do not include UDP ports'
+  resources:
+  - deployment/web
+  result: warning
+documents: 1
+injected:
+- deployment/web
+`,
+	}
+
+	for format, expectedReport := range expectedReports {
+		t.Run(format, func(t *testing.T) {
+			options := newInjectOptions()
+			options.reportOutput = format
+
+			report := new(bytes.Buffer)
+			if err := InjectYAML(bytes.NewReader(input), ioutil.Discard, report, options); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if report.String() != expectedReport {
+				t.Fatalf("Expected report:\n%s\nbut got:\n%s", expectedReport, report.String())
+			}
+		})
+	}
+}
+
 func TestRunInjectCmd(t *testing.T) {
 	testInjectOptions := newInjectOptions()
 	testInjectOptions.linkerdVersion = "testinjectversion"
@@ -317,3 +505,63 @@ func TestWalk(t *testing.T) {
 		}
 	}
 }
+
+func TestProxyConfigAnnotations(t *testing.T) {
+	options := newInjectOptions()
+
+	t.Run("Accepts valid annotations", func(t *testing.T) {
+		objectMeta := &metaV1.ObjectMeta{Annotations: map[string]string{
+			k8s.CreatedByAnnotation:                  "linkerd/cli edge-18.10.2",
+			k8s.ProxyDetectProtocolTimeoutAnnotation: "500ms",
+			k8s.ProxyOpaquePortsAnnotation:           "3306,5432",
+			k8s.ProxyUIDAnnotation:                   "1000650000",
+			"prometheus.io/scrape":                   "true",
+		}}
+
+		if err := validateProxyAnnotations(objectMeta); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := proxyDetectTimeout(objectMeta, options); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := proxyOpaquePorts(objectMeta, options); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := proxyUID(objectMeta, options); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Rejects unknown annotations", func(t *testing.T) {
+		objectMeta := &metaV1.ObjectMeta{Annotations: map[string]string{
+			"linkerd.io/proxy-detect-timeout": "500ms",
+		}}
+
+		err := validateProxyAnnotations(objectMeta)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "Unknown annotation 'linkerd.io/proxy-detect-timeout'; valid proxy configuration annotations are: linkerd.io/proxy-detect-protocol-timeout, linkerd.io/opaque-ports, linkerd.io/proxy-uid"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Rejects malformed annotation values", func(t *testing.T) {
+		objectMeta := &metaV1.ObjectMeta{Annotations: map[string]string{
+			k8s.ProxyDetectProtocolTimeoutAnnotation: "500",
+			k8s.ProxyOpaquePortsAnnotation:           "3306;5432",
+			k8s.ProxyUIDAnnotation:                   "proxy",
+		}}
+
+		if _, err := proxyDetectTimeout(objectMeta, options); err == nil {
+			t.Fatal("Expected error for detect timeout, got nothing")
+		}
+		if _, err := proxyOpaquePorts(objectMeta, options); err == nil {
+			t.Fatal("Expected error for opaque ports, got nothing")
+		}
+		if _, err := proxyUID(objectMeta, options); err == nil {
+			t.Fatal("Expected error for proxy UID, got nothing")
+		}
+	})
+}
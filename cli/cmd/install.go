@@ -1,18 +1,27 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/linkerd/linkerd2/cli/install"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
 )
 
 type installConfig struct {
@@ -25,34 +34,95 @@ type installConfig struct {
 	WebReplicas                 uint
 	PrometheusReplicas          uint
 	ImagePullPolicy             string
+	NodeArchitectures           []string
+	NodeArchLabel               string
 	UUID                        string
 	CliVersion                  string
 	ControllerLogLevel          string
+	ControllerLogFormat         string
+	ControllerTraceCollector    string
 	ControllerComponentLabel    string
 	CreatedByAnnotation         string
 	ProxyAPIPort                uint
 	EnableTLS                   bool
 	TLSTrustAnchorConfigMapName string
 	ProxyContainerName          string
+	DisableTelemetry            bool
+	DashboardReadOnly           bool
+	IdentityIssuerSecret        string
+	TapMaxRps                   uint
+	TapMaxPods                  uint
+	TapMaxDuration              string
+	ProxyConfigHash             string
+	OpenShiftUIDRange           string
+	AlertGroups                 map[string]bool
 }
 
 type installOptions struct {
-	controllerReplicas uint
-	webReplicas        uint
-	prometheusReplicas uint
-	controllerLogLevel string
+	controllerReplicas       uint
+	webReplicas              uint
+	prometheusReplicas       uint
+	controllerLogLevel       string
+	controllerLogFormat      string
+	controllerTraceCollector string
+	disableTelemetry         bool
+	dashboardReadOnly        bool
+	issuerSecret             string
+	tapMaxRps                uint
+	tapMaxPods               uint
+	tapMaxDuration           time.Duration
+	openshift                bool
+	openshiftUIDRange        string
+	alertGroups              []string
+	disableAlerts            bool
+	rbacOnly                 bool
+	skipRBAC                 bool
 	*proxyConfigOptions
 }
 
 const prometheusProxyOutboundCapacity = 10000
 
+// controlPlaneAdminPorts are the admin ports of the control plane components
+// (see pkg/admin). Their inbound traffic skips the proxy, so the admin servers
+// see the callers' own addresses; through the proxy every request would come
+// from localhost, and /log-level would accept changes from anywhere.
+var controlPlaneAdminPorts = []uint{9994, 9995, 9996, 9997, 9998, 9999}
+
+// alertGroups are the groups of Prometheus alerting rules that can be
+// installed along with the control plane.
+var alertGroups = []string{"control-plane", "certificates", "success-rate", "proxy-restarts"}
+
+// rbacKinds are the kinds of the resources that grant the control plane its
+// privileges in the cluster.
+var rbacKinds = map[string]bool{
+	"ServiceAccount":     true,
+	"Role":               true,
+	"ClusterRole":        true,
+	"RoleBinding":        true,
+	"ClusterRoleBinding": true,
+}
+
 func newInstallOptions() *installOptions {
 	return &installOptions{
-		controllerReplicas: 1,
-		webReplicas:        1,
-		prometheusReplicas: 1,
-		controllerLogLevel: "info",
-		proxyConfigOptions: newProxyConfigOptions(),
+		controllerReplicas:       1,
+		webReplicas:              1,
+		prometheusReplicas:       1,
+		controllerLogLevel:       "info",
+		controllerLogFormat:      "plain",
+		controllerTraceCollector: "",
+		disableTelemetry:         false,
+		dashboardReadOnly:        false,
+		issuerSecret:             "",
+		tapMaxRps:                1000,
+		tapMaxPods:               100,
+		tapMaxDuration:           time.Hour,
+		openshift:                false,
+		openshiftUIDRange:        "2100/100",
+		alertGroups:              alertGroups,
+		disableAlerts:            false,
+		rbacOnly:                 false,
+		skipRBAC:                 false,
+		proxyConfigOptions:       newProxyConfigOptions(),
 	}
 }
 
@@ -62,54 +132,126 @@ func newCmdInstall() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install [flags]",
 		Short: "Output Kubernetes configs to install Linkerd",
-		Long:  "Output Kubernetes configs to install Linkerd.",
+		Long: `Output Kubernetes configs to install Linkerd.
+
+The RBAC resources of the control plane, i.e. its ServiceAccounts, Roles,
+ClusterRoles and their bindings, can be output on their own with "--rbac-only",
+along with the control plane namespace, so that they can be reviewed and applied
+separately from the rest of the configs, which are output with "--skip-rbac".`,
+		Example: `  # Install the control plane.
+  linkerd install | kubectl apply -f -
+
+  # Install the RBAC resources first, and then the rest of the control plane.
+  linkerd install --rbac-only | kubectl apply -f -
+  linkerd install --skip-rbac | kubectl apply -f -
+
+  # Install the control plane with the Prometheus alerting rules about the
+  # control plane and its certificates only.
+  linkerd install --alert-groups control-plane,certificates | kubectl apply -f -
+
+  # Install the control plane on OpenShift.
+  linkerd install --openshift | oc apply -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config, err := validateAndBuildConfig(options)
 			if err != nil {
 				return err
 			}
 
-			return render(*config, os.Stdout, options)
+			if !options.rbacOnly && !options.skipRBAC {
+				return render(*config, os.Stdout, options)
+			}
+
+			buf := &bytes.Buffer{}
+			if err := render(*config, buf, options); err != nil {
+				return err
+			}
+			return filterResources(buf, os.Stdout, options.keepResource)
 		},
 	}
 
+	addInstallFlags(cmd, options)
+	cmd.PersistentFlags().BoolVar(&options.rbacOnly, "rbac-only", options.rbacOnly, "Only output the control plane namespace and the ServiceAccounts, Roles, ClusterRoles and bindings the control plane requires")
+	cmd.PersistentFlags().BoolVar(&options.skipRBAC, "skip-rbac", options.skipRBAC, "Output all the control plane configs except the ServiceAccounts, Roles, ClusterRoles and bindings output by \"--rbac-only\"")
+
+	return cmd
+}
+
+// addInstallFlags adds the flags that configure the rendered control plane,
+// shared by the commands that render it.
+func addInstallFlags(cmd *cobra.Command, options *installOptions) {
 	addProxyConfigFlags(cmd, options.proxyConfigOptions)
 	cmd.PersistentFlags().UintVar(&options.controllerReplicas, "controller-replicas", options.controllerReplicas, "Replicas of the controller to deploy")
 	cmd.PersistentFlags().UintVar(&options.webReplicas, "web-replicas", options.webReplicas, "Replicas of the web server to deploy")
 	cmd.PersistentFlags().UintVar(&options.prometheusReplicas, "prometheus-replicas", options.prometheusReplicas, "Replicas of prometheus to deploy")
 	cmd.PersistentFlags().StringVar(&options.controllerLogLevel, "controller-log-level", options.controllerLogLevel, "Log level for the controller and web components")
-
-	return cmd
+	cmd.PersistentFlags().StringVar(&options.controllerLogFormat, "controller-log-format", options.controllerLogFormat, "Log format for the controller and web components, one of: plain, json")
+	cmd.PersistentFlags().StringVar(&options.controllerTraceCollector, "controller-trace-collector", options.controllerTraceCollector, "Address (host:port) of the Zipkin receiver of the collector the public API and destination components send their spans to, e.g. collector.linkerd-jaeger:9411 (default: tracing disabled)")
+	cmd.PersistentFlags().BoolVar(&options.disableTelemetry, "disable-telemetry", options.disableTelemetry, "Disable outbound version checks from the dashboard and \"linkerd check\", for air-gapped and privacy-sensitive installs")
+	cmd.PersistentFlags().BoolVar(&options.dashboardReadOnly, "dashboard-read-only", options.dashboardReadOnly, "Disable tap, top and service profile generation in the dashboard, so that it can be exposed to users who shouldn't observe live traffic")
+	cmd.PersistentFlags().StringVar(&options.issuerSecret, "identity-issuer-secret", options.issuerSecret, "Name of an existing kubernetes.io/tls secret in the control plane namespace with the certificate and private key of the CA issuing the proxies' certificates, instead of generating a CA on startup (requires --tls=optional)")
+	cmd.PersistentFlags().UintVar(&options.tapMaxRps, "tap-max-rps", options.tapMaxRps, "Maximum total requests per second observed by a tap session, across all the tapped pods (0 for no limit)")
+	cmd.PersistentFlags().UintVar(&options.tapMaxPods, "tap-max-pods", options.tapMaxPods, "Maximum number of pods observed by a tap session; larger targets, such as namespaces, are sampled at random (0 for no limit)")
+	cmd.PersistentFlags().DurationVar(&options.tapMaxDuration, "tap-max-duration", options.tapMaxDuration, "Maximum duration of a tap session (0 for no limit)")
+	cmd.PersistentFlags().BoolVar(&options.openshift, "openshift", options.openshift, "Annotate the control plane namespace with the --openshift-uid-range, which must hold the proxy UID. This is the only OpenShift support: the proxy init container still needs the NET_ADMIN capability, so grant the service accounts of the meshed pods an SCC that allows it; no Routes are created; and linkerd check --pre has no OpenShift checks")
+	cmd.PersistentFlags().StringVar(&options.openshiftUIDRange, "openshift-uid-range", options.openshiftUIDRange, "UID range of the control plane namespace with --openshift, as <first UID>/<size>; OpenShift runs the control plane containers as the first UID of the range, and the proxy UID must be another UID within it")
+	cmd.PersistentFlags().StringSliceVar(&options.alertGroups, "alert-groups", options.alertGroups, fmt.Sprintf("Groups of Prometheus alerting rules to install, among: %s", strings.Join(alertGroups, ", ")))
+	cmd.PersistentFlags().BoolVar(&options.disableAlerts, "disable-alerts", options.disableAlerts, "Don't install any Prometheus alerting rules")
 }
 
 func validateAndBuildConfig(options *installOptions) (*installConfig, error) {
 	if err := validate(options); err != nil {
 		return nil, err
 	}
+
+	openshiftUIDRange := ""
+	if options.openshift {
+		openshiftUIDRange = options.openshiftUIDRange
+	}
+
+	enabledAlertGroups := map[string]bool{}
+	if !options.disableAlerts {
+		for _, group := range options.alertGroups {
+			enabledAlertGroups[group] = true
+		}
+	}
+
 	return &installConfig{
 		Namespace:                   controlPlaneNamespace,
-		ControllerImage:             fmt.Sprintf("%s/controller:%s", options.dockerRegistry, options.linkerdVersion),
-		WebImage:                    fmt.Sprintf("%s/web:%s", options.dockerRegistry, options.linkerdVersion),
+		ControllerImage:             fmt.Sprintf("%s/controller:%s", options.dockerRegistry, options.imageTag()),
+		WebImage:                    fmt.Sprintf("%s/web:%s", options.dockerRegistry, options.imageTag()),
 		PrometheusImage:             "prom/prometheus:v2.4.0",
-		GrafanaImage:                fmt.Sprintf("%s/grafana:%s", options.dockerRegistry, options.linkerdVersion),
+		GrafanaImage:                fmt.Sprintf("%s/grafana:%s", options.dockerRegistry, options.imageTag()),
 		ControllerReplicas:          options.controllerReplicas,
 		WebReplicas:                 options.webReplicas,
 		PrometheusReplicas:          options.prometheusReplicas,
 		ImagePullPolicy:             options.imagePullPolicy,
+		NodeArchitectures:           options.nodeArchitectures(),
+		NodeArchLabel:               k8s.NodeArchLabel,
 		UUID:                        uuid.NewV4().String(),
 		CliVersion:                  k8s.CreatedByAnnotationValue(),
 		ControllerLogLevel:          options.controllerLogLevel,
+		ControllerLogFormat:         options.controllerLogFormat,
+		ControllerTraceCollector:    options.controllerTraceCollector,
 		ControllerComponentLabel:    k8s.ControllerComponentLabel,
 		CreatedByAnnotation:         k8s.CreatedByAnnotation,
 		ProxyAPIPort:                options.proxyAPIPort,
 		EnableTLS:                   options.enableTLS(),
 		TLSTrustAnchorConfigMapName: k8s.TLSTrustAnchorConfigMapName,
 		ProxyContainerName:          k8s.ProxyContainerName,
+		DisableTelemetry:            options.disableTelemetry,
+		DashboardReadOnly:           options.dashboardReadOnly,
+		IdentityIssuerSecret:        options.issuerSecret,
+		TapMaxRps:                   options.tapMaxRps,
+		TapMaxPods:                  options.tapMaxPods,
+		TapMaxDuration:              options.tapMaxDuration.String(),
+		ProxyConfigHash:             options.configHash(),
+		OpenShiftUIDRange:           openshiftUIDRange,
+		AlertGroups:                 enabledAlertGroups,
 	}, nil
 }
 
 func render(config installConfig, w io.Writer, options *installOptions) error {
-	template, err := template.New("linkerd").Parse(install.Template)
+	template, err := template.New("linkerd").Parse(install.Template + install.NodeAffinityTemplate)
 	if err != nil {
 		return err
 	}
@@ -119,7 +261,7 @@ func render(config installConfig, w io.Writer, options *installOptions) error {
 		return err
 	}
 	if config.EnableTLS {
-		tlsTemplate, err := template.New("linkerd").Parse(install.TlsTemplate)
+		tlsTemplate, err := template.New("linkerd").Parse(install.TlsTemplate + install.NodeAffinityTemplate)
 		if err != nil {
 			return err
 		}
@@ -130,6 +272,7 @@ func render(config installConfig, w io.Writer, options *installOptions) error {
 	}
 	injectOptions := newInjectOptions()
 	injectOptions.proxyConfigOptions = options.proxyConfigOptions
+	injectOptions.ignoreInboundPorts = controlPlaneAdminPorts
 
 	// Special case for linkerd-proxy running in the Prometheus pod.
 	injectOptions.proxyOutboundCapacity[config.PrometheusImage] = prometheusProxyOutboundCapacity
@@ -141,5 +284,128 @@ func validate(options *installOptions) error {
 	if _, err := log.ParseLevel(options.controllerLogLevel); err != nil {
 		return fmt.Errorf("--controller-log-level must be one of: panic, fatal, error, warn, info, debug")
 	}
+	if options.controllerLogFormat != "plain" && options.controllerLogFormat != "json" {
+		return fmt.Errorf("--controller-log-format must be one of: plain, json")
+	}
+	if options.controllerTraceCollector != "" {
+		if _, _, err := net.SplitHostPort(options.controllerTraceCollector); err != nil {
+			return fmt.Errorf("Invalid address '%s' for --controller-trace-collector flag; expected host:port", options.controllerTraceCollector)
+		}
+	}
+	if options.issuerSecret != "" {
+		if !options.enableTLS() {
+			return fmt.Errorf("--identity-issuer-secret requires --tls=%s", optionalTLS)
+		}
+		if errs := validation.IsDNS1123Subdomain(options.issuerSecret); len(errs) > 0 {
+			return fmt.Errorf("Invalid secret name '%s' for --identity-issuer-secret flag: %s", options.issuerSecret, strings.Join(errs, ", "))
+		}
+	}
+	if options.tapMaxDuration < 0 {
+		return fmt.Errorf("--tap-max-duration must not be negative")
+	}
+	if options.rbacOnly && options.skipRBAC {
+		return fmt.Errorf("--rbac-only and --skip-rbac flags are mutually exclusive")
+	}
+	for _, group := range options.alertGroups {
+		if !isAlertGroup(group) {
+			return fmt.Errorf("Invalid group '%s' for --alert-groups flag; expected one of: %s", group, strings.Join(alertGroups, ", "))
+		}
+	}
+	if options.openshift {
+		if err := validateOpenShiftUIDRange(options.openshiftUIDRange, options.proxyUID); err != nil {
+			return err
+		}
+	}
 	return options.validate()
 }
+
+// validateOpenShiftUIDRange returns an error if uidRange isn't an OpenShift
+// UID range, i.e. <first UID>/<size>, or if the proxy UID isn't in the range.
+// OpenShift runs containers that don't set a user as the first UID of their
+// namespace's range, so the proxy can't use it: the other containers' traffic
+// would skip the proxy like the proxy's own traffic does.
+func validateOpenShiftUIDRange(uidRange string, proxyUID int64) error {
+	parts := strings.Split(uidRange, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("Invalid range '%s' for --openshift-uid-range flag; expected <first UID>/<size>", uidRange)
+	}
+	first, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || first <= 0 {
+		return fmt.Errorf("Invalid first UID '%s' for --openshift-uid-range flag", parts[0])
+	}
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || size < 2 {
+		return fmt.Errorf("Invalid size '%s' for --openshift-uid-range flag; the range must hold at least 2 UIDs", parts[1])
+	}
+
+	if proxyUID <= first || proxyUID >= first+size {
+		return fmt.Errorf("The proxy UID %d must be within the --openshift-uid-range %s, other than its first UID %d, which OpenShift runs the other containers as", proxyUID, uidRange, first)
+	}
+	return nil
+}
+
+func isAlertGroup(name string) bool {
+	for _, group := range alertGroups {
+		if name == group {
+			return true
+		}
+	}
+	return false
+}
+
+// keepResource returns true if resources of the given kind should be output
+// with the --rbac-only or --skip-rbac flags. The namespace is output in both
+// cases, so that either set of configs can be applied first.
+func (options *installOptions) keepResource(kind string) bool {
+	if kind == "Namespace" {
+		return true
+	}
+	if options.rbacOnly {
+		return rbacKinds[kind]
+	}
+	return !rbacKinds[kind]
+}
+
+// filterResources copies the resources in a stream of YAML configs from in to
+// w if keep returns true for their kind. The "### ... ###" comment introducing
+// a resource trails the previous resource in the stream, so it's moved along
+// with the resource it introduces.
+func filterResources(in io.Reader, w io.Writer, keep func(kind string) bool) error {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(in, 4096))
+	header := []string{}
+
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		var meta metaV1.TypeMeta
+		if err := yaml.Unmarshal(doc, &meta); err != nil {
+			return err
+		}
+
+		lines := strings.Split(strings.TrimRight(string(doc), "\n"), "\n")
+		end := len(lines)
+		for end > 0 && (strings.HasPrefix(lines[end-1], "#") || strings.TrimSpace(lines[end-1]) == "") {
+			end--
+		}
+
+		if meta.Kind != "" && keep(meta.Kind) {
+			body := append(header, lines[:end]...)
+			fmt.Fprintf(w, "%s\n---\n", strings.Join(body, "\n"))
+		}
+
+		header = []string{}
+		for _, line := range lines[end:] {
+			if strings.HasPrefix(line, "#") {
+				header = append(header, line)
+			}
+		}
+	}
+
+	return nil
+}
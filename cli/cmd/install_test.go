@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -30,15 +32,25 @@ func TestRender(t *testing.T) {
 		WebReplicas:                 2,
 		PrometheusReplicas:          3,
 		ImagePullPolicy:             "ImagePullPolicy",
+		NodeArchitectures:           []string{"NodeArchitecture"},
+		NodeArchLabel:               "NodeArchLabel",
 		UUID:                        "UUID",
 		CliVersion:                  "CliVersion",
 		ControllerLogLevel:          "ControllerLogLevel",
+		ControllerLogFormat:         "ControllerLogFormat",
 		ControllerComponentLabel:    "ControllerComponentLabel",
 		CreatedByAnnotation:         "CreatedByAnnotation",
 		ProxyAPIPort:                123,
 		EnableTLS:                   true,
 		TLSTrustAnchorConfigMapName: "TLSTrustAnchorConfigMapName",
 		ProxyContainerName:          "ProxyContainerName",
+		DisableTelemetry:            true,
+		DashboardReadOnly:           true,
+		IdentityIssuerSecret:        "IdentityIssuerSecret",
+		TapMaxRps:                   4,
+		TapMaxPods:                  5,
+		TapMaxDuration:              "TapMaxDuration",
+		ProxyConfigHash:             "ProxyConfigHash",
 	}
 
 	testCases := []struct {
@@ -70,3 +82,305 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIssuerSecret(t *testing.T) {
+	testCases := []struct {
+		tls           string
+		issuerSecret  string
+		expectedError string
+	}{
+		{"", "", ""},
+		{optionalTLS, "linkerd-issuer", ""},
+		{"", "linkerd-issuer", "--identity-issuer-secret requires --tls=optional"},
+		{optionalTLS, "Linkerd_Issuer", "Invalid secret name 'Linkerd_Issuer' for --identity-issuer-secret flag"},
+	}
+
+	for _, tc := range testCases {
+		options := newInstallOptions()
+		options.tls = tc.tls
+		options.issuerSecret = tc.issuerSecret
+
+		err := validate(options)
+		if tc.expectedError == "" {
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			continue
+		}
+		if err == nil || !strings.HasPrefix(err.Error(), tc.expectedError) {
+			t.Fatalf("Expected error [%s], got [%v]", tc.expectedError, err)
+		}
+	}
+}
+
+func TestValidateLogFormat(t *testing.T) {
+	for _, format := range []string{"plain", "json"} {
+		options := newInstallOptions()
+		options.controllerLogFormat = format
+		if err := validate(options); err != nil {
+			t.Fatalf("Unexpected error for log format %s: %s", format, err)
+		}
+	}
+
+	options := newInstallOptions()
+	options.controllerLogFormat = "xml"
+	expectedError := "--controller-log-format must be one of: plain, json"
+	if err := validate(options); err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error [%s], got [%v]", expectedError, err)
+	}
+}
+
+func TestRenderTraceCollector(t *testing.T) {
+	options := newInstallOptions()
+	options.controllerTraceCollector = "collector.linkerd-jaeger"
+	expectedError := "Invalid address 'collector.linkerd-jaeger' for --controller-trace-collector flag; expected host:port"
+	if err := validate(options); err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error [%s], got [%v]", expectedError, err)
+	}
+
+	options.controllerTraceCollector = "collector.linkerd-jaeger:9411"
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render(*config, &buf, options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the public API and destination components export their spans
+	if count := strings.Count(buf.String(), "-trace-collector=collector.linkerd-jaeger:9411"); count != 2 {
+		t.Fatalf("Expected the trace collector to be passed to 2 components, got %d", count)
+	}
+}
+
+func TestRenderHA(t *testing.T) {
+	options := newInstallOptions()
+	options.tls = optionalTLS
+
+	renderReplicas := func(replicas uint) string {
+		options.controllerReplicas = replicas
+		config, err := validateAndBuildConfig(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := render(*config, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+
+	// the controller and ca deployments get a PodDisruptionBudget and prefer
+	// to spread their replicas across nodes
+	output := renderReplicas(3)
+	if count := strings.Count(output, "kind: PodDisruptionBudget"); count != 2 {
+		t.Fatalf("Expected 2 PodDisruptionBudgets, got %d", count)
+	}
+	if count := strings.Count(output, "podAntiAffinity:"); count != 2 {
+		t.Fatalf("Expected 2 deployments with a podAntiAffinity, got %d", count)
+	}
+
+	output = renderReplicas(1)
+	if strings.Contains(output, "kind: PodDisruptionBudget") || strings.Contains(output, "podAntiAffinity:") {
+		t.Fatal("Expected a single replica control plane to have no PodDisruptionBudgets nor podAntiAffinity")
+	}
+}
+
+func TestRenderSkipsAdminPorts(t *testing.T) {
+	options := newInstallOptions()
+	options.tls = optionalTLS
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := render(*config, &buf, options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// every meshed control plane pod must route its admin port around the
+	// proxy, otherwise the admin server sees all requests coming from localhost
+	adminPort := regexp.MustCompile(`containerPort: (\d+)\s+name: admin-http`)
+	skipPorts := regexp.MustCompile(`--inbound-ports-to-ignore\s+- ([\d,]+)`)
+	meshed := 0
+	for _, doc := range strings.Split(buf.String(), "\n---\n") {
+		if !strings.Contains(doc, "name: linkerd-init") {
+			continue
+		}
+		meshed++
+		skip := skipPorts.FindStringSubmatch(doc)
+		if skip == nil {
+			t.Fatalf("Expected linkerd-init to skip inbound ports in:\n%s", doc)
+		}
+		skipped := map[string]bool{}
+		for _, port := range strings.Split(skip[1], ",") {
+			skipped[port] = true
+		}
+		for _, port := range adminPort.FindAllStringSubmatch(doc, -1) {
+			if port[1] == "9090" {
+				// Prometheus' own API, not a control plane admin server
+				continue
+			}
+			if !skipped[port[1]] {
+				t.Errorf("Expected admin port %s to skip the proxy, got %s", port[1], skip[1])
+			}
+		}
+	}
+	if meshed != 5 {
+		t.Fatalf("Expected 5 meshed deployments, got %d", meshed)
+	}
+}
+
+func TestRenderOpenShift(t *testing.T) {
+	options := newInstallOptions()
+	options.openshift = true
+
+	for uidRange, expectedError := range map[string]string{
+		"2102":      "Invalid range '2102' for --openshift-uid-range flag; expected <first UID>/<size>",
+		"2102/1":    "Invalid size '1' for --openshift-uid-range flag; the range must hold at least 2 UIDs",
+		"2102/100":  "The proxy UID 2102 must be within the --openshift-uid-range 2102/100, other than its first UID 2102, which OpenShift runs the other containers as",
+		"1000/1000": "The proxy UID 2102 must be within the --openshift-uid-range 1000/1000, other than its first UID 1000, which OpenShift runs the other containers as",
+	} {
+		options.openshiftUIDRange = uidRange
+		if err := validate(options); err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s], got [%v]", expectedError, err)
+		}
+	}
+
+	options.openshiftUIDRange = "2100/100"
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render(*config, &buf, options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the control plane namespace is assigned the UID range
+	expected := `  annotations:
+    openshift.io/sa.scc.uid-range: "2100/100"
+    openshift.io/sa.scc.supplemental-groups: "2100/100"
+`
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("Expected the namespace to be annotated with the UID range, got:\n%s", buf.String())
+	}
+}
+
+func TestRenderAlertGroups(t *testing.T) {
+	renderOptions := func(options *installOptions) string {
+		config, err := validateAndBuildConfig(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := render(*config, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+
+	t.Run("Renders the enabled groups only", func(t *testing.T) {
+		options := newInstallOptions()
+		options.alertGroups = []string{"certificates", "proxy-restarts"}
+		output := renderOptions(options)
+
+		for _, expected := range []string{"rule_files:", "- name: linkerd-certificates\n", "- name: linkerd-proxy-restarts\n"} {
+			if !strings.Contains(output, expected) {
+				t.Fatalf("Expected the output to contain %q", expected)
+			}
+		}
+		for _, unexpected := range []string{"linkerd-control-plane\n", "linkerd-success-rate\n"} {
+			if strings.Contains(output, unexpected) {
+				t.Fatalf("Expected the output not to contain %q", unexpected)
+			}
+		}
+	})
+
+	t.Run("Renders no rules with --disable-alerts", func(t *testing.T) {
+		options := newInstallOptions()
+		options.disableAlerts = true
+		output := renderOptions(options)
+
+		if strings.Contains(output, "rule_files:") || strings.Contains(output, "alerting_rules.yml: |-") {
+			t.Fatal("Expected the output not to contain alerting rules")
+		}
+	})
+
+	t.Run("Rejects unknown groups", func(t *testing.T) {
+		options := newInstallOptions()
+		options.alertGroups = []string{"control-plane", "latency"}
+		expectedError := "Invalid group 'latency' for --alert-groups flag; expected one of: control-plane, certificates, success-rate, proxy-restarts"
+		if err := validate(options); err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s], got [%v]", expectedError, err)
+		}
+	})
+}
+
+func TestFilterResources(t *testing.T) {
+	goldenFileBytes, err := ioutil.ReadFile("testdata/install_default.golden")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	all, err := renderedResources(bytes.NewReader(goldenFileBytes))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rbacOnly := newInstallOptions()
+	rbacOnly.rbacOnly = true
+	skipRBAC := newInstallOptions()
+	skipRBAC.skipRBAC = true
+
+	outputs := make(map[string]string)
+	for name, options := range map[string]*installOptions{"rbac-only": rbacOnly, "skip-rbac": skipRBAC} {
+		var buf bytes.Buffer
+		if err := filterResources(bytes.NewReader(goldenFileBytes), &buf, options.keepResource); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		outputs[name] = buf.String()
+	}
+
+	t.Run("Splits the resources between the RBAC and the other configs", func(t *testing.T) {
+		rbac, err := renderedResources(strings.NewReader(outputs["rbac-only"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		other, err := renderedResources(strings.NewReader(outputs["skip-rbac"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		namespace := resourceKey("Namespace", controlPlaneNamespace)
+		for key := range all {
+			kind := strings.Split(key, "/")[0]
+			isRBAC := kind == "serviceaccount" || strings.HasSuffix(kind, "role") || strings.HasSuffix(kind, "rolebinding")
+
+			if rbac[key] != (isRBAC || key == namespace) {
+				t.Errorf("Unexpected presence of %s in the --rbac-only output: %t", key, rbac[key])
+			}
+			if other[key] != (!isRBAC || key == namespace) {
+				t.Errorf("Unexpected presence of %s in the --skip-rbac output: %t", key, other[key])
+			}
+		}
+		if len(rbac)+len(other) != len(all)+1 {
+			t.Fatalf("Expected %d resources in total but got %d", len(all)+1, len(rbac)+len(other))
+		}
+	})
+
+	t.Run("Keeps the comment introducing each resource", func(t *testing.T) {
+		if !strings.HasPrefix(outputs["rbac-only"], "### Namespace ###\nkind: Namespace\n") {
+			t.Fatalf("Expected the namespace first in the output:\n%s", outputs["rbac-only"])
+		}
+		expected := "---\n### Service Account Controller ###\nkind: ServiceAccount\n"
+		if !strings.Contains(outputs["rbac-only"], expected) {
+			t.Fatalf("Expected output to contain %q:\n%s", expected, outputs["rbac-only"])
+		}
+		if strings.Contains(outputs["skip-rbac"], "### Service Account Controller ###") {
+			t.Fatalf("Unexpected service account comment in the --skip-rbac output:\n%s", outputs["skip-rbac"])
+		}
+	})
+}
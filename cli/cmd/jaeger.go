@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/linkerd/linkerd2/cli/install"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultJaegerNamespace = "linkerd-jaeger"
+
+	// jaegerUIPort is the port Jaeger serves its UI on.
+	jaegerUIPort = 16686
+)
+
+type jaegerOptions struct {
+	namespace string
+}
+
+type jaegerInstallOptions struct {
+	collectorImage  string
+	jaegerImage     string
+	imagePullPolicy string
+	*jaegerOptions
+}
+
+type jaegerDashboardOptions struct {
+	port int
+	show string
+	*jaegerOptions
+}
+
+type jaegerConfig struct {
+	Namespace           string
+	CollectorImage      string
+	JaegerImage         string
+	ImagePullPolicy     string
+	UIPort              int
+	ExtensionLabel      string
+	ComponentLabel      string
+	CreatedByAnnotation string
+	CliVersion          string
+}
+
+func newJaegerOptions() *jaegerOptions {
+	return &jaegerOptions{
+		namespace: defaultJaegerNamespace,
+	}
+}
+
+func newCmdJaeger() *cobra.Command {
+	options := newJaegerOptions()
+
+	cmd := &cobra.Command{
+		Use:   "jaeger",
+		Short: "Manage the jaeger extension for distributed tracing",
+		Long: `Manage the jaeger extension for distributed tracing.
+
+The jaeger extension deploys Jaeger along with an OpenCensus collector that
+receives the spans emitted by the proxies, in a namespace of its own.`,
+		Example: `  # Install the jaeger extension.
+  linkerd jaeger install | kubectl apply -f -
+
+  # Check that the jaeger extension is up and running.
+  linkerd jaeger check
+
+  # Open the Jaeger UI.
+  linkerd jaeger dashboard`,
+	}
+
+	cmd.PersistentFlags().StringVar(&options.namespace, "jaeger-namespace", options.namespace, "Namespace in which the jaeger extension is installed")
+
+	cmd.AddCommand(newCmdJaegerInstall(options))
+	cmd.AddCommand(newCmdJaegerCheck(options))
+	cmd.AddCommand(newCmdJaegerDashboard(options))
+
+	return cmd
+}
+
+func newCmdJaegerInstall(jaegerOptions *jaegerOptions) *cobra.Command {
+	options := &jaegerInstallOptions{
+		collectorImage:  "omnition/opencensus-collector:0.1.10",
+		jaegerImage:     "jaegertracing/all-in-one:1.8",
+		imagePullPolicy: "IfNotPresent",
+		jaegerOptions:   jaegerOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "install [flags]",
+		Short: "Output Kubernetes configs to install the jaeger extension",
+		Long:  "Output Kubernetes configs to install the jaeger extension.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := buildJaegerConfig(options)
+			if err != nil {
+				return err
+			}
+
+			return renderJaeger(*config, os.Stdout)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.collectorImage, "collector-image", options.collectorImage, "OpenCensus collector container image")
+	cmd.PersistentFlags().StringVar(&options.jaegerImage, "jaeger-image", options.jaegerImage, "Jaeger container image")
+	cmd.PersistentFlags().StringVar(&options.imagePullPolicy, "image-pull-policy", options.imagePullPolicy, "Docker image pull policy")
+
+	return cmd
+}
+
+func buildJaegerConfig(options *jaegerInstallOptions) (*jaegerConfig, error) {
+	if !alphaNumDash.MatchString(options.namespace) {
+		return nil, fmt.Errorf("%s is not a valid namespace", options.namespace)
+	}
+	if options.imagePullPolicy != "Always" && options.imagePullPolicy != "IfNotPresent" && options.imagePullPolicy != "Never" {
+		return nil, fmt.Errorf("--image-pull-policy must be one of: Always, IfNotPresent, Never")
+	}
+
+	return &jaegerConfig{
+		Namespace:           options.namespace,
+		CollectorImage:      options.collectorImage,
+		JaegerImage:         options.jaegerImage,
+		ImagePullPolicy:     options.imagePullPolicy,
+		UIPort:              jaegerUIPort,
+		ExtensionLabel:      k8s.ExtensionLabel,
+		ComponentLabel:      k8s.ControllerComponentLabel,
+		CreatedByAnnotation: k8s.CreatedByAnnotation,
+		CliVersion:          k8s.CreatedByAnnotationValue(),
+	}, nil
+}
+
+func renderJaeger(config jaegerConfig, w io.Writer) error {
+	template, err := template.New("jaeger").Parse(install.JaegerTemplate)
+	if err != nil {
+		return err
+	}
+	return template.Execute(w, config)
+}
+
+func newCmdJaegerCheck(jaegerOptions *jaegerOptions) *cobra.Command {
+	wait := 300 * time.Second
+
+	cmd := &cobra.Command{
+		Use:   "check [flags]",
+		Short: "Check the jaeger extension for potential problems",
+		Long:  "Check the jaeger extension for potential problems.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			hc, err := healthcheck.NewHealthChecker(
+				[]healthcheck.Checks{healthcheck.KubernetesAPIChecks, healthcheck.LinkerdJaegerChecks},
+				&healthcheck.HealthCheckOptions{
+					ControlPlaneNamespace: controlPlaneNamespace,
+					JaegerNamespace:       jaegerOptions.namespace,
+					KubeConfig:            kubeconfigPath,
+					KubeContext:           kubeContext,
+					RetryDeadline:         time.Now().Add(wait),
+				},
+			)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to configure the health checks: %s\n", err)
+				os.Exit(checkFailureExitCode)
+			}
+
+			ctx, cancel := interruptContext()
+			defer cancel()
+
+			exitWithCheckStatus(runChecks(ctx, os.Stdout, hc))
+		},
+	}
+
+	cmd.PersistentFlags().DurationVar(&wait, "wait", wait, "Retry and wait for some checks to succeed if they don't pass the first time")
+
+	return cmd
+}
+
+func newCmdJaegerDashboard(jaegerOptions *jaegerOptions) *cobra.Command {
+	options := &jaegerDashboardOptions{
+		port:          0,
+		show:          "jaeger",
+		jaegerOptions: jaegerOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "dashboard [flags]",
+		Short: "Open the Jaeger UI in a web browser",
+		Long:  "Open the Jaeger UI in a web browser.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.port < 0 {
+				return fmt.Errorf("port must be greater than or equal to zero, was %d", options.port)
+			}
+			if options.show != "jaeger" && options.show != showURL {
+				return fmt.Errorf("unknown value for 'show' param, was: %s, must be one of: jaeger, %s", options.show, showURL)
+			}
+
+			kubernetesProxy, err := k8s.NewProxy(kubeconfigPath, kubeContext, options.port)
+			if err != nil {
+				return fmt.Errorf("Failed to initialize proxy: %s", err)
+			}
+
+			url, err := kubernetesProxy.URLFor(options.namespace, "/services/jaeger:ui/proxy/")
+			if err != nil {
+				return fmt.Errorf("Failed to generate URL for the Jaeger UI: %s", err)
+			}
+
+			fmt.Printf("Jaeger UI available at:\n%s\n", url.String())
+
+			if options.show == "jaeger" {
+				fmt.Println("Opening Jaeger UI in the default browser")
+
+				if err := browser.OpenURL(url.String()); err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to open Jaeger URL %s in the default browser: %s\n", url, err)
+				}
+			}
+
+			// blocks until killed
+			if err := kubernetesProxy.Run(); err != nil {
+				return fmt.Errorf("Error running proxy: %s", err)
+			}
+			return nil
+		},
+	}
+
+	// This is identical to what `kubectl proxy --help` reports, `--port 0` indicates a random port.
+	cmd.PersistentFlags().IntVarP(&options.port, "port", "p", options.port, "The port on which to run the proxy (when set to 0, a random port will be used)")
+	cmd.PersistentFlags().StringVar(&options.show, "show", options.show, "Open the Jaeger UI in a browser or show its URL in the CLI (one of: jaeger, url)")
+
+	return cmd
+}
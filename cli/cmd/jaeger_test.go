@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestRenderJaeger(t *testing.T) {
+	options := &jaegerInstallOptions{
+		collectorImage:  "omnition/opencensus-collector:0.1.10",
+		jaegerImage:     "jaegertracing/all-in-one:1.8",
+		imagePullPolicy: "IfNotPresent",
+		jaegerOptions:   newJaegerOptions(),
+	}
+
+	config, err := buildJaegerConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	config.CliVersion = "CliVersion"
+
+	var buf bytes.Buffer
+	err = renderJaeger(*config, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	goldenFileBytes, err := ioutil.ReadFile("testdata/install_jaeger.golden")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	diffCompare(t, buf.String(), string(goldenFileBytes))
+}
+
+func TestBuildJaegerConfig(t *testing.T) {
+	t.Run("Returns an error for an invalid image pull policy", func(t *testing.T) {
+		options := &jaegerInstallOptions{
+			imagePullPolicy: "Sometimes",
+			jaegerOptions:   newJaegerOptions(),
+		}
+
+		_, err := buildJaegerConfig(options)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "--image-pull-policy must be one of: Always, IfNotPresent, Never"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/spf13/cobra"
+)
+
+// latencyBarWidth is the width of the bar of the fullest bucket in the
+// textual distribution.
+const latencyBarWidth = 40
+
+type latencyOptions struct {
+	namespace   string
+	timeWindow  string
+	toNamespace string
+	toResource  string
+	output      string
+}
+
+// latencyBucketJSON is a histogram bucket as exported by "-o json" and
+// "-o yaml". The upper bound of the last bucket is infinite, which JSON can't
+// represent, so it's null.
+type latencyBucketJSON struct {
+	LowerBoundMs float64  `json:"lowerBoundMs"`
+	UpperBoundMs *float64 `json:"upperBoundMs"`
+	Count        uint64   `json:"count"`
+}
+
+type latencyDistributionJSON struct {
+	Namespace  string              `json:"namespace,omitempty"`
+	Resource   string              `json:"resource"`
+	ToResource string              `json:"toResource,omitempty"`
+	TimeWindow string              `json:"timeWindow"`
+	TotalCount uint64              `json:"totalCount"`
+	Buckets    []latencyBucketJSON `json:"buckets"`
+}
+
+func newLatencyOptions() *latencyOptions {
+	return &latencyOptions{
+		namespace:   "default",
+		timeWindow:  "10m",
+		toNamespace: "",
+		toResource:  "",
+		output:      tableOutput,
+	}
+}
+
+func newCmdLatency() *cobra.Command {
+	options := newLatencyOptions()
+
+	cmd := &cobra.Command{
+		Use:   "latency [flags] (RESOURCE)",
+		Short: "Display the latency distribution of a resource's requests",
+		Long: `Display the latency distribution of a resource's requests.
+
+  The RESOURCE argument specifies the resource whose inbound requests are
+  measured, or whose outbound requests are measured if "--to" is given:
+  (TYPE [NAME] | TYPE/NAME)
+
+The count of requests in each bucket of the proxies' latency histogram is
+displayed, from the fastest to the slowest non-empty bucket. Unlike the
+percentiles displayed by "linkerd stat", the full distribution shows whether
+slow requests form their own mode, e.g. cache misses or retries, or are the
+tail of a uniformly slow distribution.
+
+With "-o json", the buckets are exported as JSON instead, for further analysis.`,
+		Example: `  # Display the latency distribution of the requests to the web deployment.
+  linkerd latency deploy/web -n emojivoto
+
+  # Display the latency distribution of the requests from the web deployment
+  # to the emoji-svc service over the last hour.
+  linkerd latency deploy/web -n emojivoto --to svc/emoji-svc -t 1h
+
+  # Export the latency distribution of the requests to the emojivoto namespace.
+  linkerd latency ns/emojivoto -o json`,
+		Args:      cobra.RangeArgs(1, 2),
+		ValidArgs: util.ValidTargets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := buildLatencyDistributionRequest(args, options)
+			if err != nil {
+				return err
+			}
+
+			output, err := requestLatencyDistributionFromAPI(validatedPublicAPIClient(time.Time{}), req, options.output)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Print(output)
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Window over which requests are counted (for example: \"1m\", \"10m\", \"1h\")")
+	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, displays the latency of the outbound requests to the specified resource")
+	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
+	addOutputFlag(cmd, &options.output)
+
+	addNamespaceCompletion(cmd)
+
+	return cmd
+}
+
+func buildLatencyDistributionRequest(args []string, options *latencyOptions) (*pb.LatencyDistributionRequest, error) {
+	if err := validateOutput(options.output); err != nil {
+		return nil, err
+	}
+
+	if _, err := time.ParseDuration(options.timeWindow); err != nil {
+		return nil, fmt.Errorf("invalid time window \"%s\": %s", options.timeWindow, err)
+	}
+
+	resource, err := util.BuildResource(options.namespace, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.LatencyDistributionRequest{
+		Resource:   &resource,
+		TimeWindow: options.timeWindow,
+	}
+
+	if options.toResource != "" {
+		toNamespace := options.toNamespace
+		if toNamespace == "" {
+			toNamespace = options.namespace
+		}
+
+		toResource, err := util.BuildResource(toNamespace, options.toResource)
+		if err != nil {
+			return nil, err
+		}
+		req.ToResource = &toResource
+	}
+
+	return req, nil
+}
+
+func requestLatencyDistributionFromAPI(client pb.ApiClient, req *pb.LatencyDistributionRequest, output string) (string, error) {
+	rsp, err := client.LatencyDistribution(context.Background(), req)
+	if err != nil {
+		return "", fmt.Errorf("LatencyDistribution API error: %v", err)
+	}
+	if e := rsp.GetError(); e != nil {
+		return "", fmt.Errorf("LatencyDistribution API response error: %v", e.Error)
+	}
+
+	buckets := rsp.GetOk().GetBuckets()
+	if isStructuredOutput(output) {
+		return renderLatencyDistributionJSON(req, buckets, output)
+	}
+	return renderLatencyDistribution(buckets), nil
+}
+
+// renderLatencyDistribution returns a table of the histogram's buckets, with a
+// bar proportional to each bucket's count. The empty buckets before the first
+// and after the last request are left out, but the ones in between are kept
+// so that gaps between modes stand out.
+func renderLatencyDistribution(buckets []*pb.LatencyBucket) string {
+	first, last := -1, -1
+	total := uint64(0)
+	max := uint64(0)
+	for i, bucket := range buckets {
+		if bucket.Count == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+		total += bucket.Count
+		if bucket.Count > max {
+			max = bucket.Count
+		}
+	}
+	if total == 0 {
+		return "No requests found.\n"
+	}
+
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(w, "LATENCY\tCOUNT\tPERCENT\tCUMULATIVE")
+
+	cumulative := uint64(0)
+	for i := first; i <= last; i++ {
+		bucket := buckets[i]
+		cumulative += bucket.Count
+
+		lower := 0.0
+		if i > 0 {
+			lower = buckets[i-1].UpperBoundMs
+		}
+
+		bar := strings.Repeat("#", int(math.Ceil(float64(bucket.Count)*latencyBarWidth/float64(max))))
+		fmt.Fprintf(w, "%s\t%d\t%.2f%%\t%.2f%%\t%s\n",
+			latencyBucketRange(lower, bucket.UpperBoundMs),
+			bucket.Count,
+			100*float64(bucket.Count)/float64(total),
+			100*float64(cumulative)/float64(total),
+			bar,
+		)
+	}
+	w.Flush()
+
+	// empty buckets have no bar, which leaves trailing padding
+	lines := strings.Split(buffer.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// latencyBucketRange formats the range of latencies of a bucket, e.g.
+// "10ms - 20ms".
+func latencyBucketRange(lower, upper float64) string {
+	if math.IsInf(upper, 1) {
+		return fmt.Sprintf("> %s", formatLatencyMs(lower))
+	}
+	return fmt.Sprintf("%s - %s", formatLatencyMs(lower), formatLatencyMs(upper))
+}
+
+func formatLatencyMs(ms float64) string {
+	return strconv.FormatFloat(ms, 'f', -1, 64) + "ms"
+}
+
+func renderLatencyDistributionJSON(req *pb.LatencyDistributionRequest, buckets []*pb.LatencyBucket, output string) (string, error) {
+	distribution := latencyDistributionJSON{
+		Namespace:  req.GetResource().GetNamespace(),
+		Resource:   resourceString(req.GetResource()),
+		TimeWindow: req.GetTimeWindow(),
+		Buckets:    make([]latencyBucketJSON, len(buckets)),
+	}
+	if req.GetToResource() != nil {
+		distribution.ToResource = resourceString(req.GetToResource())
+	}
+
+	lower := 0.0
+	for i, bucket := range buckets {
+		distribution.Buckets[i] = latencyBucketJSON{LowerBoundMs: lower, Count: bucket.Count}
+		if !math.IsInf(bucket.UpperBoundMs, 1) {
+			upper := bucket.UpperBoundMs
+			distribution.Buckets[i].UpperBoundMs = &upper
+		}
+		distribution.TotalCount += bucket.Count
+		lower = bucket.UpperBoundMs
+	}
+
+	return marshalOutput(distribution, output)
+}
+
+// resourceString formats a resource as "TYPE/NAME", or "TYPE" if it's unnamed.
+func resourceString(resource *pb.Resource) string {
+	if resource.GetName() == "" {
+		return resource.GetType()
+	}
+	return fmt.Sprintf("%s/%s", resource.GetType(), resource.GetName())
+}
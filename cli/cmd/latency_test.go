@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestLatency(t *testing.T) {
+	newResponse := func(buckets []*pb.LatencyBucket) *pb.LatencyDistributionResponse {
+		return &pb.LatencyDistributionResponse{
+			Response: &pb.LatencyDistributionResponse_Ok_{
+				Ok: &pb.LatencyDistributionResponse_Ok{Buckets: buckets},
+			},
+		}
+	}
+
+	t.Run("Renders the distribution between the first and last non-empty buckets", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			LatencyDistributionResponseToReturn: newResponse([]*pb.LatencyBucket{
+				{UpperBoundMs: 1, Count: 0},
+				{UpperBoundMs: 5, Count: 120},
+				{UpperBoundMs: 10, Count: 30},
+				{UpperBoundMs: 50, Count: 0},
+				{UpperBoundMs: 100, Count: 40},
+				{UpperBoundMs: 500, Count: 10},
+				{UpperBoundMs: 1000, Count: 0},
+				{UpperBoundMs: math.Inf(1), Count: 0},
+			}),
+		}
+
+		expectedOutput := `LATENCY         COUNT   PERCENT   CUMULATIVE
+1ms - 5ms       120     60.00%    60.00%    ########################################
+5ms - 10ms      30      15.00%    75.00%    ##########
+10ms - 50ms     0       0.00%     75.00%
+50ms - 100ms    40      20.00%    95.00%    ##############
+100ms - 500ms   10      5.00%     100.00%   ####
+`
+
+		req, err := buildLatencyDistributionRequest([]string{"deploy/web"}, newLatencyOptions())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestLatencyDistributionFromAPI(mockClient, req, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Renders the unbounded bucket", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			LatencyDistributionResponseToReturn: newResponse([]*pb.LatencyBucket{
+				{UpperBoundMs: 10, Count: 0},
+				{UpperBoundMs: math.Inf(1), Count: 5},
+			}),
+		}
+
+		expectedOutput := `LATENCY   COUNT   PERCENT   CUMULATIVE
+> 10ms    5       100.00%   100.00%   ########################################
+`
+
+		output, err := requestLatencyDistributionFromAPI(mockClient, &pb.LatencyDistributionRequest{}, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Reports the absence of requests", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			LatencyDistributionResponseToReturn: newResponse([]*pb.LatencyBucket{}),
+		}
+
+		output, err := requestLatencyDistributionFromAPI(mockClient, &pb.LatencyDistributionRequest{}, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != "No requests found.\n" {
+			t.Fatalf("Unexpected output: %s", output)
+		}
+	})
+
+	t.Run("Exports the distribution as JSON", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			LatencyDistributionResponseToReturn: newResponse([]*pb.LatencyBucket{
+				{UpperBoundMs: 0.5, Count: 3},
+				{UpperBoundMs: 10, Count: 1},
+				{UpperBoundMs: math.Inf(1), Count: 2},
+			}),
+		}
+
+		expectedOutput := `{
+  "namespace": "emojivoto",
+  "resource": "deployment/web",
+  "toResource": "service/emoji-svc",
+  "timeWindow": "10m",
+  "totalCount": 6,
+  "buckets": [
+    {
+      "lowerBoundMs": 0,
+      "upperBoundMs": 0.5,
+      "count": 3
+    },
+    {
+      "lowerBoundMs": 0.5,
+      "upperBoundMs": 10,
+      "count": 1
+    },
+    {
+      "lowerBoundMs": 10,
+      "upperBoundMs": null,
+      "count": 2
+    }
+  ]
+}
+`
+
+		options := newLatencyOptions()
+		options.namespace = "emojivoto"
+		options.toResource = "svc/emoji-svc"
+		options.output = "json"
+		req, err := buildLatencyDistributionRequest([]string{"deploy", "web"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestLatencyDistributionFromAPI(mockClient, req, options.output)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns an error for API response errors", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			LatencyDistributionResponseToReturn: &pb.LatencyDistributionResponse{
+				Response: &pb.LatencyDistributionResponse_Error{
+					Error: &pb.ResourceError{Error: "resource type 'all' is not supported"},
+				},
+			},
+		}
+
+		_, err := requestLatencyDistributionFromAPI(mockClient, &pb.LatencyDistributionRequest{}, "")
+		expected := "LatencyDistribution API response error: resource type 'all' is not supported"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
+
+func TestBuildLatencyDistributionRequest(t *testing.T) {
+	options := newLatencyOptions()
+	options.namespace = "emojivoto"
+	options.toResource = "svc/emoji-svc"
+	options.toNamespace = "backends"
+
+	req, err := buildLatencyDistributionRequest([]string{"deploy/web"}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &pb.LatencyDistributionRequest{
+		Resource:   &pb.Resource{Namespace: "emojivoto", Type: k8s.Deployment, Name: "web"},
+		ToResource: &pb.Resource{Namespace: "backends", Type: k8s.Service, Name: "emoji-svc"},
+		TimeWindow: "10m",
+	}
+	if !reflect.DeepEqual(req, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, req)
+	}
+
+	options.output = "wide"
+	_, err = buildLatencyDistributionRequest([]string{"deploy/web"}, options)
+	if err == nil {
+		t.Fatalf("Expected an error for an unsupported output format")
+	}
+
+	options.output = ""
+	options.timeWindow = "ten minutes"
+	_, err = buildLatencyDistributionRequest([]string{"deploy/web"}, options)
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid time window")
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const dnsPort = 53
+
+// proxyNetworkPolicy returns a NetworkPolicy that restricts the traffic of an
+// injected workload's pods, selected by podLabels, to the mesh. Ingress and
+// egress are allowed from and to meshed pods in any namespace, which includes
+// the control plane. Traffic between proxies is addressed to the application's
+// ports and redirected to the proxy inside the pod, so it can't be restricted
+// to the proxy's ports. Egress to DNS is allowed as well, as is traffic on the
+// ports and to the subnets that skip the proxy.
+//
+// Selecting meshed pods across namespaces requires Kubernetes 1.11 or later.
+func proxyNetworkPolicy(name, kind, namespace string, podLabels map[string]string, options *injectOptions) *networkingV1.NetworkPolicy {
+	meshedPods := networkingV1.NetworkPolicyPeer{
+		NamespaceSelector: &metaV1.LabelSelector{},
+		PodSelector: &metaV1.LabelSelector{
+			MatchExpressions: []metaV1.LabelSelectorRequirement{
+				{Key: k8s.ControllerNSLabel, Operator: metaV1.LabelSelectorOpExists},
+			},
+		},
+	}
+
+	ingress := []networkingV1.NetworkPolicyIngressRule{
+		{From: []networkingV1.NetworkPolicyPeer{meshedPods}},
+	}
+	if len(options.ignoreInboundPorts) > 0 {
+		ingress = append(ingress, networkingV1.NetworkPolicyIngressRule{
+			Ports: networkPolicyPorts(options.ignoreInboundPorts, v1.ProtocolTCP),
+		})
+	}
+
+	egress := []networkingV1.NetworkPolicyEgressRule{
+		{To: []networkingV1.NetworkPolicyPeer{meshedPods}},
+		{Ports: append(networkPolicyPorts([]uint{dnsPort}, v1.ProtocolUDP), networkPolicyPorts([]uint{dnsPort}, v1.ProtocolTCP)...)},
+	}
+	if len(options.ignoreOutboundPorts) > 0 {
+		egress = append(egress, networkingV1.NetworkPolicyEgressRule{
+			Ports: networkPolicyPorts(options.ignoreOutboundPorts, v1.ProtocolTCP),
+		})
+	}
+	if len(options.ignoreOutboundSubnets) > 0 {
+		subnets := []networkingV1.NetworkPolicyPeer{}
+		for _, subnet := range options.ignoreOutboundSubnets {
+			subnets = append(subnets, networkingV1.NetworkPolicyPeer{IPBlock: &networkingV1.IPBlock{CIDR: subnet}})
+		}
+		egress = append(egress, networkingV1.NetworkPolicyEgressRule{To: subnets})
+	}
+
+	return &networkingV1.NetworkPolicy{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-linkerd", name, kind),
+			Namespace: namespace,
+			Annotations: map[string]string{
+				k8s.CreatedByAnnotation: k8s.CreatedByAnnotationValue(),
+			},
+		},
+		Spec: networkingV1.NetworkPolicySpec{
+			PodSelector: metaV1.LabelSelector{MatchLabels: podLabels},
+			PolicyTypes: []networkingV1.PolicyType{networkingV1.PolicyTypeIngress, networkingV1.PolicyTypeEgress},
+			Ingress:     ingress,
+			Egress:      egress,
+		},
+	}
+}
+
+func networkPolicyPorts(ports []uint, protocol v1.Protocol) []networkingV1.NetworkPolicyPort {
+	policyPorts := make([]networkingV1.NetworkPolicyPort, len(ports))
+	for i, port := range ports {
+		p := intstr.FromInt(int(port))
+		proto := protocol
+		policyPorts[i] = networkingV1.NetworkPolicyPort{Protocol: &proto, Port: &p}
+	}
+	return policyPorts
+}
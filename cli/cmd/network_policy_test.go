@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+)
+
+func TestProxyNetworkPolicy(t *testing.T) {
+	options := newInjectOptions()
+	options.ignoreInboundPorts = []uint{8080}
+	options.ignoreOutboundPorts = []uint{3306}
+	options.ignoreOutboundSubnets = []string{"10.0.0.0/8"}
+
+	podLabels := map[string]string{k8s.ProxyDeploymentLabel: "web"}
+	policy := proxyNetworkPolicy("web", "deployment", "emojivoto", podLabels, options)
+
+	if policy.Name != "web-deployment-linkerd" || policy.Namespace != "emojivoto" {
+		t.Fatalf("Expected policy emojivoto/web-deployment-linkerd, got %s/%s", policy.Namespace, policy.Name)
+	}
+	if !reflect.DeepEqual(policy.Spec.PodSelector.MatchLabels, podLabels) {
+		t.Fatalf("Expected pod selector %v, got %v", podLabels, policy.Spec.PodSelector.MatchLabels)
+	}
+
+	if len(policy.Spec.Ingress) != 2 {
+		t.Fatalf("Expected 2 ingress rules, got %+v", policy.Spec.Ingress)
+	}
+	meshedPods := policy.Spec.Ingress[0].From[0]
+	if meshedPods.NamespaceSelector == nil || meshedPods.PodSelector.MatchExpressions[0].Key != k8s.ControllerNSLabel {
+		t.Fatalf("Expected ingress from meshed pods in all namespaces, got %+v", meshedPods)
+	}
+	if port := policy.Spec.Ingress[1].Ports[0].Port.IntValue(); port != 8080 {
+		t.Fatalf("Expected ingress on skipped port 8080, got %d", port)
+	}
+
+	if len(policy.Spec.Egress) != 4 {
+		t.Fatalf("Expected 4 egress rules, got %+v", policy.Spec.Egress)
+	}
+	dns := policy.Spec.Egress[1].Ports
+	if len(dns) != 2 || *dns[0].Protocol != v1.ProtocolUDP || *dns[1].Protocol != v1.ProtocolTCP || dns[0].Port.IntValue() != dnsPort {
+		t.Fatalf("Expected egress to DNS over UDP and TCP, got %+v", dns)
+	}
+	if port := policy.Spec.Egress[2].Ports[0].Port.IntValue(); port != 3306 {
+		t.Fatalf("Expected egress on skipped port 3306, got %d", port)
+	}
+	if cidr := policy.Spec.Egress[3].To[0].IPBlock.CIDR; cidr != "10.0.0.0/8" {
+		t.Fatalf("Expected egress to skipped subnet 10.0.0.0/8, got %s", cidr)
+	}
+}
+
+func TestInjectYAMLNetworkPolicy(t *testing.T) {
+	options := newInjectOptions()
+	options.networkPolicy = true
+
+	for _, inputFileName := range []string{"inject_emojivoto_deployment.input.yml", "inject_emojivoto_list.input.yml"} {
+		t.Run(inputFileName, func(t *testing.T) {
+			file, err := os.Open("testdata/" + inputFileName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			defer file.Close()
+
+			var output, report bytes.Buffer
+			if err := InjectYAML(file, &output, &report, options); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if !strings.Contains(output.String(), "kind: NetworkPolicy") {
+				t.Fatalf("Expected a NetworkPolicy in the output, got [%s]", output.String())
+			}
+			if !strings.Contains(output.String(), "name: web-deployment-linkerd") {
+				t.Fatalf("Expected the NetworkPolicy to be named after the workload, got [%s]", output.String())
+			}
+		})
+	}
+
+	t.Run("bare pods", func(t *testing.T) {
+		file, err := os.Open("testdata/inject_emojivoto_pod.input.yml")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer file.Close()
+
+		var output, report bytes.Buffer
+		if err := InjectYAML(file, &output, &report, options); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if strings.Contains(output.String(), "kind: NetworkPolicy") {
+			t.Fatalf("Expected no NetworkPolicy for a bare pod, got [%s]", output.String())
+		}
+	})
+}
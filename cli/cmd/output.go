@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+)
+
+// The formats of the "--output" flag shared by the commands that render
+// reports. The table format is each command's human-readable default. The
+// json and yaml formats render the same document, whose schema is defined by
+// the json tags of the command's report type, so that scripts can rely on it
+// across releases.
+const (
+	tableOutput = "table"
+	jsonOutput  = "json"
+	yamlOutput  = "yaml"
+)
+
+var outputFormats = []string{tableOutput, jsonOutput, yamlOutput}
+
+// addOutputFlag adds the "--output" flag to a command, with the usage shared
+// by all the commands.
+func addOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.PersistentFlags().StringVarP(output, "output", "o", *output, fmt.Sprintf("Output format. One of: %s", strings.Join(outputFormats, ", ")))
+}
+
+// validateOutput returns an error if output isn't one of the supported
+// formats. An empty format is the table format.
+func validateOutput(output string) error {
+	if output == "" {
+		return nil
+	}
+	for _, format := range outputFormats {
+		if output == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("output format \"%s\" not recognized", output)
+}
+
+// isStructuredOutput returns true if output is a machine-readable format,
+// rendered by marshalOutput rather than by the command itself.
+func isStructuredOutput(output string) bool {
+	return output == jsonOutput || output == yamlOutput
+}
+
+// marshalOutput renders a command's report as JSON or YAML.
+func marshalOutput(report interface{}, output string) (string, error) {
+	switch output {
+	case jsonOutput:
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(b) + "\n", nil
+	case yamlOutput:
+		b, err := yaml.Marshal(report)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return "", fmt.Errorf("output format \"%s\" can't be marshaled", output)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/pflag"
+)
+
+// pluginPrefix is the prefix of the executables on the PATH that extend the
+// CLI: `linkerd foo` runs `linkerd-foo` when foo isn't a built-in command.
+const pluginPrefix = "linkerd-"
+
+// RunPlugin runs the plugin named by the first positional argument in args,
+// if that argument isn't a built-in command and a matching executable is on
+// the PATH. The plugin receives all the other arguments, including global
+// flags such as --kubeconfig and --context, and inherits the CLI's standard
+// streams and environment. It returns false if no plugin was run, and
+// otherwise the exit code the CLI should exit with.
+func RunPlugin(args []string) (bool, int) {
+	name, pluginArgs := splitPluginArgs(args)
+	if name == "" || isBuiltinCommand(name) {
+		return false, 0
+	}
+
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return false, 0
+	}
+
+	plugin := exec.Command(path, pluginArgs...)
+	plugin.Stdin = os.Stdin
+	plugin.Stdout = os.Stdout
+	plugin.Stderr = os.Stderr
+	if err := plugin.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+				return true, status.ExitStatus()
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Failed to run plugin %s: %s\n", path, err)
+		return true, 1
+	}
+
+	return true, 0
+}
+
+// splitPluginArgs returns the first positional argument in args, along with
+// the remaining arguments in their original order. Values of the root
+// command's flags aren't mistaken for positional arguments.
+func splitPluginArgs(args []string) (string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return "", nil
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			rest := append([]string{}, args[:i]...)
+			return arg, append(rest, args[i+1:]...)
+		}
+		if flagTakesSeparateValue(arg) {
+			i++
+		}
+	}
+	return "", nil
+}
+
+// flagTakesSeparateValue returns true if arg is one of the root command's
+// flags and its value is passed as the next argument.
+func flagTakesSeparateValue(arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+
+	flags := RootCmd.PersistentFlags()
+	var name string
+	if strings.HasPrefix(arg, "--") {
+		name = strings.TrimPrefix(arg, "--")
+	} else {
+		shorthand := strings.TrimPrefix(arg, "-")
+		if len(shorthand) != 1 {
+			return false
+		}
+		flags.VisitAll(func(flag *pflag.Flag) {
+			if flag.Shorthand == shorthand {
+				name = flag.Name
+			}
+		})
+	}
+
+	flag := flags.Lookup(name)
+	return flag != nil && flag.NoOptDefVal == ""
+}
+
+func isBuiltinCommand(name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, cmd := range RootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
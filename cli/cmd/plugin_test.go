@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPluginArgs(t *testing.T) {
+	testCases := []struct {
+		args         []string
+		expectedName string
+		expectedArgs []string
+	}{
+		{
+			args:         []string{"foo", "bar", "--baz"},
+			expectedName: "foo",
+			expectedArgs: []string{"bar", "--baz"},
+		},
+		{
+			args:         []string{"--context", "prod", "--kubeconfig=/tmp/config", "foo", "bar"},
+			expectedName: "foo",
+			expectedArgs: []string{"--context", "prod", "--kubeconfig=/tmp/config", "bar"},
+		},
+		{
+			args:         []string{"-l", "linkerd-test", "--verbose", "foo"},
+			expectedName: "foo",
+			expectedArgs: []string{"-l", "linkerd-test", "--verbose"},
+		},
+		{
+			args:         []string{"--context", "prod"},
+			expectedName: "",
+			expectedArgs: nil,
+		},
+		{
+			args:         []string{"--", "foo"},
+			expectedName: "",
+			expectedArgs: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		name, args := splitPluginArgs(tc.args)
+		if name != tc.expectedName {
+			t.Fatalf("Expected plugin name [%s] for %v but got [%s]", tc.expectedName, tc.args, name)
+		}
+		if !reflect.DeepEqual(args, tc.expectedArgs) {
+			t.Fatalf("Expected plugin args %v for %v but got %v", tc.expectedArgs, tc.args, args)
+		}
+	}
+}
+
+func TestIsBuiltinCommand(t *testing.T) {
+	for _, name := range []string{"check", "help", "install", "version"} {
+		if !isBuiltinCommand(name) {
+			t.Fatalf("Expected %s to be a built-in command", name)
+		}
+	}
+
+	if isBuiltinCommand("foo") {
+		t.Fatal("Expected foo not to be a built-in command")
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type proxyConfigCmdOptions struct {
+	namespace     string
+	allNamespaces bool
+}
+
+func newProxyConfigCmdOptions() *proxyConfigCmdOptions {
+	return &proxyConfigCmdOptions{
+		namespace:     "default",
+		allNamespaces: false,
+	}
+}
+
+func newCmdProxyConfig() *cobra.Command {
+	options := newProxyConfigCmdOptions()
+
+	cmd := &cobra.Command{
+		Use:   "proxy-config [flags]",
+		Short: "Display the workloads injected with an outdated proxy configuration",
+		Long: `Display the workloads injected with an outdated proxy configuration.
+
+Injected pods are annotated with a hash of the proxy configuration they were
+injected with, and the control plane records the hash of the configuration it
+was installed with. Changes to the configuration, e.g. a new proxy version or
+log level, only reach a workload once it's re-injected and rolled out; this
+command lists the hashes of each meshed workload's pods so that the rollout can
+be tracked. Workloads whose pods were injected with another configuration, or
+by a CLI that didn't record the hash, are outdated.`,
+		Example: `  # Display the proxy configuration of the workloads in the emojivoto namespace.
+  linkerd proxy-config -n emojivoto
+
+  # Display the proxy configuration of all the meshed workloads.
+  linkerd proxy-config --all-namespaces`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			clientset, err := kubernetes.NewForConfig(kubeAPI.Config)
+			if err != nil {
+				return err
+			}
+
+			outdated, err := runProxyConfigCmd(clientset, os.Stdout, options)
+			if err != nil {
+				return err
+			}
+			if outdated > 0 {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the workloads")
+	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, displays workloads across all namespaces, ignoring the \"--namespace\" flag")
+
+	addNamespaceCompletion(cmd)
+
+	return cmd
+}
+
+// runProxyConfigCmd writes the proxy configuration hashes of the workloads
+// meshed with the control plane to w, and returns the number of outdated
+// workloads.
+func runProxyConfigCmd(clientset kubernetes.Interface, w io.Writer, options *proxyConfigCmdOptions) (int, error) {
+	currentHash, err := healthcheck.GetProxyConfigHash(clientset, controlPlaneNamespace)
+	if err != nil {
+		return 0, err
+	}
+	if currentHash == "" {
+		return 0, fmt.Errorf("The %s/%s ConfigMap doesn't record the proxy configuration hash; upgrade the control plane to track the proxy configuration of its workloads",
+			controlPlaneNamespace, k8s.ProxyConfigMapName)
+	}
+
+	namespace := options.namespace
+	if options.allNamespaces {
+		namespace = ""
+	}
+
+	podList, err := clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	workloads, err := healthcheck.DiagnoseProxyConfigs(clientset, currentHash, podList.Items)
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Fprintf(w, "Current proxy configuration: %s\n\n", currentHash)
+
+	if len(workloads) == 0 {
+		fmt.Fprintln(w, "No meshed workloads found.")
+		return 0, nil
+	}
+
+	return renderProxyConfigs(w, workloads, options.allNamespaces), nil
+}
+
+// renderProxyConfigs writes a table of the workloads' proxy configuration
+// hashes and returns the number of outdated workloads. Pods injected without a
+// hash are displayed as "unknown".
+func renderProxyConfigs(w io.Writer, workloads []healthcheck.WorkloadProxyConfig, allNamespaces bool) int {
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	if allNamespaces {
+		fmt.Fprint(tw, "NAMESPACE\t")
+	}
+	fmt.Fprintln(tw, "NAME\tHASH\tSTATUS")
+
+	outdated := 0
+	for _, workload := range workloads {
+		status := "current"
+		if workload.Outdated {
+			status = "outdated"
+			outdated++
+		}
+
+		hashes := make([]string, len(workload.Hashes))
+		for i, hash := range workload.Hashes {
+			if hash == "" {
+				hash = "unknown"
+			}
+			hashes[i] = hash
+		}
+
+		if allNamespaces {
+			fmt.Fprintf(tw, "%s\t", workload.Namespace)
+		}
+		fmt.Fprintf(tw, "%s/%s\t%s\t%s\n", workload.Kind, workload.Name, strings.Join(hashes, ","), status)
+	}
+	tw.Flush()
+
+	return outdated
+}
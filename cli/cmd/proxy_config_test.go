@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+)
+
+func TestRenderProxyConfigs(t *testing.T) {
+	workloads := []healthcheck.WorkloadProxyConfig{
+		{Namespace: "booksapp", Kind: "deployment", Name: "books", Hashes: []string{"1a6e45d61c15b879"}},
+		{Namespace: "booksapp", Kind: "deployment", Name: "web", Hashes: []string{"1a6e45d61c15b879", "2126890a16f63cfc"}, Outdated: true},
+		{Namespace: "booksapp", Kind: "pod", Name: "authors", Hashes: []string{""}, Outdated: true},
+	}
+
+	expected := `NAMESPACE   NAME               HASH                                STATUS
+booksapp    deployment/books   1a6e45d61c15b879                    current
+booksapp    deployment/web     1a6e45d61c15b879,2126890a16f63cfc   outdated
+booksapp    pod/authors        unknown                             outdated
+`
+
+	var buf bytes.Buffer
+	outdated := renderProxyConfigs(&buf, workloads, true)
+	if outdated != 2 {
+		t.Fatalf("Expected 2 outdated workloads, got %d", outdated)
+	}
+	if buf.String() != expected {
+		t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expected, buf.String())
+	}
+}
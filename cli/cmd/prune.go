@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	appsV1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// openshiftUIDRangeAnnotation is set on the control plane namespace when it's
+// installed with --openshift.
+const openshiftUIDRangeAnnotation = "openshift.io/sa.scc.uid-range"
+
+type pruneOptions struct {
+	force bool
+	*installOptions
+}
+
+func newPruneOptions() *pruneOptions {
+	return &pruneOptions{
+		force:          false,
+		installOptions: newInstallOptions(),
+	}
+}
+
+func newCmdPrune() *cobra.Command {
+	options := newPruneOptions()
+
+	cmd := &cobra.Command{
+		Use:   "prune [flags]",
+		Short: "Delete control plane resources that are no longer part of the install",
+		Long: `Delete control plane resources that are no longer part of the install.
+
+The Deployments, Services and ConfigMaps in the control plane namespace that are
+labeled as part of the control plane are compared against the configs that
+"linkerd install" outputs for this version, and the ones that aren't part of the
+install anymore are listed. The install options that decide which resources are
+output, i.e. whether TLS is enabled, the controller replicas, the identity issuer
+secret, the OpenShift UID range and the alert groups, are read from the installed
+control plane, so that e.g. the CA of a TLS-enabled control plane and the
+PodDisruptionBudgets of an HA control plane are kept. Nothing is deleted unless
+--force is set.`,
+		Example: `  # List the resources left behind by an upgrade, without deleting them.
+  linkerd prune
+
+  # Delete the resources left behind by an upgrade.
+  linkerd prune --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			clientset, err := kubernetes.NewForConfig(kubeAPI.Config)
+			if err != nil {
+				return err
+			}
+
+			return runPruneCmd(clientset, os.Stdout, options)
+		},
+	}
+
+	addProxyConfigFlags(cmd, options.proxyConfigOptions)
+	cmd.PersistentFlags().BoolVar(&options.force, "force", options.force, "Delete the listed resources; without it, the resources that would be deleted are only listed")
+
+	return cmd
+}
+
+func runPruneCmd(clientset kubernetes.Interface, w io.Writer, options *pruneOptions) error {
+	if err := readInstalledOptions(clientset, options.installOptions); err != nil {
+		return err
+	}
+
+	config, err := validateAndBuildConfig(options.installOptions)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := render(*config, buf, options.installOptions); err != nil {
+		return err
+	}
+
+	installed, err := renderedResources(buf)
+	if err != nil {
+		return err
+	}
+
+	orphans, err := findOrphanedResources(clientset, installed)
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		fmt.Fprintln(w, "No resources to prune")
+		return nil
+	}
+
+	for _, orphan := range orphans {
+		if !options.force {
+			fmt.Fprintf(w, "%s would be deleted (use --force to delete)\n", orphan)
+			continue
+		}
+
+		if err := deleteResource(clientset, orphan); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s deleted\n", orphan)
+	}
+
+	return nil
+}
+
+// readInstalledOptions sets the install options that decide which resources
+// "linkerd install" outputs to the ones the control plane was installed with:
+// TLS and the controller replicas are read from the controller, the identity
+// issuer secret from the CA, the OpenShift UID range from the control plane
+// namespace and the alert groups from the Prometheus config.
+func readInstalledOptions(clientset kubernetes.Interface, options *installOptions) error {
+	controller, err := installedDeployment(clientset, ControlPlanePodName)
+	if err != nil {
+		return err
+	}
+	if controller != nil {
+		if controller.Spec.Replicas != nil {
+			options.controllerReplicas = uint(*controller.Spec.Replicas)
+		}
+		if deploymentArg(controller, "-enable-tls") == "true" {
+			options.tls = optionalTLS
+		}
+	}
+
+	ca, err := installedDeployment(clientset, "ca")
+	if err != nil {
+		return err
+	}
+	if ca != nil {
+		options.issuerSecret = deploymentArg(ca, "-issuer-secret")
+	}
+
+	ns, err := clientset.CoreV1().Namespaces().Get(controlPlaneNamespace, metaV1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if uidRange, ok := ns.Annotations[openshiftUIDRangeAnnotation]; ok {
+			options.openshift = true
+			options.openshiftUIDRange = uidRange
+		}
+	}
+
+	cm, err := clientset.CoreV1().ConfigMaps(controlPlaneNamespace).Get("prometheus-config", metaV1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		rules := cm.Data["alerting_rules.yml"]
+		options.alertGroups = []string{}
+		for _, group := range alertGroups {
+			if strings.Contains(rules, fmt.Sprintf("- name: linkerd-%s\n", group)) {
+				options.alertGroups = append(options.alertGroups, group)
+			}
+		}
+		options.disableAlerts = len(options.alertGroups) == 0
+	}
+
+	return nil
+}
+
+// installedDeployment returns the control plane deployment with the given
+// name, or nil if it isn't installed.
+func installedDeployment(clientset kubernetes.Interface, name string) (*appsV1.Deployment, error) {
+	deploy, err := clientset.AppsV1().Deployments(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deploy, nil
+}
+
+// deploymentArg returns the value of a "-flag=value" argument of one of the
+// deployment's containers, or an empty string if it isn't set.
+func deploymentArg(deploy *appsV1.Deployment, flag string) string {
+	for _, container := range deploy.Spec.Template.Spec.Containers {
+		for _, arg := range container.Args {
+			if strings.HasPrefix(arg, flag+"=") {
+				return strings.TrimPrefix(arg, flag+"=")
+			}
+		}
+	}
+	return ""
+}
+
+// renderedResources returns the "kind/name" keys of the resources in a stream
+// of YAML configs, with kinds in lowercase.
+func renderedResources(in io.Reader) (map[string]bool, error) {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(in, 4096))
+	resources := make(map[string]bool)
+
+	for {
+		bytes, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var obj struct {
+			metaV1.TypeMeta   `json:",inline"`
+			metaV1.ObjectMeta `json:"metadata,omitempty"`
+		}
+		if err := yaml.Unmarshal(bytes, &obj); err != nil {
+			return nil, err
+		}
+		if obj.Kind != "" {
+			resources[resourceKey(obj.Kind, obj.Name)] = true
+		}
+	}
+
+	return resources, nil
+}
+
+// findOrphanedResources returns the sorted "kind/name" keys of the control
+// plane resources in the cluster that aren't part of the installed configs.
+func findOrphanedResources(clientset kubernetes.Interface, installed map[string]bool) ([]string, error) {
+	listOptions := metaV1.ListOptions{LabelSelector: k8s.ControllerComponentLabel}
+	existing := []string{}
+
+	deployments, err := clientset.AppsV1().Deployments(controlPlaneNamespace).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, deploy := range deployments.Items {
+		existing = append(existing, resourceKey("Deployment", deploy.Name))
+	}
+
+	services, err := clientset.CoreV1().Services(controlPlaneNamespace).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services.Items {
+		existing = append(existing, resourceKey("Service", svc.Name))
+	}
+
+	configMaps, err := clientset.CoreV1().ConfigMaps(controlPlaneNamespace).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, cm := range configMaps.Items {
+		existing = append(existing, resourceKey("ConfigMap", cm.Name))
+	}
+
+	pdbs, err := clientset.PolicyV1beta1().PodDisruptionBudgets(controlPlaneNamespace).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, pdb := range pdbs.Items {
+		existing = append(existing, resourceKey("PodDisruptionBudget", pdb.Name))
+	}
+
+	orphans := []string{}
+	for _, key := range existing {
+		if !installed[key] {
+			orphans = append(orphans, key)
+		}
+	}
+	sort.Strings(orphans)
+
+	return orphans, nil
+}
+
+func deleteResource(clientset kubernetes.Interface, key string) error {
+	parts := strings.SplitN(key, "/", 2)
+	kind, name := parts[0], parts[1]
+
+	// delete the pods of pruned deployments as well
+	propagation := metaV1.DeletePropagationBackground
+	deleteOptions := &metaV1.DeleteOptions{PropagationPolicy: &propagation}
+
+	switch kind {
+	case "deployment":
+		return clientset.AppsV1().Deployments(controlPlaneNamespace).Delete(name, deleteOptions)
+	case "service":
+		return clientset.CoreV1().Services(controlPlaneNamespace).Delete(name, deleteOptions)
+	case "configmap":
+		return clientset.CoreV1().ConfigMaps(controlPlaneNamespace).Delete(name, deleteOptions)
+	case "poddisruptionbudget":
+		return clientset.PolicyV1beta1().PodDisruptionBudgets(controlPlaneNamespace).Delete(name, deleteOptions)
+	default:
+		return fmt.Errorf("Cannot prune resource of kind %s", kind)
+	}
+}
+
+func resourceKey(kind, name string) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(kind), name)
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	policyV1beta1 "k8s.io/api/policy/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRunPruneCmd(t *testing.T) {
+	objectMeta := func(name, component string) metaV1.ObjectMeta {
+		meta := metaV1.ObjectMeta{Name: name, Namespace: controlPlaneNamespace}
+		if component != "" {
+			meta.Labels = map[string]string{k8s.ControllerComponentLabel: component}
+		}
+		return meta
+	}
+
+	newClientset := func(tls bool) *fake.Clientset {
+		controller := &appsV1.Deployment{ObjectMeta: objectMeta("controller", "controller")}
+		controller.Spec.Template.Spec.Containers = []v1.Container{
+			{Name: "destination", Args: []string{"destination", fmt.Sprintf("-enable-tls=%t", tls)}},
+		}
+
+		return fake.NewSimpleClientset([]runtime.Object{
+			controller,
+			&appsV1.Deployment{ObjectMeta: objectMeta("ca", "ca")},
+			&appsV1.Deployment{ObjectMeta: objectMeta("books", "")},
+			&v1.Service{ObjectMeta: objectMeta("api", "controller")},
+			&v1.ConfigMap{ObjectMeta: objectMeta("prometheus-config", "prometheus")},
+			&v1.ConfigMap{ObjectMeta: objectMeta("statsd-config", "controller")},
+		}...)
+	}
+
+	t.Run("Only lists orphaned resources without --force", func(t *testing.T) {
+		clientset := newClientset(false)
+		options := newPruneOptions()
+
+		var buf bytes.Buffer
+		if err := runPruneCmd(clientset, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "configmap/statsd-config would be deleted (use --force to delete)\ndeployment/ca would be deleted (use --force to delete)\n"
+		if buf.String() != expected {
+			t.Fatalf("Expected output [%s] but got [%s]", expected, buf.String())
+		}
+
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "delete" {
+				t.Fatalf("Unexpected delete without --force: %+v", action)
+			}
+		}
+	})
+
+	t.Run("Deletes orphaned resources with --force", func(t *testing.T) {
+		clientset := newClientset(false)
+		options := newPruneOptions()
+		options.force = true
+
+		var buf bytes.Buffer
+		if err := runPruneCmd(clientset, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "configmap/statsd-config deleted\ndeployment/ca deleted\n"
+		if buf.String() != expected {
+			t.Fatalf("Expected output [%s] but got [%s]", expected, buf.String())
+		}
+
+		deployments, err := clientset.AppsV1().Deployments(controlPlaneNamespace).List(metaV1.ListOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(deployments.Items) != 2 {
+			t.Fatalf("Expected 2 remaining deployments but got %d", len(deployments.Items))
+		}
+	})
+
+	t.Run("Keeps the CA of a TLS-enabled control plane", func(t *testing.T) {
+		clientset := newClientset(true)
+		options := newPruneOptions()
+		options.force = true
+
+		var buf bytes.Buffer
+		if err := runPruneCmd(clientset, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "configmap/statsd-config deleted\n"
+		if buf.String() != expected {
+			t.Fatalf("Expected output [%s] but got [%s]", expected, buf.String())
+		}
+	})
+
+	t.Run("Keeps the PodDisruptionBudgets of an HA control plane", func(t *testing.T) {
+		clientset := newClientset(true)
+		controller, err := clientset.AppsV1().Deployments(controlPlaneNamespace).Get("controller", metaV1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		replicas := int32(3)
+		controller.Spec.Replicas = &replicas
+		if _, err := clientset.AppsV1().Deployments(controlPlaneNamespace).Update(controller); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, pdb := range []string{"controller", "ca"} {
+			obj := &policyV1beta1.PodDisruptionBudget{ObjectMeta: objectMeta(pdb, pdb)}
+			if _, err := clientset.PolicyV1beta1().PodDisruptionBudgets(controlPlaneNamespace).Create(obj); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		options := newPruneOptions()
+		options.force = true
+
+		var buf bytes.Buffer
+		if err := runPruneCmd(clientset, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "configmap/statsd-config deleted\n"
+		if buf.String() != expected {
+			t.Fatalf("Expected output [%s] but got [%s]", expected, buf.String())
+		}
+	})
+
+	t.Run("Reads the install options from the control plane", func(t *testing.T) {
+		clientset := newClientset(true)
+		ca, err := clientset.AppsV1().Deployments(controlPlaneNamespace).Get("ca", metaV1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ca.Spec.Template.Spec.Containers = []v1.Container{
+			{Name: "ca", Args: []string{"ca", "-issuer-secret=linkerd-issuer"}},
+		}
+		if _, err := clientset.AppsV1().Deployments(controlPlaneNamespace).Update(ca); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ns := &v1.Namespace{ObjectMeta: metaV1.ObjectMeta{
+			Name:        controlPlaneNamespace,
+			Annotations: map[string]string{openshiftUIDRangeAnnotation: "2000/200"},
+		}}
+		if _, err := clientset.CoreV1().Namespaces().Create(ns); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		cm, err := clientset.CoreV1().ConfigMaps(controlPlaneNamespace).Get("prometheus-config", metaV1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		cm.Data = map[string]string{"alerting_rules.yml": "groups:\n- name: linkerd-certificates\n  rules: []\n"}
+		if _, err := clientset.CoreV1().ConfigMaps(controlPlaneNamespace).Update(cm); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		options := newInstallOptions()
+		if err := readInstalledOptions(clientset, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if options.tls != optionalTLS {
+			t.Errorf("Expected TLS to be enabled")
+		}
+		if options.issuerSecret != "linkerd-issuer" {
+			t.Errorf("Expected issuer secret [linkerd-issuer] but got [%s]", options.issuerSecret)
+		}
+		if !options.openshift || options.openshiftUIDRange != "2000/200" {
+			t.Errorf("Expected OpenShift UID range [2000/200] but got [%t %s]", options.openshift, options.openshiftUIDRange)
+		}
+		if len(options.alertGroups) != 1 || options.alertGroups[0] != "certificates" || options.disableAlerts {
+			t.Errorf("Expected alert groups [certificates] but got %v", options.alertGroups)
+		}
+	})
+}
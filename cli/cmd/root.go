@@ -1,14 +1,21 @@
 package cmd
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
 	"regexp"
 	"strings"
+	"syscall"
 	"time"
 
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -21,6 +28,7 @@ const (
 	lineWidth  = 80
 	okStatus   = "[ok]"
 	warnStatus = "[warn]"
+	infoStatus = "[info]"
 )
 
 var controlPlaneNamespace string
@@ -28,6 +36,7 @@ var apiAddr string // An empty value means "use the Kubernetes configuration"
 var kubeconfigPath string
 var kubeContext string
 var verbose bool
+var apiLog bool
 
 var (
 	// These regexs are not as strict as they could be, but are a quick and dirty
@@ -38,9 +47,10 @@ var (
 )
 
 var RootCmd = &cobra.Command{
-	Use:   "linkerd",
-	Short: "linkerd manages the Linkerd service mesh",
-	Long:  `linkerd manages the Linkerd service mesh.`,
+	Use:                    "linkerd",
+	Short:                  "linkerd manages the Linkerd service mesh",
+	Long:                   `linkerd manages the Linkerd service mesh.`,
+	BashCompletionFunction: bashCompletionFunc,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// enable / disable logging
 		if verbose {
@@ -48,6 +58,9 @@ var RootCmd = &cobra.Command{
 		} else {
 			log.SetLevel(log.PanicLevel)
 		}
+		if apiLog {
+			k8s.EnableAPILog(os.Stderr)
+		}
 
 		controlPlaneNamespaceFromEnv := os.Getenv("LINKERD_NAMESPACE")
 		if controlPlaneNamespace == defaultNamespace && controlPlaneNamespaceFromEnv != "" {
@@ -68,16 +81,25 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&kubeContext, "context", "", "Name of the kubeconfig context to use")
 	RootCmd.PersistentFlags().StringVar(&apiAddr, "api-addr", "", "Override kubeconfig and communicate directly with the control plane at host:port (mostly for testing)")
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Turn on debug logging")
+	RootCmd.PersistentFlags().BoolVar(&apiLog, "api-log", false, "Log every request to the Kubernetes and Linkerd APIs, with its status and duration, to stderr (also enabled by --verbose)")
 
 	RootCmd.AddCommand(newCmdCheck())
 	RootCmd.AddCommand(newCmdCompletion())
 	RootCmd.AddCommand(newCmdDashboard())
+	RootCmd.AddCommand(newCmdDoctor())
 	RootCmd.AddCommand(newCmdGet())
+	RootCmd.AddCommand(newCmdIdentity())
 	RootCmd.AddCommand(newCmdInject())
 	RootCmd.AddCommand(newCmdInstall())
+	RootCmd.AddCommand(newCmdJaeger())
+	RootCmd.AddCommand(newCmdLatency())
+	RootCmd.AddCommand(newCmdPrune())
+	RootCmd.AddCommand(newCmdProxyConfig())
+	RootCmd.AddCommand(newCmdResourceNames())
 	RootCmd.AddCommand(newCmdStat())
 	RootCmd.AddCommand(newCmdTap())
 	RootCmd.AddCommand(newCmdTop())
+	RootCmd.AddCommand(newCmdUpgrade())
 	RootCmd.AddCommand(newCmdVersion())
 }
 
@@ -91,13 +113,17 @@ func validatedPublicAPIClient(retryDeadline time.Time) pb.ApiClient {
 		healthcheck.LinkerdAPIChecks,
 	}
 
-	hc := healthcheck.NewHealthChecker(checks, &healthcheck.HealthCheckOptions{
+	hc, err := healthcheck.NewHealthChecker(checks, &healthcheck.HealthCheckOptions{
 		ControlPlaneNamespace: controlPlaneNamespace,
 		KubeConfig:            kubeconfigPath,
 		KubeContext:           kubeContext,
 		APIAddr:               apiAddr,
 		RetryDeadline:         retryDeadline,
 	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure the health checks: %s\n", err)
+		os.Exit(1)
+	}
 
 	exitOnError := func(result *healthcheck.CheckResult) {
 		if result.Retry {
@@ -125,25 +151,52 @@ func validatedPublicAPIClient(retryDeadline time.Time) pb.ApiClient {
 		}
 	}
 
-	hc.RunChecks(exitOnError)
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	hc.RunChecks(ctx, exitOnError)
 	return hc.PublicAPIClient()
 }
 
+// interruptContext returns a context that's canceled when the CLI receives
+// SIGINT or SIGTERM, so that the health checks stop retrying instead of
+// waiting for their deadline. The signals are handled until cancel is called.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(stop)
+	}()
+
+	return ctx, cancel
+}
+
 type proxyConfigOptions struct {
 	linkerdVersion        string
 	proxyImage            string
 	initImage             string
 	dockerRegistry        string
 	imagePullPolicy       string
+	imageArch             string
 	proxyUID              int64
 	proxyLogLevel         string
 	proxyBindTimeout      string
+	proxyDetectTimeout    string
 	proxyAPIPort          uint
 	proxyControlPort      uint
 	proxyMetricsPort      uint
 	proxyCpuRequest       string
 	proxyMemoryRequest    string
 	proxyOutboundCapacity map[string]uint
+	ignoreOutboundSubnets []string
+	opaquePorts           []uint
 	tls                   string
 }
 
@@ -152,6 +205,19 @@ const (
 	defaultDockerRegistry = "gcr.io/linkerd-io"
 )
 
+// supportedArchitectures lists the node architectures Linkerd images can be
+// published for. Arch-specific images are tagged with a "-<arch>" suffix.
+var supportedArchitectures = []string{"amd64", "arm64"}
+
+// defaultArchitecture is the node architecture of the untagged Linkerd
+// images, which aren't multi-arch manifests.
+const defaultArchitecture = "amd64"
+
+// defaultOpaquePorts are the well-known ports of server-speaks-first
+// protocols (SMTP, MySQL and PostgreSQL), on which the proxy can't detect the
+// protocol and must forward traffic as opaque TCP.
+var defaultOpaquePorts = []uint{25, 3306, 5432}
+
 func newProxyConfigOptions() *proxyConfigOptions {
 	return &proxyConfigOptions{
 		linkerdVersion:        version.Version,
@@ -159,13 +225,17 @@ func newProxyConfigOptions() *proxyConfigOptions {
 		initImage:             defaultDockerRegistry + "/proxy-init",
 		dockerRegistry:        defaultDockerRegistry,
 		imagePullPolicy:       "IfNotPresent",
+		imageArch:             "",
 		proxyUID:              2102,
 		proxyLogLevel:         "warn,linkerd2_proxy=info",
 		proxyBindTimeout:      "10s",
+		proxyDetectTimeout:    "",
 		proxyAPIPort:          8086,
 		proxyControlPort:      4190,
 		proxyMetricsPort:      4191,
 		proxyOutboundCapacity: map[string]uint{},
+		ignoreOutboundSubnets: nil,
+		opaquePorts:           defaultOpaquePorts,
 		proxyCpuRequest:       "",
 		proxyMemoryRequest:    "",
 		tls:                   "",
@@ -185,10 +255,24 @@ func (options *proxyConfigOptions) validate() error {
 		return fmt.Errorf("--image-pull-policy must be one of: Always, IfNotPresent, Never")
 	}
 
+	if options.imageArch != "" && !isSupportedArchitecture(options.imageArch) {
+		return fmt.Errorf("--image-arch must be blank or one of: %s", strings.Join(supportedArchitectures, ", "))
+	}
+
+	if options.proxyUID <= 0 {
+		return fmt.Errorf("Invalid user ID '%d' for --proxy-uid flag; the proxy can't run as root", options.proxyUID)
+	}
+
 	if _, err := time.ParseDuration(options.proxyBindTimeout); err != nil {
 		return fmt.Errorf("Invalid duration '%s' for --proxy-bind-timeout flag", options.proxyBindTimeout)
 	}
 
+	if options.proxyDetectTimeout != "" {
+		if _, err := time.ParseDuration(options.proxyDetectTimeout); err != nil {
+			return fmt.Errorf("Invalid duration '%s' for --proxy-detect-protocol-timeout flag", options.proxyDetectTimeout)
+		}
+	}
+
 	if options.proxyCpuRequest != "" {
 		if _, err := k8sResource.ParseQuantity(options.proxyCpuRequest); err != nil {
 			return fmt.Errorf("Invalid cpu request '%s' for --proxy-cpu flag", options.proxyCpuRequest)
@@ -201,6 +285,18 @@ func (options *proxyConfigOptions) validate() error {
 		}
 	}
 
+	for _, port := range options.opaquePorts {
+		if port == 0 || port > 65535 {
+			return fmt.Errorf("Invalid port '%d' for --opaque-ports flag", port)
+		}
+	}
+
+	for _, subnet := range options.ignoreOutboundSubnets {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			return fmt.Errorf("Invalid CIDR '%s' for --skip-outbound-subnets flag", subnet)
+		}
+	}
+
 	if options.tls != "" && options.tls != optionalTLS {
 		return fmt.Errorf("--tls must be blank or set to \"%s\"", optionalTLS)
 	}
@@ -212,14 +308,82 @@ func (options *proxyConfigOptions) enableTLS() bool {
 	return options.tls == optionalTLS
 }
 
+// imageTag returns the tag of the Linkerd images to deploy. Without
+// --image-arch, the tag refers to the images built for defaultArchitecture.
+func (options *proxyConfigOptions) imageTag() string {
+	if options.imageArch == "" {
+		return options.linkerdVersion
+	}
+	return fmt.Sprintf("%s-%s", options.linkerdVersion, options.imageArch)
+}
+
+// nodeArchitecture returns the node architecture the deployed images are
+// built for.
+func (options *proxyConfigOptions) nodeArchitecture() string {
+	if options.imageArch == "" {
+		return defaultArchitecture
+	}
+	return options.imageArch
+}
+
+// nodeArchitectures returns the node architectures the rendered pods can be
+// scheduled on.
+func (options *proxyConfigOptions) nodeArchitectures() []string {
+	return []string{options.nodeArchitecture()}
+}
+
 func (options *proxyConfigOptions) taggedProxyImage() string {
 	image := strings.Replace(options.proxyImage, defaultDockerRegistry, options.dockerRegistry, 1)
-	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+	return fmt.Sprintf("%s:%s", image, options.imageTag())
 }
 
 func (options *proxyConfigOptions) taggedProxyInitImage() string {
 	image := strings.Replace(options.initImage, defaultDockerRegistry, options.dockerRegistry, 1)
-	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+	return fmt.Sprintf("%s:%s", image, options.imageTag())
+}
+
+// configHash returns a hash of the proxy configuration shared by all the
+// workloads injected with these options, excluding the overrides set by each
+// workload's annotations. Injected pods are stamped with it, and the control
+// plane records the hash of the configuration it was installed with, so that
+// the workloads injected with an outdated configuration can be found.
+func (options *proxyConfigOptions) configHash() string {
+	fields := []struct {
+		name  string
+		value interface{}
+	}{
+		{"controlPlaneNamespace", controlPlaneNamespace},
+		{"proxyImage", options.taggedProxyImage()},
+		{"initImage", options.taggedProxyInitImage()},
+		{"imagePullPolicy", options.imagePullPolicy},
+		{"proxyUID", options.proxyUID},
+		{"proxyLogLevel", options.proxyLogLevel},
+		{"proxyBindTimeout", options.proxyBindTimeout},
+		{"proxyDetectTimeout", options.proxyDetectTimeout},
+		{"proxyAPIPort", options.proxyAPIPort},
+		{"proxyControlPort", options.proxyControlPort},
+		{"proxyMetricsPort", options.proxyMetricsPort},
+		{"proxyCpuRequest", options.proxyCpuRequest},
+		{"proxyMemoryRequest", options.proxyMemoryRequest},
+		{"ignoreOutboundSubnets", options.ignoreOutboundSubnets},
+		{"opaquePorts", options.opaquePorts},
+		{"tls", options.tls},
+	}
+
+	hash := sha256.New()
+	for _, field := range fields {
+		fmt.Fprintf(hash, "%s=%v\n", field.name, field.value)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+func isSupportedArchitecture(arch string) bool {
+	for _, supported := range supportedArchitectures {
+		if arch == supported {
+			return true
+		}
+	}
+	return false
 }
 
 func addProxyConfigFlags(cmd *cobra.Command, options *proxyConfigOptions) {
@@ -228,12 +392,16 @@ func addProxyConfigFlags(cmd *cobra.Command, options *proxyConfigOptions) {
 	cmd.PersistentFlags().StringVar(&options.proxyImage, "proxy-image", options.proxyImage, "Linkerd proxy container image name")
 	cmd.PersistentFlags().StringVar(&options.dockerRegistry, "registry", options.dockerRegistry, "Docker registry to pull images from")
 	cmd.PersistentFlags().StringVar(&options.imagePullPolicy, "image-pull-policy", options.imagePullPolicy, "Docker image pull policy")
-	cmd.PersistentFlags().Int64Var(&options.proxyUID, "proxy-uid", options.proxyUID, "Run the proxy under this user ID")
+	cmd.PersistentFlags().StringVar(&options.imageArch, "image-arch", options.imageArch, "Use images built for this node architecture and only schedule pods on matching nodes; valid settings: "+strings.Join(supportedArchitectures, ", ")+" (default: "+defaultArchitecture+")")
+	cmd.PersistentFlags().Int64Var(&options.proxyUID, "proxy-uid", options.proxyUID, "Run the proxy under this user ID; the init container's iptables rules exempt this user's traffic from redirection. Can be overridden per workload with the "+k8s.ProxyUIDAnnotation+" annotation")
 	cmd.PersistentFlags().StringVar(&options.proxyLogLevel, "proxy-log-level", options.proxyLogLevel, "Log level for the proxy")
 	cmd.PersistentFlags().StringVar(&options.proxyBindTimeout, "proxy-bind-timeout", options.proxyBindTimeout, "Timeout the proxy will use")
+	cmd.PersistentFlags().StringVar(&options.proxyDetectTimeout, "proxy-detect-protocol-timeout", options.proxyDetectTimeout, "Time the proxy waits to detect the protocol of a connection (default: proxy default); can be overridden per workload with the "+k8s.ProxyDetectProtocolTimeoutAnnotation+" annotation")
 	cmd.PersistentFlags().UintVar(&options.proxyAPIPort, "api-port", options.proxyAPIPort, "Port where the Linkerd controller is running")
 	cmd.PersistentFlags().UintVar(&options.proxyControlPort, "control-port", options.proxyControlPort, "Proxy port to use for control")
 	cmd.PersistentFlags().UintVar(&options.proxyMetricsPort, "metrics-port", options.proxyMetricsPort, "Proxy port to serve metrics on")
+	cmd.PersistentFlags().StringSliceVar(&options.ignoreOutboundSubnets, "skip-outbound-subnets", options.ignoreOutboundSubnets, "Outbound subnets (in CIDR notation) that should skip the proxy, e.g. cloud metadata endpoints")
+	cmd.PersistentFlags().UintSliceVar(&options.opaquePorts, "opaque-ports", options.opaquePorts, "Ports on which the proxy skips protocol detection and forwards traffic as opaque TCP; can be overridden per workload with the "+k8s.ProxyOpaquePortsAnnotation+" annotation. This default only applies to the current command and isn't stored in the cluster, so pass the same ports to install, upgrade and inject; workloads injected with other ports are listed as outdated by \"linkerd proxy-config\"")
 	cmd.PersistentFlags().StringVar(&options.tls, "tls", options.tls, "Enable TLS; valid settings: \"optional\"")
 
 	cmd.PersistentFlags().StringVar(&options.proxyCpuRequest, "proxy-cpu", options.proxyCpuRequest, "Amount of CPU units that the proxy sidecar requests")
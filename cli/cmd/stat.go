@@ -4,17 +4,20 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
 )
 
 type statOptions struct {
@@ -25,6 +28,13 @@ type statOptions struct {
 	fromNamespace string
 	fromResource  string
 	allNamespaces bool
+	labelSelector string
+	perPod        bool
+	breakdownBy   string
+	showURLs      bool
+	grafanaURL    string
+	debugQueries  bool
+	output        string
 }
 
 func newStatOptions() *statOptions {
@@ -36,6 +46,13 @@ func newStatOptions() *statOptions {
 		fromNamespace: "",
 		fromResource:  "",
 		allNamespaces: false,
+		labelSelector: "",
+		perPod:        false,
+		breakdownBy:   "",
+		showURLs:      false,
+		grafanaURL:    "",
+		debugQueries:  false,
+		output:        tableOutput,
 	}
 }
 
@@ -69,6 +86,29 @@ Valid resource types include:
   * services (only supported if a --from is also specified, or as a --to)
   * all (all resource types, not supported in --from or --to)
 
+If a label selector is given with "--selector", only resources whose own labels match
+it are displayed, followed by a TOTAL row aggregating their traffic.
+
+If "--per-pod" is given, the stats of each pod backing the resources are displayed
+after the resources' own stats.
+
+If "--by authority" is given, the outbound stats of each resource are broken down by
+the authority they were sent to, and displayed after the resources' own stats.
+
+If a single pod is named, its stats are read directly from the pod instead of being
+aggregated over the pods of its namespace.
+
+"linkerd stat mesh" summarizes how many pods and workloads of each namespace have
+the proxy injected, and which proxy versions they run.
+
+With "-o json" or "-o yaml", the stats of every row returned by the public API,
+including the per-pod and per-authority rows, are exported in a single document
+with a "rows" list; the TOTAL row isn't, since it can be computed from the rows.
+
+If "--show-urls" is given, the URL of the Grafana dashboard of each deployment, pod,
+replication controller and service is appended to its row. The URLs are built from
+"--grafana-url", which defaults to the Grafana served through "kubectl proxy".
+
 This command will hide resources that have completed, such as pods that are in the Succeeded or Failed phases.
 If no resource name is specified, displays stats about all resources of the specified RESOURCETYPE`,
 		Example: `  # Get all deployments in the test namespace.
@@ -92,6 +132,27 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all services in all namespaces that receive calls from hello1 deployment in the test namespace.
   linkerd stat services --from deploy/hello1 --from-namespace test --all-namespaces
 
+  # Get all deployments in the ingress-nginx namespace that call any resource in the team-a namespace.
+  linkerd stat deployments -n ingress-nginx --to-namespace team-a
+
+  # Get all deployments in the team-a namespace that receive calls from the ingress-nginx namespace.
+  linkerd stat deployments -n team-a --from-namespace ingress-nginx
+
+  # Get all deployments in the test namespace labeled app=checkout, plus their aggregate.
+  linkerd stat deployments -n test --selector app=checkout
+
+  # Get the stats of the web deployment, followed by the stats of each of its pods.
+  linkerd stat deploy/web --per-pod
+
+  # Get the stats of the web deployment, followed by its stats for each authority it calls.
+  linkerd stat deploy/web --by authority
+
+  # Get all deployments in the test namespace, with links to their Grafana dashboards.
+  linkerd stat deployments -n test --show-urls --grafana-url https://grafana.example.com
+
+  # Get the stats of the web deployment, along with the Prometheus queries that computed them.
+  linkerd stat deploy/web --debug-queries
+
   # Get all namespaces that receive traffic from the default namespace.
   linkerd stat namespaces --from ns/default
 
@@ -119,15 +180,31 @@ If no resource name is specified, displays stats about all resources of the spec
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
 	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
 	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, restricts outbound stats to the specified resource name")
-	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used. If \"--to\" is not present, restricts outbound stats to all resources in this namespace")
 	cmd.PersistentFlags().StringVar(&options.fromResource, "from", options.fromResource, "If present, restricts outbound stats from the specified resource name")
-	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace, "Sets the namespace used from lookup the \"--from\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace, "Sets the namespace used from lookup the \"--from\" resource; by default the current \"--namespace\" is used. If \"--from\" is not present, restricts outbound stats from all resources in this namespace")
+	cmd.PersistentFlags().StringVar(&options.labelSelector, "selector", options.labelSelector, "Selector (label query) to filter resources on, supports '=', '==', and '!='")
 	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns stats across all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().BoolVar(&options.perPod, "per-pod", options.perPod, "If present, also returns stats for each pod backing the specified resources")
+	cmd.PersistentFlags().StringVar(&options.breakdownBy, "by", options.breakdownBy, "If set to \"authority\", also returns the outbound stats of the specified resources broken down by authority")
+	cmd.PersistentFlags().BoolVar(&options.showURLs, "show-urls", options.showURLs, "If present, appends the URL of the Grafana dashboard of each resource")
+	cmd.PersistentFlags().StringVar(&options.grafanaURL, "grafana-url", options.grafanaURL, "Base URL of the Grafana instance serving the Linkerd dashboards, used by \"--show-urls\"; by default the Grafana served through \"kubectl proxy\" is used")
+
+	cmd.PersistentFlags().BoolVar(&options.debugQueries, "debug-queries", options.debugQueries, "If present, also prints the Prometheus queries run to compute the stats, along with their execution times")
+	addOutputFlag(cmd, &options.output)
+
+	cmd.AddCommand(newCmdStatMesh(options))
+
+	addNamespaceCompletion(cmd)
 
 	return cmd
 }
 
 func requestStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest, options *statOptions) (string, error) {
+	if isSinglePodRequest(req) {
+		return requestPodStatsFromAPI(client, req, options)
+	}
+
 	resp, err := client.StatSummary(context.Background(), req)
 	if err != nil {
 		return "", fmt.Errorf("StatSummary API error: %v", err)
@@ -136,7 +213,212 @@ func requestStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest, option
 		return "", fmt.Errorf("StatSummary API response error: %v", e.Error)
 	}
 
-	return renderStats(resp, req.Selector.Resource.Type, options), nil
+	if isStructuredOutput(options.output) {
+		return renderStatsReport(resp, options)
+	}
+
+	output := renderStats(resp, req.Selector.Resource.Type, options)
+	if options.debugQueries {
+		output += renderQueries(resp.GetOk().GetQueries())
+	}
+	return output, nil
+}
+
+// renderQueries returns the Prometheus queries run for a StatSummary request,
+// one per line and prefixed by their execution time.
+func renderQueries(queries []*pb.PrometheusQuery) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("\nPrometheus queries:\n")
+	if len(queries) == 0 {
+		buffer.WriteString("None\n")
+		return buffer.String()
+	}
+
+	w := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	for _, query := range queries {
+		duration := "-"
+		if d, err := ptypes.Duration(query.GetDuration()); err == nil {
+			duration = formatDuration(d)
+		}
+		line := fmt.Sprintf("%s\t%s", duration, query.GetQuery())
+		if query.GetError() != "" {
+			line += fmt.Sprintf(" (error: %s)", query.GetError())
+		}
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+
+	return buffer.String()
+}
+
+// statReport is the document exported by "linkerd stat -o json" and
+// "-o yaml".
+type statReport struct {
+	Rows    []statRowJSON   `json:"rows"`
+	Queries []statQueryJSON `json:"queries,omitempty"`
+}
+
+// statRowJSON is a row of stats. The rows of a breakdown by authority are
+// identified by the resource that sent the requests, along with the authority.
+// The stats are null if the resource didn't handle any requests.
+type statRowJSON struct {
+	Namespace    string     `json:"namespace,omitempty"`
+	Kind         string     `json:"kind"`
+	Name         string     `json:"name"`
+	Authority    string     `json:"authority,omitempty"`
+	MeshedPods   uint64     `json:"meshedPods"`
+	RunningPods  uint64     `json:"runningPods"`
+	Stats        *statsJSON `json:"stats"`
+	DashboardURL string     `json:"dashboardURL,omitempty"`
+}
+
+type statsJSON struct {
+	SuccessRate  float64 `json:"successRate"`
+	RequestRate  float64 `json:"requestRate"`
+	LatencyMsP50 uint64  `json:"latencyMsP50"`
+	LatencyMsP95 uint64  `json:"latencyMsP95"`
+	LatencyMsP99 uint64  `json:"latencyMsP99"`
+	TLSRate      float64 `json:"tlsRate"`
+}
+
+type statQueryJSON struct {
+	Query      string  `json:"query"`
+	DurationMs float64 `json:"durationMs"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// renderStatsReport exports the rows of a StatSummary response, sorted by
+// kind, namespace, name and authority, in a structured output format.
+func renderStatsReport(resp *pb.StatSummaryResponse, options *statOptions) (string, error) {
+	report := statReport{Rows: make([]statRowJSON, 0)}
+
+	for _, statTable := range resp.GetOk().GetStatTables() {
+		for _, r := range statTable.GetPodGroup().GetRows() {
+			row := statRowJSON{
+				Namespace:   r.GetResource().GetNamespace(),
+				Kind:        r.GetResource().GetType(),
+				Name:        r.GetResource().GetName(),
+				MeshedPods:  r.GetMeshedPodCount(),
+				RunningPods: r.GetRunningPodCount(),
+			}
+			if parent := r.GetParent(); parent != nil {
+				row.Namespace = parent.GetNamespace()
+				row.Kind = parent.GetType()
+				row.Name = parent.GetName()
+				row.Authority = r.GetResource().GetName()
+			} else if options.showURLs {
+				row.DashboardURL = grafanaDashboardURL(options.grafanaBaseURL(), row.Kind, row.Namespace, row.Name)
+			}
+
+			if r.GetStats() != nil {
+				stats := getRowStats(*r)
+				row.Stats = &statsJSON{
+					SuccessRate:  stats.successRate,
+					RequestRate:  stats.requestRate,
+					LatencyMsP50: stats.latencyP50,
+					LatencyMsP95: stats.latencyP95,
+					LatencyMsP99: stats.latencyP99,
+					TLSRate:      stats.tlsPercent,
+				}
+			}
+			report.Rows = append(report.Rows, row)
+		}
+	}
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Authority < b.Authority
+	})
+
+	if options.debugQueries {
+		for _, query := range resp.GetOk().GetQueries() {
+			exported := statQueryJSON{Query: query.GetQuery(), Error: query.GetError()}
+			if d, err := ptypes.Duration(query.GetDuration()); err == nil {
+				exported.DurationMs = float64(d) / float64(time.Millisecond)
+			}
+			report.Queries = append(report.Queries, exported)
+		}
+	}
+
+	return marshalOutput(report, options.output)
+}
+
+// isSinglePodRequest returns true if req only selects the inbound stats of a
+// named pod, which the PodStats API returns without listing the pods of the
+// namespace.
+func isSinglePodRequest(req *pb.StatSummaryRequest) bool {
+	resource := req.GetSelector().GetResource()
+	if resource.GetType() != k8s.Pod || resource.GetName() == "" {
+		return false
+	}
+	if req.GetSelector().GetLabelSelector() != "" || req.GetPerPod() || req.GetBreakdownBy() != "" || req.GetDebugQueries() {
+		return false
+	}
+	return req.GetToResource() == nil && req.GetFromResource() == nil
+}
+
+func requestPodStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest, options *statOptions) (string, error) {
+	resource := req.GetSelector().GetResource()
+	rsp, err := client.PodStats(context.Background(), &pb.PodStatsRequest{
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		TimeWindow: req.GetTimeWindow(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("PodStats API error: %v", err)
+	}
+	if e := rsp.GetError(); e != nil {
+		return "", fmt.Errorf("PodStats API response error: %v", e.Error)
+	}
+
+	resp := podStatsToStatSummary(rsp.GetOk())
+	if isStructuredOutput(options.output) {
+		return renderStatsReport(resp, options)
+	}
+	return renderStats(resp, k8s.Pod, options), nil
+}
+
+// podStatsToStatSummary converts the stats of a single pod into a StatSummary
+// response with a single row, so that they're rendered like any other stats.
+func podStatsToStatSummary(stats *pb.PodStats) *pb.StatSummaryResponse {
+	row := &pb.StatTable_PodGroup_Row{
+		Resource:   stats.GetResource(),
+		TimeWindow: stats.GetTimeWindow(),
+		Stats:      stats.GetStats(),
+	}
+	if stats.GetStatus() == string(v1.PodFailed) {
+		row.FailedPodCount = 1
+	} else {
+		row.RunningPodCount = 1
+		if stats.GetMeshed() {
+			row.MeshedPodCount = 1
+		}
+	}
+
+	return &pb.StatSummaryResponse{
+		Response: &pb.StatSummaryResponse_Ok_{
+			Ok: &pb.StatSummaryResponse_Ok{
+				StatTables: []*pb.StatTable{
+					{
+						Table: &pb.StatTable_PodGroup_{
+							PodGroup: &pb.StatTable_PodGroup{
+								Rows: []*pb.StatTable_PodGroup_Row{row},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 }
 
 func renderStats(resp *pb.StatSummaryResponse, resourceType string, options *statOptions) string {
@@ -154,6 +436,9 @@ func renderStats(resp *pb.StatSummaryResponse, resourceType string, options *sta
 
 const padding = 3
 
+// kubectlProxyPort is the port "kubectl proxy" listens on by default.
+const kubectlProxyPort = 8001
+
 type rowStats struct {
 	requestRate float64
 	successRate float64
@@ -163,28 +448,61 @@ type rowStats struct {
 	latencyP99  uint64
 }
 
+// breakdownRow is a row of stats for the traffic of a resource to one of its
+// destinations, such as an authority.
+type breakdownRow struct {
+	namespace string
+	name      string
+	by        string
+	*rowStats
+}
+
 type row struct {
-	meshed string
+	meshed       string
+	meshedPods   uint64
+	runningPods  uint64
+	dashboardURL string
 	*rowStats
 }
 
 var (
 	nameHeader      = "NAME"
 	namespaceHeader = "NAMESPACE"
+	authorityHeader = "AUTHORITY"
+	grafanaHeader   = "GRAFANA"
+	totalRowName    = "TOTAL"
 )
 
 func writeStatsToBuffer(resp *pb.StatSummaryResponse, reqResourceType string, w *tabwriter.Writer, options *statOptions) {
 	maxNameLength := len(nameHeader)
 	maxNamespaceLength := len(namespaceHeader)
 	statTables := make(map[string]map[string]*row)
+	breakdown := make(map[string]*breakdownRow)
+
+	if options.labelSelector != "" && len(totalRowName) > maxNameLength {
+		maxNameLength = len(totalRowName)
+	}
 
 	for _, statTable := range resp.GetOk().StatTables {
 		table := statTable.GetPodGroup()
 
 		for _, r := range table.Rows {
+			if r.Parent != nil {
+				key := fmt.Sprintf("%s/%s/%s", r.Parent.Namespace, r.Parent.Name, r.Resource.Name)
+				breakdown[key] = &breakdownRow{
+					namespace: r.Parent.Namespace,
+					name:      r.Parent.Name,
+					by:        r.Resource.Name,
+				}
+				if r.Stats != nil {
+					breakdown[key].rowStats = getRowStats(*r)
+				}
+				continue
+			}
+
 			name := r.Resource.Name
 			nameWithPrefix := name
-			if reqResourceType == k8s.All {
+			if reqResourceType == k8s.All || r.Resource.Type != reqResourceType {
 				nameWithPrefix = getNamePrefix(r.Resource.Type) + nameWithPrefix
 			}
 
@@ -209,18 +527,17 @@ func writeStatsToBuffer(resp *pb.StatSummaryResponse, reqResourceType string, w
 				meshedCount = "-"
 			}
 			statTables[resourceKey][key] = &row{
-				meshed: meshedCount,
+				meshed:      meshedCount,
+				meshedPods:  r.MeshedPodCount,
+				runningPods: r.RunningPodCount,
+			}
+
+			if options.showURLs {
+				statTables[resourceKey][key].dashboardURL = grafanaDashboardURL(options.grafanaBaseURL(), resourceKey, namespace, name)
 			}
 
 			if r.Stats != nil {
-				statTables[resourceKey][key].rowStats = &rowStats{
-					requestRate: getRequestRate(*r),
-					successRate: getSuccessRate(*r),
-					tlsPercent:  getPercentTls(*r),
-					latencyP50:  r.Stats.LatencyMsP50,
-					latencyP95:  r.Stats.LatencyMsP95,
-					latencyP99:  r.Stats.LatencyMsP99,
-				}
+				statTables[resourceKey][key].rowStats = getRowStats(*r)
 			}
 		}
 	}
@@ -240,11 +557,97 @@ func writeStatsToBuffer(resp *pb.StatSummaryResponse, reqResourceType string, w
 				}
 				firstDisplayedStat = false
 				printStatTable(stats, resourceType, w, maxNameLength, maxNamespaceLength, options)
+				if options.labelSelector != "" {
+					printTotalRow(stats, w, maxNameLength, maxNamespaceLength, options)
+				}
 			}
 		}
 	default:
 		if stats, ok := statTables[reqResourceType]; ok {
 			printStatTable(stats, "", w, maxNameLength, maxNamespaceLength, options)
+			// the pods of a per-pod query are those of the selected resources,
+			// so the resources' total row is the only aggregate
+			if options.labelSelector != "" {
+				printTotalRow(stats, w, maxNameLength, maxNamespaceLength, options)
+			}
+		}
+		if options.perPod {
+			if stats, ok := statTables[k8s.Pod]; ok {
+				fmt.Fprint(w, "\n")
+				printStatTable(stats, k8s.Pod, w, maxNameLength, maxNamespaceLength, options)
+			}
+		}
+		if options.breakdownBy != "" && len(breakdown) > 0 {
+			fmt.Fprint(w, "\n")
+			printBreakdownTable(breakdown, w, maxNameLength, maxNamespaceLength, options)
+		}
+	}
+}
+
+// printBreakdownTable prints the stats of each resource for each of the
+// authorities it sends requests to, grouped by resource.
+func printBreakdownTable(breakdown map[string]*breakdownRow, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, options *statOptions) {
+	maxAuthorityLength := len(authorityHeader)
+	for _, r := range breakdown {
+		if len(r.by) > maxAuthorityLength {
+			maxAuthorityLength = len(r.by)
+		}
+	}
+
+	headers := make([]string, 0)
+	if options.allNamespaces {
+		headers = append(headers,
+			namespaceHeader+strings.Repeat(" ", maxNamespaceLength-len(namespaceHeader)))
+	}
+	headers = append(headers, []string{
+		nameHeader + strings.Repeat(" ", maxNameLength-len(nameHeader)),
+		authorityHeader + strings.Repeat(" ", maxAuthorityLength-len(authorityHeader)),
+		"SUCCESS",
+		"RPS",
+		"LATENCY_P50",
+		"LATENCY_P95",
+		"LATENCY_P99",
+		"TLS\t", // trailing \t is required to format last column
+	}...)
+
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	keys := make([]string, 0)
+	for key := range breakdown {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		r := breakdown[key]
+		values := make([]interface{}, 0)
+		templateString := "%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t%.f%%\t\n"
+		templateStringEmpty := "%s\t%s\t-\t-\t-\t-\t-\t-\t\n"
+
+		if options.allNamespaces {
+			values = append(values,
+				r.namespace+strings.Repeat(" ", maxNamespaceLength-len(r.namespace)))
+			templateString = "%s\t" + templateString
+			templateStringEmpty = "%s\t" + templateStringEmpty
+		}
+		values = append(values, []interface{}{
+			r.name + strings.Repeat(" ", maxNameLength-len(r.name)),
+			r.by + strings.Repeat(" ", maxAuthorityLength-len(r.by)),
+		}...)
+
+		if r.rowStats != nil {
+			values = append(values, []interface{}{
+				r.successRate * 100,
+				r.requestRate,
+				r.latencyP50,
+				r.latencyP95,
+				r.latencyP99,
+				r.tlsPercent * 100,
+			}...)
+
+			fmt.Fprintf(w, templateString, values...)
+		} else {
+			fmt.Fprintf(w, templateStringEmpty, values...)
 		}
 	}
 }
@@ -265,6 +668,10 @@ func printStatTable(stats map[string]*row, resourceType string, w *tabwriter.Wri
 		"LATENCY_P99",
 		"TLS\t", // trailing \t is required to format last column
 	}...)
+	if options.showURLs {
+		// the URLs follow the last column, so that they're left-aligned
+		headers[len(headers)-1] += strings.Repeat(" ", padding) + grafanaHeader
+	}
 
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
 
@@ -299,14 +706,65 @@ func printStatTable(stats map[string]*row, resourceType string, w *tabwriter.Wri
 				stats[key].latencyP99,
 				stats[key].tlsPercent * 100,
 			}...)
-
-			fmt.Fprintf(w, templateString, values...)
 		} else {
-			fmt.Fprintf(w, templateStringEmpty, values...)
+			templateString = templateStringEmpty
+		}
+
+		if options.showURLs {
+			templateString = strings.TrimSuffix(templateString, "\n") + strings.Repeat(" ", padding) + "%s\n"
+			values = append(values, stats[key].dashboardURL)
 		}
+
+		fmt.Fprintf(w, templateString, values...)
 	}
 }
 
+// printTotalRow aggregates all of the rows in a table that was filtered by a
+// label selector. Latency percentiles can't be combined across resources, so
+// they're omitted.
+func printTotalRow(stats map[string]*row, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, options *statOptions) {
+	var meshedPods, runningPods uint64
+	var requestRate, successRate, tlsRate float64
+
+	for _, r := range stats {
+		meshedPods += r.meshedPods
+		runningPods += r.runningPods
+
+		if r.rowStats != nil {
+			requestRate += r.requestRate
+			successRate += r.successRate * r.requestRate
+			tlsRate += r.tlsPercent * r.requestRate
+		}
+	}
+
+	values := make([]interface{}, 0)
+	templateString := "%s\t%d/%d\t%.2f%%\t%.1frps\t-\t-\t-\t%.f%%\t\n"
+	templateStringEmpty := "%s\t%d/%d\t-\t-\t-\t-\t-\t-\t\n"
+
+	if options.allNamespaces {
+		values = append(values, "-"+strings.Repeat(" ", maxNamespaceLength-1))
+		templateString = "%s\t" + templateString
+		templateStringEmpty = "%s\t" + templateStringEmpty
+	}
+	values = append(values, []interface{}{
+		totalRowName + strings.Repeat(" ", maxNameLength-len(totalRowName)),
+		meshedPods,
+		runningPods,
+	}...)
+
+	if requestRate == 0 {
+		fmt.Fprintf(w, templateStringEmpty, values...)
+		return
+	}
+
+	values = append(values, []interface{}{
+		successRate / requestRate * 100,
+		requestRate,
+		tlsRate / requestRate * 100,
+	}...)
+	fmt.Fprintf(w, templateString, values...)
+}
+
 func getNamePrefix(resourceType string) string {
 	if resourceType == "" {
 		return ""
@@ -353,11 +811,26 @@ func buildStatSummaryRequest(resource []string, options *statOptions) (*pb.StatS
 		FromType:      fromRes.Type,
 		FromNamespace: options.fromNamespace,
 		AllNamespaces: options.allNamespaces,
+		LabelSelector: options.labelSelector,
+		PerPod:        options.perPod,
+		BreakdownBy:   options.breakdownBy,
+		DebugQueries:  options.debugQueries,
 	}
 
 	return util.BuildStatSummaryRequest(requestParams)
 }
 
+func getRowStats(r pb.StatTable_PodGroup_Row) *rowStats {
+	return &rowStats{
+		requestRate: getRequestRate(r),
+		successRate: getSuccessRate(r),
+		tlsPercent:  getPercentTls(r),
+		latencyP50:  r.Stats.LatencyMsP50,
+		latencyP95:  r.Stats.LatencyMsP95,
+		latencyP99:  r.Stats.LatencyMsP99,
+	}
+}
+
 func getRequestRate(r pb.StatTable_PodGroup_Row) float64 {
 	success := r.Stats.SuccessCount
 	failure := r.Stats.FailureCount
@@ -399,6 +872,10 @@ func sortStatsKeys(stats map[string]*row) []string {
 // validate performs all validation on the command-line options.
 // It returns the first error encountered, or `nil` if the options are valid.
 func (o *statOptions) validate(resourceType string) error {
+	if err := validateOutput(o.output); err != nil {
+		return err
+	}
+
 	err := o.validateConflictingFlags()
 	if err != nil {
 		return err
@@ -411,9 +888,54 @@ func (o *statOptions) validate(resourceType string) error {
 		}
 	}
 
+	if o.perPod {
+		switch resourceType {
+		case k8s.All, k8s.Pod, k8s.Authority:
+			return fmt.Errorf("--per-pod flag is incompatible with %s resource type", resourceType)
+		}
+		if o.fromResource != "" || o.fromNamespace != "" {
+			return fmt.Errorf("--per-pod and --from flags are mutually exclusive")
+		}
+	}
+
+	if o.breakdownBy != "" {
+		switch resourceType {
+		case k8s.All, k8s.Authority:
+			return fmt.Errorf("--by flag is incompatible with %s resource type", resourceType)
+		}
+		if o.fromResource != "" || o.fromNamespace != "" {
+			return fmt.Errorf("--by and --from flags are mutually exclusive")
+		}
+	}
+
 	return nil
 }
 
+// grafanaBaseURL returns the base URL of the Grafana instance that serves the
+// Linkerd dashboards.
+func (o *statOptions) grafanaBaseURL() string {
+	if o.grafanaURL != "" {
+		return strings.TrimSuffix(o.grafanaURL, "/")
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d/api/v1/namespaces/%s/services/grafana:http/proxy", kubectlProxyPort, controlPlaneNamespace)
+}
+
+// grafanaDashboardURL returns the URL of the Grafana dashboard of a resource,
+// using the same variables as the links in the web dashboard, or "-" if
+// there's no dashboard for the resource's type.
+func grafanaDashboardURL(baseURL, resourceType, namespace, name string) string {
+	switch resourceType {
+	case k8s.Deployment, k8s.Pod, k8s.ReplicationController, k8s.Service:
+	default:
+		return "-"
+	}
+
+	query := url.Values{}
+	query.Set("var-namespace", namespace)
+	query.Set("var-"+resourceType, name)
+	return fmt.Sprintf("%s/dashboard/db/linkerd-%s?%s", baseURL, resourceType, query.Encode())
+}
+
 // validateConflictingFlags validates that the options do not contain mutually
 // exclusive flags.
 func (o *statOptions) validateConflictingFlags() error {
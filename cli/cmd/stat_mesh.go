@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/spf13/cobra"
+)
+
+func newCmdStatMesh(options *statOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mesh [flags]",
+		Short: "Display a summary of the adoption of the mesh",
+		Long: `Display a summary of the adoption of the mesh.
+
+For each namespace, the pending and running pods that have the Linkerd proxy
+injected are counted, along with the workloads they belong to, the workloads
+that still have pods without the proxy, and the versions of the injected proxies.`,
+		Example: `  # Summarize the adoption of the mesh in the emojivoto namespace.
+  linkerd stat mesh -n emojivoto
+
+  # Summarize the adoption of the mesh in all namespaces.
+  linkerd stat mesh --all-namespaces`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.MeshCoverageRequest{Namespace: options.namespace}
+			if options.allNamespaces {
+				req.Namespace = ""
+			}
+
+			output, err := requestMeshCoverageFromAPI(validatedPublicAPIClient(time.Time{}), req)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Print(output)
+			return err
+		},
+	}
+
+	return cmd
+}
+
+func requestMeshCoverageFromAPI(client pb.ApiClient, req *pb.MeshCoverageRequest) (string, error) {
+	rsp, err := client.MeshCoverage(context.Background(), req)
+	if err != nil {
+		return "", fmt.Errorf("MeshCoverage API error: %v", err)
+	}
+
+	return renderMeshCoverage(rsp.GetNamespaces()), nil
+}
+
+// renderMeshCoverage returns a table with a row per namespace, where the
+// meshed pods are shown out of all the pending and running pods, and the
+// proxy versions are listed with the number of pods running each of them.
+func renderMeshCoverage(namespaces []*pb.NamespaceCoverage) string {
+	if len(namespaces) == 0 {
+		return "No pods found.\n"
+	}
+
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tMESHED PODS\tMESHED WORKLOADS\tUNMESHED WORKLOADS\tPROXY VERSIONS")
+
+	for _, ns := range namespaces {
+		versions := []string{}
+		for _, v := range ns.GetProxyVersions() {
+			version := v.GetVersion()
+			if version == "" {
+				version = "unknown"
+			}
+			versions = append(versions, fmt.Sprintf("%s (%d)", version, v.GetPodCount()))
+		}
+		if len(versions) == 0 {
+			versions = append(versions, "-")
+		}
+
+		fmt.Fprintf(w, "%s\t%d/%d\t%d\t%d\t%s\n",
+			ns.GetNamespace(),
+			ns.GetMeshedPodCount(),
+			ns.GetMeshedPodCount()+ns.GetUnmeshedPodCount(),
+			ns.GetMeshedWorkloadCount(),
+			len(ns.GetUnmeshedWorkloads()),
+			strings.Join(versions, ", "),
+		)
+	}
+
+	w.Flush()
+	return buffer.String()
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestStatMesh(t *testing.T) {
+	t.Run("Renders the adoption of the mesh per namespace", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			MeshCoverageResponseToReturn: &pb.MeshCoverageResponse{
+				Namespaces: []*pb.NamespaceCoverage{
+					{
+						Namespace:        "booksapp",
+						UnmeshedPodCount: 2,
+						UnmeshedWorkloads: []*pb.Resource{
+							{Namespace: "booksapp", Type: "deployment", Name: "books"},
+						},
+					},
+					{
+						Namespace:           "emojivoto",
+						MeshedPodCount:      3,
+						UnmeshedPodCount:    1,
+						MeshedWorkloadCount: 2,
+						UnmeshedWorkloads: []*pb.Resource{
+							{Namespace: "emojivoto", Type: "deployment", Name: "vote-bot"},
+						},
+						ProxyVersions: []*pb.ProxyVersionCount{
+							{Version: "edge-18.10.1", PodCount: 1},
+							{Version: "edge-18.10.2", PodCount: 2},
+						},
+					},
+				},
+			},
+		}
+
+		expectedOutput := `NAMESPACE   MESHED PODS   MESHED WORKLOADS   UNMESHED WORKLOADS   PROXY VERSIONS
+booksapp    0/2           0                  1                    -
+emojivoto   3/4           2                  1                    edge-18.10.1 (1), edge-18.10.2 (2)
+`
+
+		output, err := requestMeshCoverageFromAPI(mockClient, &pb.MeshCoverageRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Reports namespaces without pods", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			MeshCoverageResponseToReturn: &pb.MeshCoverageResponse{},
+		}
+
+		output, err := requestMeshCoverageFromAPI(mockClient, &pb.MeshCoverageRequest{Namespace: "empty"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != "No pods found.\n" {
+			t.Fatalf("Unexpected output: %s", output)
+		}
+	})
+}
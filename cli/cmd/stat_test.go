@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"io/ioutil"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 )
 
@@ -42,6 +45,434 @@ emoji      1/2   100.00%   2.0rps         123ms         123ms         123ms   10
 		}
 	})
 
+	t.Run("Returns a total row for label selector queries", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		counts := &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 2,
+			FailedPods:  0,
+		}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, "emojivoto", counts)
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME    MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+emoji      1/2   100.00%   2.0rps         123ms         123ms         123ms   100%
+TOTAL      1/2   100.00%   2.0rps             -             -             -   100%
+`
+
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.labelSelector = "app=emoji"
+		args := []string{"deploy"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if req.Selector.LabelSelector != options.labelSelector {
+			t.Fatalf("Expected label selector [%s] in request, got [%s]", options.labelSelector, req.Selector.LabelSelector)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns pod stats after the resource stats for per-pod queries", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		counts := &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, "emojivoto", counts)
+		podResponse := public.GenStatSummaryResponse("emojivoto-meshed", k8s.Pod, "emojivoto", counts)
+		response.GetOk().StatTables = append(response.GetOk().StatTables, podResponse.GetOk().StatTables...)
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME                  MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+emoji                    1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+
+NAME                  MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+po/emojivoto-meshed      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+`
+
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.perPod = true
+		args := []string{"deploy/emoji"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !req.PerPod {
+			t.Fatal("Expected a per-pod request")
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns a single total row for per-pod label selector queries", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		counts := &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, "emojivoto", counts)
+		podResponse := public.GenStatSummaryResponse("emojivoto-meshed", k8s.Pod, "emojivoto", counts)
+		response.GetOk().StatTables = append(response.GetOk().StatTables, podResponse.GetOk().StatTables...)
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME                  MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+emoji                    1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+TOTAL                    1/1   100.00%   2.0rps             -             -             -   100%
+
+NAME                  MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+po/emojivoto-meshed      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+`
+
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.labelSelector = "app=emoji"
+		options.perPod = true
+		args := []string{"deploy"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns the stats of a single pod from the PodStats API", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		response := public.GenStatSummaryResponse("emojivoto-meshed", k8s.Pod, "emojivoto", nil)
+		row := response.GetOk().StatTables[0].GetPodGroup().Rows[0]
+		mockClient.PodStatsResponseToReturn = &pb.PodStatsResponse{
+			Response: &pb.PodStatsResponse_Ok{
+				Ok: &pb.PodStats{
+					Resource:   row.Resource,
+					TimeWindow: row.TimeWindow,
+					Status:     "Running",
+					Meshed:     true,
+					Stats:      row.Stats,
+				},
+			},
+		}
+
+		expectedOutput := `NAME               MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+emojivoto-meshed      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+`
+
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		args := []string{"po/emojivoto-meshed"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !isSinglePodRequest(req) {
+			t.Fatal("Expected a single pod request")
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+
+		options.toResource = "deploy/web"
+		req, err = buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if isSinglePodRequest(req) {
+			t.Fatal("Expected outbound pod stats to be requested from the StatSummary API")
+		}
+	})
+
+	t.Run("Returns the Prometheus queries with --debug-queries", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		counts := &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, "emojivoto", counts)
+		response.GetOk().Queries = []*pb.PrometheusQuery{
+			{
+				Query:    "histogram_quantile(0.5, latency)",
+				Duration: &duration.Duration{Nanos: 3000000},
+			},
+			{
+				Query:    "sum(increase(response_total[1m]))",
+				Duration: &duration.Duration{Nanos: 12000000},
+				Error:    "timeout",
+			},
+		}
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME    MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+emoji      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+
+Prometheus queries:
+3ms   histogram_quantile(0.5, latency)
+12ms  sum(increase(response_total[1m])) (error: timeout)
+`
+
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.debugQueries = true
+		args := []string{"deploy"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !req.GetDebugQueries() {
+			t.Fatal("Expected the request to ask for the Prometheus queries")
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns Grafana dashboard URLs with --show-urls", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		counts := &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, "emojivoto", counts)
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME    MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS   GRAFANA
+emoji      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%   https://grafana.example.com/dashboard/db/linkerd-deployment?var-deployment=emoji&var-namespace=emojivoto
+`
+
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.showURLs = true
+		options.grafanaURL = "https://grafana.example.com/"
+		args := []string{"deploy/emoji"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns authority stats after the resource stats for authority breakdowns", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		counts := &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, "emojivoto", counts)
+		authorityResponse := public.GenStatSummaryResponse("voting-svc.emojivoto:8080", k8s.Authority, "emojivoto", nil)
+		authorityResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].Parent = &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      k8s.Deployment,
+			Name:      "emoji",
+		}
+		response.GetOk().StatTables = append(response.GetOk().StatTables, authorityResponse.GetOk().StatTables...)
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME    MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+emoji      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+
+NAME    AUTHORITY                   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+emoji   voting-svc.emojivoto:8080   100.00%   2.0rps         123ms         123ms         123ms   100%
+`
+
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.breakdownBy = "au"
+		args := []string{"deploy/emoji"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if req.BreakdownBy != k8s.Authority {
+			t.Fatalf("Expected an authority breakdown request, got [%s]", req.BreakdownBy)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Exports the stats as JSON and YAML", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		counts := &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 2,
+			FailedPods:  0,
+		}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, "emojivoto", counts)
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutputs := map[string]string{
+			"json": `{
+  "rows": [
+    {
+      "namespace": "emojivoto",
+      "kind": "deployment",
+      "name": "emoji",
+      "meshedPods": 1,
+      "runningPods": 2,
+      "stats": {
+        "successRate": 1,
+        "requestRate": 2.05,
+        "latencyMsP50": 123,
+        "latencyMsP95": 123,
+        "latencyMsP99": 123,
+        "tlsRate": 1
+      }
+    }
+  ]
+}
+`,
+			"yaml": `rows:
+- kind: deployment
+  meshedPods: 1
+  name: emoji
+  namespace: emojivoto
+  runningPods: 2
+  stats:
+    latencyMsP50: 123
+    latencyMsP95: 123
+    latencyMsP99: 123
+    requestRate: 2.05
+    successRate: 1
+    tlsRate: 1
+`,
+		}
+
+		for format, expectedOutput := range expectedOutputs {
+			options := newStatOptions()
+			options.output = format
+			req, err := buildStatSummaryRequest([]string{"deploy"}, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			output, err := requestStatsFromAPI(mockClient, req, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if output != expectedOutput {
+				t.Fatalf("Wrong %s output:\n expected: \n%s\n, got: \n%s", format, expectedOutput, output)
+			}
+		}
+	})
+
+	t.Run("Rejects unsupported output formats", func(t *testing.T) {
+		options := newStatOptions()
+		options.output = "wide"
+		expectedError := "output format \"wide\" not recognized"
+
+		_, err := buildStatSummaryRequest([]string{"deploy"}, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Rejects --by flag when the target is an authority", func(t *testing.T) {
+		options := newStatOptions()
+		options.breakdownBy = "authority"
+		args := []string{"au"}
+		expectedError := "--by flag is incompatible with authority resource type"
+
+		_, err := buildStatSummaryRequest(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Rejects --per-pod flag when the target is a pod", func(t *testing.T) {
+		options := newStatOptions()
+		options.perPod = true
+		args := []string{"po"}
+		expectedError := "--per-pod flag is incompatible with pod resource type"
+
+		_, err := buildStatSummaryRequest(args, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
 	t.Run("Returns an error for named resource queries with the --all-namespaces flag", func(t *testing.T) {
 		options := newStatOptions()
 		options.allNamespaces = true
@@ -104,3 +535,37 @@ emoji      1/2   100.00%   2.0rps         123ms         123ms         123ms   10
 		}
 	})
 }
+
+func TestGrafanaDashboardURL(t *testing.T) {
+	options := newStatOptions()
+	expectedURL := "http://127.0.0.1:8001/api/v1/namespaces/linkerd/services/grafana:http/proxy/dashboard/db/linkerd-pod?var-namespace=emojivoto&var-pod=web-1"
+	url := grafanaDashboardURL(options.grafanaBaseURL(), k8s.Pod, "emojivoto", "web-1")
+	if url != expectedURL {
+		t.Fatalf("Expected URL [%s], got [%s]", expectedURL, url)
+	}
+
+	url = grafanaDashboardURL(options.grafanaBaseURL(), k8s.Namespace, "", "emojivoto")
+	if url != "-" {
+		t.Fatalf("Expected no URL for namespaces, got [%s]", url)
+	}
+}
+
+// executeRootCmd runs the CLI with args through RootCmd, discarding its
+// output, so that the subcommand's flags are merged with the root's
+// persistent flags as they are when the CLI runs.
+func executeRootCmd(t *testing.T, args ...string) {
+	RootCmd.SetArgs(args)
+	RootCmd.SetOutput(ioutil.Discard)
+	defer func() {
+		RootCmd.SetArgs(nil)
+		RootCmd.SetOutput(nil)
+	}()
+
+	if err := RootCmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestStatFlags(t *testing.T) {
+	executeRootCmd(t, "stat", "deploy", "--selector", "app=web", "--linkerd-namespace", "linkerd", "--help")
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
@@ -16,28 +18,32 @@ import (
 )
 
 type tapOptions struct {
-	namespace   string
-	toResource  string
-	toNamespace string
-	maxRps      float32
-	scheme      string
-	method      string
-	authority   string
-	path        string
-	output      string
+	namespace    string
+	toResource   string
+	toNamespace  string
+	toIdentity   string
+	fromIdentity string
+	maxRps       float32
+	scheme       string
+	method       string
+	authority    string
+	path         string
+	output       string
 }
 
 func newTapOptions() *tapOptions {
 	return &tapOptions{
-		namespace:   "default",
-		toResource:  "",
-		toNamespace: "",
-		maxRps:      100.0,
-		scheme:      "",
-		method:      "",
-		authority:   "",
-		path:        "",
-		output:      "",
+		namespace:    "default",
+		toResource:   "",
+		toNamespace:  "",
+		toIdentity:   "",
+		fromIdentity: "",
+		maxRps:       100.0,
+		scheme:       "",
+		method:       "",
+		authority:    "",
+		path:         "",
+		output:       "",
 	}
 }
 
@@ -64,7 +70,13 @@ func newCmdTap() *cobra.Command {
   * namespaces
   * pods
   * replicationcontrollers
-  * services (only supported as a "--to" resource)`,
+  * services (only supported as a "--to" resource)
+
+  With "-o har", the requests are captured until the tap session ends or is
+  interrupted, and are then written as a HAR document, which can be opened in
+  browser developer tools and other HTTP analysis tools. The HAR entries have
+  the request line, response status, size and timings of each request; tap
+  doesn't capture headers, so they have none.`,
 		Example: `  # tap the web deployment in the default namespace
   linkerd tap deploy/web
 
@@ -72,20 +84,28 @@ func newCmdTap() *cobra.Command {
   linkerd tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  linkerd tap ns/test --to ns/prod`,
+  linkerd tap ns/test --to ns/prod
+
+  # tap the books deployment, filter by mTLS requests from the webapp deployment
+  linkerd tap deploy/books --from-identity webapp.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local
+
+  # capture the requests to the web deployment as a HAR document
+  linkerd tap deploy/web -o har > web.har`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			requestParams := util.TapRequestParams{
-				Resource:    strings.Join(args, "/"),
-				Namespace:   options.namespace,
-				ToResource:  options.toResource,
-				ToNamespace: options.toNamespace,
-				MaxRps:      options.maxRps,
-				Scheme:      options.scheme,
-				Method:      options.method,
-				Authority:   options.authority,
-				Path:        options.path,
+				Resource:     strings.Join(args, "/"),
+				Namespace:    options.namespace,
+				ToResource:   options.toResource,
+				ToNamespace:  options.toNamespace,
+				ToIdentity:   options.toIdentity,
+				FromIdentity: options.fromIdentity,
+				MaxRps:       options.maxRps,
+				Scheme:       options.scheme,
+				Method:       options.method,
+				Authority:    options.authority,
+				Path:         options.path,
 			}
 
 			req, err := util.BuildTapByResourceRequest(requestParams)
@@ -95,11 +115,14 @@ func newCmdTap() *cobra.Command {
 
 			wide := false
 			switch options.output {
-			// TODO: support more output formats?
 			case "":
 				// default output format.
 			case "wide":
 				wide = true
+			case "har":
+				stop := make(chan os.Signal, 1)
+				signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+				return requestTapHARFromAPI(os.Stdout, validatedPublicAPIClient(time.Time{}), req, stop)
 			default:
 				return fmt.Errorf("output format \"%s\" not recognized", options.output)
 			}
@@ -114,8 +137,12 @@ func newCmdTap() *cobra.Command {
 		"Display requests to this resource")
 	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace,
 		"Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().StringVar(&options.toIdentity, "to-identity", options.toIdentity,
+		"Display requests sent over TLS to pods with this identity")
+	cmd.PersistentFlags().StringVar(&options.fromIdentity, "from-identity", options.fromIdentity,
+		"Display requests sent over TLS from pods with this identity")
 	cmd.PersistentFlags().Float32Var(&options.maxRps, "max-rps", options.maxRps,
-		"Maximum requests per second to tap.")
+		"Maximum requests per second to tap, up to the limit configured with \"linkerd install --tap-max-rps\".")
 	cmd.PersistentFlags().StringVar(&options.scheme, "scheme", options.scheme,
 		"Display requests with this scheme")
 	cmd.PersistentFlags().StringVar(&options.method, "method", options.method,
@@ -125,7 +152,9 @@ func newCmdTap() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
 	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
-		"Output format. One of: wide")
+		"Output format. One of: wide, har")
+
+	addNamespaceCompletion(cmd)
 
 	return cmd
 }
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	"github.com/linkerd/linkerd2/pkg/version"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// The types below are the subset of the HAR 1.2 format written by
+// "linkerd tap -o har", as specified in
+// http://www.softwareishard.com/blog/har-12-spec/. Fields prefixed with an
+// underscore are custom fields, which HAR readers ignore.
+
+type harLog struct {
+	Log harLogContent `json:"log"`
+}
+
+type harLogContent struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+	Source          string      `json:"_source"`
+	Destination     string      `json:"_destination"`
+	ProxyDirection  string      `json:"_proxyDirection"`
+	GrpcStatus      string      `json:"_grpcStatus,omitempty"`
+	ResetErrorCode  *uint32     `json:"_resetErrorCode,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      uint32         `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+// harTimings are in milliseconds; -1 stands for a timing that doesn't apply
+// or isn't known.
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// harRecorder assembles the request, response and end events of each tapped
+// stream into a HAR entry.
+type harRecorder struct {
+	// now returns the time at which a request is seen, as tap events don't
+	// carry a timestamp.
+	now         func() time.Time
+	version     string
+	outstanding map[topRequestID]harExchange
+	entries     []harEntry
+}
+
+type harExchange struct {
+	started time.Time
+	event   *pb.TapEvent
+	reqInit *pb.TapEvent_Http_RequestInit
+	rspInit *pb.TapEvent_Http_ResponseInit
+}
+
+func newHARRecorder() *harRecorder {
+	return &harRecorder{
+		now:         time.Now,
+		version:     version.Version,
+		outstanding: make(map[topRequestID]harExchange),
+		entries:     []harEntry{},
+	}
+}
+
+// requestTapHARFromAPI taps the requested resource until the tap session ends
+// or stop receives a signal, and then writes the captured exchanges to w as a
+// HAR document.
+func requestTapHARFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, stop <-chan os.Signal) error {
+	rsp, err := client.TapByResource(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "Capturing requests; press Ctrl+C to stop and write the HAR document")
+	return renderTapHAR(w, rsp, newHARRecorder(), stop)
+}
+
+func renderTapHAR(w io.Writer, tapClient pb.Api_TapByResourceClient, recorder *harRecorder, stop <-chan os.Signal) error {
+	events := make(chan *pb.TapEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			log.Debug("Waiting for data...")
+			event, err := tapClient.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return
+			}
+			events <- event
+		}
+	}()
+
+loop:
+	for {
+		select {
+		case event := <-events:
+			recorder.record(event)
+		case <-done:
+			break loop
+		case <-stop:
+			break loop
+		}
+	}
+
+	return recorder.write(w)
+}
+
+// record adds the event to its stream's exchange, which becomes a HAR entry
+// once the response has ended. Streams whose request wasn't seen are skipped.
+func (r *harRecorder) record(event *pb.TapEvent) {
+	id := topRequestID{
+		src: addr.PublicAddressToString(event.GetSource()),
+		dst: addr.PublicAddressToString(event.GetDestination()),
+	}
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *pb.TapEvent_Http_RequestInit_:
+		id.stream = ev.RequestInit.GetId().GetStream()
+		r.outstanding[id] = harExchange{
+			started: r.now(),
+			event:   event,
+			reqInit: ev.RequestInit,
+		}
+
+	case *pb.TapEvent_Http_ResponseInit_:
+		id.stream = ev.ResponseInit.GetId().GetStream()
+		if exchange, ok := r.outstanding[id]; ok {
+			exchange.rspInit = ev.ResponseInit
+			r.outstanding[id] = exchange
+		} else {
+			log.Warnf("Got ResponseInit for unknown stream: %s", id)
+		}
+
+	case *pb.TapEvent_Http_ResponseEnd_:
+		id.stream = ev.ResponseEnd.GetId().GetStream()
+		if exchange, ok := r.outstanding[id]; ok {
+			delete(r.outstanding, id)
+			r.entries = append(r.entries, harEntryFor(exchange, ev.ResponseEnd))
+		} else {
+			log.Warnf("Got ResponseEnd for unknown stream: %s", id)
+		}
+	}
+}
+
+func (r *harRecorder) write(w io.Writer) error {
+	doc := harLog{
+		Log: harLogContent{
+			Version: "1.2",
+			Creator: harCreator{Name: "linkerd", Version: r.version},
+			Entries: r.entries,
+		},
+	}
+
+	// URLs are written as is, rather than with their "&" escaped
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+func harEntryFor(exchange harExchange, rspEnd *pb.TapEvent_Http_ResponseEnd) harEntry {
+	reqInit := exchange.reqInit
+	path := reqInit.GetPath()
+	if path == "" {
+		path = "/"
+	}
+
+	// the time to the response headers, and then to the end of the stream;
+	// the tap events don't tell the time spent sending the request apart
+	total := durationMs(rspEnd.GetSinceRequestInit())
+	wait := total
+	if exchange.rspInit != nil {
+		wait = durationMs(exchange.rspInit.GetSinceRequestInit())
+	}
+	receive := total - wait
+	if total < 0 || wait < 0 || receive < 0 {
+		receive = -1
+	}
+
+	status := exchange.rspInit.GetHttpStatus()
+	entry := harEntry{
+		StartedDateTime: exchange.started.UTC().Format(time.RFC3339Nano),
+		Time:            total,
+		Request: harRequest{
+			Method:      harMethod(reqInit.GetMethod()),
+			URL:         fmt.Sprintf("%s://%s%s", harScheme(reqInit.GetScheme()), reqInit.GetAuthority(), path),
+			HTTPVersion: "",
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			QueryString: harQueryString(path),
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Response: harResponse{
+			Status:      status,
+			StatusText:  http.StatusText(int(status)),
+			HTTPVersion: "",
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			Content: harContent{
+				Size:     int64(rspEnd.GetResponseBytes()),
+				MimeType: "",
+			},
+			RedirectURL: "",
+			HeadersSize: -1,
+			BodySize:    int64(rspEnd.GetResponseBytes()),
+		},
+		Timings: harTimings{
+			Blocked: -1,
+			DNS:     -1,
+			Connect: -1,
+			Send:    0,
+			Wait:    wait,
+			Receive: receive,
+			SSL:     -1,
+		},
+		ServerIPAddress: addr.PublicIPToString(exchange.event.GetDestination().GetIp()),
+		Source:          addr.PublicAddressToString(exchange.event.GetSource()),
+		Destination:     addr.PublicAddressToString(exchange.event.GetDestination()),
+		ProxyDirection:  strings.ToLower(exchange.event.GetProxyDirection().String()),
+	}
+
+	switch eos := rspEnd.GetEos().GetEnd().(type) {
+	case *pb.Eos_GrpcStatusCode:
+		entry.GrpcStatus = codes.Code(eos.GrpcStatusCode).String()
+	case *pb.Eos_ResetErrorCode:
+		code := eos.ResetErrorCode
+		entry.ResetErrorCode = &code
+	}
+
+	return entry
+}
+
+func harMethod(method *pb.HttpMethod) string {
+	if unregistered := method.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return method.GetRegistered().String()
+}
+
+func harScheme(scheme *pb.Scheme) string {
+	if unregistered := scheme.GetUnregistered(); unregistered != "" {
+		return strings.ToLower(unregistered)
+	}
+	return strings.ToLower(scheme.GetRegistered().String())
+}
+
+// harQueryString returns the query parameters of the request path, sorted by
+// name.
+func harQueryString(path string) []harNameValue {
+	params := []harNameValue{}
+	u, err := url.ParseRequestURI(path)
+	if err != nil {
+		return params
+	}
+
+	query := u.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range query[name] {
+			params = append(params, harNameValue{Name: name, Value: value})
+		}
+	}
+	return params
+}
+
+// durationMs converts a tap event duration to milliseconds, or -1 if it's
+// missing.
+func durationMs(d *duration.Duration) float64 {
+	if d == nil {
+		return -1
+	}
+	converted, err := ptypes.Duration(d)
+	if err != nil {
+		return -1
+	}
+	return float64(converted) / float64(time.Millisecond)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRenderTapHAR(t *testing.T) {
+	toTapEvent := func(httpEvent *pb.TapEvent_Http) pb.TapEvent {
+		return pb.TapEvent{
+			ProxyDirection: pb.TapEvent_OUTBOUND,
+			Source: &pb.TcpAddress{
+				Ip:   addr.PublicIPV4(1, 2, 3, 4),
+				Port: 5555,
+			},
+			Destination: &pb.TcpAddress{
+				Ip:   addr.PublicIPV4(2, 3, 4, 5),
+				Port: 6666,
+			},
+			Event: &pb.TapEvent_Http_{Http: httpEvent},
+		}
+	}
+	streamID := func(stream uint64) *pb.TapEvent_Http_StreamId {
+		return &pb.TapEvent_Http_StreamId{Base: 7, Stream: stream}
+	}
+
+	events := []pb.TapEvent{
+		toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_RequestInit_{
+				RequestInit: &pb.TapEvent_Http_RequestInit{
+					Id: streamID(1),
+					Method: &pb.HttpMethod{
+						Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_POST},
+					},
+					Scheme: &pb.Scheme{
+						Type: &pb.Scheme_Registered_{Registered: pb.Scheme_HTTPS},
+					},
+					Authority: "hello.default:7777",
+					Path:      "/hello?name=world&lang=en",
+				},
+			},
+		}),
+		// a request that doesn't complete before the session ends isn't
+		// exported
+		toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_RequestInit_{
+				RequestInit: &pb.TapEvent_Http_RequestInit{
+					Id:        streamID(2),
+					Authority: "hello.default:7777",
+					Path:      "/pending",
+				},
+			},
+		}),
+		toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseInit_{
+				ResponseInit: &pb.TapEvent_Http_ResponseInit{
+					Id:               streamID(1),
+					SinceRequestInit: &duration.Duration{Nanos: 5000000},
+					HttpStatus:       http.StatusOK,
+				},
+			},
+		}),
+		toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseEnd_{
+				ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+					Id:                streamID(1),
+					SinceRequestInit:  &duration.Duration{Nanos: 12500000},
+					SinceResponseInit: &duration.Duration{Nanos: 7500000},
+					ResponseBytes:     111,
+					Eos: &pb.Eos{
+						End: &pb.Eos_GrpcStatusCode{GrpcStatusCode: uint32(codes.OK)},
+					},
+				},
+			},
+		}),
+	}
+
+	tapClient := &public.MockApi_TapByResourceClient{TapEventsToReturn: events}
+	recorder := newHARRecorder()
+	recorder.now = func() time.Time { return time.Date(2018, 10, 16, 12, 0, 0, 0, time.UTC) }
+	recorder.version = "test-version"
+
+	writer := bytes.NewBufferString("")
+	if err := renderTapHAR(writer, tapClient, recorder, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	goldenFileBytes, err := ioutil.ReadFile("testdata/tap_har_output.golden")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	diffCompare(t, writer.String(), string(goldenFileBytes))
+}
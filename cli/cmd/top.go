@@ -14,6 +14,8 @@ import (
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/addr"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
 	runewidth "github.com/mattn/go-runewidth"
 	termbox "github.com/nsf/termbox-go"
 	log "github.com/sirupsen/logrus"
@@ -30,6 +32,7 @@ type topOptions struct {
 	authority   string
 	path        string
 	hideSources bool
+	routes      bool
 }
 
 type topRequest struct {
@@ -62,11 +65,40 @@ type tableRow struct {
 	failures    int
 }
 
-const headerHeight = 3
+// topSort is the column the table is sorted by, and whether its default
+// order is reversed.
+type topSort struct {
+	column  string
+	reverse bool
+}
+
+const (
+	headerHeight = 3
+
+	sortByCount       = "count"
+	sortByBest        = "best"
+	sortByWorst       = "worst"
+	sortByLast        = "last"
+	sortBySuccessRate = "success rate"
+
+	// defaultRoute is shown for requests that don't match any route of their
+	// destination's ServiceProfile.
+	defaultRoute = "[DEFAULT]"
+)
 
 var (
 	columnNames  = []string{"Source", "Destination", "Method", "Path", "Count", "Best", "Worst", "Last", "Success Rate"}
 	columnWidths = []int{23, 23, 10, 37, 6, 6, 6, 6, 3}
+
+	// sortKeys maps the keys that sort the table to the columns they sort by.
+	// Pressing the key of the current column reverses the order.
+	sortKeys = map[rune]string{
+		'c': sortByCount,
+		'b': sortByBest,
+		'w': sortByWorst,
+		'l': sortByLast,
+		's': sortBySuccessRate,
+	}
 )
 
 func newTopOptions() *topOptions {
@@ -80,6 +112,7 @@ func newTopOptions() *topOptions {
 		authority:   "",
 		path:        "",
 		hideSources: false,
+		routes:      false,
 	}
 }
 
@@ -106,12 +139,22 @@ func newCmdTop() *cobra.Command {
   * namespaces
   * pods
   * replicationcontrollers
-  * services (only supported as a "--to" resource)`,
+  * services (only supported as a "--to" resource)
+
+  If "--routes" is given, requests are aggregated by the route of their destination's
+  ServiceProfile instead of by path. Requests that don't match any route are shown as
+  [DEFAULT].
+
+  While the table is displayed, press c, b, w, l or s to sort it by count, best, worst
+  or last latency, or success rate. Press the same key again to reverse the order.`,
 		Example: `  # display traffic for the web deployment in the default namespace
   linkerd top deploy/web
 
   # display traffic for the web-dlbvj pod in the default namespace
-  linkerd top pod/web-dlbvj`,
+  linkerd top pod/web-dlbvj
+
+  # display traffic for the web deployment by route, without the source column
+  linkerd top deploy/web --routes --hide-sources`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -132,7 +175,15 @@ func newCmdTop() *cobra.Command {
 				return err
 			}
 
-			return getTrafficByResourceFromAPI(os.Stdout, validatedPublicAPIClient(time.Time{}), req, options)
+			var serviceProfiles map[string]*profiles.ServiceProfile
+			if options.routes {
+				serviceProfiles, err = getServiceProfiles()
+				if err != nil {
+					return fmt.Errorf("Failed to fetch ServiceProfiles: %s; run \"linkerd check --service-profiles\" for details", err)
+				}
+			}
+
+			return getTrafficByResourceFromAPI(os.Stdout, validatedPublicAPIClient(time.Time{}), req, options, serviceProfiles)
 		},
 	}
 
@@ -153,11 +204,39 @@ func newCmdTop() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
 	cmd.PersistentFlags().BoolVar(&options.hideSources, "hide-sources", options.hideSources, "Hide the source column")
+	cmd.PersistentFlags().BoolVar(&options.routes, "routes", options.routes, "Display requests by ServiceProfile route instead of path")
+
+	addNamespaceCompletion(cmd)
 
 	return cmd
 }
 
-func getTrafficByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, options *topOptions) error {
+// getServiceProfiles returns the ServiceProfiles in the control plane
+// namespace, keyed by the name of the service they describe.
+func getServiceProfiles() (map[string]*profiles.ServiceProfile, error) {
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	profileList, err := kubeAPI.GetServiceProfiles(client, controlPlaneNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceProfiles := make(map[string]*profiles.ServiceProfile)
+	for i := range profileList {
+		serviceProfiles[profileList[i].Metadata.Name] = &profileList[i]
+	}
+	return serviceProfiles, nil
+}
+
+func getTrafficByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, options *topOptions, serviceProfiles map[string]*profiles.ServiceProfile) error {
 	rsp, err := client.TapByResource(context.Background(), req)
 	if err != nil {
 		return err
@@ -170,12 +249,13 @@ func getTrafficByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapBy
 	defer termbox.Close()
 
 	requestCh := make(chan topRequest, 100)
+	sortCh := make(chan rune)
 	done := make(chan struct{})
 
 	go recvEvents(rsp, requestCh, done)
-	go pollInput(done)
+	go pollInput(done, sortCh)
 
-	renderTable(requestCh, done, !options.hideSources)
+	renderTable(requestCh, done, sortCh, !options.hideSources, options.routes, serviceProfiles)
 
 	return nil
 }
@@ -227,7 +307,7 @@ func recvEvents(tapClient pb.Api_TapByResourceClient, requestCh chan<- topReques
 	}
 }
 
-func pollInput(done chan<- struct{}) {
+func pollInput(done chan<- struct{}, sortCh chan<- rune) {
 	for {
 		switch ev := termbox.PollEvent(); ev.Type {
 		case termbox.EventKey:
@@ -235,31 +315,110 @@ func pollInput(done chan<- struct{}) {
 				close(done)
 				return
 			}
+			if _, ok := sortKeys[ev.Ch]; ok {
+				sortCh <- ev.Ch
+			}
 		}
 	}
 }
 
-func renderTable(requestCh <-chan topRequest, done <-chan struct{}, withSource bool) {
+func renderTable(requestCh <-chan topRequest, done <-chan struct{}, sortCh <-chan rune, withSource bool, byRoute bool, serviceProfiles map[string]*profiles.ServiceProfile) {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	var table []tableRow
+	tableSort := topSort{column: sortByCount}
 
 	for {
 		select {
 		case <-done:
 			return
 		case req := <-requestCh:
-			tableInsert(&table, req, withSource)
+			tableInsert(&table, req, withSource, byRoute, serviceProfiles)
+		case key := <-sortCh:
+			tableSort = toggleSort(tableSort, sortKeys[key])
 		case <-ticker.C:
 			termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
-			renderHeaders(withSource)
+			renderHeaders(withSource, byRoute)
+			sortTable(table, tableSort)
 			renderTableBody(&table, withSource)
 			termbox.Flush()
 		}
 	}
 }
 
-func tableInsert(table *[]tableRow, req topRequest, withSource bool) {
+// toggleSort returns the sort for the given column, reversing the current
+// order if the table is already sorted by that column.
+func toggleSort(current topSort, column string) topSort {
+	if current.column == column {
+		return topSort{column: column, reverse: !current.reverse}
+	}
+	return topSort{column: column}
+}
+
+// sortTable sorts the table by the given column. By default, rows with the
+// most requests, the highest latencies or the lowest success rate come first.
+func sortTable(table []tableRow, order topSort) {
+	successRate := func(row tableRow) float64 {
+		return float64(row.successes) / float64(row.successes+row.failures)
+	}
+
+	sort.SliceStable(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		if order.reverse {
+			a, b = b, a
+		}
+
+		switch order.column {
+		case sortByBest:
+			return a.best > b.best
+		case sortByWorst:
+			return a.worst > b.worst
+		case sortByLast:
+			return a.last > b.last
+		case sortBySuccessRate:
+			return successRate(a) < successRate(b)
+		default:
+			return a.count > b.count
+		}
+	})
+}
+
+// routeFor returns the name of the route of the request's destination
+// ServiceProfile that the request matches. The profile is looked up by the
+// request's authority, completed with the destination's namespace when it's
+// a short service name.
+func routeFor(serviceProfiles map[string]*profiles.ServiceProfile, req topRequest) string {
+	host := stripPort(req.reqInit.GetAuthority())
+	namespace := req.event.GetDestinationMeta().GetLabels()["namespace"]
+
+	names := []string{host}
+	labels := strings.Split(host, ".")
+	switch {
+	case len(labels) == 1 && namespace != "":
+		names = append(names, fmt.Sprintf("%s.%s.svc.cluster.local", host, namespace))
+	case len(labels) == 2:
+		names = append(names, host+".svc.cluster.local")
+	case len(labels) == 3 && labels[2] == "svc":
+		names = append(names, host+".cluster.local")
+	}
+
+	for _, name := range names {
+		if profile, ok := serviceProfiles[name]; ok {
+			method := req.reqInit.GetMethod().GetRegistered().String()
+			if route := profile.RouteFor(method, req.reqInit.GetPath()); route != nil {
+				return route.Name
+			}
+			break
+		}
+	}
+
+	return defaultRoute
+}
+
+func tableInsert(table *[]tableRow, req topRequest, withSource bool, byRoute bool, serviceProfiles map[string]*profiles.ServiceProfile) {
 	by := req.reqInit.GetPath()
+	if byRoute {
+		by = routeFor(serviceProfiles, req)
+	}
 	method := req.reqInit.GetMethod().GetRegistered().String()
 	source := stripPort(addr.PublicAddressToString(req.event.GetSource()))
 	if pod := req.event.SourceMeta.Labels["pod"]; pod != "" {
@@ -334,13 +493,16 @@ func stripPort(address string) string {
 	return strings.Split(address, ":")[0]
 }
 
-func renderHeaders(withSource bool) {
-	tbprint(0, 0, "(press q to quit)")
+func renderHeaders(withSource bool, byRoute bool) {
+	tbprint(0, 0, "(press q to quit, or c, b, w, l or s to sort)")
 	x := 0
 	for i, header := range columnNames {
 		if i == 0 && !withSource {
 			continue
 		}
+		if header == "Path" && byRoute {
+			header = "Route"
+		}
 		width := columnWidths[i]
 		padded := fmt.Sprintf("%-"+strconv.Itoa(width)+"s ", header)
 		tbprintBold(x, 2, padded)
@@ -349,9 +511,6 @@ func renderHeaders(withSource bool) {
 }
 
 func renderTableBody(table *[]tableRow, withSource bool) {
-	sort.SliceStable(*table, func(i, j int) bool {
-		return (*table)[i].count > (*table)[j].count
-	})
 	for i, row := range *table {
 		x := 0
 		if withSource {
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+)
+
+func genTopRequest(authority, path string, latency time.Duration) topRequest {
+	return topRequest{
+		event: &pb.TapEvent{
+			SourceMeta: &pb.TapEvent_EndpointMeta{
+				Labels: map[string]string{"pod": "web-dlbvj", "namespace": "booksapp"},
+			},
+			DestinationMeta: &pb.TapEvent_EndpointMeta{
+				Labels: map[string]string{"pod": "books-5b7c6c5b8-vzq4q", "namespace": "booksapp"},
+			},
+		},
+		reqInit: &pb.TapEvent_Http_RequestInit{
+			Method: &pb.HttpMethod{
+				Type: &pb.HttpMethod_Registered_{
+					Registered: pb.HttpMethod_GET,
+				},
+			},
+			Authority: authority,
+			Path:      path,
+		},
+		rspInit: &pb.TapEvent_Http_ResponseInit{
+			HttpStatus: 200,
+		},
+		rspEnd: &pb.TapEvent_Http_ResponseEnd{
+			SinceRequestInit: ptypes.DurationProto(latency),
+		},
+	}
+}
+
+func TestRouteFor(t *testing.T) {
+	profile, err := profiles.NewServiceProfile("books", "booksapp", "linkerd", []*profiles.RouteSpec{
+		&profiles.RouteSpec{
+			Name:      "GET /books/{id}",
+			Condition: &profiles.RequestMatch{Method: "GET", PathRegex: "/books/[^/]*"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	serviceProfiles := map[string]*profiles.ServiceProfile{profile.Metadata.Name: profile}
+
+	testCases := []struct {
+		authority string
+		path      string
+		expected  string
+	}{
+		{"books:7002", "/books/123", "GET /books/{id}"},
+		{"books.booksapp:7002", "/books/123", "GET /books/{id}"},
+		{"books.booksapp.svc", "/books/123", "GET /books/{id}"},
+		{"books.booksapp.svc.cluster.local:7002", "/books/123", "GET /books/{id}"},
+		{"books:7002", "/authors/123", defaultRoute},
+		{"authors:7001", "/books/123", defaultRoute},
+	}
+
+	for _, tc := range testCases {
+		route := routeFor(serviceProfiles, genTopRequest(tc.authority, tc.path, time.Millisecond))
+		if route != tc.expected {
+			t.Fatalf("Expected route [%s] for %s%s, got [%s]", tc.expected, tc.authority, tc.path, route)
+		}
+	}
+}
+
+func TestTableInsert(t *testing.T) {
+	t.Run("Aggregates requests by path", func(t *testing.T) {
+		var table []tableRow
+		tableInsert(&table, genTopRequest("books:7002", "/books/1", time.Millisecond), true, false, nil)
+		tableInsert(&table, genTopRequest("books:7002", "/books/2", time.Millisecond), true, false, nil)
+
+		if len(table) != 2 {
+			t.Fatalf("Expected 2 rows, got %d: %+v", len(table), table)
+		}
+	})
+
+	t.Run("Aggregates requests by route", func(t *testing.T) {
+		profile, err := profiles.NewServiceProfile("books", "booksapp", "linkerd", []*profiles.RouteSpec{
+			&profiles.RouteSpec{
+				Name:      "GET /books/{id}",
+				Condition: &profiles.RequestMatch{Method: "GET", PathRegex: "/books/[^/]*"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		serviceProfiles := map[string]*profiles.ServiceProfile{profile.Metadata.Name: profile}
+
+		var table []tableRow
+		tableInsert(&table, genTopRequest("books:7002", "/books/1", time.Millisecond), true, true, serviceProfiles)
+		tableInsert(&table, genTopRequest("books:7002", "/books/2", 3*time.Millisecond), true, true, serviceProfiles)
+
+		if len(table) != 1 {
+			t.Fatalf("Expected 1 row, got %d: %+v", len(table), table)
+		}
+		row := table[0]
+		if row.by != "GET /books/{id}" || row.count != 2 || row.best != time.Millisecond || row.worst != 3*time.Millisecond {
+			t.Fatalf("Unexpected row: %+v", row)
+		}
+	})
+}
+
+func TestSortTable(t *testing.T) {
+	table := []tableRow{
+		tableRow{by: "/a", count: 1, best: 3 * time.Millisecond, worst: 3 * time.Millisecond, last: 3 * time.Millisecond, successes: 1},
+		tableRow{by: "/b", count: 3, best: 1 * time.Millisecond, worst: 5 * time.Millisecond, last: 1 * time.Millisecond, successes: 2, failures: 1},
+		tableRow{by: "/c", count: 2, best: 2 * time.Millisecond, worst: 2 * time.Millisecond, last: 2 * time.Millisecond, successes: 2},
+	}
+
+	testCases := []struct {
+		order    topSort
+		expected []string
+	}{
+		{topSort{column: sortByCount}, []string{"/b", "/c", "/a"}},
+		{topSort{column: sortByCount, reverse: true}, []string{"/a", "/c", "/b"}},
+		{topSort{column: sortByBest}, []string{"/a", "/c", "/b"}},
+		{topSort{column: sortByWorst}, []string{"/b", "/a", "/c"}},
+		{topSort{column: sortByLast}, []string{"/a", "/c", "/b"}},
+		{topSort{column: sortBySuccessRate}, []string{"/b", "/a", "/c"}},
+	}
+
+	for _, tc := range testCases {
+		sortTable(table, tc.order)
+		for i, row := range table {
+			if row.by != tc.expected[i] {
+				t.Fatalf("Expected rows %v when sorting by %+v, got %+v", tc.expected, tc.order, table)
+			}
+		}
+	}
+}
+
+func TestToggleSort(t *testing.T) {
+	order := toggleSort(topSort{column: sortByCount}, sortByWorst)
+	if order != (topSort{column: sortByWorst}) {
+		t.Fatalf("Expected to sort by worst latency, got %+v", order)
+	}
+
+	order = toggleSort(order, sortByWorst)
+	if order != (topSort{column: sortByWorst, reverse: true}) {
+		t.Fatalf("Expected to reverse the sort by worst latency, got %+v", order)
+	}
+}
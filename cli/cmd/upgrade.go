@@ -0,0 +1,516 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// sharedResourcesGroup groups the rendered resources that aren't part of a
+// single control plane component, such as the namespace, RBAC and CRDs.
+const sharedResourcesGroup = "namespace, RBAC and CRDs"
+
+type upgradeOptions struct {
+	dryRun    bool
+	diff      bool
+	readiness bool
+	*installOptions
+}
+
+func newUpgradeOptions() *upgradeOptions {
+	return &upgradeOptions{
+		dryRun:         false,
+		diff:           false,
+		readiness:      false,
+		installOptions: newInstallOptions(),
+	}
+}
+
+func newCmdUpgrade() *cobra.Command {
+	options := newUpgradeOptions()
+
+	cmd := &cobra.Command{
+		Use:   "upgrade [flags]",
+		Short: "Output Kubernetes configs to upgrade an existing Linkerd control plane",
+		Long: `Output Kubernetes configs to upgrade an existing Linkerd control plane.
+
+The configs are the ones "linkerd install" outputs for this version, keeping the
+installation's UUID. Use the same flags that were passed to "linkerd install".
+Run "linkerd check --pre-upgrade" first to validate that the control plane can
+be upgraded to this version.
+
+With --dry-run, the configs aren't output. Instead, the resources that the
+upgrade would create or change are listed, grouped by control plane component,
+along with the control plane resources that aren't part of the upgrade anymore.
+Add --diff to also display the changed fields of each resource.
+
+With --readiness, the configs aren't output either. Instead, the meshed pods are
+grouped by proxy version, proxy configuration and trust anchors, to find the
+workloads that don't match the control plane and should be re-injected and
+restarted before it's upgraded.`,
+		Example: `  # Review the changes before upgrading.
+  linkerd upgrade --dry-run --diff
+
+  # Find the proxies that are left behind by the previous upgrade.
+  linkerd upgrade --readiness
+
+  # Upgrade a TLS-enabled control plane.
+  linkerd upgrade --tls optional | kubectl apply -f -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.diff && !options.dryRun {
+				return fmt.Errorf("--diff requires --dry-run")
+			}
+			if options.readiness {
+				if options.dryRun {
+					return fmt.Errorf("--readiness can't be combined with --dry-run")
+				}
+
+				output, err := requestUpgradeReadinessFromAPI(validatedPublicAPIClient(time.Time{}), &pb.ProxySkewRequest{})
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Print(output)
+				return err
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			clientset, err := kubernetes.NewForConfig(kubeAPI.Config)
+			if err != nil {
+				return err
+			}
+
+			return runUpgradeCmd(clientset, os.Stdout, options)
+		},
+	}
+
+	addInstallFlags(cmd, options.installOptions)
+	cmd.PersistentFlags().BoolVar(&options.dryRun, "dry-run", options.dryRun, "Only list the resources the upgrade would create, change or leave behind, instead of outputting the configs")
+	cmd.PersistentFlags().BoolVar(&options.diff, "diff", options.diff, "With --dry-run, display the fields the upgrade would change in each resource")
+	cmd.PersistentFlags().BoolVar(&options.readiness, "readiness", options.readiness, "Only report the proxies that don't match the control plane's version, configuration or trust anchors, instead of outputting the configs")
+
+	return cmd
+}
+
+// resourceChange describes what the upgrade would do to a resource.
+type resourceChange struct {
+	key    string
+	action string // "create", "change", "unchanged" or "remove"
+	fields []fieldDiff
+}
+
+// fieldDiff is a field of a resource that the upgrade would add ("+"),
+// change ("~") or remove ("-").
+type fieldDiff struct {
+	op   string
+	path string
+	old  string
+	new  string
+}
+
+// upgradePlan is the list of changes to each control plane component, in the
+// order the components are rendered.
+type upgradePlan struct {
+	groups  []string
+	changes map[string][]resourceChange
+}
+
+func (p *upgradePlan) add(group string, change resourceChange) {
+	if _, ok := p.changes[group]; !ok {
+		p.groups = append(p.groups, group)
+	}
+	p.changes[group] = append(p.changes[group], change)
+}
+
+func runUpgradeCmd(clientset kubernetes.Interface, w io.Writer, options *upgradeOptions) error {
+	if _, err := clientset.CoreV1().Namespaces().Get(controlPlaneNamespace, metaV1.GetOptions{}); err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("Linkerd isn't installed in the \"%s\" namespace; use \"linkerd install\" instead", controlPlaneNamespace)
+		}
+		return err
+	}
+
+	config, err := validateAndBuildConfig(options.installOptions)
+	if err != nil {
+		return err
+	}
+
+	uuid, err := installedUUID(clientset)
+	if err != nil {
+		return err
+	}
+	if uuid != "" {
+		config.UUID = uuid
+	}
+
+	buf := &bytes.Buffer{}
+	if err := render(*config, buf, options.installOptions); err != nil {
+		return err
+	}
+
+	if !options.dryRun {
+		_, err := io.Copy(w, buf)
+		return err
+	}
+
+	plan, err := planUpgrade(clientset, buf)
+	if err != nil {
+		return err
+	}
+
+	renderUpgradePlan(w, plan, options.diff)
+	return nil
+}
+
+// installedUUID returns the UUID of the installed control plane, which is
+// passed to the web deployment, or an empty string if it can't be found.
+func installedUUID(clientset kubernetes.Interface) (string, error) {
+	deploy, err := clientset.AppsV1().Deployments(controlPlaneNamespace).Get("web", metaV1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, container := range deploy.Spec.Template.Spec.Containers {
+		for _, arg := range container.Args {
+			if strings.HasPrefix(arg, "-uuid=") {
+				return strings.TrimPrefix(arg, "-uuid="), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// planUpgrade compares the resources in a stream of YAML configs against the
+// ones in the cluster.
+func planUpgrade(clientset kubernetes.Interface, in io.Reader) (*upgradePlan, error) {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(in, 4096))
+	plan := &upgradePlan{changes: make(map[string][]resourceChange)}
+	installed := make(map[string]bool)
+
+	for {
+		bytes, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var rendered map[string]interface{}
+		if err := yaml.Unmarshal(bytes, &rendered); err != nil {
+			return nil, err
+		}
+
+		var obj struct {
+			metaV1.TypeMeta   `json:",inline"`
+			metaV1.ObjectMeta `json:"metadata,omitempty"`
+		}
+		if err := yaml.Unmarshal(bytes, &obj); err != nil {
+			return nil, err
+		}
+		if obj.Kind == "" {
+			continue
+		}
+
+		key := resourceKey(obj.Kind, obj.Name)
+		installed[key] = true
+
+		group := obj.Labels[k8s.ControllerComponentLabel]
+		if group == "" {
+			group = sharedResourcesGroup
+		}
+
+		var live map[string]interface{}
+		if obj.Kind == "CustomResourceDefinition" {
+			live, err = getLiveCustomResourceDefinition(clientset, bytes)
+		} else {
+			live, err = getLiveResource(clientset, obj.Kind, obj.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		change := resourceChange{key: key, action: "create"}
+		if live != nil {
+			// the kind and API version identify the resource, and the live
+			// object's API version can differ from the rendered one's
+			delete(rendered, "apiVersion")
+			delete(rendered, "kind")
+			delete(rendered, "status")
+
+			change.action = "unchanged"
+			change.fields = diffFields("", rendered, live)
+			if len(change.fields) > 0 {
+				change.action = "change"
+			}
+		}
+		plan.add(group, change)
+	}
+
+	orphans, err := findOrphanedResources(clientset, installed)
+	if err != nil {
+		return nil, err
+	}
+	for _, orphan := range orphans {
+		plan.add("removed", resourceChange{key: orphan, action: "remove"})
+	}
+
+	return plan, nil
+}
+
+// getLiveResource returns the JSON representation of a control plane
+// resource in the cluster, or nil if it doesn't exist.
+func getLiveResource(clientset kubernetes.Interface, kind, name string) (map[string]interface{}, error) {
+	var obj interface{}
+	var err error
+
+	switch strings.ToLower(kind) {
+	case "namespace":
+		obj, err = clientset.CoreV1().Namespaces().Get(name, metaV1.GetOptions{})
+	case "serviceaccount":
+		obj, err = clientset.CoreV1().ServiceAccounts(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	case "service":
+		obj, err = clientset.CoreV1().Services(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	case "configmap":
+		obj, err = clientset.CoreV1().ConfigMaps(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	case "deployment":
+		obj, err = clientset.AppsV1().Deployments(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	case "poddisruptionbudget":
+		obj, err = clientset.PolicyV1beta1().PodDisruptionBudgets(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	case "clusterrole":
+		obj, err = clientset.RbacV1().ClusterRoles().Get(name, metaV1.GetOptions{})
+	case "clusterrolebinding":
+		obj, err = clientset.RbacV1().ClusterRoleBindings().Get(name, metaV1.GetOptions{})
+	case "role":
+		obj, err = clientset.RbacV1().Roles(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	case "rolebinding":
+		obj, err = clientset.RbacV1().RoleBindings(controlPlaneNamespace).Get(name, metaV1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("Cannot diff resource of kind %s", kind)
+	}
+
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var live map[string]interface{}
+	err = json.Unmarshal(bytes, &live)
+	return live, err
+}
+
+// getLiveCustomResourceDefinition returns the JSON representation of a
+// rendered CustomResourceDefinition if the cluster serves its kind, or nil if
+// it doesn't. The clientset can't read CRDs, so an installed CRD is reported
+// as unchanged.
+func getLiveCustomResourceDefinition(clientset kubernetes.Interface, doc []byte) (map[string]interface{}, error) {
+	var crd struct {
+		Spec struct {
+			Group   string `json:"group"`
+			Version string `json:"version"`
+			Names   struct {
+				Kind string `json:"kind"`
+			} `json:"names"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal(doc, &crd); err != nil {
+		return nil, err
+	}
+
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(crd.Spec.Group + "/" + crd.Spec.Version)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resources == nil {
+		return nil, nil
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Kind == crd.Spec.Names.Kind {
+			var live map[string]interface{}
+			err := yaml.Unmarshal(doc, &live)
+			return live, err
+		}
+	}
+	return nil, nil
+}
+
+// diffFields returns the fields set in rendered that are missing from or
+// differ in live. The fields that are only set in live, such as the ones
+// defaulted by the API server, are ignored, except for extra list items,
+// which the upgrade removes.
+func diffFields(path string, rendered, live interface{}) []fieldDiff {
+	if isEmpty(rendered) {
+		return nil
+	}
+	if live == nil {
+		return []fieldDiff{{op: "+", path: path, new: formatValue(rendered)}}
+	}
+
+	switch renderedValue := rendered.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := []string{}
+		for key := range renderedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		diffs := []fieldDiff{}
+		for _, key := range keys {
+			diffs = append(diffs, diffFields(fieldPath(path, key), renderedValue[key], liveValue[key])...)
+		}
+		return diffs
+
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			break
+		}
+
+		diffs := []fieldDiff{}
+		for i, item := range renderedValue {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if i >= len(liveValue) {
+				diffs = append(diffs, fieldDiff{op: "+", path: itemPath, new: formatValue(item)})
+				continue
+			}
+			diffs = append(diffs, diffFields(itemPath, item, liveValue[i])...)
+		}
+		for i := len(renderedValue); i < len(liveValue); i++ {
+			diffs = append(diffs, fieldDiff{op: "-", path: fmt.Sprintf("%s[%d]", path, i), old: formatValue(liveValue[i])})
+		}
+		return diffs
+
+	default:
+		if reflect.DeepEqual(rendered, live) {
+			return nil
+		}
+	}
+
+	return []fieldDiff{{op: "~", path: path, old: formatValue(live), new: formatValue(rendered)}}
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+var plainFieldName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// fieldPath appends a field name to a path, quoting names that contain dots
+// or slashes, such as label and annotation keys.
+func fieldPath(path, name string) string {
+	if !plainFieldName.MatchString(name) {
+		return fmt.Sprintf("%s[%q]", path, name)
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
+
+// renderUpgradePlan writes the resources of each component that the upgrade
+// would create, change or leave behind, followed by a summary.
+func renderUpgradePlan(w io.Writer, plan *upgradePlan, showDiff bool) {
+	counts := make(map[string]int)
+	symbols := map[string]string{"create": "+", "change": "~", "remove": "-"}
+
+	for _, group := range plan.groups {
+		printed := false
+		for _, change := range plan.changes[group] {
+			counts[change.action]++
+			if change.action == "unchanged" {
+				continue
+			}
+
+			if !printed {
+				fmt.Fprintf(w, "%s:\n", group)
+				printed = true
+			}
+
+			switch change.action {
+			case "remove":
+				fmt.Fprintf(w, "  %s %s (delete with \"linkerd prune --force\")\n", symbols[change.action], change.key)
+			default:
+				fmt.Fprintf(w, "  %s %s\n", symbols[change.action], change.key)
+			}
+
+			if !showDiff {
+				continue
+			}
+			for _, field := range change.fields {
+				switch field.op {
+				case "+":
+					fmt.Fprintf(w, "      + %s: %s\n", field.path, field.new)
+				case "-":
+					fmt.Fprintf(w, "      - %s: %s\n", field.path, field.old)
+				default:
+					fmt.Fprintf(w, "      ~ %s: %s -> %s\n", field.path, field.old, field.new)
+				}
+			}
+		}
+	}
+
+	if counts["create"]+counts["change"]+counts["remove"] == 0 {
+		fmt.Fprintln(w, "No changes; the control plane is up to date")
+		return
+	}
+
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to change, %d to remove, %d unchanged\n",
+		counts["create"], counts["change"], counts["remove"], counts["unchanged"])
+}
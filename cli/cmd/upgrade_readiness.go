@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+)
+
+func requestUpgradeReadinessFromAPI(client pb.ApiClient, req *pb.ProxySkewRequest) (string, error) {
+	rsp, err := client.ProxySkew(context.Background(), req)
+	if err != nil {
+		return "", fmt.Errorf("ProxySkew API error: %v", err)
+	}
+
+	return renderUpgradeReadiness(rsp), nil
+}
+
+// renderUpgradeReadiness returns a table per property of the proxies that has
+// to match the control plane, with the number of pods and a few workloads for
+// each of its values, followed by whether the data plane can be upgraded.
+func renderUpgradeReadiness(rsp *pb.ProxySkewResponse) string {
+	if len(rsp.GetVersions()) == 0 {
+		return "No meshed pods found; the control plane can be upgraded.\n"
+	}
+
+	var buffer bytes.Buffer
+	ready := true
+
+	sections := []struct {
+		title   string
+		current string
+		missing string
+		groups  []*pb.ProxySkewGroup
+	}{
+		{"Proxy versions", rsp.GetCurrentVersion(), "unknown", rsp.GetVersions()},
+		{"Proxy configurations", rsp.GetCurrentConfigHash(), "unknown", rsp.GetConfigHashes()},
+		{"Trust anchors", rsp.GetCurrentTrustAnchors(), "missing", rsp.GetTrustAnchors()},
+	}
+	for _, section := range sections {
+		if len(section.groups) == 0 {
+			continue
+		}
+
+		current := section.current
+		if current == "" {
+			current = section.missing
+		}
+		fmt.Fprintf(&buffer, "%s (control plane: %s)\n", section.title, current)
+
+		w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', 0)
+		fmt.Fprintln(w, "VALUE\tPODS\tSTATUS\tEXAMPLES")
+		for _, group := range section.groups {
+			value := group.GetValue()
+			if value == "" {
+				value = section.missing
+			}
+			status := "current"
+			switch {
+			case section.current == "":
+				// the control plane's value wasn't recorded, so the proxies
+				// can't be compared against it
+				status = "unknown"
+			case group.GetValue() != section.current:
+				status = "outdated"
+				ready = false
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", value, group.GetPodCount(), status, healthcheck.FormatSkewExamples(group))
+		}
+		w.Flush()
+		fmt.Fprintln(&buffer)
+	}
+
+	if ready {
+		fmt.Fprintln(&buffer, "All the proxies match the control plane; it can be upgraded.")
+	} else {
+		fmt.Fprintln(&buffer, "Some proxies don't match the control plane; re-inject and restart the outdated workloads before upgrading.")
+	}
+	return buffer.String()
+}
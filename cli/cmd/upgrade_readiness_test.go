@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestUpgradeReadiness(t *testing.T) {
+	web := &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: "web"}
+	authors := &pb.Resource{Namespace: "books", Type: "deployment", Name: "authors"}
+
+	t.Run("Reports the proxies that don't match the control plane", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			ProxySkewResponseToReturn: &pb.ProxySkewResponse{
+				CurrentVersion:      "edge-18.10.2",
+				CurrentConfigHash:   "1234",
+				CurrentTrustAnchors: "abcd",
+				Versions: []*pb.ProxySkewGroup{
+					{Value: "edge-18.10.2", PodCount: 3, Examples: []*pb.Resource{web}},
+					{Value: "edge-18.10.1", PodCount: 1, Examples: []*pb.Resource{authors}},
+				},
+				ConfigHashes: []*pb.ProxySkewGroup{
+					{Value: "1234", PodCount: 3, Examples: []*pb.Resource{web}},
+					{Value: "", PodCount: 1, Examples: []*pb.Resource{authors}},
+				},
+				TrustAnchors: []*pb.ProxySkewGroup{
+					{Value: "abcd", PodCount: 4, Examples: []*pb.Resource{authors, web}},
+				},
+			},
+		}
+
+		expectedOutput := `Proxy versions (control plane: edge-18.10.2)
+VALUE          PODS   STATUS     EXAMPLES
+edge-18.10.2   3      current    emojivoto/deployment/web
+edge-18.10.1   1      outdated   books/deployment/authors
+
+Proxy configurations (control plane: 1234)
+VALUE     PODS   STATUS     EXAMPLES
+1234      3      current    emojivoto/deployment/web
+unknown   1      outdated   books/deployment/authors
+
+Trust anchors (control plane: abcd)
+VALUE   PODS   STATUS    EXAMPLES
+abcd    4      current   books/deployment/authors, emojivoto/deployment/web
+
+Some proxies don't match the control plane; re-inject and restart the outdated workloads before upgrading.
+`
+
+		output, err := requestUpgradeReadinessFromAPI(mockClient, &pb.ProxySkewRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Reports that the control plane can be upgraded", func(t *testing.T) {
+		mockClient := &public.MockApiClient{
+			ProxySkewResponseToReturn: &pb.ProxySkewResponse{
+				CurrentVersion: "edge-18.10.2",
+				Versions: []*pb.ProxySkewGroup{
+					{Value: "edge-18.10.2", PodCount: 3, Examples: []*pb.Resource{web}},
+				},
+				ConfigHashes: []*pb.ProxySkewGroup{
+					{Value: "1234", PodCount: 3, Examples: []*pb.Resource{web}},
+				},
+			},
+		}
+
+		expectedOutput := `Proxy versions (control plane: edge-18.10.2)
+VALUE          PODS   STATUS    EXAMPLES
+edge-18.10.2   3      current   emojivoto/deployment/web
+
+Proxy configurations (control plane: unknown)
+VALUE   PODS   STATUS    EXAMPLES
+1234    3      unknown   emojivoto/deployment/web
+
+All the proxies match the control plane; it can be upgraded.
+`
+
+		output, err := requestUpgradeReadinessFromAPI(mockClient, &pb.ProxySkewRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRunUpgradeCmd(t *testing.T) {
+	replicas := int32(2)
+	newClientset := func() *fake.Clientset {
+		return fake.NewSimpleClientset([]runtime.Object{
+			&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: controlPlaneNamespace}},
+			&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "linkerd-controller", Namespace: controlPlaneNamespace}},
+			&appsV1.Deployment{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "web",
+					Namespace: controlPlaneNamespace,
+					Labels:    map[string]string{k8s.ControllerComponentLabel: "web"},
+				},
+				Spec: appsV1.DeploymentSpec{
+					Replicas: &replicas,
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "web", Args: []string{"-uuid=installed-uuid"}}},
+						},
+					},
+				},
+			},
+			&appsV1.Deployment{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "old",
+					Namespace: controlPlaneNamespace,
+					Labels:    map[string]string{k8s.ControllerComponentLabel: "old"},
+				},
+			},
+		}...)
+	}
+
+	t.Run("Fails if Linkerd isn't installed", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := runUpgradeCmd(fake.NewSimpleClientset(), &buf, newUpgradeOptions())
+		if err == nil {
+			t.Fatal("Expected an error, got none")
+		}
+	})
+
+	t.Run("Outputs the configs with the installed UUID", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := runUpgradeCmd(newClientset(), &buf, newUpgradeOptions()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "- -uuid=installed-uuid\n") {
+			t.Fatalf("Expected the configs to keep the installed UUID, got [%s]", buf.String())
+		}
+	})
+
+	t.Run("Lists the changes by component in dry run mode", func(t *testing.T) {
+		clientset := newClientset()
+		options := newUpgradeOptions()
+		options.dryRun = true
+		options.diff = true
+
+		var buf bytes.Buffer
+		if err := runUpgradeCmd(clientset, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		output := buf.String()
+
+		for _, expected := range []string{
+			"namespace, RBAC and CRDs:\n  + clusterrole/linkerd-linkerd-controller\n",
+			"web:\n  + service/web\n  ~ deployment/web\n",
+			"      ~ spec.replicas: 2 -> 1\n",
+			"removed:\n  - deployment/old (delete with \"linkerd prune --force\")\n",
+			"\nPlan: 17 to create, 1 to change, 1 to remove, 2 unchanged\n",
+		} {
+			if !strings.Contains(output, expected) {
+				t.Fatalf("Expected output to contain [%s], got [%s]", expected, output)
+			}
+		}
+		for _, unexpected := range []string{"namespace/linkerd", "serviceaccount/linkerd-controller"} {
+			if strings.Contains(output, unexpected) {
+				t.Fatalf("Expected unchanged resource %s not to be listed, got [%s]", unexpected, output)
+			}
+		}
+
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() != "get" && action.GetVerb() != "list" {
+				t.Fatalf("Unexpected %s in dry run mode: %+v", action.GetVerb(), action)
+			}
+		}
+	})
+}
+
+func TestDiffFields(t *testing.T) {
+	testCases := []struct {
+		rendered map[string]interface{}
+		live     map[string]interface{}
+		expected []fieldDiff
+	}{
+		{
+			map[string]interface{}{"a": 1.0, "b": map[string]interface{}{"c": "x"}, "d": nil, "e": map[string]interface{}{}},
+			map[string]interface{}{"a": 1.0, "b": map[string]interface{}{"c": "y"}, "f": 2.0},
+			[]fieldDiff{{op: "~", path: "b.c", old: "y", new: "x"}},
+		},
+		{
+			map[string]interface{}{"l": []interface{}{1.0, 2.0}},
+			map[string]interface{}{"l": []interface{}{1.0, 3.0, 4.0}},
+			[]fieldDiff{{op: "~", path: "l[1]", old: "3", new: "2"}, {op: "-", path: "l[2]", old: "4"}},
+		},
+		{
+			map[string]interface{}{"m": map[string]interface{}{"n": "v"}},
+			map[string]interface{}{},
+			[]fieldDiff{{op: "+", path: "m", new: `{"n":"v"}`}},
+		},
+		{
+			map[string]interface{}{"metadata": map[string]interface{}{"annotations": map[string]interface{}{"linkerd.io/created-by": "b"}}},
+			map[string]interface{}{"metadata": map[string]interface{}{"annotations": map[string]interface{}{"linkerd.io/created-by": "a"}}},
+			[]fieldDiff{{op: "~", path: `metadata.annotations["linkerd.io/created-by"]`, old: "a", new: "b"}},
+		},
+	}
+
+	for i, tc := range testCases {
+		diffs := diffFields("", tc.rendered, tc.live)
+		if !reflect.DeepEqual(diffs, tc.expected) {
+			t.Fatalf("Test case %d: expected %+v, got %+v", i, tc.expected, diffs)
+		}
+	}
+}
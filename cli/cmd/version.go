@@ -17,12 +17,14 @@ const DefaultVersionString = "unavailable"
 type versionOptions struct {
 	shortVersion      bool
 	onlyClientVersion bool
+	output            string
 }
 
 func newVersionOptions() *versionOptions {
 	return &versionOptions{
 		shortVersion:      false,
 		onlyClientVersion: false,
+		output:            tableOutput,
 	}
 }
 
@@ -32,7 +34,30 @@ func newCmdVersion() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print the client and server version information",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(options.output); err != nil {
+				return err
+			}
+
+			if isStructuredOutput(options.output) {
+				var client pb.ApiClient
+				if !options.onlyClientVersion {
+					var err error
+					client, err = newVersionClient()
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Error connecting to server: %s\n", err)
+						os.Exit(1)
+					}
+				}
+
+				output, err := marshalOutput(newVersionReport(client), options.output)
+				if err != nil {
+					return err
+				}
+				fmt.Print(output)
+				return nil
+			}
+
 			clientVersion := version.Version
 			if options.shortVersion {
 				fmt.Println(clientVersion)
@@ -54,16 +79,35 @@ func newCmdVersion() *cobra.Command {
 					fmt.Printf("Server version: %s\n", serverVersion)
 				}
 			}
+			return nil
 		},
 	}
 
 	cmd.Args = cobra.NoArgs
 	cmd.PersistentFlags().BoolVar(&options.shortVersion, "short", options.shortVersion, "Print the version number(s) only, with no additional output")
 	cmd.PersistentFlags().BoolVar(&options.onlyClientVersion, "client", options.onlyClientVersion, "Print the client version only")
+	addOutputFlag(cmd, &options.output)
 
 	return cmd
 }
 
+// versionReport is the document printed by "version -o json" and
+// "version -o yaml".
+type versionReport struct {
+	ClientVersion string `json:"clientVersion"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+}
+
+// newVersionReport returns the versions of the CLI and, unless client is nil,
+// of the control plane.
+func newVersionReport(client pb.ApiClient) *versionReport {
+	report := &versionReport{ClientVersion: version.Version}
+	if client != nil {
+		report.ServerVersion = getServerVersion(client)
+	}
+	return report
+}
+
 func getServerVersion(client pb.ApiClient) string {
 	resp, err := client.Version(context.Background(), &pb.Empty{})
 	if err != nil {
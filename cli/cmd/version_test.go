@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/version"
 )
 
 func TestGetServerVersion(t *testing.T) {
@@ -37,3 +39,34 @@ func TestGetServerVersion(t *testing.T) {
 		}
 	})
 }
+
+func TestNewVersionReport(t *testing.T) {
+	t.Run("Reports the client and server versions", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+		mockClient.VersionInfoToReturn = &pb.VersionInfo{
+			ReleaseVersion: "1.2.3",
+		}
+
+		output, err := marshalOutput(newVersionReport(mockClient), jsonOutput)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedOutput := fmt.Sprintf("{\n  \"clientVersion\": \"%s\",\n  \"serverVersion\": \"1.2.3\"\n}\n", version.Version)
+		if output != expectedOutput {
+			t.Fatalf("Expected output:\n%s\nbut got:\n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Omits the server version without a client", func(t *testing.T) {
+		output, err := marshalOutput(newVersionReport(nil), yamlOutput)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedOutput := fmt.Sprintf("clientVersion: %s\n", version.Version)
+		if output != expectedOutput {
+			t.Fatalf("Expected output:\n%s\nbut got:\n%s", expectedOutput, output)
+		}
+	})
+}
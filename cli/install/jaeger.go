@@ -0,0 +1,176 @@
+package install
+
+// JaegerTemplate provides the template for the `linkerd jaeger install`
+// command. It deploys a Jaeger all-in-one instance, along with an OpenCensus
+// collector that receives the spans emitted by the proxies, and those of the
+// control plane on its Zipkin receiver, and forwards them to Jaeger.
+const JaegerTemplate = `### Namespace ###
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: {{.Namespace}}
+  labels:
+    {{.ExtensionLabel}}: jaeger
+
+### Collector ###
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: collector
+  namespace: {{.Namespace}}
+  labels:
+    {{.ExtensionLabel}}: jaeger
+    {{.ComponentLabel}}: collector
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  type: ClusterIP
+  selector:
+    {{.ComponentLabel}}: collector
+  ports:
+  - name: opencensus
+    port: 55678
+    targetPort: 55678
+  - name: zipkin
+    port: 9411
+    targetPort: 9411
+
+---
+kind: Deployment
+apiVersion: extensions/v1beta1
+metadata:
+  name: collector
+  namespace: {{.Namespace}}
+  labels:
+    {{.ExtensionLabel}}: jaeger
+    {{.ComponentLabel}}: collector
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  replicas: 1
+  template:
+    metadata:
+      labels:
+        {{.ExtensionLabel}}: jaeger
+        {{.ComponentLabel}}: collector
+      annotations:
+        {{.CreatedByAnnotation}}: {{.CliVersion}}
+    spec:
+      volumes:
+      - name: collector-config
+        configMap:
+          name: collector-config
+          items:
+          - key: collector.yaml
+            path: collector.yaml
+      containers:
+      - name: collector
+        image: {{.CollectorImage}}
+        imagePullPolicy: {{.ImagePullPolicy}}
+        command:
+        - /occollector_linux
+        - --config=/etc/collector/collector.yaml
+        ports:
+        - name: opencensus
+          containerPort: 55678
+        - name: zipkin
+          containerPort: 9411
+        volumeMounts:
+        - name: collector-config
+          mountPath: /etc/collector
+          readOnly: true
+        livenessProbe:
+          tcpSocket:
+            port: 55678
+        readinessProbe:
+          tcpSocket:
+            port: 55678
+
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: collector-config
+  namespace: {{.Namespace}}
+  labels:
+    {{.ExtensionLabel}}: jaeger
+    {{.ComponentLabel}}: collector
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  collector.yaml: |-
+    receivers:
+      opencensus:
+        port: 55678
+      zipkin:
+        port: 9411
+    queued-exporters:
+      jaeger:
+        num-workers: 4
+        queue-size: 100
+        retry-on-failure: true
+        sender-type: jaeger-thrift-http
+        jaeger-thrift-http:
+          collector-endpoint: http://jaeger.{{.Namespace}}.svc.cluster.local:14268/api/traces
+          timeout: 5s
+
+### Jaeger ###
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: jaeger
+  namespace: {{.Namespace}}
+  labels:
+    {{.ExtensionLabel}}: jaeger
+    {{.ComponentLabel}}: jaeger
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  type: ClusterIP
+  selector:
+    {{.ComponentLabel}}: jaeger
+  ports:
+  - name: collection
+    port: 14268
+    targetPort: 14268
+  - name: ui
+    port: {{.UIPort}}
+    targetPort: {{.UIPort}}
+
+---
+kind: Deployment
+apiVersion: extensions/v1beta1
+metadata:
+  name: jaeger
+  namespace: {{.Namespace}}
+  labels:
+    {{.ExtensionLabel}}: jaeger
+    {{.ComponentLabel}}: jaeger
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  replicas: 1
+  template:
+    metadata:
+      labels:
+        {{.ExtensionLabel}}: jaeger
+        {{.ComponentLabel}}: jaeger
+      annotations:
+        {{.CreatedByAnnotation}}: {{.CliVersion}}
+    spec:
+      containers:
+      - name: jaeger
+        image: {{.JaegerImage}}
+        imagePullPolicy: {{.ImagePullPolicy}}
+        ports:
+        - name: collection
+          containerPort: 14268
+        - name: ui
+          containerPort: {{.UIPort}}
+        readinessProbe:
+          httpGet:
+            path: /
+            port: 14269
+`
@@ -6,6 +6,11 @@ kind: Namespace
 apiVersion: v1
 metadata:
   name: {{.Namespace}}
+  {{- if .OpenShiftUIDRange}}
+  annotations:
+    openshift.io/sa.scc.uid-range: "{{.OpenShiftUIDRange}}"
+    openshift.io/sa.scc.supplemental-groups: "{{.OpenShiftUIDRange}}"
+  {{- end}}
 
 ### Service Account Controller ###
 ---
@@ -26,7 +31,7 @@ rules:
   resources: ["deployments", "replicasets"]
   verbs: ["list", "get", "watch"]
 - apiGroups: [""]
-  resources: ["pods", "endpoints", "services", "namespaces", "replicationcontrollers"]
+  resources: ["pods", "endpoints", "services", "namespaces", "replicationcontrollers", "configmaps"]
   verbs: ["list", "get", "watch"]
 
 ---
@@ -43,6 +48,25 @@ subjects:
   name: linkerd-controller
   namespace: {{.Namespace}}
 
+### Service Profile CRD ###
+---
+kind: CustomResourceDefinition
+apiVersion: apiextensions.k8s.io/v1beta1
+metadata:
+  name: serviceprofiles.linkerd.io
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  group: linkerd.io
+  version: v1alpha1
+  scope: Namespaced
+  names:
+    plural: serviceprofiles
+    singular: serviceprofile
+    kind: ServiceProfile
+    shortNames:
+    - sp
+
 ### Service Account Prometheus ###
 ---
 kind: ServiceAccount
@@ -76,6 +100,20 @@ subjects:
   name: linkerd-prometheus
   namespace: {{.Namespace}}
 
+### Proxy Config ###
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-proxy-config
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  hash: "{{.ProxyConfigHash}}"
+
 ### Controller ###
 ---
 kind: Service
@@ -133,7 +171,17 @@ spec:
         {{.ControllerComponentLabel}}: controller
       annotations:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
-    spec:
+    spec:{{template "nodeAffinity" .}}
+        {{- if gt .ControllerReplicas 1}}
+        podAntiAffinity:
+          preferredDuringSchedulingIgnoredDuringExecution:
+          - weight: 100
+            podAffinityTerm:
+              labelSelector:
+                matchLabels:
+                  {{.ControllerComponentLabel}}: controller
+              topologyKey: kubernetes.io/hostname
+        {{- end}}
       serviceAccount: linkerd-controller
       containers:
       - name: public-api
@@ -149,9 +197,13 @@ spec:
         - "-prometheus-url=http://prometheus.{{.Namespace}}.svc.cluster.local:9090"
         - "-controller-namespace={{.Namespace}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.ControllerLogFormat}}"
+        {{- if .ControllerTraceCollector}}
+        - "-trace-collector={{.ControllerTraceCollector}}"
+        {{- end}}
         livenessProbe:
           httpGet:
-            path: /ping
+            path: /live
             port: 9995
           initialDelaySeconds: 10
         readinessProbe:
@@ -171,9 +223,13 @@ spec:
         - "destination"
         - "-enable-tls={{.EnableTLS}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.ControllerLogFormat}}"
+        {{- if .ControllerTraceCollector}}
+        - "-trace-collector={{.ControllerTraceCollector}}"
+        {{- end}}
         livenessProbe:
           httpGet:
-            path: /ping
+            path: /live
             port: 9999
           initialDelaySeconds: 10
         readinessProbe:
@@ -193,9 +249,10 @@ spec:
         - "proxy-api"
         - "-addr=:{{.ProxyAPIPort}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.ControllerLogFormat}}"
         livenessProbe:
           httpGet:
-            path: /ping
+            path: /live
             port: 9996
           initialDelaySeconds: 10
         readinessProbe:
@@ -214,10 +271,14 @@ spec:
         args:
         - "tap"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.ControllerLogFormat}}"
         - "-controller-namespace={{.Namespace}}"
+        - "-max-rps={{.TapMaxRps}}"
+        - "-max-tapped-pods={{.TapMaxPods}}"
+        - "-max-duration={{.TapMaxDuration}}"
         livenessProbe:
           httpGet:
-            path: /ping
+            path: /live
             port: 9998
           initialDelaySeconds: 10
         readinessProbe:
@@ -226,6 +287,24 @@ spec:
             port: 9998
           failureThreshold: 7
 
+{{- if gt .ControllerReplicas 1}}
+---
+kind: PodDisruptionBudget
+apiVersion: policy/v1beta1
+metadata:
+  name: controller
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  minAvailable: 1
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: controller
+{{- end}}
+
 ### Web ###
 ---
 kind: Service
@@ -267,7 +346,7 @@ spec:
         {{.ControllerComponentLabel}}: web
       annotations:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
-    spec:
+    spec:{{template "nodeAffinity" .}}
       containers:
       - name: web
         ports:
@@ -284,9 +363,12 @@ spec:
         - "-uuid={{.UUID}}"
         - "-controller-namespace={{.Namespace}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.ControllerLogFormat}}"
+        - "-disable-telemetry={{.DisableTelemetry}}"
+        - "-read-only={{.DashboardReadOnly}}"
         livenessProbe:
           httpGet:
-            path: /ping
+            path: /live
             port: 9994
           initialDelaySeconds: 10
         readinessProbe:
@@ -333,7 +415,7 @@ spec:
         {{.ControllerComponentLabel}}: prometheus
       annotations:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
-    spec:
+    spec:{{template "nodeAffinity" .}}
       serviceAccount: linkerd-prometheus
       volumes:
       - name: prometheus-config
@@ -382,6 +464,11 @@ data:
       scrape_interval: 10s
       scrape_timeout: 10s
       evaluation_interval: 10s
+    {{- if .AlertGroups}}
+
+    rule_files:
+    - /etc/prometheus/alerting_rules.yml
+    {{- end}}
 
     scrape_configs:
     - job_name: 'prometheus'
@@ -448,6 +535,63 @@ data:
       # foo=bar
       - action: labelmap
         regex: __meta_kubernetes_pod_label_linkerd_io_(.+)
+  {{- if .AlertGroups}}
+
+  alerting_rules.yml: |-
+    groups:
+    {{- if index .AlertGroups "control-plane"}}
+    - name: linkerd-control-plane
+      rules:
+      - alert: LinkerdControlPlaneComponentDown
+        expr: up{job="linkerd-controller"} == 0
+        for: 5m
+        labels:
+          severity: critical
+        annotations:
+          summary: The {{"{{$labels.component}}"}} component of the Linkerd control plane is down
+    {{- end}}
+    {{- if index .AlertGroups "certificates"}}
+    - name: linkerd-certificates
+      rules:
+      - alert: LinkerdCACertificateExpiringSoon
+        expr: ca_certificate_expiration_timestamp_seconds{job="linkerd-controller"} - time() < 7 * 24 * 3600
+        for: 1h
+        labels:
+          severity: warning
+        annotations:
+          summary: The certificate of the Linkerd CA expires in less than 7 days; replace the issuer secret before the proxies stop trusting each other
+      - alert: LinkerdCACertificateExpiring
+        expr: ca_certificate_expiration_timestamp_seconds{job="linkerd-controller"} - time() < 24 * 3600
+        labels:
+          severity: critical
+        annotations:
+          summary: The certificate of the Linkerd CA expires in less than a day; replace the issuer secret before the proxies stop trusting each other
+    {{- end}}
+    {{- if index .AlertGroups "success-rate"}}
+    - name: linkerd-success-rate
+      rules:
+      - alert: LinkerdSuccessRateCollapse
+        expr: |-
+          sum(rate(response_total{job="linkerd-proxy", direction="inbound", classification="success"}[5m])) by (namespace, deployment)
+            / sum(rate(response_total{job="linkerd-proxy", direction="inbound"}[5m])) by (namespace, deployment) < 0.5
+          and sum(rate(response_total{job="linkerd-proxy", direction="inbound"}[5m])) by (namespace, deployment) > 0.1
+        for: 5m
+        labels:
+          severity: critical
+        annotations:
+          summary: Less than half of the requests to the {{"{{$labels.namespace}}/{{$labels.deployment}}"}} deployment succeed
+    {{- end}}
+    {{- if index .AlertGroups "proxy-restarts"}}
+    - name: linkerd-proxy-restarts
+      rules:
+      - alert: LinkerdProxyRestartStorm
+        expr: changes(process_start_time_seconds{job="linkerd-proxy"}[15m]) >= 3
+        labels:
+          severity: warning
+        annotations:
+          summary: The Linkerd proxy of the {{"{{$labels.namespace}}/{{$labels.pod}}"}} pod restarted {{"{{$value}}"}} times in 15 minutes
+    {{- end}}
+  {{- end}}
 
 ### Grafana ###
 ---
@@ -487,7 +631,7 @@ spec:
         {{.ControllerComponentLabel}}: grafana
       annotations:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
-    spec:
+    spec:{{template "nodeAffinity" .}}
       volumes:
       - name: grafana-config
         configMap:
@@ -604,6 +748,13 @@ rules:
   resources: ["configmaps"]
   resourceNames: [{{.TLSTrustAnchorConfigMapName}}]
   verbs: ["update"]
+- apiGroups: [""]
+  resources: ["configmaps"]
+  resourceNames: ["linkerd-ca-leader"]
+  verbs: ["get", "update"]
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["create", "patch"]
 - apiGroups: [""]
   resources: ["pods"]
   verbs: ["list", "get", "watch"]
@@ -613,6 +764,34 @@ rules:
 - apiGroups: [""]
   resources: ["secrets"]
   verbs: ["create", "update"]
+{{- if .IdentityIssuerSecret}}
+
+---
+kind: Role
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-ca-issuer
+  namespace: {{.Namespace}}
+rules:
+- apiGroups: [""]
+  resources: ["secrets"]
+  verbs: ["get", "list", "watch"]
+
+---
+kind: RoleBinding
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-ca-issuer
+  namespace: {{.Namespace}}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: linkerd-ca-issuer
+subjects:
+- kind: ServiceAccount
+  name: linkerd-ca
+  namespace: {{.Namespace}}
+{{- end}}
 
 ---
 kind: ClusterRoleBinding
@@ -647,7 +826,17 @@ spec:
         {{.ControllerComponentLabel}}: ca
       annotations:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
-    spec:
+    spec:{{template "nodeAffinity" .}}
+        {{- if gt .ControllerReplicas 1}}
+        podAntiAffinity:
+          preferredDuringSchedulingIgnoredDuringExecution:
+          - weight: 100
+            podAffinityTerm:
+              labelSelector:
+                matchLabels:
+                  {{.ControllerComponentLabel}}: ca
+              topologyKey: kubernetes.io/hostname
+        {{- end}}
       serviceAccount: linkerd-ca
       containers:
       - name: ca
@@ -660,9 +849,13 @@ spec:
         - "ca"
         - "-controller-namespace={{.Namespace}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.ControllerLogFormat}}"
+        {{- if .IdentityIssuerSecret}}
+        - "-issuer-secret={{.IdentityIssuerSecret}}"
+        {{- end}}
         livenessProbe:
           httpGet:
-            path: /ping
+            path: /live
             port: 9997
           initialDelaySeconds: 10
         readinessProbe:
@@ -670,4 +863,35 @@ spec:
             path: /ready
             port: 9997
           failureThreshold: 7
+
+{{- if gt .ControllerReplicas 1}}
+---
+kind: PodDisruptionBudget
+apiVersion: policy/v1beta1
+metadata:
+  name: ca
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: ca
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  minAvailable: 1
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: ca
+{{- end}}
 `
+
+// NodeAffinityTemplate restricts control plane pods to nodes with an
+// architecture the Linkerd images are built for.
+const NodeAffinityTemplate = `{{define "nodeAffinity"}}
+      affinity:
+        nodeAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            nodeSelectorTerms:
+            - matchExpressions:
+              - key: {{.NodeArchLabel}}
+                operator: In
+                values:{{range .NodeArchitectures}}
+                - {{.}}{{end}}{{end}}`
@@ -7,6 +7,10 @@ import (
 )
 
 func main() {
+	if ran, exitCode := cmd.RunPlugin(os.Args[1:]); ran {
+		os.Exit(exitCode)
+	}
+
 	if err := cmd.RootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
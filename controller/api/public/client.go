@@ -12,6 +12,7 @@ import (
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/requestid"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -56,13 +57,49 @@ func (c *grpcOverHttpClient) ListPods(ctx context.Context, req *pb.ListPodsReque
 	return &msg, err
 }
 
+func (c *grpcOverHttpClient) MeshCoverage(ctx context.Context, req *pb.MeshCoverageRequest, _ ...grpc.CallOption) (*pb.MeshCoverageResponse, error) {
+	var msg pb.MeshCoverageResponse
+	err := c.apiRequest(ctx, "MeshCoverage", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHttpClient) ListNamespaces(ctx context.Context, req *pb.ListNamespacesRequest, _ ...grpc.CallOption) (*pb.ListNamespacesResponse, error) {
+	var msg pb.ListNamespacesResponse
+	err := c.apiRequest(ctx, "ListNamespaces", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHttpClient) LatencyDistribution(ctx context.Context, req *pb.LatencyDistributionRequest, _ ...grpc.CallOption) (*pb.LatencyDistributionResponse, error) {
+	var msg pb.LatencyDistributionResponse
+	err := c.apiRequest(ctx, "LatencyDistribution", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHttpClient) PodStats(ctx context.Context, req *pb.PodStatsRequest, _ ...grpc.CallOption) (*pb.PodStatsResponse, error) {
+	var msg pb.PodStatsResponse
+	err := c.apiRequest(ctx, "PodStats", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHttpClient) ListResources(ctx context.Context, req *pb.ListResourcesRequest, _ ...grpc.CallOption) (*pb.ListResourcesResponse, error) {
+	var msg pb.ListResourcesResponse
+	err := c.apiRequest(ctx, "ListResources", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHttpClient) ProxySkew(ctx context.Context, req *pb.ProxySkewRequest, _ ...grpc.CallOption) (*pb.ProxySkewResponse, error) {
+	var msg pb.ProxySkewResponse
+	err := c.apiRequest(ctx, "ProxySkew", req, &msg)
+	return &msg, err
+}
+
 func (c *grpcOverHttpClient) Tap(ctx context.Context, req *pb.TapRequest, _ ...grpc.CallOption) (pb.Api_TapClient, error) {
 	return nil, status.Error(codes.Unimplemented, "Tap is deprecated, use TapByResource")
 }
 
 func (c *grpcOverHttpClient) TapByResource(ctx context.Context, req *pb.TapByResourceRequest, _ ...grpc.CallOption) (pb.Api_TapByResourceClient, error) {
 	url := c.endpointNameToPublicApiUrl("TapByResource")
-	httpRsp, err := c.post(ctx, url, req)
+	httpRsp, err := c.post(k8s.WithStream(ctx), url, req)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +151,9 @@ func (c *grpcOverHttpClient) post(ctx context.Context, url *url.URL, req proto.M
 	if err != nil {
 		return nil, err
 	}
+	if id := requestid.FromContext(ctx); id != "" {
+		httpReq.Header.Set(requestid.Header, id)
+	}
 
 	rsp, err := c.httpClient.Do(httpReq.WithContext(ctx))
 	if err != nil {
@@ -184,7 +224,7 @@ func NewInternalClient(controlPlaneNamespace string, kubeAPIHost string) (pb.Api
 		return nil, err
 	}
 
-	return newClient(apiURL, http.DefaultClient, controlPlaneNamespace)
+	return newClient(apiURL, &http.Client{Transport: k8s.NewLoggingTransport(http.DefaultTransport)}, controlPlaneNamespace)
 }
 
 func NewExternalClient(controlPlaneNamespace string, kubeAPI *k8s.KubernetesAPI) (pb.ApiClient, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,8 +14,10 @@ import (
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/requestid"
 	"github.com/linkerd/linkerd2/pkg/version"
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,6 +28,7 @@ import (
 type (
 	grpcServer struct {
 		prometheusAPI       promv1.API
+		promLimiter         *promQueryLimiter
 		tapClient           tapPb.TapClient
 		k8sAPI              *k8s.API
 		controllerNamespace string
@@ -54,6 +58,7 @@ func newGrpcServer(
 ) *grpcServer {
 	return &grpcServer{
 		prometheusAPI:       promAPI,
+		promLimiter:         newPromQueryLimiter(maxConcurrentPromQueries, promQueryTimeout, promFailureThreshold, promCooldown),
 		tapClient:           tapClient,
 		k8sAPI:              k8sAPI,
 		controllerNamespace: controllerNamespace,
@@ -66,7 +71,7 @@ func (*grpcServer) Version(ctx context.Context, req *pb.Empty) (*pb.VersionInfo,
 }
 
 func (s *grpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb.ListPodsResponse, error) {
-	log.Debugf("ListPods request: %+v", req)
+	requestid.Logger(ctx).Debugf("ListPods request: %+v", req)
 
 	// Reports is a map from instance name to the absolute time of the most recent
 	// report from that instance and its process start time
@@ -128,14 +133,6 @@ func (s *grpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb
 			}
 		}
 
-		proxyVersion := ""
-		for _, container := range pod.Spec.Containers {
-			if container.Name == pkgK8s.ProxyContainerName {
-				parts := strings.Split(container.Image, ":")
-				proxyVersion = parts[1]
-			}
-		}
-
 		item := &pb.Pod{
 			Name:                pod.Namespace + "/" + pod.Name,
 			Status:              status,
@@ -144,7 +141,8 @@ func (s *grpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb
 			ControllerNamespace: controllerNS,
 			ControlPlane:        controllerComponent != "",
 			ProxyReady:          proxyReady,
-			ProxyVersion:        proxyVersion,
+			ProxyVersion:        proxyVersionOf(pod),
+			ProxyConfig:         proxyConfigForPod(pod),
 		}
 
 		ownerKind, ownerName := s.k8sAPI.GetOwnerKindAndName(pod)
@@ -183,11 +181,183 @@ func (s *grpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb
 
 	rsp := pb.ListPodsResponse{Pods: podList}
 
-	log.Debugf("ListPods response: %+v", rsp)
+	requestid.Logger(ctx).Debugf("ListPods response: %+v", rsp)
 
 	return &rsp, nil
 }
 
+func (s *grpcServer) MeshCoverage(ctx context.Context, req *pb.MeshCoverageRequest) (*pb.MeshCoverageResponse, error) {
+	requestid.Logger(ctx).Debugf("MeshCoverage request: %+v", req)
+
+	var pods []*k8sV1.Pod
+	var err error
+	if req.GetNamespace() != "" {
+		pods, err = s.k8sAPI.Pod().Lister().Pods(req.GetNamespace()).List(labels.Everything())
+	} else {
+		pods, err = s.k8sAPI.Pod().Lister().List(labels.Everything())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	coverageByNs := make(map[string]*pb.NamespaceCoverage)
+	meshedWorkloads := make(map[string]map[string]bool)
+	unmeshedWorkloads := make(map[string]map[string]bool)
+	proxyVersions := make(map[string]map[string]uint64)
+
+	for _, pod := range pods {
+		if s.shouldIgnore(pod) {
+			continue
+		}
+		if pod.Status.Phase != k8sV1.PodPending && pod.Status.Phase != k8sV1.PodRunning {
+			continue
+		}
+
+		coverage, ok := coverageByNs[pod.Namespace]
+		if !ok {
+			coverage = &pb.NamespaceCoverage{Namespace: pod.Namespace}
+			coverageByNs[pod.Namespace] = coverage
+			meshedWorkloads[pod.Namespace] = make(map[string]bool)
+			unmeshedWorkloads[pod.Namespace] = make(map[string]bool)
+			proxyVersions[pod.Namespace] = make(map[string]uint64)
+		}
+
+		ownerKind, ownerName := s.k8sAPI.GetOwnerKindAndName(pod)
+		key := ownerKind + "/" + ownerName
+
+		if pkgK8s.IsMeshed(pod, s.controllerNamespace) {
+			coverage.MeshedPodCount++
+			proxyVersions[pod.Namespace][proxyVersionOf(pod)]++
+			if !meshedWorkloads[pod.Namespace][key] {
+				meshedWorkloads[pod.Namespace][key] = true
+				coverage.MeshedWorkloadCount++
+			}
+			continue
+		}
+		coverage.UnmeshedPodCount++
+
+		if !unmeshedWorkloads[pod.Namespace][key] {
+			unmeshedWorkloads[pod.Namespace][key] = true
+			coverage.UnmeshedWorkloads = append(coverage.UnmeshedWorkloads, &pb.Resource{
+				Namespace: pod.Namespace,
+				Type:      ownerKind,
+				Name:      ownerName,
+			})
+		}
+	}
+
+	namespaces := make([]string, 0)
+	for ns := range coverageByNs {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	rsp := &pb.MeshCoverageResponse{}
+	for _, ns := range namespaces {
+		coverage := coverageByNs[ns]
+		sort.Slice(coverage.UnmeshedWorkloads, func(i, j int) bool {
+			a, b := coverage.UnmeshedWorkloads[i], coverage.UnmeshedWorkloads[j]
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.Name < b.Name
+		})
+
+		versions := make([]string, 0)
+		for version := range proxyVersions[ns] {
+			versions = append(versions, version)
+		}
+		sort.Strings(versions)
+		for _, version := range versions {
+			coverage.ProxyVersions = append(coverage.ProxyVersions, &pb.ProxyVersionCount{
+				Version:  version,
+				PodCount: proxyVersions[ns][version],
+			})
+		}
+
+		rsp.Namespaces = append(rsp.Namespaces, coverage)
+	}
+
+	requestid.Logger(ctx).Debugf("MeshCoverage response: %+v", rsp)
+
+	return rsp, nil
+}
+
+// ListNamespaces returns every namespace with its pod counts, its proxy
+// injection setting and, if a time window is requested, its inbound traffic
+// stats, so that clients don't need a query per namespace.
+func (s *grpcServer) ListNamespaces(ctx context.Context, req *pb.ListNamespacesRequest) (*pb.ListNamespacesResponse, error) {
+	requestid.Logger(ctx).Debugf("ListNamespaces request: %+v", req)
+
+	namespaces, err := s.k8sAPI.NS().Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := s.k8sAPI.Pod().Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*pb.NamespaceSummary)
+	for _, ns := range namespaces {
+		if s.isIgnoredNamespace(ns.Name) {
+			continue
+		}
+		summaries[ns.Name] = &pb.NamespaceSummary{
+			Name:        ns.Name,
+			ProxyInject: ns.Annotations[pkgK8s.ProxyInjectAnnotation],
+		}
+	}
+
+	for _, pod := range pods {
+		summary, ok := summaries[pod.Namespace]
+		if !ok {
+			continue
+		}
+
+		switch pod.Status.Phase {
+		case k8sV1.PodFailed:
+			summary.FailedPodCount++
+		case k8sV1.PodPending, k8sV1.PodRunning:
+			if pkgK8s.IsMeshed(pod, s.controllerNamespace) {
+				summary.MeshedPodCount++
+			} else {
+				summary.UnmeshedPodCount++
+			}
+		}
+	}
+
+	if req.GetTimeWindow() != "" {
+		stats, err := s.queryPrometheusMetrics(ctx, pkgK8s.Namespace, promDirectionLabels("inbound"), model.LabelNames{namespaceLabel}, req.GetTimeWindow())
+		if err == errPrometheusUnavailable {
+			// still return the namespaces and their pod counts, without stats
+			log.Warnf("Returning namespaces without stats: %s", err)
+		} else if err != nil {
+			return nil, err
+		}
+
+		for name, summary := range summaries {
+			summary.Stats = stats[rKey{Type: pkgK8s.Namespace, Name: name}]
+		}
+	}
+
+	names := make([]string, 0)
+	for name := range summaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rsp := &pb.ListNamespacesResponse{}
+	for _, name := range names {
+		rsp.Namespaces = append(rsp.Namespaces, summaries[name])
+	}
+
+	requestid.Logger(ctx).Debugf("ListNamespaces response: %+v", rsp)
+
+	return rsp, nil
+}
+
 func (s *grpcServer) SelfCheck(ctx context.Context, in *healthcheckPb.SelfCheckRequest) (*healthcheckPb.SelfCheckResponse, error) {
 	k8sClientCheck := &healthcheckPb.CheckResult{
 		SubsystemName:    K8sClientSubsystemName,
@@ -247,10 +417,29 @@ func (s *grpcServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_T
 }
 
 func (s *grpcServer) shouldIgnore(pod *k8sV1.Pod) bool {
-	for _, namespace := range s.ignoredNamespaces {
-		if pod.Namespace == namespace {
+	return s.isIgnoredNamespace(pod.Namespace)
+}
+
+func (s *grpcServer) isIgnoredNamespace(namespace string) bool {
+	for _, ignored := range s.ignoredNamespaces {
+		if namespace == ignored {
 			return true
 		}
 	}
 	return false
 }
+
+// proxyVersionOf returns the version of a pod's proxy, taken from the tag of
+// its image, or an empty string if the pod has no proxy or its image isn't
+// tagged.
+func proxyVersionOf(pod *k8sV1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == pkgK8s.ProxyContainerName {
+			parts := strings.Split(container.Image, ":")
+			if len(parts) > 1 {
+				return parts[len(parts)-1]
+			}
+		}
+	}
+	return ""
+}
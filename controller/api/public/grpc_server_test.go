@@ -5,6 +5,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/duration"
 	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
@@ -174,3 +175,234 @@ spec:
 		}
 	})
 }
+
+func TestMeshCoverage(t *testing.T) {
+	t.Run("Reports meshed and unmeshed pods per namespace", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-meshed-1
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+  ownerReferences:
+  - kind: ReplicationController
+    name: emoji
+spec:
+  containers:
+  - name: linkerd-proxy
+    image: gcr.io/linkerd-io/proxy:edge-18.10.1
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-meshed-2
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+  ownerReferences:
+  - kind: ReplicationController
+    name: emoji
+spec:
+  containers:
+  - name: linkerd-proxy
+    image: gcr.io/linkerd-io/proxy:edge-18.10.2
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: voting-meshed
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  containers:
+  - name: linkerd-proxy
+    image: gcr.io/linkerd-io/proxy:edge-18.10.2
+status:
+  phase: Pending
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-not-meshed
+  namespace: emojivoto
+status:
+  phase: Pending
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-completed
+  namespace: emojivoto
+status:
+  phase: Succeeded
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: books-not-meshed
+  namespace: booksapp
+status:
+  phase: Running
+`,
+		)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		fakeGrpcServer := newGrpcServer(
+			&MockProm{},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		k8sAPI.Sync(nil)
+
+		rsp, err := fakeGrpcServer.MeshCoverage(context.TODO(), &pb.MeshCoverageRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := &pb.MeshCoverageResponse{
+			Namespaces: []*pb.NamespaceCoverage{
+				&pb.NamespaceCoverage{
+					Namespace:        "booksapp",
+					UnmeshedPodCount: 1,
+					UnmeshedWorkloads: []*pb.Resource{
+						&pb.Resource{Namespace: "booksapp", Type: "pod", Name: "books-not-meshed"},
+					},
+				},
+				&pb.NamespaceCoverage{
+					Namespace:        "emojivoto",
+					MeshedPodCount:   3,
+					UnmeshedPodCount: 1,
+					UnmeshedWorkloads: []*pb.Resource{
+						&pb.Resource{Namespace: "emojivoto", Type: "pod", Name: "emoji-not-meshed"},
+					},
+					MeshedWorkloadCount: 2,
+					ProxyVersions: []*pb.ProxyVersionCount{
+						&pb.ProxyVersionCount{Version: "edge-18.10.1", PodCount: 1},
+						&pb.ProxyVersionCount{Version: "edge-18.10.2", PodCount: 2},
+					},
+				},
+			},
+		}
+
+		if !proto.Equal(expected, rsp) {
+			t.Fatalf("Expected: %+v, Got: %+v", expected, rsp)
+		}
+	})
+}
+
+func TestListNamespaces(t *testing.T) {
+	t.Run("Reports pod counts, injection and stats per namespace", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+  annotations:
+    linkerd.io/inject: enabled
+`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: booksapp
+`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: kube-system
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-meshed
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-failed
+  namespace: emojivoto
+status:
+  phase: Failed
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: books-not-meshed
+  namespace: booksapp
+status:
+  phase: Pending
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-dns
+  namespace: kube-system
+status:
+  phase: Running
+`,
+		)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		fakeGrpcServer := newGrpcServer(
+			&MockProm{Res: model.Vector{
+				genPromSample("emojivoto", "namespace", "emojivoto", "success", false),
+			}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{"kube-system"},
+		)
+
+		k8sAPI.Sync(nil)
+
+		rsp, err := fakeGrpcServer.ListNamespaces(context.TODO(), &pb.ListNamespacesRequest{TimeWindow: "1m"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := &pb.ListNamespacesResponse{
+			Namespaces: []*pb.NamespaceSummary{
+				&pb.NamespaceSummary{
+					Name:             "booksapp",
+					UnmeshedPodCount: 1,
+				},
+				&pb.NamespaceSummary{
+					Name:           "emojivoto",
+					MeshedPodCount: 1,
+					FailedPodCount: 1,
+					ProxyInject:    "enabled",
+					Stats: &pb.BasicStats{
+						SuccessCount:    123,
+						FailureCount:    0,
+						LatencyMsP50:    123,
+						LatencyMsP95:    123,
+						LatencyMsP99:    123,
+						TlsRequestCount: 123,
+					},
+				},
+			},
+		}
+
+		if !proto.Equal(expected, rsp) {
+			t.Fatalf("Expected: %+v, Got: %+v", expected, rsp)
+		}
+	})
+}
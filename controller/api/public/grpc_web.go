@@ -0,0 +1,225 @@
+package public
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The public API is also served over gRPC-web, so that browser clients can
+// call it with a generated gRPC-web client instead of going through the
+// proto-over-HTTP shim. Only the binary ("application/grpc-web+proto") wire
+// format is supported.
+//
+// See https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-WEB.md
+const (
+	GrpcWebPathPrefix = "/linkerd2.public.Api/"
+
+	grpcWebContentType      = "application/grpc-web"
+	grpcWebProtoContentType = "application/grpc-web+proto"
+	grpcWebTextContentType  = "application/grpc-web-text"
+
+	grpcWebFrameHeaderLength = 5
+	grpcWebDataFrame         = byte(0x00)
+	grpcWebTrailerFrame      = byte(0x80)
+)
+
+// IsGrpcWebRequest returns true if req should be handled as a gRPC-web call.
+func IsGrpcWebRequest(req *http.Request) bool {
+	return strings.HasPrefix(req.URL.Path, GrpcWebPathPrefix) &&
+		strings.HasPrefix(req.Header.Get(contentTypeHeader), grpcWebContentType)
+}
+
+func (h *handler) handleGrpcWeb(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+	if strings.HasPrefix(req.Header.Get(contentTypeHeader), grpcWebTextContentType) {
+		http.Error(w, "grpc-web-text is not supported", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set(contentTypeHeader, grpcWebProtoContentType)
+
+	ctx := req.Context()
+	method := strings.TrimPrefix(req.URL.Path, GrpcWebPathPrefix)
+
+	switch method {
+	case "StatSummary":
+		var in pb.StatSummaryRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.StatSummary(ctx, &in)
+		})
+	case "Version":
+		var in pb.Empty
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.Version(ctx, &in)
+		})
+	case "ListPods":
+		var in pb.ListPodsRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.ListPods(ctx, &in)
+		})
+	case "MeshCoverage":
+		var in pb.MeshCoverageRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.MeshCoverage(ctx, &in)
+		})
+	case "ListNamespaces":
+		var in pb.ListNamespacesRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.ListNamespaces(ctx, &in)
+		})
+	case "LatencyDistribution":
+		var in pb.LatencyDistributionRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.LatencyDistribution(ctx, &in)
+		})
+	case "PodStats":
+		var in pb.PodStatsRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.PodStats(ctx, &in)
+		})
+	case "ListResources":
+		var in pb.ListResourcesRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.ListResources(ctx, &in)
+		})
+	case "ProxySkew":
+		var in pb.ProxySkewRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.ProxySkew(ctx, &in)
+		})
+	case "SelfCheck":
+		var in healthcheckPb.SelfCheckRequest
+		h.serveGrpcWebUnary(w, req, &in, func() (proto.Message, error) {
+			return h.grpcServer.SelfCheck(ctx, &in)
+		})
+	case "TapByResource":
+		h.serveGrpcWebTapByResource(w, req)
+	default:
+		writeGrpcWebTrailer(w, status.Errorf(codes.Unimplemented, "unknown method %s", method))
+	}
+}
+
+func (h *handler) serveGrpcWebUnary(w http.ResponseWriter, req *http.Request, in proto.Message, call func() (proto.Message, error)) {
+	err := readGrpcWebMessage(req.Body, in)
+	if err != nil {
+		writeGrpcWebTrailer(w, err)
+		return
+	}
+
+	rsp, err := call()
+	if err != nil {
+		writeGrpcWebTrailer(w, err)
+		return
+	}
+
+	err = writeGrpcWebMessage(w, rsp)
+	writeGrpcWebTrailer(w, err)
+}
+
+func (h *handler) serveGrpcWebTapByResource(w http.ResponseWriter, req *http.Request) {
+	flushableWriter, err := newStreamingWriter(w)
+	if err != nil {
+		writeGrpcWebTrailer(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+
+	var in pb.TapByResourceRequest
+	err = readGrpcWebMessage(req.Body, &in)
+	if err != nil {
+		writeGrpcWebTrailer(w, err)
+		return
+	}
+
+	err = h.grpcServer.TapByResource(&in, grpcWebTapServer{w: flushableWriter, req: req})
+	writeGrpcWebTrailer(w, err)
+}
+
+type grpcWebTapServer struct {
+	w   flushableResponseWriter
+	req *http.Request
+}
+
+func (s grpcWebTapServer) Send(msg *pb.TapEvent) error {
+	err := writeGrpcWebMessage(s.w, msg)
+	if err != nil {
+		return err
+	}
+
+	s.w.Flush()
+	return nil
+}
+
+// satisfy the pb.Api_TapByResourceServer interface
+func (s grpcWebTapServer) SetHeader(metadata.MD) error  { return nil }
+func (s grpcWebTapServer) SendHeader(metadata.MD) error { return nil }
+func (s grpcWebTapServer) SetTrailer(metadata.MD)       {}
+func (s grpcWebTapServer) Context() context.Context     { return s.req.Context() }
+func (s grpcWebTapServer) SendMsg(interface{}) error    { return nil }
+func (s grpcWebTapServer) RecvMsg(interface{}) error    { return nil }
+
+func readGrpcWebMessage(r io.Reader, msg proto.Message) error {
+	header := make([]byte, grpcWebFrameHeaderLength)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "error while reading message header: %v", err)
+	}
+	if header[0] != grpcWebDataFrame {
+		return status.Errorf(codes.Unimplemented, "unsupported frame flags: %#x", header[0])
+	}
+
+	bytes := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	_, err = io.ReadFull(r, bytes)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "error while reading message: %v", err)
+	}
+
+	err = proto.Unmarshal(bytes, msg)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "error while decoding message: %v", err)
+	}
+
+	return nil
+}
+
+func writeGrpcWebMessage(w io.Writer, msg proto.Message) error {
+	bytes, err := proto.Marshal(msg)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	_, err = w.Write(grpcWebFrame(grpcWebDataFrame, bytes))
+	return err
+}
+
+// writeGrpcWebTrailer ends a gRPC-web response. Since browsers can't read HTTP
+// trailers, the gRPC status is sent as a final length-prefixed frame instead.
+func writeGrpcWebTrailer(w io.Writer, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.New(codes.Unknown, err.Error())
+	}
+	trailer := fmt.Sprintf("grpc-status: %d\r\ngrpc-message: %s\r\n", st.Code(), url.PathEscape(st.Message()))
+	w.Write(grpcWebFrame(grpcWebTrailerFrame, []byte(trailer)))
+}
+
+func grpcWebFrame(flags byte, payload []byte) []byte {
+	frame := make([]byte, grpcWebFrameHeaderLength, grpcWebFrameHeaderLength+len(payload))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(payload)))
+	return append(frame, payload...)
+}
@@ -0,0 +1,131 @@
+package public
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newGrpcWebRequest(t *testing.T, method string, msg proto.Message) *http.Request {
+	var body bytes.Buffer
+	err := writeGrpcWebMessage(&body, msg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", GrpcWebPathPrefix+method, &body)
+	req.Header.Set(contentTypeHeader, grpcWebProtoContentType)
+	return req
+}
+
+func readGrpcWebTrailer(t *testing.T, body *bytes.Buffer) string {
+	header := body.Next(grpcWebFrameHeaderLength)
+	if len(header) != grpcWebFrameHeaderLength || header[0] != grpcWebTrailerFrame {
+		t.Fatalf("Expected a trailer frame but got header %v", header)
+	}
+	return body.String()
+}
+
+func TestGrpcWeb(t *testing.T) {
+	t.Run("Delegates unary calls to the underlying grpc server", func(t *testing.T) {
+		expectedResponse := &pb.VersionInfo{GoVersion: "1.9.1", BuildDate: "2017.11.17", ReleaseVersion: "1.2.3"}
+		h := &handler{grpcServer: &mockGrpcServer{ResponseToReturn: expectedResponse}}
+
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, newGrpcWebRequest(t, "Version", &pb.Empty{}))
+
+		if rsp.Header().Get(contentTypeHeader) != grpcWebProtoContentType {
+			t.Fatalf("Expected content type [%s] but got [%s]", grpcWebProtoContentType, rsp.Header().Get(contentTypeHeader))
+		}
+
+		var actualResponse pb.VersionInfo
+		err := readGrpcWebMessage(rsp.Body, &actualResponse)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !proto.Equal(&actualResponse, expectedResponse) {
+			t.Fatalf("Expected response [%+v] but got [%+v]", expectedResponse, actualResponse)
+		}
+
+		expectedTrailer := "grpc-status: 0\r\ngrpc-message: \r\n"
+		if trailer := readGrpcWebTrailer(t, rsp.Body); trailer != expectedTrailer {
+			t.Fatalf("Expected trailer [%q] but got [%q]", expectedTrailer, trailer)
+		}
+	})
+
+	t.Run("Streams tap events", func(t *testing.T) {
+		expectedEvents := []*pb.TapEvent{
+			{ProxyDirection: pb.TapEvent_INBOUND},
+			{ProxyDirection: pb.TapEvent_OUTBOUND},
+		}
+		h := &handler{grpcServer: &mockGrpcServer{TapStreamsToReturn: expectedEvents}}
+
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, newGrpcWebRequest(t, "TapByResource", &pb.TapByResourceRequest{}))
+
+		for _, expectedEvent := range expectedEvents {
+			var actualEvent pb.TapEvent
+			err := readGrpcWebMessage(rsp.Body, &actualEvent)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !proto.Equal(&actualEvent, expectedEvent) {
+				t.Fatalf("Expected event [%+v] but got [%+v]", expectedEvent, actualEvent)
+			}
+		}
+
+		expectedTrailer := "grpc-status: 0\r\ngrpc-message: \r\n"
+		if trailer := readGrpcWebTrailer(t, rsp.Body); trailer != expectedTrailer {
+			t.Fatalf("Expected trailer [%q] but got [%q]", expectedTrailer, trailer)
+		}
+	})
+
+	t.Run("Returns errors in the trailer", func(t *testing.T) {
+		h := &handler{grpcServer: &mockGrpcServer{
+			ResponseToReturn: &pb.ListPodsResponse{},
+			ErrorToReturn:    status.Error(codes.NotFound, "no such namespace"),
+		}}
+
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, newGrpcWebRequest(t, "ListPods", &pb.ListPodsRequest{}))
+
+		expectedTrailer := "grpc-status: 5\r\ngrpc-message: no%20such%20namespace\r\n"
+		if trailer := readGrpcWebTrailer(t, rsp.Body); trailer != expectedTrailer {
+			t.Fatalf("Expected trailer [%q] but got [%q]", expectedTrailer, trailer)
+		}
+	})
+
+	t.Run("Reports non-grpc errors as unknown", func(t *testing.T) {
+		h := &handler{grpcServer: &mockGrpcServer{
+			ResponseToReturn: &pb.ListPodsResponse{},
+			ErrorToReturn:    errors.New("boom"),
+		}}
+
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, newGrpcWebRequest(t, "ListPods", &pb.ListPodsRequest{}))
+
+		expectedTrailer := "grpc-status: 2\r\ngrpc-message: boom\r\n"
+		if trailer := readGrpcWebTrailer(t, rsp.Body); trailer != expectedTrailer {
+			t.Fatalf("Expected trailer [%q] but got [%q]", expectedTrailer, trailer)
+		}
+	})
+
+	t.Run("Rejects unknown methods", func(t *testing.T) {
+		h := &handler{grpcServer: &mockGrpcServer{}}
+
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, newGrpcWebRequest(t, "Frobnicate", &pb.Empty{}))
+
+		expectedTrailer := "grpc-status: 12\r\ngrpc-message: unknown%20method%20Frobnicate\r\n"
+		if trailer := readGrpcWebTrailer(t, rsp.Body); trailer != expectedTrailer {
+			t.Fatalf("Expected trailer [%q] but got [%q]", expectedTrailer, trailer)
+		}
+	})
+}
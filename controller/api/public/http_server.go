@@ -10,18 +10,26 @@ import (
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/linkerd/linkerd2/pkg/requestid"
 	promApi "github.com/prometheus/client_golang/api"
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/plugin/ochttp"
 	"google.golang.org/grpc/metadata"
 )
 
 var (
-	statSummaryPath   = fullUrlPathFor("StatSummary")
-	versionPath       = fullUrlPathFor("Version")
-	listPodsPath      = fullUrlPathFor("ListPods")
-	tapByResourcePath = fullUrlPathFor("TapByResource")
-	selfCheckPath     = fullUrlPathFor("SelfCheck")
+	statSummaryPath         = fullUrlPathFor("StatSummary")
+	versionPath             = fullUrlPathFor("Version")
+	listPodsPath            = fullUrlPathFor("ListPods")
+	meshCoveragePath        = fullUrlPathFor("MeshCoverage")
+	listNamespacesPath      = fullUrlPathFor("ListNamespaces")
+	latencyDistributionPath = fullUrlPathFor("LatencyDistribution")
+	podStatsPath            = fullUrlPathFor("PodStats")
+	listResourcesPath       = fullUrlPathFor("ListResources")
+	proxySkewPath           = fullUrlPathFor("ProxySkew")
+	tapByResourcePath       = fullUrlPathFor("TapByResource")
+	selfCheckPath           = fullUrlPathFor("SelfCheck")
 )
 
 type handler struct {
@@ -29,9 +37,19 @@ type handler struct {
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	log.WithFields(log.Fields{
+	id := requestid.FromHTTPRequest(req)
+	w.Header().Set(requestid.Header, id)
+	req = req.WithContext(requestid.NewContext(req.Context(), id))
+
+	requestid.Logger(req.Context()).WithFields(log.Fields{
 		"req.Method": req.Method, "req.URL": req.URL, "req.Form": req.Form,
 	}).Debugf("Serving %s %s", req.Method, req.URL.Path)
+
+	if IsGrpcWebRequest(req) {
+		h.handleGrpcWeb(w, req)
+		return
+	}
+
 	// Validate request method
 	if req.Method != http.MethodPost {
 		writeErrorToHttpResponse(w, fmt.Errorf("POST required"))
@@ -46,6 +64,18 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.handleVersion(w, req)
 	case listPodsPath:
 		h.handleListPods(w, req)
+	case meshCoveragePath:
+		h.handleMeshCoverage(w, req)
+	case listNamespacesPath:
+		h.handleListNamespaces(w, req)
+	case latencyDistributionPath:
+		h.handleLatencyDistribution(w, req)
+	case podStatsPath:
+		h.handlePodStats(w, req)
+	case listResourcesPath:
+		h.handleListResources(w, req)
+	case proxySkewPath:
+		h.handleProxySkew(w, req)
 	case tapByResourcePath:
 		h.handleTapByResource(w, req)
 	case selfCheckPath:
@@ -140,6 +170,132 @@ func (h *handler) handleListPods(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func (h *handler) handleMeshCoverage(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.MeshCoverageRequest
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.MeshCoverage(req.Context(), &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleListNamespaces(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.ListNamespacesRequest
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.ListNamespaces(req.Context(), &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleLatencyDistribution(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.LatencyDistributionRequest
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.LatencyDistribution(req.Context(), &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handlePodStats(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.PodStatsRequest
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.PodStats(req.Context(), &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleListResources(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.ListResourcesRequest
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.ListResources(req.Context(), &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleProxySkew(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.ProxySkewRequest
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.ProxySkew(req.Context(), &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
 func (h *handler) handleTapByResource(w http.ResponseWriter, req *http.Request) {
 	flushableWriter, err := newStreamingWriter(w)
 	if err != nil {
@@ -199,19 +355,23 @@ func NewServer(
 	ignoredNamespaces []string,
 ) *http.Server {
 	baseHandler := &handler{
-		grpcServer: newGrpcServer(
-			promv1.NewAPI(prometheusClient),
-			tapClient,
-			k8sAPI,
-			controllerNamespace,
-			ignoredNamespaces,
-		),
+		grpcServer: instrumentedServer{
+			newGrpcServer(
+				promv1.NewAPI(prometheusClient),
+				tapClient,
+				k8sAPI,
+				controllerNamespace,
+				ignoredNamespaces,
+			),
+		},
 	}
 
 	instrumentedHandler := prometheus.WithTelemetry(baseHandler)
 
 	return &http.Server{
-		Addr:    addr,
-		Handler: instrumentedHandler,
+		Addr: addr,
+		// records a span for each request, which becomes the parent of the
+		// spans of the Prometheus queries and Kubernetes lookups it makes
+		Handler: &ochttp.Handler{Handler: instrumentedHandler},
 	}
 }
@@ -34,6 +34,36 @@ func (m *mockGrpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest)
 	return m.ResponseToReturn.(*pb.ListPodsResponse), m.ErrorToReturn
 }
 
+func (m *mockGrpcServer) MeshCoverage(ctx context.Context, req *pb.MeshCoverageRequest) (*pb.MeshCoverageResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.MeshCoverageResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) ListNamespaces(ctx context.Context, req *pb.ListNamespacesRequest) (*pb.ListNamespacesResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.ListNamespacesResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) LatencyDistribution(ctx context.Context, req *pb.LatencyDistributionRequest) (*pb.LatencyDistributionResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.LatencyDistributionResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) PodStats(ctx context.Context, req *pb.PodStatsRequest) (*pb.PodStatsResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.PodStatsResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) ListResources(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.ListResourcesResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) ProxySkew(ctx context.Context, req *pb.ProxySkewRequest) (*pb.ProxySkewResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.ProxySkewResponse), m.ErrorToReturn
+}
+
 func (m *mockGrpcServer) SelfCheck(ctx context.Context, req *healcheckPb.SelfCheckRequest) (*healcheckPb.SelfCheckResponse, error) {
 	m.LastRequestReceived = req
 	return m.ResponseToReturn.(*healcheckPb.SelfCheckResponse), m.ErrorToReturn
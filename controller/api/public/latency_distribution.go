@@ -0,0 +1,117 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/requestid"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	latencyBucketQuery = "sum(increase(response_latency_ms_bucket%s[%s])) by (le)"
+
+	leLabel = model.LabelName("le")
+)
+
+// LatencyDistribution returns the full latency histogram of a resource's
+// requests, rather than a few quantiles, so that e.g. a bimodal distribution
+// can be told apart from a uniformly slow one.
+func (s *grpcServer) LatencyDistribution(ctx context.Context, req *pb.LatencyDistributionRequest) (*pb.LatencyDistributionResponse, error) {
+	requestid.Logger(ctx).Debugf("LatencyDistribution request: %+v", req)
+
+	resource := req.GetResource()
+	if resource == nil {
+		return latencyDistributionError(req, "LatencyDistribution request missing Resource"), nil
+	}
+	if resource.GetType() == k8s.All || req.GetToResource().GetType() == k8s.All {
+		return latencyDistributionError(req, "resource type 'all' is not supported"), nil
+	}
+	if resource.GetType() == k8s.Service {
+		return latencyDistributionError(req, "service only supported as a destination on 'to' queries"), nil
+	}
+
+	var reqLabels model.LabelSet
+	if toResource := req.GetToResource(); toResource != nil {
+		reqLabels = reqLabels.Merge(promDstQueryLabels(toResource))
+		reqLabels = reqLabels.Merge(promQueryLabels(resource))
+		reqLabels = reqLabels.Merge(promDirectionLabels("outbound"))
+	} else {
+		reqLabels = reqLabels.Merge(promQueryLabels(resource))
+		reqLabels = reqLabels.Merge(promDirectionLabels("inbound"))
+	}
+
+	query := fmt.Sprintf(latencyBucketQuery, reqLabels, req.GetTimeWindow())
+	vec, err := s.queryProm(ctx, query)
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	rsp := &pb.LatencyDistributionResponse{
+		Response: &pb.LatencyDistributionResponse_Ok_{
+			Ok: &pb.LatencyDistributionResponse_Ok{
+				Buckets: latencyBuckets(vec),
+			},
+		},
+	}
+
+	requestid.Logger(ctx).Debugf("LatencyDistribution response: %+v", rsp)
+	return rsp, nil
+}
+
+func latencyDistributionError(req *pb.LatencyDistributionRequest, message string) *pb.LatencyDistributionResponse {
+	return &pb.LatencyDistributionResponse{
+		Response: &pb.LatencyDistributionResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetResource(),
+				Error:    message,
+			},
+		},
+	}
+}
+
+// latencyBuckets converts the cumulative counts of a Prometheus histogram,
+// one sample per "le" bound, into the count of each bucket. Counts that
+// decrease across bounds, which can happen when a proxy restarts mid-window,
+// are reported as empty buckets.
+func latencyBuckets(vec model.Vector) []*pb.LatencyBucket {
+	buckets := make([]*pb.LatencyBucket, 0, len(vec))
+	cumulative := make(map[float64]uint64)
+
+	for _, sample := range vec {
+		le, err := strconv.ParseFloat(string(sample.Metric[leLabel]), 64)
+		if err != nil {
+			log.Warnf("Ignoring latency bucket with invalid bound: %s", sample.Metric)
+			continue
+		}
+		if _, ok := cumulative[le]; !ok {
+			buckets = append(buckets, &pb.LatencyBucket{UpperBoundMs: le})
+		}
+		cumulative[le] += extractSampleValue(sample)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].UpperBoundMs < buckets[j].UpperBoundMs
+	})
+
+	previous := uint64(0)
+	for _, bucket := range buckets {
+		count := cumulative[bucket.UpperBoundMs]
+		if count > previous {
+			bucket.Count = count - previous
+			previous = count
+		}
+	}
+
+	// a histogram with no requests has no buckets worth rendering
+	if previous == 0 {
+		return []*pb.LatencyBucket{}
+	}
+	return buckets
+}
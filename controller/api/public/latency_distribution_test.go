@@ -0,0 +1,165 @@
+package public
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+func latencyBucketSample(le string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{leLabel: model.LabelValue(le)},
+		Value:  model.SampleValue(value),
+	}
+}
+
+func TestLatencyDistribution(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI()
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	t.Run("Queries the histogram of the requested resource", func(t *testing.T) {
+		testCases := []struct {
+			req           pb.LatencyDistributionRequest
+			expectedQuery string
+		}{
+			{
+				req: pb.LatencyDistributionRequest{
+					Resource:   &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "web"},
+					TimeWindow: "10m",
+				},
+				expectedQuery: `sum(increase(response_latency_ms_bucket{deployment="web", direction="inbound", namespace="emojivoto"}[10m])) by (le)`,
+			},
+			{
+				req: pb.LatencyDistributionRequest{
+					Resource:   &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "web"},
+					ToResource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Service, Name: "emoji-svc"},
+					TimeWindow: "1m",
+				},
+				expectedQuery: `sum(increase(response_latency_ms_bucket{deployment="web", direction="outbound", dst_namespace="emojivoto", dst_service="emoji-svc", namespace="emojivoto"}[1m])) by (le)`,
+			},
+		}
+
+		for _, tc := range testCases {
+			mockProm := &MockProm{Res: model.Vector{
+				latencyBucketSample("10", 2),
+				latencyBucketSample("+Inf", 3),
+				latencyBucketSample("100", 3),
+			}}
+			fakeGrpcServer := newGrpcServer(mockProm, tap.NewTapClient(nil), k8sAPI, "linkerd", []string{})
+
+			rsp, err := fakeGrpcServer.LatencyDistribution(context.TODO(), &tc.req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(mockProm.QueriesExecuted, []string{tc.expectedQuery}) {
+				t.Fatalf("Expected queries [%s], got %v", tc.expectedQuery, mockProm.QueriesExecuted)
+			}
+
+			expected := &pb.LatencyDistributionResponse{
+				Response: &pb.LatencyDistributionResponse_Ok_{
+					Ok: &pb.LatencyDistributionResponse_Ok{
+						Buckets: []*pb.LatencyBucket{
+							{UpperBoundMs: 10, Count: 2},
+							{UpperBoundMs: 100, Count: 1},
+							{UpperBoundMs: math.Inf(1), Count: 0},
+						},
+					},
+				},
+			}
+			if !proto.Equal(rsp, expected) {
+				t.Fatalf("Expected response %+v, got %+v", expected, rsp)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid requests", func(t *testing.T) {
+		invalidRequests := []pb.LatencyDistributionRequest{
+			{},
+			{Resource: &pb.Resource{Type: pkgK8s.All}},
+			{Resource: &pb.Resource{Type: pkgK8s.Service, Name: "web"}},
+			{Resource: &pb.Resource{Type: pkgK8s.Deployment}, ToResource: &pb.Resource{Type: pkgK8s.All}},
+		}
+
+		for _, req := range invalidRequests {
+			mockProm := &MockProm{Res: model.Vector{}}
+			fakeGrpcServer := newGrpcServer(mockProm, tap.NewTapClient(nil), k8sAPI, "linkerd", []string{})
+
+			rsp, err := fakeGrpcServer.LatencyDistribution(context.TODO(), &req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetError() == nil {
+				t.Fatalf("Expected an error in the response to %+v, got %+v", req, rsp)
+			}
+			if len(mockProm.QueriesExecuted) != 0 {
+				t.Fatalf("Expected no Prometheus queries, got: %v", mockProm.QueriesExecuted)
+			}
+		}
+	})
+}
+
+func TestLatencyBuckets(t *testing.T) {
+	testCases := []struct {
+		description string
+		vec         model.Vector
+		expected    []*pb.LatencyBucket
+	}{
+		{
+			description: "Returns no buckets without requests",
+			vec: model.Vector{
+				latencyBucketSample("10", 0),
+				latencyBucketSample("+Inf", 0),
+			},
+			expected: []*pb.LatencyBucket{},
+		},
+		{
+			description: "Converts cumulative counts to the count of each bucket",
+			vec: model.Vector{
+				latencyBucketSample("+Inf", 100.2),
+				latencyBucketSample("5", 40),
+				latencyBucketSample("1000", 99.8),
+				latencyBucketSample("50", 40),
+			},
+			expected: []*pb.LatencyBucket{
+				{UpperBoundMs: 5, Count: 40},
+				{UpperBoundMs: 50, Count: 0},
+				{UpperBoundMs: 1000, Count: 60},
+				{UpperBoundMs: math.Inf(1), Count: 0},
+			},
+		},
+		{
+			description: "Reports decreasing counts as empty buckets",
+			vec: model.Vector{
+				latencyBucketSample("5", 10),
+				latencyBucketSample("50", 8),
+				latencyBucketSample("+Inf", 12),
+				latencyBucketSample("invalid", 12),
+			},
+			expected: []*pb.LatencyBucket{
+				{UpperBoundMs: 5, Count: 10},
+				{UpperBoundMs: 50, Count: 0},
+				{UpperBoundMs: math.Inf(1), Count: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			buckets := latencyBuckets(tc.vec)
+			if !reflect.DeepEqual(buckets, tc.expected) {
+				t.Fatalf("Expected %+v, got %+v", tc.expected, buckets)
+			}
+		})
+	}
+}
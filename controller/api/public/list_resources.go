@@ -0,0 +1,153 @@
+package public
+
+import (
+	"context"
+	"sort"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/requestid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// selectableFields are the fields that field selectors can match for each
+// resource type, in addition to metadata.name and metadata.namespace. They're
+// a subset of the fields the Kubernetes API supports for the same types.
+var selectableFields = map[string][]string{
+	k8s.Namespace: {"status.phase"},
+	k8s.Pod:       {"spec.nodeName", "spec.serviceAccountName", "status.phase", "status.podIP"},
+	k8s.Service:   {"spec.clusterIP", "spec.type"},
+}
+
+// ListResources returns the Kubernetes resources of a given type that match
+// the request's label and field selectors, with their labels and annotations.
+// They're read from the informer caches, so that clients can query the mesh
+// without a round-trip to the Kubernetes API.
+func (s *grpcServer) ListResources(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	requestid.Logger(ctx).Debugf("ListResources request: %+v", req)
+
+	resource := req.GetSelector().GetResource()
+	if resource == nil {
+		return nil, status.Error(codes.InvalidArgument, "ListResources request missing Selector Resource")
+	}
+
+	labelSelector, err := labels.Parse(req.GetSelector().GetLabelSelector())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid label selector: %s", err)
+	}
+
+	fieldSelector, err := parseFieldSelector(resource.GetType(), req.GetFieldSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := s.getObjects(ctx, resource.GetNamespace(), resource.GetType(), resource.GetName())
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	rsp := &pb.ListResourcesResponse{}
+	for _, object := range objects {
+		metaObj, err := meta.Accessor(object)
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := metaObj.GetNamespace()
+		if resource.GetType() == k8s.Namespace {
+			namespace = metaObj.GetName()
+		}
+		if s.isIgnoredNamespace(namespace) {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(metaObj.GetLabels())) {
+			continue
+		}
+		if !fieldSelector.Matches(objectFields(object)) {
+			continue
+		}
+
+		rsp.Resources = append(rsp.Resources, &pb.ResourceInfo{
+			Resource: &pb.Resource{
+				Namespace: metaObj.GetNamespace(),
+				Type:      resource.GetType(),
+				Name:      metaObj.GetName(),
+			},
+			Labels:      metaObj.GetLabels(),
+			Annotations: metaObj.GetAnnotations(),
+		})
+	}
+
+	sort.Slice(rsp.Resources, func(i, j int) bool {
+		a, b := rsp.Resources[i].Resource, rsp.Resources[j].Resource
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	requestid.Logger(ctx).Debugf("ListResources response: %+v", rsp)
+
+	return rsp, nil
+}
+
+// parseFieldSelector parses a field selector, and checks that the resource
+// type supports each of its fields, since a field that isn't set would
+// silently match nothing.
+func parseFieldSelector(resourceType, selector string) (fields.Selector, error) {
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid field selector: %s", err)
+	}
+
+	for _, requirement := range parsed.Requirements() {
+		if !isSelectableField(resourceType, requirement.Field) {
+			return nil, status.Errorf(codes.InvalidArgument, "field \"%s\" is not supported in field selectors for %s resources", requirement.Field, resourceType)
+		}
+	}
+
+	return parsed, nil
+}
+
+func isSelectableField(resourceType, field string) bool {
+	if field == "metadata.name" || field == "metadata.namespace" {
+		return true
+	}
+	for _, selectable := range selectableFields[resourceType] {
+		if field == selectable {
+			return true
+		}
+	}
+	return false
+}
+
+// objectFields returns the values of the selectable fields of an object.
+func objectFields(object runtime.Object) fields.Set {
+	set := fields.Set{}
+	if metaObj, err := meta.Accessor(object); err == nil {
+		set["metadata.name"] = metaObj.GetName()
+		set["metadata.namespace"] = metaObj.GetNamespace()
+	}
+
+	switch typed := object.(type) {
+	case *apiv1.Namespace:
+		set["status.phase"] = string(typed.Status.Phase)
+	case *apiv1.Pod:
+		set["spec.nodeName"] = typed.Spec.NodeName
+		set["spec.serviceAccountName"] = typed.Spec.ServiceAccountName
+		set["status.phase"] = string(typed.Status.Phase)
+		set["status.podIP"] = typed.Status.PodIP
+	case *apiv1.Service:
+		set["spec.clusterIP"] = typed.Spec.ClusterIP
+		set["spec.type"] = string(typed.Spec.Type)
+	}
+
+	return set
+}
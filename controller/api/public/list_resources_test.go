@@ -0,0 +1,152 @@
+package public
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListResources(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+  annotations:
+    linkerd.io/inject: enabled
+`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: kube-system
+`, `
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: web
+  namespace: emojivoto
+  labels:
+    app: web
+`, `
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: voting
+  namespace: emojivoto
+  labels:
+    app: voting
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: emojivoto
+  labels:
+    app: web
+  annotations:
+    linkerd.io/proxy-version: edge-18.10.1
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-2
+  namespace: emojivoto
+  labels:
+    app: web
+status:
+  phase: Pending
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-dns
+  namespace: kube-system
+status:
+  phase: Running
+`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	fakeGrpcServer := newGrpcServer(&MockProm{}, tap.NewTapClient(nil), k8sAPI, "linkerd", []string{"kube-system"})
+
+	newRequest := func(resourceType, namespace, labelSelector, fieldSelector string) *pb.ListResourcesRequest {
+		return &pb.ListResourcesRequest{
+			Selector: &pb.ResourceSelection{
+				Resource:      &pb.Resource{Namespace: namespace, Type: resourceType},
+				LabelSelector: labelSelector,
+			},
+			FieldSelector: fieldSelector,
+		}
+	}
+
+	expectResources := func(t *testing.T, req *pb.ListResourcesRequest, expected []*pb.ResourceInfo) {
+		rsp, err := fakeGrpcServer.ListResources(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expectedRsp := &pb.ListResourcesResponse{Resources: expected}
+		if !proto.Equal(expectedRsp, rsp) {
+			t.Fatalf("Expected: %+v, Got: %+v", expectedRsp, rsp)
+		}
+	}
+
+	t.Run("Lists the resources of a type outside of ignored namespaces", func(t *testing.T) {
+		expectResources(t, newRequest(pkgK8s.Namespace, "", "", ""), []*pb.ResourceInfo{
+			&pb.ResourceInfo{
+				Resource:    &pb.Resource{Type: pkgK8s.Namespace, Name: "emojivoto"},
+				Annotations: map[string]string{"linkerd.io/inject": "enabled"},
+			},
+		})
+	})
+
+	t.Run("Filters the resources with a label selector", func(t *testing.T) {
+		expectResources(t, newRequest(pkgK8s.Deployment, "emojivoto", "app=web", ""), []*pb.ResourceInfo{
+			&pb.ResourceInfo{
+				Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "web"},
+				Labels:   map[string]string{"app": "web"},
+			},
+		})
+	})
+
+	t.Run("Filters the resources with a field selector", func(t *testing.T) {
+		expectResources(t, newRequest(pkgK8s.Pod, "", "app=web", "status.phase=Running"), []*pb.ResourceInfo{
+			&pb.ResourceInfo{
+				Resource:    &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Pod, Name: "web-1"},
+				Labels:      map[string]string{"app": "web"},
+				Annotations: map[string]string{"linkerd.io/proxy-version": "edge-18.10.1"},
+			},
+		})
+	})
+
+	t.Run("Rejects invalid requests", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			req  *pb.ListResourcesRequest
+			code codes.Code
+		}{
+			{"missing resource", &pb.ListResourcesRequest{}, codes.InvalidArgument},
+			{"invalid label selector", newRequest(pkgK8s.Pod, "", "app in web", ""), codes.InvalidArgument},
+			{"invalid field selector", newRequest(pkgK8s.Pod, "", "", "status.phase"), codes.InvalidArgument},
+			{"unsupported field", newRequest(pkgK8s.Deployment, "", "", "status.phase=Running"), codes.InvalidArgument},
+			{"unsupported type", newRequest(pkgK8s.Authority, "", "", ""), codes.Unimplemented},
+		}
+
+		for _, tc := range testCases {
+			_, err := fakeGrpcServer.ListResources(context.TODO(), tc.req)
+			if status.Code(err) != tc.code {
+				t.Fatalf("Expected a %s error for the %s request, got: %v", tc.code, tc.name, err)
+			}
+		}
+	})
+}
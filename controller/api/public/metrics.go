@@ -0,0 +1,199 @@
+package public
+
+import (
+	"context"
+	"time"
+
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgPrometheus "github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Results of Prometheus queries, as reported by the
+// public_api_prometheus_query_duration_seconds histogram.
+const (
+	promQuerySuccess     = "success"
+	promQueryUnavailable = "unavailable"
+	promQueryCanceled    = "canceled"
+	promQueryError       = "error"
+)
+
+var (
+	apiRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "public_api_requests_total",
+			Help: "A counter for public API requests, by RPC method and gRPC status code.",
+		},
+		[]string{"method", "code"},
+	)
+
+	apiRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "public_api_request_duration_seconds",
+			Help:    "A histogram of latencies for unary public API requests in seconds, by RPC method and gRPC status code.",
+			Buckets: pkgPrometheus.RequestDurationBucketsSeconds,
+		},
+		[]string{"method", "code"},
+	)
+
+	promQueryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "public_api_prometheus_query_duration_seconds",
+			Help:    "A histogram of the durations of the Prometheus queries run by the public API in seconds, by result.",
+			Buckets: pkgPrometheus.RequestDurationBucketsSeconds,
+		},
+		[]string{"result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(apiRequests, apiRequestDuration, promQueryDuration)
+}
+
+// instrumentedServer records the count, latency and status of the calls to
+// the public API methods it wraps. Latencies are only recorded for unary
+// calls, since the duration of a tap stream is up to its client.
+type instrumentedServer struct {
+	pb.ApiServer
+}
+
+func (s instrumentedServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.StatSummary(ctx, req)
+
+	code := rpcCode(err)
+	if err == nil && rsp.GetError() != nil {
+		// invalid requests are reported in the response rather than as errors
+		code = codes.InvalidArgument.String()
+	}
+	observeRequest("StatSummary", code, start)
+
+	return rsp, err
+}
+
+func (s instrumentedServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb.ListPodsResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.ListPods(ctx, req)
+	observeRequest("ListPods", rpcCode(err), start)
+	return rsp, err
+}
+
+func (s instrumentedServer) MeshCoverage(ctx context.Context, req *pb.MeshCoverageRequest) (*pb.MeshCoverageResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.MeshCoverage(ctx, req)
+	observeRequest("MeshCoverage", rpcCode(err), start)
+	return rsp, err
+}
+
+func (s instrumentedServer) ListNamespaces(ctx context.Context, req *pb.ListNamespacesRequest) (*pb.ListNamespacesResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.ListNamespaces(ctx, req)
+	observeRequest("ListNamespaces", rpcCode(err), start)
+	return rsp, err
+}
+
+func (s instrumentedServer) LatencyDistribution(ctx context.Context, req *pb.LatencyDistributionRequest) (*pb.LatencyDistributionResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.LatencyDistribution(ctx, req)
+
+	code := rpcCode(err)
+	if err == nil && rsp.GetError() != nil {
+		// invalid requests are reported in the response rather than as errors
+		code = codes.InvalidArgument.String()
+	}
+	observeRequest("LatencyDistribution", code, start)
+
+	return rsp, err
+}
+
+func (s instrumentedServer) PodStats(ctx context.Context, req *pb.PodStatsRequest) (*pb.PodStatsResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.PodStats(ctx, req)
+
+	code := rpcCode(err)
+	if err == nil && rsp.GetError() != nil {
+		// invalid requests are reported in the response rather than as errors
+		code = codes.InvalidArgument.String()
+	}
+	observeRequest("PodStats", code, start)
+
+	return rsp, err
+}
+
+func (s instrumentedServer) ListResources(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.ListResources(ctx, req)
+	observeRequest("ListResources", rpcCode(err), start)
+	return rsp, err
+}
+
+func (s instrumentedServer) ProxySkew(ctx context.Context, req *pb.ProxySkewRequest) (*pb.ProxySkewResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.ProxySkew(ctx, req)
+	observeRequest("ProxySkew", rpcCode(err), start)
+	return rsp, err
+}
+
+func (s instrumentedServer) Version(ctx context.Context, req *pb.Empty) (*pb.VersionInfo, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.Version(ctx, req)
+	observeRequest("Version", rpcCode(err), start)
+	return rsp, err
+}
+
+func (s instrumentedServer) SelfCheck(ctx context.Context, req *healthcheckPb.SelfCheckRequest) (*healthcheckPb.SelfCheckResponse, error) {
+	start := time.Now()
+	rsp, err := s.ApiServer.SelfCheck(ctx, req)
+	observeRequest("SelfCheck", rpcCode(err), start)
+	return rsp, err
+}
+
+func (s instrumentedServer) Tap(req *pb.TapRequest, stream pb.Api_TapServer) error {
+	err := s.ApiServer.Tap(req, stream)
+	apiRequests.WithLabelValues("Tap", rpcCode(err)).Inc()
+	return err
+}
+
+func (s instrumentedServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_TapByResourceServer) error {
+	err := s.ApiServer.TapByResource(req, stream)
+	apiRequests.WithLabelValues("TapByResource", rpcCode(err)).Inc()
+	return err
+}
+
+func observeRequest(method, code string, start time.Time) {
+	apiRequests.WithLabelValues(method, code).Inc()
+	apiRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// rpcCode classifies an error returned by a public API method by its gRPC
+// status code. Errors that don't carry a status are Unknown.
+func rpcCode(err error) string {
+	if err == context.Canceled {
+		return codes.Canceled.String()
+	}
+	if err == context.DeadlineExceeded {
+		return codes.DeadlineExceeded.String()
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s.Code().String()
+	}
+	return codes.Unknown.String()
+}
+
+// promQueryResult classifies the outcome of a Prometheus query.
+func promQueryResult(ctx context.Context, err error) string {
+	switch {
+	case err == nil:
+		return promQuerySuccess
+	case err == errPrometheusUnavailable:
+		return promQueryUnavailable
+	case ctx.Err() == context.Canceled:
+		return promQueryCanceled
+	default:
+		return promQueryError
+	}
+}
@@ -0,0 +1,54 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRpcCode(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{nil, "OK"},
+		{status.Error(codes.NotFound, "not found"), "NotFound"},
+		{errPrometheusUnavailable, "Unavailable"},
+		{context.Canceled, "Canceled"},
+		{context.DeadlineExceeded, "DeadlineExceeded"},
+		{errors.New("boom"), "Unknown"},
+	}
+
+	for _, tc := range testCases {
+		code := rpcCode(tc.err)
+		if code != tc.expected {
+			t.Fatalf("Expected code [%s] for error [%v], got [%s]", tc.expected, tc.err, code)
+		}
+	}
+}
+
+func TestPromQueryResult(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		ctx      context.Context
+		err      error
+		expected string
+	}{
+		{context.Background(), nil, promQuerySuccess},
+		{context.Background(), errPrometheusUnavailable, promQueryUnavailable},
+		{canceledCtx, context.Canceled, promQueryCanceled},
+		{context.Background(), errors.New("bad_data"), promQueryError},
+	}
+
+	for _, tc := range testCases {
+		result := promQueryResult(tc.ctx, tc.err)
+		if result != tc.expected {
+			t.Fatalf("Expected result [%s] for error [%v], got [%s]", tc.expected, tc.err, result)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/requestid"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	tcpOpenConnectionsQuery = "sum(tcp_open_connections%s)"
+	tcpReadBytesQuery       = "sum(increase(tcp_read_bytes_total%s[%s]))"
+	tcpWriteBytesQuery      = "sum(increase(tcp_write_bytes_total%s[%s]))"
+	proxyStartTimeQuery     = "max(process_start_time_seconds%s)"
+
+	promTCPOpenConnections = promType("QUERY_TCP_OPEN_CONNECTIONS")
+	promTCPReadBytes       = promType("QUERY_TCP_READ_BYTES")
+	promTCPWriteBytes      = promType("QUERY_TCP_WRITE_BYTES")
+	promProxyStartTime     = promType("QUERY_PROXY_START_TIME")
+
+	podLabel  = model.LabelName("pod")
+	peerLabel = model.LabelName("peer")
+)
+
+// PodStats returns the stats of a single pod. Unlike a StatSummary request
+// for the pod, it doesn't list the pods of the namespace to aggregate them by
+// owner, and it also returns the pod's TCP stats and its proxy's uptime.
+func (s *grpcServer) PodStats(ctx context.Context, req *pb.PodStatsRequest) (*pb.PodStatsResponse, error) {
+	requestid.Logger(ctx).Debugf("PodStats request: %+v", req)
+
+	if req.GetNamespace() == "" || req.GetName() == "" {
+		return podStatsError(req, "PodStats request missing pod namespace or name"), nil
+	}
+
+	pod, err := s.k8sAPI.Pod().Lister().Pods(req.GetNamespace()).Get(req.GetName())
+	if kerrors.IsNotFound(err) {
+		return podStatsError(req, fmt.Sprintf("pod %s/%s not found", req.GetNamespace(), req.GetName())), nil
+	}
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	status := string(pod.Status.Phase)
+	if pod.DeletionTimestamp != nil {
+		status = "Terminating"
+	}
+
+	stats := &pb.PodStats{
+		Resource: &pb.Resource{
+			Namespace: pod.Namespace,
+			Type:      k8s.Pod,
+			Name:      pod.Name,
+		},
+		TimeWindow: req.GetTimeWindow(),
+		Status:     status,
+		Meshed:     k8s.IsMeshed(pod, s.controllerNamespace),
+	}
+	if config := proxyConfigForPod(pod); config != nil {
+		stats.ProxyVersion = config.Version
+	}
+
+	err = s.addPodMetrics(ctx, stats, pod)
+	if err == errPrometheusUnavailable {
+		// still return the pod's status, without stats
+		log.Warnf("Returning pod without stats: %s", err)
+	} else if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	rsp := &pb.PodStatsResponse{
+		Response: &pb.PodStatsResponse_Ok{Ok: stats},
+	}
+
+	requestid.Logger(ctx).Debugf("PodStats response: %+v", rsp)
+	return rsp, nil
+}
+
+func podStatsError(req *pb.PodStatsRequest, message string) *pb.PodStatsResponse {
+	return &pb.PodStatsResponse{
+		Response: &pb.PodStatsResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: &pb.Resource{
+					Namespace: req.GetNamespace(),
+					Type:      k8s.Pod,
+					Name:      req.GetName(),
+				},
+				Error: message,
+			},
+		},
+	}
+}
+
+// addPodMetrics queries the inbound request and TCP stats of the pod's proxy,
+// and the proxy's start time, and adds them to stats.
+func (s *grpcServer) addPodMetrics(ctx context.Context, stats *pb.PodStats, pod *apiv1.Pod) error {
+	podLabels := model.LabelSet{
+		namespaceLabel: model.LabelValue(pod.Namespace),
+		podLabel:       model.LabelValue(pod.Name),
+	}
+	reqLabels := podLabels.Merge(promDirectionLabels("inbound"))
+	groupBy := promGroupByLabelNames(stats.Resource)
+
+	basicStats, err := s.queryPrometheusMetrics(ctx, k8s.Pod, reqLabels, groupBy, stats.TimeWindow)
+	if err != nil {
+		return err
+	}
+	stats.Stats = basicStats[rKey{Namespace: pod.Namespace, Type: k8s.Pod, Name: pod.Name}]
+
+	// connections are counted on the server side of the inbound proxy, so that
+	// they're not counted twice
+	tcpLabels := reqLabels.Merge(model.LabelSet{peerLabel: "src"})
+	queries := map[promType]string{
+		promTCPOpenConnections: fmt.Sprintf(tcpOpenConnectionsQuery, tcpLabels),
+		promTCPReadBytes:       fmt.Sprintf(tcpReadBytesQuery, tcpLabels, stats.TimeWindow),
+		promTCPWriteBytes:      fmt.Sprintf(tcpWriteBytesQuery, tcpLabels, stats.TimeWindow),
+		promProxyStartTime:     fmt.Sprintf(proxyStartTimeQuery, podLabels),
+	}
+
+	resultChan := make(chan promResult)
+	for prom, query := range queries {
+		go func(prom promType, query string) {
+			vec, err := s.queryProm(ctx, query)
+			resultChan <- promResult{prom: prom, vec: vec, err: err}
+		}(prom, query)
+	}
+
+	stats.TcpStats = &pb.TcpStats{}
+	for i := 0; i < len(queries); i++ {
+		result := <-resultChan
+		if result.err != nil {
+			err = result.err
+			continue
+		}
+		if len(result.vec) == 0 {
+			continue
+		}
+
+		sample := result.vec[0]
+		switch result.prom {
+		case promTCPOpenConnections:
+			stats.TcpStats.OpenConnections = extractSampleValue(sample)
+		case promTCPReadBytes:
+			stats.TcpStats.ReadBytesTotal = extractSampleValue(sample)
+		case promTCPWriteBytes:
+			stats.TcpStats.WriteBytesTotal = extractSampleValue(sample)
+		case promProxyStartTime:
+			uptime := time.Since(time.Unix(0, int64(sample.Value)*int64(time.Second)))
+			stats.ProxyUptime = &duration.Duration{
+				Seconds: int64(uptime / time.Second),
+				Nanos:   int32(uptime % time.Second),
+			}
+		}
+	}
+
+	return err
+}
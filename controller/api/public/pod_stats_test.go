@@ -0,0 +1,123 @@
+package public
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+func TestPodStats(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  containers:
+  - name: linkerd-proxy
+    image: gcr.io/linkerd-io/proxy:edge-18.10.1
+status:
+  phase: Running
+`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	t.Run("Returns the stats of a single pod", func(t *testing.T) {
+		mockProm := &MockProm{Res: prometheusMetric("emojivoto-meshed", pkgK8s.Pod, "emojivoto", "success", false)}
+		fakeGrpcServer := newGrpcServer(mockProm, tap.NewTapClient(nil), k8sAPI, "linkerd", []string{})
+
+		rsp, err := fakeGrpcServer.PodStats(context.TODO(), &pb.PodStatsRequest{
+			Namespace:  "emojivoto",
+			Name:       "emojivoto-meshed",
+			TimeWindow: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expectedQueries := []string{
+			`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod="emojivoto-meshed"}[1m])) by (le, namespace, pod))`,
+			`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod="emojivoto-meshed"}[1m])) by (le, namespace, pod))`,
+			`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod="emojivoto-meshed"}[1m])) by (le, namespace, pod))`,
+			`max(process_start_time_seconds{namespace="emojivoto", pod="emojivoto-meshed"})`,
+			`sum(increase(response_total{direction="inbound", namespace="emojivoto", pod="emojivoto-meshed"}[1m])) by (namespace, pod, classification, tls)`,
+			`sum(increase(tcp_read_bytes_total{direction="inbound", namespace="emojivoto", peer="src", pod="emojivoto-meshed"}[1m]))`,
+			`sum(increase(tcp_write_bytes_total{direction="inbound", namespace="emojivoto", peer="src", pod="emojivoto-meshed"}[1m]))`,
+			`sum(tcp_open_connections{direction="inbound", namespace="emojivoto", peer="src", pod="emojivoto-meshed"})`,
+		}
+		sort.Strings(mockProm.QueriesExecuted)
+		if len(mockProm.QueriesExecuted) != len(expectedQueries) {
+			t.Fatalf("Expected queries %v, got %v", expectedQueries, mockProm.QueriesExecuted)
+		}
+		for i, query := range mockProm.QueriesExecuted {
+			if query != expectedQueries[i] {
+				t.Fatalf("Expected query [%s], got [%s]", expectedQueries[i], query)
+			}
+		}
+
+		stats := rsp.GetOk()
+		if stats == nil {
+			t.Fatalf("Unexpected error in response: %+v", rsp.GetError())
+		}
+		if stats.GetProxyUptime().GetSeconds() <= 0 {
+			t.Fatalf("Expected a proxy uptime, got %+v", stats.GetProxyUptime())
+		}
+
+		stats.ProxyUptime = nil
+		expected := &pb.PodStats{
+			Resource:   &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Pod, Name: "emojivoto-meshed"},
+			TimeWindow: "1m",
+			Status:     "Running",
+			Meshed:     true,
+			Stats: &pb.BasicStats{
+				SuccessCount:    123,
+				LatencyMsP50:    123,
+				LatencyMsP95:    123,
+				LatencyMsP99:    123,
+				TlsRequestCount: 123,
+			},
+			TcpStats: &pb.TcpStats{
+				OpenConnections: 123,
+				ReadBytesTotal:  123,
+				WriteBytesTotal: 123,
+			},
+			ProxyVersion: "edge-18.10.1",
+		}
+		if !proto.Equal(stats, expected) {
+			t.Fatalf("Expected %+v, got %+v", expected, stats)
+		}
+	})
+
+	t.Run("Reports pods that don't exist in the response", func(t *testing.T) {
+		mockProm := &MockProm{Res: model.Vector{}}
+		fakeGrpcServer := newGrpcServer(mockProm, tap.NewTapClient(nil), k8sAPI, "linkerd", []string{})
+
+		for _, req := range []*pb.PodStatsRequest{
+			{Namespace: "emojivoto"},
+			{Namespace: "emojivoto", Name: "emojivoto-missing"},
+		} {
+			rsp, err := fakeGrpcServer.PodStats(context.TODO(), req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetError() == nil {
+				t.Fatalf("Expected an error in the response to %+v, got %+v", req, rsp)
+			}
+			if len(mockProm.QueriesExecuted) != 0 {
+				t.Fatalf("Expected no Prometheus queries, got: %v", mockProm.QueriesExecuted)
+			}
+		}
+	})
+}
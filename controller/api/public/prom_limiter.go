@@ -0,0 +1,113 @@
+package public
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxConcurrentPromQueries is the number of Prometheus queries the public
+	// API runs at once; queries beyond that wait for a slot.
+	maxConcurrentPromQueries = 32
+
+	// promQueryTimeout is the budget of a single Prometheus query, including
+	// the time spent waiting for a slot.
+	promQueryTimeout = 5 * time.Second
+
+	// promFailureThreshold is the number of consecutive failed queries after
+	// which the public API stops querying Prometheus for promCooldown.
+	promFailureThreshold = 5
+	promCooldown         = 30 * time.Second
+)
+
+// errPrometheusUnavailable is returned instead of querying Prometheus when it
+// is too slow or failing, so that callers can degrade their responses.
+var errPrometheusUnavailable = status.Error(codes.Unavailable, "Prometheus is unavailable or overloaded")
+
+// promQueryLimiter bounds the concurrency and duration of Prometheus queries,
+// and acts as a circuit breaker: after failureThreshold consecutive failures,
+// queries fail fast for the cooldown period instead of piling up goroutines
+// waiting on a slow Prometheus. Once the cooldown expires, queries are let
+// through again; the failure count is only reset by a successful query, so
+// any failure before then opens the circuit for another cooldown.
+type promQueryLimiter struct {
+	slots            chan struct{}
+	timeout          time.Duration
+	failureThreshold int
+	cooldown         time.Duration
+	now              func() time.Time
+
+	sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func newPromQueryLimiter(maxConcurrent int, timeout time.Duration, failureThreshold int, cooldown time.Duration) *promQueryLimiter {
+	return &promQueryLimiter{
+		slots:            make(chan struct{}, maxConcurrent),
+		timeout:          timeout,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+	}
+}
+
+// do runs query with a context bounded by the limiter's budget, once a slot
+// is available. It returns errPrometheusUnavailable if the circuit is open or
+// the budget runs out.
+func (l *promQueryLimiter) do(ctx context.Context, query func(context.Context) error) error {
+	if l.isOpen() {
+		return errPrometheusUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	select {
+	case l.slots <- struct{}{}:
+		defer func() { <-l.slots }()
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return errPrometheusUnavailable
+		}
+		return ctx.Err()
+	}
+
+	err := query(ctx)
+	l.record(ctx, err)
+
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return errPrometheusUnavailable
+	}
+	return err
+}
+
+func (l *promQueryLimiter) isOpen() bool {
+	l.Lock()
+	defer l.Unlock()
+	return l.now().Before(l.openUntil)
+}
+
+func (l *promQueryLimiter) record(ctx context.Context, err error) {
+	l.Lock()
+	defer l.Unlock()
+
+	if err == nil {
+		l.failures = 0
+		return
+	}
+
+	// the caller going away doesn't say anything about Prometheus
+	if ctx.Err() == context.Canceled {
+		return
+	}
+
+	l.failures++
+	if l.failures >= l.failureThreshold {
+		l.openUntil = l.now().Add(l.cooldown)
+	}
+}
@@ -0,0 +1,98 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPromQueryLimiter(t *testing.T) {
+	errQuery := errors.New("query failed")
+	failing := func(ctx context.Context) error { return errQuery }
+	succeeding := func(ctx context.Context) error { return nil }
+
+	t.Run("Opens the circuit after consecutive failures", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		limiter := newPromQueryLimiter(1, time.Second, 2, time.Minute)
+		limiter.now = func() time.Time { return now }
+
+		for i := 0; i < 2; i++ {
+			if err := limiter.do(context.Background(), failing); err != errQuery {
+				t.Fatalf("Expected error [%s] but got [%s]", errQuery, err)
+			}
+		}
+
+		called := false
+		err := limiter.do(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		if err != errPrometheusUnavailable {
+			t.Fatalf("Expected error [%s] but got [%s]", errPrometheusUnavailable, err)
+		}
+		if called {
+			t.Fatal("Expected query not to run while the circuit is open")
+		}
+
+		now = now.Add(time.Minute)
+		if err := limiter.do(context.Background(), succeeding); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Resets the failure count after a success", func(t *testing.T) {
+		limiter := newPromQueryLimiter(1, time.Second, 2, time.Minute)
+
+		limiter.do(context.Background(), failing)
+		limiter.do(context.Background(), succeeding)
+		limiter.do(context.Background(), failing)
+
+		if limiter.isOpen() {
+			t.Fatal("Expected circuit to be closed")
+		}
+	})
+
+	t.Run("Returns an error when the budget runs out", func(t *testing.T) {
+		limiter := newPromQueryLimiter(1, 10*time.Millisecond, 5, time.Minute)
+
+		err := limiter.do(context.Background(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		if err != errPrometheusUnavailable {
+			t.Fatalf("Expected error [%s] but got [%s]", errPrometheusUnavailable, err)
+		}
+	})
+
+	t.Run("Bounds the number of concurrent queries", func(t *testing.T) {
+		limiter := newPromQueryLimiter(1, 10*time.Millisecond, 5, time.Minute)
+		limiter.slots <- struct{}{}
+
+		called := false
+		err := limiter.do(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		if err != errPrometheusUnavailable {
+			t.Fatalf("Expected error [%s] but got [%s]", errPrometheusUnavailable, err)
+		}
+		if called {
+			t.Fatal("Expected query not to run without a free slot")
+		}
+	})
+
+	t.Run("Doesn't count canceled requests as failures", func(t *testing.T) {
+		limiter := newPromQueryLimiter(1, time.Second, 1, time.Minute)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		limiter.do(ctx, func(ctx context.Context) error {
+			cancel()
+			return ctx.Err()
+		})
+
+		if limiter.isOpen() {
+			t.Fatal("Expected circuit to be closed")
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package public
+
+import (
+	"strings"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	k8sV1 "k8s.io/api/core/v1"
+)
+
+const (
+	proxyLogEnvVar         = "LINKERD2_PROXY_LOG"
+	proxyOpaquePortsEnvVar = "LINKERD2_PROXY_INBOUND_PORTS_DISABLE_PROTOCOL_DETECTION"
+	proxyTLSCertEnvVar     = "LINKERD2_PROXY_TLS_CERT"
+
+	injectModeUnknown       = "unknown"
+	injectModeInitContainer = "init-container"
+)
+
+// proxyConfigForPod returns the configuration of the pod's proxy, read from
+// the proxy and init containers that were injected into its spec, so that it
+// reflects the injector's defaults as well as the values overridden by the
+// workload's annotations. It returns nil if the pod doesn't have a proxy.
+func proxyConfigForPod(pod *k8sV1.Pod) *pb.ProxyConfig {
+	var proxy *k8sV1.Container
+	for i, container := range pod.Spec.Containers {
+		if container.Name == pkgK8s.ProxyContainerName {
+			proxy = &pod.Spec.Containers[i]
+		}
+	}
+	if proxy == nil {
+		return nil
+	}
+
+	config := &pb.ProxyConfig{
+		InjectMode:    injectModeUnknown,
+		CpuRequest:    quantityString(proxy.Resources.Requests, k8sV1.ResourceCPU),
+		CpuLimit:      quantityString(proxy.Resources.Limits, k8sV1.ResourceCPU),
+		MemoryRequest: quantityString(proxy.Resources.Requests, k8sV1.ResourceMemory),
+		MemoryLimit:   quantityString(proxy.Resources.Limits, k8sV1.ResourceMemory),
+		Overrides:     map[string]string{},
+	}
+
+	if parts := strings.Split(proxy.Image, ":"); len(parts) > 1 {
+		config.Version = parts[len(parts)-1]
+	}
+
+	for _, env := range proxy.Env {
+		switch env.Name {
+		case proxyLogEnvVar:
+			config.LogLevel = env.Value
+		case proxyOpaquePortsEnvVar:
+			config.OpaquePorts = env.Value
+		case proxyTLSCertEnvVar:
+			config.Tls = true
+		}
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name != pkgK8s.InitContainerName {
+			continue
+		}
+		config.InjectMode = injectModeInitContainer
+
+		for i := 0; i < len(container.Args)-1; i++ {
+			switch container.Args[i] {
+			case "--inbound-ports-to-ignore":
+				config.InboundSkipPorts = container.Args[i+1]
+			case "--outbound-ports-to-ignore":
+				config.OutboundSkipPorts = container.Args[i+1]
+			case "--outbound-subnets-to-ignore":
+				config.OutboundSkipSubnets = container.Args[i+1]
+			}
+		}
+	}
+
+	for _, annotation := range pkgK8s.ProxyConfigAnnotations {
+		if value, ok := pod.Annotations[annotation]; ok {
+			config.Overrides[annotation] = value
+		}
+	}
+
+	return config
+}
+
+func quantityString(resources k8sV1.ResourceList, name k8sV1.ResourceName) string {
+	quantity, ok := resources[name]
+	if !ok {
+		return ""
+	}
+	return quantity.String()
+}
@@ -0,0 +1,138 @@
+package public
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestProxyConfigForPod(t *testing.T) {
+	testCases := []struct {
+		description string
+		pod         string
+		expected    *pb.ProxyConfig
+	}{
+		{
+			description: "Returns nil for pods without a proxy",
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-not-meshed
+  namespace: emojivoto
+spec:
+  containers:
+  - name: emoji-svc
+    image: buoyantio/emojivoto-emoji-svc:v5
+`,
+			expected: nil,
+		},
+		{
+			description: "Reads the configuration of pods injected with an init container",
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  annotations:
+    linkerd.io/opaque-ports: "3306"
+    linkerd.io/created-by: linkerd/cli edge-18.10.1
+spec:
+  containers:
+  - name: emoji-svc
+    image: buoyantio/emojivoto-emoji-svc:v5
+  - name: linkerd-proxy
+    image: gcr.io/linkerd-io/proxy:edge-18.10.1
+    env:
+    - name: LINKERD2_PROXY_LOG
+      value: warn,linkerd2_proxy=info
+    - name: LINKERD2_PROXY_INBOUND_PORTS_DISABLE_PROTOCOL_DETECTION
+      value: "3306"
+    - name: LINKERD2_PROXY_TLS_CERT
+      value: /var/linkerd-io/identity/certificate.crt
+    resources:
+      requests:
+        cpu: 10m
+        memory: 32Mi
+      limits:
+        memory: 64Mi
+  initContainers:
+  - name: linkerd-init
+    image: gcr.io/linkerd-io/proxy-init:edge-18.10.1
+    args:
+    - --incoming-proxy-port
+    - "4143"
+    - --outgoing-proxy-port
+    - "4140"
+    - --proxy-uid
+    - "2102"
+    - --inbound-ports-to-ignore
+    - 4190,4191
+    - --outbound-subnets-to-ignore
+    - 10.0.0.0/8
+`,
+			expected: &pb.ProxyConfig{
+				Version:             "edge-18.10.1",
+				LogLevel:            "warn,linkerd2_proxy=info",
+				InjectMode:          "init-container",
+				InboundSkipPorts:    "4190,4191",
+				OutboundSkipSubnets: "10.0.0.0/8",
+				OpaquePorts:         "3306",
+				Tls:                 true,
+				CpuRequest:          "10m",
+				MemoryRequest:       "32Mi",
+				MemoryLimit:         "64Mi",
+				Overrides:           map[string]string{"linkerd.io/opaque-ports": "3306"},
+			},
+		},
+		{
+			description: "Reports an unknown inject mode for pods without an init container",
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+spec:
+  containers:
+  - name: linkerd-proxy
+    image: gcr.io/linkerd-io/proxy:edge-18.10.1
+    env:
+    - name: LINKERD2_PROXY_LOG
+      value: debug
+`,
+			expected: &pb.ProxyConfig{
+				Version:    "edge-18.10.1",
+				LogLevel:   "debug",
+				InjectMode: "unknown",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			k8sAPI, err := k8s.NewFakeAPI(tc.pod)
+			if err != nil {
+				t.Fatalf("NewFakeAPI returned an error: %s", err)
+			}
+			k8sAPI.Sync(nil)
+
+			pods, err := k8sAPI.Pod().Lister().List(labels.Everything())
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if len(pods) != 1 {
+				t.Fatalf("Expected 1 pod, got %d", len(pods))
+			}
+
+			config := proxyConfigForPod(pods[0])
+			if !proto.Equal(config, tc.expected) {
+				t.Fatalf("Expected %+v, got %+v", tc.expected, config)
+			}
+		})
+	}
+}
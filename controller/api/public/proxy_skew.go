@@ -0,0 +1,207 @@
+package public
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/requestid"
+	"github.com/linkerd/linkerd2/pkg/version"
+	k8sV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// maxSkewExamples is the number of workloads listed for every group of
+	// proxies in a ProxySkew response.
+	maxSkewExamples = 3
+
+	// trustAnchorsFingerprintLength is the number of bytes of the trust
+	// anchors' SHA-256 digest used to tell their generations apart.
+	trustAnchorsFingerprintLength = 8
+)
+
+// workload identifies the owner of a pod.
+type workload struct {
+	namespace, kind, name string
+}
+
+// skewGroups accumulates the meshed pods sharing each value of a proxy
+// property, along with the workloads running them.
+type skewGroups struct {
+	pods      map[string]uint64
+	workloads map[string]map[workload]bool
+}
+
+func newSkewGroups() *skewGroups {
+	return &skewGroups{
+		pods:      make(map[string]uint64),
+		workloads: make(map[string]map[workload]bool),
+	}
+}
+
+func (g *skewGroups) add(value string, owner workload) {
+	g.pods[value]++
+	if g.workloads[value] == nil {
+		g.workloads[value] = make(map[workload]bool)
+	}
+	g.workloads[value][owner] = true
+}
+
+// list returns the groups, the most common value first, each with the first
+// few of its workloads in alphabetical order.
+func (g *skewGroups) list() []*pb.ProxySkewGroup {
+	groups := make([]*pb.ProxySkewGroup, 0)
+	for value, count := range g.pods {
+		workloads := make([]workload, 0)
+		for owner := range g.workloads[value] {
+			workloads = append(workloads, owner)
+		}
+		sort.Slice(workloads, func(i, j int) bool {
+			a, b := workloads[i], workloads[j]
+			if a.namespace != b.namespace {
+				return a.namespace < b.namespace
+			}
+			if a.kind != b.kind {
+				return a.kind < b.kind
+			}
+			return a.name < b.name
+		})
+		if len(workloads) > maxSkewExamples {
+			workloads = workloads[:maxSkewExamples]
+		}
+
+		group := &pb.ProxySkewGroup{Value: value, PodCount: count}
+		for _, owner := range workloads {
+			group.Examples = append(group.Examples, &pb.Resource{
+				Namespace: owner.namespace,
+				Type:      owner.kind,
+				Name:      owner.name,
+			})
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].PodCount != groups[j].PodCount {
+			return groups[i].PodCount > groups[j].PodCount
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
+
+// ProxySkew groups the meshed pods by proxy version, by the hash of the
+// proxy configuration they were injected with, and by the generation of the
+// trust anchors distributed to their namespace, so that clients can spot the
+// proxies that were left behind by an upgrade. The values the control plane
+// is currently running with are reported alongside.
+func (s *grpcServer) ProxySkew(ctx context.Context, req *pb.ProxySkewRequest) (*pb.ProxySkewResponse, error) {
+	requestid.Logger(ctx).Debugf("ProxySkew request: %+v", req)
+
+	var pods []*k8sV1.Pod
+	var err error
+	if req.GetNamespace() != "" {
+		pods, err = s.k8sAPI.Pod().Lister().Pods(req.GetNamespace()).List(labels.Everything())
+	} else {
+		pods, err = s.k8sAPI.Pod().Lister().List(labels.Everything())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	currentConfigHash, err := s.currentProxyConfigHash()
+	if err != nil {
+		return nil, err
+	}
+	currentTrustAnchors, err := s.trustAnchorsFingerprint(s.controllerNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := newSkewGroups()
+	configHashes := newSkewGroups()
+	trustAnchors := newSkewGroups()
+	trustAnchorsByNs := make(map[string]string)
+
+	for _, pod := range pods {
+		if s.shouldIgnore(pod) || !pkgK8s.IsMeshed(pod, s.controllerNamespace) {
+			continue
+		}
+		if pod.Status.Phase != k8sV1.PodPending && pod.Status.Phase != k8sV1.PodRunning {
+			continue
+		}
+		config := proxyConfigForPod(pod)
+		if config == nil {
+			continue
+		}
+
+		ownerKind, ownerName := s.k8sAPI.GetOwnerKindAndName(pod)
+		owner := workload{namespace: pod.Namespace, kind: ownerKind, name: ownerName}
+
+		versions.add(config.Version, owner)
+		configHashes.add(pod.Annotations[pkgK8s.ProxyConfigHashAnnotation], owner)
+
+		if !config.Tls {
+			continue
+		}
+		fingerprint, ok := trustAnchorsByNs[pod.Namespace]
+		if !ok {
+			fingerprint, err = s.trustAnchorsFingerprint(pod.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			trustAnchorsByNs[pod.Namespace] = fingerprint
+		}
+		trustAnchors.add(fingerprint, owner)
+	}
+
+	rsp := &pb.ProxySkewResponse{
+		CurrentVersion:      version.Version,
+		CurrentConfigHash:   currentConfigHash,
+		CurrentTrustAnchors: currentTrustAnchors,
+		Versions:            versions.list(),
+		ConfigHashes:        configHashes.list(),
+		TrustAnchors:        trustAnchors.list(),
+	}
+
+	requestid.Logger(ctx).Debugf("ProxySkew response: %+v", rsp)
+
+	return rsp, nil
+}
+
+// currentProxyConfigHash returns the hash of the proxy configuration the
+// control plane was installed with, or an empty string if it wasn't recorded.
+func (s *grpcServer) currentProxyConfigHash() (string, error) {
+	cm, err := s.k8sAPI.CM().Lister().ConfigMaps(s.controllerNamespace).Get(pkgK8s.ProxyConfigMapName)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return cm.Data[pkgK8s.ProxyConfigHashKey], nil
+}
+
+// trustAnchorsFingerprint returns a short digest of the trust anchors that
+// were distributed to a namespace, or an empty string if there are none.
+func (s *grpcServer) trustAnchorsFingerprint(namespace string) (string, error) {
+	cm, err := s.k8sAPI.CM().Lister().ConfigMaps(namespace).Get(pkgK8s.TLSTrustAnchorConfigMapName)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	pem, ok := cm.Data[pkgK8s.TLSTrustAnchorFileName]
+	if !ok {
+		return "", nil
+	}
+	digest := sha256.Sum256([]byte(pem))
+	return hex.EncodeToString(digest[:trustAnchorsFingerprintLength]), nil
+}
@@ -0,0 +1,146 @@
+package public
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+)
+
+func meshedPodConfig(namespace, name, proxyVersion, configHash string, tls bool) string {
+	env := ""
+	if tls {
+		env = `
+    env:
+    - name: LINKERD2_PROXY_TLS_CERT
+      value: /var/linkerd-io/identity/certificate.crt`
+	}
+	return `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: ` + name + `
+  namespace: ` + namespace + `
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+  annotations:
+    linkerd.io/proxy-config-hash: ` + configHash + `
+spec:
+  containers:
+  - name: linkerd-proxy
+    image: gcr.io/linkerd-io/proxy:` + proxyVersion + env + `
+status:
+  phase: Running
+`
+}
+
+func TestProxySkew(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-proxy-config
+  namespace: linkerd
+data:
+  hash: current-hash
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-ca-bundle
+  namespace: linkerd
+data:
+  trust-anchors.pem: new-anchors
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-ca-bundle
+  namespace: emojivoto
+data:
+  trust-anchors.pem: old-anchors
+`,
+		meshedPodConfig("emojivoto", "web-1", "edge-18.10.2", "current-hash", true),
+		meshedPodConfig("emojivoto", "web-2", "edge-18.10.1", "old-hash", true),
+		meshedPodConfig("emojivoto", "voting-1", "edge-18.10.2", "current-hash", false),
+		meshedPodConfig("books", "authors-1", "edge-18.10.2", "current-hash", false),
+		meshedPodConfig("kube-system", "kube-dns", "edge-18.10.1", "old-hash", false), `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-1
+  namespace: emojivoto
+spec:
+  containers:
+  - name: emoji
+    image: buoyantio/emojivoto-emoji-svc:v6
+status:
+  phase: Running
+`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	fakeGrpcServer := newGrpcServer(&MockProm{}, tap.NewTapClient(nil), k8sAPI, "linkerd", []string{"kube-system"})
+
+	pod := func(namespace, name string) *pb.Resource {
+		return &pb.Resource{Namespace: namespace, Type: "pod", Name: name}
+	}
+
+	t.Run("Groups the meshed pods of all the namespaces", func(t *testing.T) {
+		rsp, err := fakeGrpcServer.ProxySkew(context.TODO(), &pb.ProxySkewRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := &pb.ProxySkewResponse{
+			CurrentVersion:      version.Version,
+			CurrentConfigHash:   "current-hash",
+			CurrentTrustAnchors: "4588a9bb29ee6f9b",
+			Versions: []*pb.ProxySkewGroup{
+				{
+					Value:    "edge-18.10.2",
+					PodCount: 3,
+					Examples: []*pb.Resource{pod("books", "authors-1"), pod("emojivoto", "voting-1"), pod("emojivoto", "web-1")},
+				},
+				{Value: "edge-18.10.1", PodCount: 1, Examples: []*pb.Resource{pod("emojivoto", "web-2")}},
+			},
+			ConfigHashes: []*pb.ProxySkewGroup{
+				{
+					Value:    "current-hash",
+					PodCount: 3,
+					Examples: []*pb.Resource{pod("books", "authors-1"), pod("emojivoto", "voting-1"), pod("emojivoto", "web-1")},
+				},
+				{Value: "old-hash", PodCount: 1, Examples: []*pb.Resource{pod("emojivoto", "web-2")}},
+			},
+			TrustAnchors: []*pb.ProxySkewGroup{
+				{Value: "35561ec1ee26ff34", PodCount: 2, Examples: []*pb.Resource{pod("emojivoto", "web-1"), pod("emojivoto", "web-2")}},
+			},
+		}
+		if !proto.Equal(expected, rsp) {
+			t.Fatalf("Expected: %+v, Got: %+v", expected, rsp)
+		}
+	})
+
+	t.Run("Groups the meshed pods of a namespace", func(t *testing.T) {
+		rsp, err := fakeGrpcServer.ProxySkew(context.TODO(), &pb.ProxySkewRequest{Namespace: "books"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := []*pb.ProxySkewGroup{
+			{Value: "edge-18.10.2", PodCount: 1, Examples: []*pb.Resource{pod("books", "authors-1")}},
+		}
+		if len(rsp.GetVersions()) != 1 || !proto.Equal(expected[0], rsp.GetVersions()[0]) {
+			t.Fatalf("Expected versions: %+v, Got: %+v", expected, rsp.GetVersions())
+		}
+		if len(rsp.GetTrustAnchors()) != 0 {
+			t.Fatalf("Expected no trust anchors, Got: %+v", rsp.GetTrustAnchors())
+		}
+	})
+}
@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
+	"sync"
 	"time"
 
 	proto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/requestid"
 	"github.com/prometheus/common/model"
 	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,6 +58,7 @@ const (
 
 	namespaceLabel    = model.LabelName("namespace")
 	dstNamespaceLabel = model.LabelName("dst_namespace")
+	authorityLabel    = model.LabelName("authority")
 )
 
 var promTypes = []promType{promRequests, promLatencyP50, promLatencyP95, promLatencyP99}
@@ -75,6 +82,38 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		return statSummaryError(req, "service only supported as a target on 'from' queries, or as a destination on 'to' queries"), nil
 	}
 
+	if req.GetSelector().GetLabelSelector() != "" {
+		if isNonK8sResourceQuery(req.GetSelector().GetResource().GetType()) {
+			return statSummaryError(req, "label selectors are not supported for non-Kubernetes resources"), nil
+		}
+		if _, err := labels.Parse(req.GetSelector().GetLabelSelector()); err != nil {
+			return statSummaryError(req, fmt.Sprintf("invalid label selector: %s", err)), nil
+		}
+	}
+
+	if req.GetPerPod() {
+		switch req.GetSelector().GetResource().GetType() {
+		case k8s.All, k8s.Pod, k8s.Authority:
+			return statSummaryError(req, "per-pod stats are only supported for resources that have pods, such as deployments"), nil
+		}
+		if req.GetFromResource() != nil {
+			return statSummaryError(req, "per-pod stats are not supported on 'from' queries"), nil
+		}
+	}
+
+	if req.GetBreakdownBy() != "" {
+		if req.GetBreakdownBy() != k8s.Authority {
+			return statSummaryError(req, fmt.Sprintf("stats can only be broken down by %s, not %s", k8s.Authority, req.GetBreakdownBy())), nil
+		}
+		switch req.GetSelector().GetResource().GetType() {
+		case k8s.All, k8s.Authority:
+			return statSummaryError(req, fmt.Sprintf("%s breakdowns are only supported for Kubernetes resources, such as deployments", k8s.Authority)), nil
+		}
+		if req.GetFromResource() != nil {
+			return statSummaryError(req, fmt.Sprintf("%s breakdowns are not supported on 'from' queries", k8s.Authority)), nil
+		}
+	}
+
 	switch req.Outbound.(type) {
 	case *pb.StatSummaryRequest_ToResource:
 		if req.Outbound.(*pb.StatSummaryRequest_ToResource).ToResource.Type == k8s.All {
@@ -86,6 +125,11 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		}
 	}
 
+	var queries *queryRecorder
+	if req.GetDebugQueries() {
+		ctx, queries = withQueryRecorder(ctx)
+	}
+
 	statTables := make([]*pb.StatTable, 0)
 
 	var resourcesToQuery []string
@@ -111,7 +155,21 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		}()
 	}
 
-	for i := 0; i < len(resourcesToQuery); i++ {
+	queryCount := len(resourcesToQuery)
+	if req.GetPerPod() {
+		queryCount++
+		go func() {
+			resultChan <- s.podBreakdownQuery(ctx, req)
+		}()
+	}
+	if req.GetBreakdownBy() != "" {
+		queryCount++
+		go func() {
+			resultChan <- s.authorityBreakdownQuery(ctx, req)
+		}()
+	}
+
+	for i := 0; i < queryCount; i++ {
 		result := <-resultChan
 		if result.err != nil {
 			return nil, util.GRPCError(result.err)
@@ -123,6 +181,7 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		Response: &pb.StatSummaryResponse_Ok_{ // https://github.com/golang/protobuf/issues/205
 			Ok: &pb.StatSummaryResponse_Ok{
 				StatTables: statTables,
+				Queries:    queries.sorted(),
 			},
 		},
 	}
@@ -141,9 +200,59 @@ func statSummaryError(req *pb.StatSummaryRequest, message string) *pb.StatSummar
 	}
 }
 
-func (s *grpcServer) getKubernetesObjectStats(req *pb.StatSummaryRequest) (map[rKey]k8sStat, error) {
+// getObjects looks up Kubernetes objects in the informer caches, recording a
+// span for the lookup.
+func (s *grpcServer) getObjects(ctx context.Context, namespace, restype, name string) ([]runtime.Object, error) {
+	_, span := trace.StartSpan(ctx, "k8s.GetObjects")
+	defer span.End()
+	span.AddAttributes(
+		trace.StringAttribute("namespace", namespace),
+		trace.StringAttribute("type", restype),
+		trace.StringAttribute("name", name),
+	)
+
+	objects, err := s.k8sAPI.GetObjects(namespace, restype, name)
+	if err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+		return nil, err
+	}
+	span.AddAttributes(trace.Int64Attribute("objects", int64(len(objects))))
+	return objects, nil
+}
+
+// getSelectedObjects returns the Kubernetes objects matching the request's
+// resource and label selector.
+func (s *grpcServer) getSelectedObjects(ctx context.Context, req *pb.StatSummaryRequest) ([]runtime.Object, error) {
 	requestedResource := req.GetSelector().GetResource()
-	objects, err := s.k8sAPI.GetObjects(requestedResource.Namespace, requestedResource.Type, requestedResource.Name)
+	objects, err := s.getObjects(ctx, requestedResource.Namespace, requestedResource.Type, requestedResource.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the selector has already been validated in StatSummary; an empty selector
+	// matches every object
+	selector, err := labels.Parse(req.GetSelector().GetLabelSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]runtime.Object, 0)
+	for _, object := range objects {
+		metaObj, err := meta.Accessor(object)
+		if err != nil {
+			return nil, err
+		}
+
+		if selector.Matches(labels.Set(metaObj.GetLabels())) {
+			selected = append(selected, object)
+		}
+	}
+	return selected, nil
+}
+
+func (s *grpcServer) getKubernetesObjectStats(ctx context.Context, req *pb.StatSummaryRequest) (map[rKey]k8sStat, error) {
+	requestedResource := req.GetSelector().GetResource()
+	objects, err := s.getSelectedObjects(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -176,16 +285,151 @@ func (s *grpcServer) getKubernetesObjectStats(req *pb.StatSummaryRequest) (map[r
 }
 
 func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
-	k8sObjects, err := s.getKubernetesObjectStats(req)
+	k8sObjects, err := s.getKubernetesObjectStats(ctx, req)
 	if err != nil {
 		return resourceResult{res: nil, err: err}
 	}
 
 	requestMetrics, err := s.getPrometheusMetrics(ctx, req, req.TimeWindow)
+	if err == errPrometheusUnavailable {
+		// still return the Kubernetes objects and their pod counts, without stats
+		log.Warnf("Returning %s without stats: %s", req.GetSelector().GetResource().GetType(), err)
+		requestMetrics = map[rKey]*pb.BasicStats{}
+	} else if err != nil {
+		return resourceResult{res: nil, err: err}
+	}
+
+	return resourceResult{res: k8sStatTable(req, k8sObjects, requestMetrics), err: nil}
+}
+
+// podBreakdownQuery returns a table with a row for each pod backing the
+// requested resources, so that a single unhealthy pod stands out from its
+// resource's aggregate stats.
+func (s *grpcServer) podBreakdownQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
+	objects, err := s.getSelectedObjects(ctx, req)
 	if err != nil {
 		return resourceResult{res: nil, err: err}
 	}
 
+	podObjects := map[rKey]k8sStat{}
+	for _, object := range objects {
+		pods, err := s.k8sAPI.GetPodsFor(object, true)
+		if err != nil {
+			return resourceResult{res: nil, err: err}
+		}
+
+		for _, pod := range pods {
+			podStats, err := s.getPodStats(pod)
+			if err != nil {
+				return resourceResult{res: nil, err: err}
+			}
+
+			key := rKey{Namespace: pod.Namespace, Type: k8s.Pod, Name: pod.Name}
+			podObjects[key] = k8sStat{object: pod, podStats: podStats}
+		}
+	}
+
+	// query the metrics of the requested resources, grouped by pod
+	reqLabels, _ := buildRequestLabels(req)
+	groupBy := promGroupByLabelNames(&pb.Resource{Type: k8s.Pod})
+	requestMetrics, err := s.queryPrometheusMetrics(ctx, k8s.Pod, reqLabels, groupBy, req.TimeWindow)
+	if err == errPrometheusUnavailable {
+		log.Warnf("Returning pods without stats: %s", err)
+		requestMetrics = map[rKey]*pb.BasicStats{}
+	} else if err != nil {
+		return resourceResult{res: nil, err: err}
+	}
+
+	podReq := proto.Clone(req).(*pb.StatSummaryRequest)
+	podReq.Selector.Resource.Type = k8s.Pod
+
+	return resourceResult{res: k8sStatTable(podReq, podObjects, requestMetrics), err: nil}
+}
+
+// authorityBreakdownQuery returns a table with a row for each authority that
+// each of the requested resources sends requests to, so that a resource's
+// outbound stats can be told apart by destination.
+func (s *grpcServer) authorityBreakdownQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
+	k8sObjects, err := s.getKubernetesObjectStats(ctx, req)
+	if err != nil {
+		return resourceResult{res: nil, err: err}
+	}
+
+	reqLabels := promQueryLabels(req.Selector.Resource).Merge(promDirectionLabels("outbound"))
+	if toResource := req.GetToResource(); toResource != nil {
+		reqLabels = reqLabels.Merge(promDstQueryLabels(toResource))
+	}
+	parentGroupBy := promGroupByLabelNames(req.Selector.Resource)
+	groupBy := append(append(model.LabelNames{}, parentGroupBy...), authorityLabel)
+
+	results, err := s.queryPrometheusResults(ctx, reqLabels, groupBy, req.TimeWindow)
+	if err == errPrometheusUnavailable {
+		// there are no rows to return without stats
+		log.Warnf("Returning no %s breakdown: %s", k8s.Authority, err)
+		results = []promResult{}
+	} else if err != nil {
+		return resourceResult{res: nil, err: err}
+	}
+
+	type breakdownKey struct {
+		parent    rKey
+		authority rKey
+	}
+	breakdown := make(map[breakdownKey]*pb.BasicStats)
+	for _, result := range results {
+		for _, sample := range result.vec {
+			key := breakdownKey{
+				parent: metricToKey(req.Selector.Resource.Type, sample.Metric, parentGroupBy),
+				authority: rKey{
+					Namespace: string(sample.Metric[namespaceLabel]),
+					Type:      k8s.Authority,
+					Name:      string(sample.Metric[authorityLabel]),
+				},
+			}
+
+			// skip resources that don't match the request's label selector
+			if _, ok := k8sObjects[key.parent]; !ok {
+				continue
+			}
+
+			if breakdown[key] == nil {
+				breakdown[key] = &pb.BasicStats{}
+			}
+			addSampleToStats(breakdown[key], result.prom, sample)
+		}
+	}
+
+	rows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for key, stats := range breakdown {
+		rows = append(rows, &pb.StatTable_PodGroup_Row{
+			Resource: &pb.Resource{
+				Namespace: key.authority.Namespace,
+				Type:      key.authority.Type,
+				Name:      key.authority.Name,
+			},
+			Parent: &pb.Resource{
+				Namespace: key.parent.Namespace,
+				Type:      key.parent.Type,
+				Name:      key.parent.Name,
+			},
+			TimeWindow: req.TimeWindow,
+			Stats:      stats,
+		})
+	}
+
+	return resourceResult{
+		res: &pb.StatTable{
+			Table: &pb.StatTable_PodGroup_{
+				PodGroup: &pb.StatTable_PodGroup{
+					Rows: rows,
+				},
+			},
+		},
+		err: nil,
+	}
+}
+
+func k8sStatTable(req *pb.StatSummaryRequest, k8sObjects map[rKey]k8sStat, requestMetrics map[rKey]*pb.BasicStats) *pb.StatTable {
 	rows := make([]*pb.StatTable_PodGroup_Row, 0)
 	keys := getResultKeys(req, k8sObjects, requestMetrics)
 
@@ -214,15 +458,13 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 		rows = append(rows, &row)
 	}
 
-	rsp := pb.StatTable{
+	return &pb.StatTable{
 		Table: &pb.StatTable_PodGroup_{
 			PodGroup: &pb.StatTable_PodGroup{
 				Rows: rows,
 			},
 		},
 	}
-
-	return resourceResult{res: &rsp, err: nil}
 }
 
 func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
@@ -383,6 +625,21 @@ func buildRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet, labe
 
 func (s *grpcServer) getPrometheusMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string) (map[rKey]*pb.BasicStats, error) {
 	reqLabels, groupBy := buildRequestLabels(req)
+	return s.queryPrometheusMetrics(ctx, req.GetSelector().GetResource().GetType(), reqLabels, groupBy, timeWindow)
+}
+
+func (s *grpcServer) queryPrometheusMetrics(ctx context.Context, resourceType string, reqLabels model.LabelSet, groupBy model.LabelNames, timeWindow string) (map[rKey]*pb.BasicStats, error) {
+	results, err := s.queryPrometheusResults(ctx, reqLabels, groupBy, timeWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return processPrometheusMetrics(resourceType, results, groupBy), nil
+}
+
+// queryPrometheusResults runs the request volume and latency queries for the
+// given labels, grouped by groupBy.
+func (s *grpcServer) queryPrometheusResults(ctx context.Context, reqLabels model.LabelSet, groupBy model.LabelNames, timeWindow string) ([]promResult, error) {
 	resultChan := make(chan promResult)
 
 	// kick off 4 asynchronous queries: 1 request volume + 3 latency
@@ -427,47 +684,53 @@ func (s *grpcServer) getPrometheusMetrics(ctx context.Context, req *pb.StatSumma
 		return nil, err
 	}
 
-	return processPrometheusMetrics(req, results, groupBy), nil
+	return results, nil
 }
 
-func processPrometheusMetrics(req *pb.StatSummaryRequest, results []promResult, groupBy model.LabelNames) map[rKey]*pb.BasicStats {
+func processPrometheusMetrics(resourceType string, results []promResult, groupBy model.LabelNames) map[rKey]*pb.BasicStats {
 	basicStats := make(map[rKey]*pb.BasicStats)
 
 	for _, result := range results {
 		for _, sample := range result.vec {
-			resource := metricToKey(req, sample.Metric, groupBy)
+			resource := metricToKey(resourceType, sample.Metric, groupBy)
 
 			if basicStats[resource] == nil {
 				basicStats[resource] = &pb.BasicStats{}
 			}
 
-			value := extractSampleValue(sample)
-
-			switch result.prom {
-			case promRequests:
-				switch string(sample.Metric[model.LabelName("classification")]) {
-				case "success":
-					basicStats[resource].SuccessCount += value
-				case "failure":
-					basicStats[resource].FailureCount += value
-				}
-				switch string(sample.Metric[model.LabelName("tls")]) {
-				case "true":
-					basicStats[resource].TlsRequestCount += value
-				}
-			case promLatencyP50:
-				basicStats[resource].LatencyMsP50 = value
-			case promLatencyP95:
-				basicStats[resource].LatencyMsP95 = value
-			case promLatencyP99:
-				basicStats[resource].LatencyMsP99 = value
-			}
+			addSampleToStats(basicStats[resource], result.prom, sample)
 		}
 	}
 
 	return basicStats
 }
 
+// addSampleToStats adds the value of a sample returned by the query of the
+// given type to stats.
+func addSampleToStats(stats *pb.BasicStats, prom promType, sample *model.Sample) {
+	value := extractSampleValue(sample)
+
+	switch prom {
+	case promRequests:
+		switch string(sample.Metric[model.LabelName("classification")]) {
+		case "success":
+			stats.SuccessCount += value
+		case "failure":
+			stats.FailureCount += value
+		}
+		switch string(sample.Metric[model.LabelName("tls")]) {
+		case "true":
+			stats.TlsRequestCount += value
+		}
+	case promLatencyP50:
+		stats.LatencyMsP50 = value
+	case promLatencyP95:
+		stats.LatencyMsP95 = value
+	case promLatencyP99:
+		stats.LatencyMsP99 = value
+	}
+}
+
 func extractSampleValue(sample *model.Sample) uint64 {
 	value := uint64(0)
 	if !math.IsNaN(float64(sample.Value)) {
@@ -476,12 +739,12 @@ func extractSampleValue(sample *model.Sample) uint64 {
 	return value
 }
 
-func metricToKey(req *pb.StatSummaryRequest, metric model.Metric, groupBy model.LabelNames) rKey {
+func metricToKey(resourceType string, metric model.Metric, groupBy model.LabelNames) rKey {
 	// this key is used to match the metric stats we queried from prometheus
 	// with the k8s object stats we queried from k8s
 	// ASSUMPTION: this code assumes that groupBy is always ordered (..., namespace, name)
 	key := rKey{
-		Type: req.GetSelector().GetResource().GetType(),
+		Type: resourceType,
 		Name: string(metric[groupBy[len(groupBy)-1]]),
 	}
 
@@ -568,15 +831,29 @@ func isInvalidServiceRequest(req *pb.StatSummaryRequest) bool {
 }
 
 func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector, error) {
-	log.Debugf("Query request:\n\t%+v", query)
+	requestid.Logger(ctx).Debugf("Query request:\n\t%+v", query)
+
+	// the span includes the time spent waiting for the query limiter
+	ctx, span := trace.StartSpan(ctx, "prometheus.Query")
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("query", query))
 
 	// single data point (aka summary) query
-	res, err := s.prometheusAPI.Query(ctx, query, time.Time{})
+	var res model.Value
+	start := time.Now()
+	err := s.promLimiter.do(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.prometheusAPI.Query(ctx, query, time.Time{})
+		return err
+	})
+	promQueryDuration.WithLabelValues(promQueryResult(ctx, err)).Observe(time.Since(start).Seconds())
+	recordQuery(ctx, query, time.Since(start), err)
 	if err != nil {
-		log.Errorf("Query(%+v) failed with: %+v", query, err)
+		requestid.Logger(ctx).Errorf("Query(%+v) failed with: %+v", query, err)
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
 		return nil, err
 	}
-	log.Debugf("Query response:\n\t%+v", res)
+	requestid.Logger(ctx).Debugf("Query response:\n\t%+v", res)
 
 	if res.Type() != model.ValVector {
 		err = fmt.Errorf("Unexpected query result type (expected Vector): %s", res.Type())
@@ -586,3 +863,55 @@ func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector,
 
 	return res.(model.Vector), nil
 }
+
+// queryRecorder collects the Prometheus queries run for a request, so that
+// they can be returned to clients that set `debug_queries`.
+type queryRecorder struct {
+	sync.Mutex
+	queries []*pb.PrometheusQuery
+}
+
+type queryRecorderKey struct{}
+
+// withQueryRecorder returns a copy of ctx whose Prometheus queries are
+// recorded by the returned recorder.
+func withQueryRecorder(ctx context.Context) (context.Context, *queryRecorder) {
+	recorder := &queryRecorder{}
+	return context.WithValue(ctx, queryRecorderKey{}, recorder), recorder
+}
+
+// recordQuery adds the query to the recorder carried by ctx, if any.
+func recordQuery(ctx context.Context, query string, duration time.Duration, err error) {
+	recorder, ok := ctx.Value(queryRecorderKey{}).(*queryRecorder)
+	if !ok {
+		return
+	}
+
+	recorded := &pb.PrometheusQuery{
+		Query:    query,
+		Duration: ptypes.DurationProto(duration),
+	}
+	if err != nil {
+		recorded.Error = err.Error()
+	}
+
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.queries = append(recorder.queries, recorded)
+}
+
+// sorted returns the recorded queries sorted by query, as they run
+// concurrently. It returns nil on a nil recorder.
+func (r *queryRecorder) sorted() []*pb.PrometheusQuery {
+	if r == nil {
+		return nil
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	queries := append([]*pb.PrometheusQuery{}, r.queries...)
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].GetQuery() < queries[j].GetQuery()
+	})
+	return queries
+}
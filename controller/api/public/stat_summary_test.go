@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
@@ -727,6 +728,524 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Only returns resources matching the label selector", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    team: checkout
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: voting
+  namespace: emojivoto
+  labels:
+    team: polls
+spec:
+  selector:
+    matchLabels:
+      app: voting-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-voting-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+				},
+				mockPromResponse: prometheusMetric("emoji", "deployment", "emojivoto", "success", false),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+						},
+						LabelSelector: "team=checkout",
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("emoji", pkgK8s.Deployment, "emojivoto", &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Returns a row for each pod if per-pod stats are requested", func(t *testing.T) {
+		deploymentRsp := GenStatSummaryResponse("emoji", pkgK8s.Deployment, "emojivoto", &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		})
+		podRsp := GenStatSummaryResponse("emojivoto-meshed", pkgK8s.Pod, "emojivoto", &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		})
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+				},
+				mockPromResponse: model.Vector{
+					&model.Sample{
+						Metric: model.Metric{
+							"deployment":     "emoji",
+							"pod":            "emojivoto-meshed",
+							"namespace":      "emojivoto",
+							"classification": "success",
+							"tls":            "true",
+						},
+						Value:     123,
+						Timestamp: 456,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Name:      "emoji",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+						},
+					},
+					TimeWindow: "1m",
+					PerPod:     true,
+				},
+				expectedPrometheusQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`sum(increase(response_total{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (namespace, deployment, classification, tls)`,
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+					`sum(increase(response_total{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (namespace, pod, classification, tls)`,
+				},
+				expectedResponse: pb.StatSummaryResponse{
+					Response: &pb.StatSummaryResponse_Ok_{ // https://github.com/golang/protobuf/issues/205
+						Ok: &pb.StatSummaryResponse_Ok{
+							StatTables: []*pb.StatTable{
+								deploymentRsp.GetOk().StatTables[0],
+								podRsp.GetOk().StatTables[0],
+							},
+						},
+					},
+				},
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Returns a row for each authority if an authority breakdown is requested", func(t *testing.T) {
+		deploymentRsp := GenStatSummaryResponse("emoji", pkgK8s.Deployment, "emojivoto", &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		})
+		authorityRsp := GenStatSummaryResponse("voting-svc.emojivoto:8080", pkgK8s.Authority, "emojivoto", nil)
+		authorityRsp.GetOk().StatTables[0].GetPodGroup().Rows[0].Parent = &pb.Resource{
+			Namespace: "emojivoto",
+			Type:      pkgK8s.Deployment,
+			Name:      "emoji",
+		}
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+				},
+				mockPromResponse: model.Vector{
+					&model.Sample{
+						Metric: model.Metric{
+							"deployment":     "emoji",
+							"authority":      "voting-svc.emojivoto:8080",
+							"namespace":      "emojivoto",
+							"classification": "success",
+							"tls":            "true",
+						},
+						Value:     123,
+						Timestamp: 456,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Name:      "emoji",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+						},
+					},
+					TimeWindow:  "1m",
+					BreakdownBy: pkgK8s.Authority,
+				},
+				expectedPrometheusQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`sum(increase(response_total{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (namespace, deployment, classification, tls)`,
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="outbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment, authority))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="outbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment, authority))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{deployment="emoji", direction="outbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment, authority))`,
+					`sum(increase(response_total{deployment="emoji", direction="outbound", namespace="emojivoto"}[1m])) by (namespace, deployment, authority, classification, tls)`,
+				},
+				expectedResponse: pb.StatSummaryResponse{
+					Response: &pb.StatSummaryResponse_Ok_{ // https://github.com/golang/protobuf/issues/205
+						Ok: &pb.StatSummaryResponse_Ok{
+							StatTables: []*pb.StatTable{
+								authorityRsp.GetOk().StatTables[0],
+								deploymentRsp.GetOk().StatTables[0],
+							},
+						},
+					},
+				},
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Given an invalid breakdown request, returns error", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			&MockProm{Res: model.Vector{}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		for _, req := range []*pb.StatSummaryRequest{
+			&pb.StatSummaryRequest{
+				Selector:    &pb.ResourceSelection{Resource: &pb.Resource{Type: pkgK8s.Deployment}},
+				BreakdownBy: "route",
+			},
+			&pb.StatSummaryRequest{
+				Selector:    &pb.ResourceSelection{Resource: &pb.Resource{Type: pkgK8s.Authority}},
+				BreakdownBy: pkgK8s.Authority,
+			},
+			&pb.StatSummaryRequest{
+				Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: pkgK8s.Deployment}},
+				Outbound: &pb.StatSummaryRequest_FromResource{
+					FromResource: &pb.Resource{Type: pkgK8s.Deployment},
+				},
+				BreakdownBy: pkgK8s.Authority,
+			},
+		} {
+			rsp, err := fakeGrpcServer.StatSummary(context.TODO(), req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetError() == nil {
+				t.Fatalf("Expected an error response for request %+v, got: %+v", req, rsp)
+			}
+		}
+	})
+
+	t.Run("Returns objects without stats when Prometheus is unavailable", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		mockProm := &MockProm{Res: model.Vector{}}
+		fakeGrpcServer := newGrpcServer(
+			mockProm,
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+		fakeGrpcServer.promLimiter.openUntil = time.Now().Add(time.Minute)
+
+		k8sAPI.Sync(nil)
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment},
+			},
+			TimeWindow: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if len(mockProm.QueriesExecuted) != 0 {
+			t.Fatalf("Expected no Prometheus queries, got: %v", mockProm.QueriesExecuted)
+		}
+
+		expectedRow := &pb.StatTable_PodGroup_Row{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Type:      pkgK8s.Deployment,
+				Name:      "emoji",
+			},
+			TimeWindow:      "1m",
+			MeshedPodCount:  1,
+			RunningPodCount: 1,
+		}
+		rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+		if len(rows) != 1 || !proto.Equal(rows[0], expectedRow) {
+			t.Fatalf("Expected: %+v\n Got: %+v", expectedRow, rows)
+		}
+	})
+
+	t.Run("Returns the Prometheus queries if debug queries are requested", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		mockProm := &MockProm{Res: model.Vector{}}
+		fakeGrpcServer := newGrpcServer(
+			mockProm,
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		k8sAPI.Sync(nil)
+
+		req := &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment},
+			},
+			TimeWindow: "1m",
+		}
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if queries := rsp.GetOk().GetQueries(); len(queries) != 0 {
+			t.Fatalf("Expected no queries without debug_queries, got: %v", queries)
+		}
+
+		mockProm.QueriesExecuted = nil
+		req.DebugQueries = true
+		rsp, err = fakeGrpcServer.StatSummary(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expectedQueries := append([]string{}, mockProm.QueriesExecuted...)
+		sort.Strings(expectedQueries)
+
+		queries := rsp.GetOk().GetQueries()
+		if len(queries) != len(expectedQueries) || len(queries) != len(promTypes) {
+			t.Fatalf("Expected %d queries, got: %v", len(promTypes), queries)
+		}
+		for i, query := range queries {
+			if query.GetQuery() != expectedQueries[i] {
+				t.Fatalf("Expected query %s, got %s", expectedQueries[i], query.GetQuery())
+			}
+			if query.GetDuration() == nil || query.GetError() != "" {
+				t.Fatalf("Expected a duration and no error, got: %v", query)
+			}
+		}
+	})
+
+	t.Run("Given an invalid per-pod request, returns error", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			&MockProm{Res: model.Vector{}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		for _, req := range []*pb.StatSummaryRequest{
+			&pb.StatSummaryRequest{
+				Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: pkgK8s.Pod}},
+				PerPod:   true,
+			},
+			&pb.StatSummaryRequest{
+				Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: pkgK8s.Authority}},
+				PerPod:   true,
+			},
+			&pb.StatSummaryRequest{
+				Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: pkgK8s.Deployment}},
+				Outbound: &pb.StatSummaryRequest_FromResource{
+					FromResource: &pb.Resource{Type: pkgK8s.Deployment},
+				},
+				PerPod: true,
+			},
+		} {
+			rsp, err := fakeGrpcServer.StatSummary(context.TODO(), req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetError() == nil {
+				t.Fatalf("Expected an error response for request %+v, got: %+v", req, rsp)
+			}
+		}
+	})
+
+	t.Run("Given an invalid label selector, returns error", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			&MockProm{Res: model.Vector{}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		for _, selector := range []*pb.ResourceSelection{
+			&pb.ResourceSelection{
+				Resource:      &pb.Resource{Type: pkgK8s.Deployment},
+				LabelSelector: "team in (checkout",
+			},
+			&pb.ResourceSelection{
+				Resource:      &pb.Resource{Type: pkgK8s.Authority},
+				LabelSelector: "team=checkout",
+			},
+		} {
+			rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{Selector: selector})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetError() == nil {
+				t.Fatalf("Expected an error response for selector %+v, got: %+v", selector, rsp)
+			}
+		}
+	})
+
 	t.Run("Given an invalid resource type, returns error", func(t *testing.T) {
 		k8sAPI, err := k8s.NewFakeAPI()
 		if err != nil {
@@ -14,13 +14,19 @@ import (
 )
 
 type MockApiClient struct {
-	ErrorToReturn                   error
-	VersionInfoToReturn             *pb.VersionInfo
-	ListPodsResponseToReturn        *pb.ListPodsResponse
-	StatSummaryResponseToReturn     *pb.StatSummaryResponse
-	SelfCheckResponseToReturn       *healthcheckPb.SelfCheckResponse
-	Api_TapClientToReturn           pb.Api_TapClient
-	Api_TapByResourceClientToReturn pb.Api_TapByResourceClient
+	ErrorToReturn                       error
+	VersionInfoToReturn                 *pb.VersionInfo
+	ListPodsResponseToReturn            *pb.ListPodsResponse
+	MeshCoverageResponseToReturn        *pb.MeshCoverageResponse
+	ListNamespacesResponseToReturn      *pb.ListNamespacesResponse
+	LatencyDistributionResponseToReturn *pb.LatencyDistributionResponse
+	PodStatsResponseToReturn            *pb.PodStatsResponse
+	ListResourcesResponseToReturn       *pb.ListResourcesResponse
+	ProxySkewResponseToReturn           *pb.ProxySkewResponse
+	StatSummaryResponseToReturn         *pb.StatSummaryResponse
+	SelfCheckResponseToReturn           *healthcheckPb.SelfCheckResponse
+	Api_TapClientToReturn               pb.Api_TapClient
+	Api_TapByResourceClientToReturn     pb.Api_TapByResourceClient
 }
 
 func (c *MockApiClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
@@ -35,6 +41,30 @@ func (c *MockApiClient) ListPods(ctx context.Context, in *pb.ListPodsRequest, op
 	return c.ListPodsResponseToReturn, c.ErrorToReturn
 }
 
+func (c *MockApiClient) MeshCoverage(ctx context.Context, in *pb.MeshCoverageRequest, opts ...grpc.CallOption) (*pb.MeshCoverageResponse, error) {
+	return c.MeshCoverageResponseToReturn, c.ErrorToReturn
+}
+
+func (c *MockApiClient) ListNamespaces(ctx context.Context, in *pb.ListNamespacesRequest, opts ...grpc.CallOption) (*pb.ListNamespacesResponse, error) {
+	return c.ListNamespacesResponseToReturn, c.ErrorToReturn
+}
+
+func (c *MockApiClient) LatencyDistribution(ctx context.Context, in *pb.LatencyDistributionRequest, opts ...grpc.CallOption) (*pb.LatencyDistributionResponse, error) {
+	return c.LatencyDistributionResponseToReturn, c.ErrorToReturn
+}
+
+func (c *MockApiClient) PodStats(ctx context.Context, in *pb.PodStatsRequest, opts ...grpc.CallOption) (*pb.PodStatsResponse, error) {
+	return c.PodStatsResponseToReturn, c.ErrorToReturn
+}
+
+func (c *MockApiClient) ListResources(ctx context.Context, in *pb.ListResourcesRequest, opts ...grpc.CallOption) (*pb.ListResourcesResponse, error) {
+	return c.ListResourcesResponseToReturn, c.ErrorToReturn
+}
+
+func (c *MockApiClient) ProxySkew(ctx context.Context, in *pb.ProxySkewRequest, opts ...grpc.CallOption) (*pb.ProxySkewResponse, error) {
+	return c.ProxySkewResponseToReturn, c.ErrorToReturn
+}
+
 func (c *MockApiClient) Tap(ctx context.Context, in *pb.TapRequest, opts ...grpc.CallOption) (pb.Api_TapClient, error) {
 	return c.Api_TapClientToReturn, c.ErrorToReturn
 }
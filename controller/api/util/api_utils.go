@@ -14,6 +14,7 @@ import (
 	"k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 /*
@@ -59,18 +60,24 @@ type StatSummaryRequestParams struct {
 	FromType      string
 	FromName      string
 	AllNamespaces bool
+	LabelSelector string
+	PerPod        bool
+	BreakdownBy   string
+	DebugQueries  bool
 }
 
 type TapRequestParams struct {
-	Resource    string
-	Namespace   string
-	ToResource  string
-	ToNamespace string
-	MaxRps      float32
-	Scheme      string
-	Method      string
-	Authority   string
-	Path        string
+	Resource     string
+	Namespace    string
+	ToResource   string
+	ToNamespace  string
+	ToIdentity   string
+	FromIdentity string
+	MaxRps       float32
+	Scheme       string
+	Method       string
+	Authority    string
+	Path         string
 }
 
 // GRPCError generates a gRPC error code, as defined in
@@ -131,6 +138,21 @@ func BuildStatSummaryRequest(p StatSummaryRequestParams) (*pb.StatSummaryRequest
 		return nil, err
 	}
 
+	if p.LabelSelector != "" {
+		_, err := labels.Parse(p.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector \"%s\": %s", p.LabelSelector, err)
+		}
+	}
+
+	breakdownBy := ""
+	if p.BreakdownBy != "" {
+		breakdownBy, err = k8s.CanonicalResourceNameFromFriendlyName(p.BreakdownBy)
+		if err != nil || breakdownBy != k8s.Authority {
+			return nil, fmt.Errorf("stats can only be broken down by %s, not %s", k8s.Authority, p.BreakdownBy)
+		}
+	}
+
 	statRequest := &pb.StatSummaryRequest{
 		Selector: &pb.ResourceSelection{
 			Resource: &pb.Resource{
@@ -138,8 +160,23 @@ func BuildStatSummaryRequest(p StatSummaryRequestParams) (*pb.StatSummaryRequest
 				Name:      p.ResourceName,
 				Type:      resourceType,
 			},
+			LabelSelector: p.LabelSelector,
 		},
-		TimeWindow: window,
+		TimeWindow:   window,
+		PerPod:       p.PerPod,
+		BreakdownBy:  breakdownBy,
+		DebugQueries: p.DebugQueries,
+	}
+
+	// A namespace on its own, without a resource type or name, filters for all
+	// traffic to or from that namespace.
+	if p.ToName == "" && p.ToType == "" && p.ToNamespace != "" {
+		p.ToType = k8s.Namespace
+		p.ToName = p.ToNamespace
+	}
+	if p.FromName == "" && p.FromType == "" && p.FromNamespace != "" {
+		p.FromType = k8s.Namespace
+		p.FromName = p.FromNamespace
 	}
 
 	if p.ToName != "" || p.ToType != "" || p.ToNamespace != "" {
@@ -276,6 +313,32 @@ func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceReques
 		matches = append(matches, &match)
 	}
 
+	if params.ToIdentity != "" {
+		if _, err := k8s.ParseTLSIdentity(params.ToIdentity); err != nil {
+			return nil, fmt.Errorf("destination identity invalid: %s", err)
+		}
+
+		match := pb.TapByResourceRequest_Match{
+			Match: &pb.TapByResourceRequest_Match_DestinationIdentity{
+				DestinationIdentity: params.ToIdentity,
+			},
+		}
+		matches = append(matches, &match)
+	}
+
+	if params.FromIdentity != "" {
+		if _, err := k8s.ParseTLSIdentity(params.FromIdentity); err != nil {
+			return nil, fmt.Errorf("source identity invalid: %s", err)
+		}
+
+		match := pb.TapByResourceRequest_Match{
+			Match: &pb.TapByResourceRequest_Match_SourceIdentity{
+				SourceIdentity: params.FromIdentity,
+			},
+		}
+		matches = append(matches, &match)
+	}
+
 	if params.Scheme != "" {
 		match := buildMatchHTTP(&pb.TapByResourceRequest_Match_Http{
 			Match: &pb.TapByResourceRequest_Match_Http_Scheme{Scheme: params.Scheme},
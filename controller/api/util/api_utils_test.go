@@ -107,6 +107,81 @@ func TestBuildStatSummaryRequest(t *testing.T) {
 		}
 	})
 
+	t.Run("Builds namespace filters from a namespace without a resource", func(t *testing.T) {
+		statSummaryRequest, err := BuildStatSummaryRequest(
+			StatSummaryRequestParams{
+				ResourceType: k8s.Deployment,
+				Namespace:    "ingress-nginx",
+				ToNamespace:  "team-a",
+			},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error from BuildStatSummaryRequest: %s", err)
+		}
+		expected := &pb.Resource{Namespace: "team-a", Type: k8s.Namespace, Name: "team-a"}
+		if !reflect.DeepEqual(statSummaryRequest.GetToResource(), expected) {
+			t.Fatalf("Unexpected ToResource from BuildStatSummaryRequest: %+v, expected %+v", statSummaryRequest.GetToResource(), expected)
+		}
+
+		statSummaryRequest, err = BuildStatSummaryRequest(
+			StatSummaryRequestParams{
+				ResourceType:  k8s.Deployment,
+				Namespace:     "team-a",
+				FromNamespace: "ingress-nginx",
+			},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error from BuildStatSummaryRequest: %s", err)
+		}
+		expected = &pb.Resource{Namespace: "ingress-nginx", Type: k8s.Namespace, Name: "ingress-nginx"}
+		if !reflect.DeepEqual(statSummaryRequest.GetFromResource(), expected) {
+			t.Fatalf("Unexpected FromResource from BuildStatSummaryRequest: %+v, expected %+v", statSummaryRequest.GetFromResource(), expected)
+		}
+	})
+
+	t.Run("Rejects invalid label selectors", func(t *testing.T) {
+		_, err := BuildStatSummaryRequest(
+			StatSummaryRequestParams{
+				ResourceType:  k8s.Deployment,
+				LabelSelector: "app in (checkout",
+			},
+		)
+		if err == nil {
+			t.Fatal("Expected BuildStatSummaryRequest to fail for an invalid label selector")
+		}
+	})
+
+	t.Run("Builds authority breakdowns", func(t *testing.T) {
+		for _, by := range []string{"authority", "authorities", "au"} {
+			statSummaryRequest, err := BuildStatSummaryRequest(
+				StatSummaryRequestParams{
+					ResourceType: k8s.Deployment,
+					BreakdownBy:  by,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unexpected error from BuildStatSummaryRequest [%s]: %s", by, err)
+			}
+			if statSummaryRequest.BreakdownBy != k8s.Authority {
+				t.Fatalf("Unexpected BreakdownBy from BuildStatSummaryRequest [%s]: %s", by, statSummaryRequest.BreakdownBy)
+			}
+		}
+
+		_, err := BuildStatSummaryRequest(
+			StatSummaryRequestParams{
+				ResourceType: k8s.Deployment,
+				BreakdownBy:  "route",
+			},
+		)
+		if err == nil {
+			t.Fatal("Expected BuildStatSummaryRequest to fail for a route breakdown")
+		}
+		expectedMsg := "stats can only be broken down by authority, not route"
+		if err.Error() != expectedMsg {
+			t.Fatalf("Expected error [%s], got [%s]", expectedMsg, err)
+		}
+	})
+
 	t.Run("Rejects invalid Kubernetes resource types", func(t *testing.T) {
 		expectations := map[string]string{
 			"foo": "cannot find Kubernetes canonical name from friendly name [foo]",
@@ -197,3 +272,47 @@ func TestBuildResource(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildTapByResourceRequest(t *testing.T) {
+	t.Run("Adds matches for peer identities", func(t *testing.T) {
+		req, err := BuildTapByResourceRequest(TapRequestParams{
+			Resource:     "deploy/books",
+			Namespace:    "booksapp",
+			ToIdentity:   "authors.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+			FromIdentity: "webapp.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := []*pb.TapByResourceRequest_Match{
+			&pb.TapByResourceRequest_Match{
+				Match: &pb.TapByResourceRequest_Match_DestinationIdentity{
+					DestinationIdentity: "authors.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+				},
+			},
+			&pb.TapByResourceRequest_Match{
+				Match: &pb.TapByResourceRequest_Match_SourceIdentity{
+					SourceIdentity: "webapp.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+				},
+			},
+		}
+
+		matches := req.GetMatch().GetAll().GetMatches()
+		if !reflect.DeepEqual(matches, expected) {
+			t.Fatalf("Expected matches to be [%+v] but was [%+v]", expected, matches)
+		}
+	})
+
+	t.Run("Rejects invalid identities", func(t *testing.T) {
+		_, err := BuildTapByResourceRequest(TapRequestParams{
+			Resource:     "deploy/books",
+			Namespace:    "booksapp",
+			FromIdentity: "webapp.booksapp.svc.cluster.local",
+		})
+		expected := "source identity invalid: invalid TLS identity: webapp.booksapp.svc.cluster.local"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s] but got [%v]", expected, err)
+		}
+	})
+}
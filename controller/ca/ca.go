@@ -6,7 +6,10 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/binary"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"math/big"
 	"time"
 )
@@ -104,12 +107,77 @@ func NewCA() (*CA, error) {
 	return &ca, nil
 }
 
+// NewCAFromPEM creates a CA that issues certificates with the given
+// PEM-encoded CA certificate and ECDSA private key, e.g. an issuer managed
+// outside of Linkerd. The certificate is also the trust anchor distributed to
+// the proxies.
+func NewCAFromPEM(certPEM, keyPEM []byte) (*CA, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+	root, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if !root.IsCA {
+		return nil, errors.New("certificate is not a CA certificate")
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("no PEM-encoded private key found")
+	}
+	privateKey, err := parsePrivateKey(keyBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := root.PublicKey.(*ecdsa.PublicKey)
+	if !ok || publicKey.X.Cmp(privateKey.X) != 0 || publicKey.Y.Cmp(privateKey.Y) != 0 {
+		return nil, errors.New("private key doesn't match the certificate")
+	}
+
+	// Certificates issued by a previous instance of the CA with the same
+	// issuer may still be in use, so serial numbers can't restart from 1.
+	var serial [8]byte
+	if _, err := rand.Read(serial[:]); err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		validity:           (24 * 365) * time.Hour,
+		clockSkewAllocance: 12 * time.Hour,
+		privateKey:         privateKey,
+		root:               root,
+		rootPEM:            string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw})),
+		nextSerialNumber:   binary.BigEndian.Uint64(serial[:]) >> 1,
+	}, nil
+}
+
 // TrustAnchorDER returns the PEM-encoded X.509 certificate of the trust anchor
 // (root CA).
 func (ca *CA) TrustAnchorPEM() string {
 	return ca.rootPEM
 }
 
+// CheckValidity returns an error if the CA's certificate isn't valid at the
+// given time, in which case the certificates it issues won't be trusted.
+func (ca *CA) CheckValidity(now time.Time) error {
+	if now.Before(ca.root.NotBefore) {
+		return fmt.Errorf("CA certificate is not valid before %s", ca.root.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(ca.root.NotAfter) {
+		return fmt.Errorf("CA certificate expired at %s", ca.root.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Expiry returns the time at which the CA's certificate expires.
+func (ca *CA) Expiry() time.Time {
+	return ca.root.NotAfter
+}
+
 // IssueEndEntityCertificate creates a new certificate that is valid for the
 // given DNS name, generating a new keypair for it.
 func (ca *CA) IssueEndEntityCertificate(dnsName string) (*CertificateAndPrivateKey, error) {
@@ -165,6 +233,24 @@ func (ca *CA) createTemplate(publicKey *ecdsa.PublicKey) x509.Certificate {
 	}
 }
 
+// parsePrivateKey parses an ECDSA private key in either SEC 1 ("EC PRIVATE
+// KEY") or PKCS#8 ("PRIVATE KEY") form.
+func parsePrivateKey(block *pem.Block) (*ecdsa.PrivateKey, error) {
+	if block.Type == "EC PRIVATE KEY" {
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an ECDSA key")
+	}
+	return privateKey, nil
+}
+
 func generateKeyPair() (*ecdsa.PrivateKey, error) {
 	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 }
@@ -3,6 +3,7 @@ package ca
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
@@ -11,8 +12,12 @@ import (
 	"k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -20,9 +25,18 @@ import (
 type CertificateController struct {
 	namespace   string
 	k8sAPI      *k8s.API
-	ca          *CA
 	syncHandler func(key string) error
 
+	// ca is replaced when the issuer secret changes.
+	ca     *CA
+	caLock sync.RWMutex
+
+	// issuerSecret is the name of the secret in the controller namespace
+	// holding the CA's certificate and private key. If it's empty the CA is
+	// generated when the controller starts.
+	issuerSecret   string
+	issuerInformer cache.Controller
+
 	// The queue is keyed on a string. If the string doesn't contain any dots
 	// then it is a namespace name and the task is to create the CA bundle
 	// configmap in that namespace. Otherwise the string must be of the form
@@ -31,20 +45,36 @@ type CertificateController struct {
 	queue workqueue.RateLimitingInterface
 }
 
-func NewCertificateController(controllerNamespace string, k8sAPI *k8s.API) (*CertificateController, error) {
-	ca, err := NewCA()
-	if err != nil {
-		return nil, err
-	}
-
+func NewCertificateController(controllerNamespace, issuerSecret string, k8sAPI *k8s.API) (*CertificateController, error) {
 	c := &CertificateController{
-		namespace: controllerNamespace,
-		k8sAPI:    k8sAPI,
-		ca:        ca,
+		namespace:    controllerNamespace,
+		k8sAPI:       k8sAPI,
+		issuerSecret: issuerSecret,
 		queue: workqueue.NewNamedRateLimitingQueue(
 			workqueue.DefaultControllerRateLimiter(), "certificates"),
 	}
 
+	if issuerSecret == "" {
+		ca, err := NewCA()
+		if err != nil {
+			return nil, err
+		}
+		c.ca = ca
+	} else {
+		secret, err := k8sAPI.Client.CoreV1().Secrets(controllerNamespace).Get(issuerSecret, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read issuer secret %s/%s: %s", controllerNamespace, issuerSecret, err)
+		}
+		c.ca, err = caFromSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		c.issuerInformer = c.newIssuerInformer()
+	}
+
+	observeCAExpiry(c.ca)
+
 	k8sAPI.Pod().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handlePodAdd,
@@ -66,11 +96,100 @@ func (c *CertificateController) Run(readyCh <-chan struct{}, stopCh <-chan struc
 	log.Info("starting certificate controller")
 	defer log.Info("shutting down certificate controller")
 
+	if c.issuerInformer != nil {
+		go c.issuerInformer.Run(stopCh)
+	}
+
 	go wait.Until(c.worker, time.Second, stopCh)
 
 	<-stopCh
 }
 
+// CheckCertificate reports whether the controller's CA certificate is
+// currently valid. It's exposed as a subsystem check on the admin server.
+func (c *CertificateController) CheckCertificate() error {
+	return c.getCA().CheckValidity(time.Now())
+}
+
+func (c *CertificateController) getCA() *CA {
+	c.caLock.RLock()
+	defer c.caLock.RUnlock()
+	return c.ca
+}
+
+// newIssuerInformer watches the issuer secret, so that a rotated issuer is
+// picked up without restarting the controller.
+func (c *CertificateController) newIssuerInformer() cache.Controller {
+	selector := fields.OneTermEqualSelector("metadata.name", c.issuerSecret).String()
+	secrets := c.k8sAPI.Client.CoreV1().Secrets(c.namespace)
+
+	_, informer := cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (k8sRuntime.Object, error) {
+				options.FieldSelector = selector
+				return secrets.List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.FieldSelector = selector
+				return secrets.Watch(options)
+			},
+		},
+		&v1.Secret{},
+		10*time.Minute,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.handleIssuerUpdate,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				c.handleIssuerUpdate(newObj)
+			},
+		},
+	)
+	return informer
+}
+
+// handleIssuerUpdate replaces the CA when the issuer secret's contents change,
+// and enqueues the redistribution of the trust anchors and the reissuance of
+// the certificates of all the meshed pods.
+func (c *CertificateController) handleIssuerUpdate(obj interface{}) {
+	secret := obj.(*v1.Secret)
+	if secret.Name != c.issuerSecret {
+		return
+	}
+
+	ca, err := caFromSecret(secret)
+	if err != nil {
+		log.Errorf("ignoring update of issuer secret: %s", err)
+		return
+	}
+	if ca.TrustAnchorPEM() == c.getCA().TrustAnchorPEM() {
+		return
+	}
+
+	log.Infof("issuer secret %s/%s changed, reissuing certificates", c.namespace, c.issuerSecret)
+	c.caLock.Lock()
+	c.ca = ca
+	c.caLock.Unlock()
+	observeCAExpiry(ca)
+
+	pods, err := c.k8sAPI.Pod().Lister().List(labels.Everything())
+	if err != nil {
+		log.Errorf("failed to list pods: %s", err)
+		return
+	}
+	for _, pod := range pods {
+		c.handlePodAdd(pod)
+	}
+}
+
+// caFromSecret returns a CA for the PEM-encoded certificate and private key in
+// a secret of type kubernetes.io/tls.
+func caFromSecret(secret *v1.Secret) (*CA, error) {
+	ca, err := NewCAFromPEM(secret.Data[v1.TLSCertKey], secret.Data[v1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer in secret %s/%s: %s", secret.Namespace, secret.Name, err)
+	}
+	return ca, nil
+}
+
 func (c *CertificateController) worker() {
 	for c.processNextWorkItem() {
 	}
@@ -107,7 +226,7 @@ func (c *CertificateController) syncNamespace(ns string) error {
 	configMap := &v1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{Name: pkgK8s.TLSTrustAnchorConfigMapName},
 		Data: map[string]string{
-			pkgK8s.TLSTrustAnchorFileName: c.ca.TrustAnchorPEM(),
+			pkgK8s.TLSTrustAnchorFileName: c.getCA().TrustAnchorPEM(),
 		},
 	}
 
@@ -136,7 +255,7 @@ func (c *CertificateController) syncSecret(key string) error {
 	}
 	dnsName := identity.ToDNSName()
 	secretName := identity.ToSecretName()
-	certAndPrivateKey, err := c.ca.IssueEndEntityCertificate(dnsName)
+	certAndPrivateKey, err := c.getCA().IssueEndEntityCertificate(dnsName)
 	if err != nil {
 		log.Errorf("Failed to issue certificate for %s", dnsName)
 		return err
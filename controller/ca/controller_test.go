@@ -1,6 +1,9 @@
 package ca
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"testing"
 	"time"
@@ -61,13 +64,99 @@ func TestCertificateController(t *testing.T) {
 	})
 }
 
+func TestCertificateControllerIssuerSecret(t *testing.T) {
+	issuer, err := NewCA()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rotatedIssuer, err := NewCA()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	k8sAPI, err := k8s.NewFakeAPI(issuerSecretConfig(t, issuer))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	controller, err := NewCertificateController(controllerNS, "linkerd-issuer", k8sAPI)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if controller.getCA().TrustAnchorPEM() != issuer.TrustAnchorPEM() {
+		t.Fatal("Expected the CA to be loaded from the issuer secret")
+	}
+
+	k8sAPI.Pod().Informer().GetStore().Add(&v1.Pod{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      injectedPodName,
+			Namespace: injectedNS,
+			Labels: map[string]string{
+				pkgK8s.ControllerNSLabel: controllerNS,
+			},
+		},
+	})
+
+	t.Run("ignores invalid issuers", func(t *testing.T) {
+		controller.handleIssuerUpdate(&v1.Secret{
+			ObjectMeta: meta.ObjectMeta{Name: "linkerd-issuer", Namespace: controllerNS},
+			Data:       map[string][]byte{v1.TLSCertKey: []byte("bad")},
+		})
+
+		if controller.getCA().TrustAnchorPEM() != issuer.TrustAnchorPEM() {
+			t.Fatal("Expected the CA not to be replaced by an invalid issuer")
+		}
+		if controller.queue.Len() != 0 {
+			t.Fatalf("Expected no work to be enqueued, got %d items", controller.queue.Len())
+		}
+	})
+
+	t.Run("reissues certificates when the issuer changes", func(t *testing.T) {
+		certPEM, keyPEM := issuerPEM(t, rotatedIssuer)
+		controller.handleIssuerUpdate(&v1.Secret{
+			ObjectMeta: meta.ObjectMeta{Name: "linkerd-issuer", Namespace: controllerNS},
+			Data:       map[string][]byte{v1.TLSCertKey: certPEM, v1.TLSPrivateKeyKey: keyPEM},
+		})
+
+		if controller.getCA().TrustAnchorPEM() != rotatedIssuer.TrustAnchorPEM() {
+			t.Fatal("Expected the CA to be replaced by the rotated issuer")
+		}
+		// the trust anchors of the pod's namespace and the pod's certificate
+		if controller.queue.Len() != 2 {
+			t.Fatalf("Expected 2 items to be enqueued, got %d", controller.queue.Len())
+		}
+	})
+}
+
+func issuerPEM(t *testing.T, ca *CA) ([]byte, []byte) {
+	key, err := x509.MarshalECPrivateKey(ca.privateKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return []byte(ca.TrustAnchorPEM()), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})
+}
+
+func issuerSecretConfig(t *testing.T, ca *CA) string {
+	certPEM, keyPEM := issuerPEM(t, ca)
+	return fmt.Sprintf(`
+apiVersion: v1
+kind: Secret
+type: kubernetes.io/tls
+metadata:
+  name: linkerd-issuer
+  namespace: %s
+data:
+  tls.crt: %s
+  tls.key: %s`, controllerNS, base64.StdEncoding.EncodeToString(certPEM), base64.StdEncoding.EncodeToString(keyPEM))
+}
+
 func new(fixtures ...string) (*CertificateController, chan bool, chan struct{}, error) {
 	k8sAPI, err := k8s.NewFakeAPI(fixtures...)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("NewFakeAPI returned an error: %s", err)
 	}
 
-	controller, err := NewCertificateController(controllerNS, k8sAPI)
+	controller, err := NewCertificateController(controllerNS, "", k8sAPI)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("NewCertificateController returned an error: %s", err)
 	}
@@ -0,0 +1,22 @@
+package ca
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var caExpiry = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "ca_certificate_expiration_timestamp_seconds",
+		Help: "The time at which the certificate of the CA issuing the proxies' certificates expires, in seconds since the epoch.",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(caExpiry)
+}
+
+// observeCAExpiry records the expiry of the CA's certificate, which is
+// replaced when the issuer secret changes.
+func observeCAExpiry(ca *CA) {
+	caExpiry.Set(float64(ca.Expiry().Unix()))
+}
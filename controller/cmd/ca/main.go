@@ -13,10 +13,14 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const caLeaderLockName = "linkerd-ca-leader"
+
 func main() {
 	metricsAddr := flag.String("metrics-addr", ":9997", "address to serve scrapable metrics on")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
+	issuerSecret := flag.String("issuer-secret", "", "name of a kubernetes.io/tls secret in the controller namespace holding the certificate and private key of the CA issuing the proxies' certificates; changes to the secret are picked up without a restart. If unset, a new CA is generated on startup")
+	leaderElect := flag.Bool("leader-elect", true, "only run the CA in the replica holding the leader lock, so that multiple replicas don't issue certificates concurrently")
 	flags.ConfigureAndParse()
 
 	stop := make(chan os.Signal, 1)
@@ -32,7 +36,7 @@ func main() {
 		k8s.RS,
 	)
 
-	controller, err := ca.NewCertificateController(*controllerNamespace, k8sAPI)
+	controller, err := ca.NewCertificateController(*controllerNamespace, *issuerSecret, k8sAPI)
 	if err != nil {
 		log.Fatalf("Failed to create CertificateController: %v", err)
 	}
@@ -43,11 +47,26 @@ func main() {
 	go k8sAPI.Sync(ready)
 
 	go func() {
-		log.Info("starting CA")
-		controller.Run(ready, stopCh)
+		if !*leaderElect {
+			log.Info("starting CA")
+			controller.Run(ready, stopCh)
+			return
+		}
+
+		err := k8s.RunWithLeaderElection(k8sClient, *controllerNamespace, caLeaderLockName, func(leaderStopCh <-chan struct{}) {
+			log.Info("starting CA")
+			controller.Run(ready, leaderStopCh)
+		})
+		if err != nil {
+			log.Fatalf("Failed to run CA with leader election: %s", err)
+		}
 	}()
 
-	go admin.StartServer(*metricsAddr, ready)
+	go admin.StartServer(*metricsAddr, ready, admin.Check{
+		Name:     "certificate",
+		Critical: true,
+		Run:      controller.CheckCertificate,
+	})
 
 	<-stop
 
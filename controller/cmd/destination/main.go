@@ -10,6 +10,7 @@ import (
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -19,8 +20,14 @@ func main() {
 	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
 	k8sDNSZone := flag.String("kubernetes-dns-zone", "", "The DNS suffix for the local Kubernetes zone.")
 	enableTLS := flag.Bool("enable-tls", false, "Enable TLS connections among pods in the service mesh")
+	traceCollector := flag.String("trace-collector", "", "address (host:port) of the Zipkin receiver of the collector to export spans to, e.g. collector.linkerd-jaeger:9411 (default: tracing disabled)")
+	traceSamplingRate := flag.Float64("trace-sampling-rate", 1, "fraction of requests to trace, between 0 and 1")
 	flags.ConfigureAndParse()
 
+	if err := tracing.InitializeTracing("linkerd-destination", *traceCollector, *traceSamplingRate); err != nil {
+		log.Fatal(err.Error())
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
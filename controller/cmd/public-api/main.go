@@ -7,13 +7,16 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/tap"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/tracing"
 	promApi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,8 +28,14 @@ func main() {
 	tapAddr := flag.String("tap-addr", "127.0.0.1:8088", "address of tap service")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	ignoredNamespaces := flag.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
+	traceCollector := flag.String("trace-collector", "", "address (host:port) of the Zipkin receiver of the collector to export spans to, e.g. collector.linkerd-jaeger:9411 (default: tracing disabled)")
+	traceSamplingRate := flag.Float64("trace-sampling-rate", 1, "fraction of requests to trace, between 0 and 1")
 	flags.ConfigureAndParse()
 
+	if err := tracing.InitializeTracing("linkerd-public-api", *traceCollector, *traceSamplingRate); err != nil {
+		log.Fatal(err.Error())
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
@@ -42,6 +51,7 @@ func main() {
 	}
 	k8sAPI := k8s.NewAPI(
 		k8sClient,
+		k8s.CM,
 		k8s.Deploy,
 		k8s.NS,
 		k8s.Pod,
@@ -73,7 +83,15 @@ func main() {
 		server.ListenAndServe()
 	}()
 
-	go admin.StartServer(*metricsAddr, ready)
+	go admin.StartServer(*metricsAddr, ready, admin.Check{
+		Name: "prometheus",
+		Run: func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := promv1.NewAPI(prometheusClient).Query(ctx, "vector(1)", time.Now())
+			return err
+		},
+	})
 
 	<-stop
 
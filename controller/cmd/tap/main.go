@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/tap"
@@ -19,6 +20,9 @@ func main() {
 	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	tapPort := flag.Uint("tap-port", 4190, "proxy tap port to connect to")
+	maxRps := flag.Uint("max-rps", 1000, "maximum total requests per second tapped by a session (0 for no limit)")
+	maxPods := flag.Uint("max-tapped-pods", 100, "maximum number of pods tapped by a session, sampled at random when the target has more (0 for no limit)")
+	maxDuration := flag.Duration("max-duration", time.Hour, "maximum duration of a session (0 for no limit)")
 	flags.ConfigureAndParse()
 
 	stop := make(chan os.Signal, 1)
@@ -38,7 +42,7 @@ func main() {
 		k8s.RS,
 	)
 
-	server, lis, err := tap.NewServer(*addr, *tapPort, *controllerNamespace, k8sAPI)
+	server, lis, err := tap.NewServer(*addr, *tapPort, *controllerNamespace, float32(*maxRps), *maxPods, *maxDuration, k8sAPI)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
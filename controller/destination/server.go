@@ -28,7 +28,8 @@ type server struct {
 // omitted, "default" is used as a default.append
 //
 // Addresses for the given destination are fetched from the Kubernetes Endpoints
-// API.
+// API. Destinations that aren't Kubernetes services are left to the proxy to
+// resolve with DNS.
 func NewServer(addr, k8sDNSZone string, enableTLS bool, k8sAPI *k8s.API, done chan struct{}) (*grpc.Server, net.Listener, error) {
 	resolvers, err := buildResolversList(k8sDNSZone, k8sAPI)
 	if err != nil {
@@ -104,7 +105,24 @@ func (s *server) streamResolutionUsingCorrectResolverFor(host string, port int,
 			return resolver.streamResolution(host, port, listener)
 		}
 	}
-	return fmt.Errorf("cannot find resolver for host [%s] port [%d]", host, port)
+	return streamDNSFallback(host, port, listener)
+}
+
+// streamDNSFallback handles destinations that none of the resolvers know
+// about, such as hosts outside of the cluster or names that aren't backed by a
+// Kubernetes service. Instead of failing the lookup, it tells the proxy that
+// the destination doesn't exist, which makes the proxy fall back to resolving
+// the name with DNS and forwarding to it as an unmeshed endpoint. The stream is
+// held open until the proxy closes it, so that it doesn't keep retrying.
+func streamDNSFallback(host string, port int, listener updateListener) error {
+	log.Debugf("No resolver found for host [%s] port [%d], falling back to DNS", host, port)
+	listener.NoEndpoints(false)
+
+	select {
+	case <-listener.ClientClose():
+	case <-listener.ServerClose():
+	}
+	return nil
 }
 
 func buildResolversList(k8sDNSZone string, k8sAPI *k8s.API) ([]streamingDestinationResolver, error) {
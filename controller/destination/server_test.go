@@ -122,7 +122,7 @@ func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
 		}
 	})
 
-	t.Run("Returns error if no resolver can resolve", func(t *testing.T) {
+	t.Run("Falls back to DNS if no resolver can resolve", func(t *testing.T) {
 		no := &mockStreamingDestinationResolver{canResolveToReturn: false}
 
 		server := server{
@@ -130,9 +130,26 @@ func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
 			resolvers: []streamingDestinationResolver{no, no, no, no},
 		}
 
+		ctx, cancelFn := context.WithCancel(context.Background())
+		cancelFn()
+		stream := &mockDestination_GetServer{contextToReturn: ctx}
+
 		err := server.streamResolutionUsingCorrectResolverFor(host, port, stream)
-		if err == nil {
-			t.Fatalf("Expecting error, got nothing")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if no.listenerReceived != nil {
+			t.Fatalf("Expected handler [%+v] to not be called, but it was", no)
+		}
+
+		if len(stream.updatesReceived) != 1 {
+			t.Fatalf("Expecting [1] update, got [%d]: %v", len(stream.updatesReceived), stream.updatesReceived)
+		}
+
+		noEndpoints, ok := stream.updatesReceived[0].Update.(*pb.Update_NoEndpoints)
+		if !ok || noEndpoints.NoEndpoints.Exists {
+			t.Fatalf("Expecting a NoEndpoints update for a destination that doesn't exist, got [%+v]", stream.updatesReceived[0])
 		}
 	})
 
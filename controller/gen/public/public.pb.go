@@ -66,7 +66,7 @@ func (x HttpMethod_Registered) String() string {
 	return proto.EnumName(HttpMethod_Registered_name, int32(x))
 }
 func (HttpMethod_Registered) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{7, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{15, 0}
 }
 
 type Scheme_Registered int32
@@ -89,7 +89,7 @@ func (x Scheme_Registered) String() string {
 	return proto.EnumName(Scheme_Registered_name, int32(x))
 }
 func (Scheme_Registered) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{8, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{16, 0}
 }
 
 type TapEvent_ProxyDirection int32
@@ -115,7 +115,7 @@ func (x TapEvent_ProxyDirection) String() string {
 	return proto.EnumName(TapEvent_ProxyDirection_name, int32(x))
 }
 func (TapEvent_ProxyDirection) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{21, 0}
 }
 
 type Empty struct {
@@ -128,7 +128,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{0}
+	return fileDescriptor_public_292ab771697df96b, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -161,7 +161,7 @@ func (m *VersionInfo) Reset()         { *m = VersionInfo{} }
 func (m *VersionInfo) String() string { return proto.CompactTextString(m) }
 func (*VersionInfo) ProtoMessage()    {}
 func (*VersionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{1}
+	return fileDescriptor_public_292ab771697df96b, []int{1}
 }
 func (m *VersionInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionInfo.Unmarshal(m, b)
@@ -213,7 +213,7 @@ func (m *ListPodsRequest) Reset()         { *m = ListPodsRequest{} }
 func (m *ListPodsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListPodsRequest) ProtoMessage()    {}
 func (*ListPodsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{2}
+	return fileDescriptor_public_292ab771697df96b, []int{2}
 }
 func (m *ListPodsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListPodsRequest.Unmarshal(m, b)
@@ -251,7 +251,7 @@ func (m *ListPodsResponse) Reset()         { *m = ListPodsResponse{} }
 func (m *ListPodsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListPodsResponse) ProtoMessage()    {}
 func (*ListPodsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{3}
+	return fileDescriptor_public_292ab771697df96b, []int{3}
 }
 func (m *ListPodsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListPodsResponse.Unmarshal(m, b)
@@ -278,6 +278,374 @@ func (m *ListPodsResponse) GetPods() []*Pod {
 	return nil
 }
 
+type MeshCoverageRequest struct {
+	// If empty, coverage is reported for all namespaces.
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MeshCoverageRequest) Reset()         { *m = MeshCoverageRequest{} }
+func (m *MeshCoverageRequest) String() string { return proto.CompactTextString(m) }
+func (*MeshCoverageRequest) ProtoMessage()    {}
+func (*MeshCoverageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{4}
+}
+func (m *MeshCoverageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeshCoverageRequest.Unmarshal(m, b)
+}
+func (m *MeshCoverageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeshCoverageRequest.Marshal(b, m, deterministic)
+}
+func (dst *MeshCoverageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeshCoverageRequest.Merge(dst, src)
+}
+func (m *MeshCoverageRequest) XXX_Size() int {
+	return xxx_messageInfo_MeshCoverageRequest.Size(m)
+}
+func (m *MeshCoverageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeshCoverageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeshCoverageRequest proto.InternalMessageInfo
+
+func (m *MeshCoverageRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type MeshCoverageResponse struct {
+	Namespaces           []*NamespaceCoverage `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MeshCoverageResponse) Reset()         { *m = MeshCoverageResponse{} }
+func (m *MeshCoverageResponse) String() string { return proto.CompactTextString(m) }
+func (*MeshCoverageResponse) ProtoMessage()    {}
+func (*MeshCoverageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{5}
+}
+func (m *MeshCoverageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeshCoverageResponse.Unmarshal(m, b)
+}
+func (m *MeshCoverageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeshCoverageResponse.Marshal(b, m, deterministic)
+}
+func (dst *MeshCoverageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeshCoverageResponse.Merge(dst, src)
+}
+func (m *MeshCoverageResponse) XXX_Size() int {
+	return xxx_messageInfo_MeshCoverageResponse.Size(m)
+}
+func (m *MeshCoverageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeshCoverageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeshCoverageResponse proto.InternalMessageInfo
+
+func (m *MeshCoverageResponse) GetNamespaces() []*NamespaceCoverage {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+type NamespaceCoverage struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// number of pending or running pods that have linkerd injected
+	MeshedPodCount uint64 `protobuf:"varint,2,opt,name=meshed_pod_count,json=meshedPodCount,proto3" json:"meshed_pod_count,omitempty"`
+	// number of pending or running pods that don't have linkerd injected
+	UnmeshedPodCount uint64 `protobuf:"varint,3,opt,name=unmeshed_pod_count,json=unmeshedPodCount,proto3" json:"unmeshed_pod_count,omitempty"`
+	// workloads owning at least one pending or running pod without linkerd
+	UnmeshedWorkloads []*Resource `protobuf:"bytes,4,rep,name=unmeshed_workloads,json=unmeshedWorkloads,proto3" json:"unmeshed_workloads,omitempty"`
+	// number of workloads owning at least one pending or running pod that has
+	// linkerd injected
+	MeshedWorkloadCount uint64 `protobuf:"varint,5,opt,name=meshed_workload_count,json=meshedWorkloadCount,proto3" json:"meshed_workload_count,omitempty"`
+	// number of meshed pods running each proxy version, sorted by version
+	ProxyVersions        []*ProxyVersionCount `protobuf:"bytes,6,rep,name=proxy_versions,json=proxyVersions,proto3" json:"proxy_versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *NamespaceCoverage) Reset()         { *m = NamespaceCoverage{} }
+func (m *NamespaceCoverage) String() string { return proto.CompactTextString(m) }
+func (*NamespaceCoverage) ProtoMessage()    {}
+func (*NamespaceCoverage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{6}
+}
+func (m *NamespaceCoverage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamespaceCoverage.Unmarshal(m, b)
+}
+func (m *NamespaceCoverage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamespaceCoverage.Marshal(b, m, deterministic)
+}
+func (dst *NamespaceCoverage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceCoverage.Merge(dst, src)
+}
+func (m *NamespaceCoverage) XXX_Size() int {
+	return xxx_messageInfo_NamespaceCoverage.Size(m)
+}
+func (m *NamespaceCoverage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceCoverage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceCoverage proto.InternalMessageInfo
+
+func (m *NamespaceCoverage) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *NamespaceCoverage) GetMeshedPodCount() uint64 {
+	if m != nil {
+		return m.MeshedPodCount
+	}
+	return 0
+}
+
+func (m *NamespaceCoverage) GetUnmeshedPodCount() uint64 {
+	if m != nil {
+		return m.UnmeshedPodCount
+	}
+	return 0
+}
+
+func (m *NamespaceCoverage) GetUnmeshedWorkloads() []*Resource {
+	if m != nil {
+		return m.UnmeshedWorkloads
+	}
+	return nil
+}
+
+func (m *NamespaceCoverage) GetMeshedWorkloadCount() uint64 {
+	if m != nil {
+		return m.MeshedWorkloadCount
+	}
+	return 0
+}
+
+func (m *NamespaceCoverage) GetProxyVersions() []*ProxyVersionCount {
+	if m != nil {
+		return m.ProxyVersions
+	}
+	return nil
+}
+
+type ProxyVersionCount struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	PodCount             uint64   `protobuf:"varint,2,opt,name=pod_count,json=podCount,proto3" json:"pod_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProxyVersionCount) Reset()         { *m = ProxyVersionCount{} }
+func (m *ProxyVersionCount) String() string { return proto.CompactTextString(m) }
+func (*ProxyVersionCount) ProtoMessage()    {}
+func (*ProxyVersionCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{7}
+}
+func (m *ProxyVersionCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProxyVersionCount.Unmarshal(m, b)
+}
+func (m *ProxyVersionCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProxyVersionCount.Marshal(b, m, deterministic)
+}
+func (dst *ProxyVersionCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProxyVersionCount.Merge(dst, src)
+}
+func (m *ProxyVersionCount) XXX_Size() int {
+	return xxx_messageInfo_ProxyVersionCount.Size(m)
+}
+func (m *ProxyVersionCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProxyVersionCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProxyVersionCount proto.InternalMessageInfo
+
+func (m *ProxyVersionCount) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ProxyVersionCount) GetPodCount() uint64 {
+	if m != nil {
+		return m.PodCount
+	}
+	return 0
+}
+
+type ListNamespacesRequest struct {
+	// Window over which traffic stats are reported, e.g. "1m". If empty, no
+	// traffic stats are reported.
+	TimeWindow           string   `protobuf:"bytes,1,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListNamespacesRequest) Reset()         { *m = ListNamespacesRequest{} }
+func (m *ListNamespacesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListNamespacesRequest) ProtoMessage()    {}
+func (*ListNamespacesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{8}
+}
+func (m *ListNamespacesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNamespacesRequest.Unmarshal(m, b)
+}
+func (m *ListNamespacesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNamespacesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListNamespacesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNamespacesRequest.Merge(dst, src)
+}
+func (m *ListNamespacesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListNamespacesRequest.Size(m)
+}
+func (m *ListNamespacesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNamespacesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNamespacesRequest proto.InternalMessageInfo
+
+func (m *ListNamespacesRequest) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+type ListNamespacesResponse struct {
+	Namespaces           []*NamespaceSummary `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListNamespacesResponse) Reset()         { *m = ListNamespacesResponse{} }
+func (m *ListNamespacesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListNamespacesResponse) ProtoMessage()    {}
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{9}
+}
+func (m *ListNamespacesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNamespacesResponse.Unmarshal(m, b)
+}
+func (m *ListNamespacesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNamespacesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListNamespacesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNamespacesResponse.Merge(dst, src)
+}
+func (m *ListNamespacesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListNamespacesResponse.Size(m)
+}
+func (m *ListNamespacesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNamespacesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNamespacesResponse proto.InternalMessageInfo
+
+func (m *ListNamespacesResponse) GetNamespaces() []*NamespaceSummary {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+type NamespaceSummary struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// number of pending or running pods that have linkerd injected
+	MeshedPodCount uint64 `protobuf:"varint,2,opt,name=meshed_pod_count,json=meshedPodCount,proto3" json:"meshed_pod_count,omitempty"`
+	// number of pending or running pods that don't have linkerd injected
+	UnmeshedPodCount uint64 `protobuf:"varint,3,opt,name=unmeshed_pod_count,json=unmeshedPodCount,proto3" json:"unmeshed_pod_count,omitempty"`
+	// number of failed pods
+	FailedPodCount uint64 `protobuf:"varint,4,opt,name=failed_pod_count,json=failedPodCount,proto3" json:"failed_pod_count,omitempty"`
+	// value of the namespace's linkerd.io/inject annotation, if any
+	ProxyInject string `protobuf:"bytes,5,opt,name=proxy_inject,json=proxyInject,proto3" json:"proxy_inject,omitempty"`
+	// inbound traffic stats of the namespace's pods over the time window
+	Stats                *BasicStats `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *NamespaceSummary) Reset()         { *m = NamespaceSummary{} }
+func (m *NamespaceSummary) String() string { return proto.CompactTextString(m) }
+func (*NamespaceSummary) ProtoMessage()    {}
+func (*NamespaceSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{10}
+}
+func (m *NamespaceSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamespaceSummary.Unmarshal(m, b)
+}
+func (m *NamespaceSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamespaceSummary.Marshal(b, m, deterministic)
+}
+func (dst *NamespaceSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceSummary.Merge(dst, src)
+}
+func (m *NamespaceSummary) XXX_Size() int {
+	return xxx_messageInfo_NamespaceSummary.Size(m)
+}
+func (m *NamespaceSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceSummary proto.InternalMessageInfo
+
+func (m *NamespaceSummary) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NamespaceSummary) GetMeshedPodCount() uint64 {
+	if m != nil {
+		return m.MeshedPodCount
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetUnmeshedPodCount() uint64 {
+	if m != nil {
+		return m.UnmeshedPodCount
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetFailedPodCount() uint64 {
+	if m != nil {
+		return m.FailedPodCount
+	}
+	return 0
+}
+
+func (m *NamespaceSummary) GetProxyInject() string {
+	if m != nil {
+		return m.ProxyInject
+	}
+	return ""
+}
+
+func (m *NamespaceSummary) GetStats() *BasicStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
 type Pod struct {
 	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	PodIP string `protobuf:"bytes,2,opt,name=podIP,proto3" json:"podIP,omitempty"`
@@ -297,6 +665,7 @@ type Pod struct {
 	Uptime               *duration.Duration `protobuf:"bytes,9,opt,name=uptime,proto3" json:"uptime,omitempty"`
 	ProxyReady           bool               `protobuf:"varint,15,opt,name=proxyReady,proto3" json:"proxyReady,omitempty"`
 	ProxyVersion         string             `protobuf:"bytes,16,opt,name=proxyVersion,proto3" json:"proxyVersion,omitempty"`
+	ProxyConfig          *ProxyConfig       `protobuf:"bytes,17,opt,name=proxyConfig,proto3" json:"proxyConfig,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -306,7 +675,7 @@ func (m *Pod) Reset()         { *m = Pod{} }
 func (m *Pod) String() string { return proto.CompactTextString(m) }
 func (*Pod) ProtoMessage()    {}
 func (*Pod) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{4}
+	return fileDescriptor_public_292ab771697df96b, []int{11}
 }
 func (m *Pod) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pod.Unmarshal(m, b)
@@ -485,6 +854,13 @@ func (m *Pod) GetProxyVersion() string {
 	return ""
 }
 
+func (m *Pod) GetProxyConfig() *ProxyConfig {
+	if m != nil {
+		return m.ProxyConfig
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Pod) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Pod_OneofMarshaler, _Pod_OneofUnmarshaler, _Pod_OneofSizer, []interface{}{
@@ -611,93 +987,235 @@ func _Pod_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
-// Deprecated: Do not use.
-type TapRequest struct {
-	// Types that are valid to be assigned to Target:
-	//	*TapRequest_Pod
-	//	*TapRequest_Deployment
-	Target isTapRequest_Target `protobuf_oneof:"target"`
-	// validation of these fields happens on the server
-	MaxRps               float32  `protobuf:"fixed32,3,opt,name=maxRps,proto3" json:"maxRps,omitempty"`
-	ToPort               uint32   `protobuf:"varint,4,opt,name=toPort,proto3" json:"toPort,omitempty"`
-	ToIP                 string   `protobuf:"bytes,5,opt,name=toIP,proto3" json:"toIP,omitempty"`
-	FromPort             uint32   `protobuf:"varint,6,opt,name=fromPort,proto3" json:"fromPort,omitempty"`
-	FromIP               string   `protobuf:"bytes,7,opt,name=fromIP,proto3" json:"fromIP,omitempty"`
-	Scheme               string   `protobuf:"bytes,8,opt,name=scheme,proto3" json:"scheme,omitempty"`
-	Method               string   `protobuf:"bytes,9,opt,name=method,proto3" json:"method,omitempty"`
-	Authority            string   `protobuf:"bytes,10,opt,name=authority,proto3" json:"authority,omitempty"`
-	Path                 string   `protobuf:"bytes,11,opt,name=path,proto3" json:"path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// ProxyConfig is the effective configuration of a pod's proxy, as injected
+// into its spec, including the values overridden by annotations.
+type ProxyConfig struct {
+	Version  string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	LogLevel string `protobuf:"bytes,2,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
+	// "init-container" if the pod's networking is configured by the
+	// linkerd-init container, "unknown" otherwise
+	InjectMode string `protobuf:"bytes,3,opt,name=inject_mode,json=injectMode,proto3" json:"inject_mode,omitempty"`
+	// comma-separated lists of the ports that aren't redirected to the proxy
+	InboundSkipPorts  string `protobuf:"bytes,4,opt,name=inbound_skip_ports,json=inboundSkipPorts,proto3" json:"inbound_skip_ports,omitempty"`
+	OutboundSkipPorts string `protobuf:"bytes,5,opt,name=outbound_skip_ports,json=outboundSkipPorts,proto3" json:"outbound_skip_ports,omitempty"`
+	// comma-separated list of the subnets that aren't redirected to the proxy
+	OutboundSkipSubnets string `protobuf:"bytes,6,opt,name=outbound_skip_subnets,json=outboundSkipSubnets,proto3" json:"outbound_skip_subnets,omitempty"`
+	// comma-separated list of the ports on which protocol detection is skipped
+	OpaquePorts   string `protobuf:"bytes,7,opt,name=opaque_ports,json=opaquePorts,proto3" json:"opaque_ports,omitempty"`
+	Tls           bool   `protobuf:"varint,8,opt,name=tls,proto3" json:"tls,omitempty"`
+	CpuRequest    string `protobuf:"bytes,9,opt,name=cpu_request,json=cpuRequest,proto3" json:"cpu_request,omitempty"`
+	CpuLimit      string `protobuf:"bytes,10,opt,name=cpu_limit,json=cpuLimit,proto3" json:"cpu_limit,omitempty"`
+	MemoryRequest string `protobuf:"bytes,11,opt,name=memory_request,json=memoryRequest,proto3" json:"memory_request,omitempty"`
+	MemoryLimit   string `protobuf:"bytes,12,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
+	// linkerd.io annotations of the pod that override the injected defaults
+	Overrides            map[string]string `protobuf:"bytes,13,rep,name=overrides,proto3" json:"overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *TapRequest) Reset()         { *m = TapRequest{} }
-func (m *TapRequest) String() string { return proto.CompactTextString(m) }
-func (*TapRequest) ProtoMessage()    {}
-func (*TapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{5}
+func (m *ProxyConfig) Reset()         { *m = ProxyConfig{} }
+func (m *ProxyConfig) String() string { return proto.CompactTextString(m) }
+func (*ProxyConfig) ProtoMessage()    {}
+func (*ProxyConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{12}
 }
-func (m *TapRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TapRequest.Unmarshal(m, b)
+func (m *ProxyConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProxyConfig.Unmarshal(m, b)
 }
-func (m *TapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TapRequest.Marshal(b, m, deterministic)
+func (m *ProxyConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProxyConfig.Marshal(b, m, deterministic)
 }
-func (dst *TapRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TapRequest.Merge(dst, src)
+func (dst *ProxyConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProxyConfig.Merge(dst, src)
 }
-func (m *TapRequest) XXX_Size() int {
-	return xxx_messageInfo_TapRequest.Size(m)
+func (m *ProxyConfig) XXX_Size() int {
+	return xxx_messageInfo_ProxyConfig.Size(m)
 }
-func (m *TapRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TapRequest.DiscardUnknown(m)
+func (m *ProxyConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProxyConfig.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TapRequest proto.InternalMessageInfo
+var xxx_messageInfo_ProxyConfig proto.InternalMessageInfo
 
-type isTapRequest_Target interface {
-	isTapRequest_Target()
+func (m *ProxyConfig) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
 }
 
-type TapRequest_Pod struct {
-	Pod string `protobuf:"bytes,1,opt,name=pod,proto3,oneof"`
+func (m *ProxyConfig) GetLogLevel() string {
+	if m != nil {
+		return m.LogLevel
+	}
+	return ""
 }
 
-type TapRequest_Deployment struct {
-	Deployment string `protobuf:"bytes,2,opt,name=deployment,proto3,oneof"`
+func (m *ProxyConfig) GetInjectMode() string {
+	if m != nil {
+		return m.InjectMode
+	}
+	return ""
 }
 
-func (*TapRequest_Pod) isTapRequest_Target() {}
-
-func (*TapRequest_Deployment) isTapRequest_Target() {}
-
-func (m *TapRequest) GetTarget() isTapRequest_Target {
+func (m *ProxyConfig) GetInboundSkipPorts() string {
 	if m != nil {
-		return m.Target
+		return m.InboundSkipPorts
 	}
-	return nil
+	return ""
 }
 
-func (m *TapRequest) GetPod() string {
-	if x, ok := m.GetTarget().(*TapRequest_Pod); ok {
-		return x.Pod
+func (m *ProxyConfig) GetOutboundSkipPorts() string {
+	if m != nil {
+		return m.OutboundSkipPorts
 	}
 	return ""
 }
 
-func (m *TapRequest) GetDeployment() string {
-	if x, ok := m.GetTarget().(*TapRequest_Deployment); ok {
-		return x.Deployment
+func (m *ProxyConfig) GetOutboundSkipSubnets() string {
+	if m != nil {
+		return m.OutboundSkipSubnets
 	}
 	return ""
 }
 
-func (m *TapRequest) GetMaxRps() float32 {
+func (m *ProxyConfig) GetOpaquePorts() string {
 	if m != nil {
-		return m.MaxRps
+		return m.OpaquePorts
 	}
-	return 0
+	return ""
+}
+
+func (m *ProxyConfig) GetTls() bool {
+	if m != nil {
+		return m.Tls
+	}
+	return false
+}
+
+func (m *ProxyConfig) GetCpuRequest() string {
+	if m != nil {
+		return m.CpuRequest
+	}
+	return ""
+}
+
+func (m *ProxyConfig) GetCpuLimit() string {
+	if m != nil {
+		return m.CpuLimit
+	}
+	return ""
+}
+
+func (m *ProxyConfig) GetMemoryRequest() string {
+	if m != nil {
+		return m.MemoryRequest
+	}
+	return ""
+}
+
+func (m *ProxyConfig) GetMemoryLimit() string {
+	if m != nil {
+		return m.MemoryLimit
+	}
+	return ""
+}
+
+func (m *ProxyConfig) GetOverrides() map[string]string {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
+}
+
+// Deprecated: Do not use.
+type TapRequest struct {
+	// Types that are valid to be assigned to Target:
+	//	*TapRequest_Pod
+	//	*TapRequest_Deployment
+	Target isTapRequest_Target `protobuf_oneof:"target"`
+	// validation of these fields happens on the server
+	MaxRps               float32  `protobuf:"fixed32,3,opt,name=maxRps,proto3" json:"maxRps,omitempty"`
+	ToPort               uint32   `protobuf:"varint,4,opt,name=toPort,proto3" json:"toPort,omitempty"`
+	ToIP                 string   `protobuf:"bytes,5,opt,name=toIP,proto3" json:"toIP,omitempty"`
+	FromPort             uint32   `protobuf:"varint,6,opt,name=fromPort,proto3" json:"fromPort,omitempty"`
+	FromIP               string   `protobuf:"bytes,7,opt,name=fromIP,proto3" json:"fromIP,omitempty"`
+	Scheme               string   `protobuf:"bytes,8,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Method               string   `protobuf:"bytes,9,opt,name=method,proto3" json:"method,omitempty"`
+	Authority            string   `protobuf:"bytes,10,opt,name=authority,proto3" json:"authority,omitempty"`
+	Path                 string   `protobuf:"bytes,11,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TapRequest) Reset()         { *m = TapRequest{} }
+func (m *TapRequest) String() string { return proto.CompactTextString(m) }
+func (*TapRequest) ProtoMessage()    {}
+func (*TapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{13}
+}
+func (m *TapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TapRequest.Unmarshal(m, b)
+}
+func (m *TapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TapRequest.Marshal(b, m, deterministic)
+}
+func (dst *TapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TapRequest.Merge(dst, src)
+}
+func (m *TapRequest) XXX_Size() int {
+	return xxx_messageInfo_TapRequest.Size(m)
+}
+func (m *TapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TapRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TapRequest proto.InternalMessageInfo
+
+type isTapRequest_Target interface {
+	isTapRequest_Target()
+}
+
+type TapRequest_Pod struct {
+	Pod string `protobuf:"bytes,1,opt,name=pod,proto3,oneof"`
+}
+
+type TapRequest_Deployment struct {
+	Deployment string `protobuf:"bytes,2,opt,name=deployment,proto3,oneof"`
+}
+
+func (*TapRequest_Pod) isTapRequest_Target() {}
+
+func (*TapRequest_Deployment) isTapRequest_Target() {}
+
+func (m *TapRequest) GetTarget() isTapRequest_Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+func (m *TapRequest) GetPod() string {
+	if x, ok := m.GetTarget().(*TapRequest_Pod); ok {
+		return x.Pod
+	}
+	return ""
+}
+
+func (m *TapRequest) GetDeployment() string {
+	if x, ok := m.GetTarget().(*TapRequest_Deployment); ok {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (m *TapRequest) GetMaxRps() float32 {
+	if m != nil {
+		return m.MaxRps
+	}
+	return 0
 }
 
 func (m *TapRequest) GetToPort() uint32 {
@@ -839,7 +1357,7 @@ func (m *TapByResourceRequest) Reset()         { *m = TapByResourceRequest{} }
 func (m *TapByResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest) ProtoMessage()    {}
 func (*TapByResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{6}
+	return fileDescriptor_public_292ab771697df96b, []int{14}
 }
 func (m *TapByResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest.Unmarshal(m, b)
@@ -887,6 +1405,8 @@ type TapByResourceRequest_Match struct {
 	//	*TapByResourceRequest_Match_Not
 	//	*TapByResourceRequest_Match_Destinations
 	//	*TapByResourceRequest_Match_Http_
+	//	*TapByResourceRequest_Match_SourceIdentity
+	//	*TapByResourceRequest_Match_DestinationIdentity
 	Match                isTapByResourceRequest_Match_Match `protobuf_oneof:"match"`
 	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
 	XXX_unrecognized     []byte                             `json:"-"`
@@ -897,7 +1417,7 @@ func (m *TapByResourceRequest_Match) Reset()         { *m = TapByResourceRequest
 func (m *TapByResourceRequest_Match) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest_Match) ProtoMessage()    {}
 func (*TapByResourceRequest_Match) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{6, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{14, 0}
 }
 func (m *TapByResourceRequest_Match) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest_Match.Unmarshal(m, b)
@@ -941,6 +1461,14 @@ type TapByResourceRequest_Match_Http_ struct {
 	Http *TapByResourceRequest_Match_Http `protobuf:"bytes,5,opt,name=http,proto3,oneof"`
 }
 
+type TapByResourceRequest_Match_SourceIdentity struct {
+	SourceIdentity string `protobuf:"bytes,6,opt,name=source_identity,json=sourceIdentity,proto3,oneof"`
+}
+
+type TapByResourceRequest_Match_DestinationIdentity struct {
+	DestinationIdentity string `protobuf:"bytes,7,opt,name=destination_identity,json=destinationIdentity,proto3,oneof"`
+}
+
 func (*TapByResourceRequest_Match_All) isTapByResourceRequest_Match_Match() {}
 
 func (*TapByResourceRequest_Match_Any) isTapByResourceRequest_Match_Match() {}
@@ -951,6 +1479,10 @@ func (*TapByResourceRequest_Match_Destinations) isTapByResourceRequest_Match_Mat
 
 func (*TapByResourceRequest_Match_Http_) isTapByResourceRequest_Match_Match() {}
 
+func (*TapByResourceRequest_Match_SourceIdentity) isTapByResourceRequest_Match_Match() {}
+
+func (*TapByResourceRequest_Match_DestinationIdentity) isTapByResourceRequest_Match_Match() {}
+
 func (m *TapByResourceRequest_Match) GetMatch() isTapByResourceRequest_Match_Match {
 	if m != nil {
 		return m.Match
@@ -993,6 +1525,20 @@ func (m *TapByResourceRequest_Match) GetHttp() *TapByResourceRequest_Match_Http
 	return nil
 }
 
+func (m *TapByResourceRequest_Match) GetSourceIdentity() string {
+	if x, ok := m.GetMatch().(*TapByResourceRequest_Match_SourceIdentity); ok {
+		return x.SourceIdentity
+	}
+	return ""
+}
+
+func (m *TapByResourceRequest_Match) GetDestinationIdentity() string {
+	if x, ok := m.GetMatch().(*TapByResourceRequest_Match_DestinationIdentity); ok {
+		return x.DestinationIdentity
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TapByResourceRequest_Match) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TapByResourceRequest_Match_OneofMarshaler, _TapByResourceRequest_Match_OneofUnmarshaler, _TapByResourceRequest_Match_OneofSizer, []interface{}{
@@ -1001,6 +1547,8 @@ func (*TapByResourceRequest_Match) XXX_OneofFuncs() (func(msg proto.Message, b *
 		(*TapByResourceRequest_Match_Not)(nil),
 		(*TapByResourceRequest_Match_Destinations)(nil),
 		(*TapByResourceRequest_Match_Http_)(nil),
+		(*TapByResourceRequest_Match_SourceIdentity)(nil),
+		(*TapByResourceRequest_Match_DestinationIdentity)(nil),
 	}
 }
 
@@ -1033,6 +1581,12 @@ func _TapByResourceRequest_Match_OneofMarshaler(msg proto.Message, b *proto.Buff
 		if err := b.EncodeMessage(x.Http); err != nil {
 			return err
 		}
+	case *TapByResourceRequest_Match_SourceIdentity:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.SourceIdentity)
+	case *TapByResourceRequest_Match_DestinationIdentity:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.DestinationIdentity)
 	case nil:
 	default:
 		return fmt.Errorf("TapByResourceRequest_Match.Match has unexpected type %T", x)
@@ -1083,6 +1637,20 @@ func _TapByResourceRequest_Match_OneofUnmarshaler(msg proto.Message, tag, wire i
 		err := b.DecodeMessage(msg)
 		m.Match = &TapByResourceRequest_Match_Http_{msg}
 		return true, err
+	case 6: // match.source_identity
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Match = &TapByResourceRequest_Match_SourceIdentity{x}
+		return true, err
+	case 7: // match.destination_identity
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Match = &TapByResourceRequest_Match_DestinationIdentity{x}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1117,6 +1685,14 @@ func _TapByResourceRequest_Match_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TapByResourceRequest_Match_SourceIdentity:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.SourceIdentity)))
+		n += len(x.SourceIdentity)
+	case *TapByResourceRequest_Match_DestinationIdentity:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.DestinationIdentity)))
+		n += len(x.DestinationIdentity)
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1135,7 +1711,7 @@ func (m *TapByResourceRequest_Match_Seq) Reset()         { *m = TapByResourceReq
 func (m *TapByResourceRequest_Match_Seq) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest_Match_Seq) ProtoMessage()    {}
 func (*TapByResourceRequest_Match_Seq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{6, 0, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{14, 0, 0}
 }
 func (m *TapByResourceRequest_Match_Seq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest_Match_Seq.Unmarshal(m, b)
@@ -1178,7 +1754,7 @@ func (m *TapByResourceRequest_Match_Http) Reset()         { *m = TapByResourceRe
 func (m *TapByResourceRequest_Match_Http) String() string { return proto.CompactTextString(m) }
 func (*TapByResourceRequest_Match_Http) ProtoMessage()    {}
 func (*TapByResourceRequest_Match_Http) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{6, 0, 1}
+	return fileDescriptor_public_292ab771697df96b, []int{14, 0, 1}
 }
 func (m *TapByResourceRequest_Match_Http) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapByResourceRequest_Match_Http.Unmarshal(m, b)
@@ -1371,7 +1947,7 @@ func (m *HttpMethod) Reset()         { *m = HttpMethod{} }
 func (m *HttpMethod) String() string { return proto.CompactTextString(m) }
 func (*HttpMethod) ProtoMessage()    {}
 func (*HttpMethod) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{7}
+	return fileDescriptor_public_292ab771697df96b, []int{15}
 }
 func (m *HttpMethod) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HttpMethod.Unmarshal(m, b)
@@ -1507,7 +2083,7 @@ func (m *Scheme) Reset()         { *m = Scheme{} }
 func (m *Scheme) String() string { return proto.CompactTextString(m) }
 func (*Scheme) ProtoMessage()    {}
 func (*Scheme) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{8}
+	return fileDescriptor_public_292ab771697df96b, []int{16}
 }
 func (m *Scheme) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Scheme.Unmarshal(m, b)
@@ -1643,7 +2219,7 @@ func (m *IPAddress) Reset()         { *m = IPAddress{} }
 func (m *IPAddress) String() string { return proto.CompactTextString(m) }
 func (*IPAddress) ProtoMessage()    {}
 func (*IPAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{9}
+	return fileDescriptor_public_292ab771697df96b, []int{17}
 }
 func (m *IPAddress) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IPAddress.Unmarshal(m, b)
@@ -1781,7 +2357,7 @@ func (m *IPv6) Reset()         { *m = IPv6{} }
 func (m *IPv6) String() string { return proto.CompactTextString(m) }
 func (*IPv6) ProtoMessage()    {}
 func (*IPv6) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{10}
+	return fileDescriptor_public_292ab771697df96b, []int{18}
 }
 func (m *IPv6) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IPv6.Unmarshal(m, b)
@@ -1827,7 +2403,7 @@ func (m *TcpAddress) Reset()         { *m = TcpAddress{} }
 func (m *TcpAddress) String() string { return proto.CompactTextString(m) }
 func (*TcpAddress) ProtoMessage()    {}
 func (*TcpAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{11}
+	return fileDescriptor_public_292ab771697df96b, []int{19}
 }
 func (m *TcpAddress) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TcpAddress.Unmarshal(m, b)
@@ -1875,7 +2451,7 @@ func (m *Eos) Reset()         { *m = Eos{} }
 func (m *Eos) String() string { return proto.CompactTextString(m) }
 func (*Eos) ProtoMessage()    {}
 func (*Eos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{12}
+	return fileDescriptor_public_292ab771697df96b, []int{20}
 }
 func (m *Eos) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Eos.Unmarshal(m, b)
@@ -2014,7 +2590,7 @@ func (m *TapEvent) Reset()         { *m = TapEvent{} }
 func (m *TapEvent) String() string { return proto.CompactTextString(m) }
 func (*TapEvent) ProtoMessage()    {}
 func (*TapEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13}
+	return fileDescriptor_public_292ab771697df96b, []int{21}
 }
 func (m *TapEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent.Unmarshal(m, b)
@@ -2159,7 +2735,7 @@ func (m *TapEvent_EndpointMeta) Reset()         { *m = TapEvent_EndpointMeta{} }
 func (m *TapEvent_EndpointMeta) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_EndpointMeta) ProtoMessage()    {}
 func (*TapEvent_EndpointMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{21, 0}
 }
 func (m *TapEvent_EndpointMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_EndpointMeta.Unmarshal(m, b)
@@ -2201,7 +2777,7 @@ func (m *TapEvent_Http) Reset()         { *m = TapEvent_Http{} }
 func (m *TapEvent_Http) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http) ProtoMessage()    {}
 func (*TapEvent_Http) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13, 1}
+	return fileDescriptor_public_292ab771697df96b, []int{21, 1}
 }
 func (m *TapEvent_Http) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http.Unmarshal(m, b)
@@ -2378,7 +2954,7 @@ func (m *TapEvent_Http_StreamId) Reset()         { *m = TapEvent_Http_StreamId{}
 func (m *TapEvent_Http_StreamId) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_StreamId) ProtoMessage()    {}
 func (*TapEvent_Http_StreamId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13, 1, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{21, 1, 0}
 }
 func (m *TapEvent_Http_StreamId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_StreamId.Unmarshal(m, b)
@@ -2427,7 +3003,7 @@ func (m *TapEvent_Http_RequestInit) Reset()         { *m = TapEvent_Http_Request
 func (m *TapEvent_Http_RequestInit) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_RequestInit) ProtoMessage()    {}
 func (*TapEvent_Http_RequestInit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13, 1, 1}
+	return fileDescriptor_public_292ab771697df96b, []int{21, 1, 1}
 }
 func (m *TapEvent_Http_RequestInit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_RequestInit.Unmarshal(m, b)
@@ -2495,7 +3071,7 @@ func (m *TapEvent_Http_ResponseInit) Reset()         { *m = TapEvent_Http_Respon
 func (m *TapEvent_Http_ResponseInit) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_ResponseInit) ProtoMessage()    {}
 func (*TapEvent_Http_ResponseInit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13, 1, 2}
+	return fileDescriptor_public_292ab771697df96b, []int{21, 1, 2}
 }
 func (m *TapEvent_Http_ResponseInit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_ResponseInit.Unmarshal(m, b)
@@ -2551,7 +3127,7 @@ func (m *TapEvent_Http_ResponseEnd) Reset()         { *m = TapEvent_Http_Respons
 func (m *TapEvent_Http_ResponseEnd) String() string { return proto.CompactTextString(m) }
 func (*TapEvent_Http_ResponseEnd) ProtoMessage()    {}
 func (*TapEvent_Http_ResponseEnd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{13, 1, 3}
+	return fileDescriptor_public_292ab771697df96b, []int{21, 1, 3}
 }
 func (m *TapEvent_Http_ResponseEnd) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TapEvent_Http_ResponseEnd.Unmarshal(m, b)
@@ -2617,7 +3193,7 @@ func (m *ApiError) Reset()         { *m = ApiError{} }
 func (m *ApiError) String() string { return proto.CompactTextString(m) }
 func (*ApiError) ProtoMessage()    {}
 func (*ApiError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{14}
+	return fileDescriptor_public_292ab771697df96b, []int{22}
 }
 func (m *ApiError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApiError.Unmarshal(m, b)
@@ -2655,7 +3231,7 @@ func (m *PodErrors) Reset()         { *m = PodErrors{} }
 func (m *PodErrors) String() string { return proto.CompactTextString(m) }
 func (*PodErrors) ProtoMessage()    {}
 func (*PodErrors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{15}
+	return fileDescriptor_public_292ab771697df96b, []int{23}
 }
 func (m *PodErrors) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PodErrors.Unmarshal(m, b)
@@ -2695,7 +3271,7 @@ func (m *PodErrors_PodError) Reset()         { *m = PodErrors_PodError{} }
 func (m *PodErrors_PodError) String() string { return proto.CompactTextString(m) }
 func (*PodErrors_PodError) ProtoMessage()    {}
 func (*PodErrors_PodError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{15, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{23, 0}
 }
 func (m *PodErrors_PodError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PodErrors_PodError.Unmarshal(m, b)
@@ -2809,7 +3385,7 @@ func (m *PodErrors_PodError_ContainerError) Reset()         { *m = PodErrors_Pod
 func (m *PodErrors_PodError_ContainerError) String() string { return proto.CompactTextString(m) }
 func (*PodErrors_PodError_ContainerError) ProtoMessage()    {}
 func (*PodErrors_PodError_ContainerError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{15, 0, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{23, 0, 0}
 }
 func (m *PodErrors_PodError_ContainerError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PodErrors_PodError_ContainerError.Unmarshal(m, b)
@@ -2880,7 +3456,7 @@ func (m *Resource) Reset()         { *m = Resource{} }
 func (m *Resource) String() string { return proto.CompactTextString(m) }
 func (*Resource) ProtoMessage()    {}
 func (*Resource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{16}
+	return fileDescriptor_public_292ab771697df96b, []int{24}
 }
 func (m *Resource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Resource.Unmarshal(m, b)
@@ -2939,7 +3515,7 @@ func (m *ResourceSelection) Reset()         { *m = ResourceSelection{} }
 func (m *ResourceSelection) String() string { return proto.CompactTextString(m) }
 func (*ResourceSelection) ProtoMessage()    {}
 func (*ResourceSelection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{17}
+	return fileDescriptor_public_292ab771697df96b, []int{25}
 }
 func (m *ResourceSelection) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResourceSelection.Unmarshal(m, b)
@@ -2985,7 +3561,7 @@ func (m *ResourceError) Reset()         { *m = ResourceError{} }
 func (m *ResourceError) String() string { return proto.CompactTextString(m) }
 func (*ResourceError) ProtoMessage()    {}
 func (*ResourceError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{18}
+	return fileDescriptor_public_292ab771697df96b, []int{26}
 }
 func (m *ResourceError) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ResourceError.Unmarshal(m, b)
@@ -3026,17 +3602,28 @@ type StatSummaryRequest struct {
 	//	*StatSummaryRequest_None
 	//	*StatSummaryRequest_ToResource
 	//	*StatSummaryRequest_FromResource
-	Outbound             isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	Outbound isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
+	// If set, the response includes a second table with a row for each pod
+	// backing the selected resources.
+	PerPod bool `protobuf:"varint,6,opt,name=per_pod,json=perPod,proto3" json:"per_pod,omitempty"`
+	// If set to "authority", the response includes a second table breaking down
+	// the outbound traffic of each selected resource by the authority it was
+	// sent to. Each row of that table has its `parent` set to the selected
+	// resource it belongs to.
+	BreakdownBy string `protobuf:"bytes,7,opt,name=breakdown_by,json=breakdownBy,proto3" json:"breakdown_by,omitempty"`
+	// If set, the response includes the Prometheus queries run to compute the
+	// stats, along with their execution times.
+	DebugQueries         bool     `protobuf:"varint,8,opt,name=debug_queries,json=debugQueries,proto3" json:"debug_queries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StatSummaryRequest) Reset()         { *m = StatSummaryRequest{} }
 func (m *StatSummaryRequest) String() string { return proto.CompactTextString(m) }
 func (*StatSummaryRequest) ProtoMessage()    {}
 func (*StatSummaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{19}
+	return fileDescriptor_public_292ab771697df96b, []int{27}
 }
 func (m *StatSummaryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummaryRequest.Unmarshal(m, b)
@@ -3120,6 +3707,27 @@ func (m *StatSummaryRequest) GetFromResource() *Resource {
 	return nil
 }
 
+func (m *StatSummaryRequest) GetPerPod() bool {
+	if m != nil {
+		return m.PerPod
+	}
+	return false
+}
+
+func (m *StatSummaryRequest) GetBreakdownBy() string {
+	if m != nil {
+		return m.BreakdownBy
+	}
+	return ""
+}
+
+func (m *StatSummaryRequest) GetDebugQueries() bool {
+	if m != nil {
+		return m.DebugQueries
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*StatSummaryRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _StatSummaryRequest_OneofMarshaler, _StatSummaryRequest_OneofUnmarshaler, _StatSummaryRequest_OneofSizer, []interface{}{
@@ -3227,7 +3835,7 @@ func (m *StatSummaryResponse) Reset()         { *m = StatSummaryResponse{} }
 func (m *StatSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*StatSummaryResponse) ProtoMessage()    {}
 func (*StatSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{20}
+	return fileDescriptor_public_292ab771697df96b, []int{28}
 }
 func (m *StatSummaryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummaryResponse.Unmarshal(m, b)
@@ -3359,17 +3967,20 @@ func _StatSummaryResponse_OneofSizer(msg proto.Message) (n int) {
 }
 
 type StatSummaryResponse_Ok struct {
-	StatTables           []*StatTable `protobuf:"bytes,1,rep,name=stat_tables,json=statTables,proto3" json:"stat_tables,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	StatTables []*StatTable `protobuf:"bytes,1,rep,name=stat_tables,json=statTables,proto3" json:"stat_tables,omitempty"`
+	// The Prometheus queries run for the request, sorted by query, if
+	// `debug_queries` was set.
+	Queries              []*PrometheusQuery `protobuf:"bytes,2,rep,name=queries,proto3" json:"queries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *StatSummaryResponse_Ok) Reset()         { *m = StatSummaryResponse_Ok{} }
 func (m *StatSummaryResponse_Ok) String() string { return proto.CompactTextString(m) }
 func (*StatSummaryResponse_Ok) ProtoMessage()    {}
 func (*StatSummaryResponse_Ok) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{20, 0}
+	return fileDescriptor_public_292ab771697df96b, []int{28, 0}
 }
 func (m *StatSummaryResponse_Ok) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummaryResponse_Ok.Unmarshal(m, b)
@@ -3396,6 +4007,70 @@ func (m *StatSummaryResponse_Ok) GetStatTables() []*StatTable {
 	return nil
 }
 
+func (m *StatSummaryResponse_Ok) GetQueries() []*PrometheusQuery {
+	if m != nil {
+		return m.Queries
+	}
+	return nil
+}
+
+type PrometheusQuery struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// time taken to run the query, including the time spent waiting for other
+	// queries to complete when too many run concurrently
+	Duration *duration.Duration `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	// set if the query failed
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrometheusQuery) Reset()         { *m = PrometheusQuery{} }
+func (m *PrometheusQuery) String() string { return proto.CompactTextString(m) }
+func (*PrometheusQuery) ProtoMessage()    {}
+func (*PrometheusQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{29}
+}
+func (m *PrometheusQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrometheusQuery.Unmarshal(m, b)
+}
+func (m *PrometheusQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrometheusQuery.Marshal(b, m, deterministic)
+}
+func (dst *PrometheusQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrometheusQuery.Merge(dst, src)
+}
+func (m *PrometheusQuery) XXX_Size() int {
+	return xxx_messageInfo_PrometheusQuery.Size(m)
+}
+func (m *PrometheusQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrometheusQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrometheusQuery proto.InternalMessageInfo
+
+func (m *PrometheusQuery) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *PrometheusQuery) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *PrometheusQuery) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type BasicStats struct {
 	SuccessCount         uint64   `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
 	FailureCount         uint64   `protobuf:"varint,2,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
@@ -3412,7 +4087,7 @@ func (m *BasicStats) Reset()         { *m = BasicStats{} }
 func (m *BasicStats) String() string { return proto.CompactTextString(m) }
 func (*BasicStats) ProtoMessage()    {}
 func (*BasicStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{21}
+	return fileDescriptor_public_292ab771697df96b, []int{30}
 }
 func (m *BasicStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BasicStats.Unmarshal(m, b)
@@ -3487,7 +4162,7 @@ func (m *StatTable) Reset()         { *m = StatTable{} }
 func (m *StatTable) String() string { return proto.CompactTextString(m) }
 func (*StatTable) ProtoMessage()    {}
 func (*StatTable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{22}
+	return fileDescriptor_public_292ab771697df96b, []int{31}
 }
 func (m *StatTable) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatTable.Unmarshal(m, b)
@@ -3570,146 +4245,1121 @@ func _StatTable_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buff
 	}
 }
 
-func _StatTable_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*StatTable)
-	// table
-	switch x := m.Table.(type) {
-	case *StatTable_PodGroup_:
-		s := proto.Size(x.PodGroup)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+func _StatTable_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*StatTable)
+	// table
+	switch x := m.Table.(type) {
+	case *StatTable_PodGroup_:
+		s := proto.Size(x.PodGroup)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type StatTable_PodGroup struct {
+	Rows                 []*StatTable_PodGroup_Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *StatTable_PodGroup) Reset()         { *m = StatTable_PodGroup{} }
+func (m *StatTable_PodGroup) String() string { return proto.CompactTextString(m) }
+func (*StatTable_PodGroup) ProtoMessage()    {}
+func (*StatTable_PodGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{31, 0}
+}
+func (m *StatTable_PodGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatTable_PodGroup.Unmarshal(m, b)
+}
+func (m *StatTable_PodGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatTable_PodGroup.Marshal(b, m, deterministic)
+}
+func (dst *StatTable_PodGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatTable_PodGroup.Merge(dst, src)
+}
+func (m *StatTable_PodGroup) XXX_Size() int {
+	return xxx_messageInfo_StatTable_PodGroup.Size(m)
+}
+func (m *StatTable_PodGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatTable_PodGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatTable_PodGroup proto.InternalMessageInfo
+
+func (m *StatTable_PodGroup) GetRows() []*StatTable_PodGroup_Row {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type StatTable_PodGroup_Row struct {
+	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	// number of pending or running pods in this resource that have linkerd injected
+	MeshedPodCount uint64 `protobuf:"varint,3,opt,name=meshed_pod_count,json=meshedPodCount,proto3" json:"meshed_pod_count,omitempty"`
+	// number of pending or running pods in this resource
+	RunningPodCount uint64 `protobuf:"varint,4,opt,name=running_pod_count,json=runningPodCount,proto3" json:"running_pod_count,omitempty"`
+	// number of pods in this resource that have Phase PodFailed
+	FailedPodCount uint64      `protobuf:"varint,6,opt,name=failed_pod_count,json=failedPodCount,proto3" json:"failed_pod_count,omitempty"`
+	Stats          *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	// Stores a set of errors for each pod name. If a pod has no errors, it may be omitted.
+	ErrorsByPod map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// In a breakdown table, the selected resource whose traffic this row is
+	// a part of.
+	Parent               *Resource `protobuf:"bytes,8,opt,name=parent,proto3" json:"parent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *StatTable_PodGroup_Row) Reset()         { *m = StatTable_PodGroup_Row{} }
+func (m *StatTable_PodGroup_Row) String() string { return proto.CompactTextString(m) }
+func (*StatTable_PodGroup_Row) ProtoMessage()    {}
+func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{31, 0, 0}
+}
+func (m *StatTable_PodGroup_Row) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatTable_PodGroup_Row.Unmarshal(m, b)
+}
+func (m *StatTable_PodGroup_Row) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatTable_PodGroup_Row.Marshal(b, m, deterministic)
+}
+func (dst *StatTable_PodGroup_Row) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatTable_PodGroup_Row.Merge(dst, src)
+}
+func (m *StatTable_PodGroup_Row) XXX_Size() int {
+	return xxx_messageInfo_StatTable_PodGroup_Row.Size(m)
+}
+func (m *StatTable_PodGroup_Row) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatTable_PodGroup_Row.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatTable_PodGroup_Row proto.InternalMessageInfo
+
+func (m *StatTable_PodGroup_Row) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *StatTable_PodGroup_Row) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+func (m *StatTable_PodGroup_Row) GetMeshedPodCount() uint64 {
+	if m != nil {
+		return m.MeshedPodCount
+	}
+	return 0
+}
+
+func (m *StatTable_PodGroup_Row) GetRunningPodCount() uint64 {
+	if m != nil {
+		return m.RunningPodCount
+	}
+	return 0
+}
+
+func (m *StatTable_PodGroup_Row) GetFailedPodCount() uint64 {
+	if m != nil {
+		return m.FailedPodCount
+	}
+	return 0
+}
+
+func (m *StatTable_PodGroup_Row) GetStats() *BasicStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *StatTable_PodGroup_Row) GetErrorsByPod() map[string]*PodErrors {
+	if m != nil {
+		return m.ErrorsByPod
+	}
+	return nil
+}
+
+func (m *StatTable_PodGroup_Row) GetParent() *Resource {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+type LatencyDistributionRequest struct {
+	// The resource whose inbound request latency is reported. Services are only
+	// supported as a `to_resource`.
+	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	// If set, the latency of the requests sent by `resource` to this resource
+	// is reported instead.
+	ToResource           *Resource `protobuf:"bytes,3,opt,name=to_resource,json=toResource,proto3" json:"to_resource,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *LatencyDistributionRequest) Reset()         { *m = LatencyDistributionRequest{} }
+func (m *LatencyDistributionRequest) String() string { return proto.CompactTextString(m) }
+func (*LatencyDistributionRequest) ProtoMessage()    {}
+func (*LatencyDistributionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{32}
+}
+func (m *LatencyDistributionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LatencyDistributionRequest.Unmarshal(m, b)
+}
+func (m *LatencyDistributionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LatencyDistributionRequest.Marshal(b, m, deterministic)
+}
+func (dst *LatencyDistributionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencyDistributionRequest.Merge(dst, src)
+}
+func (m *LatencyDistributionRequest) XXX_Size() int {
+	return xxx_messageInfo_LatencyDistributionRequest.Size(m)
+}
+func (m *LatencyDistributionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencyDistributionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencyDistributionRequest proto.InternalMessageInfo
+
+func (m *LatencyDistributionRequest) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *LatencyDistributionRequest) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+func (m *LatencyDistributionRequest) GetToResource() *Resource {
+	if m != nil {
+		return m.ToResource
+	}
+	return nil
+}
+
+type LatencyDistributionResponse struct {
+	// Types that are valid to be assigned to Response:
+	//	*LatencyDistributionResponse_Ok_
+	//	*LatencyDistributionResponse_Error
+	Response             isLatencyDistributionResponse_Response `protobuf_oneof:"response"`
+	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
+	XXX_unrecognized     []byte                                 `json:"-"`
+	XXX_sizecache        int32                                  `json:"-"`
+}
+
+func (m *LatencyDistributionResponse) Reset()         { *m = LatencyDistributionResponse{} }
+func (m *LatencyDistributionResponse) String() string { return proto.CompactTextString(m) }
+func (*LatencyDistributionResponse) ProtoMessage()    {}
+func (*LatencyDistributionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{33}
+}
+func (m *LatencyDistributionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LatencyDistributionResponse.Unmarshal(m, b)
+}
+func (m *LatencyDistributionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LatencyDistributionResponse.Marshal(b, m, deterministic)
+}
+func (dst *LatencyDistributionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencyDistributionResponse.Merge(dst, src)
+}
+func (m *LatencyDistributionResponse) XXX_Size() int {
+	return xxx_messageInfo_LatencyDistributionResponse.Size(m)
+}
+func (m *LatencyDistributionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencyDistributionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencyDistributionResponse proto.InternalMessageInfo
+
+type isLatencyDistributionResponse_Response interface {
+	isLatencyDistributionResponse_Response()
+}
+
+type LatencyDistributionResponse_Ok_ struct {
+	Ok *LatencyDistributionResponse_Ok `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type LatencyDistributionResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*LatencyDistributionResponse_Ok_) isLatencyDistributionResponse_Response() {}
+
+func (*LatencyDistributionResponse_Error) isLatencyDistributionResponse_Response() {}
+
+func (m *LatencyDistributionResponse) GetResponse() isLatencyDistributionResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *LatencyDistributionResponse) GetOk() *LatencyDistributionResponse_Ok {
+	if x, ok := m.GetResponse().(*LatencyDistributionResponse_Ok_); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (m *LatencyDistributionResponse) GetError() *ResourceError {
+	if x, ok := m.GetResponse().(*LatencyDistributionResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*LatencyDistributionResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _LatencyDistributionResponse_OneofMarshaler, _LatencyDistributionResponse_OneofUnmarshaler, _LatencyDistributionResponse_OneofSizer, []interface{}{
+		(*LatencyDistributionResponse_Ok_)(nil),
+		(*LatencyDistributionResponse_Error)(nil),
+	}
+}
+
+func _LatencyDistributionResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*LatencyDistributionResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *LatencyDistributionResponse_Ok_:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ok); err != nil {
+			return err
+		}
+	case *LatencyDistributionResponse_Error:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("LatencyDistributionResponse.Response has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _LatencyDistributionResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*LatencyDistributionResponse)
+	switch tag {
+	case 1: // response.ok
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LatencyDistributionResponse_Ok)
+		err := b.DecodeMessage(msg)
+		m.Response = &LatencyDistributionResponse_Ok_{msg}
+		return true, err
+	case 2: // response.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceError)
+		err := b.DecodeMessage(msg)
+		m.Response = &LatencyDistributionResponse_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _LatencyDistributionResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*LatencyDistributionResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *LatencyDistributionResponse_Ok_:
+		s := proto.Size(x.Ok)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *LatencyDistributionResponse_Error:
+		s := proto.Size(x.Error)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type LatencyDistributionResponse_Ok struct {
+	// The histogram's buckets, sorted by upper bound.
+	Buckets              []*LatencyBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *LatencyDistributionResponse_Ok) Reset()         { *m = LatencyDistributionResponse_Ok{} }
+func (m *LatencyDistributionResponse_Ok) String() string { return proto.CompactTextString(m) }
+func (*LatencyDistributionResponse_Ok) ProtoMessage()    {}
+func (*LatencyDistributionResponse_Ok) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{33, 0}
+}
+func (m *LatencyDistributionResponse_Ok) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LatencyDistributionResponse_Ok.Unmarshal(m, b)
+}
+func (m *LatencyDistributionResponse_Ok) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LatencyDistributionResponse_Ok.Marshal(b, m, deterministic)
+}
+func (dst *LatencyDistributionResponse_Ok) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencyDistributionResponse_Ok.Merge(dst, src)
+}
+func (m *LatencyDistributionResponse_Ok) XXX_Size() int {
+	return xxx_messageInfo_LatencyDistributionResponse_Ok.Size(m)
+}
+func (m *LatencyDistributionResponse_Ok) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencyDistributionResponse_Ok.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencyDistributionResponse_Ok proto.InternalMessageInfo
+
+func (m *LatencyDistributionResponse_Ok) GetBuckets() []*LatencyBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+type LatencyBucket struct {
+	// The bucket's inclusive upper bound, in milliseconds. The lower bound is
+	// the previous bucket's upper bound. The last bucket is unbounded and has
+	// an upper bound of +Inf.
+	UpperBoundMs float64 `protobuf:"fixed64,1,opt,name=upper_bound_ms,json=upperBoundMs,proto3" json:"upper_bound_ms,omitempty"`
+	// The number of requests whose latency fell in the bucket over the time
+	// window.
+	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LatencyBucket) Reset()         { *m = LatencyBucket{} }
+func (m *LatencyBucket) String() string { return proto.CompactTextString(m) }
+func (*LatencyBucket) ProtoMessage()    {}
+func (*LatencyBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{34}
+}
+func (m *LatencyBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LatencyBucket.Unmarshal(m, b)
+}
+func (m *LatencyBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LatencyBucket.Marshal(b, m, deterministic)
+}
+func (dst *LatencyBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencyBucket.Merge(dst, src)
+}
+func (m *LatencyBucket) XXX_Size() int {
+	return xxx_messageInfo_LatencyBucket.Size(m)
+}
+func (m *LatencyBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencyBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencyBucket proto.InternalMessageInfo
+
+func (m *LatencyBucket) GetUpperBoundMs() float64 {
+	if m != nil {
+		return m.UpperBoundMs
+	}
+	return 0
+}
+
+func (m *LatencyBucket) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type PodStatsRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TimeWindow           string   `protobuf:"bytes,3,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PodStatsRequest) Reset()         { *m = PodStatsRequest{} }
+func (m *PodStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*PodStatsRequest) ProtoMessage()    {}
+func (*PodStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{35}
+}
+func (m *PodStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodStatsRequest.Unmarshal(m, b)
+}
+func (m *PodStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodStatsRequest.Marshal(b, m, deterministic)
+}
+func (dst *PodStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodStatsRequest.Merge(dst, src)
+}
+func (m *PodStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_PodStatsRequest.Size(m)
+}
+func (m *PodStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodStatsRequest proto.InternalMessageInfo
+
+func (m *PodStatsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PodStatsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PodStatsRequest) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+type PodStatsResponse struct {
+	// Types that are valid to be assigned to Response:
+	//	*PodStatsResponse_Ok
+	//	*PodStatsResponse_Error
+	Response             isPodStatsResponse_Response `protobuf_oneof:"response"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *PodStatsResponse) Reset()         { *m = PodStatsResponse{} }
+func (m *PodStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*PodStatsResponse) ProtoMessage()    {}
+func (*PodStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{36}
+}
+func (m *PodStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodStatsResponse.Unmarshal(m, b)
+}
+func (m *PodStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *PodStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodStatsResponse.Merge(dst, src)
+}
+func (m *PodStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_PodStatsResponse.Size(m)
+}
+func (m *PodStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodStatsResponse proto.InternalMessageInfo
+
+type isPodStatsResponse_Response interface {
+	isPodStatsResponse_Response()
+}
+
+type PodStatsResponse_Ok struct {
+	Ok *PodStats `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type PodStatsResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*PodStatsResponse_Ok) isPodStatsResponse_Response() {}
+
+func (*PodStatsResponse_Error) isPodStatsResponse_Response() {}
+
+func (m *PodStatsResponse) GetResponse() isPodStatsResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *PodStatsResponse) GetOk() *PodStats {
+	if x, ok := m.GetResponse().(*PodStatsResponse_Ok); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (m *PodStatsResponse) GetError() *ResourceError {
+	if x, ok := m.GetResponse().(*PodStatsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*PodStatsResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _PodStatsResponse_OneofMarshaler, _PodStatsResponse_OneofUnmarshaler, _PodStatsResponse_OneofSizer, []interface{}{
+		(*PodStatsResponse_Ok)(nil),
+		(*PodStatsResponse_Error)(nil),
+	}
+}
+
+func _PodStatsResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*PodStatsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *PodStatsResponse_Ok:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ok); err != nil {
+			return err
+		}
+	case *PodStatsResponse_Error:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("PodStatsResponse.Response has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _PodStatsResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*PodStatsResponse)
+	switch tag {
+	case 1: // response.ok
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PodStats)
+		err := b.DecodeMessage(msg)
+		m.Response = &PodStatsResponse_Ok{msg}
+		return true, err
+	case 2: // response.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceError)
+		err := b.DecodeMessage(msg)
+		m.Response = &PodStatsResponse_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _PodStatsResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*PodStatsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *PodStatsResponse_Ok:
+		s := proto.Size(x.Ok)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PodStatsResponse_Error:
+		s := proto.Size(x.Error)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// PodStats are the stats of a single pod, read without aggregating the stats
+// of the pod's owner.
+type PodStats struct {
+	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	Status     string    `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Meshed     bool      `protobuf:"varint,4,opt,name=meshed,proto3" json:"meshed,omitempty"`
+	// inbound traffic stats of the pod's proxy over the time window
+	Stats                *BasicStats        `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	TcpStats             *TcpStats          `protobuf:"bytes,6,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	ProxyUptime          *duration.Duration `protobuf:"bytes,7,opt,name=proxy_uptime,json=proxyUptime,proto3" json:"proxy_uptime,omitempty"`
+	ProxyVersion         string             `protobuf:"bytes,8,opt,name=proxy_version,json=proxyVersion,proto3" json:"proxy_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PodStats) Reset()         { *m = PodStats{} }
+func (m *PodStats) String() string { return proto.CompactTextString(m) }
+func (*PodStats) ProtoMessage()    {}
+func (*PodStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{37}
+}
+func (m *PodStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodStats.Unmarshal(m, b)
+}
+func (m *PodStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodStats.Marshal(b, m, deterministic)
+}
+func (dst *PodStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodStats.Merge(dst, src)
+}
+func (m *PodStats) XXX_Size() int {
+	return xxx_messageInfo_PodStats.Size(m)
+}
+func (m *PodStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodStats proto.InternalMessageInfo
+
+func (m *PodStats) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *PodStats) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+func (m *PodStats) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *PodStats) GetMeshed() bool {
+	if m != nil {
+		return m.Meshed
+	}
+	return false
+}
+
+func (m *PodStats) GetStats() *BasicStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *PodStats) GetTcpStats() *TcpStats {
+	if m != nil {
+		return m.TcpStats
+	}
+	return nil
+}
+
+func (m *PodStats) GetProxyUptime() *duration.Duration {
+	if m != nil {
+		return m.ProxyUptime
+	}
+	return nil
+}
+
+func (m *PodStats) GetProxyVersion() string {
+	if m != nil {
+		return m.ProxyVersion
+	}
+	return ""
+}
+
+type TcpStats struct {
+	// number of inbound connections currently open
+	OpenConnections uint64 `protobuf:"varint,1,opt,name=open_connections,json=openConnections,proto3" json:"open_connections,omitempty"`
+	// bytes read from and written to inbound connections over the time window
+	ReadBytesTotal       uint64   `protobuf:"varint,2,opt,name=read_bytes_total,json=readBytesTotal,proto3" json:"read_bytes_total,omitempty"`
+	WriteBytesTotal      uint64   `protobuf:"varint,3,opt,name=write_bytes_total,json=writeBytesTotal,proto3" json:"write_bytes_total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TcpStats) Reset()         { *m = TcpStats{} }
+func (m *TcpStats) String() string { return proto.CompactTextString(m) }
+func (*TcpStats) ProtoMessage()    {}
+func (*TcpStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{38}
+}
+func (m *TcpStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TcpStats.Unmarshal(m, b)
+}
+func (m *TcpStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TcpStats.Marshal(b, m, deterministic)
+}
+func (dst *TcpStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TcpStats.Merge(dst, src)
+}
+func (m *TcpStats) XXX_Size() int {
+	return xxx_messageInfo_TcpStats.Size(m)
+}
+func (m *TcpStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_TcpStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TcpStats proto.InternalMessageInfo
+
+func (m *TcpStats) GetOpenConnections() uint64 {
+	if m != nil {
+		return m.OpenConnections
+	}
+	return 0
+}
+
+func (m *TcpStats) GetReadBytesTotal() uint64 {
+	if m != nil {
+		return m.ReadBytesTotal
+	}
+	return 0
+}
+
+func (m *TcpStats) GetWriteBytesTotal() uint64 {
+	if m != nil {
+		return m.WriteBytesTotal
+	}
+	return 0
+}
+
+type ListResourcesRequest struct {
+	// The type of the resources to list, and their namespace and name if set,
+	// along with a label selector.
+	Selector *ResourceSelection `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// A string-formatted Kubernetes field selector as passed to `kubectl get
+	// --field-selector`. Only the metadata.name and metadata.namespace fields,
+	// and a few type-specific fields such as a pod's status.phase, are
+	// supported.
+	FieldSelector        string   `protobuf:"bytes,2,opt,name=field_selector,json=fieldSelector,proto3" json:"field_selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListResourcesRequest) Reset()         { *m = ListResourcesRequest{} }
+func (m *ListResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListResourcesRequest) ProtoMessage()    {}
+func (*ListResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{39}
+}
+func (m *ListResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResourcesRequest.Unmarshal(m, b)
+}
+func (m *ListResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResourcesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResourcesRequest.Merge(dst, src)
+}
+func (m *ListResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListResourcesRequest.Size(m)
+}
+func (m *ListResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResourcesRequest proto.InternalMessageInfo
+
+func (m *ListResourcesRequest) GetSelector() *ResourceSelection {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (m *ListResourcesRequest) GetFieldSelector() string {
+	if m != nil {
+		return m.FieldSelector
+	}
+	return ""
+}
+
+type ListResourcesResponse struct {
+	Resources            []*ResourceInfo `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListResourcesResponse) Reset()         { *m = ListResourcesResponse{} }
+func (m *ListResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResourcesResponse) ProtoMessage()    {}
+func (*ListResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{40}
+}
+func (m *ListResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResourcesResponse.Unmarshal(m, b)
+}
+func (m *ListResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResourcesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResourcesResponse.Merge(dst, src)
+}
+func (m *ListResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListResourcesResponse.Size(m)
+}
+func (m *ListResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResourcesResponse proto.InternalMessageInfo
+
+func (m *ListResourcesResponse) GetResources() []*ResourceInfo {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type ResourceInfo struct {
+	Resource             *Resource         `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations          map[string]string `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ResourceInfo) Reset()         { *m = ResourceInfo{} }
+func (m *ResourceInfo) String() string { return proto.CompactTextString(m) }
+func (*ResourceInfo) ProtoMessage()    {}
+func (*ResourceInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{41}
+}
+func (m *ResourceInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceInfo.Unmarshal(m, b)
+}
+func (m *ResourceInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceInfo.Marshal(b, m, deterministic)
+}
+func (dst *ResourceInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceInfo.Merge(dst, src)
+}
+func (m *ResourceInfo) XXX_Size() int {
+	return xxx_messageInfo_ResourceInfo.Size(m)
+}
+func (m *ResourceInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceInfo proto.InternalMessageInfo
+
+func (m *ResourceInfo) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *ResourceInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
 	}
-	return n
+	return nil
 }
 
-type StatTable_PodGroup struct {
-	Rows                 []*StatTable_PodGroup_Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+func (m *ResourceInfo) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
 }
 
-func (m *StatTable_PodGroup) Reset()         { *m = StatTable_PodGroup{} }
-func (m *StatTable_PodGroup) String() string { return proto.CompactTextString(m) }
-func (*StatTable_PodGroup) ProtoMessage()    {}
-func (*StatTable_PodGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{22, 0}
+type ProxySkewRequest struct {
+	// If empty, proxies are reported for all namespaces.
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *StatTable_PodGroup) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StatTable_PodGroup.Unmarshal(m, b)
+
+func (m *ProxySkewRequest) Reset()         { *m = ProxySkewRequest{} }
+func (m *ProxySkewRequest) String() string { return proto.CompactTextString(m) }
+func (*ProxySkewRequest) ProtoMessage()    {}
+func (*ProxySkewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{42}
 }
-func (m *StatTable_PodGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StatTable_PodGroup.Marshal(b, m, deterministic)
+func (m *ProxySkewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProxySkewRequest.Unmarshal(m, b)
 }
-func (dst *StatTable_PodGroup) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StatTable_PodGroup.Merge(dst, src)
+func (m *ProxySkewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProxySkewRequest.Marshal(b, m, deterministic)
 }
-func (m *StatTable_PodGroup) XXX_Size() int {
-	return xxx_messageInfo_StatTable_PodGroup.Size(m)
+func (dst *ProxySkewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProxySkewRequest.Merge(dst, src)
 }
-func (m *StatTable_PodGroup) XXX_DiscardUnknown() {
-	xxx_messageInfo_StatTable_PodGroup.DiscardUnknown(m)
+func (m *ProxySkewRequest) XXX_Size() int {
+	return xxx_messageInfo_ProxySkewRequest.Size(m)
+}
+func (m *ProxySkewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProxySkewRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StatTable_PodGroup proto.InternalMessageInfo
+var xxx_messageInfo_ProxySkewRequest proto.InternalMessageInfo
 
-func (m *StatTable_PodGroup) GetRows() []*StatTable_PodGroup_Row {
+func (m *ProxySkewRequest) GetNamespace() string {
 	if m != nil {
-		return m.Rows
+		return m.Namespace
 	}
-	return nil
+	return ""
 }
 
-type StatTable_PodGroup_Row struct {
-	Resource   *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
-	TimeWindow string    `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
-	// number of pending or running pods in this resource that have linkerd injected
-	MeshedPodCount uint64 `protobuf:"varint,3,opt,name=meshed_pod_count,json=meshedPodCount,proto3" json:"meshed_pod_count,omitempty"`
-	// number of pending or running pods in this resource
-	RunningPodCount uint64 `protobuf:"varint,4,opt,name=running_pod_count,json=runningPodCount,proto3" json:"running_pod_count,omitempty"`
-	// number of pods in this resource that have Phase PodFailed
-	FailedPodCount uint64      `protobuf:"varint,6,opt,name=failed_pod_count,json=failedPodCount,proto3" json:"failed_pod_count,omitempty"`
-	Stats          *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
-	// Stores a set of errors for each pod name. If a pod has no errors, it may be omitted.
-	ErrorsByPod          map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+type ProxySkewResponse struct {
+	// The version of the control plane, and the hash of the proxy configuration
+	// and the fingerprint of the trust anchors it was installed with, that
+	// up-to-date proxies are expected to match.
+	CurrentVersion       string            `protobuf:"bytes,1,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
+	CurrentConfigHash    string            `protobuf:"bytes,2,opt,name=current_config_hash,json=currentConfigHash,proto3" json:"current_config_hash,omitempty"`
+	CurrentTrustAnchors  string            `protobuf:"bytes,3,opt,name=current_trust_anchors,json=currentTrustAnchors,proto3" json:"current_trust_anchors,omitempty"`
+	Versions             []*ProxySkewGroup `protobuf:"bytes,4,rep,name=versions,proto3" json:"versions,omitempty"`
+	ConfigHashes         []*ProxySkewGroup `protobuf:"bytes,5,rep,name=config_hashes,json=configHashes,proto3" json:"config_hashes,omitempty"`
+	TrustAnchors         []*ProxySkewGroup `protobuf:"bytes,6,rep,name=trust_anchors,json=trustAnchors,proto3" json:"trust_anchors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *StatTable_PodGroup_Row) Reset()         { *m = StatTable_PodGroup_Row{} }
-func (m *StatTable_PodGroup_Row) String() string { return proto.CompactTextString(m) }
-func (*StatTable_PodGroup_Row) ProtoMessage()    {}
-func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_ff43b1a50eb4ceae, []int{22, 0, 0}
+func (m *ProxySkewResponse) Reset()         { *m = ProxySkewResponse{} }
+func (m *ProxySkewResponse) String() string { return proto.CompactTextString(m) }
+func (*ProxySkewResponse) ProtoMessage()    {}
+func (*ProxySkewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{43}
 }
-func (m *StatTable_PodGroup_Row) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StatTable_PodGroup_Row.Unmarshal(m, b)
+func (m *ProxySkewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProxySkewResponse.Unmarshal(m, b)
 }
-func (m *StatTable_PodGroup_Row) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StatTable_PodGroup_Row.Marshal(b, m, deterministic)
+func (m *ProxySkewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProxySkewResponse.Marshal(b, m, deterministic)
 }
-func (dst *StatTable_PodGroup_Row) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StatTable_PodGroup_Row.Merge(dst, src)
+func (dst *ProxySkewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProxySkewResponse.Merge(dst, src)
 }
-func (m *StatTable_PodGroup_Row) XXX_Size() int {
-	return xxx_messageInfo_StatTable_PodGroup_Row.Size(m)
+func (m *ProxySkewResponse) XXX_Size() int {
+	return xxx_messageInfo_ProxySkewResponse.Size(m)
 }
-func (m *StatTable_PodGroup_Row) XXX_DiscardUnknown() {
-	xxx_messageInfo_StatTable_PodGroup_Row.DiscardUnknown(m)
+func (m *ProxySkewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProxySkewResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StatTable_PodGroup_Row proto.InternalMessageInfo
+var xxx_messageInfo_ProxySkewResponse proto.InternalMessageInfo
 
-func (m *StatTable_PodGroup_Row) GetResource() *Resource {
+func (m *ProxySkewResponse) GetCurrentVersion() string {
 	if m != nil {
-		return m.Resource
+		return m.CurrentVersion
 	}
-	return nil
+	return ""
 }
 
-func (m *StatTable_PodGroup_Row) GetTimeWindow() string {
+func (m *ProxySkewResponse) GetCurrentConfigHash() string {
 	if m != nil {
-		return m.TimeWindow
+		return m.CurrentConfigHash
 	}
 	return ""
 }
 
-func (m *StatTable_PodGroup_Row) GetMeshedPodCount() uint64 {
+func (m *ProxySkewResponse) GetCurrentTrustAnchors() string {
 	if m != nil {
-		return m.MeshedPodCount
+		return m.CurrentTrustAnchors
 	}
-	return 0
+	return ""
 }
 
-func (m *StatTable_PodGroup_Row) GetRunningPodCount() uint64 {
+func (m *ProxySkewResponse) GetVersions() []*ProxySkewGroup {
 	if m != nil {
-		return m.RunningPodCount
+		return m.Versions
 	}
-	return 0
+	return nil
 }
 
-func (m *StatTable_PodGroup_Row) GetFailedPodCount() uint64 {
+func (m *ProxySkewResponse) GetConfigHashes() []*ProxySkewGroup {
 	if m != nil {
-		return m.FailedPodCount
+		return m.ConfigHashes
 	}
-	return 0
+	return nil
 }
 
-func (m *StatTable_PodGroup_Row) GetStats() *BasicStats {
+func (m *ProxySkewResponse) GetTrustAnchors() []*ProxySkewGroup {
 	if m != nil {
-		return m.Stats
+		return m.TrustAnchors
 	}
 	return nil
 }
 
-func (m *StatTable_PodGroup_Row) GetErrorsByPod() map[string]*PodErrors {
+// ProxySkewGroup counts the meshed pods that share a value, such as a proxy
+// version, along with a few of the workloads running them.
+type ProxySkewGroup struct {
+	Value                string      `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	PodCount             uint64      `protobuf:"varint,2,opt,name=pod_count,json=podCount,proto3" json:"pod_count,omitempty"`
+	Examples             []*Resource `protobuf:"bytes,3,rep,name=examples,proto3" json:"examples,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ProxySkewGroup) Reset()         { *m = ProxySkewGroup{} }
+func (m *ProxySkewGroup) String() string { return proto.CompactTextString(m) }
+func (*ProxySkewGroup) ProtoMessage()    {}
+func (*ProxySkewGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_292ab771697df96b, []int{44}
+}
+func (m *ProxySkewGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProxySkewGroup.Unmarshal(m, b)
+}
+func (m *ProxySkewGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProxySkewGroup.Marshal(b, m, deterministic)
+}
+func (dst *ProxySkewGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProxySkewGroup.Merge(dst, src)
+}
+func (m *ProxySkewGroup) XXX_Size() int {
+	return xxx_messageInfo_ProxySkewGroup.Size(m)
+}
+func (m *ProxySkewGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProxySkewGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProxySkewGroup proto.InternalMessageInfo
+
+func (m *ProxySkewGroup) GetValue() string {
 	if m != nil {
-		return m.ErrorsByPod
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ProxySkewGroup) GetPodCount() uint64 {
+	if m != nil {
+		return m.PodCount
+	}
+	return 0
+}
+
+func (m *ProxySkewGroup) GetExamples() []*Resource {
+	if m != nil {
+		return m.Examples
 	}
 	return nil
 }
@@ -3719,7 +5369,16 @@ func init() {
 	proto.RegisterType((*VersionInfo)(nil), "linkerd2.public.VersionInfo")
 	proto.RegisterType((*ListPodsRequest)(nil), "linkerd2.public.ListPodsRequest")
 	proto.RegisterType((*ListPodsResponse)(nil), "linkerd2.public.ListPodsResponse")
+	proto.RegisterType((*MeshCoverageRequest)(nil), "linkerd2.public.MeshCoverageRequest")
+	proto.RegisterType((*MeshCoverageResponse)(nil), "linkerd2.public.MeshCoverageResponse")
+	proto.RegisterType((*NamespaceCoverage)(nil), "linkerd2.public.NamespaceCoverage")
+	proto.RegisterType((*ProxyVersionCount)(nil), "linkerd2.public.ProxyVersionCount")
+	proto.RegisterType((*ListNamespacesRequest)(nil), "linkerd2.public.ListNamespacesRequest")
+	proto.RegisterType((*ListNamespacesResponse)(nil), "linkerd2.public.ListNamespacesResponse")
+	proto.RegisterType((*NamespaceSummary)(nil), "linkerd2.public.NamespaceSummary")
 	proto.RegisterType((*Pod)(nil), "linkerd2.public.Pod")
+	proto.RegisterType((*ProxyConfig)(nil), "linkerd2.public.ProxyConfig")
+	proto.RegisterMapType((map[string]string)(nil), "linkerd2.public.ProxyConfig.OverridesEntry")
 	proto.RegisterType((*TapRequest)(nil), "linkerd2.public.TapRequest")
 	proto.RegisterType((*TapByResourceRequest)(nil), "linkerd2.public.TapByResourceRequest")
 	proto.RegisterType((*TapByResourceRequest_Match)(nil), "linkerd2.public.TapByResourceRequest.Match")
@@ -3749,11 +5408,28 @@ func init() {
 	proto.RegisterType((*StatSummaryRequest)(nil), "linkerd2.public.StatSummaryRequest")
 	proto.RegisterType((*StatSummaryResponse)(nil), "linkerd2.public.StatSummaryResponse")
 	proto.RegisterType((*StatSummaryResponse_Ok)(nil), "linkerd2.public.StatSummaryResponse.Ok")
+	proto.RegisterType((*PrometheusQuery)(nil), "linkerd2.public.PrometheusQuery")
 	proto.RegisterType((*BasicStats)(nil), "linkerd2.public.BasicStats")
 	proto.RegisterType((*StatTable)(nil), "linkerd2.public.StatTable")
 	proto.RegisterType((*StatTable_PodGroup)(nil), "linkerd2.public.StatTable.PodGroup")
 	proto.RegisterType((*StatTable_PodGroup_Row)(nil), "linkerd2.public.StatTable.PodGroup.Row")
 	proto.RegisterMapType((map[string]*PodErrors)(nil), "linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry")
+	proto.RegisterType((*LatencyDistributionRequest)(nil), "linkerd2.public.LatencyDistributionRequest")
+	proto.RegisterType((*LatencyDistributionResponse)(nil), "linkerd2.public.LatencyDistributionResponse")
+	proto.RegisterType((*LatencyDistributionResponse_Ok)(nil), "linkerd2.public.LatencyDistributionResponse.Ok")
+	proto.RegisterType((*LatencyBucket)(nil), "linkerd2.public.LatencyBucket")
+	proto.RegisterType((*PodStatsRequest)(nil), "linkerd2.public.PodStatsRequest")
+	proto.RegisterType((*PodStatsResponse)(nil), "linkerd2.public.PodStatsResponse")
+	proto.RegisterType((*PodStats)(nil), "linkerd2.public.PodStats")
+	proto.RegisterType((*TcpStats)(nil), "linkerd2.public.TcpStats")
+	proto.RegisterType((*ListResourcesRequest)(nil), "linkerd2.public.ListResourcesRequest")
+	proto.RegisterType((*ListResourcesResponse)(nil), "linkerd2.public.ListResourcesResponse")
+	proto.RegisterType((*ResourceInfo)(nil), "linkerd2.public.ResourceInfo")
+	proto.RegisterMapType((map[string]string)(nil), "linkerd2.public.ResourceInfo.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "linkerd2.public.ResourceInfo.LabelsEntry")
+	proto.RegisterType((*ProxySkewRequest)(nil), "linkerd2.public.ProxySkewRequest")
+	proto.RegisterType((*ProxySkewResponse)(nil), "linkerd2.public.ProxySkewResponse")
+	proto.RegisterType((*ProxySkewGroup)(nil), "linkerd2.public.ProxySkewGroup")
 	proto.RegisterEnum("linkerd2.public.HttpMethod_Registered", HttpMethod_Registered_name, HttpMethod_Registered_value)
 	proto.RegisterEnum("linkerd2.public.Scheme_Registered", Scheme_Registered_name, Scheme_Registered_value)
 	proto.RegisterEnum("linkerd2.public.TapEvent_ProxyDirection", TapEvent_ProxyDirection_name, TapEvent_ProxyDirection_value)
@@ -3773,6 +5449,12 @@ const _ = grpc.SupportPackageIsVersion4
 type ApiClient interface {
 	StatSummary(ctx context.Context, in *StatSummaryRequest, opts ...grpc.CallOption) (*StatSummaryResponse, error)
 	ListPods(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error)
+	MeshCoverage(ctx context.Context, in *MeshCoverageRequest, opts ...grpc.CallOption) (*MeshCoverageResponse, error)
+	ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error)
+	LatencyDistribution(ctx context.Context, in *LatencyDistributionRequest, opts ...grpc.CallOption) (*LatencyDistributionResponse, error)
+	PodStats(ctx context.Context, in *PodStatsRequest, opts ...grpc.CallOption) (*PodStatsResponse, error)
+	ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error)
+	ProxySkew(ctx context.Context, in *ProxySkewRequest, opts ...grpc.CallOption) (*ProxySkewResponse, error)
 	// Superceded by `TapByResource`.
 	Tap(ctx context.Context, in *TapRequest, opts ...grpc.CallOption) (Api_TapClient, error)
 	// Executes tapping over Kubernetes resources.
@@ -3807,6 +5489,60 @@ func (c *apiClient) ListPods(ctx context.Context, in *ListPodsRequest, opts ...g
 	return out, nil
 }
 
+func (c *apiClient) MeshCoverage(ctx context.Context, in *MeshCoverageRequest, opts ...grpc.CallOption) (*MeshCoverageResponse, error) {
+	out := new(MeshCoverageResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/MeshCoverage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) ListNamespaces(ctx context.Context, in *ListNamespacesRequest, opts ...grpc.CallOption) (*ListNamespacesResponse, error) {
+	out := new(ListNamespacesResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/ListNamespaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) LatencyDistribution(ctx context.Context, in *LatencyDistributionRequest, opts ...grpc.CallOption) (*LatencyDistributionResponse, error) {
+	out := new(LatencyDistributionResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/LatencyDistribution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) PodStats(ctx context.Context, in *PodStatsRequest, opts ...grpc.CallOption) (*PodStatsResponse, error) {
+	out := new(PodStatsResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/PodStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResponse, error) {
+	out := new(ListResourcesResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/ListResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) ProxySkew(ctx context.Context, in *ProxySkewRequest, opts ...grpc.CallOption) (*ProxySkewResponse, error) {
+	out := new(ProxySkewResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/ProxySkew", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Deprecated: Do not use.
 func (c *apiClient) Tap(ctx context.Context, in *TapRequest, opts ...grpc.CallOption) (Api_TapClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Api_serviceDesc.Streams[0], "/linkerd2.public.Api/Tap", opts...)
@@ -3894,6 +5630,12 @@ func (c *apiClient) SelfCheck(ctx context.Context, in *healthcheck.SelfCheckRequ
 type ApiServer interface {
 	StatSummary(context.Context, *StatSummaryRequest) (*StatSummaryResponse, error)
 	ListPods(context.Context, *ListPodsRequest) (*ListPodsResponse, error)
+	MeshCoverage(context.Context, *MeshCoverageRequest) (*MeshCoverageResponse, error)
+	ListNamespaces(context.Context, *ListNamespacesRequest) (*ListNamespacesResponse, error)
+	LatencyDistribution(context.Context, *LatencyDistributionRequest) (*LatencyDistributionResponse, error)
+	PodStats(context.Context, *PodStatsRequest) (*PodStatsResponse, error)
+	ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResponse, error)
+	ProxySkew(context.Context, *ProxySkewRequest) (*ProxySkewResponse, error)
 	// Superceded by `TapByResource`.
 	Tap(*TapRequest, Api_TapServer) error
 	// Executes tapping over Kubernetes resources.
@@ -3942,6 +5684,114 @@ func _Api_ListPods_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_MeshCoverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeshCoverageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).MeshCoverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/MeshCoverage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).MeshCoverage(ctx, req.(*MeshCoverageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_ListNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).ListNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/ListNamespaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).ListNamespaces(ctx, req.(*ListNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_LatencyDistribution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LatencyDistributionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).LatencyDistribution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/LatencyDistribution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).LatencyDistribution(ctx, req.(*LatencyDistributionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_PodStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PodStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).PodStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/PodStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).PodStats(ctx, req.(*PodStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_ListResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).ListResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/ListResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).ListResources(ctx, req.(*ListResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_ProxySkew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProxySkewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).ProxySkew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/ProxySkew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).ProxySkew(ctx, req.(*ProxySkewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Api_Tap_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(TapRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -4032,6 +5882,30 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListPods",
 			Handler:    _Api_ListPods_Handler,
 		},
+		{
+			MethodName: "MeshCoverage",
+			Handler:    _Api_MeshCoverage_Handler,
+		},
+		{
+			MethodName: "ListNamespaces",
+			Handler:    _Api_ListNamespaces_Handler,
+		},
+		{
+			MethodName: "LatencyDistribution",
+			Handler:    _Api_LatencyDistribution_Handler,
+		},
+		{
+			MethodName: "PodStats",
+			Handler:    _Api_PodStats_Handler,
+		},
+		{
+			MethodName: "ListResources",
+			Handler:    _Api_ListResources_Handler,
+		},
+		{
+			MethodName: "ProxySkew",
+			Handler:    _Api_ProxySkew_Handler,
+		},
 		{
 			MethodName: "Version",
 			Handler:    _Api_Version_Handler,
@@ -4056,165 +5930,244 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 	Metadata: "public.proto",
 }
 
-func init() { proto.RegisterFile("public.proto", fileDescriptor_public_ff43b1a50eb4ceae) }
-
-var fileDescriptor_public_ff43b1a50eb4ceae = []byte{
-	// 2506 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0x3b, 0x73, 0x1b, 0xc9,
-	0xf1, 0xc7, 0x63, 0xf1, 0x6a, 0x00, 0x24, 0x34, 0xd2, 0xe9, 0x0f, 0xe1, 0xae, 0x74, 0xd4, 0x4a,
-	0xa7, 0x63, 0xe9, 0xfe, 0x06, 0x29, 0xe8, 0xa4, 0x13, 0xa5, 0xf3, 0x83, 0x20, 0x61, 0x81, 0x36,
-	0x45, 0x42, 0x03, 0xc8, 0x57, 0xa5, 0xba, 0x2a, 0xd4, 0x02, 0x3b, 0x24, 0xd6, 0x5c, 0xec, 0xac,
-	0x76, 0x07, 0xe2, 0x21, 0x75, 0xe4, 0x0f, 0x60, 0xc7, 0x8e, 0xed, 0xc4, 0xe5, 0xc4, 0x1f, 0xc2,
-	0x91, 0x33, 0x67, 0x76, 0xe6, 0xd4, 0x89, 0x63, 0xdb, 0x35, 0xaf, 0xc5, 0x82, 0x00, 0x1f, 0x92,
-	0x13, 0x47, 0x98, 0xee, 0xf9, 0x75, 0x6f, 0x4f, 0x77, 0x4f, 0xf7, 0xcc, 0x00, 0x4a, 0xfe, 0x64,
-	0xe0, 0x3a, 0xc3, 0xba, 0x1f, 0x50, 0x46, 0xd1, 0xaa, 0xeb, 0x78, 0x27, 0x24, 0xb0, 0x1b, 0x75,
-	0xc9, 0xae, 0xdd, 0x3e, 0xa6, 0xf4, 0xd8, 0x25, 0x1b, 0x62, 0x7a, 0x30, 0x39, 0xda, 0xb0, 0x27,
-	0x81, 0xc5, 0x1c, 0xea, 0x49, 0x81, 0x5a, 0x75, 0x48, 0xc7, 0x63, 0xea, 0x6d, 0x8c, 0x88, 0xe5,
-	0xb2, 0xd1, 0x70, 0x44, 0x86, 0x27, 0x72, 0xc6, 0xcc, 0x41, 0xa6, 0x35, 0xf6, 0xd9, 0xd4, 0x7c,
-	0x0b, 0xc5, 0x9f, 0x91, 0x20, 0x74, 0xa8, 0xb7, 0xe7, 0x1d, 0x51, 0xf4, 0x09, 0x14, 0x8e, 0xa9,
-	0x62, 0x54, 0x93, 0x6b, 0xc9, 0xf5, 0x02, 0x9e, 0x31, 0xf8, 0xec, 0x60, 0xe2, 0xb8, 0xf6, 0xae,
-	0xc5, 0x48, 0x35, 0x25, 0x67, 0x23, 0x06, 0xba, 0x0f, 0x2b, 0x01, 0x71, 0x89, 0x15, 0x12, 0xad,
-	0x20, 0x2d, 0x20, 0x67, 0xb8, 0xe6, 0x06, 0xac, 0xee, 0x3b, 0x21, 0xeb, 0x50, 0x3b, 0xc4, 0xe4,
-	0xed, 0x84, 0x84, 0x8c, 0x2b, 0xf6, 0xac, 0x31, 0x09, 0x7d, 0x6b, 0x48, 0xf4, 0x67, 0x23, 0x86,
-	0xf9, 0x35, 0x54, 0x66, 0x02, 0xa1, 0x4f, 0xbd, 0x90, 0xa0, 0x75, 0x30, 0x7c, 0x6a, 0x87, 0xd5,
-	0xe4, 0x5a, 0x7a, 0xbd, 0xd8, 0xb8, 0x51, 0x3f, 0xe3, 0x9a, 0x7a, 0x87, 0xda, 0x58, 0x20, 0xcc,
-	0x3f, 0x1b, 0x90, 0xee, 0x50, 0x1b, 0x21, 0x30, 0xb8, 0x4a, 0xa5, 0x5e, 0x8c, 0xd1, 0x0d, 0xc8,
-	0xf8, 0xd4, 0xde, 0xeb, 0xa8, 0xc5, 0x48, 0x02, 0xad, 0x01, 0xd8, 0xc4, 0x77, 0xe9, 0x74, 0x4c,
-	0x3c, 0x26, 0x17, 0xd1, 0x4e, 0xe0, 0x18, 0x0f, 0xdd, 0x81, 0x62, 0x40, 0x7c, 0xd7, 0x19, 0x5a,
-	0xfd, 0x90, 0xb0, 0x2a, 0x68, 0x88, 0x62, 0x76, 0x09, 0x43, 0x5f, 0xc1, 0x4d, 0x45, 0xf1, 0x80,
-	0xf4, 0x87, 0xd4, 0x63, 0x01, 0x75, 0x5d, 0x12, 0x54, 0x8b, 0x0a, 0xfd, 0x51, 0x6c, 0x7e, 0x27,
-	0x9a, 0x46, 0x77, 0xa1, 0x14, 0x32, 0x8b, 0x91, 0xa3, 0x89, 0x2b, 0x94, 0x97, 0x14, 0xbc, 0xa8,
-	0xb9, 0x5c, 0xfb, 0xa7, 0x00, 0xb6, 0x45, 0xc6, 0xd4, 0x13, 0x90, 0xb2, 0x82, 0x14, 0x24, 0x8f,
-	0x03, 0x10, 0xa4, 0x7f, 0x4e, 0x07, 0xd5, 0x15, 0x35, 0xc3, 0x09, 0x74, 0x13, 0xb2, 0x5c, 0xc7,
-	0x24, 0xac, 0x1a, 0x62, 0xb9, 0x8a, 0xe2, 0x5e, 0xb0, 0x6c, 0x9b, 0xd8, 0xd5, 0xcc, 0x5a, 0x72,
-	0x3d, 0x8f, 0x25, 0x81, 0x76, 0x60, 0x35, 0x74, 0xbc, 0x21, 0xd9, 0xb7, 0x42, 0x86, 0x89, 0x4f,
-	0x03, 0x56, 0xcd, 0xae, 0x25, 0xd7, 0x8b, 0x8d, 0x5b, 0x75, 0x99, 0x76, 0x75, 0x9d, 0x76, 0xf5,
-	0x5d, 0x95, 0x76, 0xf8, 0xac, 0x04, 0xda, 0x84, 0xeb, 0xb3, 0x95, 0x1f, 0x44, 0x21, 0xce, 0x89,
-	0xef, 0x2f, 0x9b, 0x42, 0x26, 0x94, 0x14, 0xbb, 0xe3, 0x5a, 0x1e, 0xa9, 0xe6, 0x85, 0x4d, 0x73,
-	0x3c, 0xf4, 0x10, 0xb2, 0x13, 0x9f, 0x39, 0x63, 0x52, 0x2d, 0x5c, 0x66, 0x91, 0x02, 0xa2, 0xdb,
-	0x00, 0x7e, 0x40, 0xbf, 0x9b, 0x62, 0x62, 0xd9, 0xd3, 0xea, 0xaa, 0x50, 0x1a, 0xe3, 0xf0, 0xcf,
-	0x0a, 0x4a, 0xa7, 0x6e, 0x45, 0x58, 0x38, 0xc7, 0x6b, 0xe6, 0x20, 0x43, 0x4f, 0x3d, 0x12, 0x98,
-	0xbf, 0x4b, 0x01, 0xf4, 0x2c, 0x5f, 0x67, 0x2f, 0x82, 0xb4, 0x4f, 0x6d, 0x99, 0x58, 0xdc, 0xd7,
-	0x3e, 0xb5, 0xcf, 0xe4, 0x50, 0x6a, 0x49, 0x0e, 0xdd, 0x84, 0xec, 0xd8, 0xfa, 0x0e, 0xfb, 0xa1,
-	0xc8, 0xb0, 0x14, 0x56, 0x14, 0xe7, 0x33, 0xda, 0xe1, 0xee, 0xe6, 0x51, 0x2a, 0x63, 0x45, 0xf1,
-	0xfc, 0x65, 0x74, 0xaf, 0x23, 0x82, 0x54, 0xc0, 0x62, 0x8c, 0x6a, 0x90, 0x3f, 0x0a, 0xe8, 0xb8,
-	0xa3, 0x83, 0x53, 0xc6, 0x11, 0xcd, 0xf5, 0xf0, 0xf1, 0x5e, 0x47, 0x79, 0x5b, 0x51, 0x22, 0x0b,
-	0x86, 0x23, 0x32, 0x96, 0xae, 0xe5, 0x59, 0x20, 0x28, 0x61, 0x0f, 0x61, 0x23, 0x6a, 0x0b, 0xa7,
-	0x16, 0xb0, 0xa2, 0xf8, 0xde, 0xb4, 0x26, 0x6c, 0x44, 0x03, 0x87, 0x4d, 0x65, 0xa6, 0xe3, 0x19,
-	0x83, 0x5b, 0xe5, 0x5b, 0x6c, 0x24, 0x93, 0x1a, 0x8b, 0xf1, 0xb3, 0x54, 0x35, 0xd9, 0xcc, 0x43,
-	0x96, 0x59, 0xc1, 0x31, 0x61, 0xe6, 0xdf, 0x33, 0x70, 0xa3, 0x67, 0xf9, 0xcd, 0x29, 0x26, 0x21,
-	0x9d, 0x04, 0x43, 0xa2, 0xdd, 0xf6, 0x4c, 0x43, 0x84, 0xe7, 0x8a, 0x0d, 0x73, 0x61, 0x13, 0x6b,
-	0x89, 0x2e, 0x71, 0xc9, 0x50, 0x86, 0x53, 0x4a, 0xa0, 0x6d, 0xc8, 0x8c, 0x2d, 0x36, 0x1c, 0x09,
-	0xcf, 0x16, 0x1b, 0x5f, 0x2c, 0x88, 0x2e, 0xfb, 0x62, 0xfd, 0x25, 0x17, 0xc1, 0x52, 0xf2, 0x3c,
-	0xff, 0xd7, 0xfe, 0x68, 0x40, 0x46, 0x00, 0xd1, 0x0e, 0xa4, 0x2d, 0xd7, 0x55, 0xd6, 0x6d, 0xbc,
-	0xc7, 0x27, 0xea, 0x5d, 0xf2, 0x96, 0x27, 0x82, 0xe5, 0xba, 0x42, 0x89, 0x37, 0x55, 0x76, 0x7e,
-	0x90, 0x12, 0x6f, 0x8a, 0x7e, 0x08, 0x69, 0x8f, 0xca, 0x52, 0xf4, 0x7e, 0x8b, 0xe5, 0x0a, 0x3c,
-	0xca, 0x50, 0x1b, 0x4a, 0x36, 0x09, 0x99, 0xe3, 0x89, 0x5d, 0x21, 0x0b, 0xc0, 0x95, 0x3c, 0xde,
-	0x4e, 0xe0, 0x39, 0x49, 0xf4, 0x63, 0x30, 0x46, 0x8c, 0xf9, 0x22, 0x0d, 0x8b, 0x8d, 0xcd, 0xf7,
-	0x59, 0x50, 0x9b, 0x31, 0xbf, 0x9d, 0xc0, 0x42, 0xbe, 0xb6, 0x0f, 0xe9, 0x2e, 0x79, 0x8b, 0x5a,
-	0x90, 0x13, 0xe1, 0x20, 0xba, 0x94, 0xbf, 0x57, 0x28, 0xb5, 0x6c, 0x6d, 0x0a, 0x06, 0xd7, 0x8e,
-	0xaa, 0x51, 0x72, 0xeb, 0xdd, 0xa8, 0xd3, 0xbb, 0x1a, 0xa5, 0xb7, 0xde, 0x8c, 0x3a, 0xc1, 0x6f,
-	0xc7, 0x13, 0x5c, 0x57, 0xfb, 0x58, 0x8a, 0xdf, 0x50, 0x29, 0x6e, 0xa8, 0x29, 0x41, 0xf1, 0x62,
-	0x20, 0x3e, 0x1e, 0x0d, 0xcc, 0x7f, 0x26, 0x01, 0xb8, 0x11, 0x2f, 0xa5, 0xda, 0x36, 0x40, 0x40,
-	0x8e, 0x9d, 0x90, 0x91, 0x80, 0xc8, 0xe2, 0xb0, 0xd2, 0xb8, 0xbf, 0xb0, 0xb8, 0x99, 0x40, 0x1d,
-	0x47, 0x68, 0xd9, 0x4a, 0x34, 0x85, 0xee, 0x41, 0x69, 0xe2, 0xc5, 0x74, 0xe9, 0x05, 0xcc, 0x71,
-	0x4d, 0x0f, 0x60, 0xa6, 0x01, 0xe5, 0x20, 0xfd, 0xa2, 0xd5, 0xab, 0x24, 0x50, 0x1e, 0x8c, 0xce,
-	0x61, 0xb7, 0x57, 0x49, 0x72, 0x56, 0xe7, 0x75, 0xaf, 0x92, 0x42, 0x00, 0xd9, 0xdd, 0xd6, 0x7e,
-	0xab, 0xd7, 0xaa, 0xa4, 0x51, 0x01, 0x32, 0x9d, 0xed, 0xde, 0x4e, 0xbb, 0x62, 0xa0, 0x22, 0xe4,
-	0x0e, 0x3b, 0xbd, 0xbd, 0xc3, 0x83, 0x6e, 0x25, 0xc3, 0x89, 0x9d, 0xc3, 0x83, 0x83, 0xd6, 0x4e,
-	0xaf, 0x92, 0xe5, 0x3a, 0xda, 0xad, 0xed, 0xdd, 0x4a, 0x8e, 0xc3, 0x7b, 0x78, 0x7b, 0xa7, 0x55,
-	0xc9, 0x37, 0xb3, 0x60, 0xb0, 0xa9, 0x4f, 0xcc, 0xdf, 0x24, 0x21, 0xdb, 0x95, 0x3e, 0xde, 0x5d,
-	0xb2, 0xe4, 0xc5, 0x1c, 0x93, 0xe0, 0xff, 0x76, 0xb9, 0x77, 0xe6, 0x96, 0xcb, 0x2d, 0xec, 0xf5,
-	0x3a, 0x95, 0x04, 0xb7, 0x90, 0x8f, 0xba, 0x95, 0x64, 0x64, 0x61, 0x0f, 0x0a, 0x7b, 0x9d, 0x6d,
-	0xdb, 0x0e, 0x48, 0xc8, 0x9b, 0x9d, 0xe1, 0xf8, 0xef, 0xbe, 0x14, 0xd6, 0xe5, 0x78, 0x34, 0x39,
-	0x85, 0xbe, 0x10, 0xdc, 0x27, 0x6a, 0x9b, 0x7e, 0xb4, 0x60, 0xf3, 0x5e, 0xe7, 0xdd, 0x13, 0x05,
-	0x7e, 0xd2, 0x34, 0x20, 0xe5, 0xf8, 0xe6, 0x26, 0x18, 0x9c, 0xcb, 0xbb, 0xe7, 0x91, 0x13, 0x84,
-	0xb2, 0x8a, 0x65, 0xb1, 0x24, 0x78, 0x5d, 0x74, 0xad, 0x50, 0x56, 0xfe, 0x2c, 0x16, 0x63, 0x73,
-	0x1f, 0xa0, 0x37, 0xf4, 0xb5, 0x21, 0x0f, 0xb8, 0x16, 0x55, 0x5c, 0x6a, 0x4b, 0x3e, 0xa8, 0x70,
-	0x38, 0xe5, 0xf8, 0xa2, 0xca, 0xf2, 0x1a, 0x9f, 0x12, 0x35, 0x5e, 0x8c, 0x4d, 0x1b, 0xd2, 0x2d,
-	0xca, 0xd5, 0x54, 0x8e, 0x03, 0x7f, 0xd8, 0x97, 0xbd, 0xbc, 0x3f, 0xa4, 0xb6, 0xcc, 0xfd, 0x72,
-	0x3b, 0x81, 0x57, 0xf8, 0x4c, 0x57, 0x4c, 0xec, 0x50, 0x9b, 0x70, 0x6c, 0x40, 0x42, 0xc2, 0xfa,
-	0x24, 0x08, 0x68, 0x20, 0xb1, 0x29, 0x8d, 0x15, 0x33, 0x2d, 0x3e, 0xc1, 0xb1, 0xcd, 0x0c, 0xa4,
-	0x89, 0x67, 0x9b, 0xff, 0x2e, 0x41, 0xbe, 0x67, 0xf9, 0xad, 0x77, 0xbc, 0x65, 0x3d, 0x82, 0xac,
-	0xdc, 0x85, 0xca, 0xec, 0x8f, 0x17, 0xf7, 0x6a, 0xb4, 0x3e, 0xac, 0xa0, 0xe8, 0x05, 0x14, 0xe5,
-	0xa8, 0x3f, 0x26, 0xcc, 0x52, 0x75, 0xe3, 0xfe, 0xb2, 0x5d, 0x2e, 0x3e, 0x52, 0x6f, 0x79, 0xb6,
-	0x4f, 0x1d, 0x8f, 0xbd, 0x24, 0xcc, 0xc2, 0x20, 0x45, 0xf9, 0x18, 0x7d, 0x1f, 0x8a, 0xb1, 0x4a,
-	0xa4, 0x42, 0x75, 0xa1, 0x09, 0x71, 0x3c, 0x7a, 0x05, 0x95, 0x18, 0x29, 0x8d, 0x31, 0xde, 0xcb,
-	0x98, 0xd5, 0x98, 0xbc, 0xb0, 0xe8, 0x15, 0xac, 0x8a, 0x03, 0x42, 0xdf, 0x76, 0x02, 0x59, 0x2e,
-	0x45, 0x17, 0x5e, 0x69, 0xac, 0x9f, 0xaf, 0xb1, 0xc3, 0x05, 0x76, 0x35, 0x1e, 0xaf, 0xf8, 0x73,
-	0x34, 0xfa, 0x52, 0x95, 0x57, 0x59, 0xea, 0x6f, 0x9f, 0xaf, 0x67, 0xae, 0x98, 0xfe, 0x3a, 0x09,
-	0xa5, 0xb8, 0xa9, 0xe8, 0x27, 0x90, 0x75, 0xad, 0x01, 0x71, 0x75, 0x55, 0x6d, 0x5c, 0x6d, 0x89,
-	0xf5, 0x7d, 0x21, 0xd4, 0xf2, 0x58, 0x30, 0xc5, 0x4a, 0x43, 0x6d, 0x0b, 0x8a, 0x31, 0x36, 0xaa,
-	0x40, 0xfa, 0x84, 0x4c, 0xd5, 0x31, 0x9a, 0x0f, 0xf9, 0x0e, 0x78, 0x67, 0xb9, 0x13, 0x7d, 0x25,
-	0x90, 0xc4, 0xb3, 0xd4, 0xd3, 0x64, 0xed, 0x5f, 0x39, 0x55, 0x97, 0x0f, 0xa1, 0x14, 0xc8, 0xca,
-	0xdd, 0x77, 0x3c, 0x47, 0x77, 0xfc, 0x07, 0x17, 0x2f, 0xaf, 0xae, 0x8a, 0xfd, 0x9e, 0xe7, 0x30,
-	0x7e, 0x00, 0x0e, 0x66, 0x24, 0xc2, 0x50, 0x0e, 0xd4, 0x5d, 0x40, 0x6a, 0xbc, 0xe0, 0x20, 0x30,
-	0xa7, 0x51, 0xca, 0x28, 0x95, 0xa5, 0x20, 0x46, 0x4b, 0x23, 0x95, 0x4e, 0xe2, 0xd9, 0x2a, 0x06,
-	0x0f, 0xae, 0xa8, 0xb2, 0xe5, 0xd9, 0xd2, 0xc8, 0x88, 0xac, 0x3d, 0x81, 0x7c, 0x97, 0x05, 0xc4,
-	0x1a, 0xef, 0x89, 0xeb, 0xc7, 0xc0, 0x0a, 0xd5, 0xde, 0xc4, 0x62, 0x2c, 0x0f, 0xe4, 0x7c, 0x5e,
-	0x58, 0x6f, 0x60, 0x45, 0xd5, 0xfe, 0x9a, 0x84, 0x62, 0x6c, 0xed, 0xe8, 0x2b, 0x48, 0x39, 0xb6,
-	0xf2, 0xd9, 0xe7, 0x97, 0x98, 0xa3, 0x3f, 0x88, 0x53, 0x8e, 0xcd, 0x37, 0x6c, 0xac, 0xe9, 0x2d,
-	0xdb, 0x2d, 0xb3, 0xfe, 0x13, 0xf5, 0xc3, 0x8d, 0xa8, 0x87, 0x4a, 0x07, 0xfc, 0xdf, 0x39, 0x15,
-	0x3c, 0x6a, 0xad, 0x73, 0x27, 0x44, 0xe3, 0xbc, 0x13, 0x62, 0x66, 0x76, 0x42, 0xac, 0xfd, 0x21,
-	0x09, 0xa5, 0x78, 0x28, 0x3e, 0x7c, 0x85, 0x2f, 0x00, 0x89, 0x3b, 0x47, 0x7f, 0x2e, 0xbd, 0x52,
-	0x97, 0x5d, 0x0b, 0x2a, 0x42, 0x28, 0xee, 0xe3, 0x4f, 0xa1, 0xc8, 0xb7, 0x92, 0xaa, 0xa3, 0x62,
-	0xe9, 0x65, 0x0c, 0x9c, 0x25, 0x0b, 0x68, 0xed, 0xb7, 0x29, 0x1e, 0x94, 0x28, 0xb8, 0xff, 0x03,
-	0x26, 0xef, 0xc1, 0x75, 0xad, 0x28, 0xbe, 0x13, 0xd2, 0x97, 0x69, 0xba, 0xa6, 0x34, 0xc5, 0xfc,
-	0xff, 0x19, 0xbf, 0xbb, 0x2b, 0x25, 0x83, 0x29, 0x23, 0xf2, 0x84, 0x68, 0xe0, 0x68, 0x93, 0x35,
-	0x39, 0x13, 0xdd, 0x87, 0x34, 0xa1, 0xa1, 0xaa, 0xe1, 0x8b, 0x97, 0xee, 0x16, 0x0d, 0x31, 0x07,
-	0xf0, 0x33, 0x11, 0xe1, 0xab, 0x37, 0x9f, 0xc2, 0xca, 0x7c, 0xc1, 0xe3, 0x07, 0x8b, 0xd7, 0x07,
-	0x3f, 0x3d, 0x38, 0xfc, 0xe6, 0xa0, 0x92, 0xe0, 0xc4, 0xde, 0x41, 0xf3, 0xf0, 0xf5, 0xc1, 0x6e,
-	0x25, 0x89, 0x4a, 0x90, 0x3f, 0x7c, 0xdd, 0x93, 0x54, 0x6a, 0xa6, 0x62, 0x0d, 0xf2, 0xdb, 0xbe,
-	0x23, 0x1a, 0x13, 0xaf, 0x34, 0xa2, 0x75, 0xa9, 0xea, 0x23, 0x09, 0x7e, 0x1d, 0x2b, 0x74, 0xa8,
-	0x2d, 0x20, 0x21, 0x7a, 0x0e, 0x59, 0xc1, 0xd6, 0xa5, 0xef, 0xee, 0xb2, 0xb7, 0x01, 0x89, 0x8d,
-	0x46, 0x58, 0x89, 0xd4, 0xfe, 0x96, 0x84, 0xbc, 0x66, 0x22, 0x0c, 0x05, 0x7e, 0xed, 0xb4, 0x1c,
-	0x8f, 0x04, 0x2a, 0xd0, 0x8d, 0x2b, 0x28, 0xab, 0xef, 0x68, 0x21, 0x41, 0xf2, 0xc3, 0x64, 0xa4,
-	0xa6, 0xf6, 0x0e, 0x56, 0xe6, 0xa7, 0x51, 0x15, 0x72, 0x63, 0x12, 0x86, 0xd6, 0xb1, 0x7e, 0x9a,
-	0xd0, 0x24, 0xdf, 0x57, 0xb3, 0xef, 0xab, 0xe7, 0x96, 0x88, 0xc1, 0x7d, 0xe1, 0x8c, 0xb9, 0x94,
-	0x7c, 0x65, 0x91, 0x04, 0x2f, 0x29, 0x01, 0xb1, 0x42, 0xea, 0xe9, 0x3b, 0xbe, 0xa4, 0x84, 0x3b,
-	0x85, 0xb3, 0x3a, 0x90, 0xd7, 0x67, 0xe9, 0x8b, 0x9f, 0x5d, 0xc4, 0x85, 0x73, 0xea, 0xeb, 0xaa,
-	0x2e, 0xc6, 0xd1, 0x23, 0x4a, 0x7a, 0xf6, 0x88, 0x62, 0xbe, 0x85, 0x6b, 0x0b, 0xd7, 0x06, 0xf4,
-	0x18, 0xf2, 0x01, 0x99, 0x3b, 0x2c, 0xdc, 0x3a, 0xf7, 0xb2, 0x81, 0x23, 0x28, 0xcf, 0x43, 0xd1,
-	0x75, 0xfa, 0xa1, 0xd0, 0x44, 0xf5, 0xba, 0xcb, 0x82, 0xdb, 0x55, 0x4c, 0xf3, 0x5b, 0x28, 0x6b,
-	0x61, 0xe9, 0xc4, 0x0f, 0xfc, 0x5c, 0x94, 0x4f, 0xa9, 0x78, 0x3e, 0xfd, 0x3e, 0x05, 0x88, 0x6f,
-	0xfa, 0xee, 0x64, 0x3c, 0xb6, 0x82, 0xa9, 0xbe, 0xaf, 0xfe, 0x00, 0xf2, 0x91, 0x55, 0x57, 0xbf,
-	0xb1, 0x46, 0x32, 0xbc, 0xc2, 0x30, 0x67, 0x4c, 0xfa, 0xa7, 0x8e, 0x67, 0xd3, 0x53, 0xf5, 0x49,
-	0xe0, 0xac, 0x6f, 0x04, 0x07, 0xfd, 0x3f, 0x18, 0x1e, 0xf5, 0x74, 0xd9, 0xbd, 0xb9, 0xb8, 0xbd,
-	0xc6, 0x3e, 0x9b, 0xf2, 0x9e, 0xcf, 0x51, 0xe8, 0x6b, 0x28, 0x32, 0xda, 0x8f, 0x56, 0x6d, 0x5c,
-	0xb2, 0x6a, 0x7e, 0xc8, 0x66, 0x34, 0x0a, 0xfd, 0x8f, 0xa0, 0x7c, 0x14, 0xd0, 0xf1, 0x4c, 0x3e,
-	0x73, 0xb9, 0x7c, 0x89, 0x4b, 0x68, 0xba, 0x09, 0x90, 0xa7, 0x13, 0x36, 0xa0, 0x13, 0xcf, 0x36,
-	0xff, 0x92, 0x84, 0xeb, 0x73, 0x1e, 0x53, 0xaf, 0x74, 0x5b, 0x90, 0xa2, 0x27, 0xe7, 0xd6, 0xc8,
-	0x25, 0x12, 0xf5, 0xc3, 0x93, 0x76, 0x02, 0xa7, 0xe8, 0x09, 0x7a, 0x12, 0x0f, 0xcd, 0xb2, 0x93,
-	0xd0, 0x5c, 0x02, 0xb4, 0x13, 0x2a, 0x78, 0xb5, 0x6d, 0x48, 0x1d, 0x9e, 0xa0, 0xe7, 0x20, 0x9e,
-	0xcb, 0xfa, 0xcc, 0x1a, 0xb8, 0xd1, 0xd5, 0xb2, 0xb6, 0xd4, 0x82, 0x1e, 0x87, 0x60, 0x08, 0xf5,
-	0x30, 0xe4, 0x2b, 0xd3, 0x65, 0x4f, 0x5c, 0xea, 0x9a, 0x56, 0xe8, 0x88, 0x63, 0x74, 0x88, 0xee,
-	0x42, 0x39, 0x9c, 0x0c, 0x87, 0x24, 0xe4, 0x27, 0xed, 0x89, 0x27, 0x0f, 0x32, 0x06, 0x2e, 0x29,
-	0xe6, 0x0e, 0xe7, 0x71, 0xd0, 0x91, 0xe5, 0xb8, 0x93, 0x80, 0x28, 0x90, 0xec, 0xee, 0x25, 0xc5,
-	0x94, 0xa0, 0x7b, 0x3c, 0xd3, 0x19, 0xf1, 0x86, 0xd3, 0xfe, 0x38, 0xec, 0xfb, 0x8f, 0x37, 0x45,
-	0xd8, 0x0d, 0x5c, 0x52, 0xdc, 0x97, 0x61, 0xe7, 0xf1, 0xe6, 0x59, 0xd4, 0xd6, 0x63, 0x55, 0x97,
-	0x63, 0xa8, 0xad, 0xc7, 0x0b, 0xa8, 0x2d, 0x11, 0xcd, 0x79, 0xd4, 0x16, 0x7a, 0x00, 0xd7, 0x98,
-	0x1b, 0x46, 0x5d, 0x47, 0x9a, 0x96, 0x15, 0xc0, 0x55, 0xe6, 0xea, 0xb7, 0x58, 0x61, 0x9d, 0xf9,
-	0x0f, 0x03, 0x0a, 0x91, 0x73, 0x50, 0x13, 0x0a, 0x3e, 0xb5, 0xfb, 0xc7, 0x01, 0x9d, 0xe8, 0x1b,
-	0xcb, 0xdd, 0xf3, 0x7d, 0xc9, 0x0b, 0xe1, 0x0b, 0x0e, 0x6d, 0x27, 0x70, 0xde, 0x57, 0xe3, 0xda,
-	0xaf, 0x0c, 0x51, 0x59, 0x05, 0x81, 0x9e, 0x83, 0x11, 0xd0, 0x53, 0x1d, 0x97, 0xcf, 0xaf, 0xa0,
-	0xab, 0x8e, 0xe9, 0x29, 0x16, 0x42, 0xb5, 0x3f, 0xa5, 0x21, 0x8d, 0xe9, 0xe9, 0x87, 0xee, 0xf9,
-	0x4b, 0xb7, 0xe1, 0x3a, 0x54, 0xc6, 0x24, 0x1c, 0x11, 0xbb, 0xcf, 0x17, 0x2d, 0xdd, 0x24, 0x63,
-	0xb3, 0x22, 0xf9, 0x1d, 0x6a, 0xcb, 0x18, 0x3e, 0x80, 0x6b, 0xc1, 0xc4, 0xf3, 0x1c, 0xef, 0x38,
-	0x06, 0x95, 0x01, 0x5a, 0x55, 0x13, 0x11, 0x76, 0x1d, 0x2a, 0x3c, 0xfe, 0x73, 0x5a, 0xa5, 0xf3,
-	0x57, 0x24, 0x3f, 0x42, 0x3e, 0x84, 0x0c, 0x4f, 0x46, 0xdd, 0x66, 0x17, 0xcf, 0x6c, 0xb3, 0x7c,
-	0xc4, 0x12, 0x89, 0xbe, 0x85, 0xb2, 0x6c, 0x60, 0xfd, 0xc1, 0x94, 0xeb, 0xaf, 0xe6, 0x84, 0x63,
-	0x9f, 0x5e, 0xd1, 0xb1, 0x75, 0xd9, 0xc1, 0x9a, 0x53, 0xde, 0xc2, 0xc4, 0xd9, 0xbf, 0x48, 0x66,
-	0x9c, 0xda, 0x1b, 0xa8, 0x9c, 0x05, 0x2c, 0xb9, 0x05, 0x6c, 0xc6, 0x6f, 0x01, 0xcb, 0x36, 0x5b,
-	0xd4, 0x29, 0x63, 0x37, 0x04, 0xde, 0x97, 0xc4, 0x1e, 0x6d, 0xfc, 0xc2, 0x80, 0xf4, 0xb6, 0xef,
-	0xa0, 0x37, 0x50, 0x8c, 0xd5, 0x05, 0x74, 0xf7, 0xe2, 0xaa, 0x21, 0x52, 0xb6, 0x76, 0xef, 0x2a,
-	0xa5, 0xc5, 0x4c, 0xa0, 0x57, 0x90, 0xd7, 0x7f, 0x24, 0xa0, 0xb5, 0x05, 0x99, 0x33, 0x7f, 0x4a,
-	0xd4, 0xee, 0x5c, 0x80, 0x88, 0x54, 0xee, 0x42, 0xba, 0x67, 0xf9, 0xe8, 0xe3, 0x65, 0x07, 0x40,
-	0xad, 0xe8, 0xd6, 0xb9, 0xa7, 0x43, 0x33, 0xfd, 0xcb, 0x54, 0x72, 0x33, 0x89, 0x5e, 0x43, 0x79,
-	0xee, 0x95, 0x0b, 0x7d, 0x76, 0xa5, 0x57, 0xb0, 0x8b, 0x34, 0x27, 0x36, 0x93, 0x68, 0x1b, 0x72,
-	0xfa, 0xaf, 0x9b, 0x73, 0xba, 0x49, 0xed, 0x93, 0x05, 0x7e, 0xec, 0xef, 0x20, 0x33, 0x81, 0x5c,
-	0x28, 0x74, 0x89, 0x7b, 0xb4, 0x33, 0x22, 0xc3, 0x13, 0xf4, 0xbd, 0x19, 0x58, 0xfe, 0xb3, 0x54,
-	0x8f, 0xff, 0xb3, 0x14, 0xe1, 0xb4, 0x75, 0xf5, 0xab, 0xc2, 0xb5, 0x37, 0x9b, 0x8f, 0xde, 0x3c,
-	0x3c, 0x76, 0xd8, 0x68, 0x32, 0xe0, 0x02, 0x1b, 0x4a, 0x5a, 0xff, 0x36, 0x36, 0x66, 0xff, 0x17,
-	0x6c, 0x1c, 0x13, 0x6f, 0x43, 0x1a, 0x3c, 0xc8, 0x8a, 0x13, 0xee, 0xa3, 0xff, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0xfd, 0xb6, 0x0b, 0x26, 0x2d, 0x1b, 0x00, 0x00,
+func init() { proto.RegisterFile("public.proto", fileDescriptor_public_292ab771697df96b) }
+
+var fileDescriptor_public_292ab771697df96b = []byte{
+	// 3768 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0xcd, 0x73, 0x1b, 0x47,
+	0x76, 0x17, 0xbe, 0x81, 0x07, 0x80, 0x04, 0x9b, 0x92, 0x16, 0x86, 0x36, 0xb2, 0x34, 0xb2, 0x64,
+	0x59, 0x76, 0x40, 0x9a, 0x5a, 0x69, 0xf5, 0xe1, 0x78, 0xc3, 0x0f, 0xc4, 0xe4, 0x2e, 0x45, 0xc2,
+	0x03, 0x28, 0x4e, 0x79, 0xb7, 0x82, 0x1a, 0x60, 0x9a, 0xe4, 0x98, 0x83, 0xe9, 0xd1, 0x7c, 0x90,
+	0x46, 0xaa, 0xf6, 0x94, 0x4b, 0xaa, 0x72, 0xd9, 0xaa, 0x54, 0x72, 0x4d, 0x55, 0x6e, 0xc9, 0x25,
+	0xc9, 0x1f, 0x90, 0x6b, 0x72, 0x4f, 0x55, 0xae, 0x49, 0x0e, 0xb9, 0xe4, 0x9e, 0x6b, 0x9c, 0xd4,
+	0xeb, 0x8f, 0xc1, 0x0c, 0x30, 0x20, 0x29, 0x39, 0xae, 0xca, 0x69, 0xa6, 0x5f, 0xff, 0xde, 0xeb,
+	0xd7, 0xdd, 0xaf, 0x5f, 0xbf, 0xee, 0xd7, 0x50, 0x73, 0xc3, 0xa1, 0x6d, 0x8d, 0xda, 0xae, 0xc7,
+	0x02, 0x46, 0x96, 0x6d, 0xcb, 0x39, 0xa5, 0x9e, 0xb9, 0xd1, 0x16, 0xe4, 0xd6, 0xed, 0x63, 0xc6,
+	0x8e, 0x6d, 0xba, 0xc6, 0xab, 0x87, 0xe1, 0xd1, 0x9a, 0x19, 0x7a, 0x46, 0x60, 0x31, 0x47, 0x30,
+	0xb4, 0x9a, 0x23, 0x36, 0x1e, 0x33, 0x67, 0xed, 0x84, 0x1a, 0x76, 0x70, 0x32, 0x3a, 0xa1, 0xa3,
+	0x53, 0x51, 0xa3, 0x95, 0xa0, 0xd0, 0x19, 0xbb, 0xc1, 0x44, 0x7b, 0x03, 0xd5, 0xdf, 0xa7, 0x9e,
+	0x6f, 0x31, 0x67, 0xcf, 0x39, 0x62, 0xe4, 0xc7, 0x50, 0x39, 0x66, 0x92, 0xd0, 0xcc, 0xdc, 0xc9,
+	0x3c, 0xac, 0xe8, 0x53, 0x02, 0xd6, 0x0e, 0x43, 0xcb, 0x36, 0x77, 0x8c, 0x80, 0x36, 0xb3, 0xa2,
+	0x36, 0x22, 0x90, 0x07, 0xb0, 0xe4, 0x51, 0x9b, 0x1a, 0x3e, 0x55, 0x02, 0x72, 0x1c, 0x32, 0x43,
+	0xd5, 0xd6, 0x60, 0x79, 0xdf, 0xf2, 0x83, 0x2e, 0x33, 0x7d, 0x9d, 0xbe, 0x09, 0xa9, 0x1f, 0xa0,
+	0x60, 0xc7, 0x18, 0x53, 0xdf, 0x35, 0x46, 0x54, 0x35, 0x1b, 0x11, 0xb4, 0xcf, 0xa0, 0x31, 0x65,
+	0xf0, 0x5d, 0xe6, 0xf8, 0x94, 0x3c, 0x84, 0xbc, 0xcb, 0x4c, 0xbf, 0x99, 0xb9, 0x93, 0x7b, 0x58,
+	0xdd, 0xb8, 0xde, 0x9e, 0x19, 0x9a, 0x76, 0x97, 0x99, 0x3a, 0x47, 0x68, 0x8f, 0x61, 0xf5, 0x15,
+	0xf5, 0x4f, 0xb6, 0xd9, 0x19, 0xf5, 0x8c, 0x63, 0x7a, 0xb5, 0x26, 0xbf, 0x86, 0xeb, 0x49, 0x26,
+	0xd9, 0xec, 0x16, 0x40, 0x04, 0x52, 0x8d, 0x6b, 0x73, 0x8d, 0x1f, 0x28, 0x48, 0xc4, 0x1f, 0xe3,
+	0xd2, 0xfe, 0x39, 0x0b, 0x2b, 0x73, 0x88, 0x8b, 0xf5, 0x21, 0x0f, 0xa1, 0x31, 0xa6, 0xfe, 0x09,
+	0x35, 0x07, 0x2e, 0x33, 0x07, 0x23, 0x16, 0x3a, 0x01, 0x9f, 0x80, 0xbc, 0xbe, 0x24, 0xe8, 0x5d,
+	0x66, 0x6e, 0x23, 0x95, 0x7c, 0x02, 0x24, 0x74, 0xe6, 0xb0, 0x39, 0x8e, 0x6d, 0x84, 0xce, 0x0c,
+	0x7a, 0x37, 0x86, 0x3e, 0x67, 0xde, 0xa9, 0xcd, 0x0c, 0xd3, 0x6f, 0xe6, 0x79, 0xbf, 0xde, 0x9b,
+	0xeb, 0x97, 0x4e, 0x7d, 0x16, 0x7a, 0x23, 0xaa, 0xaf, 0x28, 0xa6, 0xaf, 0x14, 0x0f, 0xd9, 0x80,
+	0x1b, 0x33, 0x72, 0x64, 0xd3, 0x05, 0xde, 0xf4, 0x6a, 0x12, 0x2f, 0x5a, 0xdf, 0x83, 0x25, 0xd7,
+	0x63, 0xdf, 0x4e, 0x06, 0x67, 0xc2, 0x34, 0xfc, 0x66, 0x71, 0xc1, 0x88, 0x76, 0x11, 0x26, 0x0d,
+	0x88, 0xf3, 0xea, 0x75, 0x37, 0x46, 0xf2, 0xb5, 0x9f, 0xc3, 0xca, 0x1c, 0x86, 0x34, 0xa1, 0x74,
+	0x96, 0xb0, 0x65, 0x55, 0x24, 0xb7, 0xa0, 0x32, 0x3b, 0x90, 0x65, 0x57, 0x0e, 0x8a, 0xf6, 0x0c,
+	0x6e, 0xa0, 0xbd, 0x45, 0x73, 0x14, 0x99, 0xe9, 0xfb, 0x50, 0x0d, 0xac, 0x31, 0x1d, 0x9c, 0x5b,
+	0x8e, 0xc9, 0xce, 0xa5, 0x4c, 0x40, 0xd2, 0x57, 0x9c, 0xa2, 0xfd, 0x12, 0x6e, 0xce, 0x72, 0x4a,
+	0xc3, 0xd9, 0x4c, 0x31, 0x9c, 0xbb, 0x8b, 0x0d, 0xa7, 0x17, 0x8e, 0xc7, 0x86, 0x37, 0x49, 0xd8,
+	0xcd, 0x77, 0x19, 0x68, 0xcc, 0x02, 0x08, 0x81, 0x3c, 0x42, 0xa4, 0x2e, 0xfc, 0xff, 0x07, 0x33,
+	0x96, 0x87, 0xd0, 0x38, 0x32, 0x2c, 0x3b, 0x81, 0xcd, 0x0b, 0xb9, 0x82, 0x1e, 0x21, 0xef, 0x42,
+	0x4d, 0x4c, 0xac, 0xe5, 0x7c, 0x43, 0x47, 0xc2, 0x06, 0x2a, 0x7a, 0x95, 0xd3, 0xf6, 0x38, 0x89,
+	0x7c, 0x0a, 0x05, 0x3f, 0x30, 0x02, 0x9c, 0xf2, 0xcc, 0xc3, 0xea, 0xc6, 0xad, 0xb9, 0xb1, 0xd8,
+	0x32, 0x7c, 0x6b, 0xd4, 0x43, 0x88, 0x2e, 0x90, 0xda, 0x6f, 0x0a, 0x90, 0xeb, 0x32, 0x33, 0xb5,
+	0xcf, 0xd7, 0xa1, 0xe0, 0x32, 0x73, 0xaf, 0x2b, 0xdd, 0x92, 0x28, 0x90, 0x3b, 0x00, 0x26, 0x75,
+	0x6d, 0x36, 0x19, 0x53, 0xd9, 0xaf, 0xca, 0xee, 0x35, 0x3d, 0x46, 0x23, 0x77, 0xa1, 0xea, 0x51,
+	0xd7, 0xb6, 0x46, 0xc6, 0xc0, 0xa7, 0x41, 0x13, 0x14, 0x44, 0x12, 0x7b, 0x34, 0x20, 0x3f, 0x85,
+	0x9b, 0xb2, 0x84, 0xae, 0x75, 0x30, 0x62, 0x4e, 0xe0, 0x31, 0xdb, 0xa6, 0x5e, 0xb3, 0x2a, 0xd1,
+	0x37, 0x62, 0xf5, 0xdb, 0x51, 0x35, 0xb9, 0x07, 0x35, 0x54, 0x9c, 0x1e, 0x85, 0x36, 0x17, 0x5e,
+	0x93, 0xf0, 0xaa, 0xa2, 0xa2, 0xf4, 0xf7, 0x01, 0x4c, 0x83, 0x8e, 0x99, 0xc3, 0x21, 0x75, 0x09,
+	0xa9, 0x08, 0x1a, 0x02, 0x08, 0xe4, 0xbe, 0x61, 0xc3, 0xe6, 0x92, 0xac, 0xc1, 0x02, 0xb9, 0x09,
+	0x45, 0x94, 0x11, 0xfa, 0x7c, 0xfc, 0x2b, 0xba, 0x2c, 0xe1, 0x28, 0x18, 0xa6, 0x49, 0x4d, 0x3e,
+	0xe0, 0x65, 0x5d, 0x14, 0xc8, 0x36, 0x2c, 0xfb, 0x96, 0x33, 0xa2, 0xfb, 0x86, 0x1f, 0xe8, 0xd4,
+	0x65, 0x5e, 0x20, 0x07, 0xfd, 0xbd, 0xb6, 0xd8, 0x40, 0xda, 0x6a, 0x03, 0x69, 0xef, 0xc8, 0x0d,
+	0x44, 0x9f, 0xe5, 0x20, 0xeb, 0xb0, 0x3a, 0xed, 0x79, 0x64, 0x86, 0xcd, 0x12, 0x6f, 0x3f, 0xad,
+	0x8a, 0x68, 0x50, 0x93, 0xe4, 0xae, 0x6d, 0x38, 0xb4, 0x59, 0xe6, 0x3a, 0x25, 0x68, 0xe4, 0x53,
+	0x28, 0x86, 0x2e, 0x2e, 0xa0, 0x66, 0xe5, 0x32, 0x8d, 0x24, 0x90, 0xdc, 0x06, 0xe0, 0x76, 0xa4,
+	0x53, 0xc3, 0x9c, 0x34, 0x97, 0xb9, 0xd0, 0x18, 0x05, 0x9b, 0x8d, 0xbb, 0x86, 0x66, 0x83, 0x6b,
+	0x98, 0xa0, 0x91, 0xcf, 0x41, 0xd8, 0xe2, 0x36, 0x73, 0x8e, 0xac, 0xe3, 0xe6, 0x0a, 0x6f, 0xfb,
+	0xc7, 0xe9, 0x5e, 0x47, 0x60, 0xf4, 0x38, 0xc3, 0x56, 0x09, 0x0a, 0xec, 0xdc, 0xa1, 0x9e, 0xf6,
+	0x0f, 0x79, 0xa8, 0xc6, 0x50, 0x17, 0x7b, 0x1c, 0x9b, 0x1d, 0x0f, 0x6c, 0x7a, 0x46, 0x6d, 0x69,
+	0xa4, 0x65, 0x9b, 0x1d, 0xef, 0x63, 0x19, 0x1d, 0x8b, 0x58, 0x29, 0x83, 0x31, 0x33, 0xa9, 0xdc,
+	0x37, 0x41, 0x90, 0x5e, 0x31, 0x93, 0xe2, 0x42, 0xb5, 0x9c, 0x21, 0x0b, 0x1d, 0x73, 0xe0, 0x9f,
+	0x5a, 0xee, 0x00, 0xa7, 0x44, 0x4d, 0x7e, 0x43, 0xd6, 0xf4, 0x4e, 0x2d, 0xb7, 0x8b, 0x74, 0xd2,
+	0x86, 0x55, 0x16, 0x06, 0x73, 0x70, 0xb1, 0x0a, 0x57, 0x54, 0xd5, 0x14, 0xbf, 0x01, 0x37, 0x92,
+	0x78, 0x3f, 0x1c, 0x3a, 0x54, 0xae, 0xcd, 0x8a, 0xbe, 0x1a, 0xe7, 0xe8, 0x89, 0x2a, 0x5c, 0xe2,
+	0xcc, 0x35, 0xde, 0x84, 0x54, 0x0a, 0x17, 0x86, 0x50, 0x15, 0x34, 0x21, 0xb6, 0x01, 0xb9, 0xc0,
+	0xf6, 0xe5, 0xbc, 0xe3, 0x2f, 0xf6, 0x73, 0xe4, 0x86, 0x03, 0x4f, 0xf8, 0x53, 0x3e, 0xe7, 0x15,
+	0x1d, 0x46, 0x6e, 0xa8, 0x3c, 0xec, 0x2d, 0xa8, 0x20, 0xc0, 0xb6, 0xc6, 0x96, 0x5c, 0x8c, 0x7a,
+	0x79, 0xe4, 0x86, 0xfb, 0x58, 0x26, 0xf7, 0x61, 0x69, 0x4c, 0xc7, 0xcc, 0x9b, 0x44, 0x02, 0xf8,
+	0x02, 0xd4, 0xeb, 0x82, 0xaa, 0x64, 0xdc, 0x85, 0x9a, 0x84, 0x09, 0x31, 0x35, 0xa1, 0x99, 0xa0,
+	0x09, 0x49, 0x7b, 0x50, 0xc1, 0x7d, 0xd7, 0xb3, 0x4c, 0xea, 0x37, 0xeb, 0xdc, 0x19, 0x7f, 0x7c,
+	0xd1, 0xec, 0xb7, 0x0f, 0x15, 0xba, 0xe3, 0x04, 0xde, 0x44, 0x9f, 0x72, 0xb7, 0x3e, 0x83, 0xa5,
+	0x64, 0x25, 0x76, 0xfb, 0x94, 0x4e, 0xe4, 0xfc, 0xe3, 0x2f, 0x2e, 0xcb, 0x33, 0xc3, 0x0e, 0x55,
+	0xcc, 0x24, 0x0a, 0x2f, 0xb2, 0xcf, 0x32, 0xda, 0xdf, 0x64, 0x01, 0xfa, 0x86, 0xab, 0x54, 0x27,
+	0x90, 0x73, 0x99, 0xd9, 0xcc, 0xa8, 0xb5, 0xee, 0x32, 0x73, 0xc6, 0x87, 0x65, 0x53, 0x7c, 0xd8,
+	0x4d, 0x28, 0x8e, 0x8d, 0x6f, 0x75, 0xd7, 0xe7, 0x86, 0x93, 0xd5, 0x65, 0x09, 0xe9, 0x01, 0xc3,
+	0xa9, 0xe0, 0x86, 0x52, 0xd7, 0x65, 0x09, 0xfd, 0x67, 0xc0, 0xf6, 0xba, 0xd2, 0x1e, 0xf8, 0x3f,
+	0x69, 0x41, 0xf9, 0xc8, 0x63, 0xe3, 0xae, 0x72, 0x0e, 0x75, 0x3d, 0x2a, 0xa3, 0x1c, 0xfc, 0xdf,
+	0xeb, 0xca, 0x49, 0x96, 0x25, 0xa4, 0xfb, 0xa3, 0x13, 0x3a, 0x16, 0x4b, 0xbb, 0xa2, 0xcb, 0x12,
+	0xd7, 0x87, 0x06, 0x27, 0xcc, 0x94, 0x13, 0x2c, 0x4b, 0x18, 0xe2, 0x18, 0x61, 0x70, 0xc2, 0x3c,
+	0x2b, 0x98, 0xc8, 0xc9, 0x9d, 0x12, 0x50, 0x2b, 0xd7, 0x08, 0x4e, 0xe4, 0x9c, 0xf2, 0xff, 0x17,
+	0xd9, 0x66, 0x66, 0xab, 0x0c, 0xc5, 0xc0, 0xf0, 0x8e, 0x69, 0xa0, 0xfd, 0x63, 0x11, 0xae, 0xf7,
+	0x0d, 0x77, 0x6b, 0x12, 0xc5, 0x21, 0x72, 0xd8, 0x5e, 0x28, 0x08, 0x1f, 0xb9, 0xb4, 0xf8, 0x41,
+	0x71, 0xf4, 0xa8, 0x4d, 0x47, 0xc2, 0x9d, 0x08, 0x0e, 0xb2, 0x09, 0x85, 0xb1, 0x11, 0x8c, 0x4e,
+	0xf8, 0xc8, 0xa6, 0x99, 0x41, 0x5a, 0x8b, 0xed, 0x57, 0xc8, 0xa2, 0x0b, 0xce, 0x45, 0xe3, 0xdf,
+	0xfa, 0x8b, 0x02, 0x14, 0x38, 0x90, 0x6c, 0x43, 0xce, 0xb0, 0x6d, 0xa9, 0xdd, 0xda, 0x5b, 0x34,
+	0xd1, 0xee, 0xd1, 0x37, 0x68, 0x08, 0x86, 0x6d, 0x73, 0x21, 0xce, 0xa4, 0x99, 0x7d, 0x77, 0x21,
+	0xce, 0x84, 0xfc, 0x0c, 0x72, 0x0e, 0x13, 0x5b, 0xe1, 0xdb, 0x75, 0x16, 0x05, 0x38, 0x0c, 0x23,
+	0xc6, 0x9a, 0x49, 0xfd, 0xc0, 0x72, 0xb8, 0x57, 0x16, 0x3e, 0xe8, 0x4a, 0x23, 0xbe, 0x7b, 0x4d,
+	0x4f, 0x70, 0x92, 0xdf, 0x83, 0xfc, 0x49, 0x10, 0xb8, 0xdc, 0x0c, 0xab, 0x1b, 0xeb, 0x6f, 0xd3,
+	0xa1, 0xdd, 0x20, 0x70, 0x77, 0xaf, 0xe9, 0x9c, 0x9f, 0x7c, 0x04, 0xcb, 0x02, 0x33, 0xb0, 0x4c,
+	0xea, 0x04, 0x68, 0x5c, 0x45, 0xb9, 0x4a, 0x96, 0x44, 0xc5, 0x9e, 0xa4, 0x93, 0xc7, 0x70, 0x3d,
+	0xa6, 0xc2, 0x14, 0x5f, 0x92, 0xf8, 0xd5, 0x58, 0xad, 0x62, 0x6a, 0xed, 0x43, 0xae, 0x47, 0xdf,
+	0x90, 0x0e, 0x94, 0xf8, 0x74, 0x47, 0xe1, 0xdb, 0x5b, 0x99, 0x8a, 0xe2, 0x6d, 0x4d, 0x20, 0x8f,
+	0xda, 0x93, 0x66, 0xb4, 0x78, 0xd4, 0x6a, 0x57, 0xcb, 0xa7, 0x19, 0x2d, 0x1f, 0xb5, 0xd8, 0xd5,
+	0x02, 0xba, 0x1d, 0x5f, 0x40, 0x2a, 0x9a, 0x99, 0x92, 0xc8, 0x75, 0xb9, 0x84, 0xf2, 0xb2, 0x8a,
+	0x97, 0x70, 0xb3, 0xe2, 0x8d, 0x47, 0x3f, 0xda, 0x7f, 0x65, 0x00, 0x50, 0x89, 0x57, 0x42, 0xec,
+	0x2e, 0x80, 0x47, 0x8f, 0x2d, 0x3f, 0xa0, 0x1e, 0x15, 0xce, 0x67, 0x69, 0xe3, 0xc1, 0x5c, 0xe7,
+	0xa6, 0x0c, 0x6d, 0x3d, 0x42, 0x8b, 0x50, 0x49, 0x95, 0xc8, 0x07, 0x50, 0x0b, 0x9d, 0x98, 0x2c,
+	0xd5, 0x81, 0x04, 0x55, 0x73, 0x00, 0xa6, 0x12, 0x48, 0x09, 0x72, 0x5f, 0x74, 0xfa, 0x8d, 0x6b,
+	0xa4, 0x0c, 0xf9, 0xee, 0x61, 0xaf, 0xdf, 0xc8, 0x20, 0xa9, 0xfb, 0xba, 0xdf, 0xc8, 0x12, 0x80,
+	0xe2, 0x4e, 0x67, 0xbf, 0xd3, 0xef, 0x34, 0x72, 0xa4, 0x02, 0x85, 0xee, 0x66, 0x7f, 0x7b, 0xb7,
+	0x91, 0x27, 0x55, 0x28, 0x1d, 0x76, 0xfb, 0x7b, 0x87, 0x07, 0xbd, 0x46, 0x01, 0x0b, 0xdb, 0x87,
+	0x07, 0x07, 0x9d, 0xed, 0x7e, 0xa3, 0x88, 0x32, 0x76, 0x3b, 0x9b, 0x3b, 0x8d, 0x12, 0xc2, 0xfb,
+	0xfa, 0xe6, 0x76, 0xa7, 0x51, 0xde, 0x2a, 0x42, 0x3e, 0x98, 0xb8, 0x54, 0xfb, 0xcb, 0x0c, 0x14,
+	0x7b, 0x62, 0x8c, 0x77, 0x52, 0xba, 0x3c, 0x6f, 0xc3, 0x02, 0xfc, 0x7d, 0xbb, 0x7b, 0x37, 0xd1,
+	0x5d, 0xd4, 0xb0, 0xdf, 0xef, 0x36, 0xae, 0xa1, 0x86, 0xf8, 0xd7, 0x6b, 0x64, 0x22, 0x0d, 0xfb,
+	0x50, 0xd9, 0xeb, 0x6e, 0x9a, 0xa6, 0x47, 0x7d, 0x0c, 0xe6, 0xf2, 0x96, 0x7b, 0xf6, 0x13, 0xae,
+	0x5d, 0x09, 0x67, 0x13, 0x4b, 0xe4, 0x63, 0x4e, 0x7d, 0x2a, 0xdd, 0xc0, 0x8d, 0x39, 0x9d, 0xf7,
+	0xba, 0x67, 0x4f, 0x25, 0xf8, 0xe9, 0x56, 0x1e, 0xb2, 0x96, 0xab, 0xad, 0x43, 0x1e, 0xa9, 0xb8,
+	0x0d, 0x1d, 0x59, 0x9e, 0x2f, 0xbc, 0x64, 0x51, 0x17, 0x05, 0xf4, 0xbb, 0xb6, 0xe1, 0x8b, 0x9d,
+	0xa5, 0xa8, 0xf3, 0x7f, 0x6d, 0x1f, 0xa0, 0x3f, 0x72, 0x95, 0x22, 0x8f, 0x50, 0x8a, 0x74, 0x5e,
+	0xad, 0x94, 0x06, 0x25, 0x4e, 0xcf, 0x5a, 0x2e, 0xf7, 0xe2, 0xcc, 0x13, 0xd2, 0xea, 0x3a, 0xff,
+	0xd7, 0x4c, 0xc8, 0x75, 0x18, 0x8a, 0x69, 0x1c, 0x7b, 0xee, 0x68, 0x20, 0x62, 0xd5, 0xc1, 0x08,
+	0x23, 0x1d, 0x14, 0x5a, 0xc7, 0x85, 0x8a, 0x35, 0x3d, 0x5e, 0xb1, 0x8d, 0xf1, 0xce, 0x23, 0x68,
+	0x78, 0xd4, 0xa7, 0xc1, 0x80, 0x7a, 0x1e, 0xf3, 0x04, 0x36, 0xab, 0xb0, 0xbc, 0xa6, 0x83, 0x15,
+	0x88, 0xdd, 0x2a, 0x40, 0x8e, 0x3a, 0xa6, 0xf6, 0x3f, 0x35, 0x28, 0xf7, 0x0d, 0xb7, 0x73, 0x86,
+	0x5b, 0xe2, 0x63, 0x28, 0x8a, 0x55, 0xd8, 0xcc, 0x2c, 0x38, 0x5e, 0x4c, 0xfb, 0xa7, 0x4b, 0x28,
+	0xf9, 0x02, 0xaa, 0xe2, 0x6f, 0x30, 0xa6, 0x81, 0x21, 0xfd, 0xd2, 0x83, 0xb4, 0x55, 0xce, 0x1b,
+	0x69, 0x77, 0x1c, 0xd3, 0x65, 0x96, 0x13, 0xbc, 0xa2, 0x81, 0xa1, 0x83, 0x60, 0xc5, 0x7f, 0xf2,
+	0x3b, 0x50, 0x8d, 0x39, 0x92, 0x66, 0xf6, 0x72, 0x15, 0xe2, 0x78, 0xf2, 0x25, 0x34, 0x62, 0x45,
+	0xa1, 0x4c, 0xfe, 0xad, 0x94, 0x59, 0x8e, 0xf1, 0x73, 0x8d, 0xbe, 0x84, 0x65, 0x71, 0x20, 0x33,
+	0x2d, 0x4f, 0xb8, 0x63, 0xee, 0x23, 0x97, 0x36, 0x1e, 0x2e, 0x96, 0xc8, 0xe3, 0x9f, 0x1d, 0x85,
+	0xd7, 0x97, 0xdc, 0x44, 0x99, 0xfc, 0x44, 0xba, 0x6f, 0xb1, 0x95, 0xdc, 0x5e, 0x2c, 0x27, 0xee,
+	0xac, 0x5b, 0x7f, 0x9e, 0x81, 0x5a, 0x5c, 0x55, 0xf2, 0x73, 0x28, 0xda, 0xc6, 0x90, 0xda, 0xca,
+	0xab, 0x6e, 0x5c, 0xad, 0x8b, 0xed, 0x7d, 0xce, 0x24, 0xc2, 0x31, 0x29, 0xa1, 0xf5, 0x1c, 0xaa,
+	0x31, 0xf2, 0xdb, 0x04, 0x62, 0xad, 0xef, 0x4a, 0xd2, 0x2f, 0x1f, 0x42, 0x4d, 0x46, 0x97, 0x03,
+	0xcb, 0xb1, 0x54, 0x44, 0xf1, 0xe8, 0xe2, 0xee, 0xb5, 0xa5, 0xb3, 0xdf, 0x73, 0xac, 0x00, 0x0f,
+	0x78, 0xde, 0xb4, 0x48, 0x74, 0xa8, 0x7b, 0xf2, 0x16, 0x40, 0x48, 0xbc, 0x20, 0xd0, 0x48, 0x48,
+	0x14, 0x3c, 0x52, 0x64, 0xcd, 0x8b, 0x95, 0x85, 0x92, 0x52, 0x26, 0x75, 0xcc, 0x66, 0xee, 0x8a,
+	0x4a, 0x0a, 0x96, 0x8e, 0x63, 0x0a, 0x25, 0xa3, 0x62, 0xeb, 0x29, 0x94, 0x7b, 0x81, 0x47, 0x8d,
+	0xf1, 0x1e, 0x3f, 0x5e, 0x0f, 0x0d, 0x5f, 0xae, 0x4d, 0x9d, 0xff, 0x8b, 0x03, 0x27, 0xd6, 0xcb,
+	0x8b, 0x04, 0x59, 0x6a, 0xfd, 0x6b, 0x06, 0xaa, 0xb1, 0xbe, 0x93, 0x9f, 0x42, 0xd6, 0x32, 0xe5,
+	0x98, 0x7d, 0x78, 0x89, 0x3a, 0xaa, 0x41, 0x3d, 0x6b, 0x99, 0xb8, 0x60, 0x63, 0x9b, 0x5e, 0xda,
+	0x6a, 0x99, 0xee, 0x3f, 0xd1, 0x7e, 0xb8, 0x16, 0xed, 0xa1, 0x62, 0x00, 0x7e, 0xb4, 0xc0, 0x83,
+	0x47, 0x5b, 0x6b, 0x22, 0x02, 0xcd, 0x2f, 0x8a, 0x40, 0x0b, 0xd3, 0x08, 0xb4, 0xf5, 0xf7, 0x19,
+	0xa8, 0xc5, 0xa7, 0xe2, 0xdd, 0x7b, 0xf8, 0x05, 0x10, 0x7e, 0xa6, 0x1e, 0x24, 0xcc, 0x2b, 0x7b,
+	0xd9, 0xb1, 0xb7, 0xc1, 0x99, 0xe2, 0x63, 0xfc, 0x3e, 0x54, 0x71, 0x29, 0x49, 0x3f, 0xca, 0xbb,
+	0x5e, 0xd7, 0x01, 0x49, 0xc2, 0x81, 0xb6, 0xfe, 0x3a, 0x0b, 0x55, 0xa5, 0x73, 0xc7, 0x31, 0xff,
+	0x1f, 0xa8, 0xbc, 0x07, 0xab, 0x4a, 0x50, 0x7c, 0x25, 0xe4, 0x2e, 0x93, 0xb4, 0x22, 0x25, 0xc5,
+	0xc6, 0xff, 0x3e, 0xde, 0x32, 0x4b, 0x21, 0xc3, 0x49, 0x40, 0x7d, 0x79, 0x05, 0x15, 0x2d, 0xb2,
+	0x2d, 0x24, 0x92, 0x07, 0x90, 0xa3, 0xcc, 0x97, 0x3e, 0x7c, 0xfe, 0x7a, 0xb8, 0xc3, 0x7c, 0x1d,
+	0x01, 0x18, 0x13, 0x51, 0xec, 0xbd, 0xf6, 0x0c, 0x96, 0x92, 0x0e, 0x0f, 0x03, 0x8b, 0xd7, 0x07,
+	0xbf, 0x38, 0x38, 0xfc, 0xea, 0xa0, 0x71, 0x0d, 0x0b, 0x7b, 0x07, 0x5b, 0x87, 0xaf, 0x0f, 0x76,
+	0x1a, 0x19, 0x52, 0x83, 0xf2, 0xe1, 0xeb, 0xbe, 0x28, 0x65, 0xa7, 0x22, 0xee, 0x40, 0x79, 0xd3,
+	0xb5, 0xf8, 0xc6, 0x84, 0x9e, 0x86, 0x6f, 0x5d, 0xd2, 0xfb, 0x88, 0x02, 0x1e, 0xf7, 0x2a, 0x5d,
+	0x66, 0x72, 0x88, 0x4f, 0x5e, 0x42, 0x91, 0x93, 0x95, 0xeb, 0xbb, 0x97, 0x76, 0x8b, 0x2d, 0xb0,
+	0xd1, 0x9f, 0x2e, 0x59, 0x5a, 0xff, 0x96, 0x81, 0xb2, 0x22, 0x12, 0x1d, 0x2a, 0x23, 0xe6, 0x04,
+	0x86, 0xe5, 0x50, 0x4f, 0x4e, 0xf4, 0xc6, 0x15, 0x84, 0xb5, 0xb7, 0x15, 0x13, 0x2f, 0x62, 0x30,
+	0x19, 0x89, 0x69, 0x9d, 0xc1, 0x52, 0xb2, 0x1a, 0x2f, 0x37, 0xc6, 0xd4, 0xf7, 0x8d, 0x63, 0x75,
+	0xf5, 0xa6, 0x8a, 0xb8, 0xae, 0xa6, 0xed, 0xcb, 0xc4, 0x40, 0x44, 0xc0, 0xb1, 0xb0, 0xc6, 0xc8,
+	0x25, 0xee, 0x35, 0x44, 0x01, 0x5d, 0x8a, 0x47, 0x0d, 0x9f, 0x39, 0xea, 0x0e, 0x4b, 0x94, 0xf8,
+	0x70, 0xf2, 0xc1, 0xea, 0x42, 0x59, 0xc5, 0xd2, 0x97, 0xdc, 0x8e, 0x13, 0x11, 0x3e, 0xc9, 0x96,
+	0xf9, 0x7f, 0x74, 0x49, 0x98, 0x9b, 0x5e, 0x12, 0x6a, 0x6f, 0x60, 0x65, 0xee, 0x58, 0x42, 0x9e,
+	0x40, 0xd9, 0xa3, 0x89, 0x60, 0xe1, 0x82, 0x8b, 0xef, 0x08, 0x8a, 0x76, 0xc8, 0x77, 0x9d, 0x81,
+	0xcf, 0x25, 0x31, 0xd5, 0xef, 0x3a, 0xa7, 0xf6, 0x24, 0x51, 0xfb, 0x15, 0xd4, 0x15, 0xb3, 0x18,
+	0xc4, 0x77, 0x6c, 0x2e, 0xb2, 0xa7, 0x6c, 0xdc, 0x9e, 0x7e, 0x93, 0x03, 0x82, 0x8b, 0x5e, 0x5d,
+	0x17, 0xcb, 0xf3, 0xf0, 0xe7, 0x50, 0x8e, 0xb4, 0xba, 0xfa, 0x89, 0x38, 0xe2, 0x99, 0xbd, 0xe7,
+	0xce, 0xce, 0xde, 0x73, 0x93, 0x4f, 0x20, 0xef, 0x30, 0x47, 0xb9, 0xdd, 0x9b, 0xf3, 0xcb, 0x0b,
+	0x73, 0x4b, 0xb8, 0xe7, 0x23, 0x8a, 0x7c, 0x06, 0xd5, 0x80, 0x0d, 0xa2, 0x5e, 0xe7, 0x2f, 0xe9,
+	0x35, 0x06, 0xd9, 0x01, 0x8b, 0xa6, 0xfe, 0x77, 0xa1, 0x8e, 0xf7, 0x0d, 0x53, 0xfe, 0xc2, 0xe5,
+	0xfc, 0x35, 0xe4, 0x88, 0x24, 0xfc, 0x08, 0x4a, 0x2e, 0xf5, 0xf0, 0xd2, 0x9a, 0x07, 0x3d, 0x65,
+	0xbd, 0xe8, 0x52, 0x0f, 0x2f, 0x92, 0xef, 0x42, 0x6d, 0xe8, 0x51, 0xe3, 0xd4, 0x64, 0xe7, 0xce,
+	0x60, 0x38, 0x51, 0x77, 0x58, 0x11, 0x6d, 0x6b, 0x42, 0xee, 0x41, 0xdd, 0xa4, 0xc3, 0xf0, 0x78,
+	0xf0, 0x26, 0xa4, 0x9e, 0x45, 0xd5, 0x6d, 0x56, 0x8d, 0x13, 0xbf, 0x14, 0xb4, 0x2d, 0x80, 0xb2,
+	0xba, 0x22, 0xd3, 0xfe, 0x2c, 0x0b, 0xab, 0x89, 0x29, 0x91, 0x09, 0x80, 0xe7, 0x90, 0x65, 0xa7,
+	0x0b, 0x9d, 0x70, 0x0a, 0x47, 0xfb, 0xf0, 0x74, 0xf7, 0x9a, 0x9e, 0x65, 0xa7, 0xe4, 0x69, 0x7c,
+	0xee, 0xd3, 0x42, 0xad, 0x84, 0x85, 0xed, 0x5e, 0x93, 0xd6, 0xd1, 0xfa, 0x35, 0x64, 0x0f, 0x4f,
+	0xc9, 0x4b, 0xe0, 0xf7, 0xcd, 0x83, 0xc0, 0x18, 0xda, 0xd1, 0xd9, 0xb5, 0x95, 0xaa, 0x41, 0x1f,
+	0x21, 0x3a, 0xf8, 0xea, 0xd7, 0x27, 0x2f, 0xa0, 0xa4, 0x3a, 0x9e, 0xe5, 0x8c, 0x77, 0xd2, 0xae,
+	0xc9, 0x70, 0x3b, 0xa6, 0xa1, 0x8f, 0xc3, 0x31, 0xd1, 0x4b, 0x6f, 0xa6, 0xa3, 0xa2, 0x7c, 0xb2,
+	0x16, 0xc0, 0xf2, 0x0c, 0x0e, 0x2d, 0x1a, 0x91, 0x2a, 0x3e, 0x13, 0x05, 0x5c, 0x1e, 0x2a, 0x89,
+	0x79, 0xf9, 0x46, 0x13, 0x41, 0xa7, 0xcb, 0x23, 0x17, 0x5f, 0x1e, 0x78, 0xce, 0x9d, 0xa6, 0x11,
+	0x70, 0x2e, 0xfd, 0x70, 0x34, 0xa2, 0xbe, 0x2f, 0x93, 0x17, 0x19, 0xbe, 0x73, 0xd4, 0x24, 0x51,
+	0xa4, 0x2e, 0xee, 0x41, 0x1d, 0x93, 0x19, 0xa1, 0x47, 0x13, 0x99, 0x93, 0x9a, 0x24, 0x0a, 0xd0,
+	0x07, 0xb8, 0xf8, 0x03, 0xea, 0x8c, 0x26, 0x83, 0xb1, 0x3f, 0x70, 0x9f, 0xac, 0xcb, 0x9c, 0x49,
+	0x4d, 0x52, 0x5f, 0xf9, 0xdd, 0x27, 0xeb, 0xb3, 0xa8, 0xe7, 0x4f, 0x9a, 0xf9, 0x59, 0xd4, 0xf3,
+	0x27, 0x73, 0xa8, 0xe7, 0xcd, 0xc2, 0x1c, 0xea, 0x39, 0x79, 0x04, 0x2b, 0x81, 0xed, 0x47, 0x1b,
+	0xb1, 0x50, 0xad, 0xc8, 0x81, 0xcb, 0x81, 0xad, 0x32, 0x54, 0x22, 0x7f, 0xf5, 0x57, 0x05, 0xa8,
+	0x44, 0xd3, 0x49, 0xb6, 0x44, 0xaa, 0xeb, 0xd8, 0x63, 0xa1, 0x3a, 0xc4, 0xdd, 0x5b, 0x3c, 0xfb,
+	0xb8, 0x37, 0x7c, 0x81, 0xd0, 0xdd, 0x6b, 0x3c, 0x23, 0xc6, 0xff, 0x5b, 0xff, 0x94, 0xe7, 0x9b,
+	0x0d, 0x2f, 0x90, 0x97, 0x90, 0xf7, 0xd8, 0xb9, 0xb2, 0xa4, 0x0f, 0xaf, 0x20, 0xab, 0xad, 0xb3,
+	0x73, 0x9d, 0x33, 0xb5, 0xfe, 0x3b, 0x07, 0x39, 0x9d, 0x9d, 0xbf, 0xab, 0x1b, 0xbc, 0xd4, 0x33,
+	0xa5, 0xe5, 0xbe, 0x72, 0xa9, 0xb9, 0xaf, 0x47, 0xb0, 0xe2, 0x85, 0x8e, 0x63, 0x39, 0xc7, 0x73,
+	0xe9, 0xac, 0x65, 0x59, 0x71, 0x61, 0xe6, 0xab, 0x98, 0x9a, 0xf9, 0x8a, 0xd2, 0x5a, 0x85, 0xab,
+	0xa6, 0xb5, 0xc8, 0xaf, 0xa0, 0x2e, 0xf6, 0xf4, 0xc1, 0x70, 0xc2, 0x9d, 0x54, 0x89, 0x0f, 0xec,
+	0xb3, 0x2b, 0x0e, 0x6c, 0x5b, 0x6c, 0xea, 0x5b, 0x13, 0xdc, 0xd5, 0xf9, 0x71, 0xa8, 0x4a, 0xa7,
+	0x14, 0xcc, 0xb0, 0xb8, 0x86, 0x87, 0x57, 0xc7, 0xe5, 0xcb, 0x86, 0x59, 0x02, 0x5b, 0x5f, 0x43,
+	0x63, 0x56, 0x66, 0xca, 0x59, 0x6a, 0x3d, 0x7e, 0x96, 0x4a, 0xf3, 0x28, 0x51, 0xbc, 0x11, 0x3b,
+	0x67, 0xe1, 0xee, 0xce, 0x1d, 0x91, 0xf6, 0x77, 0x19, 0x68, 0xed, 0x0b, 0x0b, 0xdf, 0xb1, 0xfc,
+	0xc0, 0xb3, 0x86, 0x21, 0x5f, 0xd3, 0x72, 0x0b, 0xfb, 0xa1, 0xec, 0xe3, 0x45, 0x72, 0x2f, 0xca,
+	0x5d, 0x26, 0x3a, 0xb6, 0x13, 0x69, 0xff, 0x91, 0x81, 0x5b, 0xa9, 0x2a, 0x47, 0x39, 0xde, 0xa9,
+	0x8b, 0x9f, 0xbf, 0x9f, 0xbd, 0x80, 0xf3, 0xfb, 0xbb, 0xfa, 0xcf, 0xb9, 0xab, 0x7f, 0x06, 0xa5,
+	0x61, 0x38, 0x3a, 0xa5, 0x81, 0x5a, 0x9c, 0xb7, 0x17, 0x69, 0xb1, 0xc5, 0x61, 0xba, 0x82, 0x27,
+	0x7c, 0xf5, 0x2f, 0xa0, 0x9e, 0x40, 0xa1, 0x87, 0x0a, 0x5d, 0xdc, 0x41, 0x45, 0x86, 0x68, 0xec,
+	0xf3, 0x3e, 0x66, 0xf4, 0x1a, 0xa7, 0x6e, 0x21, 0xf1, 0x15, 0xcf, 0x3d, 0xc6, 0x1d, 0xa6, 0x28,
+	0x68, 0x26, 0x2c, 0x77, 0x99, 0x29, 0xec, 0xfd, 0x2a, 0x2f, 0x2f, 0xa2, 0xb8, 0x2d, 0x1b, 0x4b,
+	0xee, 0xce, 0xcc, 0x6a, 0x6e, 0x2e, 0xef, 0xfe, 0xc7, 0x19, 0x68, 0x4c, 0x9b, 0x91, 0xd3, 0xf1,
+	0x71, 0x6c, 0x3a, 0xde, 0x4b, 0xb3, 0x4e, 0x0e, 0xff, 0x7e, 0x03, 0x9f, 0x18, 0xb8, 0x7f, 0xcf,
+	0x42, 0x59, 0x89, 0xfd, 0xc1, 0x0c, 0x78, 0x9a, 0xfa, 0xcd, 0x25, 0x52, 0xbf, 0x3c, 0xe9, 0x82,
+	0x0e, 0x8e, 0xfb, 0xb0, 0xb2, 0x2e, 0x4b, 0xef, 0xe2, 0x90, 0x9e, 0x42, 0x25, 0x18, 0x89, 0xf3,
+	0xa5, 0x1f, 0x65, 0x8a, 0x53, 0x2e, 0xaf, 0x04, 0x53, 0x39, 0x90, 0x7f, 0xe4, 0x33, 0x95, 0xf5,
+	0x97, 0x29, 0xdd, 0xd2, 0x65, 0xfb, 0xb7, 0xc8, 0xa9, 0xbe, 0xe6, 0x68, 0xdc, 0x78, 0x13, 0x8f,
+	0x41, 0x64, 0x52, 0x29, 0x91, 0xb8, 0xd5, 0xfe, 0x34, 0x03, 0x65, 0xd5, 0x32, 0xf9, 0x08, 0x1a,
+	0xcc, 0xa5, 0x3c, 0x23, 0xef, 0x88, 0x18, 0xd6, 0x97, 0x5b, 0xfa, 0x32, 0xd2, 0xb7, 0xa7, 0x64,
+	0x74, 0xe0, 0x1e, 0x35, 0x4c, 0x71, 0x62, 0x1c, 0x04, 0x2c, 0x30, 0x6c, 0xf5, 0x24, 0x02, 0xe9,
+	0xfc, 0xcc, 0xd8, 0x47, 0x2a, 0x6e, 0x0b, 0xe7, 0x9e, 0x15, 0xd0, 0x04, 0x54, 0xec, 0x20, 0xcb,
+	0xbc, 0x62, 0x8a, 0xd5, 0x7e, 0x0d, 0xd7, 0xf1, 0xb9, 0x87, 0x9a, 0x46, 0xff, 0xff, 0x2a, 0xfe,
+	0xbe, 0x0f, 0x4b, 0x47, 0x16, 0xb5, 0xcd, 0xb9, 0xb3, 0x05, 0xa7, 0x46, 0x67, 0x8b, 0xbe, 0x78,
+	0xa7, 0x12, 0x6b, 0x5e, 0x5a, 0xfe, 0x4b, 0xa8, 0x28, 0x83, 0x52, 0x9e, 0xe0, 0xb7, 0x16, 0x2a,
+	0x80, 0xef, 0xbe, 0xf4, 0x29, 0x5e, 0xfb, 0x97, 0x2c, 0xbf, 0xf1, 0x88, 0xea, 0xde, 0xd5, 0x92,
+	0x37, 0xa3, 0x8b, 0x3d, 0x11, 0x39, 0x7e, 0x74, 0xa1, 0x06, 0x69, 0xf7, 0x79, 0xa4, 0x0b, 0x55,
+	0xc3, 0x71, 0x58, 0x20, 0x53, 0x4d, 0x39, 0x2e, 0xa7, 0x7d, 0xb1, 0x9c, 0xcd, 0x29, 0x83, 0xdc,
+	0x0d, 0x63, 0x22, 0xbe, 0xcf, 0x0d, 0xe1, 0xe7, 0xd0, 0x98, 0x95, 0xfd, 0x56, 0xa9, 0xde, 0x75,
+	0x68, 0xf0, 0x0b, 0x86, 0xde, 0x29, 0x3d, 0xbf, 0xda, 0x23, 0xb4, 0xff, 0xcc, 0xc2, 0x4a, 0x8c,
+	0x45, 0x4e, 0xee, 0x87, 0xb0, 0x3c, 0x0a, 0x3d, 0xdc, 0xa8, 0x07, 0xc9, 0xa7, 0x06, 0x4b, 0x92,
+	0xac, 0x1e, 0x39, 0xb4, 0x61, 0x55, 0x01, 0x47, 0x3c, 0x8b, 0x3d, 0x38, 0x31, 0xfc, 0x13, 0xa9,
+	0xd8, 0x8a, 0xac, 0x12, 0xf9, 0xed, 0x5d, 0xc3, 0x3f, 0xc1, 0x57, 0x00, 0x0a, 0x1f, 0x78, 0xa1,
+	0x1f, 0x0c, 0x0c, 0x67, 0x74, 0x82, 0xb7, 0x13, 0x39, 0xf9, 0xc6, 0x43, 0x54, 0xf6, 0xb1, 0x6e,
+	0x53, 0x54, 0x91, 0x97, 0x50, 0x8e, 0xde, 0x6e, 0x89, 0x57, 0x63, 0xef, 0xa7, 0xe7, 0xd1, 0xb1,
+	0x0b, 0x3c, 0x64, 0xd1, 0x23, 0x06, 0xb2, 0x03, 0xf5, 0x98, 0x62, 0x14, 0x5d, 0xd4, 0x95, 0x24,
+	0xd4, 0x46, 0x91, 0xd2, 0x94, 0x4b, 0x49, 0xaa, 0x5b, 0xbc, 0xa2, 0x94, 0x20, 0xd6, 0x11, 0xed,
+	0x8f, 0x60, 0x29, 0x59, 0x3f, 0x9d, 0xc9, 0x4c, 0x6c, 0x26, 0x2f, 0x7c, 0x38, 0x86, 0x2b, 0x85,
+	0x7e, 0x6b, 0x8c, 0x5d, 0x9b, 0x2a, 0x63, 0xbd, 0x68, 0xa5, 0x28, 0xe8, 0xc6, 0xdf, 0x96, 0x21,
+	0xb7, 0xe9, 0x5a, 0xe4, 0x0f, 0xa0, 0x1a, 0x3b, 0x07, 0x92, 0x7b, 0x17, 0x9f, 0x12, 0xb9, 0x05,
+	0xb5, 0x3e, 0xb8, 0xca, 0x51, 0x92, 0x1c, 0x42, 0x59, 0xbd, 0xa0, 0x24, 0xf3, 0x27, 0xb8, 0x99,
+	0xd7, 0x98, 0xad, 0xbb, 0x17, 0x20, 0xa4, 0xc0, 0x5f, 0x42, 0x2d, 0xfe, 0x3e, 0x92, 0xcc, 0xab,
+	0x91, 0xf2, 0xe6, 0xb2, 0x75, 0xff, 0x12, 0x94, 0x14, 0x6e, 0xc0, 0x52, 0xf2, 0x15, 0x1d, 0x79,
+	0x90, 0xaa, 0xd1, 0xdc, 0x03, 0xbd, 0xd6, 0x87, 0x97, 0xe2, 0x64, 0x13, 0x2e, 0xac, 0xa6, 0xc4,
+	0x63, 0xe4, 0xe3, 0xab, 0x45, 0x6d, 0xa2, 0xb1, 0x4f, 0xde, 0x26, 0xc4, 0x23, 0x87, 0xb1, 0xd8,
+	0xe0, 0xce, 0xc2, 0x68, 0x64, 0xf1, 0x14, 0xcc, 0x85, 0x37, 0x7f, 0x08, 0xf5, 0x84, 0xf7, 0x27,
+	0xf7, 0x53, 0x3b, 0x3f, 0xbb, 0x39, 0xb5, 0x1e, 0x5c, 0x06, 0x93, 0xf2, 0x75, 0xa8, 0x44, 0x2b,
+	0x82, 0xdc, 0x5d, 0xbc, 0x9a, 0x94, 0x5c, 0xed, 0x22, 0x88, 0x94, 0xb9, 0x03, 0xb9, 0xbe, 0xe1,
+	0x92, 0x5b, 0x69, 0x97, 0xd0, 0x4a, 0xce, 0x7b, 0x0b, 0x6f, 0xa8, 0xb5, 0xdc, 0x9f, 0x64, 0x33,
+	0xeb, 0x19, 0xd2, 0x83, 0x7a, 0x22, 0xd3, 0x9e, 0xd2, 0xf3, 0xb4, 0x4c, 0xfc, 0x05, 0x92, 0xd7,
+	0x33, 0xe4, 0x67, 0x50, 0x52, 0x8e, 0x73, 0xc1, 0x7d, 0x56, 0x6b, 0xfe, 0x81, 0x58, 0xfc, 0xe9,
+	0xf4, 0x37, 0x50, 0xe9, 0x51, 0xfb, 0x68, 0x1b, 0x5f, 0x59, 0x93, 0xdf, 0x9e, 0x42, 0xc5, 0x1b,
+	0xec, 0x76, 0xfc, 0x0d, 0x76, 0x84, 0x53, 0x9a, 0xb5, 0xaf, 0x0a, 0x97, 0x57, 0xdc, 0x8f, 0xbf,
+	0xfe, 0xf4, 0xd8, 0x0a, 0x4e, 0xc2, 0x21, 0xc2, 0xd7, 0x24, 0xaf, 0xfa, 0x6e, 0xac, 0x4d, 0x5f,
+	0xe3, 0xad, 0x1d, 0x53, 0x67, 0x4d, 0x28, 0x3b, 0x2c, 0xf2, 0x00, 0xec, 0xf1, 0xff, 0x0e, 0x00,
+	0xd5, 0xf0, 0x9f, 0x1f, 0x55, 0x2e, 0x00, 0x00,
 }
@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// RunWithLeaderElection blocks until this process is elected leader for the
+// lock with the given name, and then calls run. It's used by controllers that
+// must be singletons, so that running multiple replicas for high availability
+// doesn't result in replicas doing duplicate work or fighting over resources.
+//
+// The lock is held in a ConfigMap in the given namespace, since the pinned
+// client-go release predates Lease-based locks. The lock is held for as long
+// as the leader keeps renewing it; if it fails to renew, the process exits so
+// that another replica can take over.
+func RunWithLeaderElection(client kubernetes.Interface, namespace, name string, run func(stopCh <-chan struct{})) error {
+	identity, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to determine leader election identity: %s", err)
+	}
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(namespace)})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{Component: name})
+
+	lock, err := resourcelock.New(
+		resourcelock.ConfigMapsResourceLock,
+		namespace,
+		name,
+		client.CoreV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: recorder,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(stopCh <-chan struct{}) {
+				log.Infof("%s became the leader for %s/%s", identity, namespace, name)
+				run(stopCh)
+			},
+			OnStoppedLeading: func() {
+				log.Fatalf("%s lost the leadership for %s/%s", identity, namespace, name)
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					log.Infof("%s is the leader for %s/%s", leader, namespace, name)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("%s waiting to become the leader for %s/%s", identity, namespace, name)
+	elector.Run()
+	return nil
+}
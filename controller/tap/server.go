@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"strings"
 	"time"
@@ -34,6 +35,9 @@ type (
 		tapPort             uint
 		k8sAPI              *k8s.API
 		controllerNamespace string
+		maxRps              float32
+		maxPods             int
+		maxDuration         time.Duration
 	}
 )
 
@@ -55,6 +59,9 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 	if req.MaxRps == 0.0 {
 		req.MaxRps = defaultMaxRps
 	}
+	if s.maxRps > 0 && req.MaxRps > s.maxRps {
+		req.MaxRps = s.maxRps
+	}
 
 	objects, err := s.k8sAPI.GetObjects(req.Target.Resource.Namespace, req.Target.Resource.Type, req.Target.Resource.Name)
 	if err != nil {
@@ -80,8 +87,29 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 			req.GetTarget().GetResource().GetType(), req.GetTarget().GetResource().GetName())
 	}
 
+	// each tapped pod is sent at least 1 rps, so the total rate is bounded by
+	// tapping at most MaxRps pods
+	maxPods := int(req.MaxRps)
+	if maxPods < 1 {
+		maxPods = 1
+	}
+	if s.maxPods > 0 && s.maxPods < maxPods {
+		maxPods = s.maxPods
+	}
+	if len(pods) > maxPods {
+		log.Infof("Sampling %d of %d pods for target: %+v", maxPods, len(pods), *req.Target.Resource)
+		pods = samplePods(pods, maxPods)
+	}
+
 	log.Infof("Tapping %d pods for target: %+v", len(pods), *req.Target.Resource)
 
+	ctx := stream.Context()
+	if s.maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.maxDuration)
+		defer cancel()
+	}
+
 	events := make(chan *public.TapEvent)
 
 	// divide the rps evenly between all pods to tap
@@ -95,15 +123,23 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 		return apiUtil.GRPCError(err)
 	}
 
+	filter, err := makeIdentityFilter(req.Match)
+	if err != nil {
+		return apiUtil.GRPCError(err)
+	}
+
 	for _, pod := range pods {
 		// initiate a tap on the pod
-		go s.tapProxy(stream.Context(), rpsPerPod, match, pod.Status.PodIP, events)
+		go s.tapProxy(ctx, rpsPerPod, match, filter, pod.Status.PodIP, events)
 	}
 
 	// read events from the taps and send them back
 	for {
 		select {
-		case <-stream.Context().Done():
+		case <-ctx.Done():
+			if stream.Context().Err() == nil {
+				return status.Errorf(codes.DeadlineExceeded, "tap session exceeded the maximum duration of %s", s.maxDuration)
+			}
 			return nil
 		case event := <-events:
 			err := stream.Send(event)
@@ -114,6 +150,18 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 	}
 }
 
+// samplePods returns n pods picked at random from pods, so that repeated taps
+// of a large resource don't always observe the same pods.
+func samplePods(pods []*apiv1.Pod, n int) []*apiv1.Pod {
+	sampled := make([]*apiv1.Pod, len(pods))
+	copy(sampled, pods)
+	for i := range sampled {
+		j := rand.Intn(i + 1)
+		sampled[i], sampled[j] = sampled[j], sampled[i]
+	}
+	return sampled[:n]
+}
+
 // TODO: validate scheme
 func parseScheme(scheme string) *httpPb.Scheme {
 	value, ok := httpPb.Scheme_Registered_value[strings.ToUpper(scheme)]
@@ -219,6 +267,28 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 				},
 			})
 
+		case *public.TapByResourceRequest_Match_DestinationIdentity:
+			// the destination's labels are known to the proxy, so narrow down
+			// the events it reports before filtering on identity
+			identity, err := pkgK8s.ParseTLSIdentity(typed.DestinationIdentity)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+
+			for k, v := range identityLabels(identity) {
+				matches = append(matches, &proxy.ObserveRequest_Match{
+					Match: &proxy.ObserveRequest_Match_DestinationLabel{
+						DestinationLabel: &proxy.ObserveRequest_Match_Label{
+							Key:   k,
+							Value: v,
+						},
+					},
+				})
+			}
+
+		case *public.TapByResourceRequest_Match_SourceIdentity:
+			// handled by the identity filter
+
 		default:
 			return nil, status.Errorf(codes.Unimplemented, "unknown match type: %v", typed)
 		}
@@ -245,6 +315,92 @@ func destinationLabels(resource *public.Resource) map[string]string {
 	return dstLabels
 }
 
+// identityFilter selects events by the TLS identities of their peers. Proxies
+// don't report peer identities in tap events, so these matches are applied
+// once the events' labels have been hydrated with the owners of the pods at
+// either end of the connection.
+type identityFilter struct {
+	source      []map[string]string
+	destination []map[string]string
+}
+
+func makeIdentityFilter(match *public.TapByResourceRequest_Match) (identityFilter, error) {
+	filter := identityFilter{}
+
+	for _, reqMatch := range match.GetAll().GetMatches() {
+		switch typed := reqMatch.Match.(type) {
+		case *public.TapByResourceRequest_Match_SourceIdentity:
+			identity, err := pkgK8s.ParseTLSIdentity(typed.SourceIdentity)
+			if err != nil {
+				return filter, status.Error(codes.InvalidArgument, err.Error())
+			}
+			filter.source = append(filter.source, identityLabels(identity))
+
+		case *public.TapByResourceRequest_Match_DestinationIdentity:
+			identity, err := pkgK8s.ParseTLSIdentity(typed.DestinationIdentity)
+			if err != nil {
+				return filter, status.Error(codes.InvalidArgument, err.Error())
+			}
+			filter.destination = append(filter.destination, identityLabels(identity))
+		}
+	}
+
+	return filter, nil
+}
+
+// matches returns true if the event was sent over TLS between peers with the
+// filter's identities. The TLS status of a connection is reported in the
+// labels of the remote peer, which is the source for inbound events and the
+// destination for outbound events.
+func (f identityFilter) matches(ev *public.TapEvent) bool {
+	if len(f.source) == 0 && len(f.destination) == 0 {
+		return true
+	}
+
+	srcLabels := ev.GetSourceMeta().GetLabels()
+	dstLabels := ev.GetDestinationMeta().GetLabels()
+
+	if srcLabels["tls"] != "true" && dstLabels["tls"] != "true" {
+		return false
+	}
+
+	for _, labels := range f.source {
+		if !hasLabels(srcLabels, labels) {
+			return false
+		}
+	}
+	for _, labels := range f.destination {
+		if !hasLabels(dstLabels, labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// identityLabels returns the labels that the tap server and proxies attach to
+// events for pods owned by the identity's owner.
+func identityLabels(identity pkgK8s.TLSIdentity) map[string]string {
+	ownerLabel := identity.Kind
+	if ownerLabel == "job" {
+		ownerLabel = "k8s_job"
+	}
+
+	return map[string]string{
+		ownerLabel:       identity.Name,
+		pkgK8s.Namespace: identity.Namespace,
+	}
+}
+
+func hasLabels(labels, expected map[string]string) bool {
+	for k, v := range expected {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 // Tap a pod.
 // This method will run continuously until an error is encountered or the
 // request is cancelled via the context.  Thus it should be called as a
@@ -253,7 +409,7 @@ func destinationLabels(resource *public.Resource) map[string]string {
 // of maxRps * 1s at most once per 1s window.  If this limit is reached in
 // less than 1s, we sleep until the end of the window before calling Observe
 // again.
-func (s *server) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, addr string, events chan *public.TapEvent) {
+func (s *server) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, filter identityFilter, addr string, events chan *public.TapEvent) {
 	tapAddr := fmt.Sprintf("%s:%d", addr, s.tapPort)
 	log.Infof("Establishing tap on %s", tapAddr)
 	conn, err := grpc.DialContext(ctx, tapAddr, grpc.WithInsecure())
@@ -289,6 +445,9 @@ func (s *server) tapProxy(ctx context.Context, maxRps float32, match *proxy.Obse
 			}
 
 			translatedEvent := s.translateEvent(event)
+			if !filter.matches(translatedEvent) {
+				continue
+			}
 
 			select {
 			case <-ctx.Done():
@@ -482,6 +641,9 @@ func NewServer(
 	addr string,
 	tapPort uint,
 	controllerNamespace string,
+	maxRps float32,
+	maxPods uint,
+	maxDuration time.Duration,
 	k8sAPI *k8s.API,
 ) (*grpc.Server, net.Listener, error) {
 	k8sAPI.Pod().Informer().AddIndexers(cache.Indexers{podIPIndex: indexPodByIP})
@@ -496,6 +658,9 @@ func NewServer(
 		tapPort:             tapPort,
 		k8sAPI:              k8sAPI,
 		controllerNamespace: controllerNamespace,
+		maxRps:              maxRps,
+		maxPods:             int(maxPods),
+		maxDuration:         maxDuration,
 	}
 	pb.RegisterTapServer(s, &srv)
 
@@ -2,12 +2,15 @@ package tap
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	public "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type tapExpected struct {
@@ -189,7 +192,7 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			server, listener, err := NewServer("localhost:0", 0, "controller-ns", k8sAPI)
+			server, listener, err := NewServer("localhost:0", 0, "controller-ns", 0, 0, 0, k8sAPI)
 			if err != nil {
 				t.Fatalf("NewServer error: %s", err)
 			}
@@ -221,4 +224,172 @@ status:
 			}
 		}
 	})
+
+	t.Run("Ends namespace taps after the maximum duration", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: controller-ns
+status:
+  phase: Running
+`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		server, listener, err := NewServer("localhost:0", 0, "controller-ns", 10, 10, 100*time.Millisecond, k8sAPI)
+		if err != nil {
+			t.Fatalf("NewServer error: %s", err)
+		}
+
+		go func() { server.Serve(listener) }()
+		defer server.GracefulStop()
+
+		k8sAPI.Sync(nil)
+
+		client, conn, err := NewClient(listener.Addr().String())
+		if err != nil {
+			t.Fatalf("NewClient error: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tapByResourceClient, err := client.TapByResource(ctx, &public.TapByResourceRequest{
+			Target: &public.ResourceSelection{
+				Resource: &public.Resource{
+					Type: pkgK8s.Namespace,
+					Name: "emojivoto",
+				},
+			},
+			Match: &public.TapByResourceRequest_Match{
+				Match: &public.TapByResourceRequest_Match_All{
+					All: &public.TapByResourceRequest_Match_Seq{},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("TapByResource failed: %v", err)
+		}
+
+		expected := "rpc error: code = DeadlineExceeded desc = tap session exceeded the maximum duration of 100ms"
+		_, err = tapByResourceClient.Recv()
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error to be [%s], but was [%v]", expected, err)
+		}
+	})
+}
+
+func TestSamplePods(t *testing.T) {
+	pods := []*apiv1.Pod{}
+	for i := 0; i < 10; i++ {
+		pods = append(pods, &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i)}})
+	}
+
+	sampled := samplePods(pods, 3)
+	if len(sampled) != 3 {
+		t.Fatalf("Expected 3 pods, got %d", len(sampled))
+	}
+
+	seen := map[string]bool{}
+	for _, pod := range sampled {
+		if seen[pod.Name] {
+			t.Fatalf("Expected distinct pods, got %s twice", pod.Name)
+		}
+		seen[pod.Name] = true
+	}
+
+	for i, pod := range pods {
+		if pod.Name != fmt.Sprintf("pod-%d", i) {
+			t.Fatalf("Expected the pods not to be reordered, got %s at %d", pod.Name, i)
+		}
+	}
+}
+
+func TestIdentityFilter(t *testing.T) {
+	match := &public.TapByResourceRequest_Match{
+		Match: &public.TapByResourceRequest_Match_All{
+			All: &public.TapByResourceRequest_Match_Seq{
+				Matches: []*public.TapByResourceRequest_Match{
+					&public.TapByResourceRequest_Match{
+						Match: &public.TapByResourceRequest_Match_SourceIdentity{
+							SourceIdentity: "webapp.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	filter, err := makeIdentityFilter(match)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	event := func(srcLabels, dstLabels map[string]string) *public.TapEvent {
+		return &public.TapEvent{
+			SourceMeta:      &public.TapEvent_EndpointMeta{Labels: srcLabels},
+			DestinationMeta: &public.TapEvent_EndpointMeta{Labels: dstLabels},
+		}
+	}
+
+	t.Run("Matches TLS events from the identity", func(t *testing.T) {
+		ev := event(
+			map[string]string{"deployment": "webapp", "namespace": "booksapp", "tls": "true"},
+			map[string]string{"deployment": "books", "namespace": "booksapp"},
+		)
+		if !filter.matches(ev) {
+			t.Fatalf("Expected event to match: %+v", ev)
+		}
+	})
+
+	t.Run("Ignores plaintext events from the identity's pods", func(t *testing.T) {
+		ev := event(
+			map[string]string{"deployment": "webapp", "namespace": "booksapp", "tls": "no_identity"},
+			map[string]string{"deployment": "books", "namespace": "booksapp"},
+		)
+		if filter.matches(ev) {
+			t.Fatalf("Expected event not to match: %+v", ev)
+		}
+	})
+
+	t.Run("Ignores TLS events from other identities", func(t *testing.T) {
+		ev := event(
+			map[string]string{"deployment": "webapp", "namespace": "default", "tls": "true"},
+			map[string]string{"deployment": "books", "namespace": "booksapp"},
+		)
+		if filter.matches(ev) {
+			t.Fatalf("Expected event not to match: %+v", ev)
+		}
+	})
+
+	t.Run("Rejects invalid identities", func(t *testing.T) {
+		_, err := makeIdentityFilter(&public.TapByResourceRequest_Match{
+			Match: &public.TapByResourceRequest_Match_All{
+				All: &public.TapByResourceRequest_Match_Seq{
+					Matches: []*public.TapByResourceRequest_Match{
+						&public.TapByResourceRequest_Match{
+							Match: &public.TapByResourceRequest_Match_DestinationIdentity{
+								DestinationIdentity: "books",
+							},
+						},
+					},
+				},
+			},
+		})
+		if err == nil {
+			t.Fatalf("Expected an error but got none")
+		}
+	})
 }
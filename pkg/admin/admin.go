@@ -1,7 +1,12 @@
 package admin
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -9,18 +14,72 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// Check reports on the health of one of a component's subsystems (e.g. its
+// connection to Prometheus). Checks are run each time the component's
+// readiness or status is requested.
+type Check struct {
+	// Name identifies the subsystem in the status page.
+	Name string
+
+	// Critical checks must pass for the component to report itself as ready.
+	// Non-critical checks are only reported in the status page.
+	Critical bool
+
+	// Run returns an error describing why the subsystem is unhealthy, or nil.
+	Run func() error
+}
+
+type subsystemStatus struct {
+	Name     string `json:"name"`
+	Healthy  bool   `json:"healthy"`
+	Critical bool   `json:"critical"`
+	Message  string `json:"message,omitempty"`
+}
+
+type status struct {
+	Ready      bool              `json:"ready"`
+	Subsystems []subsystemStatus `json:"subsystems"`
+}
+
 type handler struct {
 	promHandler http.Handler
 	ready       bool
+	trackReady  bool
+	checks      []Check
 	sync.RWMutex
 }
 
-func StartServer(addr string, readyCh <-chan struct{}) {
+// StartServer serves Prometheus metrics on /metrics, along with endpoints
+// for probes: /live succeeds as long as the process is serving requests, and
+// /ready succeeds once readyCh is closed and all critical checks pass. /status
+// returns a JSON description of the state of each subsystem. /log-level
+// returns the component's log level on GET, and changes it on PUT, without
+// restarting the component; since the admin port is reachable from the
+// cluster, PUTs are only accepted from localhost, e.g. through `kubectl
+// port-forward`. This relies on the admin port not being routed through the
+// pod's proxy, which would make every request come from localhost. /ping is kept for backwards compatibility, and
+// behaves like /live.
+func StartServer(addr string, readyCh <-chan struct{}, checks ...Check) {
 	log.Infof("starting admin server on %s", addr)
 
+	h := newHandler(readyCh, checks)
+
+	s := &http.Server{
+		Addr:         addr,
+		Handler:      h,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	log.Fatal(s.ListenAndServe())
+}
+
+func newHandler(readyCh <-chan struct{}, checks []Check) *handler {
 	h := &handler{
 		promHandler: promhttp.Handler(),
 		ready:       readyCh == nil,
+		trackReady:  readyCh != nil,
+		checks:      checks,
 	}
 
 	if readyCh != nil {
@@ -30,14 +89,7 @@ func StartServer(addr string, readyCh <-chan struct{}) {
 		}()
 	}
 
-	s := &http.Server{
-		Addr:         addr,
-		Handler:      h,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-	}
-
-	log.Fatal(s.ListenAndServe())
+	return h
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -46,8 +98,14 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.promHandler.ServeHTTP(w, req)
 	case "/ping":
 		h.servePing(w, req)
+	case "/live":
+		h.serveLive(w, req)
 	case "/ready":
 		h.serveReady(w, req)
+	case "/status":
+		h.serveStatus(w, req)
+	case "/log-level":
+		h.serveLogLevel(w, req)
 	default:
 		http.NotFound(w, req)
 	}
@@ -57,14 +115,114 @@ func (h *handler) servePing(w http.ResponseWriter, req *http.Request) {
 	w.Write([]byte("pong\n"))
 }
 
+func (h *handler) serveLive(w http.ResponseWriter, req *http.Request) {
+	w.Write([]byte("ok\n"))
+}
+
 func (h *handler) serveReady(w http.ResponseWriter, req *http.Request) {
-	if h.getReady() {
+	if h.status().Ready {
 		w.Write([]byte("ok\n"))
 	} else {
 		http.Error(w, "unready", http.StatusServiceUnavailable)
 	}
 }
 
+func (h *handler) serveStatus(w http.ResponseWriter, req *http.Request) {
+	st := h.status()
+
+	rsp, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !st.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(rsp)
+}
+
+func (h *handler) serveLogLevel(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		fmt.Fprintln(w, log.GetLevel())
+	case http.MethodPut:
+		if !isLoopback(req.RemoteAddr) {
+			http.Error(w, "the log level can only be changed from localhost", http.StatusForbidden)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		level, err := log.ParseLevel(strings.TrimSpace(string(body)))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		log.SetLevel(level)
+		log.Infof("log level set to %s", level)
+		fmt.Fprintln(w, level)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "GET or PUT required", http.StatusMethodNotAllowed)
+	}
+}
+
+// isLoopback returns true if the request's remote address is a loopback
+// address.
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (h *handler) status() status {
+	st := status{
+		Ready:      h.getReady(),
+		Subsystems: make([]subsystemStatus, 0),
+	}
+
+	if h.trackReady {
+		informers := subsystemStatus{
+			Name:     "informers",
+			Healthy:  st.Ready,
+			Critical: true,
+			Message:  "caches synced",
+		}
+		if !st.Ready {
+			informers.Message = "waiting for caches to sync"
+		}
+		st.Subsystems = append(st.Subsystems, informers)
+	}
+
+	for _, check := range h.checks {
+		sub := subsystemStatus{
+			Name:     check.Name,
+			Healthy:  true,
+			Critical: check.Critical,
+		}
+		if err := check.Run(); err != nil {
+			sub.Healthy = false
+			sub.Message = err.Error()
+			if check.Critical {
+				st.Ready = false
+			}
+		}
+		st.Subsystems = append(st.Subsystems, sub)
+	}
+
+	return st
+}
+
 func (h *handler) getReady() bool {
 	h.RLock()
 	defer h.RUnlock()
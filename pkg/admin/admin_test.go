@@ -0,0 +1,148 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func serve(h *handler, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	rsp := httptest.NewRecorder()
+	h.ServeHTTP(rsp, req)
+	return rsp
+}
+
+func TestAdminServer(t *testing.T) {
+	t.Run("Reports live before informers are synced", func(t *testing.T) {
+		h := newHandler(make(chan struct{}), nil)
+
+		rsp := serve(h, "/live")
+		if rsp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d but got %d", http.StatusOK, rsp.Code)
+		}
+
+		rsp = serve(h, "/ready")
+		if rsp.Code != http.StatusServiceUnavailable {
+			t.Fatalf("Expected status %d but got %d", http.StatusServiceUnavailable, rsp.Code)
+		}
+	})
+
+	t.Run("Reports ready once informers are synced and critical checks pass", func(t *testing.T) {
+		h := newHandler(nil, []Check{
+			{Name: "critical", Critical: true, Run: func() error { return nil }},
+			{Name: "optional", Run: func() error { return errors.New("unreachable") }},
+		})
+
+		rsp := serve(h, "/ready")
+		if rsp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d but got %d", http.StatusOK, rsp.Code)
+		}
+	})
+
+	t.Run("Reports unready when a critical check fails", func(t *testing.T) {
+		h := newHandler(nil, []Check{
+			{Name: "certificate", Critical: true, Run: func() error { return errors.New("expired") }},
+		})
+
+		rsp := serve(h, "/ready")
+		if rsp.Code != http.StatusServiceUnavailable {
+			t.Fatalf("Expected status %d but got %d", http.StatusServiceUnavailable, rsp.Code)
+		}
+	})
+
+	t.Run("Describes each subsystem in the status page", func(t *testing.T) {
+		readyCh := make(chan struct{})
+		close(readyCh)
+		h := newHandler(readyCh, []Check{
+			{Name: "prometheus", Run: func() error { return errors.New("connection refused") }},
+		})
+		h.setReady(true)
+
+		rsp := serve(h, "/status")
+		if rsp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d but got %d", http.StatusOK, rsp.Code)
+		}
+
+		var st status
+		if err := json.Unmarshal(rsp.Body.Bytes(), &st); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := status{
+			Ready: true,
+			Subsystems: []subsystemStatus{
+				{Name: "informers", Healthy: true, Critical: true, Message: "caches synced"},
+				{Name: "prometheus", Healthy: false, Critical: false, Message: "connection refused"},
+			},
+		}
+		if !reflect.DeepEqual(st, expected) {
+			t.Fatalf("Expected status %+v but got %+v", expected, st)
+		}
+	})
+}
+
+func TestLogLevel(t *testing.T) {
+	defer log.SetLevel(log.GetLevel())
+	log.SetLevel(log.InfoLevel)
+
+	h := newHandler(nil, nil)
+
+	t.Run("Returns the log level", func(t *testing.T) {
+		rsp := serve(h, "/log-level")
+		if rsp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d but got %d", http.StatusOK, rsp.Code)
+		}
+		if rsp.Body.String() != "info\n" {
+			t.Fatalf("Unexpected log level: %s", rsp.Body.String())
+		}
+	})
+
+	t.Run("Changes the log level", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/log-level", strings.NewReader("debug\n"))
+		req.RemoteAddr = "127.0.0.1:54321"
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, req)
+
+		if rsp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d but got %d", http.StatusOK, rsp.Code)
+		}
+		if log.GetLevel() != log.DebugLevel {
+			t.Fatalf("Expected log level %s but got %s", log.DebugLevel, log.GetLevel())
+		}
+	})
+
+	t.Run("Rejects invalid log levels", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/log-level", strings.NewReader("verbose"))
+		req.RemoteAddr = "[::1]:54321"
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, req)
+
+		if rsp.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status %d but got %d", http.StatusBadRequest, rsp.Code)
+		}
+		if log.GetLevel() != log.DebugLevel {
+			t.Fatalf("Expected log level %s but got %s", log.DebugLevel, log.GetLevel())
+		}
+	})
+
+	t.Run("Rejects changes from other hosts", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/log-level", strings.NewReader("info"))
+		req.RemoteAddr = "10.1.0.12:54321"
+		rsp := httptest.NewRecorder()
+		h.ServeHTTP(rsp, req)
+
+		if rsp.Code != http.StatusForbidden {
+			t.Fatalf("Expected status %d but got %d", http.StatusForbidden, rsp.Code)
+		}
+		if log.GetLevel() != log.DebugLevel {
+			t.Fatalf("Expected log level %s but got %s", log.DebugLevel, log.GetLevel())
+		}
+	})
+}
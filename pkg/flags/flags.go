@@ -19,11 +19,14 @@ func ConfigureAndParse() {
 
 	logLevel := flag.String("log-level", log.InfoLevel.String(),
 		"log level, must be one of: panic, fatal, error, warn, info, debug")
+	logFormat := flag.String("log-format", "plain",
+		"log format, must be one of: plain, json")
 	printVersion := flag.Bool("version", false, "print version and exit")
 
 	flag.Parse()
 
 	setLogLevel(*logLevel)
+	setLogFormat(*logFormat)
 	maybePrintVersionAndExit(*printVersion)
 }
 
@@ -35,6 +38,20 @@ func setLogLevel(logLevel string) {
 	log.SetLevel(level)
 }
 
+// setLogFormat configures the format of the log entries. In the json format,
+// each entry is a JSON object, with its fields, such as a request's ID, as
+// separate keys, so that log pipelines can parse and correlate them.
+func setLogFormat(logFormat string) {
+	switch logFormat {
+	case "plain":
+		log.SetFormatter(&log.TextFormatter{})
+	case "json":
+		log.SetFormatter(&log.JSONFormatter{})
+	default:
+		log.Fatalf("invalid log-format: %s", logFormat)
+	}
+}
+
 func maybePrintVersionAndExit(printVersion bool) {
 	if printVersion {
 		fmt.Println(version.Version)
@@ -0,0 +1,58 @@
+package healthcheck
+
+import (
+	"math/rand"
+	"time"
+)
+
+// retryBackoff computes the waits between the attempts of a check. They grow
+// exponentially, so that checks that fail fast don't hammer the API server
+// while the ones that are about to pass aren't kept waiting, and are
+// randomized so that the checks of concurrent runs don't retry in lockstep.
+type retryBackoff struct {
+	interval    time.Duration
+	multiplier  float64
+	maxInterval time.Duration
+}
+
+// newRetryBackoff returns the backoff configured by the HealthCheckOptions,
+// using the defaults for the settings they leave unset.
+func (hc *HealthChecker) newRetryBackoff() *retryBackoff {
+	b := &retryBackoff{
+		interval:    defaultRetryInitialInterval,
+		multiplier:  defaultRetryMultiplier,
+		maxInterval: defaultRetryMaxInterval,
+	}
+	if hc.HealthCheckOptions == nil {
+		return b
+	}
+
+	if hc.RetryInitialInterval != 0 {
+		b.interval = hc.RetryInitialInterval
+	}
+	if hc.RetryMultiplier != 0 {
+		b.multiplier = hc.RetryMultiplier
+	}
+	if hc.RetryMaxInterval != 0 {
+		b.maxInterval = hc.RetryMaxInterval
+	}
+	if b.interval > b.maxInterval {
+		b.interval = b.maxInterval
+	}
+	return b
+}
+
+// next returns how long to wait before the next attempt, between half the
+// current interval and the full interval, and grows the interval.
+func (b *retryBackoff) next() time.Duration {
+	wait := b.interval
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(int64(wait)-half+1))
+	}
+
+	b.interval = time.Duration(float64(b.interval) * b.multiplier)
+	if b.interval > b.maxInterval {
+		b.interval = b.maxInterval
+	}
+	return wait
+}
@@ -0,0 +1,41 @@
+package healthcheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	t.Run("Grows the interval exponentially up to the max interval", func(t *testing.T) {
+		hc := HealthChecker{
+			HealthCheckOptions: &HealthCheckOptions{
+				RetryInitialInterval: time.Second,
+				RetryMultiplier:      3,
+				RetryMaxInterval:     5 * time.Second,
+			},
+		}
+		backoff := hc.newRetryBackoff()
+
+		intervals := []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second}
+		for i, interval := range intervals {
+			wait := backoff.next()
+			if wait < interval/2 || wait > interval {
+				t.Fatalf("Expected wait %d to be between %s and %s, got %s", i, interval/2, interval, wait)
+			}
+		}
+	})
+
+	t.Run("Uses the defaults for the settings that are unset", func(t *testing.T) {
+		hc := HealthChecker{HealthCheckOptions: &HealthCheckOptions{RetryMaxInterval: time.Minute}}
+		backoff := hc.newRetryBackoff()
+
+		expected := retryBackoff{
+			interval:    defaultRetryInitialInterval,
+			multiplier:  defaultRetryMultiplier,
+			maxInterval: time.Minute,
+		}
+		if *backoff != expected {
+			t.Fatalf("Expected backoff %+v, got %+v", expected, *backoff)
+		}
+	})
+}
@@ -0,0 +1,286 @@
+package healthcheck
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultCertExpiryWindow is how long before the issuer certificate expires
+// the expiry check warns when the HealthCheckOptions don't configure it. It
+// matches the LinkerdCACertificateExpiringSoon alert.
+var defaultCertExpiryWindow = 7 * 24 * time.Hour
+
+func (hc *HealthChecker) addLinkerdCAChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:            "linkerd-ca/pods-ready",
+		category:      LinkerdCACategory,
+		description:   "CA pod is ready",
+		remediation:   fmt.Sprintf("Inspect the CA pod with `kubectl -n %s describe pods -l %s=ca`", hc.ControlPlaneNamespace, k8s.ControllerComponentLabel),
+		retryDeadline: hc.RetryDeadline,
+		fatal:         true,
+		skip:          hc.caNotInstalled,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			pods, err := clientset.CoreV1().Pods(hc.ControlPlaneNamespace).List(metav1.ListOptions{
+				LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerComponentLabel, caDeployment),
+			})
+			if err != nil {
+				return err
+			}
+
+			return validateContainersReady(runningContainerStatuses(pods.Items), []string{caDeployment})
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-ca/issuer-present",
+		category:    LinkerdCACategory,
+		description: "issuer certificate is present",
+		remediation: "Create the issuer secret given to --identity-issuer-secret, or restart the CA pod to distribute the trust anchors again",
+		fatal:       true,
+		skip:        hc.caNotInstalled,
+		check: func() (err error) {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			hc.issuerCertificate, err = getIssuerCertificate(clientset, hc.ControlPlaneNamespace)
+			return
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-ca/issuer-valid",
+		category:    LinkerdCACategory,
+		description: "issuer certificate is valid",
+		remediation: "Replace the issuer secret with a valid certificate, or restart the CA pod to generate a new one",
+		fatal:       false,
+		skip:        hc.caNotInstalled,
+		check: func() error {
+			return validateIssuerValidity(hc.issuerCertificate, time.Now())
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-ca/issuer-expiry",
+		category:    LinkerdCACategory,
+		description: "issuer certificate isn't about to expire",
+		remediation: "Replace the issuer secret with a new certificate before the proxies stop trusting each other",
+		fatal:       false,
+		severity:    SeverityWarning,
+		skip:        hc.caNotInstalled,
+		check: func() error {
+			return validateIssuerExpiry(hc.issuerCertificate, time.Now(), hc.certExpiryWindow())
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-ca/proxies-trust-issuer",
+		category:    LinkerdCACategory,
+		description: "proxies trust the current issuer",
+		remediation: "Restart the pods whose certificates were issued by a previous CA, once their namespace's trust anchors include the current one",
+		fatal:       false,
+		skip:        hc.caNotInstalled,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			pods, err := hc.getMeshedPods()
+			if err != nil {
+				return err
+			}
+
+			return validateProxiesTrustIssuer(clientset, pods, hc.issuerCertificate)
+		},
+	})
+}
+
+// caNotInstalled is the skip function of the CA checks, which only apply to
+// control planes installed with TLS enabled. If it can't be told whether TLS
+// is enabled, the checks are run so that they report the error.
+func (hc *HealthChecker) caNotInstalled() bool {
+	if hc.caInstalled == nil {
+		tls, err := hc.controlPlaneTLS()
+		if err != nil {
+			return false
+		}
+		hc.caInstalled = &tls
+	}
+	return !*hc.caInstalled
+}
+
+// certExpiryWindow returns the CertExpiryWindow option, or its default.
+func (hc *HealthChecker) certExpiryWindow() time.Duration {
+	if hc.HealthCheckOptions != nil && hc.CertExpiryWindow != 0 {
+		return hc.CertExpiryWindow
+	}
+	return defaultCertExpiryWindow
+}
+
+// getIssuerCertificate returns the certificate of the CA issuing the proxies'
+// certificates: the one in the issuer secret the CA is configured with, or
+// otherwise the one it generated and distributed as the trust anchors of the
+// control plane namespace.
+func getIssuerCertificate(clientset kubernetes.Interface, namespace string) (*x509.Certificate, error) {
+	deploy, err := clientset.AppsV1().Deployments(namespace).Get(caDeployment, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	issuerSecret := ""
+	for _, container := range deploy.Spec.Template.Spec.Containers {
+		for _, arg := range container.Args {
+			if strings.HasPrefix(arg, "-issuer-secret=") {
+				issuerSecret = strings.TrimPrefix(arg, "-issuer-secret=")
+			}
+		}
+	}
+
+	if issuerSecret != "" {
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(issuerSecret, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("The \"%s/%s\" issuer secret doesn't exist", namespace, issuerSecret)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		certificates := parseCertificates(secret.Data[v1.TLSCertKey])
+		if len(certificates) == 0 {
+			return nil, fmt.Errorf("The \"%s/%s\" issuer secret has no certificate", namespace, issuerSecret)
+		}
+		return certificates[0], nil
+	}
+
+	anchors, err := getTrustAnchorCertificates(clientset, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(anchors) == 0 {
+		return nil, fmt.Errorf("The \"%s/%s\" ConfigMap has no trust anchors; the CA hasn't distributed its certificate", namespace, k8s.TLSTrustAnchorConfigMapName)
+	}
+	return anchors[0], nil
+}
+
+// getTrustAnchorCertificates returns the trust anchors distributed to the
+// proxies in a namespace, or nil if there are none.
+func getTrustAnchorCertificates(clientset kubernetes.Interface, namespace string) ([]*x509.Certificate, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(k8s.TLSTrustAnchorConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCertificates([]byte(cm.Data[k8s.TLSTrustAnchorFileName])), nil
+}
+
+// parseCertificates returns the certificates in PEM data, skipping the blocks
+// that aren't valid certificates.
+func parseCertificates(data []byte) []*x509.Certificate {
+	certificates := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certificates
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		certificates = append(certificates, certificate)
+	}
+}
+
+// validateIssuerValidity returns an error if the issuer certificate isn't
+// valid at the given time, in which case the proxies' certificates aren't
+// trusted.
+func validateIssuerValidity(issuer *x509.Certificate, now time.Time) error {
+	if now.Before(issuer.NotBefore) {
+		return fmt.Errorf("The issuer certificate isn't valid before %s", issuer.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(issuer.NotAfter) {
+		return fmt.Errorf("The issuer certificate expired at %s", issuer.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateIssuerExpiry returns an error if the issuer certificate expires
+// within the given window.
+func validateIssuerExpiry(issuer *x509.Certificate, now time.Time, window time.Duration) error {
+	if issuer.NotAfter.Before(now.Add(window)) {
+		return fmt.Errorf("The issuer certificate expires at %s, in less than %s", issuer.NotAfter.Format(time.RFC3339), window)
+	}
+	return nil
+}
+
+// validateProxiesTrustIssuer returns an error if the trust anchors distributed
+// to the namespace of a meshed pod with TLS enabled don't include the issuer
+// certificate, or if the pod's certificate wasn't issued by it.
+func validateProxiesTrustIssuer(clientset kubernetes.Interface, pods []v1.Pod, issuer *x509.Certificate) error {
+	checkedNamespaces := make(map[string]bool)
+
+	for i := range pods {
+		pod := &pods[i]
+		identity, err := k8s.ParseTLSIdentity(strings.Replace(proxyEnv(pod, proxyPodIdentityEnvVar), "$"+proxyPodNamespaceEnvVar, pod.Namespace, -1))
+		if err != nil {
+			// the proxy doesn't have TLS enabled, or its identity is invalid,
+			// which the data plane checks report
+			continue
+		}
+
+		if !checkedNamespaces[pod.Namespace] {
+			anchors, err := getTrustAnchorCertificates(clientset, pod.Namespace)
+			if err != nil {
+				return err
+			}
+			if !containsCertificate(anchors, issuer) {
+				return fmt.Errorf("The trust anchors of the \"%s\" namespace don't include the current issuer certificate", pod.Namespace)
+			}
+			checkedNamespaces[pod.Namespace] = true
+		}
+
+		certificate, problem, err := getCertificate(clientset, pod.Namespace, identity.ToSecretName())
+		if err != nil {
+			return err
+		}
+		if problem != "" {
+			return fmt.Errorf("The \"%s/%s\" pod has no usable certificate: %s", pod.Namespace, pod.Name, problem)
+		}
+		if err := certificate.CheckSignatureFrom(issuer); err != nil {
+			return fmt.Errorf("The \"%s/%s\" pod's certificate wasn't issued by the current issuer: %s", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func containsCertificate(certificates []*x509.Certificate, certificate *x509.Certificate) bool {
+	for _, c := range certificates {
+		if c.Equal(certificate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package healthcheck
+
+import (
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/ca"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func caDeploymentWithArgs(args ...string) *appsV1.Deployment {
+	return &appsV1.Deployment{
+		ObjectMeta: meta.ObjectMeta{Name: caDeployment, Namespace: "linkerd"},
+		Spec: appsV1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "ca", Args: args}},
+				},
+			},
+		},
+	}
+}
+
+func trustAnchorsConfigMap(namespace string, authorities ...*ca.CA) *v1.ConfigMap {
+	pems := []string{}
+	for _, authority := range authorities {
+		pems = append(pems, authority.TrustAnchorPEM())
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: k8s.TLSTrustAnchorConfigMapName, Namespace: namespace},
+		Data:       map[string]string{k8s.TLSTrustAnchorFileName: strings.Join(pems, "")},
+	}
+}
+
+func certificateOf(t *testing.T, authority *ca.CA) *x509.Certificate {
+	certificates := parseCertificates([]byte(authority.TrustAnchorPEM()))
+	if len(certificates) != 1 {
+		t.Fatalf("Expected 1 certificate, got %d", len(certificates))
+	}
+	return certificates[0]
+}
+
+func TestGetIssuerCertificate(t *testing.T) {
+	authority, err := ca.NewCA()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		description string
+		objects     []runtime.Object
+		expected    string
+	}{
+		{
+			description: "Returns the trust anchors of the control plane namespace",
+			objects:     []runtime.Object{caDeploymentWithArgs("ca"), trustAnchorsConfigMap("linkerd", authority)},
+		},
+		{
+			description: "Returns the certificate of the issuer secret",
+			objects: []runtime.Object{
+				caDeploymentWithArgs("ca", "-issuer-secret=issuer"),
+				&v1.Secret{
+					ObjectMeta: meta.ObjectMeta{Name: "issuer", Namespace: "linkerd"},
+					Data:       map[string][]byte{v1.TLSCertKey: []byte(authority.TrustAnchorPEM())},
+				},
+			},
+		},
+		{
+			description: "Returns an error if the issuer secret doesn't exist",
+			objects:     []runtime.Object{caDeploymentWithArgs("ca", "-issuer-secret=issuer"), trustAnchorsConfigMap("linkerd", authority)},
+			expected:    "The \"linkerd/issuer\" issuer secret doesn't exist",
+		},
+		{
+			description: "Returns an error if the trust anchors weren't distributed",
+			objects:     []runtime.Object{caDeploymentWithArgs("ca")},
+			expected:    "The \"linkerd/linkerd-ca-bundle\" ConfigMap has no trust anchors; the CA hasn't distributed its certificate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			certificate, err := getIssuerCertificate(fake.NewSimpleClientset(tc.objects...), "linkerd")
+			if tc.expected != "" {
+				if err == nil || err.Error() != tc.expected {
+					t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !certificate.Equal(certificateOf(t, authority)) {
+				t.Fatalf("Expected the CA's certificate, got %s", certificate.Subject)
+			}
+		})
+	}
+}
+
+func TestValidateIssuerValidity(t *testing.T) {
+	now := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	issuer := &x509.Certificate{
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(24 * time.Hour),
+	}
+
+	if err := validateIssuerValidity(issuer, now); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err := validateIssuerValidity(issuer, now.Add(48*time.Hour))
+	expected := "The issuer certificate expired at 2018-10-02T00:00:00Z"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+
+	err = validateIssuerValidity(issuer, now.Add(-2*time.Hour))
+	expected = "The issuer certificate isn't valid before 2018-09-30T23:00:00Z"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
+
+func TestValidateIssuerExpiry(t *testing.T) {
+	now := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	issuer := &x509.Certificate{NotAfter: now.Add(72 * time.Hour)}
+
+	if err := validateIssuerExpiry(issuer, now, 48*time.Hour); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err := validateIssuerExpiry(issuer, now, 96*time.Hour)
+	expected := "The issuer certificate expires at 2018-10-04T00:00:00Z, in less than 96h0m0s"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
+
+func TestValidateProxiesTrustIssuer(t *testing.T) {
+	authority, err := ca.NewCA()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	previousAuthority, err := ca.NewCA()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	identity := k8s.TLSIdentity{Name: "books", Kind: "deployment", Namespace: "booksapp", ControllerNamespace: "linkerd"}
+
+	pod := v1.Pod{
+		ObjectMeta: meta.ObjectMeta{Name: "books-66f5b6b5c5-ddk7b", Namespace: "booksapp"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: k8s.ProxyContainerName,
+				Env: []v1.EnvVar{
+					{Name: proxyPodIdentityEnvVar, Value: "books.deployment.$LINKERD2_PROXY_POD_NAMESPACE.linkerd-managed.linkerd.svc.cluster.local"},
+				},
+			}},
+		},
+	}
+	plaintextPod := v1.Pod{
+		ObjectMeta: meta.ObjectMeta{Name: "authors-7d8f9c6b4d-l5sxv", Namespace: "booksapp"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: k8s.ProxyContainerName}}},
+	}
+
+	secret := func(authority *ca.CA) *v1.Secret {
+		crt, err := authority.IssueEndEntityCertificate(identity.ToDNSName())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return &v1.Secret{
+			ObjectMeta: meta.ObjectMeta{Name: identity.ToSecretName(), Namespace: "booksapp"},
+			Data:       map[string][]byte{k8s.TLSCertFileName: crt.Certificate},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		objects     []runtime.Object
+		expected    string
+	}{
+		{
+			description: "Passes if the proxies' certificates were issued by the current issuer",
+			objects:     []runtime.Object{trustAnchorsConfigMap("booksapp", previousAuthority, authority), secret(authority)},
+		},
+		{
+			description: "Fails if the trust anchors don't include the current issuer",
+			objects:     []runtime.Object{trustAnchorsConfigMap("booksapp", previousAuthority), secret(authority)},
+			expected:    "The trust anchors of the \"booksapp\" namespace don't include the current issuer certificate",
+		},
+		{
+			description: "Fails if a certificate was issued by a previous issuer",
+			objects:     []runtime.Object{trustAnchorsConfigMap("booksapp", previousAuthority, authority), secret(previousAuthority)},
+			expected:    "The \"booksapp/books-66f5b6b5c5-ddk7b\" pod's certificate wasn't issued by the current issuer",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(tc.objects...)
+			err := validateProxiesTrustIssuer(clientset, []v1.Pod{plaintextPod, pod}, certificateOf(t, authority))
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expected) {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
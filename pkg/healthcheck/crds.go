@@ -0,0 +1,106 @@
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// linkerdAPIGroup is the API group of Linkerd's custom resources.
+const linkerdAPIGroup = "linkerd.io"
+
+// expectedCustomResource is a custom resource kind that Linkerd reads, along
+// with the version of its schema that this version of Linkerd understands.
+type expectedCustomResource struct {
+	groupVersion string
+	kind         string
+}
+
+// serviceProfileCustomResource is the ServiceProfile kind, which
+// `linkerd top --routes` reads routes from.
+var serviceProfileCustomResource = expectedCustomResource{
+	groupVersion: profiles.ServiceProfileAPIVersion,
+	kind:         profiles.ServiceProfileKind,
+}
+
+var expectedCustomResources = []expectedCustomResource{serviceProfileCustomResource}
+
+// getLinkerdAPIResources returns the resources served in Linkerd's API group,
+// keyed by group version. The result is empty if the group isn't served, i.e.
+// if none of Linkerd's CRDs are installed.
+func (hc *HealthChecker) getLinkerdAPIResources() (map[string][]metav1.APIResource, error) {
+	if hc.linkerdAPIResources != nil {
+		return hc.linkerdAPIResources, nil
+	}
+
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := clientset.Discovery().ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string][]metav1.APIResource{}
+	for _, group := range groups.Groups {
+		if group.Name != linkerdAPIGroup {
+			continue
+		}
+
+		for _, version := range group.Versions {
+			list, err := clientset.Discovery().ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				return nil, err
+			}
+			resources[version.GroupVersion] = list.APIResources
+		}
+	}
+
+	hc.linkerdAPIResources = resources
+	return resources, nil
+}
+
+// validateCustomResources returns an error if the resources served in
+// Linkerd's API group, keyed by group version, don't include the versions and
+// kinds the control plane expects. This happens after a partial upgrade, when
+// the CRDs were upgraded but the control plane wasn't, or vice versa, and
+// leaves Linkerd unable to read the custom resources.
+func validateCustomResources(resources map[string][]metav1.APIResource) error {
+	for _, expected := range expectedCustomResources {
+		if err := validateCustomResource(resources, expected); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateCustomResource returns an error if the resources served in
+// Linkerd's API group, keyed by group version, don't include the expected
+// version and kind.
+func validateCustomResource(resources map[string][]metav1.APIResource, expected expectedCustomResource) error {
+	kinds, ok := resources[expected.groupVersion]
+	if !ok {
+		served := []string{}
+		for groupVersion := range resources {
+			served = append(served, groupVersion)
+		}
+		sort.Strings(served)
+
+		return fmt.Errorf("The installed %s CRD serves [%s], but the control plane expects %s; upgrade the CRDs and the control plane to the same version",
+			expected.kind, strings.Join(served, ", "), expected.groupVersion)
+	}
+
+	for _, resource := range kinds {
+		if resource.Kind == expected.kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("The %s API is served, but it has no %s resource; upgrade the CRDs and the control plane to the same version",
+		expected.groupVersion, expected.kind)
+}
@@ -0,0 +1,45 @@
+package healthcheck
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateCustomResources(t *testing.T) {
+	serviceProfiles := []metav1.APIResource{
+		metav1.APIResource{Name: "serviceprofiles", Kind: "ServiceProfile"},
+	}
+
+	t.Run("Returns nil if the expected versions are served", func(t *testing.T) {
+		err := validateCustomResources(map[string][]metav1.APIResource{
+			"linkerd.io/v1alpha1": serviceProfiles,
+			"linkerd.io/v1alpha2": serviceProfiles,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if the CRDs were upgraded without the control plane", func(t *testing.T) {
+		err := validateCustomResources(map[string][]metav1.APIResource{
+			"linkerd.io/v1alpha2": serviceProfiles,
+		})
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "The installed ServiceProfile CRD serves [linkerd.io/v1alpha2], but the control plane expects linkerd.io/v1alpha1; upgrade the CRDs and the control plane to the same version"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if an expected kind is missing", func(t *testing.T) {
+		err := validateCustomResources(map[string][]metav1.APIResource{
+			"linkerd.io/v1alpha1": []metav1.APIResource{},
+		})
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package healthcheck
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// addServiceAvailabilityChecks adds checks that a control plane Service has
+// ready endpoints, and that its health endpoint, served on the Service's
+// named port, responds. The pods being ready doesn't mean that the Service
+// routes to them, e.g. if its selector doesn't match their labels.
+func (hc *HealthChecker) addServiceAvailabilityChecks(service, title, port, healthPath string) {
+	hc.checkers = append(hc.checkers, &checker{
+		id:            fmt.Sprintf("linkerd-api/%s-endpoints", service),
+		category:      LinkerdAPICategory,
+		description:   fmt.Sprintf("%s service has ready endpoints", title),
+		remediation:   fmt.Sprintf("Make sure the selector of the \"%s\" service matches the labels of its pods, with `kubectl -n %s describe svc %s`", service, hc.ControlPlaneNamespace, service),
+		retryDeadline: hc.RetryDeadline,
+		fatal:         false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			endpoints, err := clientset.CoreV1().Endpoints(hc.ControlPlaneNamespace).Get(service, metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				return fmt.Errorf("The \"%s/%s\" service has no endpoints", hc.ControlPlaneNamespace, service)
+			}
+			if err != nil {
+				return err
+			}
+
+			return validateReadyEndpoints(endpoints)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:            fmt.Sprintf("linkerd-api/%s-health", service),
+		category:      LinkerdAPICategory,
+		description:   fmt.Sprintf("%s responds to health checks", title),
+		remediation:   fmt.Sprintf("Inspect the logs of the %s pods with `kubectl -n %s logs deploy/%s`", title, hc.ControlPlaneNamespace, service),
+		retryDeadline: hc.RetryDeadline,
+		fatal:         false,
+		check: func() error {
+			rsp, err := hc.getServiceProxy(service, port, healthPath)
+			if err != nil {
+				return err
+			}
+			rsp.Body.Close()
+
+			if rsp.StatusCode != http.StatusOK {
+				return fmt.Errorf("The \"%s\" service's %s health endpoint responded with %s", service, healthPath, rsp.Status)
+			}
+			return nil
+		},
+	})
+}
+
+// getServiceProxy sends a GET request for path to the named port of a
+// control plane Service, through the Kubernetes API server's service proxy.
+func (hc *HealthChecker) getServiceProxy(service, port, path string) (*http.Response, error) {
+	return hc.getAPIServerProxy(hc.ControlPlaneNamespace, fmt.Sprintf("/services/%s:%s/proxy%s", service, port, path))
+}
+
+// getPodProxy sends a GET request for path to a port of a pod, through the
+// Kubernetes API server's pod proxy.
+func (hc *HealthChecker) getPodProxy(namespace, pod string, port int32, path string) (*http.Response, error) {
+	return hc.getAPIServerProxy(namespace, fmt.Sprintf("/pods/%s:%d/proxy%s", pod, port, path))
+}
+
+// getAPIServerProxy sends a GET request for a proxy subresource of the
+// namespace to the Kubernetes API server.
+func (hc *HealthChecker) getAPIServerProxy(namespace, proxyPath string) (*http.Response, error) {
+	endpoint, err := hc.kubeAPI.UrlFor(namespace, proxyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return hc.httpClient.Do(req.WithContext(hc.ctx))
+}
+
+// validateReadyEndpoints returns an error if the endpoints have no ready
+// address, in which case the Service routes to none of its pods.
+func validateReadyEndpoints(endpoints *v1.Endpoints) error {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("The \"%s/%s\" service has no ready endpoints", endpoints.Namespace, endpoints.Name)
+}
@@ -0,0 +1,68 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestServiceHealthCheck(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/linkerd/services/grafana:http/proxy/api/health" {
+			t.Errorf("Unexpected request path: %s", req.URL.Path)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	hc := &HealthChecker{
+		HealthCheckOptions: &HealthCheckOptions{ControlPlaneNamespace: "linkerd"},
+		ctx:                context.Background(),
+		kubeAPI:            &k8s.KubernetesAPI{Config: &rest.Config{Host: server.URL}},
+		httpClient:         server.Client(),
+	}
+	hc.addServiceAvailabilityChecks("grafana", "Grafana", "http", "/api/health")
+
+	healthCheck := hc.checkers[1]
+	if healthCheck.id != "linkerd-api/grafana-health" {
+		t.Fatalf("Expected the linkerd-api/grafana-health check, got %s", healthCheck.id)
+	}
+
+	if err := healthCheck.check(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	err := healthCheck.check()
+	expected := "The \"grafana\" service's /api/health health endpoint responded with 503 Service Unavailable"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
+
+func TestValidateReadyEndpoints(t *testing.T) {
+	endpoints := &v1.Endpoints{
+		ObjectMeta: meta.ObjectMeta{Name: "grafana", Namespace: "linkerd"},
+		Subsets: []v1.EndpointSubset{
+			{NotReadyAddresses: []v1.EndpointAddress{{IP: "10.1.0.12"}}},
+		},
+	}
+
+	err := validateReadyEndpoints(endpoints)
+	expected := "The \"linkerd/grafana\" service has no ready endpoints"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+
+	endpoints.Subsets = append(endpoints.Subsets, v1.EndpointSubset{Addresses: []v1.EndpointAddress{{IP: "10.1.0.13"}}})
+	if err := validateReadyEndpoints(endpoints); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,156 @@
+package healthcheck
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+)
+
+// maxVersionExamples is the number of pods listed for every proxy version
+// that the data plane version check reports.
+const maxVersionExamples = 3
+
+// dataPlaneNamespaces returns the namespaces the data plane checks are
+// restricted to, from the DataPlaneNamespace and DataPlaneNamespaces options,
+// without duplicates. An empty list means all namespaces.
+func (hc *HealthChecker) dataPlaneNamespaces() []string {
+	namespaces := []string{}
+	seen := make(map[string]bool)
+	for _, namespace := range append([]string{hc.DataPlaneNamespace}, hc.DataPlaneNamespaces...) {
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces
+}
+
+// listedNamespaces returns the namespaces to list the data plane pods of, one
+// at a time; the empty namespace lists the pods of all namespaces.
+func (hc *HealthChecker) listedNamespaces() []string {
+	namespaces := hc.dataPlaneNamespaces()
+	if len(namespaces) == 0 {
+		return []string{""}
+	}
+	return namespaces
+}
+
+// meshedPodsSelector returns the label selector of the pods injected with a
+// proxy belonging to the control plane, restricted by the DataPlaneSelector
+// option.
+func (hc *HealthChecker) meshedPodsSelector() string {
+	selector := fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, hc.ControlPlaneNamespace)
+	if hc.DataPlaneSelector != "" {
+		selector += "," + hc.DataPlaneSelector
+	}
+	return selector
+}
+
+// selectPods returns the pods of the public API that are among the given
+// Kubernetes pods. The public API doesn't return the pods' labels, so the
+// DataPlaneSelector option is applied by listing the pods with Kubernetes.
+func selectPods(pods []*pb.Pod, selected []v1.Pod) []*pb.Pod {
+	names := make(map[string]bool)
+	for _, pod := range selected {
+		names[pod.Namespace+"/"+pod.Name] = true
+	}
+
+	filtered := make([]*pb.Pod, 0)
+	for _, pod := range pods {
+		if names[pod.Name] {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered
+}
+
+// getDataPlaneVersions returns the meshed pods grouped by proxy version. The
+// ProxySkew API reports on whole namespaces, so with the DataPlaneSelector
+// option the versions are read from the selected pods' proxy images instead.
+func (hc *HealthChecker) getDataPlaneVersions() ([]*pb.ProxySkewGroup, error) {
+	if hc.DataPlaneSelector != "" {
+		pods, err := hc.getMeshedPods()
+		if err != nil {
+			return nil, err
+		}
+		return proxyVersionGroups(pods), nil
+	}
+
+	versions := []*pb.ProxySkewGroup{}
+	for _, namespace := range hc.listedNamespaces() {
+		rsp, err := hc.apiClient.ProxySkew(hc.ctx, &pb.ProxySkewRequest{
+			Namespace: namespace,
+		})
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, rsp.GetVersions()...)
+	}
+	return versions, nil
+}
+
+// proxyVersionGroups groups the pods by the tag of their proxy image, listing
+// the first few pods of each group as examples, in the order they're given.
+func proxyVersionGroups(pods []v1.Pod) []*pb.ProxySkewGroup {
+	groups := []*pb.ProxySkewGroup{}
+	byVersion := make(map[string]*pb.ProxySkewGroup)
+	for _, pod := range pods {
+		version, ok := proxyImageTag(pod)
+		if !ok {
+			continue
+		}
+
+		group, ok := byVersion[version]
+		if !ok {
+			group = &pb.ProxySkewGroup{Value: version}
+			byVersion[version] = group
+			groups = append(groups, group)
+		}
+		group.PodCount++
+		if len(group.Examples) < maxVersionExamples {
+			group.Examples = append(group.Examples, &pb.Resource{
+				Namespace: pod.Namespace,
+				Type:      k8s.Pod,
+				Name:      pod.Name,
+			})
+		}
+	}
+	return groups
+}
+
+// proxyImageTag returns the tag of the image of the pod's proxy container,
+// and false if the pod has no proxy container or its image has no tag.
+func proxyImageTag(pod v1.Pod) (string, bool) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			parts := strings.Split(container.Image, ":")
+			if len(parts) < 2 {
+				return "", false
+			}
+			return parts[len(parts)-1], true
+		}
+	}
+	return "", false
+}
+
+// describeDataPlaneScope returns the namespaces and label selector the data
+// plane checks are restricted to, as a suffix for their messages, e.g.
+// ` in the "team-a", "team-b" namespaces matching "app=payments"`.
+func describeDataPlaneScope(namespaces []string, selector string) string {
+	scope := ""
+	switch len(namespaces) {
+	case 0:
+	case 1:
+		scope += fmt.Sprintf(" in the \"%s\" namespace", namespaces[0])
+	default:
+		scope += fmt.Sprintf(" in the \"%s\" namespaces", strings.Join(namespaces, "\", \""))
+	}
+	if selector != "" {
+		scope += fmt.Sprintf(" matching \"%s\"", selector)
+	}
+	return scope
+}
@@ -0,0 +1,104 @@
+package healthcheck
+
+import (
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDataPlaneNamespaces(t *testing.T) {
+	hc := &HealthChecker{HealthCheckOptions: &HealthCheckOptions{
+		DataPlaneNamespace:  "team-a",
+		DataPlaneNamespaces: []string{"team-b", "team-a", "", "team-c"},
+	}}
+
+	namespaces := hc.dataPlaneNamespaces()
+	expected := []string{"team-a", "team-b", "team-c"}
+	if len(namespaces) != len(expected) {
+		t.Fatalf("Expected namespaces %v, got %v", expected, namespaces)
+	}
+	for i := range namespaces {
+		if namespaces[i] != expected[i] {
+			t.Fatalf("Expected namespaces %v, got %v", expected, namespaces)
+		}
+	}
+
+	hc = &HealthChecker{HealthCheckOptions: &HealthCheckOptions{}}
+	if listed := hc.listedNamespaces(); len(listed) != 1 || listed[0] != "" {
+		t.Fatalf("Expected all namespaces to be listed, got %v", listed)
+	}
+}
+
+func TestValidateDataPlanePodsScope(t *testing.T) {
+	testCases := []struct {
+		namespaces []string
+		selector   string
+		expected   string
+	}{
+		{
+			expected: "No \"linkerd-proxy\" containers found",
+		},
+		{
+			namespaces: []string{"team-a", "team-b"},
+			selector:   "app=payments",
+			expected:   "No \"linkerd-proxy\" containers found in the \"team-a\", \"team-b\" namespaces matching \"app=payments\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDataPlanePods([]*pb.Pod{}, tc.namespaces, tc.selector)
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+		}
+	}
+}
+
+func TestSelectPods(t *testing.T) {
+	pods := []*pb.Pod{
+		{Name: "team-a/payments-5b7d9c8f4-2xkqj"},
+		{Name: "team-a/ledger-6b8c7f5d9-ztq4n"},
+		{Name: "team-b/payments-7c5d8b9f6-j2m8x"},
+	}
+	selected := []v1.Pod{
+		{ObjectMeta: meta.ObjectMeta{Namespace: "team-a", Name: "payments-5b7d9c8f4-2xkqj"}},
+		{ObjectMeta: meta.ObjectMeta{Namespace: "team-b", Name: "payments-7c5d8b9f6-j2m8x"}},
+	}
+
+	filtered := selectPods(pods, selected)
+	if len(filtered) != 2 || filtered[0] != pods[0] || filtered[1] != pods[2] {
+		t.Fatalf("Unexpected pods: %v", filtered)
+	}
+}
+
+func TestProxyVersionGroups(t *testing.T) {
+	pod := func(name, image string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: meta.ObjectMeta{Namespace: "team-a", Name: name},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{Name: "payments", Image: "payments:1.0"},
+					{Name: "linkerd-proxy", Image: image},
+				},
+			},
+		}
+	}
+
+	groups := proxyVersionGroups([]v1.Pod{
+		pod("payments-1", "gcr.io/linkerd-io/proxy:stable-2.1.0"),
+		pod("payments-2", "gcr.io/linkerd-io/proxy:stable-2.0.0"),
+		pod("payments-3", "gcr.io/linkerd-io/proxy:stable-2.1.0"),
+		pod("payments-4", "gcr.io/linkerd-io/proxy"),
+	})
+
+	err := validateDataPlaneVersions(groups, "stable-2.1.0")
+	expected := "Some proxies are not running the latest version stable-2.1.0:\n    1 running stable-2.0.0, e.g. team-a/pod/payments-2"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+
+	if len(groups) != 2 || groups[0].GetPodCount() != 2 {
+		t.Fatalf("Unexpected groups: %v", groups)
+	}
+}
@@ -0,0 +1,106 @@
+package healthcheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+)
+
+const (
+	// clusterDNSService is the name of the Service in the kube-system
+	// namespace that fronts the cluster's DNS server.
+	clusterDNSService = "kube-dns"
+
+	// nodeLocalDNSDaemonSet is the name of the DaemonSet that runs NodeLocal
+	// DNSCache in the kube-system namespace.
+	nodeLocalDNSDaemonSet = "node-local-dns"
+
+	// nodeLocalDNSAddress is the link-local address NodeLocal DNSCache listens
+	// on by default.
+	nodeLocalDNSAddress = "169.254.20.10"
+
+	dnsPort = "53"
+)
+
+// validateDataPlaneDNSPolicy returns an error if any of the pods is configured
+// with a resolver that isn't the cluster's DNS, in which case their proxies
+// can't resolve the names of control plane services and fail to start with
+// connection timeouts.
+func validateDataPlaneDNSPolicy(pods []v1.Pod, clusterNameservers []string, controlPlaneNamespace string) error {
+	for _, pod := range pods {
+		name := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+
+		switch pod.Spec.DNSPolicy {
+		case v1.DNSDefault:
+			return fmt.Errorf("The \"%s\" pod uses dnsPolicy Default, so its proxy can't resolve \"*.%s.svc.cluster.local\"; use dnsPolicy ClusterFirst instead",
+				name, controlPlaneNamespace)
+
+		case v1.DNSNone:
+			if pod.Spec.DNSConfig == nil || !containsAny(pod.Spec.DNSConfig.Nameservers, clusterNameservers) {
+				return fmt.Errorf("The \"%s\" pod uses dnsPolicy None without a cluster DNS nameserver, so its proxy can't resolve \"*.%s.svc.cluster.local\"; add one of [%s] to its dnsConfig",
+					name, controlPlaneNamespace, strings.Join(clusterNameservers, ", "))
+			}
+
+		case v1.DNSClusterFirst, "":
+			if pod.Spec.HostNetwork {
+				return fmt.Errorf("The \"%s\" pod uses the host network with dnsPolicy ClusterFirst, which falls back to the node's resolv.conf; use dnsPolicy ClusterFirstWithHostNet instead",
+					name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNodeLocalDNSPods returns an error if any of the pods routes DNS
+// traffic through its proxy. When NodeLocal DNSCache is running, DNS queries
+// that fall back to TCP are redirected to the proxy, which can't forward them
+// to the cache's link-local address.
+func validateNodeLocalDNSPods(pods []v1.Pod) error {
+	unskipped := []string{}
+	for _, pod := range pods {
+		if !skipsOutboundPort(pod, dnsPort) {
+			unskipped = append(unskipped, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+
+	if len(unskipped) > 0 {
+		return fmt.Errorf("NodeLocal DNSCache is running, but DNS traffic from these pods goes through the proxy: %s; re-inject them with --skip-outbound-ports=%s",
+			strings.Join(unskipped, ", "), dnsPort)
+	}
+
+	return nil
+}
+
+func skipsOutboundPort(pod v1.Pod, port string) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name != k8s.InitContainerName {
+			continue
+		}
+
+		for i, arg := range container.Args {
+			if arg == "--outbound-ports-to-ignore" && i+1 < len(container.Args) {
+				for _, p := range strings.Split(container.Args[i+1], ",") {
+					if p == port {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func containsAny(list []string, values []string) bool {
+	for _, elem := range list {
+		for _, value := range values {
+			if elem == value {
+				return true
+			}
+		}
+	}
+	return false
+}
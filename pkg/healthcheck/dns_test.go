@@ -0,0 +1,104 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateDataPlaneDNSPolicy(t *testing.T) {
+	pod := func(policy v1.DNSPolicy, hostNetwork bool, nameservers ...string) v1.Pod {
+		p := v1.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: "books-66f5b6b5c5-ddk7b", Namespace: "booksapp"},
+			Spec: v1.PodSpec{
+				DNSPolicy:   policy,
+				HostNetwork: hostNetwork,
+			},
+		}
+		if len(nameservers) > 0 {
+			p.Spec.DNSConfig = &v1.PodDNSConfig{Nameservers: nameservers}
+		}
+		return p
+	}
+	clusterNameservers := []string{"169.254.20.10", "10.96.0.10"}
+
+	t.Run("Returns nil if pods use cluster DNS", func(t *testing.T) {
+		pods := []v1.Pod{
+			pod(v1.DNSClusterFirst, false),
+			pod(v1.DNSClusterFirstWithHostNet, true),
+			pod(v1.DNSNone, false, "10.96.0.10"),
+		}
+
+		err := validateDataPlaneDNSPolicy(pods, clusterNameservers, "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if a pod uses the node's resolver", func(t *testing.T) {
+		err := validateDataPlaneDNSPolicy([]v1.Pod{pod(v1.DNSDefault, false)}, clusterNameservers, "linkerd")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "The \"booksapp/books-66f5b6b5c5-ddk7b\" pod uses dnsPolicy Default, so its proxy can't resolve \"*.linkerd.svc.cluster.local\"; use dnsPolicy ClusterFirst instead"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if a host network pod uses ClusterFirst", func(t *testing.T) {
+		err := validateDataPlaneDNSPolicy([]v1.Pod{pod(v1.DNSClusterFirst, true)}, clusterNameservers, "linkerd")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+
+	t.Run("Returns an error if a pod uses custom nameservers", func(t *testing.T) {
+		err := validateDataPlaneDNSPolicy([]v1.Pod{pod(v1.DNSNone, false, "8.8.8.8")}, clusterNameservers, "linkerd")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+}
+
+func TestValidateNodeLocalDNSPods(t *testing.T) {
+	pod := func(name string, initArgs ...string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "booksapp"},
+			Spec: v1.PodSpec{
+				InitContainers: []v1.Container{
+					v1.Container{Name: "linkerd-init", Args: initArgs},
+				},
+			},
+		}
+	}
+
+	t.Run("Returns nil if DNS traffic skips the proxy", func(t *testing.T) {
+		pods := []v1.Pod{
+			pod("books-66f5b6b5c5-ddk7b", "--incoming-proxy-port", "4143", "--outbound-ports-to-ignore", "3306,53"),
+		}
+
+		err := validateNodeLocalDNSPods(pods)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if DNS traffic goes through the proxy", func(t *testing.T) {
+		pods := []v1.Pod{
+			pod("books-66f5b6b5c5-ddk7b", "--outbound-ports-to-ignore", "53"),
+			pod("authors-5d8c4d9b9f-k2xkp", "--outbound-ports-to-ignore", "3306"),
+			pod("webapp-7b6c8cd8b9-6tqj9"),
+		}
+
+		err := validateNodeLocalDNSPods(pods)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "NodeLocal DNSCache is running, but DNS traffic from these pods goes through the proxy: booksapp/authors-5d8c4d9b9f-k2xkp, booksapp/webapp-7b6c8cd8b9-6tqj9; re-inject them with --skip-outbound-ports=53"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
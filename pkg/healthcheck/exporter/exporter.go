@@ -0,0 +1,124 @@
+// Package exporter runs the Linkerd checks periodically and exposes their
+// results as Prometheus metrics, so that operators can alert on the health of
+// the control plane without running the CLI and parsing its output.
+package exporter
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+var checkLabels = []string{"category", "description"}
+
+// Exporter is an http.Handler serving the metrics of the latest run of the
+// checks.
+type Exporter struct {
+	newChecker func() (*healthcheck.HealthChecker, error)
+	handler    http.Handler
+
+	status   *prometheus.GaugeVec
+	duration *prometheus.GaugeVec
+	lastRun  prometheus.Gauge
+	errors   prometheus.Counter
+}
+
+// New returns an Exporter that runs the checks of the HealthCheckers returned
+// by newChecker. A new HealthChecker is needed for every run, as checks may
+// register further checks while they run.
+func New(newChecker func() (*healthcheck.HealthChecker, error)) *Exporter {
+	e := &Exporter{
+		newChecker: newChecker,
+		status: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "linkerd_check_status",
+				Help: "Whether the check passed (1) or failed (0) in the latest run.",
+			},
+			checkLabels,
+		),
+		duration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "linkerd_check_duration_seconds",
+				Help: "The time spent running the check in the latest run, retries included.",
+			},
+			checkLabels,
+		),
+		lastRun: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "linkerd_check_last_run_timestamp_seconds",
+				Help: "The time at which the latest run of the checks completed, in seconds since the epoch.",
+			},
+		),
+		errors: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "linkerd_check_run_errors_total",
+				Help: "The number of runs of the checks that couldn't be started.",
+			},
+		),
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e.status, e.duration, e.lastRun, e.errors)
+	e.handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+	return e
+}
+
+// Run runs the checks right away and then every interval, until ctx is done.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e.runChecks(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ServeHTTP serves the metrics in the Prometheus exposition format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	e.handler.ServeHTTP(w, req)
+}
+
+// runChecks runs the checks once, and replaces the metrics of the previous
+// run with their final results. The metrics of the previous run are kept if
+// the checks were interrupted.
+func (e *Exporter) runChecks(ctx context.Context) {
+	hc, err := e.newChecker()
+	if err != nil {
+		log.Errorf("failed to configure the checks: %s", err)
+		e.errors.Inc()
+		return
+	}
+
+	results := hc.RunChecks(ctx, nil)
+	if ctx.Err() != nil {
+		return
+	}
+
+	e.status.Reset()
+	e.duration.Reset()
+	for _, result := range results.Checks {
+		if result.Retry {
+			continue
+		}
+
+		status := 1.0
+		if result.Err != nil {
+			status = 0
+		}
+		e.status.WithLabelValues(result.Category, result.Description).Set(status)
+		e.duration.WithLabelValues(result.Category, result.Description).Set(result.Duration.Seconds())
+	}
+	e.lastRun.Set(float64(time.Now().Unix()))
+}
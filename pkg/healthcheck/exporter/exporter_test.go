@@ -0,0 +1,81 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+)
+
+func scrape(t *testing.T, e *Exporter) string {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return string(body)
+}
+
+func TestExporter(t *testing.T) {
+	t.Run("Exposes the status of each check", func(t *testing.T) {
+		checkErr := fmt.Errorf("failed")
+		e := New(func() (*healthcheck.HealthChecker, error) {
+			hc, err := healthcheck.NewHealthChecker([]healthcheck.Checks{}, &healthcheck.HealthCheckOptions{})
+			if err != nil {
+				return nil, err
+			}
+			hc.Add("category", "check1", func() error {
+				return nil
+			})
+			hc.Add("category", "check2", func() error {
+				return checkErr
+			})
+			return hc, nil
+		})
+
+		e.runChecks(context.Background())
+		metrics := scrape(t, e)
+
+		expected := []string{
+			`linkerd_check_status{category="category",description="check1"} 1`,
+			`linkerd_check_status{category="category",description="check2"} 0`,
+			`linkerd_check_duration_seconds{category="category",description="check1"}`,
+			`linkerd_check_last_run_timestamp_seconds`,
+		}
+		for _, m := range expected {
+			if !strings.Contains(metrics, m) {
+				t.Fatalf("Expected the metrics to contain %q, got:\n%s", m, metrics)
+			}
+		}
+
+		checkErr = nil
+		e.runChecks(context.Background())
+		metrics = scrape(t, e)
+
+		if !strings.Contains(metrics, `linkerd_check_status{category="category",description="check2"} 1`) {
+			t.Fatalf("Expected the metrics to be updated, got:\n%s", metrics)
+		}
+	})
+
+	t.Run("Counts the runs that couldn't be started", func(t *testing.T) {
+		e := New(func() (*healthcheck.HealthChecker, error) {
+			return nil, errors.New("no kubeconfig")
+		})
+
+		e.runChecks(context.Background())
+		metrics := scrape(t, e)
+
+		if !strings.Contains(metrics, "linkerd_check_run_errors_total 1") {
+			t.Fatalf("Expected the metrics to count the error, got:\n%s", metrics)
+		}
+		if strings.Contains(metrics, "linkerd_check_status{") {
+			t.Fatalf("Expected no check status, got:\n%s", metrics)
+		}
+	})
+}
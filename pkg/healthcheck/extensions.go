@@ -0,0 +1,87 @@
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// extension is an extension installed in the cluster.
+type extension struct {
+	name      string
+	namespace string
+}
+
+func (hc *HealthChecker) addLinkerdExtensionChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-extensions/discovery",
+		category:    LinkerdExtensionCategory,
+		description: "can discover installed extensions",
+		remediation: "Make sure the current user can list namespaces",
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			namespaces, err := clientset.CoreV1().Namespaces().List(metav1.ListOptions{LabelSelector: k8s.ExtensionLabel})
+			if err != nil {
+				return err
+			}
+
+			for _, ext := range installedExtensions(namespaces.Items) {
+				hc.addExtensionChecks(ext)
+			}
+			return nil
+		},
+	})
+}
+
+// addExtensionChecks adds the checks of an installed extension, which run
+// after all the checks configured so far.
+func (hc *HealthChecker) addExtensionChecks(ext extension) {
+	if ext.name == jaegerExtension {
+		hc.addJaegerChecks(ext.namespace)
+		return
+	}
+
+	if hc.ExtensionCheck == nil {
+		return
+	}
+	check := hc.ExtensionCheck(ext.name, ext.namespace)
+	if check == nil {
+		return
+	}
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          fmt.Sprintf("linkerd-%s/checks", ext.name),
+		category:    fmt.Sprintf("linkerd-%s", ext.name),
+		description: fmt.Sprintf("%s extension checks pass", ext.name),
+		remediation: "Run the extension's check command to see which of its checks failed",
+		check:       check,
+	})
+}
+
+// installedExtensions returns the extensions installed in the given
+// namespaces, sorted by name, according to the namespaces' ExtensionLabel.
+func installedExtensions(namespaces []v1.Namespace) []extension {
+	extensions := []extension{}
+	for _, ns := range namespaces {
+		name := ns.Labels[k8s.ExtensionLabel]
+		if name == "" {
+			continue
+		}
+		extensions = append(extensions, extension{name: name, namespace: ns.Name})
+	}
+
+	sort.Slice(extensions, func(i, j int) bool {
+		if extensions[i].name == extensions[j].name {
+			return extensions[i].namespace < extensions[j].namespace
+		}
+		return extensions[i].name < extensions[j].name
+	})
+	return extensions
+}
@@ -0,0 +1,93 @@
+package healthcheck
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInstalledExtensions(t *testing.T) {
+	namespace := func(name, extension string) v1.Namespace {
+		return v1.Namespace{
+			ObjectMeta: meta.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"linkerd.io/extension": extension},
+			},
+		}
+	}
+
+	namespaces := []v1.Namespace{
+		namespace("tracing", "jaeger"),
+		namespace("linkerd-viz", "viz"),
+		namespace("other", ""),
+		namespace("linkerd-jaeger", "jaeger"),
+	}
+
+	expected := []extension{
+		{name: "jaeger", namespace: "linkerd-jaeger"},
+		{name: "jaeger", namespace: "tracing"},
+		{name: "viz", namespace: "linkerd-viz"},
+	}
+
+	extensions := installedExtensions(namespaces)
+	if !reflect.DeepEqual(extensions, expected) {
+		t.Fatalf("Expected extensions %v but got %v", expected, extensions)
+	}
+}
+
+func TestAddExtensionChecks(t *testing.T) {
+	t.Run("Adds the check returned by ExtensionCheck", func(t *testing.T) {
+		hc, err := NewHealthChecker([]Checks{}, &HealthCheckOptions{
+			ExtensionCheck: func(extension, namespace string) func() error {
+				return func() error { return nil }
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		hc.addExtensionChecks(extension{name: "viz", namespace: "linkerd-viz"})
+
+		if len(hc.checkers) != 1 {
+			t.Fatalf("Expected 1 checker but got %d", len(hc.checkers))
+		}
+		if hc.checkers[0].category != "linkerd-viz" {
+			t.Fatalf("Unexpected category: %s", hc.checkers[0].category)
+		}
+	})
+
+	t.Run("Skips extensions that can't be checked", func(t *testing.T) {
+		hc, err := NewHealthChecker([]Checks{}, &HealthCheckOptions{
+			ExtensionCheck: func(extension, namespace string) func() error {
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		hc.addExtensionChecks(extension{name: "viz", namespace: "linkerd-viz"})
+
+		if len(hc.checkers) != 0 {
+			t.Fatalf("Expected no checkers but got %d", len(hc.checkers))
+		}
+	})
+
+	t.Run("Uses the built-in jaeger checks", func(t *testing.T) {
+		hc, err := NewHealthChecker([]Checks{}, &HealthCheckOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		hc.addExtensionChecks(extension{name: "jaeger", namespace: "tracing"})
+
+		if len(hc.checkers) != 2 {
+			t.Fatalf("Expected 2 checkers but got %d", len(hc.checkers))
+		}
+		if hc.checkers[0].category != LinkerdJaegerCategory {
+			t.Fatalf("Unexpected category: %s", hc.checkers[0].category)
+		}
+	})
+}
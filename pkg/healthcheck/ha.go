@@ -0,0 +1,245 @@
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// minHAReplicas is the number of replicas that each of the replicated
+// control plane deployments runs at least in HA mode.
+const minHAReplicas = 2
+
+// haDeployments are the control plane deployments that `linkerd install`
+// scales with --controller-replicas, and that are replicated in HA mode. The
+// ca deployment is only installed with TLS enabled.
+var haDeployments = []string{controllerDeployment, caDeployment}
+
+func (hc *HealthChecker) addLinkerdHAChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:            "linkerd-ha/replicas",
+		category:      LinkerdHACategory,
+		description:   "control plane deployments are replicated",
+		remediation:   fmt.Sprintf("Scale the control plane to at least %d replicas, e.g. with `linkerd install --controller-replicas 3 | kubectl apply -f -`", minHAReplicas),
+		retryDeadline: hc.RetryDeadline,
+		fatal:         false,
+		skip:          hc.haDisabled,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			deployments, err := getHADeployments(clientset, hc.ControlPlaneNamespace)
+			if err != nil {
+				return err
+			}
+
+			return validateHAReplicas(deployments)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-ha/anti-affinity",
+		category:    LinkerdHACategory,
+		description: "control plane replicas run on different nodes",
+		remediation: "Make sure the cluster has enough schedulable nodes, and re-render the control plane with `linkerd upgrade --controller-replicas <replicas> | kubectl apply -f -` so that its replicated deployments prefer to run on different nodes",
+		fatal:       false,
+		severity:    SeverityWarning,
+		skip:        hc.haDisabled,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			deployments, err := getHADeployments(clientset, hc.ControlPlaneNamespace)
+			if err != nil {
+				return err
+			}
+
+			for _, deploy := range deployments {
+				pods, err := getDeploymentPods(clientset, deploy)
+				if err != nil {
+					return err
+				}
+				if err := validateReplicasSpread(deploy.Name, pods); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-ha/pod-disruption-budgets",
+		category:    LinkerdHACategory,
+		description: "control plane deployments have PodDisruptionBudgets",
+		remediation: "Re-render the control plane with `linkerd upgrade --controller-replicas <replicas> | kubectl apply -f -`, which adds a PodDisruptionBudget for each replicated deployment",
+		fatal:       false,
+		skip:        hc.haDisabled,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			deployments, err := getHADeployments(clientset, hc.ControlPlaneNamespace)
+			if err != nil {
+				return err
+			}
+
+			pdbs, err := clientset.PolicyV1beta1().PodDisruptionBudgets(hc.ControlPlaneNamespace).List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			return validatePodDisruptionBudgets(deployments, pdbs.Items)
+		},
+	})
+}
+
+// haDisabled is the skip function of the HA checks, which only apply to
+// control planes deployed in HA mode: either the ExpectHA option says so, or
+// the controller deployment runs more than one replica. If it can't be told
+// whether the control plane is replicated, the checks are run so that they
+// report the error.
+func (hc *HealthChecker) haDisabled() bool {
+	if hc.ExpectHA {
+		return false
+	}
+
+	if hc.haEnabled == nil {
+		clientset, err := hc.getClientset()
+		if err != nil {
+			return false
+		}
+
+		deploy, err := clientset.AppsV1().Deployments(hc.ControlPlaneNamespace).Get(controllerDeployment, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		enabled := deploymentReplicas(*deploy) > 1
+		hc.haEnabled = &enabled
+	}
+	return !*hc.haEnabled
+}
+
+// getHADeployments returns the replicated control plane deployments that are
+// installed.
+func getHADeployments(clientset kubernetes.Interface, namespace string) ([]appsv1.Deployment, error) {
+	deployments := []appsv1.Deployment{}
+	for _, name := range haDeployments {
+		deploy, err := clientset.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) && name != controllerDeployment {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, *deploy)
+	}
+	return deployments, nil
+}
+
+// getDeploymentPods returns the pods that the deployment's selector matches.
+func getDeploymentPods(clientset kubernetes.Interface, deploy appsv1.Deployment) ([]v1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := clientset.CoreV1().Pods(deploy.Namespace).List(metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// deploymentReplicas returns the number of replicas of the deployment, which
+// defaults to 1.
+func deploymentReplicas(deploy appsv1.Deployment) int32 {
+	if deploy.Spec.Replicas == nil {
+		return 1
+	}
+	return *deploy.Spec.Replicas
+}
+
+// validateHAReplicas returns an error if one of the deployments runs fewer
+// than minHAReplicas replicas, or if some of its replicas aren't available.
+func validateHAReplicas(deployments []appsv1.Deployment) error {
+	for _, deploy := range deployments {
+		replicas := deploymentReplicas(deploy)
+		if replicas < minHAReplicas {
+			return fmt.Errorf("The \"%s\" deployment has %d replica(s); it needs at least %d in HA mode",
+				deploy.Name, replicas, minHAReplicas)
+		}
+		if deploy.Status.AvailableReplicas < replicas {
+			return fmt.Errorf("The \"%s\" deployment has %d of %d replicas available",
+				deploy.Name, deploy.Status.AvailableReplicas, replicas)
+		}
+	}
+	return nil
+}
+
+// validateReplicasSpread returns an error listing the nodes that run more
+// than one of the deployment's scheduled pods, which a node failure would
+// take down together.
+func validateReplicasSpread(deployment string, pods []v1.Pod) error {
+	podsByNode := make(map[string][]string)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod.Name)
+	}
+
+	shared := []string{}
+	for node, names := range podsByNode {
+		if len(names) > 1 {
+			sort.Strings(names)
+			shared = append(shared, fmt.Sprintf("%s (%s)", node, strings.Join(names, ", ")))
+		}
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+
+	sort.Strings(shared)
+	return fmt.Errorf("Replicas of the \"%s\" deployment share nodes: %s", deployment, strings.Join(shared, ", "))
+}
+
+// validatePodDisruptionBudgets returns an error if no PodDisruptionBudget
+// selects the pods of one of the deployments.
+func validatePodDisruptionBudgets(deployments []appsv1.Deployment, pdbs []policyv1beta1.PodDisruptionBudget) error {
+	for _, deploy := range deployments {
+		podLabels := labels.Set(deploy.Spec.Template.Labels)
+
+		covered := false
+		for _, pdb := range pdbs {
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil {
+				return fmt.Errorf("The \"%s\" PodDisruptionBudget has an invalid selector: %s", pdb.Name, err)
+			}
+			if !selector.Empty() && selector.Matches(podLabels) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return fmt.Errorf("No PodDisruptionBudget selects the pods of the \"%s\" deployment", deploy.Name)
+		}
+	}
+	return nil
+}
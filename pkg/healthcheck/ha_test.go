@@ -0,0 +1,172 @@
+package healthcheck
+
+import (
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	policyV1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func haDeployment(name string, replicas, available int32) *appsV1.Deployment {
+	return &appsV1.Deployment{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "linkerd"},
+		Spec: appsV1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: meta.ObjectMeta{
+					Labels: map[string]string{"linkerd.io/control-plane-component": name},
+				},
+			},
+		},
+		Status: appsV1.DeploymentStatus{AvailableReplicas: available},
+	}
+}
+
+func TestGetHADeployments(t *testing.T) {
+	testCases := []struct {
+		description string
+		objects     []runtime.Object
+		expected    []string
+		notFound    bool
+	}{
+		{
+			description: "Returns the controller and ca deployments",
+			objects:     []runtime.Object{haDeployment("controller", 3, 3), haDeployment("ca", 3, 3), haDeployment("web", 1, 1)},
+			expected:    []string{"controller", "ca"},
+		},
+		{
+			description: "Ignores the missing ca deployment",
+			objects:     []runtime.Object{haDeployment("controller", 3, 3)},
+			expected:    []string{"controller"},
+		},
+		{
+			description: "Returns an error if the controller deployment is missing",
+			objects:     []runtime.Object{haDeployment("ca", 3, 3)},
+			notFound:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			deployments, err := getHADeployments(fake.NewSimpleClientset(tc.objects...), "linkerd")
+			if tc.notFound {
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("Expected a not found error, got [%v]", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if len(deployments) != len(tc.expected) {
+				t.Fatalf("Expected deployments %v, got %d", tc.expected, len(deployments))
+			}
+			for i, deploy := range deployments {
+				if deploy.Name != tc.expected[i] {
+					t.Fatalf("Expected deployments %v, got %s at %d", tc.expected, deploy.Name, i)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateHAReplicas(t *testing.T) {
+	testCases := []struct {
+		description string
+		deployments []appsV1.Deployment
+		expected    string
+	}{
+		{
+			description: "Returns nil if the deployments are replicated and available",
+			deployments: []appsV1.Deployment{*haDeployment("controller", 3, 3), *haDeployment("ca", 3, 3)},
+		},
+		{
+			description: "Returns an error if a deployment runs a single replica",
+			deployments: []appsV1.Deployment{*haDeployment("controller", 1, 1)},
+			expected:    "The \"controller\" deployment has 1 replica(s); it needs at least 2 in HA mode",
+		},
+		{
+			description: "Returns an error if some replicas aren't available",
+			deployments: []appsV1.Deployment{*haDeployment("controller", 3, 3), *haDeployment("ca", 3, 2)},
+			expected:    "The \"ca\" deployment has 2 of 3 replicas available",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := validateHAReplicas(tc.deployments)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateReplicasSpread(t *testing.T) {
+	pod := func(name, node string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: name},
+			Spec:       v1.PodSpec{NodeName: node},
+		}
+	}
+
+	err := validateReplicasSpread("controller", []v1.Pod{
+		pod("controller-1", "node-a"),
+		pod("controller-2", "node-b"),
+		pod("controller-3", ""),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = validateReplicasSpread("controller", []v1.Pod{
+		pod("controller-3", "node-a"),
+		pod("controller-2", "node-b"),
+		pod("controller-1", "node-a"),
+	})
+	expected := "Replicas of the \"controller\" deployment share nodes: node-a (controller-1, controller-3)"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
+
+func TestValidatePodDisruptionBudgets(t *testing.T) {
+	pdb := func(component string) policyV1beta1.PodDisruptionBudget {
+		return policyV1beta1.PodDisruptionBudget{
+			ObjectMeta: meta.ObjectMeta{Name: component, Namespace: "linkerd"},
+			Spec: policyV1beta1.PodDisruptionBudgetSpec{
+				Selector: &meta.LabelSelector{
+					MatchLabels: map[string]string{"linkerd.io/control-plane-component": component},
+				},
+			},
+		}
+	}
+	deployments := []appsV1.Deployment{*haDeployment("controller", 3, 3), *haDeployment("ca", 3, 3)}
+
+	err := validatePodDisruptionBudgets(deployments, []policyV1beta1.PodDisruptionBudget{pdb("controller"), pdb("ca")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	empty := policyV1beta1.PodDisruptionBudget{
+		ObjectMeta: meta.ObjectMeta{Name: "all", Namespace: "linkerd"},
+		Spec:       policyV1beta1.PodDisruptionBudgetSpec{Selector: &meta.LabelSelector{}},
+	}
+	err = validatePodDisruptionBudgets(deployments, []policyV1beta1.PodDisruptionBudget{pdb("controller"), empty})
+	expected := "No PodDisruptionBudget selects the pods of the \"ca\" deployment"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
@@ -1,19 +1,26 @@
 package healthcheck
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/linkerd/linkerd2/controller/api/public"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
+	admissionregistration "k8s.io/api/admissionregistration/v1beta1"
 	authorizationapi "k8s.io/api/authorization/v1beta1"
 	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sVersion "k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
 )
@@ -25,60 +32,263 @@ const (
 	// configured to interact with a working Kubernetes cluster and that the
 	// cluster meets the minimum version requirements, unless the
 	// ShouldCheckKubeVersion option is false.
+	// These checks initialize the Kubernetes API client that most other checks
+	// require.
 	KubernetesAPIChecks Checks = iota
 
 	// LinkerdPreInstallChecks adds a check to validate that the control plane
-	// namespace does not already exist. This check only runs as part of the set
-	// of pre-install checks.
-	// This check is dependent on the output of KubernetesAPIChecks, so those
-	// checks must be added first.
+	// namespace does not already exist, and checks that the caller can create
+	// the control plane's resources and grant the permissions its ClusterRoles
+	// need at runtime. These checks only run as part of the set of pre-install
+	// checks.
+	// It requires KubernetesAPIChecks.
 	LinkerdPreInstallChecks
 
 	// LinkerdDataPlaneChecks adds a data plane check to validate that the proxy
-	// containers are in the ready state.
-	// This check is dependent on the output of KubernetesAPIChecks, so those
-	// checks must be added first.
+	// containers are in the ready state, that Prometheus discovers and scrapes
+	// them, that the pods' DNS configuration lets the proxies resolve control
+	// plane names, and that the proxies' TLS identities and certificates match
+	// the control plane's trust domain. The pods checked can be restricted with
+	// the DataPlaneNamespaces and DataPlaneSelector options.
+	// It requires KubernetesAPIChecks and LinkerdAPIChecks.
 	LinkerdDataPlaneChecks
 
 	// LinkerdAPIChecks adds a series of checks to validate that the control plane
-	// namespace exists and that it's successfully serving the public API.
-	// These checks are dependent on the output of KubernetesAPIChecks, so those
-	// checks must be added first.
+	// namespace exists, that it's successfully serving the public API, that
+	// the installed CRDs match the custom resources it expects, and that the
+	// Grafana and web dashboard Services route to healthy pods.
+	// These checks require KubernetesAPIChecks, and initialize the public API
+	// client that the data plane and version checks require.
 	LinkerdAPIChecks
 
 	// LinkerdVersionChecks adds a series of checks to validate that the CLI,
 	// control plane, and data plane are running the latest available version.
-	// These checks are dependent on the output of AddLinkerdAPIChecks, so those
-	// checks must be added first, unless the the ShouldCheckControlPlaneVersion
-	// and ShouldCheckDataPlaneVersion options are false.
+	// These checks require LinkerdAPIChecks, unless the
+	// ShouldCheckControlPlaneVersion and ShouldCheckDataPlaneVersion options
+	// are false. The checks are skipped if the control plane was installed with
+	// --disable-telemetry.
 	LinkerdVersionChecks
 
-	KubernetesAPICategory     = "kubernetes-api"
-	LinkerdPreInstallCategory = "kubernetes-setup"
-	LinkerdDataPlaneCategory  = "linkerd-data-plane"
-	LinkerdAPICategory        = "linkerd-api"
-	LinkerdVersionCategory    = "linkerd-version"
+	// LinkerdInjectionSafetyChecks adds a series of checks to validate that
+	// proxies aren't injected into namespaces that are critical to the
+	// cluster's operation, that any proxy injection webhooks served by the
+	// control plane exclude them, and that those webhooks neither block all pod
+	// creation during control plane outages nor come close to the API server's
+	// admission timeout.
+	// These checks require KubernetesAPIChecks.
+	LinkerdInjectionSafetyChecks
+
+	// LinkerdJaegerChecks adds a series of checks to validate that the jaeger
+	// extension is installed in the JaegerNamespace and that its pods are ready.
+	// These checks require KubernetesAPIChecks.
+	LinkerdJaegerChecks
+
+	// LinkerdExtensionChecks adds a check that discovers the extensions
+	// installed in the cluster from the ExtensionLabel on their namespaces,
+	// and then adds the checks of each extension: the built-in checks for
+	// extensions that have them, and otherwise the check returned by the
+	// ExtensionCheck option.
+	// These checks require KubernetesAPIChecks.
+	LinkerdExtensionChecks
+
+	// LinkerdPreUpgradeChecks adds a series of checks to validate that the
+	// existing control plane can be upgraded to the CLI's version: that it's
+	// installed, that the upgrade doesn't skip versions, that the caller can
+	// update its resources, and that none of its deployments are being rolled
+	// out.
+	// These checks require KubernetesAPIChecks.
+	LinkerdPreUpgradeChecks
+
+	// LinkerdPostInstallChecks adds a series of checks to validate that all
+	// the resources `linkerd install` creates for the control plane exist, and
+	// that they were created by the installed version, so that partially
+	// applied installations and upgrades are reported.
+	// These checks require KubernetesAPIChecks.
+	LinkerdPostInstallChecks
+
+	// LinkerdCAChecks adds a series of checks to validate that the CA of a
+	// control plane installed with TLS enabled is ready, that the certificate
+	// it issues the proxies' certificates with is valid and isn't about to
+	// expire, and that the meshed pods' proxies trust it. The checks are
+	// skipped if the control plane was installed without TLS.
+	// These checks require KubernetesAPIChecks.
+	LinkerdCAChecks
+
+	// LinkerdServiceProfileChecks adds a series of checks to validate that the
+	// ServiceProfile CRD is installed and serves the version the control plane
+	// expects, and that the caller can list and create ServiceProfiles in the
+	// control plane namespace.
+	// These checks require KubernetesAPIChecks.
+	LinkerdServiceProfileChecks
+
+	// LinkerdProxyAdminChecks adds a check that queries the admin server of
+	// every running data plane proxy, through the Kubernetes API server's pod
+	// proxy, to validate that it's ready, serves its metrics, and reports the
+	// version of its image. The result of each pod is reported separately. The
+	// pods checked are restricted like the LinkerdDataPlaneChecks'.
+	// These checks require KubernetesAPIChecks.
+	LinkerdProxyAdminChecks
+
+	// LinkerdHAChecks adds a series of checks to validate that the control
+	// plane deployments are replicated, that their replicas run on different
+	// nodes, and that PodDisruptionBudgets protect them. The checks only run
+	// if the ExpectHA option is set or the controller deployment runs more
+	// than one replica.
+	// These checks require KubernetesAPIChecks.
+	LinkerdHAChecks
+
+	KubernetesAPICategory          = "kubernetes-api"
+	LinkerdPreInstallCategory      = "kubernetes-setup"
+	LinkerdDataPlaneCategory       = "linkerd-data-plane"
+	LinkerdAPICategory             = "linkerd-api"
+	LinkerdVersionCategory         = "linkerd-version"
+	LinkerdInjectionSafetyCategory = "linkerd-injection-safety"
+	LinkerdJaegerCategory          = "linkerd-jaeger"
+	LinkerdExtensionCategory       = "linkerd-extensions"
+	LinkerdPreUpgradeCategory      = "linkerd-upgrade"
+	LinkerdPostInstallCategory     = "linkerd-install"
+	LinkerdCACategory              = "linkerd-ca"
+	LinkerdServiceProfileCategory  = "linkerd-service-profiles"
+	LinkerdProxyAdminCategory      = "linkerd-proxy-admin"
+	LinkerdHACategory              = "linkerd-ha"
 )
 
+// Severity classifies how the failure of a check affects the overall result,
+// so that callers can tell warnings and connectivity problems apart from other
+// failures.
+type Severity int
+
+const (
+	// SeverityError is the severity of checks whose failure fails the overall
+	// result.
+	SeverityError Severity = iota
+
+	// SeverityWarning is the severity of checks whose failure is reported but
+	// doesn't fail the overall result.
+	SeverityWarning
+
+	// SeverityConnectivity is the severity of checks whose failure means that
+	// the cluster couldn't be reached. Their failure fails the overall result.
+	SeverityConnectivity
+
+	// SeverityInfo is the severity of checks whose failure is only reported
+	// for information, e.g. because it doesn't depend on the installation. It
+	// doesn't fail the overall result, nor is it a warning.
+	SeverityInfo
+)
+
+// Blocking returns true if the failure of a check with the severity fails the
+// overall result.
+func (s Severity) Blocking() bool {
+	return s != SeverityWarning && s != SeverityInfo
+}
+
+// checkCategories are the categories of the results of each set of checks.
+var checkCategories = map[Checks]string{
+	KubernetesAPIChecks:          KubernetesAPICategory,
+	LinkerdPreInstallChecks:      LinkerdPreInstallCategory,
+	LinkerdDataPlaneChecks:       LinkerdDataPlaneCategory,
+	LinkerdAPIChecks:             LinkerdAPICategory,
+	LinkerdVersionChecks:         LinkerdVersionCategory,
+	LinkerdInjectionSafetyChecks: LinkerdInjectionSafetyCategory,
+	LinkerdJaegerChecks:          LinkerdJaegerCategory,
+	LinkerdExtensionChecks:       LinkerdExtensionCategory,
+	LinkerdPreUpgradeChecks:      LinkerdPreUpgradeCategory,
+	LinkerdPostInstallChecks:     LinkerdPostInstallCategory,
+	LinkerdCAChecks:              LinkerdCACategory,
+	LinkerdServiceProfileChecks:  LinkerdServiceProfileCategory,
+	LinkerdProxyAdminChecks:      LinkerdProxyAdminCategory,
+	LinkerdHAChecks:              LinkerdHACategory,
+}
+
+func (c Checks) String() string {
+	if category, ok := checkCategories[c]; ok {
+		return category
+	}
+	return fmt.Sprintf("Checks(%d)", int(c))
+}
+
 var (
-	maxRetries  = 60
-	retryWindow = 5 * time.Second
+	maxRetries = 60
+
+	// defaultRetryInitialInterval, defaultRetryMultiplier and
+	// defaultRetryMaxInterval are the backoff between the attempts of a check
+	// when the HealthCheckOptions don't configure it.
+	defaultRetryInitialInterval = 500 * time.Millisecond
+	defaultRetryMultiplier      = 2.0
+	defaultRetryMaxInterval     = 10 * time.Second
+
+	// criticalNamespaces host cluster components that must be able to start
+	// regardless of the state of the Linkerd control plane.
+	criticalNamespaces = []string{"kube-system", "kube-public"}
+
+	// promQueryLatencyError and promQueryLatencyWarning are the latencies of
+	// the slowest Prometheus query behind a representative stat request above
+	// which the Prometheus latency checks fail and warn. The public API gives
+	// up on a query after 5s.
+	promQueryLatencyError   = 4 * time.Second
+	promQueryLatencyWarning = time.Second
+
+	// kubeAPICheckTimeout and latestVersionCheckTimeout bound the checks that
+	// reach the Kubernetes API and the version check endpoint for the first
+	// time, which hang when they're unreachable rather than fail.
+	kubeAPICheckTimeout       = 30 * time.Second
+	latestVersionCheckTimeout = 10 * time.Second
 )
 
+// checker is a check run by a HealthChecker. Its id identifies it across
+// releases, as "<category>/<name>". Required checkers initialize the clients or
+// the state that the checks after them rely on, so they can't be skipped. The
+// remediation tells users how to fix the check when it fails.
 type checker struct {
+	id            string
+	required      bool
 	category      string
 	description   string
+	remediation   string
 	fatal         bool
+	severity      Severity
 	retryDeadline time.Time
+	timeout       time.Duration
+	skip          func() bool
 	check         func() error
 	checkRPC      func() (*healthcheckPb.SelfCheckResponse, error)
 }
 
 type CheckResult struct {
+	// ID is the stable identifier of the check, which can be given in the
+	// SkipChecks option. It's empty for checks added with Add.
+	ID          string
 	Category    string
 	Description string
+	Severity    Severity
 	Retry       bool
 	Err         error
+
+	// Retries is the number of times the check was retried before this
+	// result. StartTime is when the check's first attempt started, and
+	// Duration the time spent running it since, retries included.
+	Retries   int
+	StartTime time.Time
+	Duration  time.Duration
+
+	// Hint tells users how to fix the check. It's only set when the check
+	// failed, and only for the checks that provide one.
+	Hint *Hint
+}
+
+// Hint is the remediation of a failed check.
+type Hint struct {
+	// Remediation is a short description of how to fix the failure.
+	Remediation string `json:"remediation"`
+}
+
+// hint returns the hint of the checker, or nil if it has none.
+func (c *checker) hint() *Hint {
+	if c.remediation == "" {
+		return nil
+	}
+	return &Hint{Remediation: c.remediation}
 }
 
 type checkObserver func(*CheckResult)
@@ -86,6 +296,7 @@ type checkObserver func(*CheckResult)
 type HealthCheckOptions struct {
 	ControlPlaneNamespace          string
 	DataPlaneNamespace             string
+	JaegerNamespace                string
 	KubeConfig                     string
 	KubeContext                    string
 	APIAddr                        string
@@ -94,28 +305,118 @@ type HealthCheckOptions struct {
 	ShouldCheckKubeVersion         bool
 	ShouldCheckControlPlaneVersion bool
 	ShouldCheckDataPlaneVersion    bool
+
+	// CheckTimeout bounds each attempt of the checks that don't have a timeout
+	// of their own. Zero means no timeout.
+	CheckTimeout time.Duration
+
+	// RetryInitialInterval, RetryMultiplier and RetryMaxInterval configure
+	// the backoff between the attempts of the checks that are retried: the
+	// interval starts at RetryInitialInterval, and is multiplied by
+	// RetryMultiplier after each attempt, up to RetryMaxInterval. Each wait is
+	// randomized between half the interval and the full interval. Zero values
+	// use the defaults, 500ms, 2 and 10s.
+	RetryInitialInterval time.Duration
+	RetryMultiplier      float64
+	RetryMaxInterval     time.Duration
+
+	// CertExpiryWindow is how long before the issuer certificate expires the
+	// CA checks warn about it. Zero uses the default, 7 days.
+	CertExpiryWindow time.Duration
+
+	// CheckServiceAccountPermissions runs the pre-install checks of the
+	// permissions the control plane needs at runtime as its ServiceAccounts,
+	// with SubjectAccessReviews, instead of as the caller. This catches
+	// clusters that restrict what the control plane's ClusterRoles grant, but
+	// requires its ServiceAccounts and RBAC to be applied already, e.g. by a
+	// cluster administrator.
+	CheckServiceAccountPermissions bool
+
+	// DataPlaneNamespaces restricts the data plane checks to the pods of these
+	// namespaces, in addition to DataPlaneNamespace, and DataPlaneSelector to
+	// the pods matching this label selector, e.g. "app=payments". By default,
+	// the pods of all namespaces are checked.
+	DataPlaneNamespaces []string
+	DataPlaneSelector   string
+
+	// ExpectHA runs the HA checks even if the control plane isn't replicated,
+	// so that a control plane meant to be deployed in HA mode with a single
+	// replica fails them.
+	ExpectHA bool
+
+	// SkipChecks are the IDs of the checks that aren't run, e.g. because they
+	// don't apply to the cluster. The checks that initialize the clients used
+	// by the other checks can't be skipped.
+	SkipChecks []string
+
+	// ExtensionCheck returns the check to run for an installed extension that
+	// has no built-in checks, or nil if that extension can't be checked.
+	ExtensionCheck func(extension, namespace string) func() error
 }
 
 type HealthChecker struct {
 	checkers []*checker
 	*HealthCheckOptions
 
-	// these fields are set in the process of running checks
+	// these fields are set in the process of running checks; ctx is the
+	// context of the check that's running
+	ctx              context.Context
 	kubeAPI          *k8s.KubernetesAPI
 	httpClient       *http.Client
 	clientset        *kubernetes.Clientset
 	kubeVersion      *k8sVersion.Info
 	controlPlanePods []v1.Pod
+	nodes            []v1.Node
+	meshedPods       []v1.Pod
+	provider         clusterProvider
 	apiClient        pb.ApiClient
 	latestVersion    string
+	promQueries      []*pb.PrometheusQuery
+
+	// controlPlaneResources caches the result of getControlPlaneResources for
+	// each kind
+	controlPlaneResources map[string]map[string]string
+
+	// caInstalled caches whether the control plane has a CA, and
+	// issuerCertificate is the certificate it issues certificates with
+	caInstalled       *bool
+	issuerCertificate *x509.Certificate
+
+	// injectionWebhooksInstalled caches whether any mutating webhooks are
+	// served from the control plane namespace
+	injectionWebhooksInstalled *bool
+
+	// linkerdAPIResources caches the result of getLinkerdAPIResources
+	linkerdAPIResources map[string][]metav1.APIResource
+
+	// haEnabled caches whether the controller deployment is replicated
+	haEnabled *bool
 }
 
-func NewHealthChecker(checks []Checks, options *HealthCheckOptions) *HealthChecker {
+// NewHealthChecker returns a HealthChecker that runs the given sets of checks.
+// The sets are reordered so that each runs after the sets it requires, and
+// otherwise keep their given order. An error is returned if a set of checks
+// requires a set that isn't given, since its checks would rely on a client
+// that is never initialized.
+func NewHealthChecker(checks []Checks, options *HealthCheckOptions) (*HealthChecker, error) {
 	hc := &HealthChecker{
 		checkers:           make([]*checker, 0),
 		HealthCheckOptions: options,
 	}
 
+	checks, err := hc.sortChecks(checks)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.RetryMultiplier != 0 && options.RetryMultiplier < 1 {
+		return nil, fmt.Errorf("The retry multiplier must be at least 1, got %g", options.RetryMultiplier)
+	}
+
+	if _, err := labels.Parse(options.DataPlaneSelector); err != nil {
+		return nil, fmt.Errorf("Invalid data plane selector \"%s\": %s", options.DataPlaneSelector, err)
+	}
+
 	for _, check := range checks {
 		switch check {
 		case KubernetesAPIChecks:
@@ -128,17 +429,169 @@ func NewHealthChecker(checks []Checks, options *HealthCheckOptions) *HealthCheck
 			hc.addLinkerdAPIChecks()
 		case LinkerdVersionChecks:
 			hc.addLinkerdVersionChecks()
+		case LinkerdInjectionSafetyChecks:
+			hc.addLinkerdInjectionSafetyChecks()
+		case LinkerdJaegerChecks:
+			hc.addLinkerdJaegerChecks()
+		case LinkerdExtensionChecks:
+			hc.addLinkerdExtensionChecks()
+		case LinkerdPreUpgradeChecks:
+			hc.addLinkerdPreUpgradeChecks()
+		case LinkerdPostInstallChecks:
+			hc.addLinkerdPostInstallChecks()
+		case LinkerdCAChecks:
+			hc.addLinkerdCAChecks()
+		case LinkerdServiceProfileChecks:
+			hc.addLinkerdServiceProfileChecks()
+		case LinkerdProxyAdminChecks:
+			hc.addLinkerdProxyAdminChecks()
+		case LinkerdHAChecks:
+			hc.addLinkerdHAChecks()
+		}
+	}
+
+	for _, c := range hc.checkers {
+		if c.required && hc.skipped(c) {
+			return nil, fmt.Errorf("The \"%s\" check can't be skipped; the checks after it rely on it", c.id)
+		}
+	}
+
+	if err := hc.validateSkipChecks(checks); err != nil {
+		return nil, err
+	}
+
+	return hc, nil
+}
+
+// validateSkipChecks returns an error if the SkipChecks option lists an ID
+// that none of the configured checks has, e.g. because of a typo. The checks
+// of the installed extensions are only added once the extensions are
+// discovered, so their IDs are known if LinkerdExtensionChecks are configured.
+func (hc *HealthChecker) validateSkipChecks(checks []Checks) error {
+	if hc.HealthCheckOptions == nil {
+		return nil
+	}
+
+	known := make(map[string]bool)
+	for _, c := range hc.checkers {
+		known[c.id] = true
+	}
+
+	extensions := false
+	for _, check := range checks {
+		if check == LinkerdExtensionChecks {
+			extensions = true
+		}
+	}
+	if extensions {
+		jaeger := &HealthChecker{HealthCheckOptions: hc.HealthCheckOptions}
+		jaeger.addJaegerChecks(hc.JaegerNamespace)
+		for _, c := range jaeger.checkers {
+			known[c.id] = true
+		}
+	}
+
+	for _, id := range hc.SkipChecks {
+		if known[id] {
+			continue
+		}
+		if extensions && strings.HasPrefix(id, "linkerd-") && strings.HasSuffix(id, "/checks") {
+			continue
+		}
+		return fmt.Errorf("Unknown check \"%s\" to skip", id)
+	}
+	return nil
+}
+
+// skipped returns true if the SkipChecks option lists the check's ID.
+func (hc *HealthChecker) skipped(c *checker) bool {
+	if c.id == "" || hc.HealthCheckOptions == nil {
+		return false
+	}
+	for _, id := range hc.SkipChecks {
+		if id == c.id {
+			return true
+		}
+	}
+	return false
+}
+
+// requires returns the sets of checks that initialize the clients the given
+// set of checks relies on: KubernetesAPIChecks initializes the Kubernetes API
+// client, and LinkerdAPIChecks the public API client and the list of control
+// plane pods.
+func (hc *HealthChecker) requires(check Checks) []Checks {
+	switch check {
+	case LinkerdPreInstallChecks, LinkerdAPIChecks, LinkerdInjectionSafetyChecks,
+		LinkerdJaegerChecks, LinkerdExtensionChecks, LinkerdPreUpgradeChecks,
+		LinkerdPostInstallChecks, LinkerdCAChecks, LinkerdServiceProfileChecks,
+		LinkerdProxyAdminChecks, LinkerdHAChecks:
+		return []Checks{KubernetesAPIChecks}
+	case LinkerdDataPlaneChecks:
+		return []Checks{KubernetesAPIChecks, LinkerdAPIChecks}
+	case LinkerdVersionChecks:
+		if hc.ShouldCheckControlPlaneVersion || hc.ShouldCheckDataPlaneVersion {
+			return []Checks{LinkerdAPIChecks}
+		}
+	}
+	return nil
+}
+
+// sortChecks returns the given sets of checks sorted topologically by the sets
+// they require, with duplicates removed. It returns an error if a required set
+// is missing.
+func (hc *HealthChecker) sortChecks(checks []Checks) ([]Checks, error) {
+	given := make(map[Checks]bool)
+	for _, check := range checks {
+		given[check] = true
+	}
+
+	sorted := make([]Checks, 0)
+	added := make(map[Checks]bool)
+	visiting := make(map[Checks]bool)
+
+	var visit func(check Checks) error
+	visit = func(check Checks) error {
+		if added[check] {
+			return nil
+		}
+		if visiting[check] {
+			return fmt.Errorf("%s checks depend on themselves", check)
+		}
+		visiting[check] = true
+
+		for _, dependency := range hc.requires(check) {
+			if !given[dependency] {
+				return fmt.Errorf("%s checks require the %s checks, which weren't configured", check, dependency)
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
 		}
+
+		visiting[check] = false
+		added[check] = true
+		sorted = append(sorted, check)
+		return nil
 	}
 
-	return hc
+	for _, check := range checks {
+		if err := visit(check); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
 }
 
 func (hc *HealthChecker) addKubernetesAPIChecks() {
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-api/client",
 		category:    KubernetesAPICategory,
 		description: "can initialize the client",
+		remediation: "Make sure the kubeconfig file and context are valid, e.g. with `kubectl config current-context`",
 		fatal:       true,
+		required:    true,
+		severity:    SeverityConnectivity,
 		check: func() (err error) {
 			hc.kubeAPI, err = k8s.NewAPI(hc.KubeConfig, hc.KubeContext)
 			return
@@ -146,23 +599,30 @@ func (hc *HealthChecker) addKubernetesAPIChecks() {
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-api/reachable",
 		category:    KubernetesAPICategory,
 		description: "can query the Kubernetes API",
+		remediation: "Make sure the Kubernetes API server is reachable from this machine, e.g. with `kubectl version`",
 		fatal:       true,
+		required:    true,
+		severity:    SeverityConnectivity,
+		timeout:     kubeAPICheckTimeout,
 		check: func() (err error) {
 			hc.httpClient, err = hc.kubeAPI.NewClient()
 			if err != nil {
 				return
 			}
-			hc.kubeVersion, err = hc.kubeAPI.GetVersionInfo(hc.httpClient)
+			hc.kubeVersion, err = hc.kubeAPI.GetVersionInfo(hc.ctx, hc.httpClient)
 			return
 		},
 	})
 
 	if hc.ShouldCheckKubeVersion {
 		hc.checkers = append(hc.checkers, &checker{
+			id:          "kubernetes-api/version-min",
 			category:    KubernetesAPICategory,
 			description: "is running the minimum Kubernetes API version",
+			remediation: "Upgrade the cluster to a supported Kubernetes version",
 			fatal:       false,
 			check: func() error {
 				return hc.kubeAPI.CheckVersion(hc.kubeVersion)
@@ -173,11 +633,13 @@ func (hc *HealthChecker) addKubernetesAPIChecks() {
 
 func (hc *HealthChecker) addLinkerdPreInstallChecks() {
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/namespace-absent",
 		category:    LinkerdPreInstallCategory,
 		description: "control plane namespace does not already exist",
+		remediation: "Remove the existing control plane, or install to another namespace with --linkerd-namespace",
 		fatal:       false,
 		check: func() error {
-			exists, err := hc.kubeAPI.NamespaceExists(hc.httpClient, hc.ControlPlaneNamespace)
+			exists, err := hc.kubeAPI.NamespaceExists(hc.ctx, hc.httpClient, hc.ControlPlaneNamespace)
 			if err != nil {
 				return err
 			}
@@ -189,73 +651,167 @@ func (hc *HealthChecker) addLinkerdPreInstallChecks() {
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/create-namespaces",
 		category:    LinkerdPreInstallCategory,
 		description: "can create Namespaces",
+		remediation: "Grant the current user permission to create Namespaces, or have a cluster administrator install Linkerd",
 		fatal:       true,
 		check: func() error {
-			return hc.checkCanCreate("", "", "v1", "Namespace")
+			return hc.checkCanPerform("create", "", "", "v1", "Namespace")
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/create-clusterroles",
 		category:    LinkerdPreInstallCategory,
 		description: "can create ClusterRoles",
+		remediation: "Grant the current user permission to create ClusterRoles, e.g. by binding it to the cluster-admin ClusterRole",
 		fatal:       true,
 		check: func() error {
-			return hc.checkCanCreate("", "rbac.authorization.k8s.io", "v1beta1", "ClusterRole")
+			return hc.checkCanPerform("create", "", "rbac.authorization.k8s.io", "v1beta1", "ClusterRole")
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/create-clusterrolebindings",
 		category:    LinkerdPreInstallCategory,
 		description: "can create ClusterRoleBindings",
+		remediation: "Grant the current user permission to create ClusterRoleBindings, e.g. by binding it to the cluster-admin ClusterRole",
 		fatal:       true,
 		check: func() error {
-			return hc.checkCanCreate("", "rbac.authorization.k8s.io", "v1beta1", "ClusterRoleBinding")
+			return hc.checkCanPerform("create", "", "rbac.authorization.k8s.io", "v1beta1", "ClusterRoleBinding")
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/create-serviceaccounts",
 		category:    LinkerdPreInstallCategory,
 		description: "can create ServiceAccounts",
+		remediation: "Grant the current user permission to create ServiceAccounts in the control plane namespace",
 		fatal:       true,
 		check: func() error {
-			return hc.checkCanCreate(hc.ControlPlaneNamespace, "", "v1", "ServiceAccount")
+			return hc.checkCanPerform("create", hc.ControlPlaneNamespace, "", "v1", "ServiceAccount")
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/create-services",
 		category:    LinkerdPreInstallCategory,
 		description: "can create Services",
+		remediation: "Grant the current user permission to create Services in the control plane namespace",
 		fatal:       true,
 		check: func() error {
-			return hc.checkCanCreate(hc.ControlPlaneNamespace, "", "v1", "Service")
+			return hc.checkCanPerform("create", hc.ControlPlaneNamespace, "", "v1", "Service")
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/create-deployments",
 		category:    LinkerdPreInstallCategory,
 		description: "can create Deployments",
+		remediation: "Grant the current user permission to create Deployments in the control plane namespace",
 		fatal:       true,
 		check: func() error {
-			return hc.checkCanCreate(hc.ControlPlaneNamespace, "extensions", "v1beta1", "Deployments")
+			return hc.checkCanPerform("create", hc.ControlPlaneNamespace, "extensions", "v1beta1", "Deployments")
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/create-configmaps",
 		category:    LinkerdPreInstallCategory,
 		description: "can create ConfigMaps",
+		remediation: "Grant the current user permission to create ConfigMaps in the control plane namespace",
 		fatal:       true,
 		check: func() error {
-			return hc.checkCanCreate(hc.ControlPlaneNamespace, "", "v1", "ConfigMap")
+			return hc.checkCanPerform("create", hc.ControlPlaneNamespace, "", "v1", "ConfigMap")
+		},
+	})
+
+	hc.addRuntimePermissionCheck("get")
+	hc.addRuntimePermissionCheck("list")
+	hc.addRuntimePermissionCheck("watch")
+	hc.addRuntimePermissionCheck("update")
+	hc.addRuntimePermissionCheck("patch")
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/provider",
+		category:    LinkerdPreInstallCategory,
+		description: "can determine the cluster provider",
+		remediation: "Grant the current user permission to list nodes and API groups",
+		fatal:       false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			nodeList, err := clientset.CoreV1().Nodes().List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+			hc.nodes = nodeList.Items
+			hc.provider = detectClusterProvider(hc.kubeVersion, hc.nodes)
+			return nil
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/gke-webhook-firewall",
+		category:    LinkerdPreInstallCategory,
+		description: "GKE master can reach webhooks on the nodes",
+		remediation: "Add a firewall rule that allows the GKE master to reach the nodes on the webhook ports",
+		fatal:       false,
+		skip:        hc.providerIsNot(providerGKE),
+		check: func() error {
+			return validateGKENodeFirewall(hc.nodes)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/eks-webhook-networking",
+		category:    LinkerdPreInstallCategory,
+		description: "EKS control plane can reach webhooks in pods",
+		remediation: "Use a CNI plugin that gives pods addresses the EKS control plane can reach, or run the webhooks with host networking",
+		fatal:       false,
+		skip:        hc.providerIsNot(providerEKS),
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			dsList, err := clientset.AppsV1().DaemonSets("kube-system").List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			names := []string{}
+			for _, ds := range dsList.Items {
+				names = append(names, ds.Name)
+			}
+			return validateEKSNetworking(names)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/aks-admission-webhooks",
+		category:    LinkerdPreInstallCategory,
+		description: "AKS cluster supports admission webhooks",
+		remediation: "Enable admission webhooks on the AKS cluster",
+		fatal:       false,
+		skip:        hc.providerIsNot(providerAKS),
+		check: func() error {
+			return validateAKSAdmission(hc.kubeVersion)
 		},
 	})
 }
 
 func (hc *HealthChecker) addLinkerdAPIChecks() {
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-api/namespace",
 		category:    LinkerdAPICategory,
 		description: "control plane namespace exists",
+		remediation: "Install the control plane with `linkerd install | kubectl apply -f -`, or set its namespace with --linkerd-namespace",
 		fatal:       true,
 		check: func() error {
 			return hc.checkNamespace(hc.ControlPlaneNamespace)
@@ -263,13 +819,16 @@ func (hc *HealthChecker) addLinkerdAPIChecks() {
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:            "linkerd-api/pods-ready",
 		category:      LinkerdAPICategory,
 		description:   "control plane pods are ready",
+		remediation:   fmt.Sprintf("Inspect the control plane pods with `kubectl -n %s get pods` and `kubectl -n %s describe pods`", hc.ControlPlaneNamespace, hc.ControlPlaneNamespace),
 		retryDeadline: hc.RetryDeadline,
 		fatal:         true,
+		required:      true,
 		check: func() error {
 			var err error
-			hc.controlPlanePods, err = hc.kubeAPI.GetPodsByNamespace(hc.httpClient, hc.ControlPlaneNamespace)
+			hc.controlPlanePods, err = hc.kubeAPI.GetPodsByNamespace(hc.ctx, hc.httpClient, hc.ControlPlaneNamespace)
 			if err != nil {
 				return err
 			}
@@ -278,9 +837,13 @@ func (hc *HealthChecker) addLinkerdAPIChecks() {
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-api/client",
 		category:    LinkerdAPICategory,
 		description: "can initialize the client",
+		remediation: "Make sure the control plane's public API service exists and --api-addr, if set, is correct",
 		fatal:       true,
+		required:    true,
+		severity:    SeverityConnectivity,
 		check: func() (err error) {
 			if hc.APIAddr != "" {
 				hc.apiClient, err = public.NewInternalClient(hc.ControlPlaneNamespace, hc.APIAddr)
@@ -292,32 +855,106 @@ func (hc *HealthChecker) addLinkerdAPIChecks() {
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-api/reachable",
 		category:    LinkerdAPICategory,
 		description: "can query the control plane API",
+		remediation: "Inspect the logs of the controller pod's public-api container",
 		fatal:       true,
 		checkRPC: func() (*healthcheckPb.SelfCheckResponse, error) {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(hc.ctx, 5*time.Second)
 			defer cancel()
 			return hc.apiClient.SelfCheck(ctx, &healthcheckPb.SelfCheckRequest{})
 		},
 	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-api/prometheus-queries",
+		category:    LinkerdAPICategory,
+		description: "Prometheus queries complete in time",
+		remediation: "Inspect the logs and resource usage of the Prometheus pod",
+		fatal:       false,
+		check: func() error {
+			queries, err := hc.getStatPromQueries()
+			if err != nil {
+				return err
+			}
+			if err := validatePromQueryLatency(queries, promQueryLatencyError); err != nil {
+				return err
+			}
+			hc.promQueries = queries
+			return nil
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-api/prometheus-latency",
+		category:    LinkerdAPICategory,
+		description: "Prometheus query latency is low",
+		remediation: "Give the Prometheus pod more CPU and memory, or reduce the number of meshed pods it scrapes",
+		fatal:       false,
+		severity:    SeverityWarning,
+		skip: func() bool {
+			// the queries failed or were too slow, which is already reported
+			return hc.promQueries == nil
+		},
+		check: func() error {
+			return validatePromQueryLatency(hc.promQueries, promQueryLatencyWarning)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-api/crd-compatibility",
+		category:    LinkerdAPICategory,
+		description: "control plane custom resources are compatible",
+		remediation: "Upgrade the control plane's custom resource definitions with `linkerd upgrade | kubectl apply -f -`",
+		fatal:       false,
+		check: func() error {
+			resources, err := hc.getLinkerdAPIResources()
+			if err != nil {
+				return err
+			}
+
+			if len(resources) == 0 {
+				// Linkerd's custom resources aren't installed
+				return nil
+			}
+			return validateCustomResources(resources)
+		},
+	})
+
+	hc.addServiceAvailabilityChecks("grafana", "Grafana", "http", "/api/health")
+	hc.addServiceAvailabilityChecks("web", "web dashboard", "admin-http", "/ready")
 }
 
 func (hc *HealthChecker) addLinkerdDataPlaneChecks() {
-	if hc.DataPlaneNamespace != "" {
+	if namespaces := hc.dataPlaneNamespaces(); len(namespaces) > 0 {
+		description := "data plane namespace exists"
+		if len(namespaces) > 1 {
+			description = "data plane namespaces exist"
+		}
+
 		hc.checkers = append(hc.checkers, &checker{
+			id:          "linkerd-data-plane/namespace",
 			category:    LinkerdDataPlaneCategory,
-			description: "data plane namespace exists",
+			description: description,
+			remediation: "Make sure the namespaces given with --namespace exist",
 			fatal:       true,
 			check: func() error {
-				return hc.checkNamespace(hc.DataPlaneNamespace)
+				for _, namespace := range namespaces {
+					if err := hc.checkNamespace(namespace); err != nil {
+						return err
+					}
+				}
+				return nil
 			},
 		})
 	}
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:            "linkerd-data-plane/proxies-ready",
 		category:      LinkerdDataPlaneCategory,
 		description:   "data plane proxies are ready",
+		remediation:   "Inspect the proxy containers of the pods that aren't ready with `kubectl logs <pod> -c linkerd-proxy`",
 		retryDeadline: hc.RetryDeadline,
 		fatal:         true,
 		check: func() error {
@@ -326,13 +963,15 @@ func (hc *HealthChecker) addLinkerdDataPlaneChecks() {
 				return err
 			}
 
-			return validateDataPlanePods(pods, hc.DataPlaneNamespace)
+			return validateDataPlanePods(pods, hc.dataPlaneNamespaces(), hc.DataPlaneSelector)
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
+		id:            "linkerd-data-plane/proxy-metrics",
 		category:      LinkerdDataPlaneCategory,
 		description:   "data plane proxy metrics are present in Prometheus",
+		remediation:   "Make sure Prometheus can scrape the proxies' admin port, and that no network policy blocks it",
 		retryDeadline: hc.RetryDeadline,
 		fatal:         false,
 		check: func() error {
@@ -344,136 +983,502 @@ func (hc *HealthChecker) addLinkerdDataPlaneChecks() {
 			return validateDataPlanePodReporting(pods)
 		},
 	})
-}
 
-func (hc *HealthChecker) addLinkerdVersionChecks() {
 	hc.checkers = append(hc.checkers, &checker{
-		category:    LinkerdVersionCategory,
-		description: "can determine the latest version",
-		fatal:       true,
-		check: func() (err error) {
-			if hc.VersionOverride != "" {
-				hc.latestVersion = hc.VersionOverride
-			} else {
-				// The UUID is only known to the web process. At some point we may want
-				// to consider providing it in the Public API.
-				uuid := "unknown"
-				for _, pod := range hc.controlPlanePods {
-					if strings.Split(pod.Name, "-")[0] == "web" {
-						for _, container := range pod.Spec.Containers {
-							if container.Name == "web" {
-								for _, arg := range container.Args {
-									if strings.HasPrefix(arg, "-uuid=") {
-										uuid = strings.TrimPrefix(arg, "-uuid=")
-									}
-								}
-							}
-						}
-					}
-				}
-				hc.latestVersion, err = version.GetLatestVersion(uuid, "cli")
+		id:            "linkerd-data-plane/scrape-targets",
+		category:      LinkerdDataPlaneCategory,
+		description:   "Prometheus scrapes the data plane proxies",
+		remediation:   "Check the proxies' targets on the Prometheus dashboard's Targets page, and make sure no network policy blocks Prometheus from the proxies' linkerd-metrics port",
+		retryDeadline: hc.RetryDeadline,
+		fatal:         false,
+		check: func() error {
+			pods, err := hc.getDataPlanePods()
+			if err != nil {
+				return err
 			}
-			return
+
+			targets, err := hc.getProxyScrapeTargets()
+			if err != nil {
+				return err
+			}
+
+			return validateScrapeTargets(pods, targets)
 		},
 	})
 
 	hc.checkers = append(hc.checkers, &checker{
-		category:    LinkerdVersionCategory,
-		description: "cli is up-to-date",
+		id:          "linkerd-data-plane/cluster-dns",
+		category:    LinkerdDataPlaneCategory,
+		description: "data plane pods resolve control plane names with cluster DNS",
+		remediation: "Make sure the pods' dnsPolicy uses the cluster DNS, and that the cluster domain matches the control plane's",
 		fatal:       false,
 		check: func() error {
-			return version.CheckClientVersion(hc.latestVersion)
-		},
-	})
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
 
-	if hc.ShouldCheckControlPlaneVersion {
-		hc.checkers = append(hc.checkers, &checker{
-			category:    LinkerdVersionCategory,
-			description: "control plane is up-to-date",
-			fatal:       false,
-			check: func() error {
-				return version.CheckServerVersion(hc.apiClient, hc.latestVersion)
-			},
-		})
-	}
+			pods, err := hc.getMeshedPods()
+			if err != nil {
+				return err
+			}
 
-	if hc.ShouldCheckDataPlaneVersion {
-		hc.checkers = append(hc.checkers, &checker{
-			category:    LinkerdVersionCategory,
-			description: "data plane is up-to-date",
-			fatal:       false,
-			check: func() error {
-				pods, err := hc.getDataPlanePods()
-				if err != nil {
-					return err
-				}
+			nameservers := []string{nodeLocalDNSAddress}
+			svc, err := clientset.CoreV1().Services("kube-system").Get(clusterDNSService, metav1.GetOptions{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+			if err == nil {
+				nameservers = append(nameservers, svc.Spec.ClusterIP)
+			}
 
-				for _, pod := range pods {
-					if pod.ProxyVersion != hc.latestVersion {
-						return fmt.Errorf("%s is running version %s but the latest version is %s",
-							pod.Name, pod.ProxyVersion, hc.latestVersion)
-					}
-				}
-				return nil
-			},
-		})
-	}
-}
+			return validateDataPlaneDNSPolicy(pods, nameservers, hc.ControlPlaneNamespace)
+		},
+	})
 
-// Add adds an arbitrary checker. This should only be used for testing. For
-// production code, pass in the desired set of checks when calling
-// NewHeathChecker.
-func (hc *HealthChecker) Add(category, description string, check func() error) {
 	hc.checkers = append(hc.checkers, &checker{
-		category:    category,
-		description: description,
-		check:       check,
-	})
-}
+		id:          "linkerd-data-plane/nodelocal-dns",
+		category:    LinkerdDataPlaneCategory,
+		description: "data plane DNS traffic is compatible with NodeLocal DNSCache",
+		remediation: "Skip the NodeLocal DNSCache address in the proxies' outbound redirects, e.g. with --skip-outbound-ports 53",
+		fatal:       false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
 
-// RunChecks runs all configured checkers, and passes the results of each
-// check to the observer. If a check fails and is marked as fatal, then all
-// remaining checks are skipped. If at least one check fails, RunChecks returns
-// false; if all checks passed, RunChecks returns true.
-func (hc *HealthChecker) RunChecks(observer checkObserver) bool {
-	success := true
+			_, err = clientset.AppsV1().DaemonSets("kube-system").Get(nodeLocalDNSDaemonSet, metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			pods, err := hc.getMeshedPods()
+			if err != nil {
+				return err
+			}
+
+			return validateNodeLocalDNSPods(pods)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-data-plane/trust-domain",
+		category:    LinkerdDataPlaneCategory,
+		description: "data plane proxy identities match the control plane's trust domain",
+		remediation: "Re-inject and restart the pods whose identities don't match the control plane's trust domain",
+		fatal:       false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			pods, err := hc.getMeshedPods()
+			if err != nil {
+				return err
+			}
+
+			identities, err := DiagnoseProxyIdentities(clientset, hc.ControlPlaneNamespace, pods)
+			if err != nil {
+				return err
+			}
+
+			return validateProxyIdentities(identities)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-data-plane/proxy-config",
+		category:    LinkerdDataPlaneCategory,
+		description: "data plane proxies were injected with the control plane's current configuration",
+		remediation: "Re-inject and restart the workloads that were injected with an outdated configuration",
+		fatal:       false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			currentHash, err := GetProxyConfigHash(clientset, hc.ControlPlaneNamespace)
+			if err != nil {
+				return err
+			}
+			if currentHash == "" {
+				// The control plane was installed by a CLI that doesn't record the
+				// hash, so there is nothing to compare the proxies against.
+				return nil
+			}
+
+			pods, err := hc.getMeshedPods()
+			if err != nil {
+				return err
+			}
+
+			workloads, err := DiagnoseProxyConfigs(clientset, currentHash, pods)
+			if err != nil {
+				return err
+			}
+
+			return validateProxyConfigs(workloads)
+		},
+	})
+}
+
+func (hc *HealthChecker) addLinkerdVersionChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-version/latest",
+		category:    LinkerdVersionCategory,
+		description: "can determine the latest version",
+		remediation: "Make sure this machine can reach versioncheck.linkerd.io, or pass --expected-version",
+		fatal:       true,
+		severity:    SeverityInfo,
+		timeout:     latestVersionCheckTimeout,
+		skip:        hc.telemetryDisabled,
+		check: func() (err error) {
+			if hc.VersionOverride != "" {
+				hc.latestVersion = hc.VersionOverride
+			} else {
+				// The UUID is only known to the web process. At some point we may want
+				// to consider providing it in the Public API.
+				uuid := hc.webArg("uuid")
+				if uuid == "" {
+					uuid = "unknown"
+				}
+				hc.latestVersion, err = version.GetLatestVersion(hc.ctx, uuid, "cli")
+			}
+			return
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-version/cli",
+		category:    LinkerdVersionCategory,
+		description: "cli is up-to-date",
+		remediation: "Install the latest CLI, e.g. with `curl -sL https://run.linkerd.io/install | sh`",
+		fatal:       false,
+		severity:    SeverityWarning,
+		skip:        hc.latestVersionUnknown,
+		check: func() error {
+			return version.CheckClientVersion(hc.latestVersion)
+		},
+	})
+
+	if hc.ShouldCheckControlPlaneVersion {
+		hc.checkers = append(hc.checkers, &checker{
+			id:          "linkerd-version/control-plane",
+			category:    LinkerdVersionCategory,
+			description: "control plane is up-to-date",
+			remediation: "Upgrade the control plane with `linkerd upgrade | kubectl apply -f -`",
+			fatal:       false,
+			severity:    SeverityWarning,
+			skip:        hc.latestVersionUnknown,
+			check: func() error {
+				return version.CheckServerVersion(hc.ctx, hc.apiClient, hc.latestVersion)
+			},
+		})
+	}
+
+	if hc.ShouldCheckDataPlaneVersion {
+		hc.checkers = append(hc.checkers, &checker{
+			id:          "linkerd-version/data-plane",
+			category:    LinkerdVersionCategory,
+			description: "data plane is up-to-date",
+			remediation: "Re-inject and restart the workloads running outdated proxies",
+			fatal:       false,
+			severity:    SeverityWarning,
+			skip:        hc.latestVersionUnknown,
+			check: func() error {
+				versions, err := hc.getDataPlaneVersions()
+				if err != nil {
+					return err
+				}
+
+				return validateDataPlaneVersions(versions, hc.latestVersion)
+			},
+		})
+	}
+}
+
+func (hc *HealthChecker) addLinkerdInjectionSafetyChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-injection-safety/critical-namespaces",
+		category:    LinkerdInjectionSafetyCategory,
+		description: "no proxies are injected in critical namespaces",
+		remediation: "Re-deploy the workloads of the critical namespaces without `linkerd inject`",
+		fatal:       false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			pods := []v1.Pod{}
+			for _, ns := range criticalNamespaces {
+				podList, err := clientset.CoreV1().Pods(ns).List(metav1.ListOptions{})
+				if err != nil {
+					return err
+				}
+				pods = append(pods, podList.Items...)
+			}
+
+			return validateCriticalNamespacePods(pods)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-injection-safety/webhook-namespace-selector",
+		category:    LinkerdInjectionSafetyCategory,
+		description: "proxy injection webhooks exclude critical namespaces",
+		remediation: "Add a namespaceSelector to the proxy injection webhooks that excludes kube-system and kube-public",
+		fatal:       false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			webhookConfigs, err := clientset.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			namespaces := []v1.Namespace{}
+			for _, name := range criticalNamespaces {
+				ns, err := clientset.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				namespaces = append(namespaces, *ns)
+			}
+
+			return validateInjectionWebhooks(webhookConfigs.Items, namespaces, hc.ControlPlaneNamespace)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-injection-safety/webhook-failure-policy",
+		category:    LinkerdInjectionSafetyCategory,
+		description: "proxy injection webhooks don't block pod creation during outages",
+		remediation: "Set the failurePolicy of the proxy injection webhooks to Ignore",
+		fatal:       false,
+		skip:        hc.injectionWebhooksNotInstalled,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			webhookConfigs, err := clientset.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			return validateWebhookFailurePolicy(controlPlaneWebhooks(webhookConfigs.Items, hc.ControlPlaneNamespace))
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-injection-safety/webhook-latency",
+		category:    LinkerdInjectionSafetyCategory,
+		description: "proxy injection webhooks respond within the API server's timeout",
+		remediation: "Inspect the logs and resource usage of the proxy injector pods",
+		fatal:       false,
+		skip:        hc.injectionWebhooksNotInstalled,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			webhookConfigs, err := clientset.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			webhooks := controlPlaneWebhooks(webhookConfigs.Items, hc.ControlPlaneNamespace)
+			if len(webhooks) == 0 {
+				return nil
+			}
+
+			// dry-run pods measure the current latency of the webhooks, but the
+			// API server only admits them through webhooks that declare they
+			// have no side effects; otherwise the API server's metrics are used
+			if supportsDryRun(hc.kubeVersion) {
+				sideEffects, err := webhookSideEffects(clientset)
+				if err != nil {
+					return err
+				}
+				if allowDryRun(webhooks, sideEffects) {
+					return validateDryRunAdmission(clientset, webhooks, hc.ControlPlaneNamespace)
+				}
+			}
+
+			metrics, err := clientset.CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw()
+			if err != nil {
+				return fmt.Errorf("Failed to read the API server's admission metrics: %s", err)
+			}
+
+			latencies, err := webhookLatencies(bytes.NewReader(metrics))
+			if err != nil {
+				return err
+			}
+
+			return validateWebhookLatency(webhooks, latencies, apiServerWebhookTimeout)
+		},
+	})
+}
+
+// Add adds an arbitrary checker. This should only be used for testing. For
+// production code, pass in the desired set of checks when calling
+// NewHeathChecker, or register a Checker with AddChecker.
+func (hc *HealthChecker) Add(category, description string, check func() error) {
+	hc.checkers = append(hc.checkers, &checker{
+		category:    category,
+		description: description,
+		check:       check,
+	})
+}
+
+// Checker is a check contributed by code outside of this package, such as an
+// extension or a custom CLI, to be run by a HealthChecker along with its own
+// checks.
+type Checker interface {
+	// ID is the stable identifier of the check, which can be given in the
+	// SkipChecks option. By convention, it's "<category>/<name>".
+	ID() string
+
+	// Category is the category reported in the check's results. It can be one
+	// of the categories of this package, or a new one.
+	Category() string
+
+	// Description is the description reported in the check's results.
+	Description() string
+
+	// Fatal returns true if the remaining checks should be skipped when the
+	// check fails.
+	Fatal() bool
+
+	// Severity is how the check's failure affects the overall result.
+	Severity() Severity
+
+	// Retry returns true if the check should be retried until the
+	// HealthChecker's RetryDeadline when it fails.
+	Retry() bool
+
+	// Check runs the check. ctx is the context the checks are run with.
+	Check(ctx context.Context) error
+}
+
+// AddChecker registers a Checker, to be run after the checks that are already
+// configured. Checkers may be registered while the checks run, in which case
+// they're run after the checks that were registered before them.
+func (hc *HealthChecker) AddChecker(c Checker) {
+	chk := &checker{
+		id:          c.ID(),
+		category:    c.Category(),
+		description: c.Description(),
+		fatal:       c.Fatal(),
+		severity:    c.Severity(),
+		check: func() error {
+			return c.Check(hc.ctx)
+		},
+	}
+	if c.Retry() && hc.HealthCheckOptions != nil {
+		chk.retryDeadline = hc.RetryDeadline
+	}
+	hc.checkers = append(hc.checkers, chk)
+}
+
+// RunChecks runs all configured checkers, and passes the results of each
+// check to the observer, which may be nil. If a check fails and is marked as
+// fatal, then all remaining checks are skipped. Checks may add further
+// checkers while they run, which are run after the ones already configured.
+// RunChecks returns the summary of the results, which is successful unless a
+// check with a blocking severity failed. The API requests made by the checks
+// are bound to ctx; once it's done, retries are abandoned, the remaining
+// checks are skipped and the summary is unsuccessful.
+func (hc *HealthChecker) RunChecks(ctx context.Context, observer checkObserver) *Results {
+	results := newResults()
+	observe := func(result *CheckResult) {
+		results.add(result)
+		if observer != nil {
+			observer(result)
+		}
+	}
+
+	for i := 0; i < len(hc.checkers); i++ {
+		if ctx.Err() != nil {
+			results.Success = false
+			break
+		}
+
+		checker := hc.checkers[i]
+		if hc.skipped(checker) || (checker.skip != nil && checker.skip()) {
+			continue
+		}
 
-	for _, checker := range hc.checkers {
 		if checker.check != nil {
-			if !hc.runCheck(checker, observer) {
-				success = false
+			if !hc.runCheck(ctx, checker, observe) {
+				if checker.severity.Blocking() {
+					results.Success = false
+				}
 				if checker.fatal {
+					results.FatalFailure = results.last()
 					break
 				}
 			}
 		}
 
 		if checker.checkRPC != nil {
-			if !hc.runCheckRPC(checker, observer) {
-				success = false
+			if !hc.runCheckRPC(ctx, checker, observe) {
+				if checker.severity.Blocking() {
+					results.Success = false
+				}
 				if checker.fatal {
+					results.FatalFailure = results.last()
 					break
 				}
 			}
 		}
 	}
 
-	return success
+	results.Duration = time.Since(results.StartTime)
+	return results
 }
 
-func (hc *HealthChecker) runCheck(c *checker, observer checkObserver) bool {
-	for {
-		err := c.check()
+func (hc *HealthChecker) runCheck(ctx context.Context, c *checker, observer checkObserver) bool {
+	start := time.Now()
+	backoff := hc.newRetryBackoff()
+	for retries := 0; ; retries++ {
+		err := hc.runWithTimeout(ctx, c, c.check)
 		checkResult := &CheckResult{
+			ID:          c.id,
 			Category:    c.category,
 			Description: c.description,
+			Severity:    c.severity,
 			Err:         err,
+			Retries:     retries,
+			StartTime:   start,
+			Duration:    time.Since(start),
+		}
+		if err != nil {
+			checkResult.Hint = c.hint()
 		}
 
-		if err != nil && time.Now().Before(c.retryDeadline) {
+		if err != nil && time.Now().Before(c.retryDeadline) && ctx.Err() == nil {
 			checkResult.Retry = true
 			observer(checkResult)
-			time.Sleep(retryWindow)
+			select {
+			case <-time.After(backoff.next()):
+			case <-ctx.Done():
+			}
 			continue
 		}
 
@@ -482,44 +1487,138 @@ func (hc *HealthChecker) runCheck(c *checker, observer checkObserver) bool {
 	}
 }
 
-func (hc *HealthChecker) runCheckRPC(c *checker, observer checkObserver) bool {
-	checkRsp, err := c.checkRPC()
-	observer(&CheckResult{
+// runCheckRPC runs a check whose response holds the results of several
+// subsystems, and reports each of them after the check's own result. It
+// returns false if the check or any of the subsystems failed.
+func (hc *HealthChecker) runCheckRPC(ctx context.Context, c *checker, observer checkObserver) bool {
+	start := time.Now()
+	var checkRsp *healthcheckPb.SelfCheckResponse
+	err := hc.runWithTimeout(ctx, c, func() (err error) {
+		checkRsp, err = c.checkRPC()
+		return
+	})
+	checkResult := &CheckResult{
+		ID:          c.id,
 		Category:    c.category,
 		Description: c.description,
+		Severity:    c.severity,
 		Err:         err,
-	})
+		StartTime:   start,
+		Duration:    time.Since(start),
+	}
+	if err != nil {
+		checkResult.Hint = c.hint()
+	}
+	observer(checkResult)
 	if err != nil {
 		return false
 	}
 
+	success := true
 	for _, check := range checkRsp.Results {
 		var err error
+		var hint *Hint
 		if check.Status != healthcheckPb.CheckStatus_OK {
 			err = fmt.Errorf(check.FriendlyMessageToUser)
+			hint = c.hint()
 		}
 		observer(&CheckResult{
+			ID:          fmt.Sprintf("%s/%s", c.id, check.SubsystemName),
 			Category:    fmt.Sprintf("%s[%s]", c.category, check.SubsystemName),
 			Description: check.CheckDescription,
+			Severity:    c.severity,
 			Err:         err,
+			Hint:        hint,
+			StartTime:   checkResult.StartTime,
+			Duration:    checkResult.Duration,
 		})
 		if err != nil {
-			return false
+			success = false
 		}
 	}
 
-	return true
+	return success
+}
+
+// runWithTimeout runs an attempt of a check, with hc.ctx set to a context
+// derived from ctx that expires after the check's timeout, or the CheckTimeout
+// option if the check has none. If the attempt fails once the timeout has
+// expired, its error says so.
+func (hc *HealthChecker) runWithTimeout(ctx context.Context, c *checker, attempt func() error) error {
+	timeout := c.timeout
+	if timeout == 0 && hc.HealthCheckOptions != nil {
+		timeout = hc.CheckTimeout
+	}
+	if timeout == 0 {
+		hc.ctx = ctx
+		return attempt()
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	hc.ctx = checkCtx
+	err := attempt()
+	hc.ctx = ctx
+
+	if err != nil && ctx.Err() == nil && checkCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("check timed out after %s: %s", timeout, err)
+	}
+	return err
 }
 
 // PublicAPIClient returns a fully configured public API client. This client is
-// only configured if the KubernetesAPIChecks and LinkerdAPIChecks are
-// configured and run first.
+// only configured if the LinkerdAPIChecks are configured and have run.
 func (hc *HealthChecker) PublicAPIClient() pb.ApiClient {
 	return hc.apiClient
 }
 
+// telemetryDisabled returns true if the control plane was installed with the
+// --disable-telemetry flag, in which case no calls should be made to the
+// external version check endpoint.
+func (hc *HealthChecker) telemetryDisabled() bool {
+	return hc.webArg("disable-telemetry") == "true"
+}
+
+// latestVersionUnknown is the skip function of the checks that compare
+// versions with the latest one, which is unknown if the linkerd-version/latest
+// check was skipped, e.g. with the SkipChecks option.
+func (hc *HealthChecker) latestVersionUnknown() bool {
+	return hc.latestVersion == ""
+}
+
+// providerIsNot returns a skip function for checks that only apply to clusters
+// running on the given provider. It relies on the provider detected as part
+// of the LinkerdPreInstallChecks.
+func (hc *HealthChecker) providerIsNot(provider clusterProvider) func() bool {
+	return func() bool {
+		return hc.provider != provider
+	}
+}
+
+// webArg returns the value of the named flag passed to the web container, or
+// an empty string if the flag is not set. It relies on the control plane pods
+// that are retrieved as part of the LinkerdAPIChecks.
+func (hc *HealthChecker) webArg(name string) string {
+	prefix := fmt.Sprintf("-%s=", name)
+	for _, pod := range hc.controlPlanePods {
+		if strings.Split(pod.Name, "-")[0] == "web" {
+			for _, container := range pod.Spec.Containers {
+				if container.Name == "web" {
+					for _, arg := range container.Args {
+						if strings.HasPrefix(arg, prefix) {
+							return strings.TrimPrefix(arg, prefix)
+						}
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
 func (hc *HealthChecker) checkNamespace(namespace string) error {
-	exists, err := hc.kubeAPI.NamespaceExists(hc.httpClient, namespace)
+	exists, err := hc.kubeAPI.NamespaceExists(hc.ctx, hc.httpClient, namespace)
 	if err != nil {
 		return err
 	}
@@ -530,45 +1629,120 @@ func (hc *HealthChecker) checkNamespace(namespace string) error {
 }
 
 func (hc *HealthChecker) getDataPlanePods() ([]*pb.Pod, error) {
-	req := &pb.ListPodsRequest{}
-	if hc.DataPlaneNamespace != "" {
-		req.Namespace = hc.DataPlaneNamespace
-	}
+	pods := make([]*pb.Pod, 0)
+	for _, namespace := range hc.listedNamespaces() {
+		resp, err := hc.apiClient.ListPods(hc.ctx, &pb.ListPodsRequest{Namespace: namespace})
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := hc.apiClient.ListPods(context.Background(), req)
-	if err != nil {
-		return nil, err
+		for _, pod := range resp.GetPods() {
+			if pod.ControllerNamespace == hc.ControlPlaneNamespace {
+				pods = append(pods, pod)
+			}
+		}
 	}
 
-	pods := make([]*pb.Pod, 0)
-	for _, pod := range resp.GetPods() {
-		if pod.ControllerNamespace == hc.ControlPlaneNamespace {
-			pods = append(pods, pod)
+	if hc.DataPlaneSelector != "" {
+		selected, err := hc.getMeshedPods()
+		if err != nil {
+			return nil, err
 		}
+		pods = selectPods(pods, selected)
 	}
 
 	return pods, nil
 }
 
-func (hc *HealthChecker) checkCanCreate(namespace, group, version, resource string) error {
+// getStatPromQueries requests the stats of the deployments of every namespace,
+// as the dashboard does, and returns the Prometheus queries that the public API
+// ran to compute them.
+func (hc *HealthChecker) getStatPromQueries() ([]*pb.PrometheusQuery, error) {
+	ctx, cancel := context.WithTimeout(hc.ctx, 30*time.Second)
+	defer cancel()
+
+	rsp, err := hc.apiClient.StatSummary(ctx, &pb.StatSummaryRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{Type: k8s.Deployment},
+		},
+		TimeWindow:   "1m",
+		DebugQueries: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if e := rsp.GetError(); e != nil {
+		return nil, fmt.Errorf("StatSummary API response error: %s", e.GetError())
+	}
+
+	return rsp.GetOk().GetQueries(), nil
+}
+
+func (hc *HealthChecker) getClientset() (*kubernetes.Clientset, error) {
 	if hc.clientset == nil {
 		var err error
 		hc.clientset, err = kubernetes.NewForConfig(hc.kubeAPI.Config)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
+	return hc.clientset, nil
+}
+
+// getMeshedPods returns the pods in the data plane namespaces (or all
+// namespaces, if not set) that are injected with a proxy belonging to the
+// control plane, and match the data plane selector, if set.
+func (hc *HealthChecker) getMeshedPods() ([]v1.Pod, error) {
+	if hc.meshedPods != nil {
+		return hc.meshedPods, nil
+	}
 
-	auth := hc.clientset.AuthorizationV1beta1()
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []v1.Pod{}
+	for _, namespace := range hc.listedNamespaces() {
+		podList, err := clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{
+			LabelSelector: hc.meshedPodsSelector(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, podList.Items...)
+	}
+
+	hc.meshedPods = pods
+	return hc.meshedPods, nil
+}
+
+// checkCanPerform returns an error if the caller isn't allowed to perform the
+// verb on the resource.
+func (hc *HealthChecker) checkCanPerform(verb, namespace, group, version, resource string) error {
+	return hc.checkCanPerformOn(verb, namespace, group, version, resource, "")
+}
+
+// checkCanPerformOn returns an error if the caller isn't allowed to perform
+// the verb on the resource with the given name, or on all the resources of
+// that kind if name is empty.
+func (hc *HealthChecker) checkCanPerformOn(verb, namespace, group, version, resource, name string) error {
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return err
+	}
+
+	auth := clientset.AuthorizationV1beta1()
 
 	sar := &authorizationapi.SelfSubjectAccessReview{
 		Spec: authorizationapi.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authorizationapi.ResourceAttributes{
 				Namespace: namespace,
-				Verb:      "create",
+				Verb:      verb,
 				Group:     group,
 				Version:   version,
 				Resource:  resource,
+				Name:      name,
 			},
 		},
 	}
@@ -579,15 +1753,32 @@ func (hc *HealthChecker) checkCanCreate(namespace, group, version, resource stri
 	}
 
 	if !response.Status.Allowed {
+		if name != "" {
+			resource = fmt.Sprintf("the \"%s\" %s", name, resource)
+		}
 		if len(response.Status.Reason) > 0 {
-			return fmt.Errorf("Missing permissions to create %s: %v", resource, response.Status.Reason)
+			return fmt.Errorf("Missing permissions to %s %s: %v", verb, resource, response.Status.Reason)
 		}
-		return fmt.Errorf("Missing permissions to create %s", resource)
+		return fmt.Errorf("Missing permissions to %s %s", verb, resource)
 	}
 	return nil
 }
 
 func validateControlPlanePods(pods []v1.Pod) error {
+	statuses := runningContainerStatuses(pods)
+
+	names := []string{"controller", "grafana", "prometheus", "web"}
+	if _, found := statuses["ca"]; found {
+		names = append(names, "ca")
+	}
+
+	return validateContainersReady(statuses, names)
+}
+
+// runningContainerStatuses returns the container statuses of the running pods,
+// keyed by the name of the component the pods belong to, which is the first
+// dash-separated segment of their names.
+func runningContainerStatuses(pods []v1.Pod) map[string][]v1.ContainerStatus {
 	statuses := make(map[string][]v1.ContainerStatus)
 
 	for _, pod := range pods {
@@ -600,11 +1791,10 @@ func validateControlPlanePods(pods []v1.Pod) error {
 		}
 	}
 
-	names := []string{"controller", "grafana", "prometheus", "web"}
-	if _, found := statuses["ca"]; found {
-		names = append(names, "ca")
-	}
+	return statuses
+}
 
+func validateContainersReady(statuses map[string][]v1.ContainerStatus, names []string) error {
 	for _, name := range names {
 		containers, found := statuses[name]
 		if !found {
@@ -612,8 +1802,8 @@ func validateControlPlanePods(pods []v1.Pod) error {
 		}
 		for _, container := range containers {
 			if !container.Ready {
-				return fmt.Errorf("The \"%s\" pod's \"%s\" container is not ready", name,
-					container.Name)
+				return fmt.Errorf("The \"%s\" pod's \"%s\" container is not ready%s", name,
+					container.Name, containerFailureDetails(container))
 			}
 		}
 	}
@@ -621,13 +1811,37 @@ func validateControlPlanePods(pods []v1.Pod) error {
 	return nil
 }
 
-func validateDataPlanePods(pods []*pb.Pod, targetNamespace string) error {
-	if len(pods) == 0 {
-		msg := fmt.Sprintf("No \"%s\" containers found", k8s.ProxyContainerName)
-		if targetNamespace != "" {
-			msg += fmt.Sprintf(" in the \"%s\" namespace", targetNamespace)
+// containerFailureDetails describes why a container may not be ready, from its
+// restart count, the reason it's waiting, and the reason and exit code of its
+// last termination, e.g. " (restarts: 3, waiting: CrashLoopBackOff, last
+// terminated: Error, exit code 1)". It returns an empty string if the status
+// records none of these.
+func containerFailureDetails(status v1.ContainerStatus) string {
+	details := []string{}
+	if status.RestartCount > 0 {
+		details = append(details, fmt.Sprintf("restarts: %d", status.RestartCount))
+	}
+	if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+		details = append(details, fmt.Sprintf("waiting: %s", waiting.Reason))
+	}
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		reason := terminated.Reason
+		if reason == "" {
+			reason = "unknown reason"
 		}
-		return fmt.Errorf(msg)
+		details = append(details, fmt.Sprintf("last terminated: %s, exit code %d", reason, terminated.ExitCode))
+	}
+
+	if len(details) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+}
+
+func validateDataPlanePods(pods []*pb.Pod, namespaces []string, selector string) error {
+	if len(pods) == 0 {
+		return fmt.Errorf("No \"%s\" containers found%s",
+			k8s.ProxyContainerName, describeDataPlaneScope(namespaces, selector))
 	}
 
 	for _, pod := range pods {
@@ -666,3 +1880,110 @@ func validateDataPlanePodReporting(pods []*pb.Pod) error {
 
 	return nil
 }
+
+// validatePromQueryLatency returns an error if one of the queries failed, or if
+// the slowest one took longer than threshold.
+func validatePromQueryLatency(queries []*pb.PrometheusQuery, threshold time.Duration) error {
+	var slowest *pb.PrometheusQuery
+	var slowestDuration time.Duration
+	for _, query := range queries {
+		if query.GetError() != "" {
+			return fmt.Errorf("Prometheus query failed: %s", query.GetError())
+		}
+		duration, err := ptypes.Duration(query.GetDuration())
+		if err != nil {
+			return err
+		}
+		if slowest == nil || duration > slowestDuration {
+			slowest = query
+			slowestDuration = duration
+		}
+	}
+
+	if slowestDuration > threshold {
+		return fmt.Errorf("The slowest Prometheus query took %s, more than %s; Prometheus may need more resources:\n    %s",
+			slowestDuration.Round(time.Millisecond), threshold, slowest.GetQuery())
+	}
+	return nil
+}
+
+// validateDataPlaneVersions returns an error listing the groups of proxies,
+// as reported by the ProxySkew API, that aren't running the latest version.
+func validateDataPlaneVersions(versions []*pb.ProxySkewGroup, latestVersion string) error {
+	outdated := []string{}
+	for _, group := range versions {
+		if group.GetValue() != latestVersion {
+			outdated = append(outdated, fmt.Sprintf("%d running %s, e.g. %s",
+				group.GetPodCount(), group.GetValue(), FormatSkewExamples(group)))
+		}
+	}
+
+	if len(outdated) > 0 {
+		return fmt.Errorf("Some proxies are not running the latest version %s:\n    %s",
+			latestVersion, strings.Join(outdated, "\n    "))
+	}
+	return nil
+}
+
+// FormatSkewExamples returns the example workloads of a ProxySkew group as a
+// comma-separated list of namespace/kind/name triples.
+func FormatSkewExamples(group *pb.ProxySkewGroup) string {
+	examples := make([]string, len(group.GetExamples()))
+	for i, workload := range group.GetExamples() {
+		examples[i] = fmt.Sprintf("%s/%s/%s", workload.GetNamespace(), workload.GetType(), workload.GetName())
+	}
+	return strings.Join(examples, ", ")
+}
+
+func validateCriticalNamespacePods(pods []v1.Pod) error {
+	injected := []string{}
+	for _, pod := range pods {
+		if pod.Labels[k8s.ControllerNSLabel] != "" {
+			injected = append(injected, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+
+	if len(injected) > 0 {
+		return fmt.Errorf("The %s proxy is injected in pods that are critical to the cluster: %s",
+			k8s.ProxyContainerName, strings.Join(injected, ", "))
+	}
+
+	return nil
+}
+
+// validateInjectionWebhooks returns an error if any of the mutating webhooks
+// served from the control plane namespace would be called for pods created in
+// one of the given critical namespaces. If such a webhook's failurePolicy is
+// Fail, those pods won't be able to start while the control plane is down.
+func validateInjectionWebhooks(configs []admissionregistration.MutatingWebhookConfiguration, namespaces []v1.Namespace, controlPlaneNamespace string) error {
+	for _, config := range configs {
+		for _, webhook := range config.Webhooks {
+			service := webhook.ClientConfig.Service
+			if service == nil || service.Namespace != controlPlaneNamespace {
+				continue
+			}
+
+			selector := labels.Everything()
+			if webhook.NamespaceSelector != nil {
+				var err error
+				selector, err = metav1.LabelSelectorAsSelector(webhook.NamespaceSelector)
+				if err != nil {
+					return fmt.Errorf("The \"%s\" webhook has an invalid namespaceSelector: %s", webhook.Name, err)
+				}
+			}
+
+			for _, ns := range namespaces {
+				if !selector.Matches(labels.Set(ns.Labels)) {
+					continue
+				}
+
+				if webhook.FailurePolicy != nil && *webhook.FailurePolicy == admissionregistration.Fail {
+					return fmt.Errorf("The \"%s\" webhook applies to the \"%s\" namespace with failurePolicy Fail; pods critical to the cluster won't start while the control plane is unavailable", webhook.Name, ns.Name)
+				}
+				return fmt.Errorf("The \"%s\" webhook applies to the \"%s\" namespace; add a namespaceSelector that excludes it", webhook.Name, ns.Name)
+			}
+		}
+	}
+
+	return nil
+}
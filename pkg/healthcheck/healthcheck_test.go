@@ -8,9 +8,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/linkerd/linkerd2/controller/api/public"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	admissionregistration "k8s.io/api/admissionregistration/v1beta1"
 	"k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -130,7 +133,7 @@ func TestHealthChecker(t *testing.T) {
 			"cat5[rpc2] rpc desc2: rpc error",
 		}
 
-		hc.RunChecks(observer)
+		hc.RunChecks(context.Background(), observer)
 
 		if !reflect.DeepEqual(observedResults, expectedResults) {
 			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
@@ -146,7 +149,7 @@ func TestHealthChecker(t *testing.T) {
 			},
 		}
 
-		success := hc.RunChecks(nullObserver)
+		success := hc.RunChecks(context.Background(), nullObserver).Success
 
 		if !success {
 			t.Fatalf("Expecting checks to be successful, but got [%t]", success)
@@ -162,7 +165,7 @@ func TestHealthChecker(t *testing.T) {
 			},
 		}
 
-		success := hc.RunChecks(nullObserver)
+		success := hc.RunChecks(context.Background(), nullObserver).Success
 
 		if success {
 			t.Fatalf("Expecting checks to not be successful, but got [%t]", success)
@@ -178,7 +181,7 @@ func TestHealthChecker(t *testing.T) {
 			},
 		}
 
-		success := hc.RunChecks(nullObserver)
+		success := hc.RunChecks(context.Background(), nullObserver).Success
 
 		if success {
 			t.Fatalf("Expecting checks to not be successful, but got [%t]", success)
@@ -208,7 +211,7 @@ func TestHealthChecker(t *testing.T) {
 			"cat6 desc6: fatal",
 		}
 
-		hc.RunChecks(observer)
+		hc.RunChecks(context.Background(), observer)
 
 		if !reflect.DeepEqual(observedResults, expectedResults) {
 			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
@@ -216,7 +219,7 @@ func TestHealthChecker(t *testing.T) {
 	})
 
 	t.Run("Retries checks if retry is specified", func(t *testing.T) {
-		retryWindow = 0
+		defaultRetryInitialInterval = 0
 		returnError := true
 
 		retryCheck := &checker{
@@ -254,12 +257,574 @@ func TestHealthChecker(t *testing.T) {
 			"cat7 desc7 retry=false",
 		}
 
-		hc.RunChecks(observer)
+		hc.RunChecks(context.Background(), observer)
 
 		if !reflect.DeepEqual(observedResults, expectedResults) {
 			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
 		}
 	})
+
+	t.Run("Does not run checks that should be skipped", func(t *testing.T) {
+		skippedCheck := &checker{
+			category:    "cat8",
+			description: "desc8",
+			skip:        func() bool { return true },
+			check: func() error {
+				return fmt.Errorf("skipped check was run")
+			},
+		}
+
+		hc := HealthChecker{
+			checkers: []*checker{
+				passingCheck1,
+				skippedCheck,
+				passingCheck2,
+			},
+		}
+
+		observedResults := make([]string, 0)
+		observer := func(result *CheckResult) {
+			observedResults = append(observedResults, fmt.Sprintf("%s %s", result.Category, result.Description))
+		}
+
+		expectedResults := []string{
+			"cat1 desc1",
+			"cat2 desc2",
+		}
+
+		success := hc.RunChecks(context.Background(), observer).Success
+
+		if !success {
+			t.Fatalf("Expecting checks to be successful, but got [%t]", success)
+		}
+		if !reflect.DeepEqual(observedResults, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
+		}
+	})
+
+	t.Run("Is successful if only warning and info checks fail", func(t *testing.T) {
+		warningCheck := &checker{
+			category:    "cat10",
+			description: "desc10",
+			severity:    SeverityWarning,
+			check: func() error {
+				return fmt.Errorf("warning")
+			},
+		}
+
+		infoCheck := &checker{
+			category:    "cat12",
+			description: "desc12",
+			severity:    SeverityInfo,
+			check: func() error {
+				return fmt.Errorf("info")
+			},
+		}
+
+		hc := HealthChecker{
+			checkers: []*checker{
+				passingCheck1,
+				warningCheck,
+				infoCheck,
+				passingCheck2,
+			},
+		}
+
+		observedSeverities := make([]Severity, 0)
+		observer := func(result *CheckResult) {
+			observedSeverities = append(observedSeverities, result.Severity)
+		}
+
+		success := hc.RunChecks(context.Background(), observer).Success
+
+		if !success {
+			t.Fatalf("Expecting checks to be successful, but got [%t]", success)
+		}
+		expectedSeverities := []Severity{SeverityError, SeverityWarning, SeverityInfo, SeverityError}
+		if !reflect.DeepEqual(observedSeverities, expectedSeverities) {
+			t.Fatalf("Expected severities %v, but got %v", expectedSeverities, observedSeverities)
+		}
+	})
+
+	t.Run("Skips the checks listed in the SkipChecks option", func(t *testing.T) {
+		skippedCheck := &checker{
+			id:          "cat13/skipped",
+			category:    "cat13",
+			description: "desc13",
+			check: func() error {
+				return fmt.Errorf("error")
+			},
+		}
+
+		hc := HealthChecker{
+			checkers: []*checker{
+				passingCheck1,
+				skippedCheck,
+				passingCheck2,
+			},
+			HealthCheckOptions: &HealthCheckOptions{SkipChecks: []string{"cat13/skipped"}},
+		}
+
+		observedResults := make([]string, 0)
+		observer := func(result *CheckResult) {
+			observedResults = append(observedResults, fmt.Sprintf("%s %s", result.Category, result.Description))
+		}
+
+		success := hc.RunChecks(context.Background(), observer).Success
+
+		if !success {
+			t.Fatalf("Expecting checks to be successful, but got [%t]", success)
+		}
+		expectedResults := []string{"cat1 desc1", "cat2 desc2"}
+		if !reflect.DeepEqual(observedResults, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
+		}
+	})
+
+	t.Run("Runs checks added by other checks", func(t *testing.T) {
+		hc := HealthChecker{}
+		addingCheck := &checker{
+			category:    "cat9",
+			description: "desc9",
+			check: func() error {
+				hc.checkers = append(hc.checkers, passingCheck2)
+				return nil
+			},
+		}
+		hc.checkers = []*checker{addingCheck, passingCheck1}
+
+		observedResults := make([]string, 0)
+		observer := func(result *CheckResult) {
+			observedResults = append(observedResults, fmt.Sprintf("%s %s", result.Category, result.Description))
+		}
+
+		expectedResults := []string{
+			"cat9 desc9",
+			"cat1 desc1",
+			"cat2 desc2",
+		}
+
+		success := hc.RunChecks(context.Background(), observer).Success
+
+		if !success {
+			t.Fatalf("Expecting checks to be successful, but got [%t]", success)
+		}
+		if !reflect.DeepEqual(observedResults, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
+		}
+	})
+
+	t.Run("Stops retrying and skips the remaining checks when canceled", func(t *testing.T) {
+		retryingCheck := &checker{
+			category:    "cat11",
+			description: "desc11",
+			check: func() error {
+				return fmt.Errorf("retry")
+			},
+			retryDeadline: time.Now().Add(time.Hour),
+		}
+
+		hc := HealthChecker{
+			checkers: []*checker{
+				retryingCheck,
+				passingCheck1,
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		observedResults := make([]string, 0)
+		observer := func(result *CheckResult) {
+			res := fmt.Sprintf("%s %s retry=%t retries=%d", result.Category, result.Description, result.Retry, result.Retries)
+			observedResults = append(observedResults, res)
+			if result.Retry {
+				cancel()
+			}
+		}
+
+		expectedResults := []string{
+			"cat11 desc11 retry=true retries=0",
+			"cat11 desc11 retry=false retries=1",
+		}
+
+		success := hc.RunChecks(ctx, observer).Success
+
+		if success {
+			t.Fatalf("Expecting checks to fail, but got [%t]", success)
+		}
+		if !reflect.DeepEqual(observedResults, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
+		}
+	})
+}
+
+func TestCheckTimeout(t *testing.T) {
+	testCases := []struct {
+		checkTimeout time.Duration
+		options      *HealthCheckOptions
+		expected     string
+	}{
+		{
+			checkTimeout: 10 * time.Millisecond,
+			expected:     "check timed out after 10ms: context deadline exceeded",
+		},
+		{
+			options:  &HealthCheckOptions{CheckTimeout: 20 * time.Millisecond},
+			expected: "check timed out after 20ms: context deadline exceeded",
+		},
+		{
+			checkTimeout: 10 * time.Millisecond,
+			options:      &HealthCheckOptions{CheckTimeout: time.Hour},
+			expected:     "check timed out after 10ms: context deadline exceeded",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			hc := HealthChecker{HealthCheckOptions: tc.options}
+			hc.checkers = []*checker{
+				{
+					category:    "cat1",
+					description: "desc1",
+					timeout:     tc.checkTimeout,
+					check: func() error {
+						<-hc.ctx.Done()
+						return hc.ctx.Err()
+					},
+				},
+			}
+
+			var err error
+			success := hc.RunChecks(context.Background(), func(result *CheckResult) {
+				err = result.Err
+			}).Success
+
+			if success {
+				t.Fatalf("Expecting checks to fail, but got [%t]", success)
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestCheckHints(t *testing.T) {
+	hc := HealthChecker{
+		checkers: []*checker{
+			{
+				id:          "cat1/pass",
+				category:    "cat1",
+				description: "desc1",
+				remediation: "fix desc1",
+				check: func() error {
+					return nil
+				},
+			},
+			{
+				id:          "cat1/fail",
+				category:    "cat1",
+				description: "desc2",
+				remediation: "fix desc2",
+				check: func() error {
+					return fmt.Errorf("error")
+				},
+			},
+			{
+				category:    "cat2",
+				description: "desc3",
+				check: func() error {
+					return fmt.Errorf("error")
+				},
+			},
+		},
+	}
+
+	hints := []*Hint{}
+	hc.RunChecks(context.Background(), func(result *CheckResult) {
+		hints = append(hints, result.Hint)
+	})
+
+	expected := []*Hint{
+		nil,
+		{Remediation: "fix desc2"},
+		nil,
+	}
+	if !reflect.DeepEqual(hints, expected) {
+		t.Fatalf("Expected hints %+v, got %+v", expected, hints)
+	}
+}
+
+type testChecker struct {
+	category string
+	fatal    bool
+	severity Severity
+	check    func(ctx context.Context) error
+}
+
+func (c *testChecker) ID() string                      { return c.category + "/custom" }
+func (c *testChecker) Category() string                { return c.category }
+func (c *testChecker) Description() string             { return "custom check" }
+func (c *testChecker) Fatal() bool                     { return c.fatal }
+func (c *testChecker) Severity() Severity              { return c.severity }
+func (c *testChecker) Retry() bool                     { return false }
+func (c *testChecker) Check(ctx context.Context) error { return c.check(ctx) }
+
+func TestAddChecker(t *testing.T) {
+	type ctxKey struct{}
+
+	t.Run("Runs the registered checkers with the context of the checks", func(t *testing.T) {
+		hc := HealthChecker{}
+		hc.AddChecker(&testChecker{
+			category: "vendor",
+			check: func(ctx context.Context) error {
+				if ctx.Value(ctxKey{}) != "value" {
+					return fmt.Errorf("unexpected context")
+				}
+				return nil
+			},
+		})
+
+		observedResults := make([]string, 0)
+		observer := func(result *CheckResult) {
+			observedResults = append(observedResults, fmt.Sprintf("%s %s %v", result.Category, result.Description, result.Err))
+		}
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+		success := hc.RunChecks(ctx, observer).Success
+
+		if !success {
+			t.Fatalf("Expecting checks to be successful, but got [%t]", success)
+		}
+		expectedResults := []string{"vendor custom check <nil>"}
+		if !reflect.DeepEqual(observedResults, expectedResults) {
+			t.Fatalf("Expected results %v, but got %v", expectedResults, observedResults)
+		}
+	})
+
+	t.Run("Applies the severity and fatality of the registered checkers", func(t *testing.T) {
+		failing := func(ctx context.Context) error {
+			return fmt.Errorf("error")
+		}
+
+		hc := HealthChecker{}
+		hc.AddChecker(&testChecker{category: "warning", severity: SeverityWarning, check: failing})
+		if success := hc.RunChecks(context.Background(), func(*CheckResult) {}).Success; !success {
+			t.Fatalf("Expecting checks to be successful, but got [%t]", success)
+		}
+
+		hc = HealthChecker{}
+		hc.AddChecker(&testChecker{category: "fatal", fatal: true, check: failing})
+		hc.AddChecker(&testChecker{category: "skipped", check: failing})
+
+		observedCategories := make([]string, 0)
+		success := hc.RunChecks(context.Background(), func(result *CheckResult) {
+			observedCategories = append(observedCategories, result.Category)
+		}).Success
+
+		if success {
+			t.Fatalf("Expecting checks to fail, but got [%t]", success)
+		}
+		if !reflect.DeepEqual(observedCategories, []string{"fatal"}) {
+			t.Fatalf("Expected only the fatal check to run, but got %v", observedCategories)
+		}
+	})
+}
+
+func TestNewHealthChecker(t *testing.T) {
+	t.Run("Runs checks after the checks they require", func(t *testing.T) {
+		hc, err := NewHealthChecker(
+			[]Checks{LinkerdAPIChecks, KubernetesAPIChecks},
+			&HealthCheckOptions{},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if hc.checkers[0].category != KubernetesAPICategory {
+			t.Fatalf("Expected the first check to be a %s check, but got %s", KubernetesAPICategory, hc.checkers[0].category)
+		}
+	})
+
+	t.Run("Rejects checks whose required checks are missing", func(t *testing.T) {
+		_, err := NewHealthChecker(
+			[]Checks{KubernetesAPIChecks, LinkerdDataPlaneChecks},
+			&HealthCheckOptions{},
+		)
+
+		expected := "linkerd-data-plane checks require the linkerd-api checks, which weren't configured"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], but got [%v]", expected, err)
+		}
+	})
+
+	t.Run("Rejects skipping the checks that initialize the clients", func(t *testing.T) {
+		_, err := NewHealthChecker(
+			[]Checks{KubernetesAPIChecks},
+			&HealthCheckOptions{SkipChecks: []string{"kubernetes-api/reachable"}},
+		)
+
+		expected := "The \"kubernetes-api/reachable\" check can't be skipped; the checks after it rely on it"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], but got [%v]", expected, err)
+		}
+	})
+
+	t.Run("Rejects an invalid data plane selector", func(t *testing.T) {
+		_, err := NewHealthChecker(
+			[]Checks{KubernetesAPIChecks, LinkerdAPIChecks, LinkerdDataPlaneChecks},
+			&HealthCheckOptions{DataPlaneSelector: "app in (payments"},
+		)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+
+	t.Run("Skips fatal checks that don't initialize the clients", func(t *testing.T) {
+		_, err := NewHealthChecker(
+			[]Checks{KubernetesAPIChecks, LinkerdPreInstallChecks},
+			&HealthCheckOptions{SkipChecks: []string{"kubernetes-setup/create-clusterroles"}},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Rejects unknown checks to skip", func(t *testing.T) {
+		_, err := NewHealthChecker(
+			[]Checks{KubernetesAPIChecks, LinkerdPreInstallChecks},
+			&HealthCheckOptions{SkipChecks: []string{"kubernetes-setup/create-clusterrole"}},
+		)
+
+		expected := "Unknown check \"kubernetes-setup/create-clusterrole\" to skip"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], but got [%v]", expected, err)
+		}
+	})
+
+	t.Run("Skips the checks of extensions that aren't discovered yet", func(t *testing.T) {
+		_, err := NewHealthChecker(
+			[]Checks{KubernetesAPIChecks, LinkerdExtensionChecks, LinkerdVersionChecks},
+			&HealthCheckOptions{SkipChecks: []string{"linkerd-jaeger/pods-ready", "linkerd-policy/checks", "linkerd-version/latest"}},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Gives every check a unique ID", func(t *testing.T) {
+		hc, err := NewHealthChecker(
+			[]Checks{KubernetesAPIChecks, LinkerdPreInstallChecks, LinkerdAPIChecks, LinkerdDataPlaneChecks,
+				LinkerdVersionChecks, LinkerdInjectionSafetyChecks, LinkerdJaegerChecks, LinkerdExtensionChecks,
+				LinkerdProxyAdminChecks, LinkerdHAChecks},
+			&HealthCheckOptions{
+				DataPlaneNamespace:             "emojivoto",
+				ShouldCheckKubeVersion:         true,
+				ShouldCheckControlPlaneVersion: true,
+				ShouldCheckDataPlaneVersion:    true,
+			},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		ids := map[string]bool{}
+		for _, c := range hc.checkers {
+			if c.id == "" || ids[c.id] {
+				t.Fatalf("Expected a unique ID for check \"%s\", got \"%s\"", c.description, c.id)
+			}
+			ids[c.id] = true
+		}
+	})
+}
+
+func TestSortChecks(t *testing.T) {
+	testCases := []struct {
+		checks   []Checks
+		options  HealthCheckOptions
+		expected []Checks
+		err      string
+	}{
+		{
+			checks:   []Checks{KubernetesAPIChecks, LinkerdAPIChecks, LinkerdDataPlaneChecks},
+			expected: []Checks{KubernetesAPIChecks, LinkerdAPIChecks, LinkerdDataPlaneChecks},
+		},
+		{
+			checks:   []Checks{LinkerdDataPlaneChecks, LinkerdAPIChecks, KubernetesAPIChecks, LinkerdAPIChecks},
+			expected: []Checks{KubernetesAPIChecks, LinkerdAPIChecks, LinkerdDataPlaneChecks},
+		},
+		{
+			checks:   []Checks{LinkerdVersionChecks, KubernetesAPIChecks, LinkerdPreInstallChecks},
+			expected: []Checks{LinkerdVersionChecks, KubernetesAPIChecks, LinkerdPreInstallChecks},
+		},
+		{
+			checks:   []Checks{LinkerdVersionChecks, KubernetesAPIChecks, LinkerdAPIChecks},
+			options:  HealthCheckOptions{ShouldCheckControlPlaneVersion: true},
+			expected: []Checks{KubernetesAPIChecks, LinkerdAPIChecks, LinkerdVersionChecks},
+		},
+		{
+			checks:  []Checks{KubernetesAPIChecks, LinkerdVersionChecks},
+			options: HealthCheckOptions{ShouldCheckDataPlaneVersion: true},
+			err:     "linkerd-version checks require the linkerd-api checks, which weren't configured",
+		},
+		{
+			checks: []Checks{LinkerdJaegerChecks},
+			err:    "linkerd-jaeger checks require the kubernetes-api checks, which weren't configured",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			hc := HealthChecker{HealthCheckOptions: &tc.options}
+
+			sorted, err := hc.sortChecks(tc.checks)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error [%s], but got [%v]", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(sorted, tc.expected) {
+				t.Fatalf("Expected checks %v, but got %v", tc.expected, sorted)
+			}
+		})
+	}
+}
+
+func TestTelemetryDisabled(t *testing.T) {
+	webPod := func(args ...string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: "web-dead-beef"},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					v1.Container{Name: "web", Args: args},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		pods     []v1.Pod
+		disabled bool
+	}{
+		{[]v1.Pod{}, false},
+		{[]v1.Pod{webPod("-uuid=foo")}, false},
+		{[]v1.Pod{webPod("-uuid=foo", "-disable-telemetry=false")}, false},
+		{[]v1.Pod{webPod("-uuid=foo", "-disable-telemetry=true")}, true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			hc := HealthChecker{controlPlanePods: tc.pods}
+			if disabled := hc.telemetryDisabled(); disabled != tc.disabled {
+				t.Fatalf("Expected telemetryDisabled() to return %t, but got %t", tc.disabled, disabled)
+			}
+		})
+	}
 }
 
 func TestValidateControlPlanePods(t *testing.T) {
@@ -312,6 +877,33 @@ func TestValidateControlPlanePods(t *testing.T) {
 		}
 	})
 
+	t.Run("Describes why a container is not ready", func(t *testing.T) {
+		controller := pod("controller-6f78cbd47-bc557", v1.PodRunning, false)
+		controller.Status.ContainerStatuses[0].RestartCount = 5
+		controller.Status.ContainerStatuses[0].State = v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		}
+		controller.Status.ContainerStatuses[0].LastTerminationState = v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+		}
+
+		pods := []v1.Pod{
+			controller,
+			pod("grafana-5b7d796646-hh46d", v1.PodRunning, true),
+			pod("prometheus-74d6879cd6-bbdk6", v1.PodRunning, true),
+			pod("web-98c9ddbcd-7b5lh", v1.PodRunning, true),
+		}
+
+		err := validateControlPlanePods(pods)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "The \"controller\" pod's \"controller\" container is not ready (restarts: 5, waiting: CrashLoopBackOff, last terminated: Error, exit code 1)"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
 	t.Run("Returns nil if all pods are running and all containers are ready", func(t *testing.T) {
 		pods := []v1.Pod{
 			pod("controller-6f78cbd47-bc557", v1.PodRunning, true),
@@ -330,7 +922,7 @@ func TestValidateControlPlanePods(t *testing.T) {
 func TestValidateDataPlanePods(t *testing.T) {
 
 	t.Run("Returns an error if no inject pods were found", func(t *testing.T) {
-		err := validateDataPlanePods([]*pb.Pod{}, "emojivoto")
+		err := validateDataPlanePods([]*pb.Pod{}, []string{"emojivoto"}, "")
 		if err == nil {
 			t.Fatal("Expected error, got nothing")
 		}
@@ -347,7 +939,7 @@ func TestValidateDataPlanePods(t *testing.T) {
 			&pb.Pod{Name: "web-6cfbccc48-5g8px", Status: "Running", ProxyReady: true},
 		}
 
-		err := validateDataPlanePods(pods, "emojivoto")
+		err := validateDataPlanePods(pods, []string{"emojivoto"}, "")
 		if err == nil {
 			t.Fatal("Expected error, got nothing")
 		}
@@ -364,7 +956,7 @@ func TestValidateDataPlanePods(t *testing.T) {
 			&pb.Pod{Name: "web-6cfbccc48-5g8px", Status: "Running", ProxyReady: true},
 		}
 
-		err := validateDataPlanePods(pods, "emojivoto")
+		err := validateDataPlanePods(pods, []string{"emojivoto"}, "")
 		if err == nil {
 			t.Fatal("Expected error, got nothing")
 		}
@@ -381,7 +973,7 @@ func TestValidateDataPlanePods(t *testing.T) {
 			&pb.Pod{Name: "web-6cfbccc48-5g8px", Status: "Running", ProxyReady: true},
 		}
 
-		err := validateDataPlanePods(pods, "emojivoto")
+		err := validateDataPlanePods(pods, []string{"emojivoto"}, "")
 		if err != nil {
 			t.Fatalf("Unexpected error: %s", err)
 		}
@@ -423,3 +1015,207 @@ func TestValidateDataPlanePodReporting(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateCriticalNamespacePods(t *testing.T) {
+	t.Run("Returns nil if no pods are injected", func(t *testing.T) {
+		pods := []v1.Pod{
+			v1.Pod{ObjectMeta: meta.ObjectMeta{Name: "kube-dns-6b4f4b544c-8tq5z", Namespace: "kube-system"}},
+		}
+
+		err := validateCriticalNamespacePods(pods)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if any pods are injected", func(t *testing.T) {
+		pods := []v1.Pod{
+			v1.Pod{ObjectMeta: meta.ObjectMeta{Name: "kube-dns-6b4f4b544c-8tq5z", Namespace: "kube-system"}},
+			v1.Pod{ObjectMeta: meta.ObjectMeta{
+				Name:      "metrics-server-5cbbc84f8c-4kz4h",
+				Namespace: "kube-system",
+				Labels:    map[string]string{k8s.ControllerNSLabel: "linkerd"},
+			}},
+		}
+
+		err := validateCriticalNamespacePods(pods)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "The linkerd-proxy proxy is injected in pods that are critical to the cluster: kube-system/metrics-server-5cbbc84f8c-4kz4h" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
+func TestValidatePromQueryLatency(t *testing.T) {
+	queries := []*pb.PrometheusQuery{
+		{Query: "fast", Duration: &duration.Duration{Nanos: 20000000}},
+		{Query: "slow", Duration: &duration.Duration{Seconds: 1, Nanos: 500000000}},
+	}
+
+	t.Run("Returns nil if all queries are faster than the threshold", func(t *testing.T) {
+		err := validatePromQueryLatency(queries, 2*time.Second)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns nil if no queries were run", func(t *testing.T) {
+		err := validatePromQueryLatency(nil, time.Second)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if the slowest query is slower than the threshold", func(t *testing.T) {
+		err := validatePromQueryLatency(queries, time.Second)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "The slowest Prometheus query took 1.5s, more than 1s; Prometheus may need more resources:\n    slow" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if a query failed", func(t *testing.T) {
+		failed := append(queries, &pb.PrometheusQuery{
+			Query:    "broken",
+			Duration: &duration.Duration{},
+			Error:    "server_error: server error: 500",
+		})
+
+		err := validatePromQueryLatency(failed, 2*time.Second)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "Prometheus query failed: server_error: server error: 500" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
+func TestValidateDataPlaneVersions(t *testing.T) {
+	versions := []*pb.ProxySkewGroup{
+		{
+			Value:    "edge-18.10.2",
+			PodCount: 5,
+			Examples: []*pb.Resource{{Namespace: "emojivoto", Type: "deployment", Name: "web"}},
+		},
+		{
+			Value:    "edge-18.10.1",
+			PodCount: 2,
+			Examples: []*pb.Resource{
+				{Namespace: "books", Type: "deployment", Name: "authors"},
+				{Namespace: "emojivoto", Type: "pod", Name: "vote-bot"},
+			},
+		},
+	}
+
+	t.Run("Returns nil if all proxies are running the latest version", func(t *testing.T) {
+		err := validateDataPlaneVersions(versions[:1], "edge-18.10.2")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error listing the outdated proxies", func(t *testing.T) {
+		err := validateDataPlaneVersions(versions, "edge-18.10.2")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "Some proxies are not running the latest version edge-18.10.2:\n    2 running edge-18.10.1, e.g. books/deployment/authors, emojivoto/pod/vote-bot" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
+func TestValidateInjectionWebhooks(t *testing.T) {
+	namespaces := []v1.Namespace{
+		v1.Namespace{ObjectMeta: meta.ObjectMeta{Name: "kube-system"}},
+	}
+
+	webhookConfig := func(namespace string, selector *meta.LabelSelector, policy admissionregistration.FailurePolicyType) admissionregistration.MutatingWebhookConfiguration {
+		return admissionregistration.MutatingWebhookConfiguration{
+			Webhooks: []admissionregistration.Webhook{
+				admissionregistration.Webhook{
+					Name: "linkerd-proxy-injector.linkerd.io",
+					ClientConfig: admissionregistration.WebhookClientConfig{
+						Service: &admissionregistration.ServiceReference{
+							Namespace: namespace,
+							Name:      "proxy-injector",
+						},
+					},
+					NamespaceSelector: selector,
+					FailurePolicy:     &policy,
+				},
+			},
+		}
+	}
+
+	excludeKubeSystem := &meta.LabelSelector{
+		MatchExpressions: []meta.LabelSelectorRequirement{
+			meta.LabelSelectorRequirement{
+				Key:      "linkerd.io/inject",
+				Operator: meta.LabelSelectorOpNotIn,
+				Values:   []string{"disabled"},
+			},
+		},
+	}
+	namespacesWithLabel := []v1.Namespace{
+		v1.Namespace{ObjectMeta: meta.ObjectMeta{
+			Name:   "kube-system",
+			Labels: map[string]string{"linkerd.io/inject": "disabled"},
+		}},
+	}
+
+	t.Run("Ignores webhooks served from other namespaces", func(t *testing.T) {
+		configs := []admissionregistration.MutatingWebhookConfiguration{
+			webhookConfig("istio-system", nil, admissionregistration.Fail),
+		}
+
+		err := validateInjectionWebhooks(configs, namespaces, "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns nil if critical namespaces are excluded", func(t *testing.T) {
+		configs := []admissionregistration.MutatingWebhookConfiguration{
+			webhookConfig("linkerd", excludeKubeSystem, admissionregistration.Fail),
+		}
+
+		err := validateInjectionWebhooks(configs, namespacesWithLabel, "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if critical namespaces aren't excluded", func(t *testing.T) {
+		configs := []admissionregistration.MutatingWebhookConfiguration{
+			webhookConfig("linkerd", nil, admissionregistration.Ignore),
+		}
+
+		err := validateInjectionWebhooks(configs, namespaces, "linkerd")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "The \"linkerd-proxy-injector.linkerd.io\" webhook applies to the \"kube-system\" namespace; add a namespaceSelector that excludes it" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if the webhook would block critical pods", func(t *testing.T) {
+		configs := []admissionregistration.MutatingWebhookConfiguration{
+			webhookConfig("linkerd", excludeKubeSystem, admissionregistration.Fail),
+		}
+
+		err := validateInjectionWebhooks(configs, namespaces, "linkerd")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "The \"linkerd-proxy-injector.linkerd.io\" webhook applies to the \"kube-system\" namespace with failurePolicy Fail; pods critical to the cluster won't start while the control plane is unavailable" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
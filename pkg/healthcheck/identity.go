@@ -0,0 +1,232 @@
+package healthcheck
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The environment variables that configure a proxy's TLS identity, as set by
+// `linkerd inject`.
+const (
+	proxyPodIdentityEnvVar        = "LINKERD2_PROXY_TLS_POD_IDENTITY"
+	proxyControllerIdentityEnvVar = "LINKERD2_PROXY_TLS_CONTROLLER_IDENTITY"
+	proxyPodNamespaceEnvVar       = "LINKERD2_PROXY_POD_NAMESPACE"
+)
+
+// ProxyIdentity compares the TLS identity a meshed pod's proxy was configured
+// with when it was injected against the identity the control plane's CA
+// derives from the pod's owner, and against the certificate the CA issued.
+// Any mismatch silently breaks TLS between the proxy and its peers.
+type ProxyIdentity struct {
+	Pod *v1.Pod
+
+	// Configured is the identity the proxy presents to its peers.
+	Configured string
+
+	// Expected is the identity the CA issues a certificate for, in the control
+	// plane's trust domain.
+	Expected string
+
+	// Problems lists the mismatches between the proxy's configuration, the
+	// expected identity and the proxy's certificate.
+	Problems []string
+}
+
+// DiagnoseProxyIdentities returns the identities of the proxies of the given
+// pods that have TLS enabled, along with any problem with them. The pods'
+// owners, certificates and trust anchors are read from the Kubernetes API.
+func DiagnoseProxyIdentities(clientset kubernetes.Interface, controlPlaneNamespace string, pods []v1.Pod) ([]ProxyIdentity, error) {
+	trustAnchors := make(map[string]*x509.CertPool)
+	identities := make([]ProxyIdentity, 0)
+
+	for i := range pods {
+		pod := &pods[i]
+		if proxyEnv(pod, proxyPodIdentityEnvVar) == "" {
+			continue
+		}
+
+		ownerKind, ownerName, err := podOwner(clientset, pod)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, ok := trustAnchors[pod.Namespace]
+		if !ok {
+			pool, err = getTrustAnchors(clientset, pod.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			trustAnchors[pod.Namespace] = pool
+		}
+
+		identity := diagnoseProxyIdentity(pod, ownerKind, ownerName, controlPlaneNamespace)
+
+		if configured, err := k8s.ParseTLSIdentity(identity.Configured); err == nil {
+			certificate, problem, err := getCertificate(clientset, pod.Namespace, configured.ToSecretName())
+			if err != nil {
+				return nil, err
+			}
+
+			if problem != "" {
+				identity.Problems = append(identity.Problems, problem)
+			} else {
+				identity.Problems = append(identity.Problems, validateCertificate(certificate, identity.Configured, pool, pod.Namespace)...)
+			}
+		}
+
+		identities = append(identities, identity)
+	}
+
+	return identities, nil
+}
+
+// diagnoseProxyIdentity compares the identities a pod's proxy is configured
+// with against the ones derived from the pod's owner and the control plane
+// namespace.
+func diagnoseProxyIdentity(pod *v1.Pod, ownerKind, ownerName, controlPlaneNamespace string) ProxyIdentity {
+	expected := k8s.TLSIdentity{
+		Name:                ownerName,
+		Kind:                ownerKind,
+		Namespace:           pod.Namespace,
+		ControllerNamespace: controlPlaneNamespace,
+	}
+	identity := ProxyIdentity{
+		Pod:        pod,
+		Configured: strings.Replace(proxyEnv(pod, proxyPodIdentityEnvVar), "$"+proxyPodNamespaceEnvVar, pod.Namespace, -1),
+		Expected:   expected.ToDNSName(),
+	}
+
+	configured, err := k8s.ParseTLSIdentity(identity.Configured)
+	if err != nil {
+		identity.Problems = append(identity.Problems, err.Error())
+		return identity
+	}
+
+	if configured.TrustDomain() != expected.TrustDomain() {
+		identity.Problems = append(identity.Problems,
+			fmt.Sprintf("trust domain %s doesn't match the control plane's %s", configured.TrustDomain(), expected.TrustDomain()))
+	} else if configured != expected {
+		identity.Problems = append(identity.Problems,
+			fmt.Sprintf("identity is for %s/%s, but the CA issues certificates for the pod's owner, %s/%s",
+				configured.Kind, configured.Name, expected.Kind, expected.Name))
+	}
+
+	controllerIdentity := expected.ToControllerIdentity().ToDNSName()
+	if configuredController := proxyEnv(pod, proxyControllerIdentityEnvVar); configuredController != controllerIdentity {
+		identity.Problems = append(identity.Problems,
+			fmt.Sprintf("controller identity %s doesn't match the control plane's %s", configuredController, controllerIdentity))
+	}
+
+	return identity
+}
+
+// validateCertificate returns the problems with the certificate issued to a
+// proxy: it must be valid for the proxy's identity and signed by the trust
+// anchors distributed in the proxy's namespace.
+func validateCertificate(certificate *x509.Certificate, identity string, trustAnchors *x509.CertPool, namespace string) []string {
+	problems := []string{}
+
+	if err := certificate.VerifyHostname(identity); err != nil {
+		problems = append(problems, fmt.Sprintf("certificate is for [%s], not %s", strings.Join(certificate.DNSNames, ", "), identity))
+	}
+
+	if trustAnchors == nil {
+		problems = append(problems, fmt.Sprintf("the %s/%s ConfigMap with the trust anchors doesn't exist", namespace, k8s.TLSTrustAnchorConfigMapName))
+	} else if _, err := certificate.Verify(x509.VerifyOptions{Roots: trustAnchors}); err != nil {
+		problems = append(problems, fmt.Sprintf("certificate isn't trusted by the trust anchors in the %s/%s ConfigMap: %s", namespace, k8s.TLSTrustAnchorConfigMapName, err))
+	}
+
+	return problems
+}
+
+// podOwner returns the kind and name of the pod's owner, the same way the CA
+// does.
+func podOwner(clientset kubernetes.Interface, pod *v1.Pod) (string, string, error) {
+	if len(pod.GetOwnerReferences()) != 1 {
+		return "pod", pod.Name, nil
+	}
+
+	parent := pod.GetOwnerReferences()[0]
+	if parent.Kind == "ReplicaSet" {
+		rs, err := clientset.AppsV1().ReplicaSets(pod.Namespace).Get(parent.Name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) || (err == nil && len(rs.GetOwnerReferences()) != 1) {
+			return strings.ToLower(parent.Kind), parent.Name, nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+		rsParent := rs.GetOwnerReferences()[0]
+		return strings.ToLower(rsParent.Kind), rsParent.Name, nil
+	}
+
+	return strings.ToLower(parent.Kind), parent.Name, nil
+}
+
+// getTrustAnchors returns the trust anchors distributed to the proxies in a
+// namespace, or nil if there are none.
+func getTrustAnchors(clientset kubernetes.Interface, namespace string) (*x509.CertPool, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(k8s.TLSTrustAnchorConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM([]byte(cm.Data[k8s.TLSTrustAnchorFileName]))
+	return pool, nil
+}
+
+// getCertificate returns the certificate in a proxy's TLS secret, or a
+// problem describing why there's no usable certificate.
+func getCertificate(clientset kubernetes.Interface, namespace, secretName string) (*x509.Certificate, string, error) {
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(secretName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, fmt.Sprintf("the %s/%s secret with the proxy's certificate doesn't exist", namespace, secretName), nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	certificate, err := x509.ParseCertificate(secret.Data[k8s.TLSCertFileName])
+	if err != nil {
+		return nil, fmt.Sprintf("the %s/%s secret has an invalid certificate: %s", namespace, secretName, err), nil
+	}
+	return certificate, "", nil
+}
+
+// proxyEnv returns the value of an environment variable of the pod's proxy
+// container.
+func proxyEnv(pod *v1.Pod, name string) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != k8s.ProxyContainerName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+	}
+	return ""
+}
+
+// validateProxyIdentities returns an error describing the problems with the
+// identity of the first proxy that has any.
+func validateProxyIdentities(identities []ProxyIdentity) error {
+	for _, identity := range identities {
+		if len(identity.Problems) > 0 {
+			return fmt.Errorf("The \"%s/%s\" pod's proxy identity %s doesn't match the control plane: %s; run \"linkerd identity\" for details",
+				identity.Pod.Namespace, identity.Pod.Name, identity.Configured, strings.Join(identity.Problems, "; "))
+		}
+	}
+	return nil
+}
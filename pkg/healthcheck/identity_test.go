@@ -0,0 +1,176 @@
+package healthcheck
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/ca"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDiagnoseProxyIdentities(t *testing.T) {
+	authority, err := ca.NewCA()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	otherAuthority, err := ca.NewCA()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	identity := k8s.TLSIdentity{Name: "books", Kind: "deployment", Namespace: "booksapp", ControllerNamespace: "linkerd"}
+
+	pod := func(podIdentity, controllerIdentity string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: meta.ObjectMeta{
+				Name:            "books-66f5b6b5c5-ddk7b",
+				Namespace:       "booksapp",
+				Labels:          map[string]string{k8s.ControllerNSLabel: "linkerd"},
+				OwnerReferences: []meta.OwnerReference{{Kind: "ReplicaSet", Name: "books-66f5b6b5c5"}},
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Name: k8s.ProxyContainerName,
+					Env: []v1.EnvVar{
+						{Name: proxyPodIdentityEnvVar, Value: podIdentity},
+						{Name: proxyControllerIdentityEnvVar, Value: controllerIdentity},
+					},
+				}},
+			},
+		}
+	}
+
+	secret := func(authority *ca.CA, dnsName string) *v1.Secret {
+		crt, err := authority.IssueEndEntityCertificate(dnsName)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return &v1.Secret{
+			ObjectMeta: meta.ObjectMeta{Name: identity.ToSecretName(), Namespace: "booksapp"},
+			Data:       map[string][]byte{k8s.TLSCertFileName: crt.Certificate},
+		}
+	}
+
+	newClientset := func(objects ...runtime.Object) *fake.Clientset {
+		return fake.NewSimpleClientset(append(objects,
+			&appsV1.ReplicaSet{ObjectMeta: meta.ObjectMeta{
+				Name:            "books-66f5b6b5c5",
+				Namespace:       "booksapp",
+				OwnerReferences: []meta.OwnerReference{{Kind: "Deployment", Name: "books"}},
+			}},
+			&v1.ConfigMap{
+				ObjectMeta: meta.ObjectMeta{Name: k8s.TLSTrustAnchorConfigMapName, Namespace: "booksapp"},
+				Data:       map[string]string{k8s.TLSTrustAnchorFileName: authority.TrustAnchorPEM()},
+			},
+		)...)
+	}
+
+	configuredIdentity := "books.deployment.$LINKERD2_PROXY_POD_NAMESPACE.linkerd-managed.linkerd.svc.cluster.local"
+	controllerIdentity := identity.ToControllerIdentity().ToDNSName()
+
+	testCases := []struct {
+		description      string
+		pod              v1.Pod
+		secret           *v1.Secret
+		expectedProblems []string
+	}{
+		{
+			description: "Returns no problems for matching identities",
+			pod:         pod(configuredIdentity, controllerIdentity),
+			secret:      secret(authority, identity.ToDNSName()),
+		},
+		{
+			description: "Flags proxies in another trust domain",
+			pod: pod(
+				"books.deployment.$LINKERD2_PROXY_POD_NAMESPACE.linkerd-managed.linkerd-old.svc.cluster.local",
+				"controller.deployment.linkerd-old.linkerd-managed.linkerd-old.svc.cluster.local",
+			),
+			secret: secret(authority, identity.ToDNSName()),
+			expectedProblems: []string{
+				"trust domain linkerd-managed.linkerd-old.svc.cluster.local doesn't match the control plane's linkerd-managed.linkerd.svc.cluster.local",
+				"controller identity controller.deployment.linkerd-old.linkerd-managed.linkerd-old.svc.cluster.local doesn't match the control plane's controller.deployment.linkerd.linkerd-managed.linkerd.svc.cluster.local",
+				"certificate is for [books.deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local], not books.deployment.booksapp.linkerd-managed.linkerd-old.svc.cluster.local",
+			},
+		},
+		{
+			description: "Flags proxies whose identity isn't derived from the pod's owner",
+			pod: pod(
+				"books-66f5b6b5c5.replicaset.$LINKERD2_PROXY_POD_NAMESPACE.linkerd-managed.linkerd.svc.cluster.local",
+				controllerIdentity,
+			),
+			expectedProblems: []string{
+				"identity is for replicaset/books-66f5b6b5c5, but the CA issues certificates for the pod's owner, deployment/books",
+				"the booksapp/books-66f5b6b5c5-replicaset-tls-linkerd-io secret with the proxy's certificate doesn't exist",
+			},
+		},
+		{
+			description: "Flags certificates that aren't trusted",
+			pod:         pod(configuredIdentity, controllerIdentity),
+			secret:      secret(otherAuthority, identity.ToDNSName()),
+			expectedProblems: []string{
+				"certificate isn't trusted by the trust anchors in the booksapp/linkerd-ca-bundle ConfigMap",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			objects := []runtime.Object{}
+			if tc.secret != nil {
+				objects = append(objects, tc.secret)
+			}
+
+			identities, err := DiagnoseProxyIdentities(newClientset(objects...), "linkerd", []v1.Pod{tc.pod})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if len(identities) != 1 {
+				t.Fatalf("Expected 1 identity, got %d", len(identities))
+			}
+
+			if identities[0].Expected != identity.ToDNSName() {
+				t.Fatalf("Expected identity [%s], got [%s]", identity.ToDNSName(), identities[0].Expected)
+			}
+
+			problems := identities[0].Problems
+			if len(problems) != len(tc.expectedProblems) {
+				t.Fatalf("Expected problems %v, got %v", tc.expectedProblems, problems)
+			}
+			for i, problem := range problems {
+				if !strings.HasPrefix(problem, tc.expectedProblems[i]) {
+					t.Fatalf("Expected problem [%s], got [%s]", tc.expectedProblems[i], problem)
+				}
+			}
+		})
+	}
+
+	t.Run("Skips proxies without TLS", func(t *testing.T) {
+		identities, err := DiagnoseProxyIdentities(newClientset(), "linkerd", []v1.Pod{pod("", "")})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(identities) != 0 {
+			t.Fatalf("Expected no identities, got %+v", identities)
+		}
+	})
+}
+
+func TestValidateProxyIdentities(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: meta.ObjectMeta{Name: "books-66f5b6b5c5-ddk7b", Namespace: "booksapp"}}
+
+	err := validateProxyIdentities([]ProxyIdentity{{Pod: pod, Configured: "books"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = validateProxyIdentities([]ProxyIdentity{{Pod: pod, Configured: "books", Problems: []string{"foo", "bar"}}})
+	expected := "The \"booksapp/books-66f5b6b5c5-ddk7b\" pod's proxy identity books doesn't match the control plane: foo; bar; run \"linkerd identity\" for details"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
@@ -0,0 +1,52 @@
+package healthcheck
+
+import (
+	"k8s.io/api/core/v1"
+)
+
+// jaegerExtension is the value of the ExtensionLabel on the namespace the
+// jaeger extension is installed in.
+const jaegerExtension = "jaeger"
+
+// jaegerComponents are the names of the components the jaeger extension
+// deploys.
+var jaegerComponents = []string{"collector", "jaeger"}
+
+func (hc *HealthChecker) addLinkerdJaegerChecks() {
+	hc.addJaegerChecks(hc.JaegerNamespace)
+}
+
+func (hc *HealthChecker) addJaegerChecks(namespace string) {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-jaeger/namespace",
+		category:    LinkerdJaegerCategory,
+		description: "jaeger extension namespace exists",
+		remediation: "Install the jaeger extension with `linkerd jaeger install | kubectl apply -f -`",
+		fatal:       true,
+		check: func() error {
+			return hc.checkNamespace(namespace)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:            "linkerd-jaeger/pods-ready",
+		category:      LinkerdJaegerCategory,
+		description:   "jaeger extension pods are ready",
+		remediation:   "Inspect the jaeger extension pods with `kubectl -n linkerd-jaeger describe pods`",
+		retryDeadline: hc.RetryDeadline,
+		fatal:         true,
+		check: func() error {
+			pods, err := hc.kubeAPI.GetPodsByNamespace(hc.ctx, hc.httpClient, namespace)
+			if err != nil {
+				return err
+			}
+			return validateJaegerPods(pods)
+		},
+	})
+}
+
+// validateJaegerPods returns an error if the collector or Jaeger pods aren't
+// running and ready.
+func validateJaegerPods(pods []v1.Pod) error {
+	return validateContainersReady(runningContainerStatuses(pods), jaegerComponents)
+}
@@ -0,0 +1,64 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateJaegerPods(t *testing.T) {
+	pod := func(name, container string, phase v1.PodPhase, ready bool) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: name},
+			Status: v1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []v1.ContainerStatus{
+					v1.ContainerStatus{Name: container, Ready: ready},
+				},
+			},
+		}
+	}
+
+	t.Run("Returns nil if the collector and Jaeger are ready", func(t *testing.T) {
+		pods := []v1.Pod{
+			pod("collector-7d5f8b6b8c-x2xkz", "collector", v1.PodRunning, true),
+			pod("jaeger-6c8b9d4f5d-lq7wm", "jaeger", v1.PodRunning, true),
+		}
+
+		err := validateJaegerPods(pods)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if the collector isn't running", func(t *testing.T) {
+		pods := []v1.Pod{
+			pod("collector-7d5f8b6b8c-x2xkz", "collector", v1.PodPending, false),
+			pod("jaeger-6c8b9d4f5d-lq7wm", "jaeger", v1.PodRunning, true),
+		}
+
+		err := validateJaegerPods(pods)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "No running pods for \"collector\"" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if Jaeger isn't ready", func(t *testing.T) {
+		pods := []v1.Pod{
+			pod("collector-7d5f8b6b8c-x2xkz", "collector", v1.PodRunning, true),
+			pod("jaeger-6c8b9d4f5d-lq7wm", "jaeger", v1.PodRunning, false),
+		}
+
+		err := validateJaegerPods(pods)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "The \"jaeger\" pod's \"jaeger\" container is not ready" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
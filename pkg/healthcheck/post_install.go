@@ -0,0 +1,239 @@
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// caDeployment is the control plane deployment that's only installed with
+// TLS enabled, along with its RBAC.
+const caDeployment = "ca"
+
+func (hc *HealthChecker) addLinkerdPostInstallChecks() {
+	hc.addControlPlaneResourceCheck("linkerd-install/service-accounts", "ServiceAccount")
+	hc.addControlPlaneResourceCheck("linkerd-install/cluster-roles", "ClusterRole")
+	hc.addControlPlaneResourceCheck("linkerd-install/cluster-role-bindings", "ClusterRoleBinding")
+	hc.addControlPlaneResourceCheck("linkerd-install/services", "Service")
+	hc.addControlPlaneResourceCheck("linkerd-install/config-maps", "ConfigMap")
+	hc.addControlPlaneResourceCheck("linkerd-install/deployments", "Deployment")
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-install/versions",
+		category:    LinkerdPostInstallCategory,
+		description: "control plane resources match the installed version",
+		remediation: "Re-apply the output of `linkerd install` or `linkerd upgrade` for the installed version",
+		fatal:       false,
+		check: func() error {
+			installed, err := hc.installedVersion()
+			if err != nil {
+				return err
+			}
+
+			for _, kind := range []string{"Service", "ConfigMap", "Deployment"} {
+				found, err := hc.getControlPlaneResources(kind)
+				if err != nil {
+					return err
+				}
+				if err := validateResourceVersions(kind, found, installed); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// addControlPlaneResourceCheck adds a check that all the resources of a kind
+// that `linkerd install` creates exist.
+func (hc *HealthChecker) addControlPlaneResourceCheck(id, kind string) {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          id,
+		category:    LinkerdPostInstallCategory,
+		description: fmt.Sprintf("control plane %ss exist", kind),
+		remediation: "Re-apply the output of `linkerd install` or `linkerd upgrade` for the installed version",
+		fatal:       false,
+		check: func() error {
+			tls, err := hc.controlPlaneTLS()
+			if err != nil {
+				return err
+			}
+
+			found, err := hc.getControlPlaneResources(kind)
+			if err != nil {
+				return err
+			}
+
+			expected := expectedControlPlaneResources(hc.ControlPlaneNamespace, tls)[kind]
+			return validateResourcesExist(kind, expected, found)
+		},
+	})
+}
+
+// expectedControlPlaneResources returns the names of the resources of each
+// kind that `linkerd install` creates for a control plane in namespace.
+func expectedControlPlaneResources(namespace string, tls bool) map[string][]string {
+	clusterRoles := []string{
+		fmt.Sprintf("linkerd-%s-controller", namespace),
+		fmt.Sprintf("linkerd-%s-prometheus", namespace),
+	}
+	resources := map[string][]string{
+		"ServiceAccount":     {"linkerd-controller", "linkerd-prometheus"},
+		"ClusterRole":        clusterRoles,
+		"ClusterRoleBinding": clusterRoles,
+		"Service":            {"api", "proxy-api", "web", "prometheus", "grafana"},
+		"ConfigMap":          {k8s.ProxyConfigMapName, "prometheus-config", "grafana-config"},
+		"Deployment":         {"controller", "web", "prometheus", "grafana"},
+	}
+
+	if tls {
+		caRole := fmt.Sprintf("linkerd-%s-ca", namespace)
+		resources["ServiceAccount"] = append(resources["ServiceAccount"], "linkerd-ca")
+		resources["ClusterRole"] = append(resources["ClusterRole"], caRole)
+		resources["ClusterRoleBinding"] = append(resources["ClusterRoleBinding"], caRole)
+		resources["Deployment"] = append(resources["Deployment"], caDeployment)
+	}
+
+	return resources
+}
+
+// controlPlaneTLS returns true if the control plane was installed with TLS
+// enabled, i.e. if its CA deployment exists.
+func (hc *HealthChecker) controlPlaneTLS() (bool, error) {
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = clientset.AppsV1().Deployments(hc.ControlPlaneNamespace).Get(caDeployment, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getControlPlaneResources returns the value of the CreatedByAnnotation of
+// the resources of a kind in the control plane namespace, or of the cluster
+// for cluster-scoped kinds, keyed by name.
+func (hc *HealthChecker) getControlPlaneResources(kind string) (map[string]string, error) {
+	if found, ok := hc.controlPlaneResources[kind]; ok {
+		return found, nil
+	}
+
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []metav1.ObjectMeta
+	ns := hc.ControlPlaneNamespace
+	switch kind {
+	case "ServiceAccount":
+		list, err := clientset.CoreV1().ServiceAccounts(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			objects = append(objects, item.ObjectMeta)
+		}
+	case "ClusterRole":
+		list, err := clientset.RbacV1().ClusterRoles().List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			objects = append(objects, item.ObjectMeta)
+		}
+	case "ClusterRoleBinding":
+		list, err := clientset.RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			objects = append(objects, item.ObjectMeta)
+		}
+	case "Service":
+		list, err := clientset.CoreV1().Services(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			objects = append(objects, item.ObjectMeta)
+		}
+	case "ConfigMap":
+		list, err := clientset.CoreV1().ConfigMaps(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			objects = append(objects, item.ObjectMeta)
+		}
+	case "Deployment":
+		list, err := clientset.AppsV1().Deployments(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			objects = append(objects, item.ObjectMeta)
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported control plane resource kind: %s", kind)
+	}
+
+	found := make(map[string]string)
+	for _, object := range objects {
+		found[object.Name] = object.Annotations[k8s.CreatedByAnnotation]
+	}
+
+	if hc.controlPlaneResources == nil {
+		hc.controlPlaneResources = make(map[string]map[string]string)
+	}
+	hc.controlPlaneResources[kind] = found
+	return found, nil
+}
+
+// validateResourcesExist returns an error listing the expected resources of
+// a kind that weren't found.
+func validateResourcesExist(kind string, expected []string, found map[string]string) error {
+	missing := []string{}
+	for _, name := range expected {
+		if _, ok := found[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("Missing %ss: %s", kind, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// validateResourceVersions returns an error if one of the resources of a kind
+// that record the version that created them was created by another version
+// than the installed one.
+func validateResourceVersions(kind string, found map[string]string, installed string) error {
+	names := []string{}
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version, err := parseCreatedBy(found[name])
+		if err != nil {
+			continue
+		}
+		if version != installed {
+			return fmt.Errorf("The \"%s\" %s was created by version %s, but the control plane runs version %s",
+				name, kind, version, installed)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package healthcheck
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpectedControlPlaneResources(t *testing.T) {
+	t.Run("Returns the CA's resources if TLS is enabled", func(t *testing.T) {
+		resources := expectedControlPlaneResources("linkerd", true)
+
+		expected := []string{"linkerd-linkerd-controller", "linkerd-linkerd-prometheus", "linkerd-linkerd-ca"}
+		if !reflect.DeepEqual(resources["ClusterRole"], expected) {
+			t.Fatalf("Expected ClusterRoles %v, got %v", expected, resources["ClusterRole"])
+		}
+		if !reflect.DeepEqual(resources["ClusterRoleBinding"], expected) {
+			t.Fatalf("Expected ClusterRoleBindings %v, got %v", expected, resources["ClusterRoleBinding"])
+		}
+	})
+
+	t.Run("Doesn't return the CA's resources if TLS is disabled", func(t *testing.T) {
+		resources := expectedControlPlaneResources("linkerd", false)
+
+		expected := []string{"controller", "web", "prometheus", "grafana"}
+		if !reflect.DeepEqual(resources["Deployment"], expected) {
+			t.Fatalf("Expected Deployments %v, got %v", expected, resources["Deployment"])
+		}
+	})
+}
+
+func TestValidateResourcesExist(t *testing.T) {
+	found := map[string]string{"api": "", "web": ""}
+
+	if err := validateResourcesExist("Service", []string{"api", "web"}, found); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err := validateResourcesExist("Service", []string{"api", "proxy-api", "web", "grafana"}, found)
+	expected := "Missing Services: proxy-api, grafana"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
+
+func TestValidateResourceVersions(t *testing.T) {
+	t.Run("Ignores the resources that don't record their version", func(t *testing.T) {
+		found := map[string]string{
+			"controller": "linkerd/cli stable-2.1.0",
+			"custom":     "",
+		}
+		if err := validateResourceVersions("Deployment", found, "stable-2.1.0"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if a resource was created by another version", func(t *testing.T) {
+		found := map[string]string{
+			"controller": "linkerd/cli stable-2.1.0",
+			"web":        "linkerd/cli stable-2.0.0",
+		}
+		err := validateResourceVersions("Deployment", found, "stable-2.1.0")
+		expected := "The \"web\" Deployment was created by version stable-2.0.0, but the control plane runs version stable-2.1.0"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package healthcheck
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	k8sVersion "k8s.io/apimachinery/pkg/version"
+)
+
+// clusterProvider identifies the managed Kubernetes offering a cluster runs
+// on, which determines the set of provider-specific pre-install checks.
+type clusterProvider string
+
+const (
+	providerUnknown clusterProvider = ""
+	providerGKE     clusterProvider = "GKE"
+	providerEKS     clusterProvider = "EKS"
+	providerAKS     clusterProvider = "AKS"
+
+	// awsNodeDaemonSet is the name of the DaemonSet that runs the Amazon VPC
+	// CNI plugin on EKS nodes.
+	awsNodeDaemonSet = "aws-node"
+)
+
+// detectClusterProvider guesses the cluster's provider from the API server's
+// version string, which GKE and EKS annotate, and from the nodes' provider
+// IDs.
+func detectClusterProvider(version *k8sVersion.Info, nodes []v1.Node) clusterProvider {
+	if version != nil {
+		switch {
+		case strings.Contains(version.GitVersion, "-gke."):
+			return providerGKE
+		case strings.Contains(version.GitVersion, "-eks-"):
+			return providerEKS
+		}
+	}
+
+	for _, node := range nodes {
+		switch {
+		case strings.HasPrefix(node.Spec.ProviderID, "azure://"):
+			return providerAKS
+		case strings.HasPrefix(node.Spec.ProviderID, "gce://"):
+			return providerGKE
+		}
+	}
+
+	return providerUnknown
+}
+
+// validateGKENodeFirewall returns an error if none of the nodes have external
+// IPs, which indicates a GKE private cluster. The firewall rules GKE creates
+// for private clusters only allow the master to reach nodes on ports 443 and
+// 10250, so traffic to webhooks listening on any other port is silently
+// dropped.
+func validateGKENodeFirewall(nodes []v1.Node) error {
+	for _, node := range nodes {
+		for _, address := range node.Status.Addresses {
+			if address.Type == v1.NodeExternalIP {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("The cluster appears to be a GKE private cluster; make sure a firewall rule allows the master to reach the nodes on the control plane's webhook ports, or proxy injection will time out")
+}
+
+// validateEKSNetworking returns an error if the Amazon VPC CNI plugin isn't
+// running. With other CNI plugins, pod IPs aren't routable from the EKS
+// control plane's security group, so it can't call webhooks served by pods.
+func validateEKSNetworking(daemonSets []string) error {
+	for _, name := range daemonSets {
+		if name == awsNodeDaemonSet {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("The \"%s\" DaemonSet is not running in the kube-system namespace; with a custom CNI the EKS control plane can't reach pod IPs, so it can't call webhooks served by the control plane", awsNodeDaemonSet)
+}
+
+// validateAKSAdmission returns an error if the AKS cluster is running a
+// Kubernetes version that doesn't support admission webhooks. Note that AKS
+// never calls admission webhooks for the kube-system namespace.
+func validateAKSAdmission(version *k8sVersion.Info) error {
+	if version == nil {
+		return fmt.Errorf("Unable to determine the Kubernetes version")
+	}
+
+	major, err := strconv.Atoi(version.Major)
+	if err != nil {
+		return fmt.Errorf("Unknown Kubernetes major version [%s]", version.Major)
+	}
+	minor, err := strconv.Atoi(strings.TrimSuffix(version.Minor, "+"))
+	if err != nil {
+		return fmt.Errorf("Unknown Kubernetes minor version [%s]", version.Minor)
+	}
+
+	if major == 1 && minor < 10 {
+		return fmt.Errorf("AKS clusters running Kubernetes %d.%d don't support admission webhooks; upgrade to 1.10 or later to use proxy auto-injection", major, minor)
+	}
+
+	return nil
+}
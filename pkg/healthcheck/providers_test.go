@@ -0,0 +1,113 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"k8s.io/api/core/v1"
+	k8sVersion "k8s.io/apimachinery/pkg/version"
+)
+
+func TestDetectClusterProvider(t *testing.T) {
+	node := func(providerID string) v1.Node {
+		return v1.Node{Spec: v1.NodeSpec{ProviderID: providerID}}
+	}
+
+	expectations := []struct {
+		version  *k8sVersion.Info
+		nodes    []v1.Node
+		provider clusterProvider
+	}{
+		{
+			version:  &k8sVersion.Info{GitVersion: "v1.10.7-gke.6"},
+			provider: providerGKE,
+		},
+		{
+			version:  &k8sVersion.Info{GitVersion: "v1.10.3-eks-129c61"},
+			provider: providerEKS,
+		},
+		{
+			version:  &k8sVersion.Info{GitVersion: "v1.11.3"},
+			nodes:    []v1.Node{node("azure:///subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/aks-nodepool1-0")},
+			provider: providerAKS,
+		},
+		{
+			version:  &k8sVersion.Info{GitVersion: "v1.11.3"},
+			nodes:    []v1.Node{node("gce://project/us-central1-a/gke-node-0")},
+			provider: providerGKE,
+		},
+		{
+			version:  &k8sVersion.Info{GitVersion: "v1.11.3"},
+			nodes:    []v1.Node{node("")},
+			provider: providerUnknown,
+		},
+	}
+
+	for _, exp := range expectations {
+		t.Run(exp.version.GitVersion+string(exp.provider), func(t *testing.T) {
+			provider := detectClusterProvider(exp.version, exp.nodes)
+			if provider != exp.provider {
+				t.Fatalf("Expected provider [%s] but got [%s]", exp.provider, provider)
+			}
+		})
+	}
+}
+
+func TestValidateGKENodeFirewall(t *testing.T) {
+	node := func(addressTypes ...v1.NodeAddressType) v1.Node {
+		addresses := []v1.NodeAddress{}
+		for _, addressType := range addressTypes {
+			addresses = append(addresses, v1.NodeAddress{Type: addressType})
+		}
+		return v1.Node{Status: v1.NodeStatus{Addresses: addresses}}
+	}
+
+	t.Run("Returns nil if nodes have external IPs", func(t *testing.T) {
+		err := validateGKENodeFirewall([]v1.Node{node(v1.NodeInternalIP, v1.NodeExternalIP)})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error for private clusters", func(t *testing.T) {
+		err := validateGKENodeFirewall([]v1.Node{node(v1.NodeInternalIP), node(v1.NodeInternalIP)})
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+}
+
+func TestValidateEKSNetworking(t *testing.T) {
+	t.Run("Returns nil if the VPC CNI plugin is running", func(t *testing.T) {
+		err := validateEKSNetworking([]string{"aws-node", "kube-proxy"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if a custom CNI plugin is running", func(t *testing.T) {
+		err := validateEKSNetworking([]string{"calico-node", "kube-proxy"})
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+}
+
+func TestValidateAKSAdmission(t *testing.T) {
+	t.Run("Returns nil if admission webhooks are supported", func(t *testing.T) {
+		err := validateAKSAdmission(&k8sVersion.Info{Major: "1", Minor: "11"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if admission webhooks aren't supported", func(t *testing.T) {
+		err := validateAKSAdmission(&k8sVersion.Info{Major: "1", Minor: "9+"})
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "AKS clusters running Kubernetes 1.9 don't support admission webhooks; upgrade to 1.10 or later to use proxy auto-injection"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
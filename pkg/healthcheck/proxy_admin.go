@@ -0,0 +1,150 @@
+package healthcheck
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/api/core/v1"
+)
+
+const (
+	// proxyAdminPortName is the name of the proxy container's port that serves
+	// the proxy's admin endpoints.
+	proxyAdminPortName = "linkerd-metrics"
+
+	// proxyBuildInfoMetric is the metric whose version label reports the
+	// version of the proxy. Older proxies don't serve it.
+	proxyBuildInfoMetric = "proxy_build_info"
+)
+
+func (hc *HealthChecker) addLinkerdProxyAdminChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-proxy-admin/admin-server",
+		category:    LinkerdProxyAdminCategory,
+		description: "data plane proxies' admin servers respond",
+		remediation: "Inspect the proxy containers of the pods whose admin server doesn't respond with `kubectl logs <pod> -c linkerd-proxy`, and restart them",
+		fatal:       false,
+		checkRPC: func() (*healthcheckPb.SelfCheckResponse, error) {
+			pods, err := hc.getMeshedPods()
+			if err != nil {
+				return nil, err
+			}
+
+			rsp := &healthcheckPb.SelfCheckResponse{}
+			for i := range pods {
+				pod := &pods[i]
+				if pod.Status.Phase != v1.PodRunning {
+					// the readiness of the pods that aren't running is reported by
+					// the linkerd-data-plane/proxies-ready check
+					continue
+				}
+
+				result := &healthcheckPb.CheckResult{
+					SubsystemName:    fmt.Sprintf("%s/%s", pod.Namespace, pod.Name),
+					CheckDescription: "proxy admin server is ready",
+					Status:           healthcheckPb.CheckStatus_OK,
+				}
+				if err := hc.checkProxyAdmin(pod); err != nil {
+					result.Status = healthcheckPb.CheckStatus_FAIL
+					result.FriendlyMessageToUser = err.Error()
+				}
+				rsp.Results = append(rsp.Results, result)
+			}
+			return rsp, nil
+		},
+	})
+}
+
+// checkProxyAdmin returns an error if the admin server of the pod's proxy
+// isn't ready, doesn't serve its metrics, or reports a version other than the
+// tag of the proxy's image. A proxy that doesn't serve /ready is only checked
+// for its metrics, which older proxies' readiness probes use instead.
+func (hc *HealthChecker) checkProxyAdmin(pod *v1.Pod) error {
+	port, err := proxyAdminPort(pod)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := hc.getPodProxy(pod.Namespace, pod.Name, port, "/ready")
+	if err != nil {
+		return err
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK && rsp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("The proxy's /ready endpoint responded with %s", rsp.Status)
+	}
+
+	rsp, err = hc.getPodProxy(pod.Namespace, pod.Name, port, "/metrics")
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("The proxy's /metrics endpoint responded with %s", rsp.Status)
+	}
+
+	reported, err := proxyReportedVersion(rsp.Body)
+	if err != nil {
+		return err
+	}
+	return validateProxyReportedVersion(*pod, reported)
+}
+
+// proxyAdminPort returns the number of the port that serves the admin
+// endpoints of the pod's proxy.
+func proxyAdminPort(pod *v1.Pod) (int32, error) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != k8s.ProxyContainerName {
+			continue
+		}
+		for _, port := range container.Ports {
+			if port.Name == proxyAdminPortName {
+				return port.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("The \"%s\" container has no \"%s\" port", k8s.ProxyContainerName, proxyAdminPortName)
+}
+
+// proxyReportedVersion returns the version that a proxy reports in its
+// metrics, in the Prometheus text format, or an empty string if it doesn't
+// report one.
+func proxyReportedVersion(metrics io.Reader) (string, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse the proxy's metrics: %s", err)
+	}
+
+	family, ok := families[proxyBuildInfoMetric]
+	if !ok {
+		return "", nil
+	}
+	for _, metric := range family.GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "version" {
+				return label.GetValue(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// validateProxyReportedVersion returns an error if the version a proxy
+// reports differs from the tag of its image, e.g. because the image was
+// retagged. Proxies that don't report their version pass.
+func validateProxyReportedVersion(pod v1.Pod, reported string) error {
+	if reported == "" {
+		return nil
+	}
+
+	expected, ok := proxyImageTag(pod)
+	if !ok || reported == expected {
+		return nil
+	}
+	return fmt.Errorf("The proxy reports version %s, but its image is tagged %s", reported, expected)
+}
@@ -0,0 +1,118 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestCheckProxyAdmin(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: meta.ObjectMeta{Namespace: "team-a", Name: "payments-5b7d9c8f4-2xkqj"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "linkerd-proxy",
+					Image: "gcr.io/linkerd-io/proxy:stable-2.1.0",
+					Ports: []v1.ContainerPort{{Name: "linkerd-metrics", ContainerPort: 4191}},
+				},
+			},
+		},
+	}
+
+	readyStatus := http.StatusOK
+	metrics := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/namespaces/team-a/pods/payments-5b7d9c8f4-2xkqj:4191/proxy/ready":
+			w.WriteHeader(readyStatus)
+		case "/api/v1/namespaces/team-a/pods/payments-5b7d9c8f4-2xkqj:4191/proxy/metrics":
+			w.Write([]byte(metrics))
+		default:
+			t.Errorf("Unexpected request path: %s", req.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	hc := &HealthChecker{
+		HealthCheckOptions: &HealthCheckOptions{ControlPlaneNamespace: "linkerd"},
+		ctx:                context.Background(),
+		kubeAPI:            &k8s.KubernetesAPI{Config: &rest.Config{Host: server.URL}},
+		httpClient:         server.Client(),
+	}
+
+	testCases := []struct {
+		description string
+		readyStatus int
+		metrics     string
+		expected    string
+	}{
+		{
+			description: "Returns nil if the admin server is ready and reports the proxy's version",
+			readyStatus: http.StatusOK,
+			metrics:     "proxy_build_info{version=\"stable-2.1.0\"} 1\n",
+		},
+		{
+			description: "Returns nil if the proxy doesn't serve /ready nor report its version",
+			readyStatus: http.StatusNotFound,
+			metrics:     "process_start_time_seconds 1.5e+09\n",
+		},
+		{
+			description: "Returns an error if the proxy isn't ready",
+			readyStatus: http.StatusServiceUnavailable,
+			expected:    "The proxy's /ready endpoint responded with 503 Service Unavailable",
+		},
+		{
+			description: "Returns an error if the proxy reports another version",
+			readyStatus: http.StatusOK,
+			metrics:     "proxy_build_info{version=\"stable-2.0.0\"} 1\n",
+			expected:    "The proxy reports version stable-2.0.0, but its image is tagged stable-2.1.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			readyStatus = tc.readyStatus
+			metrics = tc.metrics
+
+			err := hc.checkProxyAdmin(pod)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestProxyAdminPort(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "payments", Ports: []v1.ContainerPort{{Name: "linkerd-metrics", ContainerPort: 8080}}}},
+		},
+	}
+
+	_, err := proxyAdminPort(pod)
+	expected := "The \"linkerd-proxy\" container has no \"linkerd-metrics\" port"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
+
+func TestProxyReportedVersion(t *testing.T) {
+	_, err := proxyReportedVersion(strings.NewReader("proxy_build_info{version=\"stable-2.1.0\" 1\n"))
+	if err == nil {
+		t.Fatal("Expected error, got nothing")
+	}
+}
@@ -0,0 +1,109 @@
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WorkloadProxyConfig compares the hashes of the proxy configurations the
+// pods of a meshed workload were injected with against the hash of the
+// configuration the control plane was installed with. Workloads injected with
+// another configuration keep it until they're re-injected and rolled out.
+type WorkloadProxyConfig struct {
+	Namespace string
+	Kind      string
+	Name      string
+
+	// Hashes are the distinct hashes of the configurations the workload's pods
+	// were injected with, sorted. Pods injected by a CLI that didn't stamp the
+	// hash have an empty one.
+	Hashes []string
+
+	// Outdated is set if any of the workload's pods wasn't injected with the
+	// control plane's current configuration.
+	Outdated bool
+}
+
+// GetProxyConfigHash returns the hash of the proxy configuration the control
+// plane was installed with, or an empty string if the control plane doesn't
+// record it.
+func GetProxyConfigHash(clientset kubernetes.Interface, controlPlaneNamespace string) (string, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(controlPlaneNamespace).Get(k8s.ProxyConfigMapName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return cm.Data[k8s.ProxyConfigHashKey], nil
+}
+
+// DiagnoseProxyConfigs groups the given meshed pods by owner and returns the
+// configuration hashes of each workload, compared against currentHash. The
+// workloads are sorted by namespace, kind and name.
+func DiagnoseProxyConfigs(clientset kubernetes.Interface, currentHash string, pods []v1.Pod) ([]WorkloadProxyConfig, error) {
+	byWorkload := make(map[string]*WorkloadProxyConfig)
+	hashes := make(map[string]map[string]struct{})
+
+	for i := range pods {
+		pod := &pods[i]
+
+		ownerKind, ownerName, err := podOwner(clientset, pod)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.Join([]string{pod.Namespace, ownerKind, ownerName}, "/")
+		workload, ok := byWorkload[key]
+		if !ok {
+			workload = &WorkloadProxyConfig{Namespace: pod.Namespace, Kind: ownerKind, Name: ownerName}
+			byWorkload[key] = workload
+			hashes[key] = make(map[string]struct{})
+		}
+
+		hash := pod.Annotations[k8s.ProxyConfigHashAnnotation]
+		if hash != currentHash {
+			workload.Outdated = true
+		}
+		if _, ok := hashes[key][hash]; !ok {
+			hashes[key][hash] = struct{}{}
+			workload.Hashes = append(workload.Hashes, hash)
+		}
+	}
+
+	keys := make([]string, 0, len(byWorkload))
+	for key := range byWorkload {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	workloads := make([]WorkloadProxyConfig, len(keys))
+	for i, key := range keys {
+		sort.Strings(byWorkload[key].Hashes)
+		workloads[i] = *byWorkload[key]
+	}
+
+	return workloads, nil
+}
+
+func validateProxyConfigs(workloads []WorkloadProxyConfig) error {
+	outdated := []string{}
+	for _, workload := range workloads {
+		if workload.Outdated {
+			outdated = append(outdated, fmt.Sprintf("%s/%s/%s", workload.Namespace, workload.Kind, workload.Name))
+		}
+	}
+
+	if len(outdated) > 0 {
+		return fmt.Errorf("The proxies of %s weren't injected with the control plane's current configuration; re-inject them to roll it out, or run \"linkerd proxy-config\" for details",
+			strings.Join(outdated, ", "))
+	}
+	return nil
+}
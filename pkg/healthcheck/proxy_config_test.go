@@ -0,0 +1,98 @@
+package healthcheck
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetProxyConfigHash(t *testing.T) {
+	hash, err := GetProxyConfigHash(fake.NewSimpleClientset(), "linkerd")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if hash != "" {
+		t.Fatalf("Expected no hash, got [%s]", hash)
+	}
+
+	clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: k8s.ProxyConfigMapName, Namespace: "linkerd"},
+		Data:       map[string]string{k8s.ProxyConfigHashKey: "1a6e45d61c15b879"},
+	})
+	hash, err = GetProxyConfigHash(clientset, "linkerd")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if hash != "1a6e45d61c15b879" {
+		t.Fatalf("Expected hash [1a6e45d61c15b879], got [%s]", hash)
+	}
+}
+
+func TestDiagnoseProxyConfigs(t *testing.T) {
+	pod := func(name, owner, hash string) v1.Pod {
+		pod := v1.Pod{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      name,
+				Namespace: "booksapp",
+			},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []meta.OwnerReference{{Kind: "ReplicaSet", Name: owner}}
+		}
+		if hash != "" {
+			pod.Annotations = map[string]string{k8s.ProxyConfigHashAnnotation: hash}
+		}
+		return pod
+	}
+
+	clientset := fake.NewSimpleClientset(&appsV1.ReplicaSet{
+		ObjectMeta: meta.ObjectMeta{
+			Name:            "web-5b8c8b6b7c",
+			Namespace:       "booksapp",
+			OwnerReferences: []meta.OwnerReference{{Kind: "Deployment", Name: "web"}},
+		},
+	})
+
+	workloads, err := DiagnoseProxyConfigs(clientset, "current", []v1.Pod{
+		pod("web-5b8c8b6b7c-x2p4d", "web-5b8c8b6b7c", "current"),
+		pod("web-5b8c8b6b7c-9kd2f", "web-5b8c8b6b7c", "old"),
+		pod("books-7d6b9c5f4-abcde", "books-7d6b9c5f4", "current"),
+		pod("authors", "", ""),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []WorkloadProxyConfig{
+		{Namespace: "booksapp", Kind: "deployment", Name: "web", Hashes: []string{"current", "old"}, Outdated: true},
+		{Namespace: "booksapp", Kind: "pod", Name: "authors", Hashes: []string{""}, Outdated: true},
+		{Namespace: "booksapp", Kind: "replicaset", Name: "books-7d6b9c5f4", Hashes: []string{"current"}, Outdated: false},
+	}
+	if !reflect.DeepEqual(workloads, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, workloads)
+	}
+}
+
+func TestValidateProxyConfigs(t *testing.T) {
+	err := validateProxyConfigs([]WorkloadProxyConfig{
+		{Namespace: "booksapp", Kind: "deployment", Name: "books", Hashes: []string{"current"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = validateProxyConfigs([]WorkloadProxyConfig{
+		{Namespace: "booksapp", Kind: "deployment", Name: "books", Hashes: []string{"current"}},
+		{Namespace: "booksapp", Kind: "deployment", Name: "web", Hashes: []string{"old"}, Outdated: true},
+		{Namespace: "booksapp", Kind: "pod", Name: "authors", Hashes: []string{""}, Outdated: true},
+	})
+	expected := "The proxies of booksapp/deployment/web, booksapp/pod/authors weren't injected with the control plane's current configuration; re-inject them to roll it out, or run \"linkerd proxy-config\" for details"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
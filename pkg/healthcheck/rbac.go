@@ -0,0 +1,218 @@
+package healthcheck
+
+import (
+	"fmt"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	authorizationapi "k8s.io/api/authorization/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The ServiceAccounts the control plane's ClusterRoles are bound to.
+const (
+	controllerServiceAccount = "linkerd-controller"
+	prometheusServiceAccount = "linkerd-prometheus"
+	caServiceAccount         = "linkerd-ca"
+)
+
+// runtimePermission is a permission that one of the control plane's
+// ClusterRoles grants its ServiceAccount on a resource, cluster-wide.
+type runtimePermission struct {
+	serviceAccount string
+	group          string
+	version        string
+	resource       string
+	verbs          []string
+
+	// name restricts the permission to the resource with that name, if set
+	name string
+}
+
+var readVerbs = []string{"get", "list", "watch"}
+
+// controlPlaneRuntimePermissions are the permissions of the ClusterRoles that
+// `linkerd install` creates. Kubernetes only lets a user create a ClusterRole
+// that grants permissions the user already has, so the installation fails
+// part way through if the caller is missing any of them. The permissions of
+// the CA's ServiceAccount are only needed with TLS enabled.
+var controlPlaneRuntimePermissions = []runtimePermission{
+	{serviceAccount: controllerServiceAccount, group: "apps", version: "v1", resource: "deployments", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "apps", version: "v1", resource: "replicasets", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "extensions", version: "v1beta1", resource: "deployments", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "extensions", version: "v1beta1", resource: "replicasets", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "", version: "v1", resource: "pods", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "", version: "v1", resource: "endpoints", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "", version: "v1", resource: "services", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "", version: "v1", resource: "namespaces", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "", version: "v1", resource: "replicationcontrollers", verbs: readVerbs},
+	{serviceAccount: controllerServiceAccount, group: "", version: "v1", resource: "configmaps", verbs: readVerbs},
+	{serviceAccount: prometheusServiceAccount, group: "", version: "v1", resource: "pods", verbs: readVerbs},
+	{serviceAccount: caServiceAccount, group: "", version: "v1", resource: "pods", verbs: readVerbs},
+	{serviceAccount: caServiceAccount, group: "apps", version: "v1", resource: "replicasets", verbs: readVerbs},
+	{serviceAccount: caServiceAccount, group: "extensions", version: "v1beta1", resource: "replicasets", verbs: readVerbs},
+	{serviceAccount: caServiceAccount, group: "", version: "v1", resource: "configmaps", verbs: []string{"update"}, name: k8s.TLSTrustAnchorConfigMapName},
+	{serviceAccount: caServiceAccount, group: "", version: "v1", resource: "configmaps", verbs: []string{"get", "update"}, name: "linkerd-ca-leader"},
+	{serviceAccount: caServiceAccount, group: "", version: "v1", resource: "secrets", verbs: []string{"update"}},
+	{serviceAccount: caServiceAccount, group: "", version: "v1", resource: "events", verbs: []string{"patch"}},
+}
+
+// addRuntimePermissionCheck adds a check that the caller can perform the verb
+// on all the resources the control plane's ClusterRoles grant it for. With
+// the CheckServiceAccountPermissions option, the check is run as each of the
+// control plane's ServiceAccounts instead, for the permissions their own
+// ClusterRole grants. The check is a warning if only the CA needs the verb,
+// since the CA is only installed with TLS enabled.
+func (hc *HealthChecker) addRuntimePermissionCheck(verb string) {
+	permissions := runtimePermissionsFor(verb)
+
+	description := fmt.Sprintf("can %s the resources the control plane uses", verb)
+	remediation := fmt.Sprintf("Grant the current user cluster-wide permission to %s the resources the control plane's ClusterRoles grant, e.g. by binding it to the cluster-admin ClusterRole", verb)
+	if hc.CheckServiceAccountPermissions {
+		description = fmt.Sprintf("control plane ServiceAccounts can %s the resources they use", verb)
+		remediation = "Apply the control plane's ClusterRoles and ClusterRoleBindings, and make sure no authorization policy of the cluster restricts its ServiceAccounts"
+	}
+
+	severity := SeverityError
+	if tlsOnly(permissions) {
+		description = fmt.Sprintf("can %s the resources the CA uses", verb)
+		remediation = fmt.Sprintf("Grant the current user cluster-wide permission to %s the resources the CA's ClusterRole grants, or install without --tls", verb)
+		if hc.CheckServiceAccountPermissions {
+			description = fmt.Sprintf("CA ServiceAccount can %s the resources it uses", verb)
+			remediation = "Apply the CA's ClusterRole and ClusterRoleBinding, or install without --tls"
+		}
+		severity = SeverityWarning
+	}
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "kubernetes-setup/runtime-" + verb,
+		category:    LinkerdPreInstallCategory,
+		description: description,
+		remediation: remediation,
+		fatal:       false,
+		severity:    severity,
+		check: func() error {
+			if hc.CheckServiceAccountPermissions {
+				return hc.checkServiceAccountPermissions(verb, permissions)
+			}
+
+			for _, permission := range permissions {
+				if err := hc.checkCanPerformOn(verb, "", permission.group, permission.version, permission.resource, permission.name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// checkServiceAccountPermissions returns an error if one of the control
+// plane's ServiceAccounts isn't allowed to perform the verb as its
+// permissions say, or if it doesn't exist. The CA's ServiceAccount is
+// ignored if it doesn't exist, since it's only installed with TLS enabled.
+func (hc *HealthChecker) checkServiceAccountPermissions(verb string, permissions []runtimePermission) error {
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return err
+	}
+
+	exists := make(map[string]bool)
+	for _, permission := range permissions {
+		found, checked := exists[permission.serviceAccount]
+		if !checked {
+			_, err := clientset.CoreV1().ServiceAccounts(hc.ControlPlaneNamespace).Get(permission.serviceAccount, metav1.GetOptions{})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+			found = err == nil
+			exists[permission.serviceAccount] = found
+		}
+
+		if !found {
+			if permission.serviceAccount == caServiceAccount {
+				continue
+			}
+			return fmt.Errorf("The \"%s/%s\" ServiceAccount doesn't exist; its permissions can only be checked once the control plane's RBAC is applied",
+				hc.ControlPlaneNamespace, permission.serviceAccount)
+		}
+
+		if err := hc.checkServiceAccountCanPerform(verb, permission); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkServiceAccountCanPerform returns an error if the permission's
+// ServiceAccount isn't allowed to perform the verb on the permission's
+// resource, according to a SubjectAccessReview.
+func (hc *HealthChecker) checkServiceAccountCanPerform(verb string, permission runtimePermission) error {
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return err
+	}
+
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Verb:     verb,
+				Group:    permission.group,
+				Version:  permission.version,
+				Resource: permission.resource,
+				Name:     permission.name,
+			},
+			User:   serviceAccountUser(hc.ControlPlaneNamespace, permission.serviceAccount),
+			Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + hc.ControlPlaneNamespace},
+		},
+	}
+
+	response, err := clientset.AuthorizationV1beta1().SubjectAccessReviews().Create(sar)
+	if err != nil {
+		return err
+	}
+
+	if !response.Status.Allowed {
+		resource := permission.resource
+		if permission.name != "" {
+			resource = fmt.Sprintf("the \"%s\" %s", permission.name, permission.resource)
+		}
+		if len(response.Status.Reason) > 0 {
+			return fmt.Errorf("The \"%s/%s\" ServiceAccount is missing permissions to %s %s: %v",
+				hc.ControlPlaneNamespace, permission.serviceAccount, verb, resource, response.Status.Reason)
+		}
+		return fmt.Errorf("The \"%s/%s\" ServiceAccount is missing permissions to %s %s",
+			hc.ControlPlaneNamespace, permission.serviceAccount, verb, resource)
+	}
+	return nil
+}
+
+// serviceAccountUser returns the name of the user that the API server
+// authenticates a ServiceAccount's token as.
+func serviceAccountUser(namespace, serviceAccount string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+}
+
+// runtimePermissionsFor returns the runtime permissions that include the
+// verb.
+func runtimePermissionsFor(verb string) []runtimePermission {
+	permissions := []runtimePermission{}
+	for _, permission := range controlPlaneRuntimePermissions {
+		for _, v := range permission.verbs {
+			if v == verb {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+// tlsOnly returns true if all the permissions are only needed with TLS
+// enabled.
+func tlsOnly(permissions []runtimePermission) bool {
+	for _, permission := range permissions {
+		if permission.serviceAccount != caServiceAccount {
+			return false
+		}
+	}
+	return len(permissions) > 0
+}
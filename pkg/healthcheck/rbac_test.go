@@ -0,0 +1,57 @@
+package healthcheck
+
+import (
+	"testing"
+)
+
+func TestRuntimePermissionsFor(t *testing.T) {
+	testCases := []struct {
+		verb      string
+		resources []string
+		tlsOnly   bool
+	}{
+		{
+			verb:      "watch",
+			resources: []string{"deployments", "replicasets", "deployments", "replicasets", "pods", "endpoints", "services", "namespaces", "replicationcontrollers", "configmaps", "pods", "pods", "replicasets", "replicasets"},
+			tlsOnly:   false,
+		},
+		{
+			verb:      "update",
+			resources: []string{"configmaps", "configmaps", "secrets"},
+			tlsOnly:   true,
+		},
+		{
+			verb:      "patch",
+			resources: []string{"events"},
+			tlsOnly:   true,
+		},
+		{
+			verb:      "delete",
+			resources: []string{},
+			tlsOnly:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.verb, func(t *testing.T) {
+			permissions := runtimePermissionsFor(tc.verb)
+
+			resources := []string{}
+			for _, permission := range permissions {
+				resources = append(resources, permission.resource)
+			}
+			if len(resources) != len(tc.resources) {
+				t.Fatalf("Expected resources %v, got %v", tc.resources, resources)
+			}
+			for i := range resources {
+				if resources[i] != tc.resources[i] {
+					t.Fatalf("Expected resources %v, got %v", tc.resources, resources)
+				}
+			}
+
+			if tlsOnly(permissions) != tc.tlsOnly {
+				t.Fatalf("Expected tlsOnly to be %t for %s", tc.tlsOnly, tc.verb)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// The statuses of the checks in a Report.
+const (
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusInfo    = "info"
+	StatusError   = "error"
+)
+
+// Report is a machine-readable summary of a run of checks, so that callers
+// such as CI jobs can tell the outcome of each check without parsing the
+// output of the CLI.
+type Report struct {
+	Success bool            `json:"success"`
+	Results []*ReportResult `json:"results"`
+}
+
+// ReportResult is the final result of a check in a Report.
+type ReportResult struct {
+	ID          string `json:"id,omitempty"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	Hint        *Hint  `json:"hint,omitempty"`
+	Retries     int    `json:"retries"`
+	DurationMs  int64  `json:"durationMs"`
+}
+
+// NewReport returns the report of the given check results. The results of
+// the attempts that were retried are left out, so that each check is
+// reported once. Like RunChecks, the report is successful unless a check with
+// a blocking severity failed.
+func NewReport(results []*CheckResult) *Report {
+	report := &Report{
+		Success: true,
+		Results: make([]*ReportResult, 0),
+	}
+
+	for _, result := range results {
+		if result.Retry {
+			continue
+		}
+
+		entry := &ReportResult{
+			ID:          result.ID,
+			Category:    result.Category,
+			Description: result.Description,
+			Status:      StatusOK,
+			Retries:     result.Retries,
+			DurationMs:  int64(result.Duration / time.Millisecond),
+		}
+		if result.Err != nil {
+			switch result.Severity {
+			case SeverityWarning:
+				entry.Status = StatusWarning
+			case SeverityInfo:
+				entry.Status = StatusInfo
+			default:
+				entry.Status = StatusError
+			}
+			if result.Severity.Blocking() {
+				report.Success = false
+			}
+			entry.Error = result.Err.Error()
+			entry.Hint = result.Hint
+		}
+		report.Results = append(report.Results, entry)
+	}
+
+	return report
+}
+
+// RunChecksJSON runs all configured checkers like RunChecks, and writes their
+// report to w as JSON once they have all run. It returns whether the checks
+// were successful, along with the error met while writing the report, if any.
+func (hc *HealthChecker) RunChecksJSON(ctx context.Context, w io.Writer) (bool, error) {
+	results := hc.RunChecks(ctx, nil)
+
+	b, err := json.MarshalIndent(NewReport(results.Checks), "", "  ")
+	if err != nil {
+		return results.Success, err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return results.Success, err
+}
@@ -0,0 +1,110 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewReport(t *testing.T) {
+	results := []*CheckResult{
+		{Category: "cat1", Description: "desc1", Duration: 12 * time.Millisecond},
+		{Category: "cat2", Description: "desc2", Err: fmt.Errorf("retry"), Retry: true},
+		{Category: "cat2", Description: "desc2", Retries: 1, Duration: 5 * time.Second},
+		{Category: "cat3", Description: "desc3", Err: fmt.Errorf("warning"), Severity: SeverityWarning},
+		{Category: "cat4", Description: "desc4", Err: fmt.Errorf("info"), Severity: SeverityInfo, Hint: &Hint{Remediation: "fix desc4"}},
+	}
+
+	t.Run("Reports the final result of each check", func(t *testing.T) {
+		expected := &Report{
+			Success: true,
+			Results: []*ReportResult{
+				{Category: "cat1", Description: "desc1", Status: StatusOK, DurationMs: 12},
+				{Category: "cat2", Description: "desc2", Status: StatusOK, Retries: 1, DurationMs: 5000},
+				{Category: "cat3", Description: "desc3", Status: StatusWarning, Error: "warning"},
+				{Category: "cat4", Description: "desc4", Status: StatusInfo, Error: "info", Hint: &Hint{Remediation: "fix desc4"}},
+			},
+		}
+
+		report := NewReport(results)
+		if !reflect.DeepEqual(report, expected) {
+			t.Fatalf("Expected report %+v, got %+v", expected, report)
+		}
+	})
+
+	t.Run("Fails if a check with an error severity failed", func(t *testing.T) {
+		failed := append(results, &CheckResult{Category: "cat5", Description: "desc5", Err: fmt.Errorf("error")})
+
+		report := NewReport(failed)
+		if report.Success {
+			t.Fatalf("Expected the report to fail")
+		}
+		if status := report.Results[4].Status; status != StatusError {
+			t.Fatalf("Expected status %s, got %s", StatusError, status)
+		}
+	})
+}
+
+func TestRunChecksJSON(t *testing.T) {
+	hc := HealthChecker{
+		checkers: []*checker{
+			{
+				category:    "cat1",
+				description: "desc1",
+				check: func() error {
+					return nil
+				},
+			},
+			{
+				id:          "cat2/fail",
+				category:    "cat2",
+				description: "desc2",
+				remediation: "fix desc2",
+				check: func() error {
+					return fmt.Errorf("error")
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	success, err := hc.RunChecksJSON(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if success {
+		t.Fatalf("Expected the checks to fail")
+	}
+
+	expected := `{
+  "success": false,
+  "results": [
+    {
+      "category": "cat1",
+      "description": "desc1",
+      "status": "ok",
+      "retries": 0,
+      "durationMs": 0
+    },
+    {
+      "id": "cat2/fail",
+      "category": "cat2",
+      "description": "desc2",
+      "status": "error",
+      "error": "error",
+      "hint": {
+        "remediation": "fix desc2"
+      },
+      "retries": 0,
+      "durationMs": 0
+    }
+  ]
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,75 @@
+package healthcheck
+
+import (
+	"time"
+)
+
+// Counts is the number of checks that passed and failed.
+type Counts struct {
+	Passed int
+	Failed int
+}
+
+// Results is the summary of a run of checks returned by RunChecks, so that
+// callers don't have to accumulate the results passed to the observer.
+type Results struct {
+	// Success is false if a check with a blocking severity failed, or if the
+	// checks were interrupted.
+	Success bool
+
+	// Checks are all the results passed to the observer, in order, including
+	// the results of the attempts that were retried.
+	Checks []*CheckResult
+
+	// ByCategory and BySeverity count the final result of each check by its
+	// category and by its severity.
+	ByCategory map[string]Counts
+	BySeverity map[Severity]Counts
+
+	// StartTime is when the checks started, and Duration the time spent
+	// running them.
+	StartTime time.Time
+	Duration  time.Duration
+
+	// FatalFailure is the result of the fatal check whose failure skipped the
+	// remaining checks, if any.
+	FatalFailure *CheckResult
+}
+
+func newResults() *Results {
+	return &Results{
+		Success:    true,
+		StartTime:  time.Now(),
+		Checks:     make([]*CheckResult, 0),
+		ByCategory: make(map[string]Counts),
+		BySeverity: make(map[Severity]Counts),
+	}
+}
+
+// add records a result passed to the observer.
+func (r *Results) add(result *CheckResult) {
+	r.Checks = append(r.Checks, result)
+	if result.Retry {
+		return
+	}
+
+	category := r.ByCategory[result.Category]
+	severity := r.BySeverity[result.Severity]
+	if result.Err != nil {
+		category.Failed++
+		severity.Failed++
+	} else {
+		category.Passed++
+		severity.Passed++
+	}
+	r.ByCategory[result.Category] = category
+	r.BySeverity[result.Severity] = severity
+}
+
+// last returns the latest result passed to the observer.
+func (r *Results) last() *CheckResult {
+	if len(r.Checks) == 0 {
+		return nil
+	}
+	return r.Checks[len(r.Checks)-1]
+}
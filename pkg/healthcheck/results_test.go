@@ -0,0 +1,103 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunChecksResults(t *testing.T) {
+	defaultRetryInitialInterval = 0
+
+	attempts := 0
+	hc := HealthChecker{
+		checkers: []*checker{
+			{
+				category:    "cat1",
+				description: "desc1",
+				check: func() error {
+					return nil
+				},
+			},
+			{
+				category:      "cat1",
+				description:   "desc2",
+				retryDeadline: time.Now().Add(time.Minute),
+				check: func() error {
+					attempts++
+					if attempts < 2 {
+						return fmt.Errorf("retry")
+					}
+					return nil
+				},
+			},
+			{
+				category:    "cat2",
+				description: "desc3",
+				severity:    SeverityWarning,
+				check: func() error {
+					return fmt.Errorf("warning")
+				},
+			},
+			{
+				category:    "cat3",
+				description: "desc4",
+				fatal:       true,
+				check: func() error {
+					return fmt.Errorf("fatal")
+				},
+			},
+			{
+				category:    "cat4",
+				description: "desc5",
+				check: func() error {
+					return nil
+				},
+			},
+		},
+	}
+
+	results := hc.RunChecks(context.Background(), nil)
+
+	if results.Success {
+		t.Fatalf("Expected the checks to fail")
+	}
+	if len(results.Checks) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(results.Checks))
+	}
+	if !results.Checks[1].Retry {
+		t.Fatalf("Expected the retried attempt to be recorded")
+	}
+
+	expectedByCategory := map[string]Counts{
+		"cat1": {Passed: 2},
+		"cat2": {Failed: 1},
+		"cat3": {Failed: 1},
+	}
+	if !reflect.DeepEqual(results.ByCategory, expectedByCategory) {
+		t.Fatalf("Expected counts by category %+v, got %+v", expectedByCategory, results.ByCategory)
+	}
+
+	expectedBySeverity := map[Severity]Counts{
+		SeverityError:   {Passed: 2, Failed: 1},
+		SeverityWarning: {Failed: 1},
+	}
+	if !reflect.DeepEqual(results.BySeverity, expectedBySeverity) {
+		t.Fatalf("Expected counts by severity %+v, got %+v", expectedBySeverity, results.BySeverity)
+	}
+
+	for i, result := range results.Checks {
+		if result.StartTime.Before(results.StartTime) || result.StartTime.Add(result.Duration).After(results.StartTime.Add(results.Duration)) {
+			t.Fatalf("Expected result %d to be timed within the run, got %s for %s", i, result.StartTime, result.Duration)
+		}
+	}
+	if !results.Checks[2].StartTime.Equal(results.Checks[1].StartTime) {
+		t.Fatalf("Expected the retried check to keep the start time of its first attempt")
+	}
+
+	if results.FatalFailure == nil || results.FatalFailure.Description != "desc4" {
+		t.Fatalf("Expected the fatal failure of desc4, got %+v", results.FatalFailure)
+	}
+}
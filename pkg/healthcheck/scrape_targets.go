@@ -0,0 +1,114 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+const (
+	// prometheusTargetsPath is the path of Prometheus's targets API.
+	prometheusTargetsPath = "/api/v1/targets"
+
+	// proxyScrapeJob is the job of the Prometheus scrape config that scrapes
+	// the proxies.
+	proxyScrapeJob = "linkerd-proxy"
+)
+
+// prometheusTarget is an active target of Prometheus's targets API.
+type prometheusTarget struct {
+	Labels    map[string]string `json:"labels"`
+	Health    string            `json:"health"`
+	LastError string            `json:"lastError"`
+}
+
+// getProxyScrapeTargets returns the targets of the control plane Prometheus
+// that scrape the proxies.
+func (hc *HealthChecker) getProxyScrapeTargets() ([]prometheusTarget, error) {
+	rsp, err := hc.getServiceProxy("prometheus", "admin-http", prometheusTargetsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected response from the Prometheus targets API: %s", rsp.Status)
+	}
+
+	return parseProxyScrapeTargets(rsp.Body)
+}
+
+// parseProxyScrapeTargets returns the active targets of the proxy scrape job
+// in a response of Prometheus's targets API.
+func parseProxyScrapeTargets(body io.Reader) ([]prometheusTarget, error) {
+	var response struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ActiveTargets []prometheusTarget `json:"activeTargets"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("Failed to parse the response of the Prometheus targets API: %s", err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("The Prometheus targets API failed: %s", response.Error)
+	}
+
+	targets := []prometheusTarget{}
+	for _, target := range response.Data.ActiveTargets {
+		if target.Labels["job"] == proxyScrapeJob {
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// validateScrapeTargets returns an error listing the pods whose proxy
+// Prometheus hasn't discovered, and the ones whose proxy it discovered but
+// fails to scrape, along with the scrape error. The pods' names are prefixed
+// with their namespace, as returned by the public API.
+func validateScrapeTargets(pods []*pb.Pod, targets []prometheusTarget) error {
+	targetsByPod := make(map[string]prometheusTarget)
+	for _, target := range targets {
+		targetsByPod[target.Labels["namespace"]+"/"+target.Labels["pod"]] = target
+	}
+
+	undiscovered := []string{}
+	failing := []string{}
+	for _, pod := range pods {
+		target, ok := targetsByPod[pod.Name]
+		if !ok {
+			undiscovered = append(undiscovered, pod.Name)
+			continue
+		}
+		if target.Health == "up" {
+			continue
+		}
+
+		lastError := target.LastError
+		if lastError == "" {
+			lastError = "not scraped yet"
+		}
+		failing = append(failing, fmt.Sprintf("%s (%s)", pod.Name, lastError))
+	}
+	sort.Strings(undiscovered)
+	sort.Strings(failing)
+
+	problems := []string{}
+	if len(undiscovered) > 0 {
+		problems = append(problems, fmt.Sprintf("Prometheus hasn't discovered the proxies of %s", strings.Join(undiscovered, ", ")))
+	}
+	if len(failing) > 0 {
+		problems = append(problems, fmt.Sprintf("Prometheus fails to scrape the proxies of %s", strings.Join(failing, ", ")))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package healthcheck
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestParseProxyScrapeTargets(t *testing.T) {
+	t.Run("Returns the targets of the proxy scrape job", func(t *testing.T) {
+		body := `{
+  "status": "success",
+  "data": {
+    "activeTargets": [
+      {"labels": {"job": "linkerd-controller", "namespace": "linkerd", "pod": "controller-5b7d9c8f4-2xkqj"}, "health": "up", "lastError": ""},
+      {"labels": {"job": "linkerd-proxy", "namespace": "emojivoto", "pod": "web-6b8c7f5d9-ztq4n"}, "health": "down", "lastError": "connection refused"}
+    ]
+  }
+}`
+
+		targets, err := parseProxyScrapeTargets(strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(targets) != 1 {
+			t.Fatalf("Expected 1 target, got %d", len(targets))
+		}
+		if targets[0].Labels["pod"] != "web-6b8c7f5d9-ztq4n" || targets[0].Health != "down" || targets[0].LastError != "connection refused" {
+			t.Fatalf("Unexpected target: %+v", targets[0])
+		}
+	})
+
+	t.Run("Returns an error if the API failed", func(t *testing.T) {
+		_, err := parseProxyScrapeTargets(strings.NewReader(`{"status": "error", "error": "unavailable"}`))
+		expected := "The Prometheus targets API failed: unavailable"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
+
+func TestValidateScrapeTargets(t *testing.T) {
+	target := func(namespace, pod, health, lastError string) prometheusTarget {
+		return prometheusTarget{
+			Labels:    map[string]string{"job": proxyScrapeJob, "namespace": namespace, "pod": pod},
+			Health:    health,
+			LastError: lastError,
+		}
+	}
+
+	pods := []*pb.Pod{
+		{Name: "emojivoto/web-6b8c7f5d9-ztq4n"},
+		{Name: "emojivoto/voting-7c5d8b9f6-j2m8x"},
+		{Name: "emojivoto/emoji-5f6d7c8b4-q9w2e"},
+	}
+
+	testCases := []struct {
+		description string
+		targets     []prometheusTarget
+		expected    string
+	}{
+		{
+			description: "Returns nil if all the proxies are scraped",
+			targets: []prometheusTarget{
+				target("emojivoto", "web-6b8c7f5d9-ztq4n", "up", ""),
+				target("emojivoto", "voting-7c5d8b9f6-j2m8x", "up", ""),
+				target("emojivoto", "emoji-5f6d7c8b4-q9w2e", "up", ""),
+			},
+		},
+		{
+			description: "Tells undiscovered proxies apart from failing scrapes",
+			targets: []prometheusTarget{
+				target("emojivoto", "web-6b8c7f5d9-ztq4n", "down", "context deadline exceeded"),
+				target("emojivoto", "voting-7c5d8b9f6-j2m8x", "unknown", ""),
+			},
+			expected: "Prometheus hasn't discovered the proxies of emojivoto/emoji-5f6d7c8b4-q9w2e; Prometheus fails to scrape the proxies of emojivoto/voting-7c5d8b9f6-j2m8x (not scraped yet), emojivoto/web-6b8c7f5d9-ztq4n (context deadline exceeded)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := validateScrapeTargets(pods, tc.targets)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
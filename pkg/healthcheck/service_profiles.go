@@ -0,0 +1,90 @@
+package healthcheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serviceProfileResource is the resource name of ServiceProfiles, as used in
+// RBAC rules.
+const serviceProfileResource = "serviceprofiles"
+
+func (hc *HealthChecker) addLinkerdServiceProfileChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-service-profiles/crd-exists",
+		category:    LinkerdServiceProfileCategory,
+		description: "ServiceProfile CRD is installed",
+		remediation: "Install the ServiceProfile CRD, which `linkerd install` outputs, with `linkerd install | kubectl apply -f -`",
+		fatal:       true,
+		check: func() error {
+			resources, err := hc.getLinkerdAPIResources()
+			if err != nil {
+				return err
+			}
+			return validateCustomResourceExists(resources, serviceProfileCustomResource)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-service-profiles/crd-version",
+		category:    LinkerdServiceProfileCategory,
+		description: "ServiceProfile CRD serves the expected version",
+		remediation: "Upgrade the ServiceProfile CRD along with the control plane with `linkerd upgrade | kubectl apply -f -`",
+		fatal:       false,
+		check: func() error {
+			resources, err := hc.getLinkerdAPIResources()
+			if err != nil {
+				return err
+			}
+			return validateCustomResource(resources, serviceProfileCustomResource)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-service-profiles/list",
+		category:    LinkerdServiceProfileCategory,
+		description: "can list ServiceProfiles",
+		remediation: "Grant the current user permission to list ServiceProfiles in the control plane namespace",
+		fatal:       false,
+		check: func() error {
+			return hc.checkCanServiceProfiles("list")
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-service-profiles/create",
+		category:    LinkerdServiceProfileCategory,
+		description: "can create ServiceProfiles",
+		remediation: "Grant the current user permission to create ServiceProfiles in the control plane namespace",
+		fatal:       false,
+		severity:    SeverityWarning,
+		check: func() error {
+			return hc.checkCanServiceProfiles("create")
+		},
+	})
+}
+
+// checkCanServiceProfiles returns an error if the caller isn't allowed to
+// perform the verb on the ServiceProfiles of the control plane namespace,
+// which is where the control plane reads them from.
+func (hc *HealthChecker) checkCanServiceProfiles(verb string) error {
+	version := strings.TrimPrefix(profiles.ServiceProfileAPIVersion, linkerdAPIGroup+"/")
+	return hc.checkCanPerform(verb, hc.ControlPlaneNamespace, linkerdAPIGroup, version, serviceProfileResource)
+}
+
+// validateCustomResourceExists returns an error if none of the versions
+// served in Linkerd's API group, keyed by group version, has the expected
+// kind, i.e. if its CRD isn't installed.
+func validateCustomResourceExists(resources map[string][]metav1.APIResource, expected expectedCustomResource) error {
+	for _, kinds := range resources {
+		for _, resource := range kinds {
+			if resource.Kind == expected.kind {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("The %s CRD isn't installed, so %ss can't be read or created", expected.kind, expected.kind)
+}
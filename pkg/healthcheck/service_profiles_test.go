@@ -0,0 +1,42 @@
+package healthcheck
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateCustomResourceExists(t *testing.T) {
+	t.Run("Returns nil if any version serves the kind", func(t *testing.T) {
+		err := validateCustomResourceExists(map[string][]metav1.APIResource{
+			"linkerd.io/v1alpha2": []metav1.APIResource{
+				metav1.APIResource{Name: "serviceprofiles", Kind: "ServiceProfile"},
+			},
+		}, serviceProfileCustomResource)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if Linkerd's API group isn't served", func(t *testing.T) {
+		err := validateCustomResourceExists(map[string][]metav1.APIResource{}, serviceProfileCustomResource)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "The ServiceProfile CRD isn't installed, so ServiceProfiles can't be read or created"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if no version serves the kind", func(t *testing.T) {
+		err := validateCustomResourceExists(map[string][]metav1.APIResource{
+			"linkerd.io/v1alpha1": []metav1.APIResource{
+				metav1.APIResource{Name: "trafficsplits", Kind: "TrafficSplit"},
+			},
+		}, serviceProfileCustomResource)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+}
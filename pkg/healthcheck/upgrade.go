@@ -0,0 +1,143 @@
+package healthcheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// controllerDeployment is the control plane deployment whose CreatedByAnnotation
+// records the version of the CLI that installed the control plane.
+const controllerDeployment = "controller"
+
+func (hc *HealthChecker) addLinkerdPreUpgradeChecks() {
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-upgrade/namespace",
+		category:    LinkerdPreUpgradeCategory,
+		description: "control plane namespace exists",
+		remediation: "Install the control plane with `linkerd install | kubectl apply -f -`, or set its namespace with --linkerd-namespace",
+		fatal:       true,
+		check: func() error {
+			return hc.checkNamespace(hc.ControlPlaneNamespace)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-upgrade/version-skew",
+		category:    LinkerdPreUpgradeCategory,
+		description: "control plane can be upgraded to the CLI's version",
+		remediation: "Upgrade with the CLI of each minor version in turn, from the same release channel as the control plane",
+		fatal:       false,
+		check: func() error {
+			installed, err := hc.installedVersion()
+			if err != nil {
+				return err
+			}
+			return version.CheckUpgradeVersion(installed, version.Version)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-upgrade/update-deployments",
+		category:    LinkerdPreUpgradeCategory,
+		description: "can update Deployments",
+		remediation: "Grant the current user permission to update Deployments in the control plane namespace",
+		fatal:       true,
+		check: func() error {
+			return hc.checkCanPerform("update", hc.ControlPlaneNamespace, "apps", "v1", "deployments")
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-upgrade/update-configmaps",
+		category:    LinkerdPreUpgradeCategory,
+		description: "can update ConfigMaps",
+		remediation: "Grant the current user permission to update ConfigMaps in the control plane namespace",
+		fatal:       true,
+		check: func() error {
+			return hc.checkCanPerform("update", hc.ControlPlaneNamespace, "", "v1", "configmaps")
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-upgrade/update-services",
+		category:    LinkerdPreUpgradeCategory,
+		description: "can update Services",
+		remediation: "Grant the current user permission to update Services in the control plane namespace",
+		fatal:       true,
+		check: func() error {
+			return hc.checkCanPerform("update", hc.ControlPlaneNamespace, "", "v1", "services")
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		id:          "linkerd-upgrade/no-rollouts",
+		category:    LinkerdPreUpgradeCategory,
+		description: "control plane deployments aren't being rolled out",
+		remediation: fmt.Sprintf("Wait for the rollouts to complete, e.g. with `kubectl -n %s rollout status deploy`", hc.ControlPlaneNamespace),
+		fatal:       false,
+		check: func() error {
+			clientset, err := hc.getClientset()
+			if err != nil {
+				return err
+			}
+
+			deployments, err := clientset.AppsV1().Deployments(hc.ControlPlaneNamespace).List(metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			return validateNoRollouts(deployments.Items)
+		},
+	})
+}
+
+// installedVersion returns the version of the CLI that installed or last
+// upgraded the control plane.
+func (hc *HealthChecker) installedVersion() (string, error) {
+	clientset, err := hc.getClientset()
+	if err != nil {
+		return "", err
+	}
+
+	deploy, err := clientset.AppsV1().Deployments(hc.ControlPlaneNamespace).Get(controllerDeployment, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return parseCreatedBy(deploy.Annotations[k8s.CreatedByAnnotation])
+}
+
+// parseCreatedBy returns the version recorded in a CreatedByAnnotation value,
+// e.g. "stable-2.1.0" for "linkerd/cli stable-2.1.0".
+func parseCreatedBy(createdBy string) (string, error) {
+	parts := strings.Fields(createdBy)
+	if len(parts) != 2 || parts[0] != "linkerd/cli" {
+		return "", fmt.Errorf("The \"%s\" deployment doesn't record the version it was installed with", controllerDeployment)
+	}
+	return parts[1], nil
+}
+
+// validateNoRollouts returns an error if one of the deployments is being
+// rolled out, i.e. some of its pods don't run its latest template yet.
+func validateNoRollouts(deployments []appsv1.Deployment) error {
+	for _, deploy := range deployments {
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+
+		status := deploy.Status
+		if status.ObservedGeneration < deploy.Generation ||
+			status.UpdatedReplicas < replicas ||
+			status.Replicas > status.UpdatedReplicas {
+			return fmt.Errorf("The \"%s\" deployment is being rolled out: %d of %d pods are up-to-date",
+				deploy.Name, status.UpdatedReplicas, replicas)
+		}
+	}
+	return nil
+}
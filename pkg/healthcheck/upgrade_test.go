@@ -0,0 +1,65 @@
+package healthcheck
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseCreatedBy(t *testing.T) {
+	t.Run("Returns the version of the CLI", func(t *testing.T) {
+		version, err := parseCreatedBy("linkerd/cli stable-2.1.0")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if version != "stable-2.1.0" {
+			t.Fatalf("Expected version stable-2.1.0, got %s", version)
+		}
+	})
+
+	t.Run("Returns an error if the version isn't recorded", func(t *testing.T) {
+		for _, createdBy := range []string{"", "linkerd/cli", "helm stable-2.1.0"} {
+			if _, err := parseCreatedBy(createdBy); err == nil {
+				t.Fatalf("Expected an error for %q", createdBy)
+			}
+		}
+	})
+}
+
+func TestValidateNoRollouts(t *testing.T) {
+	deployment := func(name string, generation, observedGeneration int64, replicas, current, updated int32) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: meta.ObjectMeta{Name: name, Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: observedGeneration,
+				Replicas:           current,
+				UpdatedReplicas:    updated,
+			},
+		}
+	}
+
+	testCases := []struct {
+		deployment appsv1.Deployment
+		expected   string
+	}{
+		{deployment("controller", 2, 2, 1, 1, 1), ""},
+		{deployment("controller", 3, 2, 1, 1, 1), "The \"controller\" deployment is being rolled out: 1 of 1 pods are up-to-date"},
+		{deployment("web", 2, 2, 2, 2, 1), "The \"web\" deployment is being rolled out: 1 of 2 pods are up-to-date"},
+		{deployment("web", 2, 2, 1, 2, 1), "The \"web\" deployment is being rolled out: 1 of 1 pods are up-to-date"},
+	}
+
+	for _, tc := range testCases {
+		err := validateNoRollouts([]appsv1.Deployment{tc.deployment})
+		if tc.expected == "" {
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	admissionregistration "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sVersion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// apiServerWebhookTimeout is how long the API server waits for an
+	// admission webhook to respond before applying its failurePolicy. It isn't
+	// configurable per webhook in the Kubernetes versions Linkerd supports.
+	apiServerWebhookTimeout = 30 * time.Second
+
+	// webhookLatencyThreshold is the fraction of apiServerWebhookTimeout above
+	// which a webhook's mean admission latency is reported.
+	webhookLatencyThreshold = 0.5
+
+	// dryRunPodName is the name of the pod that's created in dry-run mode to
+	// measure the admission latency of the proxy injection webhooks.
+	dryRunPodName = "linkerd-check-admission-latency"
+
+	// dryRunRequests is the number of dry-run pods created in a row, so that
+	// a webhook that's only slow to respond to its first request after being
+	// idle can be told apart from one that's always slow.
+	dryRunRequests = 2
+)
+
+// webhookLatencyMetrics are the names of the API server histograms that
+// track the latency of admission webhook calls, labeled by webhook name. The
+// metric was renamed in Kubernetes 1.14.
+var webhookLatencyMetrics = []string{
+	"apiserver_admission_webhook_admission_latencies_seconds",
+	"apiserver_admission_webhook_admission_duration_seconds",
+}
+
+// controlPlaneWebhooks returns the mutating webhooks served from the control
+// plane namespace.
+func controlPlaneWebhooks(configs []admissionregistration.MutatingWebhookConfiguration, controlPlaneNamespace string) []admissionregistration.Webhook {
+	webhooks := []admissionregistration.Webhook{}
+	for _, config := range configs {
+		for _, webhook := range config.Webhooks {
+			service := webhook.ClientConfig.Service
+			if service != nil && service.Namespace == controlPlaneNamespace {
+				webhooks = append(webhooks, webhook)
+			}
+		}
+	}
+	return webhooks
+}
+
+// injectionWebhooksNotInstalled is the skip function of the checks of the
+// proxy injection webhooks, which only apply to control planes that serve
+// mutating webhooks. If it can't be told whether the control plane serves
+// any, the checks are run so that they report the error.
+func (hc *HealthChecker) injectionWebhooksNotInstalled() bool {
+	if hc.injectionWebhooksInstalled == nil {
+		clientset, err := hc.getClientset()
+		if err != nil {
+			return false
+		}
+
+		webhookConfigs, err := clientset.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+		if err != nil {
+			return false
+		}
+		installed := len(controlPlaneWebhooks(webhookConfigs.Items, hc.ControlPlaneNamespace)) > 0
+		hc.injectionWebhooksInstalled = &installed
+	}
+	return !*hc.injectionWebhooksInstalled
+}
+
+// validateWebhookFailurePolicy returns an error if any of the webhooks has
+// failurePolicy Fail and no namespaceSelector, in which case no pod can be
+// created anywhere in the cluster while the control plane is unavailable.
+func validateWebhookFailurePolicy(webhooks []admissionregistration.Webhook) error {
+	for _, webhook := range webhooks {
+		if webhook.FailurePolicy == nil || *webhook.FailurePolicy != admissionregistration.Fail {
+			continue
+		}
+
+		if namespaceSelectorMatchesAll(webhook.NamespaceSelector) {
+			return fmt.Errorf("The \"%s\" webhook has failurePolicy Fail and applies to all namespaces, so no pods can be created while the control plane is unavailable; set failurePolicy to Ignore or add a namespaceSelector", webhook.Name)
+		}
+	}
+
+	return nil
+}
+
+// webhookLatencies returns the mean admission latency of each webhook, keyed
+// by webhook name, from the API server's metrics in the Prometheus text
+// format.
+func webhookLatencies(metrics io.Reader) (map[string]time.Duration, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	sums := map[string]float64{}
+	counts := map[string]uint64{}
+	for _, name := range webhookLatencyMetrics {
+		family, ok := families[name]
+		if !ok {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			webhook := ""
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "name" {
+					webhook = label.GetValue()
+				}
+			}
+
+			sums[webhook] += metric.GetHistogram().GetSampleSum()
+			counts[webhook] += metric.GetHistogram().GetSampleCount()
+		}
+	}
+
+	latencies := map[string]time.Duration{}
+	for webhook, count := range counts {
+		if count > 0 {
+			latencies[webhook] = time.Duration(sums[webhook] / float64(count) * float64(time.Second))
+		}
+	}
+	return latencies, nil
+}
+
+// validateWebhookLatency returns an error if the mean admission latency of
+// any of the webhooks is close to the API server's timeout, past which pod
+// creation fails or skips injection, depending on the failurePolicy.
+func validateWebhookLatency(webhooks []admissionregistration.Webhook, latencies map[string]time.Duration, timeout time.Duration) error {
+	threshold := time.Duration(float64(timeout) * webhookLatencyThreshold)
+
+	for _, webhook := range webhooks {
+		latency, ok := latencies[webhook.Name]
+		if !ok || latency < threshold {
+			continue
+		}
+
+		policy := admissionregistration.Ignore
+		if webhook.FailurePolicy != nil {
+			policy = *webhook.FailurePolicy
+		}
+
+		return fmt.Errorf("The \"%s\" webhook takes %s on average to admit pods, close to the API server's %s timeout; with failurePolicy %s, pods %s when it times out",
+			webhook.Name, latency.Round(time.Millisecond), timeout, policy, timeoutOutcome(policy))
+	}
+
+	return nil
+}
+
+func timeoutOutcome(policy admissionregistration.FailurePolicyType) string {
+	if policy == admissionregistration.Fail {
+		return "can't be created"
+	}
+	return "are created without a proxy"
+}
+
+// namespaceSelectorMatchesAll returns true if the selector is missing or
+// empty, and therefore matches every namespace.
+func namespaceSelectorMatchesAll(selector *metav1.LabelSelector) bool {
+	return selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0)
+}
+
+// supportsDryRun returns true if the API server supports dry-run requests,
+// which are enabled by default from Kubernetes 1.13. Older API servers ignore
+// the dryRun parameter and persist the object.
+func supportsDryRun(version *k8sVersion.Info) bool {
+	if version == nil {
+		return false
+	}
+
+	major, err := strconv.Atoi(version.Major)
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(strings.TrimSuffix(version.Minor, "+"))
+	if err != nil {
+		return false
+	}
+
+	return major > 1 || (major == 1 && minor >= 13)
+}
+
+// webhookNamespace returns the first of the namespaces in which pods are
+// admitted by the webhook, other than the control plane's and the ones critical
+// to the cluster, or "" if there's none.
+func webhookNamespace(webhook admissionregistration.Webhook, namespaces []v1.Namespace, controlPlaneNamespace string) (string, error) {
+	selector := labels.Everything()
+	if webhook.NamespaceSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(webhook.NamespaceSelector)
+		if err != nil {
+			return "", fmt.Errorf("The \"%s\" webhook has an invalid namespaceSelector: %s", webhook.Name, err)
+		}
+	}
+
+	for _, ns := range namespaces {
+		if ns.Name == controlPlaneNamespace || isCriticalNamespace(ns.Name) {
+			continue
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return ns.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+func isCriticalNamespace(name string) bool {
+	for _, ns := range criticalNamespaces {
+		if ns == name {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookSideEffects returns the sideEffects declared by each mutating
+// webhook, keyed by webhook name. The field is missing from the client's
+// admissionregistration types, so the configurations are read as JSON.
+func webhookSideEffects(clientset kubernetes.Interface) (map[string]string, error) {
+	body, err := clientset.AdmissionregistrationV1beta1().RESTClient().Get().
+		Resource("mutatingwebhookconfigurations").
+		DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	var configs struct {
+		Items []struct {
+			Webhooks []struct {
+				Name        string `json:"name"`
+				SideEffects string `json:"sideEffects"`
+			} `json:"webhooks"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &configs); err != nil {
+		return nil, err
+	}
+
+	sideEffects := map[string]string{}
+	for _, config := range configs.Items {
+		for _, webhook := range config.Webhooks {
+			sideEffects[webhook.Name] = webhook.SideEffects
+		}
+	}
+	return sideEffects, nil
+}
+
+// allowDryRun returns true if all the webhooks declare that they have no side
+// effects on dry-run requests. The API server rejects the dry-run requests
+// that webhooks without such a declaration would be called for.
+func allowDryRun(webhooks []admissionregistration.Webhook, sideEffects map[string]string) bool {
+	for _, webhook := range webhooks {
+		switch sideEffects[webhook.Name] {
+		case "None", "NoneOnDryRun":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validateDryRunAdmission creates dry-run pods through each of the webhooks,
+// in a namespace whose pods it admits, and returns an error if the API server
+// took close to its timeout to admit them.
+func validateDryRunAdmission(clientset kubernetes.Interface, webhooks []admissionregistration.Webhook, controlPlaneNamespace string) error {
+	namespaces, err := clientset.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		namespace, err := webhookNamespace(webhook, namespaces.Items, controlPlaneNamespace)
+		if err != nil {
+			return err
+		}
+		if namespace == "" {
+			continue
+		}
+
+		latencies, err := measureAdmissionLatency(clientset, namespace)
+		if err != nil {
+			return err
+		}
+
+		if err := validateAdmissionLatency(webhook.Name, namespace, latencies, apiServerWebhookTimeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// measureAdmissionLatency creates dryRunRequests pods in dry-run mode in the
+// namespace, one after the other, and returns how long the API server took
+// to respond to each request, including the time spent calling the admission
+// webhooks.
+func measureAdmissionLatency(clientset kubernetes.Interface, namespace string) ([]time.Duration, error) {
+	pod := &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: dryRunPodName, Namespace: namespace},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "pause", Image: "k8s.gcr.io/pause:3.1"}},
+		},
+	}
+
+	latencies := []time.Duration{}
+	for i := 0; i < dryRunRequests; i++ {
+		start := time.Now()
+		err := clientset.CoreV1().RESTClient().Post().
+			Namespace(namespace).
+			Resource("pods").
+			Param("dryRun", "All").
+			Body(pod).
+			Do().
+			Error()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create a dry-run pod in the \"%s\" namespace: %s", namespace, err)
+		}
+		latencies = append(latencies, time.Since(start))
+	}
+
+	return latencies, nil
+}
+
+// validateAdmissionLatency returns an error if any of the latencies of the
+// dry-run pod creations through the webhook is close to the API server's
+// timeout. If only the first request was slow, the webhook is likely cold;
+// otherwise the time is likely spent on the network path from the API server
+// to the webhook's pods.
+func validateAdmissionLatency(webhook, namespace string, latencies []time.Duration, timeout time.Duration) error {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	threshold := time.Duration(float64(timeout) * webhookLatencyThreshold)
+	first, last := latencies[0], latencies[len(latencies)-1]
+
+	if last >= threshold {
+		return fmt.Errorf("Creating a pod in the \"%s\" namespace through the \"%s\" webhook took %s, close to the API server's %s timeout; check the network path from the API server to the webhook's pods, e.g. firewall rules or proxies between the masters and the nodes",
+			namespace, webhook, last.Round(time.Millisecond), timeout)
+	}
+	if first >= threshold {
+		return fmt.Errorf("Creating a pod in the \"%s\" namespace through the \"%s\" webhook took %s the first time and %s the next, close to the API server's %s timeout; the webhook is slow to respond when cold, e.g. after a restart or a period without pod creations",
+			namespace, webhook, first.Round(time.Millisecond), last.Round(time.Millisecond), timeout)
+	}
+
+	return nil
+}
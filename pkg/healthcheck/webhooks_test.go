@@ -0,0 +1,220 @@
+package healthcheck
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	admissionregistration "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sVersion "k8s.io/apimachinery/pkg/version"
+)
+
+func TestValidateWebhookFailurePolicy(t *testing.T) {
+	webhook := func(selector *meta.LabelSelector, policy admissionregistration.FailurePolicyType) admissionregistration.Webhook {
+		return admissionregistration.Webhook{
+			Name:              "linkerd-proxy-injector.linkerd.io",
+			NamespaceSelector: selector,
+			FailurePolicy:     &policy,
+		}
+	}
+	injectEnabled := &meta.LabelSelector{MatchLabels: map[string]string{"linkerd.io/inject": "enabled"}}
+
+	t.Run("Returns nil for webhooks that can't block all pod creation", func(t *testing.T) {
+		webhooks := []admissionregistration.Webhook{
+			webhook(nil, admissionregistration.Ignore),
+			webhook(injectEnabled, admissionregistration.Fail),
+		}
+
+		err := validateWebhookFailurePolicy(webhooks)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if a webhook fails closed for all namespaces", func(t *testing.T) {
+		webhooks := []admissionregistration.Webhook{
+			webhook(&meta.LabelSelector{}, admissionregistration.Fail),
+		}
+
+		err := validateWebhookFailurePolicy(webhooks)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "The \"linkerd-proxy-injector.linkerd.io\" webhook has failurePolicy Fail and applies to all namespaces, so no pods can be created while the control plane is unavailable; set failurePolicy to Ignore or add a namespaceSelector"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
+func TestWebhookLatencies(t *testing.T) {
+	metrics := `# HELP apiserver_admission_webhook_admission_latencies_seconds Admission webhook latency histogram in seconds, identified by name and broken out for each operation and API resource and type (validate or admit).
+# TYPE apiserver_admission_webhook_admission_latencies_seconds histogram
+apiserver_admission_webhook_admission_latencies_seconds_bucket{name="linkerd-proxy-injector.linkerd.io",operation="CREATE",rejected="false",type="admit",le="+Inf"} 3
+apiserver_admission_webhook_admission_latencies_seconds_sum{name="linkerd-proxy-injector.linkerd.io",operation="CREATE",rejected="false",type="admit"} 0.5
+apiserver_admission_webhook_admission_latencies_seconds_count{name="linkerd-proxy-injector.linkerd.io",operation="CREATE",rejected="false",type="admit"} 3
+apiserver_admission_webhook_admission_latencies_seconds_bucket{name="linkerd-proxy-injector.linkerd.io",operation="CREATE",rejected="true",type="admit",le="+Inf"} 1
+apiserver_admission_webhook_admission_latencies_seconds_sum{name="linkerd-proxy-injector.linkerd.io",operation="CREATE",rejected="true",type="admit"} 31.5
+apiserver_admission_webhook_admission_latencies_seconds_count{name="linkerd-proxy-injector.linkerd.io",operation="CREATE",rejected="true",type="admit"} 1
+# HELP apiserver_request_count Counter of apiserver requests broken out for each verb, API resource, client, and HTTP response contentType and code.
+# TYPE apiserver_request_count counter
+apiserver_request_count{client="kubectl",code="200",contentType="application/json",resource="pods",scope="namespace",subresource="",verb="LIST"} 12
+`
+
+	latencies, err := webhookLatencies(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := 8 * time.Second
+	if latencies["linkerd-proxy-injector.linkerd.io"] != expected {
+		t.Fatalf("Expected latency %s but got %v", expected, latencies)
+	}
+}
+
+func TestValidateWebhookLatency(t *testing.T) {
+	policy := admissionregistration.Fail
+	webhooks := []admissionregistration.Webhook{
+		admissionregistration.Webhook{Name: "linkerd-proxy-injector.linkerd.io", FailurePolicy: &policy},
+	}
+
+	t.Run("Returns nil if webhooks respond well within the timeout", func(t *testing.T) {
+		latencies := map[string]time.Duration{"linkerd-proxy-injector.linkerd.io": 200 * time.Millisecond}
+
+		err := validateWebhookLatency(webhooks, latencies, apiServerWebhookTimeout)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Returns an error if a webhook responds close to the timeout", func(t *testing.T) {
+		latencies := map[string]time.Duration{"linkerd-proxy-injector.linkerd.io": 18 * time.Second}
+
+		err := validateWebhookLatency(webhooks, latencies, apiServerWebhookTimeout)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "The \"linkerd-proxy-injector.linkerd.io\" webhook takes 18s on average to admit pods, close to the API server's 30s timeout; with failurePolicy Fail, pods can't be created when it times out"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
+func TestSupportsDryRun(t *testing.T) {
+	testCases := []struct {
+		version  *k8sVersion.Info
+		expected bool
+	}{
+		{nil, false},
+		{&k8sVersion.Info{Major: "1", Minor: "11"}, false},
+		{&k8sVersion.Info{Major: "1", Minor: "13"}, true},
+		{&k8sVersion.Info{Major: "1", Minor: "14+"}, true},
+		{&k8sVersion.Info{Major: "1", Minor: "unknown"}, false},
+	}
+
+	for i, tc := range testCases {
+		if supportsDryRun(tc.version) != tc.expected {
+			t.Fatalf("Test case %d: expected %t for version %+v", i, tc.expected, tc.version)
+		}
+	}
+}
+
+func TestAllowDryRun(t *testing.T) {
+	webhooks := []admissionregistration.Webhook{
+		{Name: "linkerd-proxy-injector.linkerd.io"},
+		{Name: "linkerd-sp-validator.linkerd.io"},
+	}
+
+	testCases := []struct {
+		sideEffects map[string]string
+		expected    bool
+	}{
+		{map[string]string{}, false},
+		{map[string]string{"linkerd-proxy-injector.linkerd.io": "None", "linkerd-sp-validator.linkerd.io": "NoneOnDryRun"}, true},
+		{map[string]string{"linkerd-proxy-injector.linkerd.io": "None", "linkerd-sp-validator.linkerd.io": "Unknown"}, false},
+		{map[string]string{"linkerd-proxy-injector.linkerd.io": "None"}, false},
+	}
+
+	for i, tc := range testCases {
+		if allowDryRun(webhooks, tc.sideEffects) != tc.expected {
+			t.Fatalf("Test case %d: expected %t for side effects %v", i, tc.expected, tc.sideEffects)
+		}
+	}
+}
+
+func TestWebhookNamespace(t *testing.T) {
+	namespace := func(name string, labels map[string]string) v1.Namespace {
+		return v1.Namespace{ObjectMeta: meta.ObjectMeta{Name: name, Labels: labels}}
+	}
+	injectEnabled := map[string]string{"linkerd.io/inject": "enabled"}
+	namespaces := []v1.Namespace{
+		namespace("booksapp", nil),
+		namespace("emojivoto", injectEnabled),
+		namespace("kube-system", injectEnabled),
+		namespace("linkerd", injectEnabled),
+	}
+
+	testCases := []struct {
+		selector *meta.LabelSelector
+		expected string
+	}{
+		{nil, "booksapp"},
+		{&meta.LabelSelector{MatchLabels: injectEnabled}, "emojivoto"},
+		{&meta.LabelSelector{MatchLabels: map[string]string{"linkerd.io/inject": "disabled"}}, ""},
+	}
+
+	for i, tc := range testCases {
+		webhook := admissionregistration.Webhook{Name: "linkerd-proxy-injector.linkerd.io", NamespaceSelector: tc.selector}
+
+		ns, err := webhookNamespace(webhook, namespaces, "linkerd")
+		if err != nil {
+			t.Fatalf("Test case %d: unexpected error: %s", i, err)
+		}
+		if ns != tc.expected {
+			t.Fatalf("Test case %d: expected namespace [%s], got [%s]", i, tc.expected, ns)
+		}
+	}
+}
+
+func TestValidateAdmissionLatency(t *testing.T) {
+	webhook := "linkerd-proxy-injector.linkerd.io"
+
+	testCases := []struct {
+		description string
+		latencies   []time.Duration
+		expected    string
+	}{
+		{
+			description: "Returns nil if pods are admitted well within the timeout",
+			latencies:   []time.Duration{300 * time.Millisecond, 100 * time.Millisecond},
+		},
+		{
+			description: "Flags webhooks that are slow when cold",
+			latencies:   []time.Duration{20 * time.Second, 100 * time.Millisecond},
+			expected:    "Creating a pod in the \"emojivoto\" namespace through the \"linkerd-proxy-injector.linkerd.io\" webhook took 20s the first time and 100ms the next, close to the API server's 30s timeout; the webhook is slow to respond when cold, e.g. after a restart or a period without pod creations",
+		},
+		{
+			description: "Flags webhooks that are always slow",
+			latencies:   []time.Duration{20 * time.Second, 18 * time.Second},
+			expected:    "Creating a pod in the \"emojivoto\" namespace through the \"linkerd-proxy-injector.linkerd.io\" webhook took 18s, close to the API server's 30s timeout; check the network path from the API server to the webhook's pods, e.g. firewall rules or proxies between the masters and the nodes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := validateAdmissionLatency(webhook, "emojivoto", tc.latencies, apiServerWebhookTimeout)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
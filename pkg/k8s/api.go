@@ -7,8 +7,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"time"
 
+	"github.com/linkerd/linkerd2/pkg/profiles"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
@@ -34,8 +34,8 @@ func (kubeAPI *KubernetesAPI) NewClient() (*http.Client, error) {
 	}, nil
 }
 
-func (kubeAPI *KubernetesAPI) GetVersionInfo(client *http.Client) (*version.Info, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (kubeAPI *KubernetesAPI) GetVersionInfo(ctx context.Context, client *http.Client) (*version.Info, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
 	defer cancel()
 
 	rsp, err := kubeAPI.getRequest(ctx, client, "/version")
@@ -73,8 +73,8 @@ func (kubeAPI *KubernetesAPI) CheckVersion(versionInfo *version.Info) error {
 	return nil
 }
 
-func (kubeAPI *KubernetesAPI) NamespaceExists(client *http.Client, namespace string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (kubeAPI *KubernetesAPI) NamespaceExists(ctx context.Context, client *http.Client, namespace string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
 	defer cancel()
 
 	rsp, err := kubeAPI.getRequest(ctx, client, "/api/v1/namespaces/"+namespace)
@@ -91,12 +91,12 @@ func (kubeAPI *KubernetesAPI) NamespaceExists(client *http.Client, namespace str
 }
 
 // GetPodsByNamespace returns all pods in a given namespace
-func (kubeAPI *KubernetesAPI) GetPodsByNamespace(client *http.Client, namespace string) ([]v1.Pod, error) {
-	return kubeAPI.getPods(client, "/api/v1/namespaces/"+namespace+"/pods")
+func (kubeAPI *KubernetesAPI) GetPodsByNamespace(ctx context.Context, client *http.Client, namespace string) ([]v1.Pod, error) {
+	return kubeAPI.getPods(ctx, client, "/api/v1/namespaces/"+namespace+"/pods")
 }
 
-func (kubeAPI *KubernetesAPI) getPods(client *http.Client, path string) ([]v1.Pod, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (kubeAPI *KubernetesAPI) getPods(ctx context.Context, client *http.Client, path string) ([]v1.Pod, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
 	defer cancel()
 
 	rsp, err := kubeAPI.getRequest(ctx, client, path)
@@ -123,6 +123,37 @@ func (kubeAPI *KubernetesAPI) getPods(client *http.Client, path string) ([]v1.Po
 	return podList.Items, nil
 }
 
+// GetServiceProfiles returns all ServiceProfiles in a given namespace
+func (kubeAPI *KubernetesAPI) GetServiceProfiles(client *http.Client, namespace string) ([]profiles.ServiceProfile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), apiRequestTimeout)
+	defer cancel()
+
+	rsp, err := kubeAPI.getRequest(ctx, client, "/apis/"+profiles.ServiceProfileAPIVersion+"/namespaces/"+namespace+"/serviceprofiles")
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected Kubernetes API response: %s", rsp.Status)
+	}
+
+	bytes, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var profileList struct {
+		Items []profiles.ServiceProfile `json:"items"`
+	}
+	err = json.Unmarshal(bytes, &profileList)
+	if err != nil {
+		return nil, err
+	}
+
+	return profileList.Items, nil
+}
+
 // UrlFor generates a URL based on the Kubernetes config.
 func (kubeAPI *KubernetesAPI) UrlFor(namespace string, extraPathStartingWithSlash string) (*url.URL, error) {
 	return generateKubernetesApiBaseUrlFor(kubeAPI.Host, namespace, extraPathStartingWithSlash)
@@ -143,12 +174,14 @@ func (kubeAPI *KubernetesAPI) getRequest(ctx context.Context, client *http.Clien
 }
 
 // NewAPI validates a Kubernetes config and returns a client for accessing the
-// configured cluster
+// configured cluster. The HTTP clients and clientsets built from it share a
+// rate limiter, retry the requests the Kubernetes API throttles or fails to
+// serve, and log their requests.
 func NewAPI(configPath, kubeContext string) (*KubernetesAPI, error) {
 	config, err := getConfig(configPath, kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring Kubernetes API client: %v", err)
 	}
 
-	return &KubernetesAPI{Config: config}, nil
+	return &KubernetesAPI{Config: withAPILog(withRetries(config))}, nil
 }
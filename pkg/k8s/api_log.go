@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+)
+
+// apiLogger logs the requests sent to the Kubernetes API, including the ones
+// proxied to the Linkerd public API. Requests are logged at debug level, so
+// that they're only displayed with --verbose, unless EnableAPILog is called.
+var apiLogger = log.StandardLogger()
+
+// EnableAPILog logs the requests sent to the Kubernetes API to w, regardless
+// of the log level.
+func EnableAPILog(w io.Writer) {
+	apiLogger = &log.Logger{
+		Out:       w,
+		Formatter: new(log.TextFormatter),
+		Hooks:     make(log.LevelHooks),
+		Level:     log.DebugLevel,
+	}
+}
+
+// logTransport logs each request when it's sent, so that a request that
+// hangs can be identified, and again when its response headers are received,
+// with the response status and the time it took.
+type logTransport struct {
+	base http.RoundTripper
+}
+
+// NewLoggingTransport returns a transport that logs the requests sent through
+// base.
+func NewLoggingTransport(base http.RoundTripper) http.RoundTripper {
+	return &logTransport{base: base}
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	apiLogger.Debugf("%s %s", req.Method, req.URL)
+	start := time.Now()
+
+	rsp, err := t.base.RoundTrip(req)
+	duration := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		apiLogger.Debugf("%s %s failed after %s: %s", req.Method, req.URL, duration, err)
+		return rsp, err
+	}
+
+	apiLogger.Debugf("%s %s returned %s in %s", req.Method, req.URL, rsp.Status, duration)
+	return rsp, nil
+}
+
+// withAPILog configures config so that all the clients built from it log
+// their requests.
+func withAPILog(config *rest.Config) *rest.Config {
+	wrapTransport := config.WrapTransport
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrapTransport != nil {
+			rt = wrapTransport(rt)
+		}
+		return NewLoggingTransport(rt)
+	}
+
+	return config
+}
@@ -0,0 +1,45 @@
+package k8s
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	defaultLogger := apiLogger
+	defer func() { apiLogger = defaultLogger }()
+
+	var buf bytes.Buffer
+	EnableAPILog(&buf)
+
+	client := &http.Client{Transport: NewLoggingTransport(http.DefaultTransport)}
+	rsp, err := client.Get(server.URL + "/api/v1/namespaces")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rsp.Body.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+
+	url := server.URL + "/api/v1/namespaces"
+	expected := []string{
+		"GET " + url,
+		"GET " + url + " returned 404 Not Found in ",
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, expected[i]) {
+			t.Fatalf("Expected log line %d to contain [%s], got [%s]", i, expected[i], line)
+		}
+	}
+}
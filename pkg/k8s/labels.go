@@ -7,6 +7,7 @@ package k8s
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/linkerd/linkerd2/pkg/version"
 	appsV1 "k8s.io/api/apps/v1"
@@ -50,10 +51,22 @@ const (
 	// StatefulSet that this proxy belongs to.
 	ProxyStatefulSetLabel = "linkerd.io/proxy-statefulset"
 
+	// ExtensionLabel identifies this object as a component of an optional
+	// Linkerd extension (e.g. jaeger), installed apart from the control plane.
+	ExtensionLabel = "linkerd.io/extension"
+
+	// NodeArchLabel is the well-known node label set by the kubelet to the
+	// node's CPU architecture (e.g. amd64, arm64).
+	NodeArchLabel = "beta.kubernetes.io/arch"
+
 	/*
 	 * Annotations
 	 */
 
+	// AnnotationPrefix is the prefix of all the annotations Linkerd reads or
+	// writes on workloads.
+	AnnotationPrefix = "linkerd.io/"
+
 	// CreatedByAnnotation indicates the source of the injected data plane
 	// (e.g. linkerd/cli v2.0.0).
 	CreatedByAnnotation = "linkerd.io/created-by"
@@ -62,6 +75,29 @@ const (
 	// (e.g. v0.1.3).
 	ProxyVersionAnnotation = "linkerd.io/proxy-version"
 
+	// ProxyConfigHashAnnotation indicates the hash of the configuration the
+	// data plane was injected with, excluding the workload's own overrides.
+	ProxyConfigHashAnnotation = "linkerd.io/proxy-config-hash"
+
+	// ProxyInjectAnnotation can be set on a namespace to record whether
+	// proxies should be injected into its workloads ("enabled" or
+	// "disabled").
+	ProxyInjectAnnotation = "linkerd.io/inject"
+
+	// ProxyDetectProtocolTimeoutAnnotation can be set on a workload's pod
+	// template to override the proxy's protocol detection timeout for that
+	// workload (e.g. 500ms).
+	ProxyDetectProtocolTimeoutAnnotation = "linkerd.io/proxy-detect-protocol-timeout"
+
+	// ProxyUIDAnnotation can be set on a workload's pod template to override
+	// the user ID the proxy runs as for that workload (e.g. 1000650000).
+	ProxyUIDAnnotation = "linkerd.io/proxy-uid"
+
+	// ProxyOpaquePortsAnnotation can be set on a workload's pod template to
+	// override the comma-separated list of ports on which the proxy skips
+	// protocol detection and forwards traffic as opaque TCP (e.g. 3306,5432).
+	ProxyOpaquePortsAnnotation = "linkerd.io/opaque-ports"
+
 	/*
 	 * Component Names
 	 */
@@ -80,10 +116,26 @@ const (
 	// that contains the actual trust anchor bundle.
 	TLSTrustAnchorFileName = "trust-anchors.pem"
 
+	// ProxyConfigMapName is the name of the ConfigMap that records the hash of
+	// the proxy configuration the control plane was installed with.
+	ProxyConfigMapName = "linkerd-proxy-config"
+
+	// ProxyConfigHashKey is the name (key) within the proxy ConfigMap that
+	// contains the hash of the proxy configuration.
+	ProxyConfigHashKey = "hash"
+
 	TLSCertFileName       = "certificate.crt"
 	TLSPrivateKeyFileName = "private-key.p8"
 )
 
+// ProxyConfigAnnotations lists the annotations that can be set on a workload's
+// pod template to override the proxy configuration for that workload.
+var ProxyConfigAnnotations = []string{
+	ProxyDetectProtocolTimeoutAnnotation,
+	ProxyOpaquePortsAnnotation,
+	ProxyUIDAnnotation,
+}
+
 // CreatedByAnnotationValue returns the value associated with
 // CreatedByAnnotation.
 func CreatedByAnnotationValue() string {
@@ -133,8 +185,51 @@ type TLSIdentity struct {
 }
 
 func (i TLSIdentity) ToDNSName() string {
-	return fmt.Sprintf("%s.%s.%s.linkerd-managed.%s.svc.cluster.local", i.Name,
-		i.Kind, i.Namespace, i.ControllerNamespace)
+	return fmt.Sprintf("%s.%s.%s.%s", i.Name, i.Kind, i.Namespace, i.TrustDomain())
+}
+
+// TrustDomain returns the DNS suffix shared by all the identities managed by
+// the identity's controller. Proxies only trust peers in their trust domain.
+func (i TLSIdentity) TrustDomain() string {
+	return fmt.Sprintf("linkerd-managed.%s.svc.cluster.local", i.ControllerNamespace)
+}
+
+// ParseTLSIdentity is the inverse of ToDNSName. Since pod owner names may
+// contain dots, the name is taken to be everything before the kind and
+// namespace.
+func ParseTLSIdentity(dnsName string) (TLSIdentity, error) {
+	const suffix = ".svc.cluster.local"
+	const managed = ".linkerd-managed."
+
+	trimmed := strings.TrimSuffix(dnsName, suffix)
+	sep := strings.LastIndex(trimmed, managed)
+	if trimmed == dnsName || sep == -1 {
+		return TLSIdentity{}, fmt.Errorf("invalid TLS identity: %s", dnsName)
+	}
+
+	owner := trimmed[:sep]
+	controllerNamespace := trimmed[sep+len(managed):]
+
+	namespaceSep := strings.LastIndex(owner, ".")
+	if namespaceSep == -1 {
+		return TLSIdentity{}, fmt.Errorf("invalid TLS identity: %s", dnsName)
+	}
+	kindSep := strings.LastIndex(owner[:namespaceSep], ".")
+	if kindSep == -1 {
+		return TLSIdentity{}, fmt.Errorf("invalid TLS identity: %s", dnsName)
+	}
+
+	identity := TLSIdentity{
+		Name:                owner[:kindSep],
+		Kind:                owner[kindSep+1 : namespaceSep],
+		Namespace:           owner[namespaceSep+1:],
+		ControllerNamespace: controllerNamespace,
+	}
+	if identity.Name == "" || identity.Kind == "" || identity.Namespace == "" || identity.ControllerNamespace == "" {
+		return TLSIdentity{}, fmt.Errorf("invalid TLS identity: %s", dnsName)
+	}
+
+	return identity, nil
 }
 
 func (i TLSIdentity) ToSecretName() string {
@@ -38,3 +38,37 @@ func TestGetPodLabels(t *testing.T) {
 		}
 	})
 }
+
+func TestParseTLSIdentity(t *testing.T) {
+	t.Run("Parses identities produced by ToDNSName", func(t *testing.T) {
+		expected := TLSIdentity{
+			Name:                "books.v1",
+			Kind:                "deployment",
+			Namespace:           "booksapp",
+			ControllerNamespace: "linkerd",
+		}
+
+		identity, err := ParseTLSIdentity(expected.ToDNSName())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if identity != expected {
+			t.Fatalf("Expected identity [%+v] but got [%+v]", expected, identity)
+		}
+	})
+
+	t.Run("Rejects names that aren't Linkerd identities", func(t *testing.T) {
+		for _, dnsName := range []string{
+			"",
+			"books.booksapp.svc.cluster.local",
+			"booksapp.linkerd-managed.linkerd.svc.cluster.local",
+			"books.deployment.booksapp.linkerd-managed.linkerd",
+			".deployment.booksapp.linkerd-managed.linkerd.svc.cluster.local",
+		} {
+			_, err := ParseTLSIdentity(dnsName)
+			if err == nil {
+				t.Fatalf("Expected error parsing [%s] but got none", dnsName)
+			}
+		}
+	})
+}
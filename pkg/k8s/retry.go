@@ -0,0 +1,229 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// apiQPS and apiBurst bound the rate of requests a single command sends to
+	// the Kubernetes API, across all of its clients.
+	apiQPS   = 20
+	apiBurst = 40
+
+	// apiRequestTimeout bounds a unary call to the Kubernetes API, including
+	// the time spent waiting to be let through by the rate limiter and
+	// retrying. Clients aren't given a timeout, which would also cut the
+	// streams of tap and top short; the retry transport sets it as the
+	// deadline of the requests that don't have one, except for streams.
+	apiRequestTimeout = 15 * time.Second
+
+	// apiMaxRetries is the number of times a throttled or failed request is
+	// retried, waiting twice as long before each attempt.
+	apiMaxRetries     = 4
+	apiInitialBackoff = 250 * time.Millisecond
+	apiMaxBackoff     = 4 * time.Second
+)
+
+// retryTransport rate limits the requests it sends, and retries them with an
+// exponential backoff when the Kubernetes API throttles them (429) or fails to
+// serve them (5xx), so that bursts of requests, such as the ones sent by
+// `linkerd check`, don't trip the API server's priority and fairness limits.
+// Only throttled requests are retried for methods that aren't safe, since the
+// API server didn't process them.
+type retryTransport struct {
+	base           http.RoundTripper
+	rateLimiter    flowcontrol.RateLimiter
+	requestTimeout time.Duration
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, rateLimiter flowcontrol.RateLimiter) *retryTransport {
+	return &retryTransport{
+		base:           base,
+		rateLimiter:    rateLimiter,
+		requestTimeout: apiRequestTimeout,
+		maxRetries:     apiMaxRetries,
+		initialBackoff: apiInitialBackoff,
+		maxBackoff:     apiMaxBackoff,
+	}
+}
+
+type streamKey struct{}
+
+// WithStream returns a copy of ctx for requests whose responses are streamed
+// until the client is done with them, such as tap's, so that the retry
+// transport doesn't bound them with apiRequestTimeout.
+func WithStream(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamKey{}, true)
+}
+
+// isStream returns true if the response to req is streamed until the client
+// closes it: watches, followed logs, upgraded connections and the requests
+// sent with a WithStream context.
+func isStream(req *http.Request) bool {
+	if req.Context().Value(streamKey{}) != nil {
+		return true
+	}
+	query := req.URL.Query()
+	if query.Get("watch") == "true" || query.Get("watch") == "1" || query.Get("follow") == "true" {
+		return true
+	}
+	return strings.Contains(req.URL.Path, "/watch/") || req.Header.Get("Upgrade") != ""
+}
+
+// cancelBody cancels the context of the request once its response is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// RoundTrip bounds the requests that aren't streams and don't have a deadline
+// with requestTimeout, and sends them with retries.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok || isStream(req) {
+		return t.roundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.requestTimeout)
+	rsp, err := t.roundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	rsp.Body = &cancelBody{ReadCloser: rsp.Body, cancel: cancel}
+	return rsp, nil
+}
+
+// accept waits for the rate limiter to let a request through, or for ctx to
+// be done.
+func (t *retryTransport) accept(ctx context.Context) error {
+	for !t.rateLimiter.TryAccept() {
+		select {
+		case <-time.After(time.Second / apiQPS):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.initialBackoff
+
+	for attempt := 0; ; attempt++ {
+		if err := t.accept(req.Context()); err != nil {
+			return nil, err
+		}
+
+		rsp, err := t.base.RoundTrip(req)
+		if err != nil || attempt == t.maxRetries || !shouldRetry(req, rsp) {
+			return rsp, err
+		}
+
+		retryReq, ok := rewind(req)
+		if !ok {
+			return rsp, err
+		}
+
+		wait := retryAfter(rsp, backoff, t.maxBackoff)
+		apiLogger.Debugf("%s %s returned %s, retrying in %s", req.Method, req.URL, rsp.Status, wait)
+		io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		req = retryReq
+		backoff *= 2
+		if backoff > t.maxBackoff {
+			backoff = t.maxBackoff
+		}
+	}
+}
+
+func shouldRetry(req *http.Request, rsp *http.Response) bool {
+	if rsp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return rsp.StatusCode >= 500 && rsp.StatusCode != http.StatusNotImplemented
+	default:
+		return false
+	}
+}
+
+// rewind returns a copy of req that can be sent again, or false if its body
+// can't be read again.
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	retryReq := req.WithContext(req.Context())
+	retryReq.Body = body
+	return retryReq, true
+}
+
+// retryAfter returns how long to wait before retrying: the delay requested by
+// the API server in the Retry-After header if there's one, or backoff, capped
+// at maxBackoff.
+func retryAfter(rsp *http.Response, backoff, maxBackoff time.Duration) time.Duration {
+	wait := backoff
+	if seconds, err := strconv.Atoi(rsp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	if wait > maxBackoff {
+		return maxBackoff
+	}
+	return wait
+}
+
+// withRetries configures config so that all the clients built from it share a
+// rate limiter, and retry throttled and failed requests.
+func withRetries(config *rest.Config) *rest.Config {
+	rateLimiter := flowcontrol.NewTokenBucketRateLimiter(apiQPS, apiBurst)
+
+	// the retry transport does the rate limiting, so that retries are limited
+	// as well, and so that clientsets don't limit requests a second time
+	config.RateLimiter = flowcontrol.NewFakeAlwaysRateLimiter()
+
+	wrapTransport := config.WrapTransport
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrapTransport != nil {
+			rt = wrapTransport(rt)
+		}
+		return newRetryTransport(rt, rateLimiter)
+	}
+
+	return config
+}
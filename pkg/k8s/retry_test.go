@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+func TestRetryTransport(t *testing.T) {
+	newServer := func(statuses ...int) (*httptest.Server, *int) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			status := statuses[len(statuses)-1]
+			if requests < len(statuses) {
+				status = statuses[requests]
+			}
+			requests++
+			w.WriteHeader(status)
+		}))
+		return server, &requests
+	}
+
+	newClient := func() *http.Client {
+		transport := newRetryTransport(http.DefaultTransport, flowcontrol.NewFakeAlwaysRateLimiter())
+		transport.initialBackoff = time.Millisecond
+		transport.maxBackoff = time.Millisecond
+		return &http.Client{Transport: transport}
+	}
+
+	testCases := []struct {
+		method           string
+		statuses         []int
+		expectedStatus   int
+		expectedRequests int
+	}{
+		{http.MethodGet, []int{http.StatusOK}, http.StatusOK, 1},
+		{http.MethodGet, []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}, http.StatusOK, 3},
+		{http.MethodGet, []int{http.StatusInternalServerError}, http.StatusInternalServerError, apiMaxRetries + 1},
+		{http.MethodGet, []int{http.StatusNotFound}, http.StatusNotFound, 1},
+		{http.MethodGet, []int{http.StatusNotImplemented}, http.StatusNotImplemented, 1},
+		{http.MethodPost, []int{http.StatusTooManyRequests, http.StatusCreated}, http.StatusCreated, 2},
+		{http.MethodPost, []int{http.StatusServiceUnavailable, http.StatusCreated}, http.StatusServiceUnavailable, 1},
+	}
+
+	for _, tc := range testCases {
+		server, requests := newServer(tc.statuses...)
+
+		req, err := http.NewRequest(tc.method, server.URL, strings.NewReader("{}"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		rsp, err := newClient().Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		rsp.Body.Close()
+		server.Close()
+
+		if rsp.StatusCode != tc.expectedStatus {
+			t.Fatalf("Expected status %d for %s with responses %v, got %d", tc.expectedStatus, tc.method, tc.statuses, rsp.StatusCode)
+		}
+		if *requests != tc.expectedRequests {
+			t.Fatalf("Expected %d requests for %s with responses %v, got %d", tc.expectedRequests, tc.method, tc.statuses, *requests)
+		}
+	}
+}
+
+func TestRetryTransportTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// hang until the client gives up
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	newClient := func(rateLimiter flowcontrol.RateLimiter) *http.Client {
+		transport := newRetryTransport(http.DefaultTransport, rateLimiter)
+		transport.requestTimeout = 10 * time.Millisecond
+		return &http.Client{Transport: transport}
+	}
+
+	t.Run("Bounds unary requests", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/pods", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := newClient(flowcontrol.NewFakeAlwaysRateLimiter()).Do(req); err == nil {
+			t.Fatal("Expected the request to time out")
+		}
+	})
+
+	t.Run("Doesn't bound streams", func(t *testing.T) {
+		for _, url := range []string{"/api/v1/pods?watch=true", "/api/v1/namespaces/default/pods/web/log?follow=true", "/TapByResource"} {
+			ctx, cancel := context.WithCancel(context.Background())
+			if url == "/TapByResource" {
+				ctx = WithStream(ctx)
+			}
+			req, err := http.NewRequest(http.MethodGet, server.URL+url, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := newClient(flowcontrol.NewFakeAlwaysRateLimiter()).Do(req.WithContext(ctx))
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				t.Fatalf("Expected the stream %s to stay open, got %v", url, err)
+			case <-time.After(50 * time.Millisecond):
+			}
+			cancel()
+			<-done
+		}
+	})
+
+	t.Run("Stops waiting for the rate limiter when the request is done", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/pods", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := newClient(flowcontrol.NewFakeNeverRateLimiter()).Do(req); err == nil {
+			t.Fatal("Expected the request to time out while waiting for the rate limiter")
+		}
+	})
+}
+
+func TestWithRetries(t *testing.T) {
+	kubeAPI := &KubernetesAPI{Config: withRetries(&rest.Config{Host: "https://localhost:6443"})}
+
+	// a timeout would cut the streams of tap and top short
+	if kubeAPI.Timeout != 0 {
+		t.Fatalf("Expected no client timeout, got %s", kubeAPI.Timeout)
+	}
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if client.Timeout != 0 {
+		t.Fatalf("Expected no HTTP client timeout, got %s", client.Timeout)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	testCases := []struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"", time.Second},
+		{"2", 2 * time.Second},
+		{"60", 4 * time.Second},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", time.Second},
+	}
+
+	for _, tc := range testCases {
+		rsp := &http.Response{Header: http.Header{}}
+		if tc.retryAfter != "" {
+			rsp.Header.Set("Retry-After", tc.retryAfter)
+		}
+
+		wait := retryAfter(rsp, time.Second, 4*time.Second)
+		if wait != tc.expected {
+			t.Fatalf("Expected to wait %s for Retry-After [%s], got %s", tc.expected, tc.retryAfter, wait)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package profiles
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// ServiceProfileAPIVersion is the API version of the ServiceProfile custom
+	// resource.
+	ServiceProfileAPIVersion = "linkerd.io/v1alpha1"
+
+	// ServiceProfileKind is the kind of the ServiceProfile custom resource.
+	ServiceProfileKind = "ServiceProfile"
+)
+
+var (
+	// path segments that are most likely identifiers, rather than part of a
+	// route: numbers, UUIDs and long hex strings
+	idSegmentRegexp = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,})$`)
+
+	validMethods = map[string]bool{
+		"GET": true, "HEAD": true, "POST": true, "PUT": true, "DELETE": true,
+		"CONNECT": true, "OPTIONS": true, "TRACE": true, "PATCH": true,
+	}
+)
+
+type (
+	// ServiceProfile describes the routes of a service, and how the proxy should
+	// treat requests to each of them.
+	ServiceProfile struct {
+		APIVersion string                 `json:"apiVersion"`
+		Kind       string                 `json:"kind"`
+		Metadata   ServiceProfileMetadata `json:"metadata"`
+		Spec       ServiceProfileSpec     `json:"spec"`
+	}
+
+	ServiceProfileMetadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+
+	ServiceProfileSpec struct {
+		Routes []*RouteSpec `json:"routes"`
+	}
+
+	// RouteSpec names a class of requests to a service.
+	RouteSpec struct {
+		Name        string        `json:"name"`
+		Condition   *RequestMatch `json:"condition"`
+		IsRetryable bool          `json:"isRetryable,omitempty"`
+		Timeout     string        `json:"timeout,omitempty"`
+	}
+
+	// RequestMatch describes the requests that belong to a route.
+	RequestMatch struct {
+		Method    string `json:"method,omitempty"`
+		PathRegex string `json:"pathRegex"`
+	}
+)
+
+// NewServiceProfile returns a ServiceProfile for the given service, living in
+// the control plane namespace.
+func NewServiceProfile(service, namespace, controlPlaneNamespace string, routes []*RouteSpec) (*ServiceProfile, error) {
+	if service == "" || namespace == "" {
+		return nil, fmt.Errorf("a service profile requires both a service and a namespace")
+	}
+
+	profile := &ServiceProfile{
+		APIVersion: ServiceProfileAPIVersion,
+		Kind:       ServiceProfileKind,
+		Metadata: ServiceProfileMetadata{
+			Name:      fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+			Namespace: controlPlaneNamespace,
+		},
+		Spec: ServiceProfileSpec{
+			Routes: routes,
+		},
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// Validate returns an error describing the first invalid route in the
+// profile, or `nil` if all routes are valid.
+func (p *ServiceProfile) Validate() error {
+	names := make(map[string]bool)
+
+	for _, route := range p.Spec.Routes {
+		if route.Name == "" {
+			return fmt.Errorf("every route requires a name")
+		}
+		if names[route.Name] {
+			return fmt.Errorf("route name \"%s\" is used more than once", route.Name)
+		}
+		names[route.Name] = true
+
+		if route.Condition == nil || route.Condition.PathRegex == "" {
+			return fmt.Errorf("route \"%s\" requires a path regex", route.Name)
+		}
+		if _, err := regexp.Compile(route.Condition.PathRegex); err != nil {
+			return fmt.Errorf("route \"%s\" has an invalid path regex: %s", route.Name, err)
+		}
+		if route.Condition.Method != "" && !validMethods[route.Condition.Method] {
+			return fmt.Errorf("route \"%s\" has an invalid method: %s", route.Name, route.Condition.Method)
+		}
+
+		if route.Timeout != "" {
+			if _, err := time.ParseDuration(route.Timeout); err != nil {
+				return fmt.Errorf("route \"%s\" has an invalid timeout: %s", route.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RouteFor returns the first of the profile's routes that matches a request
+// with the given method and path, or nil if none of them do. As in the proxy,
+// a route's path regex has to match the whole path.
+func (p *ServiceProfile) RouteFor(method, path string) *RouteSpec {
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+
+	for _, route := range p.Spec.Routes {
+		if route.Condition == nil {
+			continue
+		}
+		if route.Condition.Method != "" && route.Condition.Method != method {
+			continue
+		}
+
+		pathRegex, err := regexp.Compile("^(?:" + route.Condition.PathRegex + ")$")
+		if err != nil {
+			continue
+		}
+		if pathRegex.MatchString(path) {
+			return route
+		}
+	}
+
+	return nil
+}
+
+// Render writes the profile to w as YAML.
+func (p *ServiceProfile) Render(w io.Writer) error {
+	out, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(out)
+	return err
+}
+
+// PathToRegex converts an observed request path into a regex matching that
+// path and any others that only differ by identifier segments, e.g.
+// "/books/123" becomes "/books/[^/]*".
+func PathToRegex(path string) string {
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if idSegmentRegexp.MatchString(segment) {
+			segments[i] = "[^/]*"
+		} else {
+			segments[i] = regexp.QuoteMeta(segment)
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
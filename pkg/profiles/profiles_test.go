@@ -0,0 +1,157 @@
+package profiles
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewServiceProfile(t *testing.T) {
+	t.Run("Renders a profile with all route options", func(t *testing.T) {
+		routes := []*RouteSpec{
+			&RouteSpec{
+				Name:        "GET /books/{id}",
+				Condition:   &RequestMatch{Method: "GET", PathRegex: "/books/[^/]*"},
+				IsRetryable: true,
+				Timeout:     "300ms",
+			},
+			&RouteSpec{
+				Name:      "POST /books",
+				Condition: &RequestMatch{Method: "POST", PathRegex: "/books"},
+			},
+		}
+
+		profile, err := NewServiceProfile("books", "booksapp", "linkerd", routes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var buf bytes.Buffer
+		if err := profile.Render(&buf); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := `apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: books.booksapp.svc.cluster.local
+  namespace: linkerd
+spec:
+  routes:
+  - condition:
+      method: GET
+      pathRegex: /books/[^/]*
+    isRetryable: true
+    name: GET /books/{id}
+    timeout: 300ms
+  - condition:
+      method: POST
+      pathRegex: /books
+    name: POST /books
+`
+		if buf.String() != expected {
+			t.Fatalf("Expected:\n%s\nbut got:\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("Rejects invalid routes", func(t *testing.T) {
+		testCases := []struct {
+			routes   []*RouteSpec
+			expected string
+		}{
+			{
+				[]*RouteSpec{&RouteSpec{Condition: &RequestMatch{PathRegex: "/"}}},
+				"every route requires a name",
+			},
+			{
+				[]*RouteSpec{
+					&RouteSpec{Name: "root", Condition: &RequestMatch{PathRegex: "/"}},
+					&RouteSpec{Name: "root", Condition: &RequestMatch{PathRegex: "/index"}},
+				},
+				"route name \"root\" is used more than once",
+			},
+			{
+				[]*RouteSpec{&RouteSpec{Name: "root"}},
+				"route \"root\" requires a path regex",
+			},
+			{
+				[]*RouteSpec{&RouteSpec{Name: "root", Condition: &RequestMatch{Method: "FETCH", PathRegex: "/"}}},
+				"route \"root\" has an invalid method: FETCH",
+			},
+			{
+				[]*RouteSpec{&RouteSpec{Name: "root", Condition: &RequestMatch{PathRegex: "/"}, Timeout: "1 second"}},
+				"route \"root\" has an invalid timeout: ",
+			},
+		}
+
+		for i, tc := range testCases {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				_, err := NewServiceProfile("books", "booksapp", "linkerd", tc.routes)
+				if err == nil || !strings.HasPrefix(err.Error(), tc.expected) {
+					t.Fatalf("Expected error [%s] but got [%v]", tc.expected, err)
+				}
+			})
+		}
+	})
+}
+
+func TestPathToRegex(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/", "/"},
+		{"/books", "/books"},
+		{"/books/123", "/books/[^/]*"},
+		{"/books/123/edit?draft=true", "/books/[^/]*/edit"},
+		{"/users/0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d", "/users/[^/]*"},
+		{"/static/app.js", "/static/app\\.js"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d: %s", i, tc.path), func(t *testing.T) {
+			actual := PathToRegex(tc.path)
+			if actual != tc.expected {
+				t.Fatalf("Expected %s but got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRouteFor(t *testing.T) {
+	profile, err := NewServiceProfile("books", "booksapp", "linkerd", []*RouteSpec{
+		&RouteSpec{Name: "GET /books/{id}", Condition: &RequestMatch{Method: "GET", PathRegex: "/books/[^/]*"}},
+		&RouteSpec{Name: "books", Condition: &RequestMatch{PathRegex: "/books.*"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{"GET", "/books/123", "GET /books/{id}"},
+		{"GET", "/books/123?draft=true", "GET /books/{id}"},
+		{"DELETE", "/books/123", "books"},
+		{"GET", "/books/123/edit", "books"},
+		{"GET", "/authors/123", ""},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d: %s %s", i, tc.method, tc.path), func(t *testing.T) {
+			route := profile.RouteFor(tc.method, tc.path)
+			if tc.expected == "" {
+				if route != nil {
+					t.Fatalf("Expected no route but got %s", route.Name)
+				}
+				return
+			}
+			if route == nil || route.Name != tc.expected {
+				t.Fatalf("Expected route %s but got %+v", tc.expected, route)
+			}
+		})
+	}
+}
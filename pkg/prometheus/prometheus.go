@@ -4,16 +4,19 @@ import (
 	"net/http"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/linkerd/linkerd2/pkg/tracing"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 )
 
-// returns a grpc server pre-configured with prometheus interceptors
+// returns a grpc server pre-configured with prometheus interceptors, that
+// records a span for each call when tracing is enabled
 func NewGrpcServer() *grpc.Server {
 	server := grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
 		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
+		grpc.StatsHandler(&tracing.ServerHandler{}),
 	)
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
@@ -0,0 +1,47 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// Header is the HTTP header that carries the ID of a request between the
+// web server and the public API, so that their log entries can be correlated.
+const Header = "X-Request-Id"
+
+// logField is the name of the log entry field holding the request ID.
+const logField = "request-id"
+
+type contextKey struct{}
+
+// FromHTTPRequest returns the ID in the request's header, or a new ID if the
+// request doesn't have one yet.
+func FromHTTPRequest(req *http.Request) string {
+	if id := req.Header.Get(Header); id != "" {
+		return id
+	}
+	return uuid.NewV4().String()
+}
+
+// NewContext returns a copy of ctx that carries the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Logger returns a log entry with the request ID carried by ctx as a field,
+// if any.
+func Logger(ctx context.Context) *log.Entry {
+	if id := FromContext(ctx); id != "" {
+		return log.WithField(logField, id)
+	}
+	return log.NewEntry(log.StandardLogger())
+}
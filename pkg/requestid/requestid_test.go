@@ -0,0 +1,41 @@
+package requestid
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromHTTPRequest(t *testing.T) {
+	t.Run("Returns the ID in the request's header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(Header, "abc")
+
+		if id := FromHTTPRequest(req); id != "abc" {
+			t.Fatalf("Expected request ID abc but got %s", id)
+		}
+	})
+
+	t.Run("Returns a new ID for requests without one", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+
+		first, second := FromHTTPRequest(req), FromHTTPRequest(req)
+		if first == "" || first == second {
+			t.Fatalf("Expected distinct request IDs but got %s and %s", first, second)
+		}
+	})
+}
+
+func TestContext(t *testing.T) {
+	if id := FromContext(context.Background()); id != "" {
+		t.Fatalf("Unexpected request ID: %s", id)
+	}
+
+	ctx := NewContext(context.Background(), "abc")
+	if id := FromContext(ctx); id != "abc" {
+		t.Fatalf("Expected request ID abc but got %s", id)
+	}
+	if id := Logger(ctx).Data[logField]; id != "abc" {
+		t.Fatalf("Expected a request-id log field with abc but got %v", id)
+	}
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	openzipkin "github.com/openzipkin/zipkin-go"
+	zipkinHTTP "github.com/openzipkin/zipkin-go/reporter/http"
+	"go.opencensus.io/exporter/zipkin"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// InitializeTracing exports the spans recorded by this process to the Zipkin
+// receiver of the collector at collectorAddr, e.g.
+// collector.linkerd-jaeger:9411, under the given service name. Requests are
+// traced with probability sampleRate. Tracing is left disabled if
+// collectorAddr is empty.
+func InitializeTracing(serviceName, collectorAddr string, sampleRate float64) error {
+	if collectorAddr == "" {
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(collectorAddr); err != nil {
+		return fmt.Errorf("invalid trace collector address %s; expected host:port", collectorAddr)
+	}
+	if sampleRate < 0 || sampleRate > 1 {
+		return fmt.Errorf("invalid trace sampling rate %v; must be between 0 and 1", sampleRate)
+	}
+
+	endpoint, err := openzipkin.NewEndpoint(serviceName, "")
+	if err != nil {
+		return err
+	}
+	reporter := zipkinHTTP.NewReporter(fmt.Sprintf("http://%s/api/v2/spans", collectorAddr))
+
+	trace.RegisterExporter(zipkin.NewExporter(reporter, endpoint))
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(sampleRate)})
+	return nil
+}
+
+// ServerHandler is a gRPC stats handler that records a span for each call
+// served, named after the called method. Streaming calls, such as the
+// destination service's Get, are traced until the stream ends.
+type ServerHandler struct{}
+
+// TagRPC starts the call's span and returns a context carrying it.
+func (h *ServerHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	ctx, _ = trace.StartSpan(ctx, info.FullMethodName, trace.WithSpanKind(trace.SpanKindServer))
+	return ctx
+}
+
+// HandleRPC ends the call's span once the call is over, recording the call's
+// status.
+func (h *ServerHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	end, ok := s.(*stats.End)
+	if !ok {
+		return
+	}
+
+	span := trace.FromContext(ctx)
+	if span == nil {
+		return
+	}
+	if end.Error != nil {
+		span.SetStatus(trace.Status{
+			Code:    int32(status.Code(end.Error)),
+			Message: end.Error.Error(),
+		})
+	}
+	span.End()
+}
+
+// TagConn implements stats.Handler; connections aren't traced.
+func (h *ServerHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements stats.Handler; connections aren't traced.
+func (h *ServerHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+type recordingExporter struct {
+	sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (e *recordingExporter) ExportSpan(s *trace.SpanData) {
+	e.Lock()
+	defer e.Unlock()
+	e.spans = append(e.spans, s)
+}
+
+func TestInitializeTracing(t *testing.T) {
+	testCases := []struct {
+		collectorAddr string
+		sampleRate    float64
+		expectErr     bool
+	}{
+		{"", 5, false},
+		{"collector.linkerd-jaeger", 1, true},
+		{"collector.linkerd-jaeger:9411", 1.5, true},
+		{"collector.linkerd-jaeger:9411", -1, true},
+	}
+
+	for _, tc := range testCases {
+		err := InitializeTracing("linkerd-test", tc.collectorAddr, tc.sampleRate)
+		if tc.expectErr && err == nil {
+			t.Fatalf("Expected an error for collector %q and rate %v", tc.collectorAddr, tc.sampleRate)
+		}
+		if !tc.expectErr && err != nil {
+			t.Fatalf("Unexpected error for collector %q and rate %v: %s", tc.collectorAddr, tc.sampleRate, err)
+		}
+	}
+}
+
+func TestServerHandler(t *testing.T) {
+	exporter := &recordingExporter{}
+	trace.RegisterExporter(exporter)
+	defer trace.UnregisterExporter(exporter)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+
+	handler := &ServerHandler{}
+	ctx := handler.TagRPC(context.Background(), &stats.RPCTagInfo{
+		FullMethodName: "/io.linkerd.proxy.destination.Destination/Get",
+	})
+	handler.HandleRPC(ctx, &stats.Begin{})
+
+	exporter.Lock()
+	if len(exporter.spans) != 0 {
+		t.Fatalf("Expected the span to be ended with the call, got %d spans", len(exporter.spans))
+	}
+	exporter.Unlock()
+
+	handler.HandleRPC(ctx, &stats.End{Error: status.Error(codes.Unavailable, "no endpoints")})
+
+	exporter.Lock()
+	defer exporter.Unlock()
+	if len(exporter.spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(exporter.spans))
+	}
+	span := exporter.spans[0]
+	if span.Name != "/io.linkerd.proxy.destination.Destination/Get" {
+		t.Fatalf("Unexpected span name: %s", span.Name)
+	}
+	if span.SpanKind != trace.SpanKindServer {
+		t.Fatalf("Expected a server span, got kind %d", span.SpanKind)
+	}
+	if span.Status.Code != int32(codes.Unavailable) || span.Status.Message != "rpc error: code = Unavailable desc = no endpoints" {
+		t.Fatalf("Unexpected span status: %+v", span.Status)
+	}
+}
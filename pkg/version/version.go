@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,8 +45,8 @@ func CheckClientVersion(expectedVersion string) error {
 	return nil
 }
 
-func CheckServerVersion(apiClient pb.ApiClient, expectedVersion string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func CheckServerVersion(ctx context.Context, apiClient pb.ApiClient, expectedVersion string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	rsp, err := apiClient.Version(ctx, &pb.Empty{})
@@ -60,14 +61,51 @@ func CheckServerVersion(apiClient pb.ApiClient, expectedVersion string) error {
 	return nil
 }
 
-func GetLatestVersion(uuid string, source string) (string, error) {
+// CheckUpgradeVersion returns an error if a control plane running the
+// installed version can't be upgraded to the target version: upgrades can't
+// switch release channels nor downgrade, and stable upgrades can't skip a
+// minor version.
+func CheckUpgradeVersion(installed, target string) error {
+	if installed == target {
+		return nil
+	}
+
+	channel := parseChannel(installed)
+	if channel == "" {
+		return fmt.Errorf("Unsupported version format: %s", installed)
+	}
+	if targetChannel := parseChannel(target); targetChannel != channel {
+		return fmt.Errorf("can't upgrade from the %s channel to version %s", channel, target)
+	}
+
+	from, err := parseVersionNumbers(installed)
+	if err != nil {
+		return err
+	}
+	to, err := parseVersionNumbers(target)
+	if err != nil {
+		return err
+	}
+
+	if compareVersionNumbers(to, from) < 0 {
+		return fmt.Errorf("can't downgrade from version %s to %s", parseVersion(installed), parseVersion(target))
+	}
+	if channel == "stable" && (to[0] != from[0] || to[1] > from[1]+1) {
+		return fmt.Errorf("can't upgrade from version %s to %s; upgrade to each minor version in turn",
+			parseVersion(installed), parseVersion(target))
+	}
+
+	return nil
+}
+
+func GetLatestVersion(ctx context.Context, uuid string, source string) (string, error) {
 	url := fmt.Sprintf(versionCheckURL, Version, uuid, source)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return "", err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	rsp, err := http.DefaultClient.Do(req.WithContext(ctx))
@@ -118,6 +156,45 @@ func parseChannel(version string) string {
 	return ""
 }
 
+// parseVersionNumbers returns the dot-separated numbers of a version, e.g.
+// [2 1 0] for "stable-2.1.0".
+func parseVersionNumbers(version string) ([]int, error) {
+	parts := strings.Split(parseVersion(version), ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("Unsupported version format: %s", version)
+	}
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("Unsupported version format: %s", version)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// compareVersionNumbers returns -1, 0 or 1 if a is lower, equal or higher
+// than b.
+func compareVersionNumbers(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
 func versionMismatchError(expectedVersion, actualVersion string) error {
 	channel := parseChannel(expectedVersion)
 	expectedVersionStr := parseVersion(expectedVersion)
@@ -1,6 +1,8 @@
 package version_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
@@ -27,7 +29,7 @@ func TestCheckClientVersion(t *testing.T) {
 func TestCheckServerVersion(t *testing.T) {
 	t.Run("Passes when server version matches", func(t *testing.T) {
 		apiClient := createMockPublicApi(version.Version)
-		err := version.CheckServerVersion(apiClient, version.Version)
+		err := version.CheckServerVersion(context.Background(), apiClient, version.Version)
 		if err != nil {
 			t.Fatalf("Unexpected error: %s", err)
 		}
@@ -35,13 +37,48 @@ func TestCheckServerVersion(t *testing.T) {
 
 	t.Run("Fails when server version does not match", func(t *testing.T) {
 		apiClient := createMockPublicApi(version.Version + "latest")
-		err := version.CheckServerVersion(apiClient, version.Version)
+		err := version.CheckServerVersion(context.Background(), apiClient, version.Version)
 		if err == nil {
 			t.Fatalf("Expected error, got none")
 		}
 	})
 }
 
+func TestCheckUpgradeVersion(t *testing.T) {
+	testCases := []struct {
+		installed string
+		target    string
+		expected  string
+	}{
+		{"stable-2.1.0", "stable-2.1.0", ""},
+		{"stable-2.1.0", "stable-2.1.1", ""},
+		{"stable-2.1.3", "stable-2.2.0", ""},
+		{"stable-2.1.0", "stable-2.3.0", "can't upgrade from version 2.1.0 to 2.3.0; upgrade to each minor version in turn"},
+		{"stable-2.9.0", "stable-3.0.0", "can't upgrade from version 2.9.0 to 3.0.0; upgrade to each minor version in turn"},
+		{"stable-2.2.0", "stable-2.1.0", "can't downgrade from version 2.2.0 to 2.1.0"},
+		{"edge-18.10.2", "edge-18.12.1", ""},
+		{"edge-18.10.2", "edge-18.9.4", "can't downgrade from version 18.10.2 to 18.9.4"},
+		{"edge-18.12.1", "stable-2.1.0", "can't upgrade from the edge channel to version stable-2.1.0"},
+		{"undefined", "stable-2.1.0", "Unsupported version format: undefined"},
+		{"stable-2.1.0", "stable-2.2.0-rc1", "Unsupported version format: stable-2.2.0-rc1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s to %s", tc.installed, tc.target), func(t *testing.T) {
+			err := version.CheckUpgradeVersion(tc.installed, tc.target)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expected, err)
+			}
+		})
+	}
+}
+
 func createMockPublicApi(version string) *public.MockApiClient {
 	return &public.MockApiClient{
 		VersionInfoToReturn: &pb.VersionInfo{
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/linkerd/linkerd2/proxy-init/iptables"
 	"github.com/spf13/cobra"
@@ -14,6 +15,7 @@ type rootOptions struct {
 	portsToRedirect       []int
 	inboundPortsToIgnore  []int
 	outboundPortsToIgnore []int
+	subnetsToIgnore       []string
 	simulateOnly          bool
 }
 
@@ -25,6 +27,7 @@ func newRootOptions() *rootOptions {
 		portsToRedirect:       make([]int, 0),
 		inboundPortsToIgnore:  make([]int, 0),
 		outboundPortsToIgnore: make([]int, 0),
+		subnetsToIgnore:       make([]string, 0),
 		simulateOnly:          false,
 	}
 }
@@ -51,6 +54,7 @@ func NewRootCmd() *cobra.Command {
 	cmd.PersistentFlags().IntSliceVarP(&options.portsToRedirect, "ports-to-redirect", "r", options.portsToRedirect, "Port to redirect to proxy, if no port is specified then ALL ports are redirected")
 	cmd.PersistentFlags().IntSliceVar(&options.inboundPortsToIgnore, "inbound-ports-to-ignore", options.inboundPortsToIgnore, "Inbound ports to ignore and not redirect to proxy. This has higher precedence than any other parameters.")
 	cmd.PersistentFlags().IntSliceVar(&options.outboundPortsToIgnore, "outbound-ports-to-ignore", options.outboundPortsToIgnore, "Outbound ports to ignore and not redirect to proxy. This has higher precedence than any other parameters.")
+	cmd.PersistentFlags().StringSliceVar(&options.subnetsToIgnore, "outbound-subnets-to-ignore", options.subnetsToIgnore, "Outbound subnets (in CIDR notation) to ignore and not redirect to proxy. This has higher precedence than any other parameters.")
 	cmd.PersistentFlags().BoolVar(&options.simulateOnly, "simulate", options.simulateOnly, "Don't execute any command, just print what would be executed")
 
 	return cmd
@@ -65,6 +69,12 @@ func buildFirewallConfiguration(options *rootOptions) (*iptables.FirewallConfigu
 		return nil, fmt.Errorf("--outgoing-proxy-port must be a valid TCP port number")
 	}
 
+	for _, subnet := range options.subnetsToIgnore {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			return nil, fmt.Errorf("%s is not a valid CIDR address", subnet)
+		}
+	}
+
 	firewallConfiguration := &iptables.FirewallConfiguration{
 		ProxyInboundPort:       options.incomingProxyPort,
 		ProxyOutgoingPort:      options.outgoingProxyPort,
@@ -72,6 +82,7 @@ func buildFirewallConfiguration(options *rootOptions) (*iptables.FirewallConfigu
 		PortsToRedirectInbound: options.portsToRedirect,
 		InboundPortsToIgnore:   options.inboundPortsToIgnore,
 		OutboundPortsToIgnore:  options.outboundPortsToIgnore,
+		SubnetsToIgnore:        options.subnetsToIgnore,
 		SimulateOnly:           options.simulateOnly,
 	}
 
@@ -17,6 +17,7 @@ func TestBuildFirewallConfiguration(t *testing.T) {
 			PortsToRedirectInbound: make([]int, 0),
 			InboundPortsToIgnore:   make([]int, 0),
 			OutboundPortsToIgnore:  make([]int, 0),
+			SubnetsToIgnore:        make([]string, 0),
 			ProxyInboundPort:       expectedIncomingProxyPort,
 			ProxyOutgoingPort:      expectedOutgoingProxyPort,
 			ProxyUid:               expectedProxyUserId,
@@ -71,6 +72,14 @@ func TestBuildFirewallConfiguration(t *testing.T) {
 				},
 				errorMessage: "--outgoing-proxy-port must be a valid TCP port number",
 			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					subnetsToIgnore:   []string{"10.0.0.0/8", "169.254.169.254"},
+				},
+				errorMessage: "169.254.169.254 is not a valid CIDR address",
+			},
 		} {
 			_, err := buildFirewallConfiguration(tt.options)
 			if err == nil {
@@ -25,6 +25,7 @@ type FirewallConfiguration struct {
 	PortsToRedirectInbound []int
 	InboundPortsToIgnore   []int
 	OutboundPortsToIgnore  []int
+	SubnetsToIgnore        []string
 	ProxyInboundPort       int
 	ProxyOutgoingPort      int
 	ProxyUid               int
@@ -90,6 +91,8 @@ func addOutgoingTrafficRules(commands []*exec.Cmd, firewallConfiguration Firewal
 	commands = append(commands, makeIgnoreLoopback(outputChainName, "ignore-loopback"))
 	// Ignore ports
 	commands = addRulesForIgnoredPorts(firewallConfiguration.OutboundPortsToIgnore, outputChainName, commands)
+	// Ignore subnets
+	commands = addRulesForIgnoredSubnets(firewallConfiguration.SubnetsToIgnore, outputChainName, commands)
 
 	log.Printf("Redirecting all OUTPUT to %d", firewallConfiguration.ProxyOutgoingPort)
 	commands = append(commands, makeRedirectChainToPort(outputChainName, firewallConfiguration.ProxyOutgoingPort, "redirect-all-outgoing-to-proxy-port"))
@@ -143,6 +146,15 @@ func addRulesForIgnoredPorts(portsToIgnore []int, chainName string, commands []*
 	return commands
 }
 
+func addRulesForIgnoredSubnets(subnetsToIgnore []string, chainName string, commands []*exec.Cmd) []*exec.Cmd {
+	for _, subnet := range subnetsToIgnore {
+		log.Printf("Will ignore subnet %s on chain %s", subnet, chainName)
+
+		commands = append(commands, makeIgnoreSubnet(chainName, subnet, fmt.Sprintf("ignore-subnet-%s", subnet)))
+	}
+	return commands
+}
+
 func executeCommand(firewallConfiguration FirewallConfiguration, cmd *exec.Cmd) error {
 
 	log.Printf("> %s", strings.Trim(fmt.Sprintf("%v", cmd.Args), "[]"))
@@ -210,6 +222,17 @@ func makeIgnorePort(chainName string, portToIgnore int, comment string) *exec.Cm
 		"--comment", formatComment(comment))
 }
 
+func makeIgnoreSubnet(chainName string, subnetToIgnore string, comment string) *exec.Cmd {
+	return exec.Command("iptables",
+		"-t", "nat",
+		"-A", chainName,
+		"-p", "tcp",
+		"-d", subnetToIgnore,
+		"-j", "RETURN",
+		"-m", "comment",
+		"--comment", formatComment(comment))
+}
+
 func makeIgnoreLoopback(chainName string, comment string) *exec.Cmd {
 	return exec.Command("iptables",
 		"-t", "nat",
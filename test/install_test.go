@@ -138,7 +138,12 @@ func TestCheckPostInstall(t *testing.T) {
 		t.Fatalf("Check command failed\n%s", out)
 	}
 
-	err = TestHelper.ValidateOutput(out, "check.golden")
+	golden := "check.golden"
+	if TestHelper.TLS() {
+		golden = "check.tls.golden"
+	}
+
+	err = TestHelper.ValidateOutput(out, golden)
 	if err != nil {
 		t.Fatalf("Received unexpected output\n%s", err.Error())
 	}
@@ -12,7 +12,8 @@ const routeToCrumbTitle = {
   "servicemesh": "Service Mesh",
   "overview": "Overview",
   "tap": "Tap",
-  "top": "Top"
+  "top": "Top",
+  "profiles": "Service Profiles"
 };
 
 class BreadcrumbHeader extends React.Component {
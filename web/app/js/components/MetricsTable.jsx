@@ -174,6 +174,8 @@ const columnDefinitions = (resource, namespaces, onFilterClick, showNamespaceCol
 /** @extends React.Component */
 export class MetricsTableBase extends BaseTable {
   static defaultProps = {
+    exportable: false,
+    namespace: "",
     showNamespaceColumn: true,
     metrics: []
   }
@@ -181,8 +183,11 @@ export class MetricsTableBase extends BaseTable {
   static propTypes = {
     api: PropTypes.shape({
       PrefixedLink: PropTypes.func.isRequired,
+      urlForExport: PropTypes.func,
     }).isRequired,
+    exportable: PropTypes.bool,
     metrics: PropTypes.arrayOf(processedMetricsPropType),
+    namespace: PropTypes.string,
     resource: PropTypes.string.isRequired,
     showNamespaceColumn: PropTypes.bool
   }
@@ -224,6 +229,19 @@ export class MetricsTableBase extends BaseTable {
     };
   }
 
+  renderExportLinks() {
+    let { resource, namespace } = this.props;
+
+    return (
+      <div className="metric-table-export">
+        Export:&nbsp;
+        <a href={this.api.urlForExport(resource, namespace, "csv")}>CSV</a>
+        &nbsp;|&nbsp;
+        <a href={this.api.urlForExport(resource, namespace, "json")}>JSON</a>
+      </div>
+    );
+  }
+
   render() {
     let tableData = this.preprocessMetrics();
     let namespaceFilterText = _.map(tableData.namespaces, ns => {
@@ -250,14 +268,17 @@ export class MetricsTableBase extends BaseTable {
     };
 
     return (
-      <BaseTable
-        dataSource={tableData.rows}
-        columns={columns}
-        pagination={false}
-        className="metric-table"
-        rowKey={r => `${r.namespace}/${r.name}`}
-        locale={locale}
-        size="middle" />
+      <div>
+        {this.props.exportable ? this.renderExportLinks() : null}
+        <BaseTable
+          dataSource={tableData.rows}
+          columns={columns}
+          pagination={false}
+          className="metric-table"
+          rowKey={r => `${r.namespace}/${r.name}`}
+          locale={locale}
+          size="middle" />
+      </div>
     );
   }
 }
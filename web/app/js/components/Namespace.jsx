@@ -114,6 +114,8 @@ class Namespaces extends React.Component {
         <MetricsTable
           resource={resource}
           metrics={metrics}
+          namespace={this.state.ns}
+          exportable={true}
           showNamespaceColumn={false} />
       </div>
     );
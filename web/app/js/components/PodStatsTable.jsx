@@ -0,0 +1,110 @@
+import _ from 'lodash';
+import PropTypes from 'prop-types';
+import React from 'react';
+import { Table } from 'antd';
+import { formatWithComma, styleNum } from './util/Utils.js';
+
+// formatUptime displays a proxy's uptime, received as a JSON-encoded protobuf
+// Duration such as "3725.5s", as e.g. "1h 2m 5s"
+export const formatUptime = uptime => {
+  if (_.isEmpty(uptime)) {
+    return "---";
+  }
+
+  let seconds = Math.floor(parseFloat(uptime));
+  if (Number.isNaN(seconds)) {
+    return "---";
+  }
+
+  let units = [
+    { suffix: "d", seconds: 24 * 60 * 60 },
+    { suffix: "h", seconds: 60 * 60 },
+    { suffix: "m", seconds: 60 }
+  ];
+  let parts = _.reduce(units, (mem, unit) => {
+    if (seconds >= unit.seconds || !_.isEmpty(mem)) {
+      mem.push(`${Math.floor(seconds / unit.seconds)}${unit.suffix}`);
+      seconds = seconds % unit.seconds;
+    }
+    return mem;
+  }, []);
+  parts.push(`${seconds}s`);
+
+  return parts.join(" ");
+};
+
+const formatBytes = bytes => _.isNil(bytes) ? "---" : styleNum(parseInt(bytes, 10), "B");
+
+const columns = [
+  {
+    title: "Status",
+    dataIndex: "status",
+    key: "status"
+  },
+  {
+    title: "Proxy Version",
+    dataIndex: "proxyVersion",
+    key: "proxyVersion",
+    render: version => _.isEmpty(version) ? "---" : version
+  },
+  {
+    title: "Proxy Uptime",
+    dataIndex: "proxyUptime",
+    key: "proxyUptime",
+    render: formatUptime
+  },
+  {
+    title: "TCP Connections",
+    dataIndex: "tcpStats.openConnections",
+    key: "openConnections",
+    render: connections => _.isNil(connections) ? "---" : formatWithComma(parseInt(connections, 10))
+  },
+  {
+    title: "Bytes Read",
+    dataIndex: "tcpStats.readBytesTotal",
+    key: "readBytesTotal",
+    render: formatBytes
+  },
+  {
+    title: "Bytes Written",
+    dataIndex: "tcpStats.writeBytesTotal",
+    key: "writeBytesTotal",
+    render: formatBytes
+  }
+];
+
+// PodStatsTable shows the stats of a single pod that aren't part of its
+// request metrics: its proxy's version and uptime, and the TCP connections it
+// accepted over the metrics window.
+export default class PodStatsTable extends React.Component {
+  static defaultProps = {
+    stats: null
+  }
+
+  static propTypes = {
+    stats: PropTypes.shape({
+      proxyUptime: PropTypes.string,
+      proxyVersion: PropTypes.string,
+      status: PropTypes.string,
+      tcpStats: PropTypes.shape({
+        openConnections: PropTypes.string,
+        readBytesTotal: PropTypes.string,
+        writeBytesTotal: PropTypes.string,
+      })
+    })
+  }
+
+  render() {
+    let tableData = _.isNil(this.props.stats) ? [] : [this.props.stats];
+
+    return (
+      <Table
+        dataSource={tableData}
+        columns={columns}
+        pagination={false}
+        className="metric-table"
+        rowKey={r => _.get(r, "resource.name", "")}
+        size="middle" />
+    );
+  }
+}
@@ -0,0 +1,136 @@
+import _ from 'lodash';
+import PropTypes from 'prop-types';
+import React from 'react';
+import { Table, Tooltip } from 'antd';
+
+// formatResource displays a proxy's request and limit for a resource,
+// e.g. "10m / 1", with "-" standing in for a missing value
+const formatResource = (request, limit) => {
+  if (_.isEmpty(request) && _.isEmpty(limit)) {
+    return "-";
+  }
+  return `${request || "-"} / ${limit || "-"}`;
+};
+
+const formatList = list => _.isEmpty(list) ? "-" : list.split(",").join(", ");
+
+const columns = [
+  {
+    title: "Pod",
+    dataIndex: "name",
+    key: "name"
+  },
+  {
+    title: "Version",
+    dataIndex: "proxyConfig.version",
+    key: "version"
+  },
+  {
+    title: "Log Level",
+    dataIndex: "proxyConfig.logLevel",
+    key: "logLevel"
+  },
+  {
+    title: "Inject Mode",
+    dataIndex: "proxyConfig.injectMode",
+    key: "injectMode"
+  },
+  {
+    title: "TLS",
+    dataIndex: "proxyConfig.tls",
+    key: "tls",
+    render: tls => tls ? "Yes" : "No"
+  },
+  {
+    title: "Skipped Inbound Ports",
+    dataIndex: "proxyConfig.inboundSkipPorts",
+    key: "inboundSkipPorts",
+    render: formatList
+  },
+  {
+    title: "Skipped Outbound Ports",
+    key: "outboundSkip",
+    render: row => {
+      let ports = _.get(row, "proxyConfig.outboundSkipPorts");
+      let subnets = _.get(row, "proxyConfig.outboundSkipSubnets");
+      return formatList(_.compact([ports, subnets]).join(","));
+    }
+  },
+  {
+    title: "Opaque Ports",
+    dataIndex: "proxyConfig.opaquePorts",
+    key: "opaquePorts",
+    render: formatList
+  },
+  {
+    title: "CPU (Request / Limit)",
+    key: "cpu",
+    render: row => formatResource(row.proxyConfig.cpuRequest, row.proxyConfig.cpuLimit)
+  },
+  {
+    title: "Memory (Request / Limit)",
+    key: "memory",
+    render: row => formatResource(row.proxyConfig.memoryRequest, row.proxyConfig.memoryLimit)
+  },
+  {
+    title: "Overrides",
+    dataIndex: "proxyConfig.overrides",
+    key: "overrides",
+    render: overrides => {
+      if (_.isEmpty(overrides)) {
+        return "-";
+      }
+      return (
+        <Tooltip
+          placement="top"
+          title={_.map(overrides, (value, annotation) => <div key={annotation}>{annotation}: {value}</div>)}
+          overlayStyle={{ fontSize: "12px" }}>
+          {_.size(overrides)} annotation{_.size(overrides) === 1 ? "" : "s"}
+        </Tooltip>
+      );
+    }
+  }
+];
+
+// ProxyConfigTable shows the effective configuration of the proxies of a
+// workload's pods, as injected into their specs, so that pods still running an
+// older configuration, e.g. during a rollout, stand out.
+export default class ProxyConfigTable extends React.Component {
+  static propTypes = {
+    pods: PropTypes.arrayOf(PropTypes.shape({
+      name: PropTypes.string.isRequired,
+      proxyConfig: PropTypes.shape({
+        cpuLimit: PropTypes.string,
+        cpuRequest: PropTypes.string,
+        inboundSkipPorts: PropTypes.string,
+        injectMode: PropTypes.string,
+        logLevel: PropTypes.string,
+        memoryLimit: PropTypes.string,
+        memoryRequest: PropTypes.string,
+        opaquePorts: PropTypes.string,
+        outboundSkipPorts: PropTypes.string,
+        outboundSkipSubnets: PropTypes.string,
+        overrides: PropTypes.objectOf(PropTypes.string),
+        tls: PropTypes.bool,
+        version: PropTypes.string,
+      })
+    })).isRequired
+  }
+
+  render() {
+    let tableData = _.chain(this.props.pods)
+      .filter(pod => !_.isNil(pod.proxyConfig))
+      .sortBy('name')
+      .value();
+
+    return (
+      <Table
+        dataSource={tableData}
+        columns={columns}
+        pagination={false}
+        className="metric-table"
+        rowKey={r => r.name}
+        size="middle" />
+    );
+  }
+}
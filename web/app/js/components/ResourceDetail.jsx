@@ -3,8 +3,10 @@ import AddResources from './AddResources.jsx';
 import ErrorBanner from './ErrorBanner.jsx';
 import MetricsTable from './MetricsTable.jsx';
 import Octopus from './Octopus.jsx';
+import PodStatsTable from './PodStatsTable.jsx';
 import { processNeighborData } from './util/TapUtils.jsx';
 import PropTypes from 'prop-types';
+import ProxyConfigTable from './ProxyConfigTable.jsx';
 import React from 'react';
 import { Spin } from 'antd';
 import TopModule from './TopModule.jsx';
@@ -30,12 +32,17 @@ const getResourceFromUrl = (match, pathPrefix) => {
 };
 
 export class ResourceDetailBase extends React.Component {
+  static defaultProps = {
+    readOnly: "false"
+  }
+
   static propTypes = {
     api: PropTypes.shape({
       PrefixedLink: PropTypes.func.isRequired,
     }).isRequired,
     match: PropTypes.shape({}).isRequired,
-    pathPrefix: PropTypes.string.isRequired
+    pathPrefix: PropTypes.string.isRequired,
+    readOnly: PropTypes.string
   }
 
   constructor(props) {
@@ -57,6 +64,8 @@ export class ResourceDetailBase extends React.Component {
       pollingInterval: 2000,
       resourceMetrics: [],
       podMetrics: [], // metrics for all pods whose owner is this resource
+      podStats: null, // TCP and proxy stats of the resource, if it's a pod
+      pods: [], // all pods whose owner is this resource, or the pod itself
       neighborMetrics: {
         upstream: {},
         downstream: {}
@@ -93,6 +102,7 @@ export class ResourceDetailBase extends React.Component {
     this.setState({ pendingRequests: true });
 
     let { resource } = this.state;
+    let isPod = resource.type === "pod";
 
     this.api.setCurrentRequests([
       // inbound stats for this resource
@@ -101,10 +111,12 @@ export class ResourceDetailBase extends React.Component {
       ),
       // list of all pods in this namespace (hack since we can't currently query for all pods in a resource)
       this.api.fetchPods(resource.namespace),
-      // metrics for all pods in this namespace (hack, continued)
-      this.api.fetchMetrics(
-        `${this.api.urlsForResource("pod", resource.namespace)}`
-      ),
+      // metrics for all pods in this namespace (hack, continued), or the
+      // stats of the pod itself, which don't require listing the namespace
+      isPod ? this.api.fetchPodStats(resource.namespace, resource.name) :
+        this.api.fetchMetrics(
+          `${this.api.urlsForResource("pod", resource.namespace)}`
+        ),
       // upstream resources of this resource (meshed traffic only)
       this.api.fetchMetrics(
         `${this.api.urlsForResource(resource.type)}&to_name=${resource.name}&to_type=${resource.type}&to_namespace=${resource.namespace}`
@@ -118,7 +130,8 @@ export class ResourceDetailBase extends React.Component {
     Promise.all(this.api.getCurrentPromises())
       .then(([resourceRsp, podListRsp, podMetricsRsp, upstreamRsp, downstreamRsp]) => {
         let resourceMetrics = processSingleResourceRollup(resourceRsp);
-        let podMetrics = processSingleResourceRollup(podMetricsRsp);
+        let podMetrics = isPod ? [] : processSingleResourceRollup(podMetricsRsp);
+        let podStats = isPod ? _.get(podMetricsRsp, "ok", null) : null;
         let upstreamMetrics = processSingleResourceRollup(upstreamRsp);
         let downstreamMetrics = processSingleResourceRollup(downstreamRsp);
 
@@ -135,6 +148,12 @@ export class ResourceDetailBase extends React.Component {
         }, {});
 
         let podMetricsForResource = _.filter(podMetrics, pod => podBelongsToResource[pod.namespace + "/" + pod.name]);
+        let podsForResource = _.filter(podListRsp.pods, pod => {
+          if (resource.type === "pod") {
+            return pod.name === resource.namespace + "/" + resource.name;
+          }
+          return podBelongsToResource[pod.name];
+        });
         let resourceIsMeshed = true;
         if (!_.isEmpty(this.state.resourceMetrics)) {
           resourceIsMeshed = _.get(this.state.resourceMetrics, '[0].pods.meshedPods') > 0;
@@ -144,6 +163,8 @@ export class ResourceDetailBase extends React.Component {
           resourceMetrics,
           resourceIsMeshed,
           podMetrics: podMetricsForResource,
+          podStats,
+          pods: podsForResource,
           neighborMetrics: {
             upstream: upstreamMetrics,
             downstream: downstreamMetrics
@@ -226,7 +247,7 @@ export class ResourceDetailBase extends React.Component {
         </div>
 
         {
-          !this.state.resourceIsMeshed ? null :
+          !this.state.resourceIsMeshed || this.props.readOnly === "true" ? null :
           <div className="page-section">
             <TopModule
               pathPrefix={this.props.pathPrefix}
@@ -257,6 +278,22 @@ export class ResourceDetailBase extends React.Component {
           )
         }
 
+        { _.isNil(this.state.podStats) ? null : (
+          <div className="page-section">
+            <h2 className="subsection-header">Proxy</h2>
+            <PodStatsTable stats={this.state.podStats} />
+          </div>
+          )
+        }
+
+        { !_.some(this.state.pods, 'proxyConfig') ? null : (
+          <div className="page-section">
+            <h2 className="subsection-header">Proxy Configuration</h2>
+            <ProxyConfigTable pods={this.state.pods} />
+          </div>
+          )
+        }
+
         {
           this.state.resource.type === "pod" ? null : (
             <div className="page-section">
@@ -46,7 +46,8 @@ export class ResourceListBase extends React.Component {
     return (
       <MetricsTable
         resource={this.props.resource}
-        metrics={processedMetrics} />
+        metrics={processedMetrics}
+        exportable={true} />
     );
   }
 
@@ -1,7 +1,6 @@
 import _ from 'lodash';
 import CallToAction from './CallToAction.jsx';
 import ErrorBanner from './ErrorBanner.jsx';
-import ErrorModal from './ErrorModal.jsx';
 import { incompleteMeshMessage } from './util/CopyUtils.jsx';
 import Metric from './Metric.jsx';
 import moment from 'moment';
@@ -54,16 +53,7 @@ const namespacesColumns = PrefixedLink => [
     key: "namespace",
     defaultSortOrder: "ascend",
     sorter: (a, b) => (a.namespace || "").localeCompare(b.namespace),
-    render: d => {
-      return  (
-        <React.Fragment>
-          <PrefixedLink to={"/namespaces/" + d.namespace}>{d.namespace}</PrefixedLink>
-          { _.isEmpty(d.errors) ? null :
-          <ErrorModal errors={d.errors} resourceName={d.namespace} resourceType="namespace" />
-          }
-        </React.Fragment>
-      );
-    }
+    render: d => <PrefixedLink to={"/namespaces/" + d.namespace}>{d.namespace}</PrefixedLink>
   },
   {
     title: "Meshed pods",
@@ -80,8 +70,7 @@ const namespacesColumns = PrefixedLink => [
       let containerWidth = 132;
       let percent = row.meshedPercent.get();
       let barWidth = percent < 0 ? 0 : Math.round(percent * containerWidth);
-      let barType = _.isEmpty(row.errors) ?
-        getClassification(row.meshedPods, row.failedPods) : "poor";
+      let barType = getClassification(row.meshedPods, row.failedPods);
 
 
       let percentMeshedMsg = "";
@@ -108,6 +97,62 @@ const namespacesColumns = PrefixedLink => [
   }
 ];
 
+const coverageColumns = ResourceLink => [
+  {
+    title: "Namespace",
+    dataIndex: "namespace",
+    key: "namespace",
+    defaultSortOrder: "ascend",
+    sorter: (a, b) => (a.namespace || "").localeCompare(b.namespace)
+  },
+  {
+    title: "Meshed pods",
+    dataIndex: "meshedPods",
+    key: "meshedPods",
+    className: "numeric",
+    sorter: (a, b) => numericSort(a.meshedPods, b.meshedPods)
+  },
+  {
+    title: "Unmeshed pods",
+    dataIndex: "unmeshedPods",
+    key: "unmeshedPods",
+    className: "numeric",
+    sorter: (a, b) => numericSort(a.unmeshedPods, b.unmeshedPods)
+  },
+  {
+    title: "Meshed workloads",
+    dataIndex: "meshedWorkloads",
+    key: "meshedWorkloads",
+    className: "numeric",
+    sorter: (a, b) => numericSort(a.meshedWorkloads, b.meshedWorkloads)
+  },
+  {
+    title: "Proxy versions",
+    key: "proxyVersions",
+    render: row => _.isEmpty(row.proxyVersions) ? "---" : (
+      <React.Fragment>
+        {_.map(row.proxyVersions, v => (
+          <div key={v.version}>{`${v.version || "unknown"} (${v.podCount})`}</div>
+        ))}
+      </React.Fragment>
+    )
+  },
+  {
+    title: "Workloads without injection",
+    key: "unmeshedWorkloads",
+    render: row => _.isEmpty(row.unmeshedWorkloads) ? "---" : (
+      <React.Fragment>
+        {_.map(row.unmeshedWorkloads, (w, i) => (
+          <div key={i}>
+            {w.type === "deployment" || w.type === "pod" || w.type === "replicationcontroller" ?
+              <ResourceLink resource={w} /> : `${w.type}/${w.name}`}
+          </div>
+        ))}
+      </React.Fragment>
+    )
+  }
+];
+
 const componentsToDeployNames = {
   "Destination": "controller",
   "Grafana" : "grafana",
@@ -127,10 +172,10 @@ class ServiceMesh extends React.Component {
     api: PropTypes.shape({
       cancelCurrentRequests: PropTypes.func.isRequired,
       PrefixedLink: PropTypes.func.isRequired,
-      fetchMetrics: PropTypes.func.isRequired,
+      ResourceLink: PropTypes.func.isRequired,
+      fetch: PropTypes.func.isRequired,
       getCurrentPromises: PropTypes.func.isRequired,
       setCurrentRequests: PropTypes.func.isRequired,
-      urlsForResource: PropTypes.func.isRequired,
     }).isRequired,
     controllerNamespace: PropTypes.string.isRequired,
     productName: PropTypes.string,
@@ -146,6 +191,7 @@ class ServiceMesh extends React.Component {
     this.state = {
       pollingInterval: 2000,
       components: [],
+      coverage: [],
       pendingRequests: false,
       loaded: false,
       error: null
@@ -199,23 +245,36 @@ class ServiceMesh extends React.Component {
   }
 
   extractNsStatuses(nsData) {
-    let podsByNs = _.get(nsData, ["ok", "statTables", 0, "podGroup", "rows"], []);
-    let dataPlaneNamepaces = _.map(podsByNs, ns => {
-      let meshedPods = parseInt(ns.meshedPodCount, 10);
-      let totalPods = parseInt(ns.runningPodCount, 10);
-      let failedPods = parseInt(ns.failedPodCount, 10);
+    return _.map(_.get(nsData, "namespaces", []), ns => {
+      let meshedPods = parseInt(ns.meshedPodCount, 10) || 0;
+      let totalPods = meshedPods + (parseInt(ns.unmeshedPodCount, 10) || 0);
+      let failedPods = parseInt(ns.failedPodCount, 10) || 0;
 
       return {
-        namespace: ns.resource.name,
-        meshedPodsStr: ns.meshedPodCount + "/" + ns.runningPodCount,
+        namespace: ns.name,
+        meshedPodsStr: meshedPods + "/" + totalPods,
         meshedPercent: new Percentage(meshedPods, totalPods),
         meshedPods,
         totalPods,
-        failedPods,
-        errors: ns.errorsByPod
+        failedPods
+      };
+    });
+  }
+
+  extractCoverage(coverageData) {
+    return _.map(_.get(coverageData, "namespaces", []), ns => {
+      return {
+        namespace: ns.namespace,
+        meshedPods: parseInt(ns.meshedPodCount, 10) || 0,
+        unmeshedPods: parseInt(ns.unmeshedPodCount, 10) || 0,
+        meshedWorkloads: parseInt(ns.meshedWorkloadCount, 10) || 0,
+        proxyVersions: _.map(ns.proxyVersions, v => ({
+          version: v.version,
+          podCount: parseInt(v.podCount, 10) || 0
+        })),
+        unmeshedWorkloads: ns.unmeshedWorkloads
       };
     });
-    return _.compact(dataPlaneNamepaces);
   }
 
   loadFromServer() {
@@ -226,14 +285,16 @@ class ServiceMesh extends React.Component {
 
     this.api.setCurrentRequests([
       this.api.fetchPods(this.props.controllerNamespace),
-      this.api.fetchMetrics(this.api.urlsForResource("namespace"))
+      this.api.fetch("/api/namespaces"),
+      this.api.fetch("/api/mesh-coverage")
     ]);
 
     this.serverPromise = Promise.all(this.api.getCurrentPromises())
-      .then(([pods, nsStats]) => {
+      .then(([pods, nsStats, coverage]) => {
         this.setState({
           components: this.getControllerComponentData(pods),
           nsStatuses: this.extractNsStatuses(nsStats),
+          coverage: this.extractCoverage(coverage),
           pendingRequests: false,
           loaded: true,
           error: null
@@ -350,6 +411,27 @@ class ServiceMesh extends React.Component {
     );
   }
 
+  renderMeshCoverageTable() {
+    if (_.isEmpty(this.state.coverage)) {
+      return null;
+    }
+
+    return (
+      <div className="mesh-section">
+        <div className="clearfix header-with-metric">
+          <div className="subsection-header">Mesh coverage</div>
+        </div>
+        <Table
+          className="metric-table service-mesh-table"
+          dataSource={this.state.coverage}
+          columns={coverageColumns(this.api.ResourceLink)}
+          rowKey="namespace"
+          pagination={false}
+          size="middle" />
+      </div>
+    );
+  }
+
   render() {
     return (
       <div className="page-content">
@@ -367,6 +449,8 @@ class ServiceMesh extends React.Component {
             </Row>
 
             {this.renderNamespaceStatusTable()}
+
+            {this.renderMeshCoverageTable()}
           </div>
         )}
       </div>
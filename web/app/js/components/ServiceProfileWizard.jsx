@@ -0,0 +1,256 @@
+import _ from 'lodash';
+import ErrorBanner from './ErrorBanner.jsx';
+import PropTypes from 'prop-types';
+import React from 'react';
+import { withContext } from './util/AppContext.jsx';
+import { wsCloseCodes } from './util/TapUtils.jsx';
+import { Button, Checkbox, Col, Form, Input, Row, Table } from 'antd';
+import 'whatwg-fetch';
+
+const colSpan = 5;
+const rowGutter = 16;
+const observeDurationMs = 10000;
+
+// replace path segments that look like identifiers with a placeholder, so that
+// e.g. /books/1 and /books/2 are suggested as a single route
+const routeTemplate = path => {
+  return _.map(_.split(_.split(path, "?")[0], "/"), segment => {
+    return /^([0-9]+|[0-9a-fA-F-]{16,})$/.test(segment) ? "{id}" : segment;
+  }).join("/");
+};
+
+class ServiceProfileWizard extends React.Component {
+  static propTypes = {
+    pathPrefix: PropTypes.string.isRequired
+  }
+
+  constructor(props) {
+    super(props);
+
+    this.state = {
+      error: null,
+      query: {
+        namespace: "",
+        service: "",
+        resource: ""
+      },
+      routes: {},
+      observing: false,
+      profile: ""
+    };
+  }
+
+  componentWillUnmount() {
+    this.stopObserving();
+  }
+
+  onWebsocketOpen = () => {
+    this.ws.send(JSON.stringify({
+      id: "profile-web",
+      resource: this.state.query.resource,
+      namespace: this.state.query.namespace,
+      maxRps: 0
+    }));
+  }
+
+  onWebsocketRecv = e => {
+    let d = JSON.parse(e.data);
+    let req = _.get(d, "http.requestInit");
+    if (_.isNil(req) || d.proxyDirection !== "INBOUND") {
+      return;
+    }
+
+    let method = _.get(req, "method.registered", "");
+    let name = `${method} ${routeTemplate(req.path)}`;
+    if (_.has(this.state.routes, name)) {
+      return;
+    }
+
+    this.setState(prevState => ({
+      routes: {
+        ...prevState.routes,
+        [name]: {
+          key: name,
+          name,
+          method,
+          path: req.path,
+          isRetryable: false,
+          timeout: "",
+          included: true
+        }
+      }
+    }));
+  }
+
+  onWebsocketClose = e => {
+    this.stopObserving();
+    if (!e.wasClean && e.code !== 1006) {
+      this.setState({
+        error: {
+          error: `Websocket close error [${e.code}: ${wsCloseCodes[e.code]}] ${e.reason ? ":" : ""} ${e.reason}`
+        }
+      });
+    }
+  }
+
+  handleQueryChange = name => e => {
+    let query = _.clone(this.state.query);
+    query[name] = e.target.value;
+    this.setState({ query });
+  }
+
+  handleRouteChange = (key, field, value) => {
+    let routes = _.cloneDeep(this.state.routes);
+    routes[key][field] = value;
+    this.setState({ routes, profile: "" });
+  }
+
+  startObserving = () => {
+    let protocol = window.location.protocol === "https:" ? "wss" : "ws";
+    let tapWebSocket = `${protocol}://${window.location.host}${this.props.pathPrefix}/api/tap`;
+
+    this.setState({ observing: true, error: null });
+
+    this.ws = new WebSocket(tapWebSocket);
+    this.ws.onmessage = this.onWebsocketRecv;
+    this.ws.onclose = this.onWebsocketClose;
+    this.ws.onopen = this.onWebsocketOpen;
+    this.timerId = window.setTimeout(this.stopObserving, observeDurationMs);
+  }
+
+  stopObserving = () => {
+    window.clearTimeout(this.timerId);
+    if (this.ws) {
+      this.ws.close(1000);
+      this.ws = null;
+    }
+    this.setState({ observing: false });
+  }
+
+  generateProfile = () => {
+    let routes = _(this.state.routes)
+      .values()
+      .filter("included")
+      .map(r => _.pick(r, ["name", "method", "path", "isRetryable", "timeout"]))
+      .value();
+
+    fetch(`${this.props.pathPrefix}/api/service-profile`, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({
+        service: this.state.query.service,
+        namespace: this.state.query.namespace,
+        routes
+      })
+    })
+      .then(rsp => {
+        if (rsp.ok) {
+          return rsp.text();
+        }
+        return rsp.json().then(e => { throw { status: rsp.status, error: e.error }; });
+      })
+      .then(profile => this.setState({ profile, error: null }))
+      .catch(error => this.setState({ error }));
+  }
+
+  renderRouteTable() {
+    let columns = [
+      {
+        title: "Include",
+        key: "included",
+        render: r => <Checkbox checked={r.included} onChange={e => this.handleRouteChange(r.key, "included", e.target.checked)} />
+      },
+      {
+        title: "Route Name",
+        key: "name",
+        render: r => <Input value={r.name} onChange={e => this.handleRouteChange(r.key, "name", e.target.value)} />
+      },
+      { title: "Method", dataIndex: "method", key: "method" },
+      { title: "Observed Path", dataIndex: "path", key: "path" },
+      {
+        title: "Retryable",
+        key: "isRetryable",
+        render: r => <Checkbox checked={r.isRetryable} onChange={e => this.handleRouteChange(r.key, "isRetryable", e.target.checked)} />
+      },
+      {
+        title: "Timeout",
+        key: "timeout",
+        render: r => <Input placeholder="e.g. 300ms" value={r.timeout} onChange={e => this.handleRouteChange(r.key, "timeout", e.target.value)} />
+      }
+    ];
+
+    return (
+      <Table
+        dataSource={_.sortBy(_.values(this.state.routes), "name")}
+        columns={columns}
+        pagination={false}
+        size="middle" />
+    );
+  }
+
+  renderProfile() {
+    let href = `data:text/yaml;charset=utf-8,${encodeURIComponent(this.state.profile)}`;
+    let fileName = `${this.state.query.service}.${this.state.query.namespace}.svc.cluster.local.yml`;
+
+    return (
+      <div className="service-profile">
+        <pre>{this.state.profile}</pre>
+        <a href={href} download={fileName}>Download YAML</a>
+        <p>Apply it with <code>kubectl apply -f {fileName}</code></p>
+      </div>
+    );
+  }
+
+  render() {
+    let { query } = this.state;
+
+    return (
+      <div>
+        {!this.state.error ? null :
+        <ErrorBanner message={this.state.error} onHideMessage={() => this.setState({ error: null })} />}
+
+        <Form layout="vertical">
+          <Row gutter={rowGutter}>
+            <Col span={colSpan}>
+              <Form.Item label="Namespace">
+                <Input value={query.namespace} onChange={this.handleQueryChange("namespace")} />
+              </Form.Item>
+            </Col>
+            <Col span={colSpan}>
+              <Form.Item label="Service">
+                <Input value={query.service} onChange={this.handleQueryChange("service")} />
+              </Form.Item>
+            </Col>
+            <Col span={colSpan}>
+              <Form.Item label="Resource to observe">
+                <Input placeholder="e.g. deploy/books" value={query.resource} onChange={this.handleQueryChange("resource")} />
+              </Form.Item>
+            </Col>
+            <Col span={colSpan}>
+              <Form.Item label=" ">
+                {
+                  this.state.observing ?
+                    <Button type="primary" onClick={this.stopObserving}>Stop</Button> :
+                    <Button
+                      type="primary"
+                      disabled={_.isEmpty(query.namespace) || _.isEmpty(query.resource)}
+                      onClick={this.startObserving}>Observe routes</Button>
+                }
+              </Form.Item>
+            </Col>
+          </Row>
+        </Form>
+
+        {this.renderRouteTable()}
+
+        <Button
+          disabled={_.isEmpty(query.service) || _.isEmpty(this.state.routes)}
+          onClick={this.generateProfile}>Generate ServiceProfile</Button>
+
+        {_.isEmpty(this.state.profile) ? null : this.renderProfile()}
+      </div>
+    );
+  }
+}
+
+export default withContext(ServiceProfileWizard);
@@ -26,16 +26,20 @@ const classificationLabels = {
 
 class Sidebar extends React.Component {
   static defaultProps = {
-    productName: 'controller'
+    disableTelemetry: "false",
+    productName: 'controller',
+    readOnly: "false"
   }
 
   static propTypes = {
     api: PropTypes.shape({
       PrefixedLink: PropTypes.func.isRequired,
     }).isRequired,
+    disableTelemetry: PropTypes.string,
     location: ReactRouterPropTypes.location.isRequired,
     pathPrefix: PropTypes.string.isRequired,
     productName: PropTypes.string,
+    readOnly: PropTypes.string,
     releaseVersion: PropTypes.string.isRequired,
     uuid: PropTypes.string.isRequired,
   }
@@ -65,7 +69,9 @@ class Sidebar extends React.Component {
   }
 
   componentDidMount() {
-    this.fetchVersion();
+    if (this.props.disableTelemetry !== "true") {
+      this.fetchVersion();
+    }
     this.startServerPolling();
   }
 
@@ -196,19 +202,38 @@ class Sidebar extends React.Component {
               </PrefixedLink>
             </Menu.Item>
 
-            <Menu.Item className="sidebar-menu-item" key="/tap">
-              <PrefixedLink to="/tap">
-                <Icon><i className="fas fa-microscope" /></Icon>
-                <span>Tap</span>
-              </PrefixedLink>
-            </Menu.Item>
+            {
+              this.props.readOnly === "true" ? null : (
+                <Menu.Item className="sidebar-menu-item" key="/tap">
+                  <PrefixedLink to="/tap">
+                    <Icon><i className="fas fa-microscope" /></Icon>
+                    <span>Tap</span>
+                  </PrefixedLink>
+                </Menu.Item>
+              )
+            }
 
-            <Menu.Item className="sidebar-menu-item" key="/top">
-              <PrefixedLink to="/top">
-                <Icon><i className="fas fa-stream" /></Icon>
-                <span>Top</span>
-              </PrefixedLink>
-            </Menu.Item>
+            {
+              this.props.readOnly === "true" ? null : (
+                <Menu.Item className="sidebar-menu-item" key="/top">
+                  <PrefixedLink to="/top">
+                    <Icon><i className="fas fa-stream" /></Icon>
+                    <span>Top</span>
+                  </PrefixedLink>
+                </Menu.Item>
+              )
+            }
+
+            {
+              this.props.readOnly === "true" ? null : (
+                <Menu.Item className="sidebar-menu-item" key="/profiles">
+                  <PrefixedLink to="/profiles">
+                    <Icon type="file-text" />
+                    <span>Service Profiles</span>
+                  </PrefixedLink>
+                </Menu.Item>
+              )
+            }
 
             <Menu.Item className="sidebar-menu-item" key="/servicemesh">
               <PrefixedLink to="/servicemesh">
@@ -315,6 +340,7 @@ class Sidebar extends React.Component {
             this.state.collapsed ? null : (
               <Version
                 isLatest={this.state.isLatest}
+                versionCheckDisabled={this.props.disableTelemetry === "true"}
                 latestVersion={this.state.latestVersion}
                 releaseVersion={this.props.releaseVersion}
                 error={this.state.error}
@@ -7,7 +7,16 @@ import TapQueryCliCmd from './TapQueryCliCmd.jsx';
 import TapQueryForm from './TapQueryForm.jsx';
 import { withContext } from './util/AppContext.jsx';
 import { addUrlProps, UrlQueryParamTypes } from 'react-url-query';
-import { emptyTapQuery, processTapEvent, setMaxRps, wsCloseCodes } from './util/TapUtils.jsx';
+import {
+  droppedEventsMessage,
+  emptyTapQuery,
+  maxTapReconnectAttempts,
+  parseTapStreamStatus,
+  processTapEvent,
+  setMaxRps,
+  tapReconnectDelayMs,
+  wsCloseCodes
+} from './util/TapUtils.jsx';
 import './../../css/tap.css';
 
 const urlPropsQueryConfig = {
@@ -33,6 +42,9 @@ class Tap extends React.Component {
     this.tapResultsById = {};
     this.throttledWebsocketRecvHandler = _.throttle(this.updateTapResults, 500);
     this.loadFromServer = this.loadFromServer.bind(this);
+    this.reconnectToken = null;
+    this.reconnectAttempts = 0;
+    this.reconnectTimerId = null;
 
     this.state = {
       tapResultsById: this.tapResultsById,
@@ -53,6 +65,7 @@ class Tap extends React.Component {
       maxLinesToDisplay: 40,
       tapRequestInProgress: false,
       tapIsClosing: false,
+      droppedEvents: 0,
       pollingInterval: 10000,
       pendingRequests: false
     };
@@ -68,6 +81,7 @@ class Tap extends React.Component {
 
   componentWillUnmount() {
     this._isMounted = false;
+    window.clearTimeout(this.reconnectTimerId);
     if (this.ws) {
       this.ws.close(1000);
     }
@@ -81,7 +95,8 @@ class Tap extends React.Component {
 
     this.ws.send(JSON.stringify({
       id: "tap-web",
-      ...query
+      ...query,
+      reconnectToken: this.reconnectToken || ""
     }));
     this.setState({
       error: null
@@ -89,11 +104,28 @@ class Tap extends React.Component {
   }
 
   onWebsocketRecv = e => {
+    let status = parseTapStreamStatus(e.data);
+    if (!_.isNil(status)) {
+      this.reconnectToken = status.reconnectToken;
+      this.reconnectAttempts = 0;
+      this.setState({ droppedEvents: status.dropped });
+      return;
+    }
+
     this.indexTapResult(e.data);
     this.throttledWebsocketRecvHandler();
   }
 
   onWebsocketClose = e => {
+    // resume the stream if the connection dropped, rather than being closed
+    // by either end
+    if (!e.wasClean && this._isMounted && this.state.tapRequestInProgress && !this.state.tapIsClosing &&
+      !_.isNil(this.reconnectToken) && this.reconnectAttempts < maxTapReconnectAttempts) {
+      this.reconnectAttempts++;
+      this.reconnectTimerId = window.setTimeout(this.openWebSocket, tapReconnectDelayMs);
+      return;
+    }
+
     this.stopTapStreaming();
     /* We ignore any abnormal closure since it doesn't matter as long as
     the connection to the websocket is closed. This is also a workaround
@@ -204,12 +236,21 @@ class Tap extends React.Component {
 
   startTapStreaming() {
     this.tapResultsById = {};
+    this.reconnectToken = null;
+    this.reconnectAttempts = 0;
 
     this.setState({
       tapRequestInProgress: true,
-      tapResultsById: this.tapResultsById
+      tapResultsById: this.tapResultsById,
+      droppedEvents: 0
     });
 
+    this.openWebSocket();
+  }
+
+  openWebSocket = () => {
+    this.reconnectTimerId = null;
+
     let protocol = window.location.protocol === "https:" ? "wss" : "ws";
     let tapWebSocket = `${protocol}://${window.location.host}${this.props.pathPrefix}/api/tap`;
 
@@ -237,6 +278,14 @@ class Tap extends React.Component {
   }
 
   handleTapStop = () => {
+    if (!_.isNil(this.reconnectTimerId)) {
+      // there's no connection to close while waiting to reconnect
+      window.clearTimeout(this.reconnectTimerId);
+      this.reconnectTimerId = null;
+      this.stopTapStreaming();
+      return;
+    }
+
     this.ws.close(1000);
     this.setState({ tapIsClosing: true });
   }
@@ -315,6 +364,9 @@ class Tap extends React.Component {
 
         <TapQueryCliCmd cmdName="tap" query={this.state.query} />
 
+        {this.state.droppedEvents === 0 ? null :
+        <p className="tap-dropped-events">{droppedEventsMessage(this.state.droppedEvents)}</p>}
+
         <TapEventTable
           resource={this.state.query.resource}
           tableRows={tableRows} />
@@ -5,7 +5,15 @@ import PropTypes from 'prop-types';
 import React from 'react';
 import TopEventTable from './TopEventTable.jsx';
 import { withContext } from './util/AppContext.jsx';
-import { processTapEvent, setMaxRps, wsCloseCodes } from './util/TapUtils.jsx';
+import {
+  droppedEventsMessage,
+  maxTapReconnectAttempts,
+  parseTapStreamStatus,
+  processTapEvent,
+  setMaxRps,
+  tapReconnectDelayMs,
+  wsCloseCodes
+} from './util/TapUtils.jsx';
 
 class TopModule extends React.Component {
   static propTypes = {
@@ -40,10 +48,14 @@ class TopModule extends React.Component {
     this.topEventIndex = {};
     this.throttledWebsocketRecvHandler = _.throttle(this.updateTapEventIndexState, 500);
     this.updateTapClosingState = this.props.updateTapClosingState;
+    this.reconnectToken = null;
+    this.reconnectAttempts = 0;
+    this.reconnectTimerId = null;
 
     this.state = {
       error: null,
-      topEventIndex: {}
+      topEventIndex: {},
+      droppedEvents: 0
     };
   }
 
@@ -77,7 +89,8 @@ class TopModule extends React.Component {
 
     this.ws.send(JSON.stringify({
       id: "top-web",
-      ...query
+      ...query,
+      reconnectToken: this.reconnectToken || ""
     }));
     this.setState({
       error: null
@@ -85,12 +98,29 @@ class TopModule extends React.Component {
   }
 
   onWebsocketRecv = e => {
+    let status = parseTapStreamStatus(e.data);
+    if (!_.isNil(status)) {
+      this.reconnectToken = status.reconnectToken;
+      this.reconnectAttempts = 0;
+      this.setState({ droppedEvents: status.dropped });
+      return;
+    }
+
     this.indexTapResult(e.data);
     this.props.updateNeighbors(e.data);
     this.throttledWebsocketRecvHandler();
   }
 
   onWebsocketClose = e => {
+    // resume the stream if the connection dropped, rather than being closed
+    // by either end
+    if (!e.wasClean && this.props.startTap && !_.isNil(this.reconnectToken) &&
+      this.reconnectAttempts < maxTapReconnectAttempts) {
+      this.reconnectAttempts++;
+      this.reconnectTimerId = window.setTimeout(this.openWebSocket, tapReconnectDelayMs);
+      return;
+    }
+
     this.updateTapClosingState(false);
     /* We ignore any abnormal closure since it doesn't matter as long as
     the connection to the websocket is closed. This is also a workaround
@@ -307,6 +337,15 @@ class TopModule extends React.Component {
 
   startTapStreaming() {
     this.clearTopTable();
+    this.reconnectToken = null;
+    this.reconnectAttempts = 0;
+    this.setState({ droppedEvents: 0 });
+
+    this.openWebSocket();
+  }
+
+  openWebSocket = () => {
+    this.reconnectTimerId = null;
 
     let protocol = window.location.protocol === "https:" ? "wss" : "ws";
     let tapWebSocket = `${protocol}://${window.location.host}${this.props.pathPrefix}/api/tap`;
@@ -319,6 +358,14 @@ class TopModule extends React.Component {
   }
 
   stopTapStreaming() {
+    if (!_.isNil(this.reconnectTimerId)) {
+      // there's no connection to close while waiting to reconnect
+      window.clearTimeout(this.reconnectTimerId);
+      this.reconnectTimerId = null;
+      this.updateTapClosingState(false);
+      return;
+    }
+
     this.closeWebSocket();
   }
 
@@ -337,6 +384,8 @@ class TopModule extends React.Component {
     return (
       <React.Fragment>
         {this.banner()}
+        {this.state.droppedEvents === 0 ? null :
+        <p className="tap-dropped-events">{droppedEventsMessage(this.state.droppedEvents)}</p>}
         <TopEventTable resourceType={resourceType} tableRows={tableRows} />
       </React.Fragment>
     );
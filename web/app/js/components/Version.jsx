@@ -8,7 +8,8 @@ class Version extends React.Component {
   static defaultProps = {
     error: null,
     latestVersion: '',
-    productName: 'controller'
+    productName: 'controller',
+    versionCheckDisabled: false
   }
 
   static propTypes = {
@@ -17,6 +18,7 @@ class Version extends React.Component {
     latestVersion: PropTypes.string,
     productName: PropTypes.string,
     releaseVersion: PropTypes.string.isRequired,
+    versionCheckDisabled: PropTypes.bool,
   }
 
   numericVersion = version => {
@@ -36,7 +38,11 @@ class Version extends React.Component {
   }
 
   renderVersionCheck = () => {
-    const {latestVersion, error, isLatest} = this.props;
+    const {latestVersion, error, isLatest, versionCheckDisabled} = this.props;
+
+    if (versionCheckDisabled) {
+      return "Version check disabled.";
+    }
 
     if (!latestVersion) {
       return (
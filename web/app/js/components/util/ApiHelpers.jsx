@@ -54,6 +54,7 @@ export const apiErrorPropType = PropTypes.shape({
 const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
   let metricsWindow = defaultMetricsWindow;
   const podsPath = `/api/pods`;
+  const podStatsPath = `/api/pod-stats`;
 
   const validMetricsWindows = {
     "10s": "10 minutes",
@@ -88,6 +89,11 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     return apiFetch(podsPath);
   };
 
+  // the stats of a single pod, including its TCP stats and its proxy's uptime
+  const fetchPodStats = (namespace, name) => {
+    return fetchMetrics(`${podStatsPath}?namespace=${namespace}&name=${name}`);
+  };
+
   const getMetricsWindow = () => metricsWindow;
   const getMetricsWindowDisplayText = () => validMetricsWindows[metricsWindow];
 
@@ -102,6 +108,13 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     return !namespace ? baseUrl + '&all_namespaces=true' : baseUrl + '&namespace=' + namespace;
   };
 
+  // a link to download the stats for the given resource as a csv or json file
+  const urlForExport = (type, namespace, format) => {
+    let url = '/api/tps-reports/export?resource_type=' + type + '&format=' + format + '&window=' + getMetricsWindow();
+    url = !namespace ? url + '&all_namespaces=true' : url + '&namespace=' + namespace;
+    return `${pathPrefix}${url}`;
+  };
+
   // maintain a list of a component's requests,
   // convenient for providing a cancel() functionality
   let currentRequests = [];
@@ -177,11 +190,13 @@ const ApiHelpers = (pathPrefix, defaultMetricsWindow = '1m') => {
     fetch: apiFetch,
     fetchMetrics,
     fetchPods,
+    fetchPodStats,
     getMetricsWindow,
     setMetricsWindow,
     getValidMetricsWindows: () => _.keys(validMetricsWindows),
     getMetricsWindowDisplayText,
     urlsForResource,
+    urlForExport,
     PrefixedLink,
     ResourceLink,
     setCurrentRequests,
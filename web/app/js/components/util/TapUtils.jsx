@@ -16,6 +16,24 @@ export const setMaxRps = query => {
   }
 };
 
+// how long to wait before reconnecting to a tap stream that was disconnected,
+// and how many times to try
+export const tapReconnectDelayMs = 1000;
+export const maxTapReconnectAttempts = 5;
+
+// the tap websocket interleaves stream status messages with the tap events.
+// they carry the token to reconnect to the stream with, and the number of
+// events that were dropped because the browser couldn't keep up
+export const parseTapStreamStatus = jsonString => {
+  if (!_.startsWith(jsonString, '{"reconnectToken"')) {
+    return null;
+  }
+  return JSON.parse(jsonString);
+};
+
+export const droppedEventsMessage = dropped =>
+  `${dropped} tap ${dropped === 1 ? "event was" : "events were"} dropped because the browser couldn't keep up`;
+
 // use a generator to get this object, to prevent it from being overwritten
 export const emptyTapQuery = () => ({
   resource: "",
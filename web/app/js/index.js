@@ -11,6 +11,7 @@ import ResourceDetail from './components/ResourceDetail.jsx';
 import ResourceList from './components/ResourceList.jsx';
 import { RouterToUrlQuery } from 'react-url-query';
 import ServiceMesh from './components/ServiceMesh.jsx';
+import ServiceProfileWizard from './components/ServiceProfileWizard.jsx';
 import Sidebar from './components/Sidebar.jsx';
 import Tap from './components/Tap.jsx';
 import Top from './components/Top.jsx';
@@ -27,6 +28,10 @@ if (proxyPathMatch) {
   pathPrefix = proxyPathMatch[0];
 }
 
+// in read-only mode, the pages that observe live traffic or generate configs
+// aren't available
+let readOnly = appData.readOnly === "true";
+
 const context = {
   ...appData,
   api: ApiHelpers(pathPrefix),
@@ -52,8 +57,9 @@ let applicationHtml = (
                   <Route path={`${pathPrefix}/namespaces/:namespace/pods/:pod`} component={ResourceDetail} />
                   <Route path={`${pathPrefix}/namespaces/:namespace/deployments/:deployment`} component={ResourceDetail} />
                   <Route path={`${pathPrefix}/namespaces/:namespace/replicationcontrollers/:replicationcontroller`} component={ResourceDetail} />
-                  <Route path={`${pathPrefix}/tap`} component={Tap} />
-                  <Route path={`${pathPrefix}/top`} component={Top} />
+                  { readOnly ? null : <Route path={`${pathPrefix}/tap`} component={Tap} /> }
+                  { readOnly ? null : <Route path={`${pathPrefix}/top`} component={Top} /> }
+                  { readOnly ? null : <Route path={`${pathPrefix}/profiles`} component={ServiceProfileWizard} /> }
                   <Route
                     path={`${pathPrefix}/namespaces`}
                     render={() => <ResourceList resource="namespace" />} />
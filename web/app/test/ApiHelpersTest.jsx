@@ -283,6 +283,16 @@ describe('ApiHelpers', () => {
     });
   });
 
+  describe('fetchPodStats', () => {
+    it('fetches the stats of a single pod over the metrics window', () => {
+      api = ApiHelpers("/random/prefix");
+      api.fetchPodStats("emojivoto", "web-1");
+
+      expect(fetchStub.calledOnce).to.be.true;
+      expect(fetchStub.args[0][0]).to.equal('/random/prefix/api/pod-stats?namespace=emojivoto&name=web-1&window=1m');
+    });
+  });
+
   describe('urlsForResource', () => {
     it('returns the correct rollup url for deployment overviews', () => {
       api = ApiHelpers('/go/my/own/way');
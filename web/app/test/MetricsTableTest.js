@@ -75,4 +75,21 @@ describe('Tests for <MetricsTableBase>', () => {
     expect(table.props().columns).to.have.length(8);
   });
 
+  it('renders export links when exportable', () => {
+    const component = shallow(
+      <MetricsTableBase
+        {...defaultProps}
+        metrics={[]}
+        namespace="emojivoto"
+        exportable={true}
+        resource="deployment" />
+    );
+
+    const links = component.find(".metric-table-export a");
+
+    expect(links).to.have.length(2);
+    expect(links.at(0).props().href).to.equal('/api/tps-reports/export?resource_type=deployment&format=csv&window=1m&namespace=emojivoto');
+    expect(links.at(1).props().href).to.equal('/api/tps-reports/export?resource_type=deployment&format=json&window=1m&namespace=emojivoto');
+  });
+
 });
@@ -0,0 +1,57 @@
+import Adapter from 'enzyme-adapter-react-16';
+import { expect } from 'chai';
+import React from 'react';
+import { Table } from 'antd';
+import Enzyme, { shallow } from 'enzyme';
+import PodStatsTable, { formatUptime } from '../js/components/PodStatsTable.jsx';
+
+Enzyme.configure({ adapter: new Adapter() });
+
+describe('Tests for <PodStatsTable>', () => {
+  const stats = {
+    resource: { namespace: "emojivoto", type: "pod", name: "web-1" },
+    timeWindow: "1m",
+    status: "Running",
+    meshed: true,
+    tcpStats: {
+      openConnections: "12",
+      readBytesTotal: "2048",
+      writeBytesTotal: "0"
+    },
+    proxyUptime: "3725.500s",
+    proxyVersion: "edge-18.10.1"
+  };
+
+  it('renders a single row for the pod', () => {
+    const component = shallow(<PodStatsTable stats={stats} />);
+    const table = component.find(Table);
+
+    expect(table).to.have.length(1);
+    expect(table.props().dataSource).to.have.length(1);
+    expect(table.props().dataSource[0].proxyVersion).to.equal("edge-18.10.1");
+  });
+
+  it('renders an empty table without stats', () => {
+    const component = shallow(<PodStatsTable />);
+
+    expect(component.find(Table).props().dataSource).to.have.length(0);
+  });
+
+  it('formats the TCP stats', () => {
+    const component = shallow(<PodStatsTable stats={stats} />);
+    const columns = component.find(Table).props().columns;
+    const render = key => columns.find(c => c.key === key).render;
+
+    expect(render("openConnections")("12")).to.equal("12");
+    expect(render("openConnections")(undefined)).to.equal("---");
+    expect(render("readBytesTotal")("2048")).to.equal("2.048kB");
+    expect(render("writeBytesTotal")("0")).to.equal("0B");
+  });
+
+  it('formats the proxy uptime', () => {
+    expect(formatUptime("3725.500s")).to.equal("1h 2m 5s");
+    expect(formatUptime("90000s")).to.equal("1d 1h 0m 0s");
+    expect(formatUptime("42s")).to.equal("42s");
+    expect(formatUptime(undefined)).to.equal("---");
+  });
+});
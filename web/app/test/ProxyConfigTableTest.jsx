@@ -0,0 +1,59 @@
+import Adapter from 'enzyme-adapter-react-16';
+import { expect } from 'chai';
+import ProxyConfigTable from '../js/components/ProxyConfigTable.jsx';
+import React from 'react';
+import { Table } from 'antd';
+import Enzyme, { shallow } from 'enzyme';
+
+Enzyme.configure({ adapter: new Adapter() });
+
+describe('Tests for <ProxyConfigTable>', () => {
+  const pods = [
+    {
+      name: "emojivoto/web-2",
+      proxyConfig: {
+        version: "edge-18.10.1",
+        logLevel: "warn,linkerd2_proxy=info",
+        injectMode: "init-container",
+        inboundSkipPorts: "4190,4191",
+        tls: true,
+        cpuRequest: "10m",
+        overrides: { "linkerd.io/opaque-ports": "3306" }
+      }
+    },
+    {
+      name: "emojivoto/web-1",
+      proxyConfig: {
+        version: "edge-18.10.0",
+        logLevel: "warn,linkerd2_proxy=info",
+        injectMode: "cni"
+      }
+    },
+    {
+      name: "emojivoto/web-unmeshed"
+    }
+  ];
+
+  it('lists the pods with a proxy, sorted by name', () => {
+    const component = shallow(<ProxyConfigTable pods={pods} />);
+    const table = component.find(Table);
+
+    expect(table).to.have.length(1);
+    expect(table.props().dataSource).to.have.length(2);
+    expect(table.props().dataSource[0].name).to.equal("emojivoto/web-1");
+    expect(table.props().dataSource[1].name).to.equal("emojivoto/web-2");
+  });
+
+  it('formats the skipped ports and resources', () => {
+    const component = shallow(<ProxyConfigTable pods={pods} />);
+    const columns = component.find(Table).props().columns;
+    const render = key => columns.find(c => c.key === key).render;
+
+    expect(render("inboundSkipPorts")("4190,4191")).to.equal("4190, 4191");
+    expect(render("inboundSkipPorts")("")).to.equal("-");
+    expect(render("outboundSkip")({ proxyConfig: { outboundSkipPorts: "443", outboundSkipSubnets: "10.0.0.0/8" }}))
+      .to.equal("443, 10.0.0.0/8");
+    expect(render("cpu")(pods[0])).to.equal("10m / -");
+    expect(render("memory")(pods[0])).to.equal("-");
+  });
+});
@@ -1,7 +1,7 @@
 import _ from 'lodash';
 import Adapter from 'enzyme-adapter-react-16';
 import { expect } from 'chai';
-import nsFixtures from './fixtures/namespaces.json';
+import nsFixtures from './fixtures/namespaceSummaries.json';
 import podFixtures from './fixtures/podRollup.json';
 import { routerWrap } from './testHelpers.jsx';
 import ServiceMesh from '../js/components/ServiceMesh.jsx';
@@ -130,6 +130,32 @@ describe('ServiceMesh', () => {
     });
   });
 
+  it("renders the mesh coverage of each namespace", () => {
+    fetchStub.resolves({
+      ok: true,
+      json: () => Promise.resolve({
+        namespaces: [{
+          namespace: "emojivoto",
+          meshedPodCount: "3",
+          unmeshedPodCount: "1",
+          meshedWorkloadCount: "2",
+          proxyVersions: [
+            { version: "edge-18.10.1", podCount: "1" },
+            { version: "edge-18.10.2", podCount: "2" }
+          ]
+        }]
+      })
+    });
+    component = mount(routerWrap(ServiceMesh));
+
+    return withPromise(() => {
+      component.update();
+      expect(component.html()).to.include("Mesh coverage");
+      expect(component.html()).to.include("edge-18.10.1 (1)");
+      expect(component.html()).to.include("edge-18.10.2 (2)");
+    });
+  });
+
   describe("renderAddDeploymentsMessage", () => {
     it("displays when no resources are in the mesh", () => {
       fetchStub.resolves({
@@ -145,16 +171,11 @@ describe('ServiceMesh', () => {
 
     it("displays a message if >1 resource has not been added to the mesh", () => {
       let nsAllResourcesAdded = _.cloneDeep(nsFixtures);
-      nsAllResourcesAdded.ok.statTables[0].podGroup.rows.push({
-        "resource": {
-          "namespace": "",
-          "type": "namespace",
-          "name": "test-1"
-        },
-        "timeWindow": "1m",
+      nsAllResourcesAdded.namespaces.push({
+        "name": "test-1",
         "meshedPodCount": "0",
-        "runningPodCount": "5",
-        "stats": null
+        "unmeshedPodCount": "5",
+        "failedPodCount": "0"
       });
 
       fetchStub.resolves({
@@ -170,11 +191,11 @@ describe('ServiceMesh', () => {
 
     it("displays a message if 1 resource has not added to servicemesh", () => {
       let nsOneResourceNotAdded = _.cloneDeep(nsFixtures);
-      _.each(nsOneResourceNotAdded.ok.statTables[0].podGroup.rows, row => {
+      _.each(nsOneResourceNotAdded.namespaces, ns => {
         // set all namespaces to have fully meshed pod counts, except one
-        if (row.resource.name !== "default") {
-          row.meshedPodCount = "10";
-          row.runningPodCount = "10";
+        if (ns.name !== "default") {
+          ns.meshedPodCount = "10";
+          ns.unmeshedPodCount = "0";
         }
       });
       fetchStub.resolves({
@@ -190,9 +211,9 @@ describe('ServiceMesh', () => {
 
     it("displays a message if all resources have been added to servicemesh", () => {
       let nsAllResourcesAdded = _.cloneDeep(nsFixtures);
-      _.each(nsAllResourcesAdded.ok.statTables[0].podGroup.rows, row => {
-        row.meshedPodCount = "10";
-        row.runningPodCount = "10";
+      _.each(nsAllResourcesAdded.namespaces, ns => {
+        ns.meshedPodCount = "10";
+        ns.unmeshedPodCount = "0";
       });
       fetchStub.resolves({
         ok: true,
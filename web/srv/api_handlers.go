@@ -2,9 +2,14 @@ package srv
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -14,6 +19,7 @@ import (
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -21,6 +27,39 @@ type (
 	jsonError struct {
 		Error string `json:"error"`
 	}
+
+	serviceProfileRequest struct {
+		Service   string                `json:"service"`
+		Namespace string                `json:"namespace"`
+		Routes    []serviceProfileRoute `json:"routes"`
+	}
+
+	// tapWebsocketRequest is the first message sent by a client on the tap
+	// websocket. A client that was disconnected can set ReconnectToken to the
+	// token of its previous stream to resume it.
+	tapWebsocketRequest struct {
+		util.TapRequestParams
+		ReconnectToken string `json:"reconnectToken"`
+	}
+
+	// tapStreamStatus is sent to the client on the tap websocket when the
+	// stream starts, and whenever the number of events that were dropped
+	// because the client couldn't keep up changes.
+	tapStreamStatus struct {
+		ReconnectToken string `json:"reconnectToken"`
+		Dropped        uint64 `json:"dropped"`
+	}
+
+	// serviceProfileRoute is a route as configured in the dashboard. If no
+	// PathRegex is given, one is generated from the observed Path.
+	serviceProfileRoute struct {
+		Name        string `json:"name"`
+		Method      string `json:"method"`
+		Path        string `json:"path"`
+		PathRegex   string `json:"pathRegex"`
+		IsRetryable bool   `json:"isRetryable"`
+		Timeout     string `json:"timeout"`
+	}
 )
 
 var (
@@ -31,6 +70,15 @@ var (
 		ReadBufferSize:  maxMessageSize,
 		WriteBufferSize: maxMessageSize,
 	}
+
+	// tapBufferSize is the number of tap events buffered for a client before
+	// further events are dropped.
+	tapBufferSize = 100
+	// tapWriteTimeout is how long a client has to accept a message before
+	// its websocket is closed.
+	tapWriteTimeout = 10 * time.Second
+	// tapStatusInterval is how often the client is told about dropped events.
+	tapStatusInterval = time.Second
 )
 
 func renderJsonError(w http.ResponseWriter, err error, status int) {
@@ -82,7 +130,48 @@ func (h *handler) handleApiPods(w http.ResponseWriter, req *http.Request, p http
 	renderJsonPb(w, pods)
 }
 
-func (h *handler) handleApiStat(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+func (h *handler) handleApiMeshCoverage(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	coverage, err := h.apiClient.MeshCoverage(req.Context(), &pb.MeshCoverageRequest{
+		Namespace: req.FormValue("namespace"),
+	})
+
+	if err != nil {
+		renderJsonError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	renderJsonPb(w, coverage)
+}
+
+func (h *handler) handleApiNamespaces(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	namespaces, err := h.apiClient.ListNamespaces(req.Context(), &pb.ListNamespacesRequest{
+		TimeWindow: req.FormValue("window"),
+	})
+
+	if err != nil {
+		renderJsonError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	renderJsonPb(w, namespaces)
+}
+
+func (h *handler) handleApiPodStats(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	result, err := h.apiClient.PodStats(req.Context(), &pb.PodStatsRequest{
+		Namespace:  req.FormValue("namespace"),
+		Name:       req.FormValue("name"),
+		TimeWindow: req.FormValue("window"),
+	})
+
+	if err != nil {
+		renderJsonError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	renderJsonPb(w, result)
+}
+
+func statSummaryRequestFromForm(req *http.Request) (*pb.StatSummaryRequest, error) {
 	allNs := false
 	if req.FormValue("all_namespaces") == "true" {
 		allNs = true
@@ -99,6 +188,7 @@ func (h *handler) handleApiStat(w http.ResponseWriter, req *http.Request, p http
 		FromType:      req.FormValue("from_type"),
 		FromNamespace: req.FormValue("from_namespace"),
 		AllNamespaces: allNs,
+		LabelSelector: req.FormValue("label_selector"),
 	}
 
 	// default to returning deployment stats
@@ -106,7 +196,11 @@ func (h *handler) handleApiStat(w http.ResponseWriter, req *http.Request, p http
 		requestParams.ResourceType = defaultResourceType
 	}
 
-	statRequest, err := util.BuildStatSummaryRequest(requestParams)
+	return util.BuildStatSummaryRequest(requestParams)
+}
+
+func (h *handler) handleApiStat(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	statRequest, err := statSummaryRequestFromForm(req)
 	if err != nil {
 		renderJsonError(w, err, http.StatusInternalServerError)
 		return
@@ -120,6 +214,147 @@ func (h *handler) handleApiStat(w http.ResponseWriter, req *http.Request, p http
 	renderJsonPb(w, result)
 }
 
+// handleApiStatExport serves the same stats as handleApiStat, as a CSV or JSON
+// file download.
+func (h *handler) handleApiStatExport(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	format := req.FormValue("format")
+	if format != "csv" && format != "json" {
+		renderJsonError(w, fmt.Errorf("unsupported export format \"%s\"; must be one of csv, json", format), http.StatusBadRequest)
+		return
+	}
+
+	statRequest, err := statSummaryRequestFromForm(req)
+	if err != nil {
+		renderJsonError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := h.apiClient.StatSummary(req.Context(), statRequest)
+	if err != nil {
+		renderJsonError(w, err, http.StatusInternalServerError)
+		return
+	}
+	if e := result.GetError(); e != nil {
+		renderJsonError(w, errors.New(e.Error), http.StatusInternalServerError)
+		return
+	}
+
+	fileName := fmt.Sprintf("linkerd-%s-%s.%s", statRequest.GetSelector().GetResource().GetType(), statRequest.GetTimeWindow(), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+
+	if format == "json" {
+		renderJsonPb(w, result)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	if err := writeStatsCsv(w, result); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func writeStatsCsv(w io.Writer, rsp *pb.StatSummaryResponse) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"namespace",
+		"type",
+		"name",
+		"meshed_pods",
+		"running_pods",
+		"success_count",
+		"failure_count",
+		"success_rate",
+		"rps",
+		"latency_ms_p50",
+		"latency_ms_p95",
+		"latency_ms_p99",
+		"tls_percent",
+		"time_window",
+	})
+
+	for _, table := range rsp.GetOk().GetStatTables() {
+		for _, row := range table.GetPodGroup().GetRows() {
+			record := []string{
+				row.GetResource().GetNamespace(),
+				row.GetResource().GetType(),
+				row.GetResource().GetName(),
+				strconv.FormatUint(row.GetMeshedPodCount(), 10),
+				strconv.FormatUint(row.GetRunningPodCount(), 10),
+			}
+
+			stats := row.GetStats()
+			if stats == nil {
+				record = append(record, "", "", "", "", "", "", "", "")
+			} else {
+				total := stats.GetSuccessCount() + stats.GetFailureCount()
+				successRate, tlsPercent, rps := "", "", ""
+				if total > 0 {
+					successRate = strconv.FormatFloat(float64(stats.GetSuccessCount())/float64(total), 'f', 4, 64)
+					tlsPercent = strconv.FormatFloat(100*float64(stats.GetTlsRequestCount())/float64(total), 'f', 2, 64)
+				}
+				if window, err := time.ParseDuration(row.GetTimeWindow()); err == nil {
+					rps = strconv.FormatFloat(float64(total)/window.Seconds(), 'f', 4, 64)
+				}
+
+				record = append(record,
+					strconv.FormatUint(stats.GetSuccessCount(), 10),
+					strconv.FormatUint(stats.GetFailureCount(), 10),
+					successRate,
+					rps,
+					strconv.FormatUint(stats.GetLatencyMsP50(), 10),
+					strconv.FormatUint(stats.GetLatencyMsP95(), 10),
+					strconv.FormatUint(stats.GetLatencyMsP99(), 10),
+					tlsPercent,
+				)
+			}
+
+			record = append(record, row.GetTimeWindow())
+			cw.Write(record)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (h *handler) handleApiServiceProfile(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	var profileReq serviceProfileRequest
+	if err := json.NewDecoder(req.Body).Decode(&profileReq); err != nil {
+		renderJsonError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	routes := make([]*profiles.RouteSpec, 0)
+	for _, route := range profileReq.Routes {
+		pathRegex := route.PathRegex
+		if pathRegex == "" && route.Path != "" {
+			pathRegex = profiles.PathToRegex(route.Path)
+		}
+
+		routes = append(routes, &profiles.RouteSpec{
+			Name: route.Name,
+			Condition: &profiles.RequestMatch{
+				Method:    route.Method,
+				PathRegex: pathRegex,
+			},
+			IsRetryable: route.IsRetryable,
+			Timeout:     route.Timeout,
+		})
+	}
+
+	profile, err := profiles.NewServiceProfile(profileReq.Service, profileReq.Namespace, h.controllerNamespace, routes)
+	if err != nil {
+		renderJsonError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/yaml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.yml\"", profile.Metadata.Name))
+	if err := profile.Render(w); err != nil {
+		log.Error(err.Error())
+	}
+}
+
 func websocketError(ws *websocket.Conn, wsError int, msg string) {
 	ws.WriteControl(websocket.CloseMessage,
 		websocket.FormatCloseMessage(wsError, msg),
@@ -145,50 +380,73 @@ func (h *handler) handleApiTap(w http.ResponseWriter, req *http.Request, p httpr
 		return
 	}
 
-	var requestParams util.TapRequestParams
-	err = json.Unmarshal(message, &requestParams)
+	var tapWsReq tapWebsocketRequest
+	err = json.Unmarshal(message, &tapWsReq)
+	if err != nil {
+		websocketError(ws, websocket.CloseInternalServerErr, err.Error())
+		return
+	}
+
+	// a client that was disconnected resumes its previous stream; if that
+	// stream expired, a new one is started from the request it sent
+	session := h.tapSessions.resume(tapWsReq.ReconnectToken)
+	if session == nil {
+		session, err = newTapSession(tapWsReq.TapRequestParams)
+		if err != nil {
+			websocketError(ws, websocket.CloseInternalServerErr, err.Error())
+			return
+		}
+	}
+
+	tapReq, err := util.BuildTapByResourceRequest(session.params)
 	if err != nil {
 		websocketError(ws, websocket.CloseInternalServerErr, err.Error())
 		return
 	}
 
-	tapReq, err := util.BuildTapByResourceRequest(requestParams)
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	tapClient, err := h.apiClient.TapByResource(ctx, tapReq)
 	if err != nil {
 		websocketError(ws, websocket.CloseInternalServerErr, err.Error())
 		return
 	}
 
+	// events are buffered between the tap stream and the websocket, so that a
+	// slow client doesn't hold up the tap stream; when the buffer is full,
+	// events are dropped and the client is told how many
+	events := make(chan *pb.TapEvent, tapBufferSize)
 	go func() {
-		tapClient, err := h.apiClient.TapByResource(req.Context(), tapReq)
-		if err != nil {
-			websocketError(ws, websocket.CloseInternalServerErr, err.Error())
-			return
-		}
+		defer close(events)
 		defer tapClient.CloseSend()
 
 		for {
-			rsp, err := tapClient.Recv()
+			event, err := tapClient.Recv()
 			if err == io.EOF {
-				break
+				return
 			}
 			if err != nil {
-				websocketError(ws, websocket.CloseInternalServerErr, err.Error())
-				break
+				if ctx.Err() == nil {
+					websocketError(ws, websocket.CloseInternalServerErr, err.Error())
+				}
+				return
 			}
 
-			buf := new(bytes.Buffer)
-			err = pbMarshaler.Marshal(buf, rsp)
-			if err != nil {
-				websocketError(ws, websocket.CloseInternalServerErr, err.Error())
-				break
+			select {
+			case events <- event:
+			default:
+				session.drop()
 			}
+		}
+	}()
 
-			if err := ws.WriteMessage(websocket.TextMessage, []byte(buf.String())); err != nil {
-				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure) {
-					log.Error(err)
-				}
-				break
-			}
+	go func() {
+		defer cancel()
+		if err := writeTapEvents(ws, session, events, tapStatusInterval); err != nil {
+			log.Errorf("Failed to write to the tap websocket: %s", err)
+			// unblocks the read loop below
+			ws.Close()
 		}
 	}()
 
@@ -196,10 +454,67 @@ func (h *handler) handleApiTap(w http.ResponseWriter, req *http.Request, p httpr
 		_, _, err := ws.ReadMessage()
 		if err != nil {
 			log.Debugf("Received close frame: %v", err)
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure) {
-				log.Errorf("Unexpected close error: %s", err)
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure) {
+					log.Errorf("Unexpected close error: %s", err)
+				}
+				// the client didn't close the stream, so it may reconnect
+				h.tapSessions.suspend(session)
 			}
 			return
 		}
 	}
 }
+
+// writeTapEvents writes the events to the websocket until there are no more
+// events, or a write fails. The stream's status is sent first, and again
+// every interval if more events were dropped in the meantime.
+func writeTapEvents(ws *websocket.Conn, session *tapSession, events <-chan *pb.TapEvent, interval time.Duration) error {
+	reportedDropped := session.droppedCount()
+	if err := writeTapStatus(ws, session.token, reportedDropped); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			buf := new(bytes.Buffer)
+			if err := pbMarshaler.Marshal(buf, event); err != nil {
+				websocketError(ws, websocket.CloseInternalServerErr, err.Error())
+				return err
+			}
+
+			ws.SetWriteDeadline(time.Now().Add(tapWriteTimeout))
+			if err := ws.WriteMessage(websocket.TextMessage, buf.Bytes()); err != nil {
+				return err
+			}
+
+		case <-ticker.C:
+			dropped := session.droppedCount()
+			if dropped == reportedDropped {
+				continue
+			}
+			if err := writeTapStatus(ws, session.token, dropped); err != nil {
+				return err
+			}
+			reportedDropped = dropped
+		}
+	}
+}
+
+func writeTapStatus(ws *websocket.Conn, token string, dropped uint64) error {
+	status, err := json.Marshal(tapStreamStatus{ReconnectToken: token, Dropped: dropped})
+	if err != nil {
+		return err
+	}
+
+	ws.SetWriteDeadline(time.Now().Add(tapWriteTimeout))
+	return ws.WriteMessage(websocket.TextMessage, status)
+}
@@ -1,12 +1,15 @@
 package srv
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/julienschmidt/httprouter"
 	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
@@ -53,3 +56,160 @@ func TestHandleApiVersion(t *testing.T) {
 		t.Errorf("Expected to find: %+v", expectedVersionJson)
 	}
 }
+
+func TestHandleApiServiceProfile(t *testing.T) {
+	handler := &handler{
+		controllerNamespace: "linkerd",
+	}
+
+	t.Run("Renders a ServiceProfile from observed routes", func(t *testing.T) {
+		body := `{"service":"books","namespace":"booksapp","routes":[{"name":"GET /books/{id}","method":"GET","path":"/books/123","isRetryable":true,"timeout":"300ms"}]}`
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/service-profile", strings.NewReader(body))
+		handler.handleApiServiceProfile(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected StatusCode %d but got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+		}
+
+		expectedProfile := `apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: books.booksapp.svc.cluster.local
+  namespace: linkerd
+spec:
+  routes:
+  - condition:
+      method: GET
+      pathRegex: /books/[^/]*
+    isRetryable: true
+    name: GET /books/{id}
+    timeout: 300ms
+`
+		if recorder.Body.String() != expectedProfile {
+			t.Fatalf("Expected:\n%s\nbut got:\n%s", expectedProfile, recorder.Body.String())
+		}
+	})
+
+	t.Run("Rejects invalid routes", func(t *testing.T) {
+		body := `{"service":"books","namespace":"booksapp","routes":[{"name":"","path":"/books"}]}`
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/service-profile", strings.NewReader(body))
+		handler.handleApiServiceProfile(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected StatusCode %d but got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+}
+
+func TestHandleApiStatExport(t *testing.T) {
+	response := public.GenStatSummaryResponse("emoji", "deployment", "emojivoto", &public.PodCounts{
+		MeshedPods:  1,
+		RunningPods: 2,
+	})
+	handler := &handler{
+		apiClient: &public.MockApiClient{StatSummaryResponseToReturn: &response},
+	}
+
+	t.Run("Exports stats as CSV", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/tps-reports/export?resource_type=deployment&namespace=emojivoto&format=csv", nil)
+		handler.handleApiStatExport(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected StatusCode %d but got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+		}
+
+		expectedDisposition := "attachment; filename=\"linkerd-deployment-1m.csv\""
+		if recorder.Header().Get("Content-Disposition") != expectedDisposition {
+			t.Fatalf("Expected Content-Disposition [%s] but got [%s]", expectedDisposition, recorder.Header().Get("Content-Disposition"))
+		}
+
+		expectedCsv := `namespace,type,name,meshed_pods,running_pods,success_count,failure_count,success_rate,rps,latency_ms_p50,latency_ms_p95,latency_ms_p99,tls_percent,time_window
+emojivoto,deployment,emoji,1,2,123,0,1.0000,2.0500,123,123,123,100.00,1m
+`
+		if recorder.Body.String() != expectedCsv {
+			t.Fatalf("Expected:\n%s\nbut got:\n%s", expectedCsv, recorder.Body.String())
+		}
+	})
+
+	t.Run("Exports stats as JSON", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/tps-reports/export?resource_type=deployment&namespace=emojivoto&format=json", nil)
+		handler.handleApiStatExport(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected StatusCode %d but got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+		}
+
+		if !strings.Contains(recorder.Body.String(), `"name":"emoji"`) {
+			t.Fatalf("Expected exported JSON to contain the emoji deployment, got: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("Rejects unknown formats", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/tps-reports/export?resource_type=deployment&format=xls", nil)
+		handler.handleApiStatExport(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected StatusCode %d but got %d", http.StatusBadRequest, recorder.Code)
+		}
+	})
+}
+
+func TestWriteTapEvents(t *testing.T) {
+	session := &tapSession{token: "abc"}
+	session.drop()
+
+	events := make(chan *pb.TapEvent, 2)
+	events <- &pb.TapEvent{ProxyDirection: pb.TapEvent_INBOUND}
+	events <- &pb.TapEvent{ProxyDirection: pb.TapEvent_OUTBOUND}
+	close(events)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ws, err := websocketUpgrader.Upgrade(w, req, nil)
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			return
+		}
+		defer ws.Close()
+
+		if err := writeTapEvents(ws, session, events, time.Minute); err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer ws.Close()
+
+	_, message, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var status tapStreamStatus
+	if err := json.Unmarshal(message, &status); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedStatus := tapStreamStatus{ReconnectToken: "abc", Dropped: 1}
+	if status != expectedStatus {
+		t.Fatalf("Expected status %+v, got %+v", expectedStatus, status)
+	}
+
+	for _, direction := range []string{"INBOUND", "OUTBOUND"} {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !strings.Contains(string(message), `"proxyDirection":"`+direction+`"`) {
+			t.Fatalf("Expected an %s tap event, got %s", direction, message)
+		}
+	}
+}
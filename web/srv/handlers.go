@@ -5,12 +5,21 @@ import (
 	"regexp"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	log "github.com/sirupsen/logrus"
 )
 
 var proxyPathRegexp = regexp.MustCompile("/api/v1/namespaces/.*/proxy/")
 
+// readOnlyGrpcWebMethods are the public API methods that can't be called
+// through gRPC-web when the dashboard runs in read-only mode, because they
+// observe live traffic.
+var readOnlyGrpcWebMethods = map[string]bool{
+	"Tap":           true,
+	"TapByResource": true,
+}
+
 type (
 	renderTemplate func(http.ResponseWriter, string, string, interface{}) error
 	serveFile      func(http.ResponseWriter, string, string, interface{}) error
@@ -21,6 +30,10 @@ type (
 		apiClient           pb.ApiClient
 		uuid                string
 		controllerNamespace string
+		disableTelemetry    bool
+		readOnly            bool
+		grpcWebProxy        http.Handler
+		tapSessions         *tapSessions
 	}
 )
 
@@ -34,6 +47,8 @@ func (h *handler) handleIndex(w http.ResponseWriter, req *http.Request, p httpro
 	params := appParams{
 		UUID:                h.uuid,
 		ControllerNamespace: h.controllerNamespace,
+		DisableTelemetry:    h.disableTelemetry,
+		ReadOnly:            h.readOnly,
 		PathPrefix:          pathPfx,
 	}
 
@@ -52,3 +67,30 @@ func (h *handler) handleIndex(w http.ResponseWriter, req *http.Request, p httpro
 		log.Error(err.Error())
 	}
 }
+
+func (h *handler) handleGrpcWeb(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	if !public.IsGrpcWebRequest(req) {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if h.readOnly && readOnlyGrpcWebMethods[p.ByName("method")] {
+		http.Error(w, "not available in read-only mode", http.StatusForbidden)
+		return
+	}
+
+	h.grpcWebProxy.ServeHTTP(w, req)
+}
+
+// withReadOnlyCheck wraps the handlers of the actions that aren't available
+// when the dashboard runs in read-only mode.
+func (h *handler) withReadOnlyCheck(handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		if h.readOnly {
+			http.Error(w, "not available in read-only mode", http.StatusForbidden)
+			return
+		}
+
+		handle(w, req, p)
+	}
+}
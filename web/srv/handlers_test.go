@@ -53,6 +53,8 @@ func TestHandleIndex(t *testing.T) {
 		"data-go-version=\"the best one\"",
 		"data-controller-namespace=\"\"",
 		"data-uuid=\"\"",
+		"data-disable-telemetry=\"false\"",
+		"data-read-only=\"false\"",
 	}
 	for _, expectedSubstring := range expectedSubstrings {
 		if !strings.Contains(actualBody, expectedSubstring) {
@@ -60,3 +62,56 @@ func TestHandleIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestReadOnlyMode(t *testing.T) {
+	proxied := false
+	handler := &handler{
+		readOnly: true,
+		grpcWebProxy: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			proxied = true
+		}),
+	}
+
+	t.Run("Rejects actions that aren't available in read-only mode", func(t *testing.T) {
+		called := false
+		handle := handler.withReadOnlyCheck(func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+			called = true
+		})
+
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/service-profile", nil)
+		handle(recorder, req, httprouter.Params{})
+
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("Expected StatusCode %d but got %d", http.StatusForbidden, recorder.Code)
+		}
+		if called {
+			t.Fatal("Expected handler not to be called in read-only mode")
+		}
+	})
+
+	t.Run("Rejects gRPC-web tap requests", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", public.GrpcWebPathPrefix+"TapByResource", nil)
+		req.Header.Set("Content-Type", "application/grpc-web+proto")
+		handler.handleGrpcWeb(recorder, req, httprouter.Params{httprouter.Param{Key: "method", Value: "TapByResource"}})
+
+		if recorder.Code != http.StatusForbidden {
+			t.Fatalf("Expected StatusCode %d but got %d", http.StatusForbidden, recorder.Code)
+		}
+		if proxied {
+			t.Fatal("Expected tap request not to be proxied in read-only mode")
+		}
+	})
+
+	t.Run("Proxies other gRPC-web requests", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", public.GrpcWebPathPrefix+"StatSummary", nil)
+		req.Header.Set("Content-Type", "application/grpc-web+proto")
+		handler.handleGrpcWeb(recorder, req, httprouter.Params{httprouter.Param{Key: "method", Value: "StatSummary"}})
+
+		if !proxied {
+			t.Fatal("Expected request to be proxied")
+		}
+	})
+}
@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
 	"path"
 	"path/filepath"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/filesonly"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/linkerd/linkerd2/pkg/requestid"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -40,18 +44,27 @@ type (
 		Data                pb.VersionInfo
 		UUID                string
 		ControllerNamespace string
+		DisableTelemetry    bool
+		ReadOnly            bool
 		Error               bool
 		ErrorMessage        string
 		PathPrefix          string
 	}
 )
 
-// this is called by the HTTP server to actually respond to a request
+// this is called by the HTTP server to actually respond to a request. Each
+// request is given an ID, which is passed on to the public API along with the
+// requests made on its behalf, to correlate their log entries.
 func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	id := requestid.FromHTTPRequest(req)
+	w.Header().Set(requestid.Header, id)
+	req = req.WithContext(requestid.NewContext(req.Context(), id))
+
+	requestid.Logger(req.Context()).Debugf("Serving %s %s", req.Method, req.URL.Path)
 	s.router.ServeHTTP(w, req)
 }
 
-func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackDevServer string, reload bool, apiClient pb.ApiClient) *http.Server {
+func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackDevServer, apiAddr string, reload, disableTelemetry, readOnly bool, apiClient pb.ApiClient) *http.Server {
 	server := &Server{
 		templateDir:     templateDir,
 		staticDir:       staticDir,
@@ -72,6 +85,10 @@ func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackD
 		serveFile:           server.serveFile,
 		uuid:                uuid,
 		controllerNamespace: controllerNamespace,
+		disableTelemetry:    disableTelemetry,
+		readOnly:            readOnly,
+		grpcWebProxy:        newGrpcWebProxy(apiAddr),
+		tapSessions:         newTapSessions(),
 	}
 
 	httpServer := &http.Server{
@@ -96,6 +113,7 @@ func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackD
 	server.router.GET("/namespaces/:namespace/replicationcontrollers/:replicationcontroller", handler.handleIndex)
 	server.router.GET("/tap", handler.handleIndex)
 	server.router.GET("/top", handler.handleIndex)
+	server.router.GET("/profiles", handler.handleIndex)
 	server.router.ServeFiles(
 		"/dist/*filepath", // add catch-all parameter to match all files in dir
 		filesonly.FileSystem(server.staticDir))
@@ -106,12 +124,28 @@ func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackD
 	// but was renamed to avoid triggering ad blockers.
 	// See: https://github.com/linkerd/linkerd2/issues/970
 	server.router.GET("/api/tps-reports", handler.handleApiStat)
+	server.router.GET("/api/tps-reports/export", handler.handleApiStatExport)
 	server.router.GET("/api/pods", handler.handleApiPods)
-	server.router.GET("/api/tap", handler.handleApiTap)
+	server.router.GET("/api/pod-stats", handler.handleApiPodStats)
+	server.router.GET("/api/mesh-coverage", handler.handleApiMeshCoverage)
+	server.router.GET("/api/namespaces", handler.handleApiNamespaces)
+	server.router.GET("/api/tap", handler.withReadOnlyCheck(handler.handleApiTap))
+	server.router.POST("/api/service-profile", handler.withReadOnlyCheck(handler.handleApiServiceProfile))
+
+	// gRPC-web calls are proxied through to the public API as-is
+	server.router.POST(public.GrpcWebPathPrefix+":method", handler.handleGrpcWeb)
 
 	return httpServer
 }
 
+func newGrpcWebProxy(apiAddr string) http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: apiAddr})
+	// flush periodically so that streaming responses (e.g. tap) reach the
+	// browser within 100ms instead of being buffered until they complete
+	proxy.FlushInterval = 100 * time.Millisecond
+	return proxy
+}
+
 func (s *Server) RenderTemplate(w http.ResponseWriter, templateFile, templateName string, args interface{}) error {
 	log.Debugf("emitting template %s", templateFile)
 	template, err := s.loadTemplate(templateFile)
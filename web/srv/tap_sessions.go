@@ -0,0 +1,120 @@
+package srv
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+)
+
+const (
+	// tapSessionTTL is how long a disconnected tap session can be resumed.
+	tapSessionTTL = time.Minute
+
+	// maxTapSessions is the maximum number of disconnected tap sessions that
+	// are kept around. When it's reached, the oldest session is discarded.
+	maxTapSessions = 100
+)
+
+type (
+	// tapSession is a tap websocket stream that a client can reconnect to
+	// after its connection dropped, with the same tap request and without
+	// losing count of the events it was sent.
+	tapSession struct {
+		token   string
+		params  util.TapRequestParams
+		dropped uint64 // accessed atomically
+	}
+
+	suspendedTapSession struct {
+		session *tapSession
+		expires time.Time
+	}
+
+	// tapSessions holds the tap sessions that were disconnected, keyed by
+	// their reconnect token, until they're resumed or expire.
+	tapSessions struct {
+		sync.Mutex
+		suspended map[string]suspendedTapSession
+		now       func() time.Time
+	}
+)
+
+func newTapSession(params util.TapRequestParams) (*tapSession, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	return &tapSession{
+		token:  hex.EncodeToString(token),
+		params: params,
+	}, nil
+}
+
+// drop records that an event couldn't be sent to the client.
+func (s *tapSession) drop() {
+	atomic.AddUint64(&s.dropped, 1)
+}
+
+// droppedCount returns the number of events dropped over the session's
+// lifetime.
+func (s *tapSession) droppedCount() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+func newTapSessions() *tapSessions {
+	return &tapSessions{
+		suspended: make(map[string]suspendedTapSession),
+		now:       time.Now,
+	}
+}
+
+// suspend keeps a disconnected session around so that it can be resumed.
+func (t *tapSessions) suspend(session *tapSession) {
+	t.Lock()
+	defer t.Unlock()
+
+	now := t.now()
+	var oldestToken string
+	var oldestExpiry time.Time
+	for token, suspended := range t.suspended {
+		if now.After(suspended.expires) {
+			delete(t.suspended, token)
+			continue
+		}
+		if oldestToken == "" || suspended.expires.Before(oldestExpiry) {
+			oldestToken = token
+			oldestExpiry = suspended.expires
+		}
+	}
+	if len(t.suspended) >= maxTapSessions {
+		delete(t.suspended, oldestToken)
+	}
+
+	t.suspended[session.token] = suspendedTapSession{
+		session: session,
+		expires: now.Add(tapSessionTTL),
+	}
+}
+
+// resume returns the suspended session with the given reconnect token, or nil
+// if there's no such session or it has expired.
+func (t *tapSessions) resume(token string) *tapSession {
+	t.Lock()
+	defer t.Unlock()
+
+	suspended, ok := t.suspended[token]
+	if !ok {
+		return nil
+	}
+	delete(t.suspended, token)
+
+	if t.now().After(suspended.expires) {
+		return nil
+	}
+	return suspended.session
+}
@@ -0,0 +1,81 @@
+package srv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+)
+
+func TestTapSessions(t *testing.T) {
+	now := time.Now()
+	sessions := newTapSessions()
+	sessions.now = func() time.Time { return now }
+
+	newSession := func() *tapSession {
+		session, err := newTapSession(util.TapRequestParams{Resource: "deploy/web"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return session
+	}
+
+	t.Run("Resumes a suspended session once", func(t *testing.T) {
+		session := newSession()
+		session.drop()
+		sessions.suspend(session)
+
+		resumed := sessions.resume(session.token)
+		if resumed != session {
+			t.Fatalf("Expected to resume session %+v, got %+v", session, resumed)
+		}
+		if resumed.droppedCount() != 1 {
+			t.Fatalf("Expected the resumed session to have dropped 1 event, got %d", resumed.droppedCount())
+		}
+
+		if sessions.resume(session.token) != nil {
+			t.Fatal("Expected a session to be resumed only once")
+		}
+	})
+
+	t.Run("Doesn't resume unknown sessions", func(t *testing.T) {
+		if sessions.resume("") != nil {
+			t.Fatal("Expected no session for an empty token")
+		}
+		if sessions.resume("foo") != nil {
+			t.Fatal("Expected no session for an unknown token")
+		}
+	})
+
+	t.Run("Doesn't resume expired sessions", func(t *testing.T) {
+		session := newSession()
+		sessions.suspend(session)
+
+		now = now.Add(tapSessionTTL + time.Second)
+		if sessions.resume(session.token) != nil {
+			t.Fatal("Expected an expired session not to be resumed")
+		}
+	})
+
+	t.Run("Discards the oldest session when there are too many", func(t *testing.T) {
+		tokens := []string{}
+		for i := 0; i < maxTapSessions+1; i++ {
+			session := newSession()
+			sessions.suspend(session)
+			tokens = append(tokens, session.token)
+			now = now.Add(time.Millisecond)
+		}
+
+		if len(sessions.suspended) != maxTapSessions {
+			t.Fatalf("Expected %d suspended sessions, got %d", maxTapSessions, len(sessions.suspended))
+		}
+		if sessions.resume(tokens[0]) != nil {
+			t.Fatal("Expected the oldest session to be discarded")
+		}
+		for i, token := range tokens[1:] {
+			if sessions.resume(token) == nil {
+				t.Fatalf("Expected session %d to be resumed", i+1)
+			}
+		}
+	})
+}